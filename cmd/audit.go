@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// clusterAuditTimeout bounds the copy of a run's mutations to the audit ConfigMap.
+const clusterAuditTimeout = 10 * time.Second
+
+var (
+	auditLimit   int
+	auditRun     string
+	auditCluster bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the changes the CLI made to the cluster and the Kubeasy API",
+	Long: `Lists every create, update, patch and delete request the CLI sent to the kubeasy
+cluster or the Kubeasy API, with the command that sent it and its outcome. They are
+read from the run logs in ~/.kubeasy/runs, which keep the last 50 runs.
+
+With KUBEASY_AUDIT_CLUSTER=on, the cluster changes of each run are also copied to
+the kube-system/kubeasy-audit ConfigMap; --cluster reads them from there.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	// Viewing the audit must not evict the oldest run log
+	Annotations: map[string]string{noRunLogAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var mutations []runlog.Mutation
+		var err error
+		if auditCluster {
+			clientset, cerr := commandContextFrom(cmd.Context()).Clientset()
+			if cerr != nil {
+				ui.Error("Failed to connect to the cluster")
+				return cerr
+			}
+			mutations, err = kube.ReadClusterAudit(cmd.Context(), clientset)
+		} else {
+			mutations, err = runlog.ReadMutations()
+		}
+		if err != nil {
+			ui.Error("Failed to read the audit log")
+			return err
+		}
+
+		mutations = filterMutations(mutations, auditRun, auditLimit)
+		if len(mutations) == 0 {
+			ui.Info("No changes recorded")
+			return nil
+		}
+		rows := make([][]string, len(mutations))
+		for i, m := range mutations {
+			outcome := m.Status
+			if m.Error != "" {
+				outcome = fmt.Sprintf("%s: %s", m.Status, m.Error)
+			}
+			rows[i] = []string{m.Time.Local().Format("2006-01-02 15:04:05"), m.Command, m.Scope, m.Verb, m.Target, outcome}
+		}
+		return ui.Table([]string{"Time", "Command", "Scope", "Verb", "Target", "Outcome"}, rows)
+	},
+}
+
+// filterMutations keeps the mutations of run runID (all runs when empty), at most the
+// last limit of them (all when limit is not positive).
+func filterMutations(mutations []runlog.Mutation, runID string, limit int) []runlog.Mutation {
+	if runID != "" {
+		var kept []runlog.Mutation
+		for _, m := range mutations {
+			if m.RunID == runID {
+				kept = append(kept, m)
+			}
+		}
+		mutations = kept
+	}
+	if limit > 0 && len(mutations) > limit {
+		mutations = mutations[len(mutations)-limit:]
+	}
+	return mutations
+}
+
+// copyClusterAudit copies the cluster mutations of run to the audit ConfigMap when
+// KUBEASY_AUDIT_CLUSTER asks for it. It is best effort: the command already finished.
+func copyClusterAudit(run *runlog.Run) {
+	if run == nil || !runlog.ClusterAuditEnabled() {
+		return
+	}
+	var mutations []runlog.Mutation
+	for _, m := range run.Mutations() {
+		if m.Scope == runlog.ScopeCluster {
+			mutations = append(mutations, m)
+		}
+	}
+	if len(mutations) == 0 {
+		return
+	}
+
+	clientset, err := kube.GetKubernetesClient()
+	if err != nil {
+		logger.Debug("Could not copy the audit log to the cluster: %v", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clusterAuditTimeout)
+	defer cancel()
+	if err := kube.AppendClusterAudit(ctx, clientset, run.ID, mutations); err != nil {
+		logger.Warning("Could not copy the audit log to the cluster: %v", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().IntVar(&auditLimit, "limit", 50, "Show at most this many changes, the most recent ones (0 for all)")
+	auditCmd.Flags().StringVar(&auditRun, "run", "", "Only show the changes of this run ID")
+	auditCmd.Flags().BoolVar(&auditCluster, "cluster", false, "Read the changes copied to the kube-system/kubeasy-audit ConfigMap instead of the local run logs")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMutations(t *testing.T) {
+	mutations := []runlog.Mutation{
+		{RunID: "run-1", Event: runlog.Event{Verb: "create"}},
+		{RunID: "run-2", Event: runlog.Event{Verb: "patch"}},
+		{RunID: "run-2", Event: runlog.Event{Verb: "delete"}},
+	}
+
+	assert.Len(t, filterMutations(mutations, "", 0), 3)
+	assert.Equal(t, []runlog.Mutation{mutations[2]}, filterMutations(mutations, "", 1), "the most recent ones are kept")
+	assert.Equal(t, mutations[1:], filterMutations(mutations, "run-2", 10))
+	assert.Empty(t, filterMutations(mutations, "run-3", 10))
+}
+
+// TestAuditRunE_ReadsRunLogs verifies that the changes recorded by previous runs are listed.
+func TestAuditRunE_ReadsRunLogs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	require.NoError(t, auditCmd.RunE(auditCmd, nil), "no run logs yet")
+
+	run, err := runlog.Start("kubeasy challenge start", []string{"pod-evicted"})
+	require.NoError(t, err)
+	run.Record(runlog.Event{Type: runlog.EventMutation, Scope: runlog.ScopeCluster, Verb: "create", Target: "/api/v1/namespaces", Status: runlog.OutcomeOK})
+	run.Finish(nil, false)
+
+	require.NoError(t, auditCmd.RunE(auditCmd, nil))
+}
+
+// TestAuditRunE_Cluster verifies that --cluster reads the audit ConfigMap.
+func TestAuditRunE_Cluster(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	orig := auditCluster
+	t.Cleanup(func() { auditCluster = orig })
+	auditCluster = true
+
+	cc := testCommandContext()
+	useCommandContext(t, auditCmd, cc)
+	clientset, err := cc.Clientset()
+	require.NoError(t, err)
+	require.NoError(t, kube.AppendClusterAudit(context.Background(), clientset, "run-1", []runlog.Mutation{
+		{RunID: "run-1", Event: runlog.Event{Type: runlog.EventMutation, Scope: runlog.ScopeCluster, Verb: "delete", Status: runlog.OutcomeOK}},
+	}))
+
+	require.NoError(t, auditCmd.RunE(auditCmd, nil))
+}
@@ -0,0 +1,484 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// objectiveExamples holds one commented example per validation type, appended to
+// challengeYAMLTemplate. Each block is valid YAML once
+// the leading "  # " is removed; TestObjectiveExamples keeps them in sync with the schema.
+const objectiveExamples = `  # ---------------------------------------------------------------------------
+  # Examples — one per validation type. Uncomment, adapt, and renumber "order".
+  # Run 'kubeasy challenge schema' to list every field accepted by each type.
+  # ---------------------------------------------------------------------------
+  #
+  # status: compare fields of the resource status
+  # - key: replicas-ready
+  #   title: "Replicas Ready"
+  #   description: "All replicas of the deployment are ready"
+  #   order: 1
+  #   type: status
+  #   spec:
+  #     target:
+  #       kind: Deployment
+  #       name: {{.Slug}}
+  #     checks:
+  #       - field: readyReplicas
+  #         operator: ">="
+  #         value: 1
+  #
+  # condition: check Kubernetes conditions (Ready, Available, ...)
+  # - key: pod-ready
+  #   title: "Application Running"
+  #   description: "The application pod must be in Ready state"
+  #   order: 2
+  #   type: condition
+  #   spec:
+  #     target:
+  #       kind: Pod
+  #       labelSelector:
+  #         app: {{.Slug}}
+  #     checks:
+  #       - type: Ready
+  #         status: "True"
+  #
+  # log: search container logs for expected strings
+  # - key: app-started
+  #   title: "Application Started"
+  #   description: "The application logs its startup message"
+  #   order: 3
+  #   type: log
+  #   spec:
+  #     target:
+  #       kind: Pod
+  #       labelSelector:
+  #         app: {{.Slug}}
+  #     expectedStrings:
+  #       - "Server listening"
+  #     sinceSeconds: 300
+  #
+  # event: forbid (or require) Kubernetes event reasons
+  # - key: no-crashes
+  #   title: "Stable Operation"
+  #   description: "No crash or eviction events"
+  #   order: 4
+  #   type: event
+  #   spec:
+  #     target:
+  #       kind: Pod
+  #       labelSelector:
+  #         app: {{.Slug}}
+  #     forbiddenReasons:
+  #       - "OOMKilled"
+  #       - "BackOff"
+  #     sinceSeconds: 300
+  #
+  # connectivity: send HTTP requests from a pod in the cluster
+  # - key: service-reachable
+  #   title: "Service Reachable"
+  #   description: "The service answers HTTP requests"
+  #   order: 5
+  #   type: connectivity
+  #   spec:
+  #     sourcePod:
+  #       labelSelector:
+  #         app: {{.Slug}}
+  #     targets:
+  #       - url: "http://{{.Slug}}"
+  #         expectedStatusCode: 200
+  #         timeoutSeconds: 5
+  #
+  # rbac: check what a ServiceAccount is allowed to do
+  # - key: sa-can-read-pods
+  #   title: "Read Access"
+  #   description: "The service account can list pods"
+  #   order: 6
+  #   type: rbac
+  #   spec:
+  #     serviceAccount: {{.Slug}}
+  #     namespace: {{.Slug}}
+  #     checks:
+  #       - verb: list
+  #         resource: pods
+  #         allowed: true
+  #
+  # spec: check fields of the resource manifest
+  # - key: memory-limit-set
+  #   title: "Memory Limit"
+  #   description: "The container declares a memory limit"
+  #   order: 7
+  #   type: spec
+  #   spec:
+  #     target:
+  #       kind: Deployment
+  #       name: {{.Slug}}
+  #     checks:
+  #       - path: spec.template.spec.containers[0].resources.limits.memory
+  #         exists: true
+  #
+  # triggered: run an action, wait, then run nested validations
+  # - key: survives-load
+  #   title: "Survives Load"
+  #   description: "The application stays ready under load"
+  #   order: 8
+  #   type: triggered
+  #   spec:
+  #     trigger:
+  #       type: load
+  #       url: "http://{{.Slug}}"
+  #       requestsPerSecond: 20
+  #       durationSeconds: 30
+  #     waitAfterSeconds: 10
+  #     then:
+  #       - key: still-ready
+  #         title: "Still Ready"
+  #         type: condition
+  #         spec:
+  #           target:
+  #             kind: Pod
+  #             labelSelector:
+  #               app: {{.Slug}}
+  #           checks:
+  #             - type: Ready
+  #               status: "True"
+  #
+  # plugin: delegate to an external validator (kubeasy-validator-<name> on PATH)
+  # - key: custom-check
+  #   title: "Custom Check"
+  #   description: "Checked by the kubeasy-validator-example plugin"
+  #   order: 9
+  #   type: plugin
+  #   spec:
+  #     name: example
+  #     timeoutSeconds: 30
+  #     config:
+  #       target: {{.Slug}}
+  #
+  # promMetrics: scrape a Prometheus metrics endpoint and compare values
+  # - key: serves-traffic
+  #   title: "Serves Traffic"
+  #   description: "The application has answered requests without errors"
+  #   order: 10
+  #   type: promMetrics
+  #   spec:
+  #     target:
+  #       kind: Pod
+  #       labelSelector:
+  #         app: {{.Slug}}
+  #     port: 9090
+  #     checks:
+  #       - metric: http_requests_total
+  #         operator: ">"
+  #         value: 0
+  #       - metric: http_requests_total
+  #         labels:
+  #           code: "200"
+  #         operator: ">="
+  #         value: 10
+  #
+  # endpoints: count the ready endpoints of a Service
+  # - key: service-has-endpoints
+  #   title: "Service Has Endpoints"
+  #   description: "The Service routes traffic to at least one ready pod"
+  #   order: 11
+  #   type: endpoints
+  #   spec:
+  #     service: {{.Slug}}
+  #     operator: ">="
+  #     readyEndpoints: 1
+  #
+  # networkPolicy: evaluate the NetworkPolicies for a connection, without sending traffic
+  # - key: database-isolated
+  #   title: "Database Isolated"
+  #   description: "Only the application may reach the database"
+  #   order: 12
+  #   type: networkPolicy
+  #   spec:
+  #     from:
+  #       labels:
+  #         app: debug
+  #     to:
+  #       labels:
+  #         app: {{.Slug}}-db
+  #     port: 5432
+  #     expect: denied
+  #
+  # node: check node labels/taints/allocatable and where pods were scheduled
+  # - key: runs-on-gpu-node
+  #   title: "Runs on the GPU Node"
+  #   description: "The workload tolerates the GPU taint and lands on the GPU node"
+  #   order: 13
+  #   type: node
+  #   spec:
+  #     nodeSelector:
+  #       accelerator: gpu
+  #     taints:
+  #       - key: dedicated
+  #         value: gpu
+  #         effect: NoSchedule
+  #     target:
+  #       kind: Deployment
+  #       name: {{.Slug}}
+  #     scheduledOn:
+  #       accelerator: gpu
+  #
+  # podDisruptionBudget: check the budget protecting a workload during node drains
+  # - key: survives-drain
+  #   title: "Survives a Drain"
+  #   description: "A PodDisruptionBudget keeps the application available"
+  #   order: 14
+  #   type: podDisruptionBudget
+  #   spec:
+  #     target:
+  #       kind: Deployment
+  #       name: {{.Slug}}
+  #     minAvailable: "50%"
+  #     operator: ">="
+  #     disruptionsAllowed: 1
+  #
+  # probes: check liveness/readiness/startup probes and call their endpoints
+  # - key: health-checks
+  #   title: "Health Checks"
+  #   description: "The application is probed on its health endpoint"
+  #   order: 15
+  #   type: probes
+  #   spec:
+  #     target:
+  #       kind: Deployment
+  #       name: {{.Slug}}
+  #     probes:
+  #       - type: readiness
+  #         path: /ready
+  #         exercise: true
+  #       - type: liveness
+  #         path: /healthz
+  #
+  # images: enforce image supply-chain rules on every container
+  # - key: trusted-images
+  #   title: "Trusted Images"
+  #   description: "Images are versioned and come from a trusted registry"
+  #   order: 16
+  #   type: images
+  #   spec:
+  #     target:
+  #       kind: Deployment
+  #       name: {{.Slug}}
+  #     forbidLatest: true
+  #     allowedRegistries:
+  #       - docker.io/library
+  #       - ghcr.io/kubeasy-dev
+  #
+  # resources: require resource requests/limits on every container, within bounds
+  # - key: right-sized
+  #   title: "Right-Sized"
+  #   description: "Every container declares sensible requests and a memory limit"
+  #   order: 17
+  #   type: resources
+  #   spec:
+  #     target:
+  #       kind: Deployment
+  #       name: {{.Slug}}
+  #     requests:
+  #       cpu:
+  #         min: 50m
+  #       memory: {}
+  #     limits:
+  #       memory:
+  #         max: 512Mi
+  #
+  # - key: scraped
+  #   title: "Scraped by Prometheus"
+  #   description: "The service is annotated for Prometheus scraping"
+  #   order: 18
+  #   type: metadata
+  #   spec:
+  #     target:
+  #       kind: Service
+  #       name: {{.Slug}}
+  #     annotations:
+  #       - key: prometheus.io/scrape
+  #         value: "true"
+  #       - key: prometheus.io/port
+  #         matches: "[0-9]+"
+`
+
+// challenge init flags
+var (
+	challengeInitDir           string
+	challengeInitTitle         string
+	challengeInitType          string
+	challengeInitTheme         string
+	challengeInitDifficulty    string
+	challengeInitEstimatedTime int
+)
+
+// challengeInitOptions holds the metadata written into a new challenge skeleton.
+type challengeInitOptions struct {
+	Slug               string
+	Title              string
+	Type               string
+	Theme              string
+	Difficulty         string
+	EstimatedTime      int
+	MinRequiredVersion string
+}
+
+var challengeInitCmd = &cobra.Command{
+	Use:   "init [challenge-slug]",
+	Short: "Generate a new challenge skeleton for authors",
+	Long: `Generates a new challenge skeleton in <dir>/<slug>/:
+
+  challenge.yaml   metadata and a commented example for every validation type
+  manifests/       a starter deployment for the broken initial state
+  policies/        Kyverno policies protecting the challenge (optional)
+
+The skeleton is linted right away, so it starts from a state that passes
+'kubeasy dev lint'. No cluster, API or interactive prompt is needed.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slug := args[0]
+		ui.Section(fmt.Sprintf("Initializing Challenge: %s", slug))
+
+		if err := validateChallengeSlug(slug); err != nil {
+			ui.Error("Invalid challenge slug")
+			return err
+		}
+
+		opts := challengeInitOptions{
+			Slug:               slug,
+			Title:              challengeInitTitle,
+			Type:               challengeInitType,
+			Theme:              challengeInitTheme,
+			Difficulty:         challengeInitDifficulty,
+			EstimatedTime:      challengeInitEstimatedTime,
+			MinRequiredVersion: minRequiredVersionForCreate(),
+		}
+		if opts.Title == "" {
+			opts.Title = titleFromSlug(slug)
+		}
+
+		challengeDir, err := scaffoldChallenge(challengeInitDir, opts)
+		if err != nil {
+			ui.Error("Failed to generate challenge skeleton")
+			return err
+		}
+
+		challengeYAMLPath := filepath.Join(challengeDir, "challenge.yaml")
+		issues, err := devutils.LintChallengeFile(cmd.Context(), challengeYAMLPath)
+		if err != nil {
+			ui.Error("Failed to lint generated challenge")
+			return err
+		}
+		for _, issue := range issues {
+			ui.Warning(fmt.Sprintf("[%s] %s", issue.Field, issue.Message))
+		}
+
+		ui.Success(fmt.Sprintf("Challenge '%s' created in %s/", slug, challengeDir))
+		ui.Println()
+		ui.KeyValue("Type", opts.Type)
+		ui.KeyValue("Theme", opts.Theme)
+		ui.KeyValue("Difficulty", opts.Difficulty)
+		ui.KeyValue("Estimated time", fmt.Sprintf("%d minutes", opts.EstimatedTime))
+		ui.Println()
+		ui.Info("Next steps:")
+		_ = ui.BulletList([]string{
+			fmt.Sprintf("Break the manifests in %s/manifests/ to create the initial situation", challengeDir),
+			fmt.Sprintf("Uncomment and adapt the example objectives in %s", challengeYAMLPath),
+			fmt.Sprintf("Run 'kubeasy dev lint %s' after each change", slug),
+			fmt.Sprintf("Run 'kubeasy dev test %s' to deploy and validate locally", slug),
+		})
+		return nil
+	},
+}
+
+// scaffoldChallenge writes a challenge skeleton into parentDir/<slug> and returns its path.
+// It refuses to overwrite an existing directory and removes partial output on failure.
+func scaffoldChallenge(parentDir string, opts challengeInitOptions) (string, error) {
+	if !slices.Contains(defaultChallengeTypes, opts.Type) {
+		return "", fmt.Errorf("invalid type '%s' (valid: %s)", opts.Type, strings.Join(defaultChallengeTypes, ", "))
+	}
+	if !slices.Contains(defaultChallengeDifficulties, opts.Difficulty) {
+		return "", fmt.Errorf("invalid difficulty '%s' (valid: %s)", opts.Difficulty, strings.Join(defaultChallengeDifficulties, ", "))
+	}
+	if opts.EstimatedTime <= 0 {
+		return "", fmt.Errorf("estimated time must be a positive integer")
+	}
+
+	challengeDir := filepath.Join(parentDir, opts.Slug)
+	if _, err := os.Stat(challengeDir); err == nil {
+		return "", fmt.Errorf("directory '%s' already exists", challengeDir)
+	}
+
+	for _, dir := range []string{
+		filepath.Join(challengeDir, "manifests"),
+		filepath.Join(challengeDir, "policies"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			_ = os.RemoveAll(challengeDir)
+			return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	success := false
+	defer func() {
+		if !success {
+			_ = os.RemoveAll(challengeDir)
+		}
+	}()
+
+	files := []struct {
+		path string
+		tmpl *template.Template
+	}{
+		{filepath.Join(challengeDir, "challenge.yaml"), challengeYAMLTemplate},
+		{filepath.Join(challengeDir, "manifests", "deployment.yaml"), deploymentManifestTemplate},
+	}
+	for _, f := range files {
+		var buf bytes.Buffer
+		if err := f.tmpl.Execute(&buf, opts); err != nil {
+			return "", fmt.Errorf("failed to generate %s: %w", filepath.Base(f.path), err)
+		}
+		if err := os.WriteFile(f.path, buf.Bytes(), 0o600); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", f.path, err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(challengeDir, "policies", ".gitkeep"), []byte{}, 0o600); err != nil {
+		return "", fmt.Errorf("failed to create policies/.gitkeep: %w", err)
+	}
+
+	success = true
+	return challengeDir, nil
+}
+
+// titleFromSlug turns "pod-evicted" into "Pod Evicted".
+func titleFromSlug(slug string) string {
+	words := strings.Split(slug, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func init() {
+	challengeCmd.AddCommand(challengeInitCmd)
+	challengeInitCmd.Flags().StringVar(&challengeInitDir, "dir", ".", "Parent directory of the new challenge")
+	challengeInitCmd.Flags().StringVar(&challengeInitTitle, "title", "", "Challenge title (derived from the slug if omitted)")
+	challengeInitCmd.Flags().StringVar(&challengeInitType, "type", "fix", "Challenge type (fix, build, migrate)")
+	challengeInitCmd.Flags().StringVar(&challengeInitTheme, "theme", "pods-containers", "Challenge theme")
+	challengeInitCmd.Flags().StringVar(&challengeInitDifficulty, "difficulty", "easy", "Challenge difficulty (easy, medium, hard)")
+	challengeInitCmd.Flags().IntVar(&challengeInitEstimatedTime, "estimated-time", 30, "Estimated time in minutes")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInitOptions(slug string) challengeInitOptions {
+	return challengeInitOptions{
+		Slug:          slug,
+		Title:         titleFromSlug(slug),
+		Type:          "fix",
+		Theme:         "pods-containers",
+		Difficulty:    "easy",
+		EstimatedTime: 30,
+	}
+}
+
+func TestScaffoldChallenge_PassesLint(t *testing.T) {
+	dir := t.TempDir()
+
+	challengeDir, err := scaffoldChallenge(dir, testInitOptions("pod-evicted"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "pod-evicted"), challengeDir)
+
+	for _, p := range []string{"challenge.yaml", "manifests/deployment.yaml", "policies/.gitkeep"} {
+		assert.FileExists(t, filepath.Join(challengeDir, p))
+	}
+
+	issues, err := devutils.LintChallengeFile(context.Background(), filepath.Join(challengeDir, "challenge.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestScaffoldChallenge_Errors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "existing"), 0o755))
+
+	_, err := scaffoldChallenge(dir, testInitOptions("existing"))
+	assert.ErrorContains(t, err, "already exists")
+
+	opts := testInitOptions("bad-type")
+	opts.Type = "unknown"
+	_, err = scaffoldChallenge(dir, opts)
+	assert.ErrorContains(t, err, "invalid type")
+	assert.NoDirExists(t, filepath.Join(dir, "bad-type"))
+}
+
+// TestObjectiveExamples uncomments the examples and checks they pass the strict
+// schema and cover every registered validation type.
+func TestObjectiveExamples(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("title: t\ndescription: d\ntheme: pods-containers\ndifficulty: easy\ntype: fix\n")
+	b.WriteString("estimatedTime: 10\ninitialSituation: i\nobjective: o\nobjectives:\n")
+	rendered := strings.ReplaceAll(objectiveExamples, "{{.Slug}}", "demo")
+	for _, line := range strings.Split(rendered, "\n") {
+		line = strings.TrimPrefix(line, "  # ")
+		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "  ") {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	cfg, err := validation.ParseStrict([]byte(b.String()))
+	require.NoError(t, err)
+
+	seen := map[validation.ValidationType]bool{}
+	for _, v := range cfg.Validations {
+		seen[v.Type] = true
+	}
+	for _, reg := range validation.RegisteredTypes {
+		assert.True(t, seen[reg.Type], "missing example for validation type %q", reg.Type)
+	}
+}
+
+func TestTitleFromSlug(t *testing.T) {
+	assert.Equal(t, "Pod Evicted", titleFromSlug("pod-evicted"))
+	assert.Equal(t, "Rbac 101", titleFromSlug("rbac-101"))
+}
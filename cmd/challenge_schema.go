@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var challengeSchemaJSON bool
+
+var challengeSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Show the challenge.yaml schema",
+	Long: `Shows the validation types supported by this CLI version.
+
+Use --json to print the full JSON Schema for challenge.yaml, e.g. for editor
+integration:
+
+  kubeasy challenge schema --json > challenge.schema.json
+
+Then reference it from your editor (VS Code YAML extension):
+
+  # yaml-language-server: $schema=./challenge.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if challengeSchemaJSON {
+			_, err := os.Stdout.Write(validation.JSONSchema())
+			return err
+		}
+
+		ui.Section("challenge.yaml validation types")
+		rows := make([][]string, 0, len(validation.RegisteredTypes))
+		for _, reg := range validation.RegisteredTypes {
+			rows = append(rows, []string{string(reg.Type), reg.SpecName, strings.Join(specFieldNames(reg.Spec), ", ")})
+		}
+		if err := ui.Table([]string{"TYPE", "SPEC", "FIELDS"}, rows); err != nil {
+			return fmt.Errorf("failed to render table: %w", err)
+		}
+		ui.Println()
+		ui.Info("Run 'kubeasy challenge schema --json' for the full JSON Schema")
+		return nil
+	},
+}
+
+// specFieldNames returns the sorted yaml field names of a spec struct.
+func specFieldNames(spec interface{}) []string {
+	t := reflect.TypeOf(spec)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	challengeCmd.AddCommand(challengeSchemaCmd)
+	challengeSchemaCmd.Flags().BoolVar(&challengeSchemaJSON, "json", false, "Print the JSON Schema for challenge.yaml")
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var (
+	challengeTestTimeout  time.Duration
+	challengeTestInterval time.Duration
+	challengeTestKeep     bool
+)
+
+var challengeTestCmd = &cobra.Command{
+	Use:   "test [path]",
+	Short: "Test a local challenge end to end (broken state fails, solution passes)",
+	Long: `Runs the full author loop against the local Kind cluster, without the Kubeasy API:
+
+  1. Deploys the challenge from <path> into a fresh namespace (direct apply)
+  2. Runs the validations and expects at least one of them to FAIL
+  3. Applies the optional <path>/solution/ directory on top of it
+  4. Re-runs the validations until they all PASS or --timeout expires
+
+The namespace is deleted at the end unless --keep is set.
+The command exits with an error if the broken state passes or the solution fails.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		challengeDir, slug, err := resolveChallengeTestDir(args[0])
+		if err != nil {
+			ui.Error("Invalid challenge path")
+			return err
+		}
+
+		ui.Section(fmt.Sprintf("Testing Challenge: %s", slug))
+		ui.Info(fmt.Sprintf("Directory: %s", challengeDir))
+
+		config, err := validation.LoadFromFileStrict(cmd.Context(), filepath.Join(challengeDir, "challenge.yaml"))
+		if err != nil {
+			ui.Error("Failed to load validations")
+			return fmt.Errorf("failed to load validations: %w", err)
+		}
+		if len(config.Validations) == 0 {
+			ui.Error("No objectives defined in challenge.yaml: nothing to test")
+			return fmt.Errorf("no objectives defined")
+		}
+
+		if err := runDevApply(cmd.Context(), slug, challengeDir, true); err != nil {
+			return err
+		}
+		if !challengeTestKeep {
+			defer func() {
+				if err := deleteChallengeResources(context.WithoutCancel(cmd.Context()), slug); err != nil {
+					logger.Warning("Failed to clean up challenge %s: %v", slug, err)
+				}
+			}()
+		}
+
+		executor, err := newLocalExecutor(slug)
+		if err != nil {
+			return err
+		}
+		// The validations are re-run until the solution passes: serve them from informers.
+		executor.EnableCache()
+		defer executor.Close()
+
+		// Step 1: the broken initial state must not pass.
+		ui.Section("Initial State")
+		brokenResults := executeWithChecklist(cmd.Context(), executor, config.Validations)
+		brokenFails := !devutils.DisplayValidationResults(config.Validations, brokenResults)
+
+		// Step 2: the solution, when present, must make every validation pass.
+		solutionDir := filepath.Join(challengeDir, deployer.SolutionDirName)
+		hasSolution := isDir(solutionDir)
+		solutionPasses := false
+		if hasSolution {
+			ui.Section("Solution")
+			solutionPasses, err = applySolutionAndValidate(cmd.Context(), executor, config.Validations, solutionDir, slug)
+			if err != nil {
+				return err
+			}
+		}
+
+		ui.Section("Test Result")
+		ui.KeyValue("Initial state fails", passFail(brokenFails))
+		if hasSolution {
+			ui.KeyValue("Solution passes", passFail(solutionPasses))
+		} else {
+			ui.KeyValue("Solution passes", "skipped (no solution/ directory)")
+		}
+		ui.Println()
+
+		switch {
+		case !brokenFails:
+			ui.Error("All validations pass before any fix: the initial state is not broken")
+			return kerrors.New(kerrors.CodeValidationFailed, "initial state passes all validations")
+		case hasSolution && !solutionPasses:
+			ui.Error(fmt.Sprintf("The solution did not pass all validations within %s", challengeTestTimeout))
+			return kerrors.New(kerrors.CodeValidationFailed, "solution failed validations")
+		}
+		ui.Success("Challenge behaves as expected")
+		return nil
+	},
+}
+
+// applySolutionAndValidate applies the solution overlay, then re-runs the validations
+// until they all pass or challengeTestTimeout expires. The last results are displayed.
+func applySolutionAndValidate(ctx context.Context, executor *validation.Executor, validations []validation.Validation, solutionDir, namespace string) (bool, error) {
+	clientset, err := kube.GetKubernetesClient()
+	if err != nil {
+		ui.Error("Failed to get Kubernetes client")
+		return false, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+	dynamicClient, err := kube.GetDynamicClient()
+	if err != nil {
+		ui.Error("Failed to get dynamic client")
+		return false, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+
+	err = ui.TimedSpinner("Applying solution manifests", func() error {
+		return deployer.ApplySolution(ctx, clientset, dynamicClient, solutionDir, namespace)
+	})
+	if err != nil {
+		ui.Error("Failed to apply solution")
+		return false, err
+	}
+
+	var results []validation.Result
+	deadline := time.Now().Add(challengeTestTimeout)
+	err = ui.TimedSpinner("Waiting for validations to pass", func() error {
+		for {
+			results = executor.ExecuteAll(ctx, validations)
+			if allResultsPassed(results) || time.Now().After(deadline) {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(challengeTestInterval):
+			}
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return devutils.DisplayValidationResults(validations, results), nil
+}
+
+// newLocalExecutor builds a validation executor for the given namespace in the Kind cluster.
+func newLocalExecutor(namespace string) (*validation.Executor, error) {
+	clientset, err := kube.GetKubernetesClient()
+	if err != nil {
+		ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+	dynamicClient, err := kube.GetDynamicClient()
+	if err != nil {
+		ui.Error("Failed to get dynamic client")
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+	restConfig, err := kube.GetRestConfig()
+	if err != nil {
+		ui.Error("Failed to get REST config")
+		return nil, fmt.Errorf("failed to get REST config: %w", err)
+	}
+	return validation.NewExecutor(clientset, dynamicClient, restConfig, namespace), nil
+}
+
+// resolveChallengeTestDir accepts a challenge directory or its challenge.yaml and
+// returns the absolute directory and the slug derived from its name.
+func resolveChallengeTestDir(path string) (string, string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+	if filepath.Base(absPath) == "challenge.yaml" {
+		absPath = filepath.Dir(absPath)
+	}
+	if _, err := os.Stat(filepath.Join(absPath, "challenge.yaml")); err != nil {
+		return "", "", fmt.Errorf("no challenge.yaml found in %s", absPath)
+	}
+	slug := filepath.Base(absPath)
+	if err := validateChallengeSlug(slug); err != nil {
+		return "", "", err
+	}
+	return absPath, slug, nil
+}
+
+func allResultsPassed(results []validation.Result) bool {
+	for _, r := range results {
+		if !r.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+func init() {
+	challengeCmd.AddCommand(challengeTestCmd)
+	challengeTestCmd.Flags().DurationVar(&challengeTestTimeout, "timeout", 3*time.Minute, "How long to wait for the solution to pass")
+	challengeTestCmd.Flags().DurationVar(&challengeTestInterval, "interval", 5*time.Second, "Interval between validation runs while waiting")
+	challengeTestCmd.Flags().BoolVar(&challengeTestKeep, "keep", false, "Keep the challenge namespace after the test")
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveChallengeTestDir(t *testing.T) {
+	root := t.TempDir()
+	challengeDir := filepath.Join(root, "pod-evicted")
+	require.NoError(t, os.Mkdir(challengeDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(challengeDir, "challenge.yaml"), []byte("title: t\n"), 0o600))
+
+	t.Run("directory", func(t *testing.T) {
+		dir, slug, err := resolveChallengeTestDir(challengeDir)
+		require.NoError(t, err)
+		assert.Equal(t, challengeDir, dir)
+		assert.Equal(t, "pod-evicted", slug)
+	})
+
+	t.Run("challenge.yaml path", func(t *testing.T) {
+		dir, slug, err := resolveChallengeTestDir(filepath.Join(challengeDir, "challenge.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, challengeDir, dir)
+		assert.Equal(t, "pod-evicted", slug)
+	})
+
+	t.Run("missing challenge.yaml", func(t *testing.T) {
+		_, _, err := resolveChallengeTestDir(root)
+		assert.ErrorContains(t, err, "no challenge.yaml found")
+	})
+
+	t.Run("invalid slug", func(t *testing.T) {
+		badDir := filepath.Join(root, "Bad_Name")
+		require.NoError(t, os.Mkdir(badDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(badDir, "challenge.yaml"), []byte("title: t\n"), 0o600))
+		_, _, err := resolveChallengeTestDir(badDir)
+		assert.ErrorContains(t, err, "invalid challenge slug")
+	})
+}
+
+func TestAllResultsPassed(t *testing.T) {
+	assert.True(t, allResultsPassed(nil))
+	assert.True(t, allResultsPassed([]validation.Result{{Passed: true}, {Passed: true}}))
+	assert.False(t, allResultsPassed([]validation.Result{{Passed: true}, {Passed: false}}))
+}
@@ -3,10 +3,16 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	cleanDryRun bool
+	cleanYes    bool
+)
+
 var cleanChallengeCmd = &cobra.Command{
 	Use:   "clean [challenge-slug]",
 	Short: "Clean a challenge",
@@ -22,7 +28,22 @@ var cleanChallengeCmd = &cobra.Command{
 
 		ui.Section(fmt.Sprintf("Cleaning Challenge: %s", challengeSlug))
 
-		if err := deleteChallengeResources(cmd.Context(), challengeSlug); err != nil {
+		plan := []steps.Step{clusterCleanupStep(challengeSlug)}
+		if cleanDryRun {
+			printPlan(plan)
+			return nil
+		}
+
+		confirmed, err := confirmPlan(plan, fmt.Sprintf("Delete the resources of challenge '%s'?", challengeSlug), cleanYes)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			ui.Info("Clean cancelled")
+			return nil
+		}
+
+		if err := runSteps(cmd.Context(), "clean", plan); err != nil {
 			return err
 		}
 
@@ -36,4 +57,6 @@ var cleanChallengeCmd = &cobra.Command{
 
 func init() {
 	challengeCmd.AddCommand(cleanChallengeCmd)
+	cleanChallengeCmd.Flags().BoolVarP(&cleanYes, "yes", "y", false, "Skip the confirmation prompt (required when not running in a terminal)")
+	cleanChallengeCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Print what would be deleted without changing anything")
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"testing"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,3 +14,22 @@ func TestCleanRunE_InvalidSlug(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid challenge slug")
 }
+
+// TestCleanRunE_DryRun verifies that --dry-run succeeds without a cluster.
+func TestCleanRunE_DryRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	orig := cleanDryRun
+	t.Cleanup(func() { cleanDryRun = orig })
+	cleanDryRun = true
+
+	err := cleanChallengeCmd.RunE(cleanChallengeCmd, []string{"pod-evicted"})
+	require.NoError(t, err)
+}
+
+// TestCleanRunE_RequiresYesWhenNonInteractive verifies that clean refuses to run unattended without --yes.
+func TestCleanRunE_RequiresYesWhenNonInteractive(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	err := cleanChallengeCmd.RunE(cleanChallengeCmd, []string{"pod-evicted"})
+	require.ErrorIs(t, err, ui.ErrConfirmationRequired)
+}
@@ -8,8 +8,12 @@ import (
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/report"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
 )
 
 // validateChallengeSlug validates that a challenge slug has the correct format
@@ -17,21 +21,21 @@ func validateChallengeSlug(slug string) error {
 	// Challenge slugs should be lowercase alphanumeric with hyphens
 	// Example: "basic-pod", "deployment-rollout", "config-map-101"
 	if !regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`).MatchString(slug) {
-		return fmt.Errorf("invalid challenge slug format: '%s' (must be lowercase alphanumeric with hyphens)", slug)
+		return kerrors.New(kerrors.CodeInvalidInput, "invalid challenge slug format: '%s' (must be lowercase alphanumeric with hyphens)", slug)
 	}
 	if len(slug) < 3 || len(slug) > 63 {
-		return fmt.Errorf("invalid challenge slug length: '%s' (must be between 3 and 63 characters)", slug)
+		return kerrors.New(kerrors.CodeInvalidInput, "invalid challenge slug length: '%s' (must be between 3 and 63 characters)", slug)
 	}
 	return nil
 }
 
 // getChallenge tries to get a challenge and returns an error if it fails
-func getChallenge(slug string) (*api.ChallengeEntity, error) {
+func getChallenge(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
 	if err := validateChallengeSlug(slug); err != nil {
 		return nil, err
 	}
 
-	challenge, err := api.GetChallengeBySlug(context.Background(), slug)
+	challenge, err := commandContextFrom(ctx).API.GetChallenge(ctx, slug)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch challenge: %w", err)
 	}
@@ -49,10 +53,13 @@ func deleteChallengeResources(ctx context.Context, challengeSlug string) error {
 	ui.Println()
 
 	// Get Kubernetes clientset
-	clientset, err := kube.GetKubernetesClient()
+	clientset, err := commandContextFrom(ctx).Clientset()
 	if err != nil {
 		ui.Error("Failed to get Kubernetes clientset")
-		return fmt.Errorf("failed to get Kubernetes clientset: %w", err)
+		return err
+	}
+	if err := guardKubeasyCluster(ctx, clientset); err != nil {
+		return err
 	}
 
 	// Delete namespace and restore context
@@ -67,3 +74,105 @@ func deleteChallengeResources(ctx context.Context, challengeSlug string) error {
 	ui.Success("Challenge resources deleted")
 	return nil
 }
+
+// apiGetProfile fetches the logged-in user; a variable so tests can stub it.
+var apiGetProfile = api.GetProfile
+
+// currentUserID returns the ID of the logged-in user, which selects their variant of
+// challenges with variants.
+func currentUserID(ctx context.Context) (string, error) {
+	profile, err := apiGetProfile(ctx)
+	if err != nil {
+		return "", err
+	}
+	return profile.ID, nil
+}
+
+// loadUserValidations loads the validations of the variant of slug assigned to the
+// logged-in user. A challenge without variants loads without the user; one with
+// variants fails when the user cannot be fetched (see validation.CheckVariantUser).
+func loadUserValidations(ctx context.Context, slug string) (*validation.ValidationConfig, error) {
+	userID, userErr := currentUserID(ctx)
+	config, err := validation.LoadForChallenge(ctx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.CheckVariantUser(slug, config, userErr); err != nil {
+		return nil, err
+	}
+	if userErr != nil {
+		logger.Debug("Could not fetch the user profile: %v", userErr)
+	}
+	return config, nil
+}
+
+// executeWithChecklist runs validations in parallel while showing a live checklist of
+// which ones are running, passed or failed, prefixed with their phase. Results are
+// returned in input order.
+func executeWithChecklist(ctx context.Context, executor *validation.Executor, validations []validation.Validation) []validation.Result {
+	onProgress, stop := checklistProgress(validations)
+	defer stop()
+	return executor.ExecuteAllWithProgress(ctx, validations, onProgress)
+}
+
+// verifyWithChecklist runs the validations of a challenge through the SDK while
+// showing the same live checklist as executeWithChecklist.
+func verifyWithChecklist(ctx context.Context, client *sdk.Client, slug string, since time.Time) (*sdk.Verification, error) {
+	var (
+		onProgress func(validation.ProgressEvent)
+		stop       = func() {}
+	)
+	defer func() { stop() }()
+	return client.Verify(ctx, slug, sdk.VerifyOptions{
+		Since: since,
+		OnLoaded: func(config *validation.ValidationConfig) {
+			ui.Info("Running validations...")
+			ui.Println()
+			onProgress, stop = checklistProgress(config.Validations)
+		},
+		OnProgress: func(ev validation.ProgressEvent) { onProgress(ev) },
+	})
+}
+
+// checklistProgress shows a live checklist of validations and returns the progress
+// handler updating it and the function stopping it.
+func checklistProgress(validations []validation.Validation) (func(validation.ProgressEvent), func()) {
+	names := make([]string, len(validations))
+	for i, v := range validations {
+		names[i] = v.Title
+		if names[i] == "" {
+			names[i] = v.Key
+		}
+		if v.Phase != "" {
+			names[i] = fmt.Sprintf("[%s] %s", v.Phase, names[i])
+		}
+	}
+
+	checklist := ui.NewChecklist(names)
+	return func(ev validation.ProgressEvent) {
+		switch {
+		case !ev.Done:
+			checklist.Set(ev.Index, "running")
+		case ev.Result.Passed:
+			checklist.Set(ev.Index, "success")
+		case ev.Result.Skipped:
+			checklist.Set(ev.Index, "skipped")
+		default:
+			checklist.Set(ev.Index, "error")
+		}
+	}, checklist.Stop
+}
+
+// writeReports writes the --report files of a validation run. Reports are announced
+// unless quiet, e.g. when stdout carries JSON output.
+func writeReports(targets []report.Target, run report.Run, quiet bool) error {
+	for _, t := range targets {
+		if err := report.Write(t, run); err != nil {
+			return fmt.Errorf("failed to write %s report %s: %w", t.Format, t.Path, err)
+		}
+		if !quiet {
+			ui.Info(fmt.Sprintf("Wrote %s report to %s", t.Format, t.Path))
+		}
+	}
+	return nil
+}
@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/report"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestValidateChallengeSlug verifies that validateChallengeSlug accepts valid slugs
@@ -49,3 +57,52 @@ func TestValidateChallengeSlug(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteReports(t *testing.T) {
+	dir := t.TempDir()
+	targets, err := report.ParseTargets([]string{"junit=" + filepath.Join(dir, "junit.xml"), "sarif=" + filepath.Join(dir, "out.sarif")})
+	require.NoError(t, err)
+
+	run := report.Run{
+		Name:        "pod-evicted",
+		Validations: []validation.Validation{{Key: "pod-ready", Type: validation.TypeCondition}},
+		Results:     []validation.Result{{Key: "pod-ready", Passed: true}},
+	}
+	require.NoError(t, writeReports(targets, run, true))
+	assert.FileExists(t, filepath.Join(dir, "junit.xml"))
+	assert.FileExists(t, filepath.Join(dir, "out.sarif"))
+
+	blocked := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(blocked, nil, 0o600))
+	err = writeReports([]report.Target{{Format: report.FormatJUnit, Path: filepath.Join(blocked, "junit.xml")}}, run, true)
+	assert.ErrorContains(t, err, "failed to write junit report")
+}
+
+func TestLoadUserValidations_VariantNeedsUser(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "demo"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "demo", "challenge.yaml"), []byte(`objectives: []
+variants:
+  - name: first
+    objectives:
+      - {key: a, type: status, spec: {target: {kind: Pod, name: a}, checks: [{field: phase, operator: "==", value: Running}]}}
+  - name: second
+    objectives:
+      - {key: b, type: status, spec: {target: {kind: Pod, name: b}, checks: [{field: phase, operator: "==", value: Running}]}}
+`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "plain"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plain", "challenge.yaml"), []byte("objectives: []\n"), 0o600))
+	t.Setenv("KUBEASY_LOCAL_CHALLENGES_DIR", dir)
+
+	orig := apiGetProfile
+	t.Cleanup(func() { apiGetProfile = orig })
+	apiGetProfile = func(context.Context) (*api.UserProfile, error) { return nil, errors.New("not authenticated") }
+
+	_, err := loadUserValidations(context.Background(), "demo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot select your variant of demo")
+
+	config, err := loadUserValidations(context.Background(), "plain")
+	require.NoError(t, err, "a challenge without variants loads without the user")
+	assert.Empty(t, config.Variant)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/keystore"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// commandContext is what command implementations use instead of package-level
+// getters: the configuration of the invocation, the logger, the Kubeasy API, the
+// cluster clients and the local state store. The root command builds one per
+// invocation and stores it in the command's context; tests store their own, with
+// stubbed API functions and fake clients.
+type commandContext struct {
+	Config      cliConfig
+	Log         *logger.Logger
+	Credentials keystore.CredentialStore
+	API         sdk.API
+	// Connect connects to the kubeasy cluster. Cluster calls it on first use only.
+	Connect func() (*sdk.Cluster, error)
+	// Deploy deploys a challenge in its namespace; nil deploys it from the registry.
+	Deploy func(ctx context.Context, cluster *sdk.Cluster, slug string) (string, error)
+	// HealPlatform repairs the platform components before start and submit; nil
+	// skips the watchdog.
+	HealPlatform func(ctx context.Context, cluster *sdk.Cluster) ([]deployer.Repair, error)
+	State        stateStore
+
+	cluster *sdk.Cluster
+}
+
+// cliConfig is the configuration of an invocation, from flags and the environment.
+type cliConfig struct {
+	APIURL  string
+	LogFile string
+	// SkipClusterGuard is --i-know-what-im-doing.
+	SkipClusterGuard bool
+}
+
+// stateStore is the local state file (see internal/state).
+type stateStore interface {
+	Path() string
+	Load() (*state.State, error)
+	Update(fn func(*state.State) error) error
+	Clear() error
+}
+
+// fileStateStore is the state file under ~/.kubeasy.
+type fileStateStore struct{}
+
+func (fileStateStore) Path() string                             { return state.GetPath() }
+func (fileStateStore) Load() (*state.State, error)              { return state.Load() }
+func (fileStateStore) Update(fn func(*state.State) error) error { return state.Update(fn) }
+func (fileStateStore) Clear() error                             { return state.Clear() }
+
+// newCommandContext returns the context of an invocation from the parsed flags and
+// the environment. An invalid KUBEASY_CREDENTIAL_STORE warns and keeps the default
+// store.
+func newCommandContext() *commandContext {
+	credentials, err := keystore.FromEnv()
+	if err != nil {
+		ui.Warning(fmt.Sprintf("%v; using the default credential store", err))
+		credentials = keystore.Auto()
+	}
+	apiFuncs := sdk.DefaultAPI()
+	apiFuncs.UserID = currentUserID
+	return &commandContext{
+		Config: cliConfig{
+			APIURL:           constants.WebsiteURL,
+			LogFile:          constants.LogFilePath,
+			SkipClusterGuard: skipClusterGuard,
+		},
+		Log:          logger.GetLogger(),
+		Credentials:  credentials,
+		API:          apiFuncs,
+		Connect:      sdk.ClusterFromKubeconfig,
+		HealPlatform: healPlatform,
+		State:        fileStateStore{},
+	}
+}
+
+type commandContextKey struct{}
+
+// withCommandContext returns a copy of ctx carrying cc.
+func withCommandContext(ctx context.Context, cc *commandContext) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, commandContextKey{}, cc)
+}
+
+// setCommandContext stores cc in the context of cmd, where its implementation finds it.
+func setCommandContext(cmd *cobra.Command, cc *commandContext) {
+	cmd.SetContext(withCommandContext(cmd.Context(), cc))
+}
+
+// commandContextFrom returns the command context stored in ctx, or a new one when
+// there is none (e.g. a command run outside Execute).
+func commandContextFrom(ctx context.Context) *commandContext {
+	if ctx != nil {
+		if cc, ok := ctx.Value(commandContextKey{}).(*commandContext); ok {
+			return cc
+		}
+	}
+	return newCommandContext()
+}
+
+// Cluster returns the clients of the kubeasy cluster, connecting on first use.
+func (cc *commandContext) Cluster() (*sdk.Cluster, error) {
+	if cc.cluster == nil {
+		cluster, err := cc.Connect()
+		if err != nil {
+			return nil, err
+		}
+		cc.cluster = cluster
+	}
+	return cc.cluster, nil
+}
+
+// Clientset returns the typed client of the kubeasy cluster.
+func (cc *commandContext) Clientset() (kubernetes.Interface, error) {
+	cluster, err := cc.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	return cluster.Clientset, nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var errUnstubbedAPI = errors.New("unexpected API call")
+
+// testCommandContext returns a command context whose API calls fail until a test
+// stubs them, over a fake cluster holding objects.
+func testCommandContext(objects ...runtime.Object) *commandContext {
+	clientset := fake.NewClientset(objects...)
+	return &commandContext{
+		API: sdk.API{
+			GetChallenge: func(context.Context, string) (*api.ChallengeEntity, error) { return nil, errUnstubbedAPI },
+			GetProgress:  func(context.Context, string) (*api.ChallengeStatusResponse, error) { return nil, errUnstubbedAPI },
+			StartChallenge: func(context.Context, string) (*api.ChallengeStartResponse, error) {
+				return nil, errUnstubbedAPI
+			},
+			SubmitChallenge: func(context.Context, string, api.ChallengeSubmitRequest) (*api.ChallengeSubmitResponse, error) {
+				return nil, errUnstubbedAPI
+			},
+			ResetChallenge: func(context.Context, string) (*api.ChallengeResetResponse, error) { return nil, errUnstubbedAPI },
+			UserID:         func(context.Context) (string, error) { return "", nil },
+		},
+		Connect: func() (*sdk.Cluster, error) { return &sdk.Cluster{Clientset: clientset}, nil },
+		State:   fileStateStore{},
+	}
+}
+
+// challengeNamespace is the namespace of the challenge the cmd tests run.
+func challengeNamespace() *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pod-evicted"}}
+}
+
+// useCommandContext runs cmd with cc until the end of the test.
+func useCommandContext(t *testing.T, cmd *cobra.Command, cc *commandContext) {
+	t.Helper()
+	prev := cmd.Context()
+	setCommandContext(cmd, cc)
+	t.Cleanup(func() { cmd.SetContext(prev) })
+}
+
+func TestCommandContextFrom(t *testing.T) {
+	cc := testCommandContext()
+	assert.Same(t, cc, commandContextFrom(withCommandContext(context.Background(), cc)))
+
+	t.Setenv("KUBEASY_CREDENTIAL_STORE", "env")
+	skipClusterGuard = true
+	t.Cleanup(func() { skipClusterGuard = false })
+	fallback := commandContextFrom(context.Background())
+	assert.True(t, fallback.Config.SkipClusterGuard, "built from the flags")
+	assert.NotNil(t, fallback.API.GetChallenge)
+	_, err := fallback.Credentials.Set("key")
+	assert.Error(t, err, "the env store is read-only")
+}
+
+func TestCommandContextCluster_ConnectsOnce(t *testing.T) {
+	calls := 0
+	cc := &commandContext{Connect: func() (*sdk.Cluster, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("cluster down")
+		}
+		return &sdk.Cluster{Clientset: fake.NewClientset()}, nil
+	}}
+
+	_, err := cc.Clientset()
+	require.Error(t, err)
+	first, err := cc.Cluster()
+	require.NoError(t, err, "a failed connection is retried")
+	second, err := cc.Cluster()
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 2, calls)
+}
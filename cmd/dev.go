@@ -18,6 +18,7 @@ challenge.yaml in the current directory or ../challenges/<slug>/.
 Commands:
   create   - Scaffold a new challenge directory
   get      - Display challenge metadata from local files
+  list     - List local challenges and their metadata
   apply    - Deploy challenge manifests from local files
   validate - Run validations locally without submitting to API
   test     - Apply manifests and run validations in one step
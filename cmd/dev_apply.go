@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
@@ -12,6 +13,7 @@ var (
 	devApplyDir   string
 	devApplyClean bool
 	devApplyWatch bool
+	devApplyDiff  bool
 )
 
 var devApplyCmd = &cobra.Command{
@@ -23,7 +25,9 @@ This is the dev equivalent of 'kubeasy challenge start'.
 It searches for challenge.yaml in the current directory or ../challenges/<slug>/.
 Use --dir to specify a custom directory.
 Use --clean to delete existing resources before applying.
-Use --watch/-w to watch for changes and auto-redeploy (uses fsnotify).`,
+Use --watch/-w to watch for changes and auto-redeploy (uses fsnotify).
+Use --diff to preview what applying would change (server-side dry run) without
+changing the cluster.`,
 	Args:          cobra.ExactArgs(1),
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -47,7 +51,14 @@ Use --watch/-w to watch for changes and auto-redeploy (uses fsnotify).`,
 			ui.Info(fmt.Sprintf("Using local directory: %s", challengeDir))
 		}
 
-		if err := runDevApply(cmd, challengeSlug, challengeDir, devApplyClean); err != nil {
+		if devApplyDiff {
+			if devApplyClean || devApplyWatch {
+				return fmt.Errorf("--diff cannot be combined with --clean or --watch")
+			}
+			return runDevDiff(cmd.Context(), challengeSlug, challengeDir)
+		}
+
+		if err := runDevApply(cmd.Context(), challengeSlug, challengeDir, devApplyClean); err != nil {
 			return err
 		}
 
@@ -64,8 +75,8 @@ Use --watch/-w to watch for changes and auto-redeploy (uses fsnotify).`,
 				challengeDir = dir
 			}
 
-			return devutils.FsWatchLoop(cmd.Context(), challengeDir, func() {
-				if err := runDevApply(cmd, challengeSlug, challengeDir, false); err != nil {
+			return devutils.FsWatchLoop(cmd.Context(), challengeDir, func(ctx context.Context) {
+				if err := runDevApply(ctx, challengeSlug, challengeDir, false); err != nil {
 					ui.Error(fmt.Sprintf("Re-apply failed: %v", err))
 				} else {
 					ui.Success("Re-applied successfully")
@@ -83,4 +94,5 @@ func init() {
 	devApplyCmd.Flags().StringVar(&devApplyDir, "dir", "", "Read from local directory")
 	devApplyCmd.Flags().BoolVar(&devApplyClean, "clean", false, "Delete existing resources before applying")
 	devApplyCmd.Flags().BoolVarP(&devApplyWatch, "watch", "w", false, "Watch for changes and auto-redeploy")
+	devApplyCmd.Flags().BoolVar(&devApplyDiff, "diff", false, "Show what applying would change, without changing anything")
 }
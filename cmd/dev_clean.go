@@ -3,10 +3,13 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var devCleanDryRun bool
+
 var devCleanCmd = &cobra.Command{
 	Use:   "clean [challenge-slug]",
 	Short: "Remove dev challenge resources from the cluster",
@@ -26,7 +29,13 @@ API login.`,
 			return err
 		}
 
-		if err := deleteChallengeResources(cmd.Context(), challengeSlug); err != nil {
+		plan := []steps.Step{clusterCleanupStep(challengeSlug)}
+		if devCleanDryRun {
+			printPlan(plan)
+			return nil
+		}
+
+		if err := runSteps(cmd.Context(), "dev clean", plan); err != nil {
 			return err
 		}
 
@@ -40,4 +49,5 @@ API login.`,
 
 func init() {
 	devCmd.AddCommand(devCleanCmd)
+	devCleanCmd.Flags().BoolVar(&devCleanDryRun, "dry-run", false, "Print what would be deleted without changing anything")
 }
@@ -68,7 +68,9 @@ func fetchMetadata(ctx context.Context) (types, themes, difficulties []string) {
 	return types, themes, difficulties
 }
 
-// challengeYAMLTemplate is a well-commented template for new challenge.yaml files.
+// challengeYAMLTemplate is the challenge.yaml generated by 'dev create' and
+// 'challenge init', with a commented example objective per validation type. It is
+// executed with a challengeInitOptions.
 var challengeYAMLTemplate = template.Must(template.New("challenge.yaml").Parse(`title: "{{.Title}}"
 type: "{{.Type}}"
 theme: "{{.Theme}}"
@@ -95,40 +97,9 @@ initialSituation: |
 objective: |
   TODO: Write the objective here.
 
-# Define validation objectives that check the user's solution.
-# Each objective runs against the cluster to verify the fix.
+# Validation objectives run against the cluster to verify the user's fix.
 objectives: []
-  # Example condition objective (uncomment and adapt):
-  #
-  # - key: pod-running
-  #   title: "Application Running"
-  #   description: "The application pod must be in Ready state"
-  #   order: 1
-  #   type: condition
-  #   spec:
-  #     target:
-  #       kind: Pod
-  #       labelSelector:
-  #         app: my-app
-  #     checks:
-  #       - type: Ready
-  #         status: "True"
-  #
-  # - key: no-crashes
-  #   title: "Stable Operation"
-  #   description: "No crash or eviction events"
-  #   order: 2
-  #   type: event
-  #   spec:
-  #     target:
-  #       kind: Pod
-  #       labelSelector:
-  #         app: my-app
-  #     forbiddenReasons:
-  #       - "OOMKilled"
-  #       - "CrashLoopBackOff"
-  #     sinceSeconds: 300
-`))
+` + objectiveExamples))
 
 // deploymentManifestTemplate generates a starter deployment.yaml for a challenge.
 var deploymentManifestTemplate = template.Must(template.New("deployment.yaml").Parse(`apiVersion: apps/v1
@@ -345,14 +316,8 @@ In non-interactive mode, use flags: --name, --type, --theme, --difficulty.`,
 
 		// Generate challenge.yaml from template
 		var buf bytes.Buffer
-		err := challengeYAMLTemplate.Execute(&buf, struct {
-			Title              string
-			Type               string
-			Theme              string
-			Difficulty         string
-			EstimatedTime      int
-			MinRequiredVersion string
-		}{
+		err := challengeYAMLTemplate.Execute(&buf, challengeInitOptions{
+			Slug:               slug,
 			Title:              name,
 			Type:               challengeType,
 			Theme:              theme,
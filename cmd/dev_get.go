@@ -7,28 +7,9 @@ import (
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
-	"go.yaml.in/yaml/v3"
 )
 
-// challengeMetadata holds the full challenge.yaml content for display.
-type challengeMetadata struct {
-	Title            string `yaml:"title"`
-	Type             string `yaml:"type"`
-	Theme            string `yaml:"theme"`
-	Difficulty       string `yaml:"difficulty"`
-	EstimatedTime    int    `yaml:"estimatedTime"`
-	Description      string `yaml:"description"`
-	InitialSituation string `yaml:"initialSituation"`
-	Objectives       []struct {
-		Key   string `yaml:"key"`
-		Title string `yaml:"title"`
-		Order int    `yaml:"order"`
-		Type  string `yaml:"type"`
-	} `yaml:"objectives"`
-}
-
 var devGetCmd = &cobra.Command{
 	Use:   "get [challenge-slug]",
 	Short: "Display challenge metadata from local files",
@@ -53,17 +34,24 @@ It searches for challenge.yaml in the current directory or ../challenges/<slug>/
 			return fmt.Errorf("challenge file not found")
 		}
 
-		data, err := os.ReadFile(localPath)
+		data, err := os.ReadFile(localPath) //nolint:gosec // path found by FindLocalChallengeFile
 		if err != nil {
 			ui.Error(fmt.Sprintf("Failed to read challenge file: %v", err))
 			return err
 		}
-
-		var meta challengeMetadata
-		if err := yaml.Unmarshal(data, &meta); err != nil {
+		data, err = validation.ResolveIncludes(cmd.Context(), data, localPath)
+		if err != nil {
+			ui.Error("Failed to resolve the includes of challenge.yaml")
+			return err
+		}
+		// Metadata is read leniently so an invalid objective does not hide it: the
+		// errors of the strict parse are printed as warnings after it
+		meta, err := validation.ParseChallengeYaml(data)
+		if err != nil {
 			ui.Error("Failed to parse challenge.yaml")
-			return fmt.Errorf("failed to parse challenge.yaml: %w", err)
+			return err
 		}
+		_, specErr := validation.ParseChallengeSpec(data, challengeSlug)
 
 		ui.Println()
 		ui.Section(meta.Title)
@@ -82,20 +70,20 @@ It searches for challenge.yaml in the current directory or ../challenges/<slug>/
 		}
 
 		if sit := strings.TrimSpace(meta.InitialSituation); sit != "" {
-			pterm.DefaultSection.Println("Initial Situation")
-			pterm.Println(sit)
+			ui.Section("Initial Situation")
+			ui.Text(sit)
 			ui.Println()
 		}
 
 		if len(meta.Objectives) > 0 {
-			pterm.DefaultSection.Println("Validation Objectives")
+			ui.Section("Validation Objectives")
 			rows := make([][]string, 0, len(meta.Objectives))
 			for _, o := range meta.Objectives {
 				rows = append(rows, []string{
 					fmt.Sprintf("%d", o.Order),
 					o.Key,
 					o.Title,
-					o.Type,
+					string(o.Type),
 				})
 			}
 			if err := ui.Table([]string{"#", "KEY", "TITLE", "TYPE"}, rows); err != nil {
@@ -103,6 +91,11 @@ It searches for challenge.yaml in the current directory or ../challenges/<slug>/
 			}
 		}
 
+		if specErr != nil {
+			ui.Println()
+			ui.Warning(specErr.Error())
+			ui.Info("Run 'kubeasy dev lint " + challengeSlug + "' for every issue of the file")
+		}
 		return nil
 	},
 }
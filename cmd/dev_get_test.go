@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevGet_InvalidObjective(t *testing.T) {
+	writeTempChallengeYaml(t, "broken-probe", `title: Broken Probe
+type: fix
+theme: monitoring
+difficulty: easy
+estimatedTime: 15
+description: The readiness probe never succeeds.
+objectives:
+  - key: pod-ready
+    title: Pod is ready
+    order: 1
+    type: status
+    spec:
+      target: {kind: Pod, labelSelector: {app: web}}
+      checks: [{field: conditions.Ready, operator: "==", value: "True"}]
+  - key: probe-tuned
+    title: Probe is tuned
+    order: 2
+    type: probe
+    spec: {}
+`)
+	out := captureUI(t)
+	devGetCmd.SetContext(context.Background())
+
+	require.NoError(t, devGetCmd.RunE(devGetCmd, []string{"broken-probe"}), "an invalid objective does not hide the metadata")
+	got := out.String()
+	assert.Contains(t, got, "Broken Probe")
+	assert.Contains(t, got, "Difficulty: easy")
+	assert.Contains(t, got, "Objectives: 2")
+	assert.Contains(t, got, "probe-tuned")
+	assert.Contains(t, got, `unknown type "probe"`)
+	assert.Contains(t, got, "kubeasy dev lint broken-probe")
+}
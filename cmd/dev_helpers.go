@@ -1,50 +1,69 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/notify"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/report"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
-	"github.com/spf13/cobra"
 )
 
 // DevValidateOpts holds options for dev validate runs.
 type DevValidateOpts struct {
 	FailFast   bool
 	JSONOutput bool
+	// Executor is reused across runs when set; otherwise each run creates its own.
+	Executor *validation.Executor
+	// Reports are written after each run (--report).
+	Reports []report.Target
+	// OnResults, when set, receives the results of each run (watch mode --notify).
+	OnResults func(validations []validation.Validation, results []validation.Result)
+}
+
+// notifyResults returns an OnResults sending desktop notifications with notifier. A
+// failure to notify is reported once, then notifications are off.
+func notifyResults(notifier *notify.Notifier) func([]validation.Validation, []validation.Result) {
+	return func(validations []validation.Validation, results []validation.Result) {
+		if err := notifier.Observe(validations, results); err != nil {
+			logger.Debug("Desktop notification failed: %v", err)
+			ui.Warning("Desktop notifications are unavailable on this system: continuing without them")
+		}
+	}
 }
 
 // runDevApply deploys challenge manifests to the Kind cluster.
 // It searches for the challenge in challengeDir or uses FindLocalChallengeFile.
-func runDevApply(cmd *cobra.Command, challengeSlug, challengeDir string, clean bool) error {
+func runDevApply(ctx context.Context, challengeSlug, challengeDir string, clean bool) error {
 	if clean {
 		ui.Info("Cleaning existing resources before apply...")
-		if err := deleteChallengeResources(cmd.Context(), challengeSlug); err != nil {
+		if err := deleteChallengeResources(ctx, challengeSlug); err != nil {
 			ui.Warning(fmt.Sprintf("Clean failed (namespace may not exist yet): %v", err))
 		}
 	}
 
-	clientset, err := kube.GetKubernetesClient()
+	cluster, err := commandContextFrom(ctx).Cluster()
 	if err != nil {
 		ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
-		return fmt.Errorf("failed to get Kubernetes client: %w", err)
+		return err
 	}
-
-	dynamicClient, err := kube.GetDynamicClient()
-	if err != nil {
-		ui.Error("Failed to get dynamic client")
-		return fmt.Errorf("failed to get dynamic client: %w", err)
+	clientset, dynamicClient := cluster.Clientset, cluster.DynamicClient
+	if err := guardKubeasyCluster(ctx, clientset); err != nil {
+		return err
 	}
 
 	err = ui.WaitMessage("Creating namespace", func() error {
-		return kube.CreateNamespace(cmd.Context(), clientset, challengeSlug)
+		return kube.CreateNamespace(ctx, clientset, challengeSlug)
 	})
 	if err != nil {
 		ui.Error("Failed to create namespace")
@@ -66,7 +85,7 @@ func runDevApply(cmd *cobra.Command, challengeSlug, challengeDir string, clean b
 		imageTag := challengeSlug + ":latest"
 		ui.Info(fmt.Sprintf("Detected image/ directory, building '%s'...", imageTag))
 		err = ui.TimedSpinner("Building and loading Docker image", func() error {
-			return deployer.BuildAndLoadImage(cmd.Context(), imageDir, imageTag, constants.KubeasyClusterName)
+			return deployer.BuildAndLoadImage(ctx, imageDir, imageTag, constants.KubeasyClusterName)
 		})
 		if err != nil {
 			ui.Error("Failed to build/load Docker image")
@@ -74,9 +93,13 @@ func runDevApply(cmd *cobra.Command, challengeSlug, challengeDir string, clean b
 		}
 	}
 
+	var results []kube.ApplyResult
 	err = ui.TimedSpinner("Deploying challenge manifests", func() error {
-		return deployer.DeployLocalChallenge(cmd.Context(), clientset, dynamicClient, challengeDir, challengeSlug)
+		var deployErr error
+		results, deployErr = deployer.DeployLocalChallenge(ctx, clientset, dynamicClient, challengeDir, challengeSlug)
+		return deployErr
 	})
+	reportApplyResults(results)
 	if err != nil {
 		ui.Error("Failed to deploy challenge")
 		return fmt.Errorf("failed to deploy challenge: %w", err)
@@ -89,10 +112,98 @@ func runDevApply(cmd *cobra.Command, challengeSlug, challengeDir string, clean b
 	return nil
 }
 
+// runDevDiff previews the changes runDevApply would make to the cluster.
+func runDevDiff(ctx context.Context, challengeSlug, challengeDir string) error {
+	if challengeDir == "" {
+		localPath := validation.FindLocalChallengeFile(challengeSlug)
+		if localPath == "" {
+			return fmt.Errorf("could not find local challenge file for slug %q", challengeSlug)
+		}
+		challengeDir = filepath.Dir(localPath)
+	}
+
+	cluster, err := commandContextFrom(ctx).Cluster()
+	if err != nil {
+		ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
+		return err
+	}
+	clientset, dynamicClient := cluster.Clientset, cluster.DynamicClient
+
+	var results []kube.ApplyResult
+	err = ui.WaitMessage("Comparing manifests with the cluster", func() error {
+		var diffErr error
+		results, diffErr = deployer.DiffLocalChallenge(ctx, clientset, dynamicClient, challengeDir, challengeSlug)
+		return diffErr
+	})
+	if err != nil {
+		ui.Error("Failed to compute the diff")
+		return fmt.Errorf("failed to diff challenge manifests: %w", err)
+	}
+
+	ui.Println()
+	printApplyDiffs(results)
+	reportApplyResults(results)
+	ui.Info("Dry run: nothing was changed")
+	return nil
+}
+
+// printApplyDiffs prints the objects a dry run would create or change, with their diffs.
+func printApplyDiffs(results []kube.ApplyResult) {
+	for _, r := range results {
+		name := fmt.Sprintf("%s/%s", r.GVK.Kind, r.Name)
+		switch r.Action {
+		case kube.ApplyCreated:
+			ui.Text(ui.Colorize(ui.SeveritySuccess, "+ "+name+" would be created"))
+		case kube.ApplyUpdated:
+			ui.Text(ui.Colorize(ui.SeverityWarning, "~ "+name+" would change"))
+		default:
+			continue
+		}
+		printDiff(r.Diff)
+	}
+}
+
+// printDiff prints a unified diff, indented, with added and removed lines colored.
+func printDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		sev := ui.SeverityNone
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			continue
+		case strings.HasPrefix(line, "+"):
+			sev = ui.SeveritySuccess
+		case strings.HasPrefix(line, "-"):
+			sev = ui.SeverityError
+		case strings.HasPrefix(line, "@@"):
+			sev = ui.SeverityMuted
+		}
+		ui.Text("    " + ui.Colorize(sev, line))
+	}
+}
+
+// reportApplyResults summarizes what deploying the manifests did, and names every
+// document that was skipped so authors notice a typo'd kind or a missing CRD.
+func reportApplyResults(results []kube.ApplyResult) {
+	if len(results) == 0 {
+		return
+	}
+	ui.Info(fmt.Sprintf("Manifests: %s", kube.SummarizeApply(results)))
+	for _, r := range results {
+		if r.Action != kube.ApplySkipped {
+			continue
+		}
+		what := fmt.Sprintf("document #%d", r.Document)
+		if r.GVK.Kind != "" {
+			what = fmt.Sprintf("%s/%s", r.GVK.Kind, r.Name)
+		}
+		ui.Warning(fmt.Sprintf("Skipped %s (%s:%d): %v", what, filepath.Base(r.Source), r.Line, r.Err))
+	}
+}
+
 // runDevValidate runs validations against the cluster and displays results.
 // It loads the challenge YAML from local filesystem.
 // Returns true if all validations passed.
-func runDevValidate(cmd *cobra.Command, challengeSlug, challengeDir string, opts DevValidateOpts) (bool, error) {
+func runDevValidate(ctx context.Context, challengeSlug, challengeDir string, opts DevValidateOpts) (bool, error) {
 	var config *validation.ValidationConfig
 
 	loadConfig := func() error {
@@ -108,7 +219,7 @@ func runDevValidate(cmd *cobra.Command, challengeSlug, challengeDir string, opts
 		}
 
 		var err error
-		config, err = validation.LoadFromFile(path)
+		config, err = validation.LoadFromFileStrict(ctx, path)
 		return err
 	}
 
@@ -139,36 +250,21 @@ func runDevValidate(cmd *cobra.Command, challengeSlug, challengeDir string, opts
 		return true, nil
 	}
 
-	// Get Kubernetes clients
-	clientset, err := kube.GetKubernetesClient()
-	if err != nil {
-		if !opts.JSONOutput {
-			ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
+	// Reuse the caller's executor (watch mode) so its object cache stays warm
+	executor := opts.Executor
+	if executor == nil {
+		// Get Kubernetes clients
+		cluster, err := commandContextFrom(ctx).Cluster()
+		if err != nil {
+			if !opts.JSONOutput {
+				ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
+			}
+			return false, err
 		}
-		return false, fmt.Errorf("failed to get Kubernetes client: %w", err)
-	}
 
-	dynamicClient, err := kube.GetDynamicClient()
-	if err != nil {
-		if !opts.JSONOutput {
-			ui.Error("Failed to get dynamic client")
-		}
-		return false, fmt.Errorf("failed to get dynamic client: %w", err)
+		executor = validation.NewExecutor(cluster.Clientset, cluster.DynamicClient, cluster.RestConfig, challengeSlug)
 	}
 
-	restConfig, err := kube.GetRestConfig()
-	if err != nil {
-		if !opts.JSONOutput {
-			ui.Error("Failed to get REST config")
-		}
-		return false, fmt.Errorf("failed to get REST config: %w", err)
-	}
-
-	namespace := challengeSlug
-
-	// Create executor and run validations
-	executor := validation.NewExecutor(clientset, dynamicClient, restConfig, namespace)
-
 	if !opts.JSONOutput {
 		ui.Info("Running validations...")
 		ui.Println()
@@ -177,11 +273,21 @@ func runDevValidate(cmd *cobra.Command, challengeSlug, challengeDir string, opts
 	totalStart := time.Now()
 	var results []validation.Result
 	if opts.FailFast {
-		results = executor.ExecuteSequential(cmd.Context(), config.Validations, true)
+		results = executor.ExecuteSequential(ctx, config.Validations, true)
+	} else if opts.JSONOutput {
+		results = executor.ExecuteAll(ctx, config.Validations)
 	} else {
-		results = executor.ExecuteAll(cmd.Context(), config.Validations)
+		results = executeWithChecklist(ctx, executor, config.Validations)
 	}
 	totalDuration := time.Since(totalStart)
+	if opts.OnResults != nil {
+		opts.OnResults(config.Validations, results)
+	}
+
+	run := report.Run{Name: challengeSlug, Validations: config.Validations, Results: results, Duration: totalDuration}
+	if err := writeReports(opts.Reports, run, opts.JSONOutput); err != nil {
+		return false, err
+	}
 
 	if opts.JSONOutput {
 		out := devutils.FormatValidationJSON(challengeSlug, config.Validations, results, totalDuration)
@@ -202,6 +308,7 @@ func runDevValidate(cmd *cobra.Command, challengeSlug, challengeDir string, opts
 
 	// Display results
 	allPassed := devutils.DisplayValidationResults(config.Validations, results)
+	devutils.DisplaySummary(validation.Summarize(config.Validations, results, totalDuration))
 
 	// Display overall result
 	ui.Section("Validation Result")
@@ -214,3 +321,55 @@ func runDevValidate(cmd *cobra.Command, challengeSlug, challengeDir string, opts
 
 	return allPassed, nil
 }
+
+// applySolutionOverlay applies the solution manifests in solutionDir to the challenge
+// namespace and waits for workloads to be ready. The returned function rolls the
+// overlay back so the learner-facing broken state is left untouched.
+func applySolutionOverlay(ctx context.Context, challengeSlug, solutionDir string, opts DevValidateOpts) (func(), error) {
+	if !isDir(solutionDir) {
+		return nil, fmt.Errorf("solution directory %q not found", solutionDir)
+	}
+
+	cluster, err := commandContextFrom(ctx).Cluster()
+	if err != nil {
+		return nil, err
+	}
+	clientset, dynamicClient := cluster.Clientset, cluster.DynamicClient
+
+	var rollback func(context.Context) error
+	apply := func() error {
+		var err error
+		rollback, err = deployer.ApplySolutionOverlay(ctx, clientset, dynamicClient, solutionDir, challengeSlug)
+		if err != nil {
+			return err
+		}
+		return deployer.WaitForChallengeReady(ctx, clientset, challengeSlug)
+	}
+	if opts.JSONOutput {
+		err = apply()
+	} else {
+		err = ui.TimedSpinner("Applying solution overlay", apply)
+	}
+	if err != nil {
+		if rollback != nil {
+			_ = rollback(context.WithoutCancel(ctx))
+		}
+		if !opts.JSONOutput {
+			ui.Error("Failed to apply solution overlay")
+		}
+		return nil, fmt.Errorf("failed to apply solution overlay: %w", err)
+	}
+
+	return func() {
+		if err := rollback(context.WithoutCancel(ctx)); err != nil {
+			logger.Warning("Failed to roll back solution overlay for %s: %v", challengeSlug, err)
+			if !opts.JSONOutput {
+				ui.Warning(fmt.Sprintf("Failed to roll back solution overlay: %v", err))
+			}
+			return
+		}
+		if !opts.JSONOutput {
+			ui.Info("Solution overlay rolled back")
+		}
+	}, nil
+}
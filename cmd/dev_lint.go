@@ -53,7 +53,7 @@ or ../challenges/<slug>/. If no slug is given, it lints the current directory.`,
 		ui.Info(fmt.Sprintf("File: %s", absPath))
 		ui.Println()
 
-		issues, err = devutils.LintChallengeFile(challengeYAML)
+		issues, err = devutils.LintChallengeFile(cmd.Context(), challengeYAML)
 		if err != nil {
 			ui.Error(fmt.Sprintf("Failed to lint: %v", err))
 			return err
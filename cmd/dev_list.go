@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var devListDir string
+
+var devListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List local challenges and their metadata",
+	Long: `Lists every challenge found in a local challenges directory, using the
+metadata (title, type, difficulty, estimated time) declared in each challenge.yaml.
+No cluster or Kubeasy API required.
+
+The directory defaults to $KUBEASY_LOCAL_CHALLENGES_DIR, or the current directory
+when the variable is not set. Use --dir to specify another directory.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := devListDir
+		if dir == "" {
+			dir = os.Getenv("KUBEASY_LOCAL_CHALLENGES_DIR")
+		}
+		if dir == "" {
+			dir = "."
+		}
+
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+
+		ui.Section("Local Challenges")
+		ui.Info(fmt.Sprintf("Directory: %s", absDir))
+		ui.Println()
+
+		specs, err := validation.ListLocalChallenges(cmd.Context(), absDir)
+		if err != nil {
+			if specs == nil {
+				ui.Error("Failed to list local challenges")
+				return err
+			}
+			logger.Debug("Some local challenges could not be parsed: %v", err)
+			ui.Warning(fmt.Sprintf("Some challenges could not be parsed: %v", err))
+		}
+
+		if len(specs) == 0 {
+			ui.Info("No challenges found")
+			return nil
+		}
+
+		rows := make([][]string, 0, len(specs))
+		for _, s := range specs {
+			rows = append(rows, []string{
+				s.Slug,
+				s.Title,
+				s.Type,
+				s.Difficulty,
+				fmt.Sprintf("%d min", s.EstimatedTime),
+				fmt.Sprintf("%d", len(s.Validations)),
+			})
+		}
+		if err := ui.Table([]string{"SLUG", "TITLE", "TYPE", "DIFFICULTY", "TIME", "OBJECTIVES"}, rows); err != nil {
+			return fmt.Errorf("failed to render table: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	devCmd.AddCommand(devListCmd)
+	devListCmd.Flags().StringVar(&devListDir, "dir", "", "Directory containing challenge folders")
+}
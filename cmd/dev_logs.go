@@ -9,10 +9,8 @@ import (
 	"sync"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,14 +44,13 @@ Use --tail to control how many recent lines to show (default: 50).`,
 			return err
 		}
 
-		clientset, err := kube.GetKubernetesClient()
+		ctx := cmd.Context()
+		clientset, err := commandContextFrom(ctx).Clientset()
 		if err != nil {
 			ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
-			return fmt.Errorf("failed to get Kubernetes client: %w", err)
+			return err
 		}
 
-		ctx := cmd.Context()
-
 		// Try to find pods from challenge.yaml label selectors
 		pods, err := findChallengePods(ctx, clientset, challengeSlug, devLogsDir)
 		if err != nil {
@@ -79,7 +76,7 @@ Use --tail to control how many recent lines to show (default: 50).`,
 			go func(podName string) {
 				defer wg.Done()
 				if err := streamPodLogsWithPrefix(ctx, clientset, challengeSlug, podName, devLogsContainer, devLogsTail, devLogsFollow); err != nil {
-					pterm.Error.Printf("[%s] %v\n", podName, err)
+					ui.Error(fmt.Sprintf("[%s] %v", podName, err))
 				}
 			}(pod.Name)
 		}
@@ -94,7 +91,7 @@ func findChallengePods(ctx context.Context, clientset kubernetes.Interface, slug
 	challengeDir, dirErr := devutils.ResolveLocalChallengeDir(slug, dirFlag)
 	if dirErr == nil {
 		challengeYAML := filepath.Join(challengeDir, "challenge.yaml")
-		config, parseErr := validation.LoadFromFile(challengeYAML)
+		config, parseErr := validation.LoadFromFile(ctx, challengeYAML)
 		if parseErr == nil && len(config.Validations) > 0 {
 			selectors := extractTargetSelectors(config.Validations)
 			if len(selectors) > 0 {
@@ -196,7 +193,7 @@ func streamPodLogsWithPrefix(ctx context.Context, clientset kubernetes.Interface
 	defer func() { _ = stream.Close() }()
 
 	reader := bufio.NewReader(stream)
-	prefix := pterm.LightCyan(fmt.Sprintf("[%s] ", podName))
+	prefix := ui.Colorize(ui.SeverityInfo, fmt.Sprintf("[%s] ", podName))
 	for {
 		line, err := reader.ReadString('\n')
 		if len(line) > 0 {
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
 	"github.com/spf13/cobra"
@@ -30,14 +29,13 @@ Requires the challenge to be deployed in the Kind cluster.`,
 			return err
 		}
 
-		clientset, err := kube.GetKubernetesClient()
+		ctx := cmd.Context()
+		clientset, err := commandContextFrom(ctx).Clientset()
 		if err != nil {
 			ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
-			return fmt.Errorf("failed to get Kubernetes client: %w", err)
+			return err
 		}
 
-		ctx := cmd.Context()
-
 		ui.Section(fmt.Sprintf("Challenge Status: %s", challengeSlug))
 
 		// Check namespace exists
@@ -130,7 +128,7 @@ Requires the challenge to be deployed in the Kind cluster.`,
 		challengeDir, dirErr := devutils.ResolveLocalChallengeDir(challengeSlug, devStatusDir)
 		if dirErr == nil {
 			challengeYAML := filepath.Join(challengeDir, "challenge.yaml")
-			config, parseErr := validation.LoadFromFile(challengeYAML)
+			config, parseErr := validation.LoadFromFile(ctx, challengeYAML)
 			if parseErr == nil {
 				ui.Println()
 				ui.Info(fmt.Sprintf("Objectives defined: %d", len(config.Validations)))
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/notify"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +17,7 @@ var (
 	devTestClean         bool
 	devTestWatch         bool
 	devTestWatchInterval time.Duration
+	devTestNotify        bool
 	devTestFailFast      bool
 	devTestJSON          bool
 )
@@ -28,6 +32,7 @@ It searches for challenge.yaml in the current directory or ../challenges/<slug>/
 Use --dir to specify a custom directory.
 Use --clean to delete existing resources before applying.
 Use --watch to continuously re-run validations at the given interval after the initial apply (see --watch-interval).
+Use --notify with --watch for a desktop notification when an objective changes state.
 Use --fail-fast to stop at the first validation failure.
 Use --json for structured JSON output (useful for CI).`,
 	Args:          cobra.ExactArgs(1),
@@ -66,7 +71,7 @@ Use --json for structured JSON output (useful for CI).`,
 			ui.Info(fmt.Sprintf("Using local directory: %s", challengeDir))
 		}
 
-		if err := runDevApply(cmd, challengeSlug, challengeDir, devTestClean); err != nil {
+		if err := runDevApply(cmd.Context(), challengeSlug, challengeDir, devTestClean); err != nil {
 			return err
 		}
 
@@ -82,21 +87,35 @@ Use --json for structured JSON output (useful for CI).`,
 		if devTestWatch && devTestWatchInterval <= 0 {
 			return fmt.Errorf("--watch-interval must be a positive duration (e.g. 5s, 1m)")
 		}
+		if devTestNotify && !devTestWatch {
+			return fmt.Errorf("--notify requires --watch")
+		}
 
 		if devTestWatch {
+			executor, err := newLocalExecutor(challengeSlug)
+			if err != nil {
+				return err
+			}
+			executor.EnableCache()
+			defer executor.Close()
+			opts.Executor = executor
+			if devTestNotify {
+				opts.OnResults = notifyResults(notify.New(challengeSlug))
+			}
+
 			header := fmt.Sprintf("Validating Dev Challenge: %s (watch mode)", challengeSlug)
-			return devutils.TickerWatchLoop(cmd.Context(), devTestWatchInterval, header, func() {
-				runDevValidate(cmd, challengeSlug, challengeDir, opts) //nolint:errcheck
+			return devutils.TickerWatchLoop(cmd.Context(), devTestWatchInterval, header, func(ctx context.Context) {
+				runDevValidate(ctx, challengeSlug, challengeDir, opts) //nolint:errcheck
 			})
 		}
 
-		allPassed, err := runDevValidate(cmd, challengeSlug, challengeDir, opts)
+		allPassed, err := runDevValidate(cmd.Context(), challengeSlug, challengeDir, opts)
 		if err != nil {
 			return err
 		}
 
 		if !allPassed {
-			return fmt.Errorf("some validations failed")
+			return kerrors.New(kerrors.CodeValidationFailed, "some validations failed")
 		}
 
 		return nil
@@ -109,6 +128,7 @@ func init() {
 	devTestCmd.Flags().BoolVar(&devTestClean, "clean", false, "Delete existing resources before applying")
 	devTestCmd.Flags().BoolVarP(&devTestWatch, "watch", "w", false, "Continuously re-run validations at the given interval after apply (see --watch-interval)")
 	devTestCmd.Flags().DurationVarP(&devTestWatchInterval, "watch-interval", "i", 5*time.Second, "Interval between watch re-runs (e.g. 10s, 1m)")
+	devTestCmd.Flags().BoolVar(&devTestNotify, "notify", false, "With --watch, show desktop notifications when objectives change state")
 	devTestCmd.Flags().BoolVar(&devTestFailFast, "fail-fast", false, "Stop at the first validation failure")
 	devTestCmd.Flags().BoolVar(&devTestJSON, "json", false, "Output results as JSON")
 }
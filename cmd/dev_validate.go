@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/notify"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/report"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -13,8 +17,13 @@ var (
 	devValidateDir           string
 	devValidateWatch         bool
 	devValidateWatchInterval time.Duration
+	devValidateNotify        bool
 	devValidateFailFast      bool
 	devValidateJSON          bool
+	devValidateSolution      string
+	devValidateReports       []string
+	devValidateRecord        string
+	devValidateReplay        string
 )
 
 var devValidateCmd = &cobra.Command{
@@ -27,16 +36,30 @@ results to the Kubeasy API. No login required.
 It searches for challenge.yaml in the current directory or ../challenges/<slug>/.
 Use --dir to specify a custom directory.
 Use --watch to continuously re-run validations at the given interval.
+Use --notify with --watch for a desktop notification when an objective changes state.
 Use --fail-fast to stop at the first validation failure.
-Use --json for structured JSON output (useful for CI).`,
+Use --json for structured JSON output (useful for CI).
+Use --report junit=path or --report sarif=path to also write a report file.
+
+Use --record <dir> to save every cluster response the validations read (objects,
+logs, events) to <dir>/recording.json, and --replay <dir> to run the validations
+against such a recording offline, e.g. to reproduce a failure reported from another
+machine ('kubeasy challenge submit --record' records a learner's run). Checks that
+exec into pods cannot be replayed. A recording holds whatever the validations read,
+Secrets included: review it before sharing it.`,
 	Args:          cobra.ExactArgs(1),
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		challengeSlug := args[0]
 
+		reports, err := report.ParseTargets(devValidateReports)
+		if err != nil {
+			return err
+		}
 		opts := DevValidateOpts{
 			FailFast:   devValidateFailFast,
 			JSONOutput: devValidateJSON,
+			Reports:    reports,
 		}
 
 		if !opts.JSONOutput {
@@ -65,21 +88,75 @@ Use --json for structured JSON output (useful for CI).`,
 		if devValidateWatch && devValidateWatchInterval <= 0 {
 			return fmt.Errorf("--watch-interval must be a positive duration (e.g. 5s, 1m)")
 		}
+		if devValidateNotify && !devValidateWatch {
+			return fmt.Errorf("--notify requires --watch")
+		}
+
+		if devValidateRecord != "" && devValidateReplay != "" {
+			return fmt.Errorf("--record and --replay cannot be combined")
+		}
+		if (devValidateRecord != "" || devValidateReplay != "") && devValidateWatch {
+			return fmt.Errorf("--record and --replay cannot be combined with --watch")
+		}
+		if devValidateReplay != "" && devValidateSolution != "" {
+			return fmt.Errorf("--replay cannot be combined with --solution")
+		}
+		cc := commandContextFrom(cmd.Context())
+		setCommandContext(cmd, cc)
+		if devValidateReplay != "" {
+			recording, err := cc.replayCluster(devValidateReplay)
+			if err != nil {
+				return err
+			}
+			if !opts.JSONOutput {
+				ui.Info(fmt.Sprintf("Replaying %d cluster responses recorded on %s by kubeasy %s",
+					len(recording.Exchanges), recording.RecordedAt.Local().Format(time.DateTime), recording.CLIVersion))
+				if recording.Namespace != challengeSlug {
+					ui.Warning(fmt.Sprintf("The recording is of namespace %q, not %q", recording.Namespace, challengeSlug))
+				}
+			}
+		}
+		if devValidateRecord != "" {
+			recorder := cc.recordCluster(challengeSlug)
+			defer saveRecording(recorder, devValidateRecord, opts.JSONOutput)
+		}
+
+		if devValidateSolution != "" {
+			if devValidateWatch {
+				return fmt.Errorf("--solution cannot be combined with --watch")
+			}
+			rollback, err := applySolutionOverlay(cmd.Context(), challengeSlug, devValidateSolution, opts)
+			if err != nil {
+				return err
+			}
+			defer rollback()
+		}
 
 		if devValidateWatch {
+			executor, err := newLocalExecutor(challengeSlug)
+			if err != nil {
+				return err
+			}
+			executor.EnableCache()
+			defer executor.Close()
+			opts.Executor = executor
+			if devValidateNotify {
+				opts.OnResults = notifyResults(notify.New(challengeSlug))
+			}
+
 			header := fmt.Sprintf("Validating Dev Challenge: %s (watch mode)", challengeSlug)
-			return devutils.TickerWatchLoop(cmd.Context(), devValidateWatchInterval, header, func() {
-				runDevValidate(cmd, challengeSlug, challengeDir, opts) //nolint:errcheck
+			return devutils.TickerWatchLoop(cmd.Context(), devValidateWatchInterval, header, func(ctx context.Context) {
+				runDevValidate(ctx, challengeSlug, challengeDir, opts) //nolint:errcheck
 			})
 		}
 
-		allPassed, err := runDevValidate(cmd, challengeSlug, challengeDir, opts)
+		allPassed, err := runDevValidate(cmd.Context(), challengeSlug, challengeDir, opts)
 		if err != nil {
 			return err
 		}
 
 		if !allPassed {
-			return fmt.Errorf("some validations failed")
+			return kerrors.New(kerrors.CodeValidationFailed, "some validations failed")
 		}
 
 		return nil
@@ -91,6 +168,13 @@ func init() {
 	devValidateCmd.Flags().StringVar(&devValidateDir, "dir", "", "Read from local directory")
 	devValidateCmd.Flags().BoolVarP(&devValidateWatch, "watch", "w", false, "Continuously re-run validations at the given interval (see --watch-interval)")
 	devValidateCmd.Flags().DurationVarP(&devValidateWatchInterval, "watch-interval", "i", 5*time.Second, "Interval between watch re-runs (e.g. 10s, 1m)")
+	devValidateCmd.Flags().BoolVar(&devValidateNotify, "notify", false, "With --watch, show desktop notifications when objectives change state")
 	devValidateCmd.Flags().BoolVar(&devValidateFailFast, "fail-fast", false, "Stop at the first validation failure")
 	devValidateCmd.Flags().BoolVar(&devValidateJSON, "json", false, "Output results as JSON")
+	devValidateCmd.Flags().StringArrayVar(&devValidateReports, "report", nil, "Write a report file as format=path, format being junit or sarif (repeatable)")
+	devValidateCmd.Flags().StringVar(&devValidateRecord, "record", "", "Save the cluster responses read by the validations to this directory")
+	devValidateCmd.Flags().StringVar(&devValidateReplay, "replay", "", "Run the validations against the recording in this directory instead of the cluster")
+	// Instructor-only: verify a challenge by applying its solution, then rolling it back.
+	devValidateCmd.Flags().StringVar(&devValidateSolution, "solution", "", "Apply the solution manifests in this directory before validating, then roll them back")
+	_ = devValidateCmd.Flags().MarkHidden("solution")
 }
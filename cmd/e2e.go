@@ -0,0 +1,524 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/fakecluster"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/spf13/cobra"
+	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// e2eReportVersion is bumped when the format of the e2e report changes.
+const e2eReportVersion = 1
+
+// e2eScriptOutputLimit is how much of the output of a break or fix script the report
+// keeps, from its end.
+const e2eScriptOutputLimit = 4096
+
+var (
+	e2eDir         string
+	e2eBreak       string
+	e2eFix         string
+	e2eReportPath  string
+	e2eClusterName string
+	e2eTimeout     time.Duration
+	e2eKeep        bool
+)
+
+var e2eCmd = &cobra.Command{
+	Use:   "e2e [challenge-slug]",
+	Short: "Run the whole challenge flow against a disposable kind cluster",
+	Long: `Runs a local challenge end to end and writes a JSON report:
+
+  setup    create the kind cluster --cluster-name and install the components
+  start    start the challenge against a mock API, deploying its manifests
+  break    run the --break script, if any
+  verify   check that the objectives fail before the fix
+  fix      run the --fix script, or apply the solution/ directory
+  verify   check that the objectives pass, retrying until --timeout
+  reset    reset the challenge
+  teardown delete the cluster (kept with --keep)
+
+The run uses a scratch home directory: the kubeconfig, the local state and the
+kubeasy cluster of the user are never touched, and no login is needed. Scripts run
+in the challenge directory with KUBECONFIG, KUBEASY_E2E_CONTEXT,
+KUBEASY_E2E_NAMESPACE and KUBEASY_E2E_CHALLENGE_DIR set.
+
+The command fails when a step fails; the report tells which.`,
+	Hidden:        true,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slug := args[0]
+		if err := validateChallengeSlug(slug); err != nil {
+			return err
+		}
+		dir, err := devutils.ResolveLocalChallengeDir(slug, e2eDir)
+		if err != nil {
+			return err
+		}
+		breakScript, err := absScript(e2eBreak)
+		if err != nil {
+			return err
+		}
+		fixScript, err := absScript(e2eFix)
+		if err != nil {
+			return err
+		}
+		if fixScript == "" {
+			if _, err := os.Stat(filepath.Join(dir, deployer.SolutionDirName)); err != nil {
+				return fmt.Errorf("no --fix script and no %s/ directory in %s", deployer.SolutionDirName, dir)
+			}
+		}
+
+		reportPath := e2eReportPath
+		if reportPath != "-" {
+			if reportPath, err = filepath.Abs(reportPath); err != nil {
+				return err
+			}
+		}
+
+		restore, err := useE2EEnvironment(e2eClusterName, e2eKeep)
+		if err != nil {
+			return err
+		}
+		defer restore()
+
+		cc := commandContextFrom(cmd.Context())
+		h := &e2eHarness{
+			slug:        slug,
+			dir:         dir,
+			breakScript: breakScript,
+			fixScript:   fixScript,
+			timeout:     e2eTimeout,
+			keep:        e2eKeep,
+			cc:          cc,
+		}
+		report := runE2E(cmd.Context(), slug, h.plan())
+		if err := writeE2EReport(cmd.OutOrStdout(), reportPath, report); err != nil {
+			return err
+		}
+		if !report.Passed {
+			return fmt.Errorf("e2e run of %s failed", slug)
+		}
+		return nil
+	},
+}
+
+// e2eReport is the machine-readable outcome of 'kubeasy e2e'.
+type e2eReport struct {
+	Version    int             `json:"version"`
+	Slug       string          `json:"slug"`
+	CLIVersion string          `json:"cliVersion"`
+	StartedAt  time.Time       `json:"startedAt"`
+	DurationMS int64           `json:"durationMs"`
+	Passed     bool            `json:"passed"`
+	Steps      []e2eStepResult `json:"steps"`
+}
+
+// e2eStepResult is the outcome of one step of an e2e run.
+type e2eStepResult struct {
+	Name string `json:"name"`
+	// Status is "passed", "failed" or "skipped".
+	Status     string `json:"status"`
+	DurationMS int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+	// Output is the end of the output of a script.
+	Output     string         `json:"output,omitempty"`
+	Objectives []e2eObjective `json:"objectives,omitempty"`
+}
+
+// e2eObjective is the result of an objective at a verify step.
+type e2eObjective struct {
+	Key     string `json:"key"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// e2eStep is a step of an e2e run. Steps after a failed one are skipped, except the
+// Always ones, which clean up.
+type e2eStep struct {
+	Name   string
+	Always bool
+	Run    func(ctx context.Context, result *e2eStepResult) error
+}
+
+// runE2E runs steps in order and reports their outcome.
+func runE2E(ctx context.Context, slug string, steps []e2eStep) e2eReport {
+	report := e2eReport{
+		Version:    e2eReportVersion,
+		Slug:       slug,
+		CLIVersion: constants.Version,
+		StartedAt:  time.Now().UTC(),
+		Passed:     true,
+	}
+	for _, step := range steps {
+		result := e2eStepResult{Name: step.Name, Status: "skipped"}
+		if report.Passed || step.Always {
+			ui.Section(fmt.Sprintf("e2e: %s", step.Name))
+			start := time.Now()
+			err := step.Run(ctx, &result)
+			result.DurationMS = time.Since(start).Milliseconds()
+			result.Status = "passed"
+			if err != nil {
+				result.Status, result.Error = "failed", err.Error()
+				report.Passed = false
+				ui.Error(fmt.Sprintf("%s failed: %v", step.Name, err))
+			} else {
+				ui.Success(fmt.Sprintf("%s passed", step.Name))
+			}
+		}
+		report.Steps = append(report.Steps, result)
+	}
+	report.DurationMS = time.Since(report.StartedAt).Milliseconds()
+	return report
+}
+
+// writeE2EReport writes report as JSON to path, or to out when path is "-".
+func writeE2EReport(out io.Writer, path string, report e2eReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the e2e report: %w", err)
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err := out.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec // the report holds no secret
+		return fmt.Errorf("failed to write the e2e report: %w", err)
+	}
+	ui.Info(fmt.Sprintf("Report written to %s", path))
+	return nil
+}
+
+// e2eHarness builds the steps of an e2e run of a local challenge.
+type e2eHarness struct {
+	slug        string
+	dir         string
+	breakScript string
+	fixScript   string
+	timeout     time.Duration
+	keep        bool
+	cc          *commandContext
+
+	createdCluster bool
+	client         *sdk.Client
+}
+
+func (h *e2eHarness) plan() []e2eStep {
+	plan := []e2eStep{
+		{Name: "setup", Run: h.setup},
+		{Name: "start", Run: h.start},
+	}
+	if h.breakScript != "" {
+		plan = append(plan, e2eStep{Name: "break", Run: h.script(h.breakScript)})
+	}
+	fix := h.applySolution
+	if h.fixScript != "" {
+		fix = h.script(h.fixScript)
+	}
+	return append(plan,
+		e2eStep{Name: "verify-broken", Run: h.verify(false)},
+		e2eStep{Name: "fix", Run: fix},
+		e2eStep{Name: "verify-fixed", Run: h.verify(true)},
+		e2eStep{Name: "reset", Always: true, Run: h.reset},
+		e2eStep{Name: "teardown", Always: true, Run: h.teardown},
+	)
+}
+
+// setup creates the cluster and installs the components, like 'kubeasy setup' does.
+// An existing cluster of that name is reused and kept.
+func (h *e2eHarness) setup(ctx context.Context, _ *e2eStepResult) error {
+	clusters, err := cluster.NewProvider().List()
+	if err != nil {
+		return fmt.Errorf("failed to list kind clusters: %w", err)
+	}
+	exists := false
+	for _, name := range clusters {
+		exists = exists || name == constants.KubeasyClusterName
+	}
+	if exists {
+		ui.Warning(fmt.Sprintf("Reusing the existing kind cluster '%s': it will not be deleted", constants.KubeasyClusterName))
+		if err := cluster.NewProvider().ExportKubeConfig(constants.KubeasyClusterName, kube.GetKubeConfigPath(), false); err != nil {
+			return fmt.Errorf("failed to export the kubeconfig of kind cluster '%s': %w", constants.KubeasyClusterName, err)
+		}
+	} else {
+		err := ui.TimedSpinner(fmt.Sprintf("Creating kind cluster '%s'", constants.KubeasyClusterName), func() error {
+			return cluster.NewProvider().Create(
+				constants.KubeasyClusterName,
+				cluster.CreateWithV1Alpha4Config(e2eKindConfig()),
+				cluster.CreateWithNodeImage(constants.KindNodeImage),
+				cluster.CreateWithKubeconfigPath(kube.GetKubeConfigPath()),
+			)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create kind cluster: %w", err)
+		}
+		h.createdCluster = true
+	}
+	return installComponents(ctx)
+}
+
+// e2eKindConfig is the kind config of 'kubeasy setup' without the host port mappings,
+// which would clash with the cluster of the user, nor the audit log, which is shared
+// with it.
+func e2eKindConfig() *kindv1alpha4.Cluster {
+	cfg := kindClusterConfig()
+	for i := range cfg.Nodes {
+		cfg.Nodes[i].ExtraPortMappings = nil
+		cfg.Nodes[i].KubeadmConfigPatches = nil
+		var mounts []kindv1alpha4.Mount
+		for _, m := range cfg.Nodes[i].ExtraMounts {
+			if m.ContainerPath == deployer.ContainerdHostsPath {
+				mounts = append(mounts, m)
+			}
+		}
+		cfg.Nodes[i].ExtraMounts = mounts
+	}
+	return cfg
+}
+
+// start starts the challenge against a mock API, deploying the manifests of the local
+// challenge directory.
+func (h *e2eHarness) start(ctx context.Context, _ *e2eStepResult) error {
+	if err := os.MkdirAll(constants.GetContainerdHostsDir(), 0o750); err != nil {
+		return err
+	}
+	title := h.slug
+	if spec, err := validation.LoadChallengeSpecFromFile(ctx, filepath.Join(h.dir, "challenge.yaml")); err == nil && spec.Title != "" {
+		title = spec.Title
+	}
+	mockAPI := fakecluster.NewAPI(&fakecluster.Scenario{
+		Challenge: api.ChallengeEntity{Slug: h.slug, Title: title},
+		Status:    "not_started",
+	})
+	h.client = sdk.New(sdk.Config{
+		API: sdk.API{
+			GetChallenge:    mockAPI.GetChallenge,
+			GetProgress:     mockAPI.GetProgress,
+			StartChallenge:  mockAPI.StartChallenge,
+			SubmitChallenge: mockAPI.SubmitChallenge,
+			ResetChallenge:  mockAPI.ResetChallenge,
+			UserID:          mockAPI.UserID,
+		},
+		Connect: h.cc.Cluster,
+		LoadValidations: func(ctx context.Context, slug, userID string) (*sdk.ValidationConfig, error) {
+			return validation.LoadFromFile(ctx, filepath.Join(h.dir, "challenge.yaml"))
+		},
+		GuardCluster: guardKubeasyCluster,
+		Deploy:       h.deploy,
+		Reporter:     sdk.Reporter{Task: ui.WaitMessage, Info: ui.Info, Warn: ui.Warning},
+	})
+	_, err := h.client.Start(ctx, h.slug, sdk.StartOptions{})
+	return err
+}
+
+func (h *e2eHarness) deploy(ctx context.Context, cluster *sdk.Cluster, slug string) (string, error) {
+	if deployer.HasImageDir(h.dir) {
+		if err := deployer.BuildAndLoadImage(ctx, filepath.Join(h.dir, "image"), slug+":latest", constants.KubeasyClusterName); err != nil {
+			return "", fmt.Errorf("failed to build/load Docker image: %w", err)
+		}
+	}
+	results, err := deployer.DeployLocalChallenge(ctx, cluster.Clientset, cluster.DynamicClient, h.dir, slug)
+	reportApplyResults(results)
+	return "", err
+}
+
+// script returns a step running path in the challenge directory.
+func (h *e2eHarness) script(path string) func(ctx context.Context, result *e2eStepResult) error {
+	return func(ctx context.Context, result *e2eStepResult) error {
+		output, err := runE2EScript(ctx, path, h.dir, []string{
+			"KUBECONFIG=" + kube.GetKubeConfigPath(),
+			"KUBEASY_E2E_CONTEXT=" + constants.KubeasyClusterContext,
+			"KUBEASY_E2E_NAMESPACE=" + h.slug,
+			"KUBEASY_E2E_CHALLENGE_DIR=" + h.dir,
+		})
+		result.Output = output
+		return err
+	}
+}
+
+// runE2EScript runs path in dir with env added to the environment, streaming its
+// output to stderr, and returns the end of that output.
+func runE2EScript(ctx context.Context, path, dir string, env []string) (string, error) {
+	var output bytes.Buffer
+	c := exec.CommandContext(ctx, path) //nolint:gosec // the script is given by the user
+	c.Dir = dir
+	c.Env = append(os.Environ(), env...)
+	c.Stdout = io.MultiWriter(os.Stderr, &output)
+	c.Stderr = c.Stdout
+	err := c.Run()
+	out := output.String()
+	if len(out) > e2eScriptOutputLimit {
+		out = "..." + out[len(out)-e2eScriptOutputLimit:]
+	}
+	if err != nil {
+		return out, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return out, nil
+}
+
+func (h *e2eHarness) applySolution(ctx context.Context, _ *e2eStepResult) error {
+	cluster, err := h.cc.Cluster()
+	if err != nil {
+		return err
+	}
+	return deployer.ApplySolution(ctx, cluster.Clientset, cluster.DynamicClient, filepath.Join(h.dir, deployer.SolutionDirName), h.slug)
+}
+
+// verify returns a step checking that the objectives pass, retrying until the
+// timeout, or that they fail, once.
+func (h *e2eHarness) verify(wantPass bool) func(ctx context.Context, result *e2eStepResult) error {
+	return func(ctx context.Context, result *e2eStepResult) error {
+		return verifyE2E(ctx, func(ctx context.Context) (*sdk.Verification, error) {
+			return h.client.Verify(ctx, h.slug, sdk.VerifyOptions{})
+		}, wantPass, h.timeout, result)
+	}
+}
+
+// e2eVerifyInterval is how often verifyE2E runs the validations while waiting for them
+// to pass.
+var e2eVerifyInterval = 5 * time.Second
+
+func verifyE2E(ctx context.Context, verify func(context.Context) (*sdk.Verification, error), wantPass bool, timeout time.Duration, result *e2eStepResult) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		v, err := verify(ctx)
+		if err != nil {
+			return err
+		}
+		result.Objectives = result.Objectives[:0]
+		for _, r := range v.Results {
+			result.Objectives = append(result.Objectives, e2eObjective{Key: r.Key, Passed: r.Passed, Message: r.Message})
+		}
+		switch {
+		case !wantPass && v.Passed():
+			return errors.New("the objectives pass before the fix: the challenge is not broken")
+		case !wantPass:
+			return nil
+		case v.Passed():
+			return nil
+		case time.Now().After(deadline):
+			return fmt.Errorf("still failing after %s: %s", timeout, strings.Join(v.Failing(), ", "))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(e2eVerifyInterval):
+		}
+	}
+}
+
+func (h *e2eHarness) reset(ctx context.Context, _ *e2eStepResult) error {
+	if h.client == nil {
+		return nil
+	}
+	return h.client.Reset(ctx, h.slug)
+}
+
+func (h *e2eHarness) teardown(ctx context.Context, _ *e2eStepResult) error {
+	if !h.createdCluster || h.keep {
+		return nil
+	}
+	return ui.TimedSpinner(fmt.Sprintf("Deleting kind cluster '%s'", constants.KubeasyClusterName), func() error {
+		return cluster.NewProvider().Delete(constants.KubeasyClusterName, kube.GetKubeConfigPath())
+	})
+}
+
+// useE2EEnvironment points the rest of the process at the kind cluster clusterName
+// and a scratch home directory, keeping the Docker configuration of the user. The
+// returned function restores the environment and, unless keep, removes the scratch
+// directory.
+func useE2EEnvironment(clusterName string, keep bool) (func(), error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	scratch, err := os.MkdirTemp("", "kubeasy-e2e-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the e2e home: %w", err)
+	}
+	kubeconfig := filepath.Join(scratch, ".kube", "config")
+	env := map[string]string{"HOME": scratch, "KUBECONFIG": kubeconfig}
+	if os.Getenv("DOCKER_CONFIG") == "" {
+		// Docker contexts (Colima, Rancher Desktop...) are configured in ~/.docker
+		env["DOCKER_CONFIG"] = filepath.Join(home, ".docker")
+	}
+	previous := map[string]*string{}
+	for key, value := range env {
+		if old, ok := os.LookupEnv(key); ok {
+			previous[key] = &old
+		} else {
+			previous[key] = nil
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return nil, err
+		}
+	}
+	clusterNameBefore, contextBefore := constants.KubeasyClusterName, constants.KubeasyClusterContext
+	constants.KubeasyClusterName, constants.KubeasyClusterContext = clusterName, "kind-"+clusterName
+	kube.UseKubeconfig(kubeconfig)
+	ui.Info(fmt.Sprintf("Using scratch home %s", scratch))
+
+	return func() {
+		kube.UseKubeconfig("")
+		constants.KubeasyClusterName, constants.KubeasyClusterContext = clusterNameBefore, contextBefore
+		for key, old := range previous {
+			if old == nil {
+				_ = os.Unsetenv(key)
+			} else {
+				_ = os.Setenv(key, *old)
+			}
+		}
+		if !keep {
+			_ = os.RemoveAll(scratch)
+		}
+	}, nil
+}
+
+// absScript returns the absolute path of a script flag, "" when unset.
+func absScript(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return "", fmt.Errorf("script %s: %w", path, err)
+	}
+	return abs, nil
+}
+
+func init() {
+	rootCmd.AddCommand(e2eCmd)
+	e2eCmd.Flags().StringVar(&e2eDir, "dir", "", "Challenge directory (default ./<slug> or the current directory)")
+	e2eCmd.Flags().StringVar(&e2eBreak, "break", "", "Script run after the start to break the challenge further")
+	e2eCmd.Flags().StringVar(&e2eFix, "fix", "", "Script that fixes the challenge (default: apply the solution/ directory)")
+	e2eCmd.Flags().StringVar(&e2eReportPath, "report", "kubeasy-e2e-report.json", "Where to write the JSON report ('-' for stdout)")
+	e2eCmd.Flags().StringVar(&e2eClusterName, "cluster-name", "kubeasy-e2e", "Name of the disposable kind cluster")
+	e2eCmd.Flags().DurationVar(&e2eTimeout, "timeout", 3*time.Minute, "How long to wait for the objectives to pass after the fix")
+	e2eCmd.Flags().BoolVar(&e2eKeep, "keep", false, "Keep the cluster and the scratch home for debugging")
+}
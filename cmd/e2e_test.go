@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunE2E_SkipsAfterFailureButCleansUp(t *testing.T) {
+	var ran []string
+	step := func(name string, err error) func(context.Context, *e2eStepResult) error {
+		return func(context.Context, *e2eStepResult) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+	report := runE2E(context.Background(), "pod-evicted", []e2eStep{
+		{Name: "setup", Run: step("setup", nil)},
+		{Name: "start", Run: step("start", errors.New("boom"))},
+		{Name: "verify", Run: step("verify", nil)},
+		{Name: "reset", Always: true, Run: step("reset", nil)},
+	})
+
+	assert.False(t, report.Passed)
+	assert.Equal(t, []string{"setup", "start", "reset"}, ran)
+	require.Len(t, report.Steps, 4)
+	assert.Equal(t, "passed", report.Steps[0].Status)
+	assert.Equal(t, "failed", report.Steps[1].Status)
+	assert.Equal(t, "boom", report.Steps[1].Error)
+	assert.Equal(t, "skipped", report.Steps[2].Status)
+	assert.Equal(t, "passed", report.Steps[3].Status)
+}
+
+func TestVerifyE2E(t *testing.T) {
+	interval := e2eVerifyInterval
+	e2eVerifyInterval = time.Millisecond
+	t.Cleanup(func() { e2eVerifyInterval = interval })
+
+	verification := func(passed bool) *sdk.Verification {
+		return &sdk.Verification{
+			Config:  &sdk.ValidationConfig{},
+			Results: []sdk.Result{{Key: "pod-ready", Passed: passed, Message: "msg"}},
+		}
+	}
+	constant := func(passed bool) func(context.Context) (*sdk.Verification, error) {
+		return func(context.Context) (*sdk.Verification, error) { return verification(passed), nil }
+	}
+	ctx := context.Background()
+
+	var result e2eStepResult
+	require.NoError(t, verifyE2E(ctx, constant(false), false, time.Minute, &result), "a broken challenge fails before the fix")
+	assert.Equal(t, []e2eObjective{{Key: "pod-ready", Passed: false, Message: "msg"}}, result.Objectives)
+	assert.Error(t, verifyE2E(ctx, constant(true), false, time.Minute, &e2eStepResult{}), "a challenge passing before the fix is not broken")
+
+	calls := 0
+	converging := func(context.Context) (*sdk.Verification, error) {
+		calls++
+		return verification(calls == 3), nil
+	}
+	require.NoError(t, verifyE2E(ctx, converging, true, time.Minute, &result), "the fix is given time to converge")
+	assert.Equal(t, 3, calls)
+	assert.True(t, result.Objectives[0].Passed)
+
+	err := verifyE2E(ctx, constant(false), true, 0, &e2eStepResult{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pod-ready")
+}
+
+func TestRunE2EScript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "fix.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho \"ns=$KUBEASY_E2E_NAMESPACE dir=$(pwd)\"\n"), 0o700)) //nolint:gosec // must be executable
+
+	output, err := runE2EScript(context.Background(), script, dir, []string{"KUBEASY_E2E_NAMESPACE=pod-evicted"})
+	require.NoError(t, err)
+	resolved, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Contains(t, output, "ns=pod-evicted")
+	assert.Contains(t, output, resolved, "scripts run in the challenge directory")
+
+	failing := filepath.Join(dir, "break.sh")
+	require.NoError(t, os.WriteFile(failing, []byte("#!/bin/sh\necho oops\nexit 3\n"), 0o700)) //nolint:gosec // must be executable
+	output, err = runE2EScript(context.Background(), failing, dir, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "break.sh")
+	assert.Contains(t, output, "oops", "the output of a failed script is kept")
+}
+
+func TestE2EKindConfig(t *testing.T) {
+	cfg := e2eKindConfig()
+	require.NotEmpty(t, cfg.Nodes)
+	for _, node := range cfg.Nodes {
+		assert.Empty(t, node.ExtraPortMappings, "host ports would clash with the kubeasy cluster")
+		assert.Empty(t, node.KubeadmConfigPatches)
+		require.Len(t, node.ExtraMounts, 1)
+		assert.Equal(t, deployer.ContainerdHostsPath, node.ExtraMounts[0].ContainerPath)
+	}
+}
+
+func TestWriteE2EReport(t *testing.T) {
+	report := e2eReport{Version: e2eReportVersion, Slug: "pod-evicted", Passed: true, Steps: []e2eStepResult{{Name: "setup", Status: "passed"}}}
+
+	var out bytes.Buffer
+	require.NoError(t, writeE2EReport(&out, "-", report))
+	var decoded e2eReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, report.Steps, decoded.Steps)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, writeE2EReport(&out, path, report))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"slug": "pod-evicted"`)
+}
+
+func TestUseE2EEnvironment(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("KUBECONFIG", "/somewhere/config")
+	t.Setenv("DOCKER_CONFIG", "")
+	require.NoError(t, os.Unsetenv("DOCKER_CONFIG"))
+	contextBefore := constants.KubeasyClusterContext
+
+	restore, err := useE2EEnvironment("kubeasy-e2e", false)
+	require.NoError(t, err)
+	scratch := os.Getenv("HOME")
+	assert.NotEqual(t, home, scratch)
+	assert.Equal(t, filepath.Join(scratch, ".kube", "config"), os.Getenv("KUBECONFIG"))
+	assert.Equal(t, filepath.Join(scratch, ".kube", "config"), kube.GetDefaultKubeconfigPath())
+	assert.Equal(t, filepath.Join(home, ".docker"), os.Getenv("DOCKER_CONFIG"), "the Docker contexts of the user are kept")
+	assert.Equal(t, "kind-kubeasy-e2e", constants.KubeasyClusterContext)
+
+	restore()
+	assert.Equal(t, home, os.Getenv("HOME"))
+	assert.Equal(t, "/somewhere/config", os.Getenv("KUBECONFIG"))
+	_, set := os.LookupEnv("DOCKER_CONFIG")
+	assert.False(t, set)
+	assert.Equal(t, contextBefore, constants.KubeasyClusterContext)
+	_, err = os.Stat(scratch)
+	assert.True(t, os.IsNotExist(err), "the scratch home is removed")
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/exam"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+const (
+	examActionSubmit = "Submit"
+	examActionSkip   = "Skip this challenge"
+	examActionPause  = "Pause (resume later)"
+)
+
+var (
+	apiGetBundle = api.GetBundleBySlug
+	examPrompt   = ui.Select
+	examSubmit   = runSubmit
+	examNow      = time.Now
+)
+
+var (
+	examTimeLimit time.Duration
+	examRestart   bool
+)
+
+var examCmd = &cobra.Command{
+	Use:   "exam [bundle-slug]",
+	Short: "Take a bundle of challenges as one timed assessment",
+	Long: `Runs the challenges of a bundle one after another as a single assessment.
+
+Each challenge is started in turn; work on it in another terminal, then choose
+"Submit" to validate it and move on, or "Skip" to give up on it. A failed
+submission can be retried until you skip it or the time runs out.
+
+The time limit comes from the bundle (or --time-limit). Once it expires no more
+submissions are accepted and the remaining challenges are skipped.
+
+Progress is saved in ~/.kubeasy/exams/<bundle>.json: choose "Pause" or interrupt
+the command and run it again to resume. When the exam is over, running it again
+shows the final report; use --restart to take it again.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bundleSlug := args[0]
+		if err := validateChallengeSlug(bundleSlug); err != nil {
+			return err
+		}
+		if examTimeLimit < 0 {
+			return fmt.Errorf("--time-limit must be positive")
+		}
+
+		session, err := loadOrCreateExamSession(cmd, bundleSlug)
+		if err != nil {
+			return err
+		}
+
+		if session.FinishedAt == nil {
+			paused, err := runExam(cmd, session)
+			if err != nil || paused {
+				return err
+			}
+		}
+
+		printExamReport(session)
+		return nil
+	},
+}
+
+// loadOrCreateExamSession resumes the saved session of a bundle, or fetches the
+// bundle and starts a new one.
+func loadOrCreateExamSession(cmd *cobra.Command, bundleSlug string) (*exam.Session, error) {
+	if examRestart {
+		if err := exam.Delete(bundleSlug); err != nil {
+			return nil, fmt.Errorf("failed to delete previous exam session: %w", err)
+		}
+	} else {
+		session, err := exam.Load(bundleSlug)
+		if err != nil {
+			ui.Error("Failed to load exam session")
+			return nil, err
+		}
+		if session != nil {
+			if session.FinishedAt == nil {
+				ui.Info(fmt.Sprintf("Resuming exam '%s'", session.Title))
+			}
+			return session, nil
+		}
+	}
+
+	var bundle *api.BundleResponse
+	err := ui.WaitMessage("Fetching exam bundle", func() error {
+		var err error
+		bundle, err = apiGetBundle(cmd.Context(), bundleSlug)
+		return err
+	})
+	if err != nil {
+		ui.Error("Failed to fetch exam bundle")
+		return nil, fmt.Errorf("failed to fetch bundle: %w", err)
+	}
+	if len(bundle.Challenges) == 0 {
+		ui.Error("This bundle has no challenges")
+		return nil, fmt.Errorf("bundle %q has no challenges", bundleSlug)
+	}
+
+	limit := examTimeLimit
+	if limit == 0 && bundle.TimeLimitMinutes != nil {
+		limit = time.Duration(*bundle.TimeLimitMinutes) * time.Minute
+	}
+
+	ui.Section(fmt.Sprintf("Exam: %s", bundle.Title))
+	if bundle.Description != "" {
+		ui.Println()
+		ui.Info(bundle.Description)
+	}
+	ui.KeyValue("Challenges", fmt.Sprintf("%d", len(bundle.Challenges)))
+	if limit > 0 {
+		ui.KeyValue("Time limit", limit.String())
+	} else {
+		ui.KeyValue("Time limit", "none")
+	}
+	ui.Println()
+
+	session := exam.NewSession(bundle, examNow(), limit)
+	if err := session.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save exam session: %w", err)
+	}
+	return session, nil
+}
+
+// runExam walks the session until every challenge is done or the time is up, then
+// finishes it. It returns paused=true when the user chose to stop for now.
+func runExam(cmd *cobra.Command, session *exam.Session) (paused bool, err error) {
+	for {
+		if session.Expired(examNow()) {
+			ui.Warning("Time is up: no more submissions are accepted")
+			break
+		}
+		current := session.Current()
+		if current == nil {
+			break
+		}
+
+		ui.Section(fmt.Sprintf("Challenge %d/%d: %s", session.Position(current.Slug), len(session.Challenges), current.Title))
+		if remaining, ok := session.Remaining(examNow()); ok {
+			ui.KeyValue("Time remaining", formatElapsed(remaining))
+		}
+
+		if current.Status == exam.StatusPending {
+			if err := runStart(cmd.Context(), current.Slug, sdk.StartOptions{}); err != nil {
+				return false, err
+			}
+			now := examNow()
+			current.Status = exam.StatusInProgress
+			current.StartedAt = &now
+			if err := session.Save(); err != nil {
+				return false, fmt.Errorf("failed to save exam session: %w", err)
+			}
+		}
+
+		action, err := examPrompt("When you are done with this challenge", []string{examActionSubmit, examActionSkip, examActionPause})
+		if err != nil {
+			return false, err
+		}
+
+		switch action {
+		case examActionPause:
+			ui.Info(fmt.Sprintf("Exam paused. Resume with 'kubeasy exam %s'", session.Bundle))
+			return true, nil
+		case examActionSkip:
+			current.GiveUp()
+		case examActionSubmit:
+			if session.Expired(examNow()) {
+				continue
+			}
+			outcome, err := examSubmit(cmd.Context(), current.Slug, submitOptions{})
+			if errors.Is(err, errSubmitCancelled) {
+				// Nothing was sent: the attempt stays open and uncounted
+				continue
+			}
+			if err != nil {
+				logger.Debug("Exam submission of %s failed: %v", current.Slug, err)
+				ui.Error(err.Error())
+				continue
+			}
+			recordExamSubmission(current, outcome, examNow())
+		}
+		if err := session.Save(); err != nil {
+			return false, fmt.Errorf("failed to save exam session: %w", err)
+		}
+	}
+
+	session.Finish(examNow())
+	if err := session.Save(); err != nil {
+		return false, fmt.Errorf("failed to save exam session: %w", err)
+	}
+	return false, nil
+}
+
+// recordExamSubmission updates an attempt with a submission outcome. A nil outcome
+// means nothing was submitted, e.g. the challenge was already completed before the exam.
+func recordExamSubmission(attempt *exam.ChallengeAttempt, outcome *submitOutcome, now time.Time) {
+	attempt.Submissions++
+	attempt.SubmittedAt = &now
+	switch {
+	case outcome == nil:
+		attempt.Status = exam.StatusSkipped
+	case outcome.AllPassed && outcome.Result != nil && outcome.Result.Success:
+		attempt.Status = exam.StatusPassed
+		if outcome.Result.XpAwarded != nil {
+			attempt.XP = *outcome.Result.XpAwarded
+		}
+	default:
+		// Stays current: the learner can fix the issue and submit again, or skip.
+		attempt.Status = exam.StatusFailed
+		ui.Info("You can fix the remaining issues and submit again, or skip this challenge")
+	}
+}
+
+func printExamReport(session *exam.Session) {
+	now := examNow()
+	if session.FinishedAt != nil {
+		now = *session.FinishedAt
+	}
+
+	ui.Section(fmt.Sprintf("Exam Report: %s", session.Title))
+	rows := make([][]string, len(session.Challenges))
+	for i, c := range session.Challenges {
+		xp := "-"
+		if c.XP > 0 {
+			xp = fmt.Sprintf("%d", c.XP)
+		}
+		rows[i] = []string{c.Slug, ui.StatusLabel(string(c.Status)), fmt.Sprintf("%d", c.Submissions), formatElapsed(c.Duration(now)), xp}
+	}
+	if err := ui.Table([]string{"CHALLENGE", "RESULT", "SUBMISSIONS", "TIME", "XP"}, rows); err != nil {
+		logger.Debug("Failed to render exam report: %v", err)
+	}
+	ui.Println()
+
+	score := session.Score()
+	ui.KeyValue("Score", fmt.Sprintf("%d/%d (%d%%)", score.Passed, score.Total, score.Percent()))
+	ui.KeyValue("XP earned", fmt.Sprintf("%d", score.XP))
+	ui.KeyValue("Time spent", formatElapsed(now.Sub(session.StartedAt)))
+	if session.TimeLimit > 0 {
+		ui.KeyValue("Time limit", session.TimeLimit.String())
+	}
+	ui.Println()
+	if score.Passed == score.Total {
+		ui.Success("All challenges passed!")
+	}
+	ui.Info("Clean up the challenge namespaces with 'kubeasy challenge clean <slug>'")
+}
+
+func init() {
+	rootCmd.AddCommand(examCmd)
+	examCmd.Flags().DurationVar(&examTimeLimit, "time-limit", 0, "Override the time limit of the bundle (e.g. 2h)")
+	examCmd.Flags().BoolVar(&examRestart, "restart", false, "Discard the saved session and take the exam again")
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/exam"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubExamBundle(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	origGetBundle := apiGetBundle
+	t.Cleanup(func() { apiGetBundle = origGetBundle })
+
+	limit := 60
+	apiGetBundle = func(ctx context.Context, slug string) (*api.BundleResponse, error) {
+		return &api.BundleResponse{
+			Slug:             slug,
+			Title:            "CKA Warm-up",
+			TimeLimitMinutes: &limit,
+			Challenges: []api.BundleChallenge{
+				{Slug: "pod-evicted", Title: "Pod Evicted"},
+				{Slug: "rbac-101", Title: "RBAC 101"},
+			},
+		}, nil
+	}
+}
+
+func testExamCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestLoadOrCreateExamSession(t *testing.T) {
+	stubExamBundle(t)
+
+	session, err := loadOrCreateExamSession(testExamCmd(), "cka-warmup")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, session.TimeLimit, "time limit comes from the bundle")
+	require.Len(t, session.Challenges, 2)
+
+	// A saved session is resumed instead of fetching the bundle again.
+	session.Challenges[0].Status = exam.StatusPassed
+	require.NoError(t, session.Save())
+	apiGetBundle = func(ctx context.Context, slug string) (*api.BundleResponse, error) {
+		t.Fatal("bundle must not be fetched when resuming")
+		return nil, nil
+	}
+	resumed, err := loadOrCreateExamSession(testExamCmd(), "cka-warmup")
+	require.NoError(t, err)
+	assert.Equal(t, exam.StatusPassed, resumed.Challenges[0].Status)
+}
+
+func TestRunExam_TimeUpFinishesSession(t *testing.T) {
+	stubExamBundle(t)
+	session, err := loadOrCreateExamSession(testExamCmd(), "cka-warmup")
+	require.NoError(t, err)
+	session.StartedAt = time.Now().Add(-2 * time.Hour)
+
+	paused, err := runExam(testExamCmd(), session)
+	require.NoError(t, err)
+	assert.False(t, paused)
+	require.NotNil(t, session.FinishedAt)
+	assert.Equal(t, exam.StatusSkipped, session.Challenges[0].Status)
+
+	saved, err := exam.Load("cka-warmup")
+	require.NoError(t, err)
+	assert.NotNil(t, saved.FinishedAt, "finished session is persisted")
+}
+
+func TestRunExam_Pause(t *testing.T) {
+	stubExamBundle(t)
+	origPrompt := examPrompt
+	t.Cleanup(func() { examPrompt = origPrompt })
+	examPrompt = func(label string, options []string) (string, error) {
+		return examActionPause, nil
+	}
+
+	session, err := loadOrCreateExamSession(testExamCmd(), "cka-warmup")
+	require.NoError(t, err)
+	session.Challenges[0].Status = exam.StatusInProgress
+
+	paused, err := runExam(testExamCmd(), session)
+	require.NoError(t, err)
+	assert.True(t, paused)
+	assert.Nil(t, session.FinishedAt)
+}
+
+func TestRecordExamSubmission(t *testing.T) {
+	xp := 50
+	now := time.Now()
+	tests := []struct {
+		name    string
+		outcome *submitOutcome
+		want    exam.ChallengeStatus
+		wantXP  int
+	}{
+		{"passed", &submitOutcome{AllPassed: true, Result: &api.ChallengeSubmitResponse{Success: true, XpAwarded: &xp}}, exam.StatusPassed, 50},
+		{"failed validations", &submitOutcome{AllPassed: false, Result: &api.ChallengeSubmitResponse{}}, exam.StatusFailed, 0},
+		{"nothing submitted", nil, exam.StatusSkipped, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt := &exam.ChallengeAttempt{Status: exam.StatusInProgress}
+			recordExamSubmission(attempt, tt.outcome, now)
+			assert.Equal(t, tt.want, attempt.Status)
+			assert.Equal(t, tt.wantXP, attempt.XP)
+			assert.Equal(t, 1, attempt.Submissions)
+		})
+	}
+}
+
+func TestRunExam_FailedSubmissionThenSkip(t *testing.T) {
+	stubExamBundle(t)
+	origPrompt, origSubmit := examPrompt, examSubmit
+	t.Cleanup(func() { examPrompt, examSubmit = origPrompt, origSubmit })
+	// Submit the first challenge, which fails, then skip both challenges
+	actions := []string{examActionSubmit, examActionSkip, examActionSkip}
+	examPrompt = func(label string, options []string) (string, error) {
+		action := actions[0]
+		actions = actions[1:]
+		return action, nil
+	}
+	examSubmit = func(ctx context.Context, slug string, opts submitOptions) (*submitOutcome, error) {
+		return &submitOutcome{AllPassed: false, Result: &api.ChallengeSubmitResponse{}}, nil
+	}
+
+	session, err := loadOrCreateExamSession(testExamCmd(), "cka-warmup")
+	require.NoError(t, err)
+	for i := range session.Challenges {
+		session.Challenges[i].Status = exam.StatusInProgress // already started
+	}
+
+	paused, err := runExam(testExamCmd(), session)
+	require.NoError(t, err)
+	assert.False(t, paused)
+	assert.Empty(t, actions)
+	assert.Equal(t, exam.StatusFailed, session.Challenges[0].Status, "a failed attempt stays failed when skipped")
+	assert.Equal(t, 1, session.Challenges[0].Submissions)
+	assert.Equal(t, exam.StatusSkipped, session.Challenges[1].Status, "a challenge never attempted is skipped")
+}
+
+func TestRunExam_CancelledSubmission(t *testing.T) {
+	stubExamBundle(t)
+	origPrompt, origSubmit := examPrompt, examSubmit
+	t.Cleanup(func() { examPrompt, examSubmit = origPrompt, origSubmit })
+	// Submit the first challenge but decline to send failing results, then pause
+	actions := []string{examActionSubmit, examActionPause}
+	examPrompt = func(label string, options []string) (string, error) {
+		action := actions[0]
+		actions = actions[1:]
+		return action, nil
+	}
+	examSubmit = func(ctx context.Context, slug string, opts submitOptions) (*submitOutcome, error) {
+		return nil, errSubmitCancelled
+	}
+
+	session, err := loadOrCreateExamSession(testExamCmd(), "cka-warmup")
+	require.NoError(t, err)
+	session.Challenges[0].Status = exam.StatusInProgress // already started
+
+	paused, err := runExam(testExamCmd(), session)
+	require.NoError(t, err)
+	assert.True(t, paused)
+	assert.Empty(t, actions)
+	assert.Equal(t, exam.StatusInProgress, session.Challenges[0].Status, "a cancelled submission leaves the challenge open")
+	assert.Zero(t, session.Challenges[0].Submissions)
+	assert.Nil(t, session.Challenges[0].SubmittedAt)
+	assert.Equal(t, session.Challenges[0].Slug, session.Current().Slug)
+}
+
+func TestRunExam_TimeUpAfterFailedSubmission(t *testing.T) {
+	stubExamBundle(t)
+	session, err := loadOrCreateExamSession(testExamCmd(), "cka-warmup")
+	require.NoError(t, err)
+	session.StartedAt = time.Now().Add(-2 * time.Hour)
+	recordExamSubmission(&session.Challenges[0], &submitOutcome{Result: &api.ChallengeSubmitResponse{}}, time.Now())
+
+	_, err = runExam(testExamCmd(), session)
+	require.NoError(t, err)
+	assert.Equal(t, exam.StatusFailed, session.Challenges[0].Status, "an attempted challenge fails when time is up")
+	assert.Equal(t, exam.StatusSkipped, session.Challenges[1].Status)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/spf13/cobra"
+)
+
+// exitCode returns the exit status of a run that ended with err: the status of its
+// error code (see kerrors.ExitCode), or 130 when the command was interrupted.
+func exitCode(err error, interrupted bool) int {
+	if interrupted {
+		return exitCodeInterrupted
+	}
+	return kerrors.ExitCode(err)
+}
+
+// reportErrorCode prints the code of err on w, as a {"error": {"code", "message"}}
+// JSON line for a command run with --json. It goes to stderr like any error, so the
+// JSON document the command may already have written to stdout stays parseable.
+func reportErrorCode(cmd *cobra.Command, err error, w io.Writer) {
+	if jsonOutputRequested(cmd) {
+		data, jerr := json.Marshal(struct {
+			Error kerrors.JSON `json:"error"`
+		}{kerrors.ToJSON(err)})
+		if jerr == nil {
+			_, _ = fmt.Fprintln(w, string(data))
+			return
+		}
+	}
+	_, _ = fmt.Fprintf(w, "Error code: %s\n", kerrors.CodeOf(err))
+}
+
+// jsonOutputRequested reports whether cmd has a --json flag that was set.
+func jsonOutputRequested(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	flag := cmd.Flags().Lookup("json")
+	return flag != nil && flag.Value.Type() == "bool" && flag.Value.String() == "true"
+}
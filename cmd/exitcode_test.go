@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, exitCodeInterrupted, exitCode(errors.New("boom"), true))
+	assert.Equal(t, kerrors.ExitFailure, exitCode(errors.New("boom"), false))
+	assert.Equal(t, kerrors.ExitValidation, exitCode(kerrors.New(kerrors.CodeValidationFailed, "some validations failed"), false))
+}
+
+func TestReportErrorCode(t *testing.T) {
+	err := kerrors.New(kerrors.CodeClusterNotKubeasy, "cluster is not managed by kubeasy")
+
+	cmd := &cobra.Command{Use: "plain"}
+	var out bytes.Buffer
+	reportErrorCode(cmd, err, &out)
+	assert.Equal(t, "Error code: CLUSTER_NOT_KUBEASY\n", out.String())
+
+	var jsonOutput bool
+	cmd = &cobra.Command{Use: "validate"}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "")
+	require.NoError(t, cmd.Flags().Set("json", "true"))
+	out.Reset()
+	reportErrorCode(cmd, err, &out)
+	var doc struct {
+		Error kerrors.JSON `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.Equal(t, kerrors.CodeClusterNotKubeasy, doc.Error.Code)
+	assert.Equal(t, "cluster is not managed by kubeasy", doc.Error.Message)
+}
+
+func TestFlagErrorsAreUsageErrors(t *testing.T) {
+	err := rootCmd.FlagErrorFunc()(rootCmd, errors.New("unknown flag: --nope"))
+	assert.Equal(t, kerrors.CodeInvalidInput, kerrors.CodeOf(err))
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var loadValidationsForExplain = loadUserValidations
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [challenge-slug] [objective-key]",
+	Short: "Explain what an objective checks",
+	Long: `Prints a human-readable explanation of an objective: which resources it
+looks at and what it expects, rendered from challenge.yaml, followed by the
+result observed at your last 'kubeasy challenge submit'.
+
+Without an objective key, lists the objectives of the challenge.`,
+	Args:          cobra.RangeArgs(1, 2),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		challengeSlug := args[0]
+		if err := validateChallengeSlug(challengeSlug); err != nil {
+			return err
+		}
+
+		config, err := loadValidationsForExplain(cmd.Context(), challengeSlug)
+		if err != nil {
+			ui.Error("Failed to load validations")
+			return fmt.Errorf("failed to load validations: %w", err)
+		}
+
+		observed, err := audit.LoadLastResults(challengeSlug)
+		if err != nil {
+			logger.Debug("Could not load last results for %s: %v", challengeSlug, err)
+		}
+
+		if len(args) == 1 {
+			if err := printObjectiveList(config.Validations, observed); err != nil {
+				return err
+			}
+			return printForbiddenActions(config.ForbiddenActions)
+		}
+
+		key := args[1]
+		for _, v := range config.Validations {
+			if v.Key == key {
+				printObjectiveExplanation(v, findObserved(observed, key))
+				return nil
+			}
+		}
+		ui.Error(fmt.Sprintf("Objective %q not found in challenge %s", key, challengeSlug))
+		return fmt.Errorf("objective %q not found (available: %s)", key, strings.Join(objectiveKeys(config.Validations), ", "))
+	},
+}
+
+func printObjectiveList(validations []validation.Validation, observed []audit.ObservedResult) error {
+	rows := make([][]string, len(validations))
+	for i, v := range validations {
+		last := "-"
+		if r := findObserved(observed, v.Key); r != nil {
+			last = ui.StatusLabel("failed")
+			if r.Passed {
+				last = ui.StatusLabel("passed")
+			}
+		}
+		rows[i] = []string{v.Key, v.Title, string(v.Type), last}
+	}
+	ui.Section("Objectives")
+	if err := ui.Table([]string{"KEY", "TITLE", "TYPE", "LAST RESULT"}, rows); err != nil {
+		return err
+	}
+	ui.Println()
+	ui.Info("Run 'kubeasy explain <slug> <objective-key>' for details")
+	return nil
+}
+
+// printForbiddenActions lists the actions the challenge forbids, checked at submit.
+func printForbiddenActions(actions []validation.ForbiddenAction) error {
+	if len(actions) == 0 {
+		return nil
+	}
+	rows := make([][]string, len(actions))
+	for i, a := range actions {
+		rows[i] = []string{a.Key, forbiddenActionSummary(a)}
+	}
+	ui.Println()
+	ui.Section("Forbidden Actions")
+	return ui.Table([]string{"KEY", "DESCRIPTION"}, rows)
+}
+
+// forbiddenActionSummary returns the description of a forbidden action, or what it
+// matches when it has none, e.g. "delete NetworkPolicy deny-all".
+func forbiddenActionSummary(a validation.ForbiddenAction) string {
+	if a.Description != "" {
+		return a.Description
+	}
+	target := a.Target.Kind
+	if a.Target.Name != "" {
+		target += " " + a.Target.Name
+	}
+	var parts []string
+	if len(a.Verbs) > 0 {
+		parts = append(parts, strings.Join(a.Verbs, "/")+" "+target)
+	}
+	if a.Event != nil {
+		parts = append(parts, fmt.Sprintf("%s event on %s", a.Event.Reason, target))
+	}
+	return strings.Join(parts, ", or ")
+}
+
+func printObjectiveExplanation(v validation.Validation, observed *audit.ObservedResult) {
+	title := v.Title
+	if title == "" {
+		title = v.Key
+	}
+	ui.Section(title)
+	ui.KeyValue("Key", v.Key)
+	ui.KeyValue("Type", string(v.Type))
+	if v.Phase != "" {
+		ui.KeyValue("Phase", v.Phase)
+	}
+	ui.Println()
+
+	if v.Description != "" {
+		ui.Panel("Description", v.Description)
+		ui.Println()
+	}
+	ui.Panel("What it checks", strings.Join(validation.Explain(v), "\n"))
+	ui.Println()
+
+	if observed == nil {
+		ui.Info("Not observed yet: submit the challenge to see what the grader finds")
+		return
+	}
+	ui.KeyValue("Last observed", observed.ObservedAt.Local().Format(time.DateTime))
+	ui.ValidationResult(v.Key, observed.Passed, []string{observed.Message})
+}
+
+func findObserved(observed []audit.ObservedResult, key string) *audit.ObservedResult {
+	for i := range observed {
+		if observed[i].Key == key {
+			return &observed[i]
+		}
+	}
+	return nil
+}
+
+func objectiveKeys(validations []validation.Validation) []string {
+	keys := make([]string, len(validations))
+	for i, v := range validations {
+		keys[i] = v.Key
+	}
+	return keys
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubExplainValidations(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	orig := loadValidationsForExplain
+	t.Cleanup(func() { loadValidationsForExplain = orig })
+	loadValidationsForExplain = func(_ context.Context, slug string) (*validation.ValidationConfig, error) {
+		return &validation.ValidationConfig{Validations: []validation.Validation{
+			{Key: "pod-ready", Title: "Pod Ready", Type: validation.TypeCondition, Spec: validation.ConditionSpec{
+				Target: validation.Target{Kind: "Pod", Name: "web"},
+				Checks: []validation.ConditionCheck{{Type: "Ready", Status: "True"}},
+			}},
+			{Key: "no-crash", Title: "No Crash", Type: validation.TypeEvent, Spec: validation.EventSpec{
+				Target:           validation.Target{Kind: "Pod", Name: "web"},
+				ForbiddenReasons: []string{"BackOff"},
+			}},
+		}, ForbiddenActions: []validation.ForbiddenAction{
+			{Key: "no-delete", Target: validation.Target{Kind: "Pod", Name: "web"}, Verbs: []string{"delete"}},
+		}}, nil
+	}
+}
+
+func TestExplainCmd(t *testing.T) {
+	stubExplainValidations(t)
+	require.NoError(t, audit.SaveLastResults("pod-evicted", []audit.ObservedResult{
+		{Key: "pod-ready", Passed: false, Message: "Ready: got False", ObservedAt: time.Now()},
+	}))
+
+	assert.NoError(t, explainCmd.RunE(explainCmd, []string{"pod-evicted"}), "lists objectives")
+	assert.NoError(t, explainCmd.RunE(explainCmd, []string{"pod-evicted", "pod-ready"}), "explains an observed objective")
+	assert.NoError(t, explainCmd.RunE(explainCmd, []string{"pod-evicted", "no-crash"}), "explains an objective never observed")
+
+	err := explainCmd.RunE(explainCmd, []string{"pod-evicted", "missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: pod-ready, no-crash")
+
+	assert.Error(t, explainCmd.RunE(explainCmd, []string{"Invalid_Slug"}))
+}
+
+func TestFindObserved(t *testing.T) {
+	observed := []audit.ObservedResult{{Key: "a", Passed: true}, {Key: "b"}}
+	require.NotNil(t, findObserved(observed, "b"))
+	assert.Equal(t, "b", findObserved(observed, "b").Key)
+	assert.Nil(t, findObserved(observed, "c"))
+	assert.Nil(t, findObserved(nil, "a"))
+}
+
+func TestForbiddenActionSummary(t *testing.T) {
+	assert.Equal(t, "Keep the policy", forbiddenActionSummary(validation.ForbiddenAction{Description: "Keep the policy"}))
+	assert.Equal(t, "delete/patch NetworkPolicy deny-all", forbiddenActionSummary(validation.ForbiddenAction{
+		Target: validation.Target{Kind: "NetworkPolicy", Name: "deny-all"},
+		Verbs:  []string{"delete", "patch"},
+	}))
+	assert.Equal(t, "scale Deployment, or ScalingReplicaSet event on Deployment", forbiddenActionSummary(validation.ForbiddenAction{
+		Target: validation.Target{Kind: "Deployment"},
+		Verbs:  []string{"scale"},
+		Event:  &validation.ForbiddenActionEvent{Reason: "ScalingReplicaSet"},
+	}))
+}
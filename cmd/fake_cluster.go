@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/fakecluster"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+)
+
+// fakeClusterScenario is the --fake-cluster flag.
+var fakeClusterScenario string
+
+// useFakeCluster makes cc run against the in-memory cluster and API of the scenario
+// at path (see internal/fakecluster): challenges are deployed by seeding the
+// scenario objects, the challenge.yaml is read from the scenario's challenges
+// directory and kubectl contexts are edited in a scratch kubeconfig, never the
+// user's.
+func (cc *commandContext) useFakeCluster(path string) (*fakecluster.Scenario, error) {
+	scenario, err := fakecluster.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig, err := fakecluster.EnsureKubeconfig(scenario.StateDir())
+	if err != nil {
+		return nil, err
+	}
+	kube.UseKubeconfig(kubeconfig)
+	if err := os.Setenv("KUBEASY_LOCAL_CHALLENGES_DIR", scenario.ChallengesDir); err != nil {
+		return nil, fmt.Errorf("failed to set KUBEASY_LOCAL_CHALLENGES_DIR: %w", err)
+	}
+
+	fakeAPI := fakecluster.NewAPI(scenario)
+	cc.API = sdk.API{
+		GetChallenge:    fakeAPI.GetChallenge,
+		GetProgress:     fakeAPI.GetProgress,
+		StartChallenge:  fakeAPI.StartChallenge,
+		SubmitChallenge: fakeAPI.SubmitChallenge,
+		ResetChallenge:  fakeAPI.ResetChallenge,
+		UserID:          fakeAPI.UserID,
+	}
+	cc.Connect = func() (*sdk.Cluster, error) {
+		cluster, err := scenario.Cluster(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return &sdk.Cluster{Clientset: cluster.Clientset, DynamicClient: cluster.DynamicClient, RestConfig: cluster.RestConfig}, nil
+	}
+	// There are no platform components to repair
+	cc.HealPlatform = nil
+	// The scenario objects are in the cluster from the start
+	cc.Deploy = func(ctx context.Context, cluster *sdk.Cluster, slug string) (string, error) {
+		return scenario.Hash(), nil
+	}
+	return scenario, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUseFakeCluster_StartAndSubmit plays a challenge from start to completion against
+// a fake cluster, as in separate invocations.
+func TestUseFakeCluster_StartAndSubmit(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("KUBEASY_LOCAL_CHALLENGES_DIR", "")
+	t.Cleanup(func() { kube.UseKubeconfig("") })
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "pod-evicted"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pod-evicted", "challenge.yaml"), []byte(`title: "Pod Evicted"
+objectives:
+  - key: pod-running
+    title: Pod running
+    order: 1
+    type: status
+    spec:
+      target: {kind: Pod, name: web}
+      checks:
+        - {field: phase, operator: "==", value: Running}
+`), 0o600))
+	scenario := filepath.Join(dir, "scenario.yaml")
+	require.NoError(t, os.WriteFile(scenario, []byte(`challenge: {slug: pod-evicted, title: Pod Evicted}
+objects:
+  - apiVersion: v1
+    kind: Namespace
+    metadata: {name: pod-evicted}
+  - apiVersion: v1
+    kind: Pod
+    metadata: {name: web, namespace: pod-evicted}
+    status: {phase: Running}
+`), 0o600))
+
+	invoke := func() *commandContext {
+		cc := testCommandContext()
+		_, err := cc.useFakeCluster(scenario)
+		require.NoError(t, err)
+		return cc
+	}
+
+	cc := invoke()
+	useCommandContext(t, startChallengeCmd, cc)
+	require.NoError(t, startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"}))
+	namespace, err := kube.ContextNamespace("kind-kubeasy")
+	require.NoError(t, err)
+	assert.Equal(t, "pod-evicted", namespace, "the scratch kubeconfig points at the challenge")
+	_, err = os.Stat(filepath.Join(home, ".kube", "config"))
+	assert.True(t, os.IsNotExist(err), "the user's kubeconfig is never written")
+
+	cc = invoke()
+	useCommandContext(t, submitCmd, cc)
+	require.NoError(t, submitCmd.RunE(submitCmd, []string{"pod-evicted"}))
+	progress, err := cc.API.GetProgress(context.Background(), "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "completed", progress.Status)
+}
+
+func TestUseFakeCluster_InvalidScenario(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	_, err := testCommandContext().useFakeCluster(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +16,7 @@ var getChallengeCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		challengeSlug := args[0]
 
-		challenge, err := getChallenge(challengeSlug)
+		challenge, err := getChallenge(cmd.Context(), challengeSlug)
 		if err != nil {
 			ui.Error(err.Error())
 			return err
@@ -41,8 +40,8 @@ var getChallengeCmd = &cobra.Command{
 
 		// Display initial situation
 		if challenge.InitialSituation != "" {
-			pterm.DefaultSection.Println("Initial Situation")
-			pterm.Println(challenge.InitialSituation)
+			ui.Section("Initial Situation")
+			ui.Text(challenge.InitialSituation)
 			ui.Println()
 		}
 
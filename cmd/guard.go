@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"k8s.io/client-go/kubernetes"
+)
+
+// skipClusterGuard is the --i-know-what-im-doing flag.
+var skipClusterGuard bool
+
+// guardKubeasyCluster refuses to modify a cluster that 'kubeasy setup' did not mark,
+// which protects users whose kubeasy context was renamed or repointed at another
+// cluster. --i-know-what-im-doing downgrades the refusal to a warning. It also warns
+// when the environment fingerprint does not match this CLI.
+func guardKubeasyCluster(ctx context.Context, clientset kubernetes.Interface) error {
+	fp, err := kube.ReadClusterFingerprint(ctx, clientset)
+	if err == nil {
+		for _, issue := range fp.CompatibilityIssues(constants.Version) {
+			ui.Warning(issue)
+		}
+		return nil
+	}
+	if commandContextFrom(ctx).Config.SkipClusterGuard {
+		ui.Warning(fmt.Sprintf("Proceeding on a cluster not verified as kubeasy's: %v", err))
+		return nil
+	}
+	if errors.Is(err, kube.ErrNotKubeasyCluster) {
+		ui.Error(fmt.Sprintf("Context %s does not point to a cluster set up by kubeasy", constants.KubeasyClusterContext))
+		ui.Info("Run 'kubeasy setup' to set it up, or pass --i-know-what-im-doing to proceed anyway")
+	}
+	return fmt.Errorf("refusing to modify the cluster: %w", err)
+}
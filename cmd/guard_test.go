@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGuardKubeasyCluster(t *testing.T) {
+	ctx := context.Background()
+	t.Cleanup(func() { skipClusterGuard = false })
+
+	clientset := fake.NewClientset()
+	skipClusterGuard = false
+	assert.ErrorIs(t, guardKubeasyCluster(ctx, clientset), kube.ErrNotKubeasyCluster)
+
+	skipClusterGuard = true
+	assert.NoError(t, guardKubeasyCluster(ctx, clientset), "--i-know-what-im-doing overrides the guard")
+
+	skipClusterGuard = false
+	require.NoError(t, kube.EnsureClusterMarker(ctx, clientset))
+	assert.NoError(t, guardKubeasyCluster(ctx, clientset))
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// ideInfoVersion is bumped when a field of ideInfo changes meaning or is removed.
+const ideInfoVersion = 1
+
+var loadValidationsForIDE = loadUserValidations
+
+var ideInfoCmd = &cobra.Command{
+	Use:   "ide-info [challenge-slug]",
+	Short: "Describe the active challenge as JSON for editor extensions",
+	Long: `Prints a JSON description of a started challenge for editor extensions:
+its namespace, kube context, objectives with the result observed at the last
+submission, and the paths of the local files the CLI maintains for it.
+
+Without argument, describes the only challenge started on this machine.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slug, err := activeChallenge(args)
+		if err != nil {
+			return err
+		}
+
+		config, err := loadValidationsForIDE(cmd.Context(), slug)
+		if err != nil {
+			return fmt.Errorf("failed to load validations: %w", err)
+		}
+		observed, err := audit.LoadLastResults(slug)
+		if err != nil {
+			logger.Debug("Could not load last results for %s: %v", slug, err)
+		}
+
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(buildIDEInfo(slug, config.Validations, observed))
+	},
+}
+
+// ideInfo is the document printed by 'kubeasy ide-info'.
+type ideInfo struct {
+	Version     int            `json:"version"`
+	Slug        string         `json:"slug"`
+	Namespace   string         `json:"namespace"`
+	KubeContext string         `json:"kubeContext"`
+	StartedAt   *time.Time     `json:"startedAt,omitempty"`
+	Objectives  []ideObjective `json:"objectives"`
+	Files       ideFiles       `json:"files"`
+}
+
+type ideObjective struct {
+	Key         string `json:"key"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type"`
+	Phase       string `json:"phase,omitempty"`
+	// LastResult is the outcome at the last submission, nil before the first one.
+	LastResult *audit.ObservedResult `json:"lastResult,omitempty"`
+}
+
+type ideFiles struct {
+	Kubeconfig string `json:"kubeconfig"`
+	StateDir   string `json:"stateDir"`
+	// LastResults changes after each submission; extensions can watch it to refresh.
+	LastResults string `json:"lastResults"`
+	AuditLog    string `json:"auditLog"`
+}
+
+// activeChallenge returns the slug given as argument, or the only started challenge.
+func activeChallenge(args []string) (string, error) {
+	if len(args) == 1 {
+		return args[0], validateChallengeSlug(args[0])
+	}
+	slugs, err := audit.ListStartedChallenges()
+	if err != nil {
+		return "", err
+	}
+	switch len(slugs) {
+	case 0:
+		return "", fmt.Errorf("no started challenge: start one with 'kubeasy challenge start <slug>'")
+	case 1:
+		return slugs[0], nil
+	default:
+		return "", fmt.Errorf("several challenges are started (%s): pass the slug", strings.Join(slugs, ", "))
+	}
+}
+
+func buildIDEInfo(slug string, validations []validation.Validation, observed []audit.ObservedResult) ideInfo {
+	stateDir := audit.GetStateDir(slug)
+	info := ideInfo{
+		Version:     ideInfoVersion,
+		Slug:        slug,
+		Namespace:   slug,
+		KubeContext: constants.KubeasyClusterContext,
+		Objectives:  make([]ideObjective, len(validations)),
+		Files: ideFiles{
+			Kubeconfig:  kube.GetKubeConfigPath(),
+			StateDir:    stateDir,
+			LastResults: audit.GetLastResultsPath(slug),
+			AuditLog:    audit.GetAuditLogPath(),
+		},
+	}
+	if ts, err := audit.LoadStartTime(slug); err == nil {
+		info.StartedAt = &ts
+	}
+	for i, v := range validations {
+		info.Objectives[i] = ideObjective{
+			Key:         v.Key,
+			Title:       v.Title,
+			Description: v.Description,
+			Type:        string(v.Type),
+			Phase:       v.Phase,
+			LastResult:  findObserved(observed, v.Key),
+		}
+	}
+	return info
+}
+
+func init() {
+	rootCmd.AddCommand(ideInfoCmd)
+}
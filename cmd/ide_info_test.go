@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDEInfoCmd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	orig := loadValidationsForIDE
+	t.Cleanup(func() { loadValidationsForIDE = orig })
+	loadValidationsForIDE = func(_ context.Context, slug string) (*validation.ValidationConfig, error) {
+		return &validation.ValidationConfig{Validations: []validation.Validation{
+			{Key: "pod-ready", Title: "Pod Ready", Type: validation.TypeCondition, Phase: "Fix"},
+			{Key: "no-crash", Title: "No Crash", Type: validation.TypeEvent},
+		}}, nil
+	}
+
+	_, err := activeChallenge(nil)
+	require.Error(t, err, "nothing started yet")
+
+	require.NoError(t, audit.SaveStartTime("pod-evicted", time.Now()))
+	require.NoError(t, audit.SaveLastResults("pod-evicted", []audit.ObservedResult{{Key: "pod-ready", Passed: true}}))
+
+	var out bytes.Buffer
+	ideInfoCmd.SetOut(&out)
+	t.Cleanup(func() { ideInfoCmd.SetOut(nil) })
+	require.NoError(t, ideInfoCmd.RunE(ideInfoCmd, nil), "the only started challenge is used")
+
+	var info ideInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &info))
+	assert.Equal(t, ideInfoVersion, info.Version)
+	assert.Equal(t, "pod-evicted", info.Slug)
+	assert.Equal(t, "pod-evicted", info.Namespace)
+	assert.NotNil(t, info.StartedAt)
+	assert.Equal(t, audit.GetLastResultsPath("pod-evicted"), info.Files.LastResults)
+	require.Len(t, info.Objectives, 2)
+	assert.Equal(t, "Fix", info.Objectives[0].Phase)
+	require.NotNil(t, info.Objectives[0].LastResult)
+	assert.True(t, info.Objectives[0].LastResult.Passed)
+	assert.Nil(t, info.Objectives[1].LastResult)
+
+	require.NoError(t, audit.SaveStartTime("other", time.Now()))
+	_, err = activeChallenge(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "other, pod-evicted")
+
+	assert.Error(t, ideInfoCmd.RunE(ideInfoCmd, []string{"Invalid_Slug"}))
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+)
+
+// exitCodeInterrupted is the conventional exit code of a process stopped by SIGINT.
+const exitCodeInterrupted = 130
+
+var (
+	interruptMu   sync.Mutex
+	interruptHint string
+)
+
+// setInterruptHint records what the user should do if the command is interrupted now,
+// e.g. which command resumes the work. "" clears it.
+func setInterruptHint(hint string) {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+	interruptHint = hint
+}
+
+func currentInterruptHint() string {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+	return interruptHint
+}
+
+// interruptContext returns a context cancelled by the first SIGINT or SIGTERM, so the
+// running command unwinds through its normal error paths: rollbacks and deferred
+// cleanup run and file locks are released. A second signal kills the process.
+// release stops listening for signals; ctx.Err() before release tells whether the
+// command was interrupted.
+func interruptContext(parent context.Context) (ctx context.Context, release func()) {
+	ctx, cancel := context.WithCancel(parent)
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigs:
+			// Restore the default behaviour so a second signal terminates immediately
+			signal.Stop(sigs)
+			logger.Warning("Received %s: cancelling the running command", sig)
+			ui.Warning("Interrupted: cleaning up (press Ctrl+C again to force quit)")
+			cancel()
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		signal.Stop(sigs)
+		cancel()
+	}
+}
+
+// reportInterrupted tells the user what was left incomplete by an interrupted command.
+func reportInterrupted() {
+	if hint := currentInterruptHint(); hint != "" {
+		ui.Info(hint)
+	}
+}
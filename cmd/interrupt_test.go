@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterruptContext_ReleaseIsNotAnInterruption(t *testing.T) {
+	ctx, release := interruptContext(context.Background())
+	assert.NoError(t, ctx.Err(), "the context is live until a signal or release")
+	release()
+	assert.Error(t, ctx.Err())
+}
+
+func TestLogStepEvent_TracksInterruptHint(t *testing.T) {
+	t.Cleanup(func() { setInterruptHint("") })
+	onEvent := logStepEvent("setup")
+
+	onEvent(steps.Event{Step: "cluster", Index: 1, Total: 2, Status: steps.StatusStarted})
+	assert.Contains(t, currentInterruptHint(), `setup stopped during step "cluster"`)
+
+	onEvent(steps.Event{Step: "cluster", Index: 1, Total: 2, Status: steps.StatusCompleted})
+	onEvent(steps.Event{Step: "components", Index: 2, Total: 2, Status: steps.StatusStarted})
+	assert.Contains(t, currentInterruptHint(), `"components"`)
+
+	onEvent(steps.Event{Step: "components", Index: 2, Total: 2, Status: steps.StatusCompleted})
+	assert.Empty(t, currentInterruptHint(), "a finished command leaves nothing to resume")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	kubeconfigRole     string
+	kubeconfigDuration time.Duration
+	kubeconfigOutput   string
+)
+
+var kubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig [challenge-slug]",
+	Short: "Generate a kubeconfig restricted to a challenge namespace",
+	Long: `Generates a kubeconfig whose permissions are limited to the challenge namespace,
+so you practice with the realistic, restricted access you would get on a shared cluster.
+
+A ServiceAccount and a RoleBinding named "kubeasy-learner" are created in the
+challenge namespace, bound to the "edit" ClusterRole by default (see --role).
+The kubeconfig is written to ~/.kubeasy/kubeconfigs/<slug>.yaml unless --output
+is set ("-" prints it to stdout). It is removed by 'kubeasy challenge reset'.
+
+Use it with:
+  export KUBECONFIG=~/.kubeasy/kubeconfigs/<slug>.yaml
+or pass it to a single command with 'kubectl --kubeconfig'.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		challengeSlug := args[0]
+		if err := validateChallengeSlug(challengeSlug); err != nil {
+			return err
+		}
+		if kubeconfigDuration < 10*time.Minute {
+			return fmt.Errorf("--duration must be at least 10m")
+		}
+
+		toStdout := kubeconfigOutput == "-"
+		if !toStdout {
+			ui.Section(fmt.Sprintf("Learner Kubeconfig: %s", challengeSlug))
+		}
+
+		cluster, err := commandContextFrom(cmd.Context()).Cluster()
+		if err != nil {
+			ui.Error("Failed to get Kubernetes client. Is the cluster running? Try 'kubeasy setup'")
+			return err
+		}
+		clientset, restConfig := cluster.Clientset, cluster.RestConfig
+
+		if _, err := clientset.CoreV1().Namespaces().Get(cmd.Context(), challengeSlug, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				ui.Error(fmt.Sprintf("Namespace '%s' not found", challengeSlug))
+				ui.Info("Start the challenge first with 'kubeasy challenge start " + challengeSlug + "'")
+				return fmt.Errorf("challenge namespace %q not found", challengeSlug)
+			}
+			return fmt.Errorf("failed to get namespace %q: %w", challengeSlug, err)
+		}
+
+		var data []byte
+		generate := func() error {
+			if err := kube.EnsureLearnerAccess(cmd.Context(), clientset, challengeSlug, kubeconfigRole); err != nil {
+				return err
+			}
+			token, err := kube.CreateLearnerToken(cmd.Context(), clientset, challengeSlug, kubeconfigDuration)
+			if err != nil {
+				return err
+			}
+			data, err = kube.BuildLearnerKubeconfig(restConfig, challengeSlug, token)
+			return err
+		}
+		if toStdout {
+			err = generate()
+		} else {
+			err = ui.WaitMessage("Creating restricted credentials", generate)
+		}
+		if err != nil {
+			ui.Error("Failed to generate kubeconfig")
+			return err
+		}
+
+		if toStdout {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+
+		path := kubeconfigOutput
+		if path == "" {
+			path = constants.GetLearnerKubeconfigPath(challengeSlug)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write kubeconfig: %w", err)
+		}
+
+		ui.Success("Kubeconfig generated")
+		ui.KeyValue("File", path)
+		ui.KeyValue("Namespace", challengeSlug)
+		ui.KeyValue("Role", kubeconfigRole)
+		ui.KeyValue("Expires in", kubeconfigDuration.String())
+		ui.Println()
+		ui.Info(fmt.Sprintf("Run: export KUBECONFIG=%s", path))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(kubeconfigCmd)
+	kubeconfigCmd.Flags().StringVar(&kubeconfigRole, "role", kube.DefaultLearnerClusterRole, "ClusterRole granted inside the challenge namespace (view, edit, admin, ...)")
+	kubeconfigCmd.Flags().DurationVar(&kubeconfigDuration, "duration", 24*time.Hour, "Validity of the generated token")
+	kubeconfigCmd.Flags().StringVarP(&kubeconfigOutput, "output", "o", "", "Output file (default ~/.kubeasy/kubeconfigs/<slug>.yaml, '-' for stdout)")
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -27,7 +28,9 @@ The API key is stored in the most secure available location:
   - Local config file (~/.config/kubeasy-cli/credentials) as fallback
     for headless environments
 
-You can also set the KUBEASY_API_KEY environment variable for CI/CD use.
+You can also set the KUBEASY_TOKEN (or KUBEASY_API_KEY) environment variable for CI/CD use.
+KUBEASY_CREDENTIAL_STORE restricts where the key is read from and saved to:
+auto (default), env, keyring or file.
 
 This command will prompt you for your API key.
 If you don't have an API key or forgot it, visit https://kubeasy.dev/profile
@@ -37,7 +40,7 @@ After successful login, you will be able to use commands requiring authenticatio
 		ui.Section("Login to Kubeasy")
 
 		// Check if token already exists
-		existingToken, err := keystore.Get()
+		existingToken, err := api.CredentialStore().Get()
 		if err == nil && strings.TrimSpace(existingToken) != "" {
 			// Build expiration info from JWT
 			expInfo := ""
@@ -99,12 +102,14 @@ After successful login, you will be able to use commands requiring authenticatio
 		}
 
 		// Store the key
-		storageType, err := keystore.Set(apiKey)
+		storageType, err := api.CredentialStore().Set(apiKey)
 		if err != nil {
 			logger.Error("Failed to store API key: %v", err)
 			ui.Error("Failed to store API key")
 			ui.Println()
-			if configDir, dirErr := keystore.GetConfigDirPath(); dirErr == nil {
+			if errors.Is(err, keystore.ErrReadOnly) {
+				ui.Info(fmt.Sprintf("The %s credential store is read-only: set %s instead", keystore.StoreEnvVarName, keystore.TokenEnvVarName))
+			} else if configDir, dirErr := keystore.GetConfigDirPath(); dirErr == nil {
 				ui.Info(fmt.Sprintf("Please check that you have write access to: %s", configDir))
 			} else {
 				ui.Info("Please check that you have write access to the config directory")
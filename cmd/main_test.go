@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
@@ -8,8 +9,22 @@ import (
 )
 
 // TestMain enables CI mode for all cmd package tests to avoid pterm spinner
-// goroutine data races during testing.
+// goroutine data races during testing, and disables interactive prompts.
 func TestMain(m *testing.M) {
 	ui.SetCIMode(true)
+	ui.SetInteractive(false)
 	os.Exit(m.Run())
 }
+
+// captureUI returns a buffer receiving the uncolored ui output of the test.
+func captureUI(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var out bytes.Buffer
+	ui.SetOutput(&out)
+	ui.SetColorEnabled(false)
+	t.Cleanup(func() {
+		ui.SetOutput(nil)
+		ui.SetColorEnabled(true)
+	})
+	return &out
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var nsPrint bool
+
+var nsCmd = &cobra.Command{
+	Use:   "ns [challenge-slug]",
+	Short: "Point kubectl at the namespace of the active challenge",
+	Long: `Sets the current context of your kubeconfig to the kubeasy cluster and its
+default namespace to the challenge, so plain 'kubectl get pods' shows the
+challenge resources.
+
+Without argument, uses the only challenge started on this machine.
+
+With --print, the kubeconfig is left untouched and the kubectl commands are
+printed instead, e.g. to review them or run them with:
+  eval "$(kubeasy ns --print)"`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slug, err := activeChallenge(args)
+		if err != nil {
+			return err
+		}
+
+		if nsPrint {
+			for _, line := range nsCommands(slug) {
+				if _, err := fmt.Fprintln(cmd.OutOrStdout(), line); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if err := kube.SetNamespaceForContext(constants.KubeasyClusterContext, slug); err != nil {
+			ui.Error("Failed to update the kubeconfig. Is the cluster set up? Try 'kubeasy setup'")
+			return err
+		}
+		ui.Success(fmt.Sprintf("kubectl now uses namespace '%s'", slug))
+		ui.KeyValue("Context", constants.KubeasyClusterContext)
+		ui.KeyValue("Namespace", slug)
+
+		// SetNamespaceForContext edits ~/.kube/config: a KUBECONFIG pointing elsewhere
+		// keeps kubectl on another file, the usual reason it "shows nothing"
+		if path := kube.GetKubeConfigPath(); path != kube.GetDefaultKubeconfigPath() {
+			ui.Warning(fmt.Sprintf("KUBECONFIG is set to %s, which was not changed: kubectl will not use this setting", path))
+			ui.Info("Unset it, or run: eval \"$(kubeasy ns --print)\"")
+		}
+		return nil
+	},
+}
+
+// nsCommands returns the kubectl commands that point the current kubeconfig at slug.
+func nsCommands(slug string) []string {
+	return []string{
+		fmt.Sprintf("kubectl config use-context %s", constants.KubeasyClusterContext),
+		fmt.Sprintf("kubectl config set-context --current --namespace=%s", slug),
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(nsCmd)
+	nsCmd.Flags().BoolVar(&nsPrint, "print", false, "Print the kubectl commands instead of changing the kubeconfig")
+}
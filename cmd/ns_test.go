@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestNsCmd(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("KUBECONFIG", "")
+	kubeconfigPath := filepath.Join(home, ".kube", "config")
+	require.NoError(t, os.MkdirAll(filepath.Dir(kubeconfigPath), 0o755))
+	config := clientcmdapi.NewConfig()
+	config.Clusters["kind-kubeasy"] = &clientcmdapi.Cluster{Server: "https://127.0.0.1:6443"}
+	config.Contexts[constants.KubeasyClusterContext] = &clientcmdapi.Context{Cluster: "kind-kubeasy", Namespace: "default"}
+	config.Contexts["work"] = &clientcmdapi.Context{Cluster: "kind-kubeasy"}
+	config.CurrentContext = "work"
+	require.NoError(t, clientcmd.WriteToFile(*config, kubeconfigPath))
+
+	require.Error(t, nsCmd.RunE(nsCmd, nil), "nothing started yet")
+	require.NoError(t, audit.SaveStartTime("pod-evicted", time.Now()))
+
+	nsPrint = true
+	t.Cleanup(func() { nsPrint = false })
+	var out bytes.Buffer
+	nsCmd.SetOut(&out)
+	t.Cleanup(func() { nsCmd.SetOut(nil) })
+	require.NoError(t, nsCmd.RunE(nsCmd, nil))
+	assert.Equal(t, "kubectl config use-context kind-kubeasy\nkubectl config set-context --current --namespace=pod-evicted\n", out.String())
+	loaded, err := clientcmd.LoadFromFile(kubeconfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, "work", loaded.CurrentContext, "--print leaves the kubeconfig untouched")
+
+	nsPrint = false
+	require.NoError(t, nsCmd.RunE(nsCmd, nil))
+	loaded, err = clientcmd.LoadFromFile(kubeconfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, constants.KubeasyClusterContext, loaded.CurrentContext)
+	assert.Equal(t, "pod-evicted", loaded.Contexts[constants.KubeasyClusterContext].Namespace)
+
+	assert.Error(t, nsCmd.RunE(nsCmd, []string{"Invalid_Slug"}))
+}
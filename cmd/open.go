@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var openPrint bool
+
+// openBrowser opens url in the default browser; tests replace it.
+var openBrowser = defaultOpenBrowser
+
+var openCmd = &cobra.Command{
+	Use:   "open [challenge-slug]",
+	Short: "Open a challenge page in the browser",
+	Long: `Opens the page of a challenge on the Kubeasy website in the default browser.
+
+Use --print to only print the URL, e.g. on a machine without a desktop.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		challengeSlug := args[0]
+		if err := validateChallengeSlug(challengeSlug); err != nil {
+			return err
+		}
+
+		pageURL, err := challengePageURL(challengeSlug)
+		if err != nil {
+			return err
+		}
+
+		if openPrint {
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), pageURL)
+			return err
+		}
+
+		if err := openBrowser(pageURL); err != nil {
+			logger.Debug("Could not open browser: %v", err)
+			ui.Warning("Could not open a browser, visit the page at:")
+			ui.Text(pageURL)
+			return nil
+		}
+		ui.Info("Opened " + pageURL)
+		return nil
+	},
+}
+
+// challengePageURL returns the website page of a challenge.
+func challengePageURL(slug string) (string, error) {
+	pageURL, err := url.JoinPath(constants.WebsiteURL, "challenges", slug)
+	if err != nil {
+		return "", fmt.Errorf("invalid website URL %q: %w", constants.WebsiteURL, err)
+	}
+	return pageURL, nil
+}
+
+// defaultOpenBrowser starts the platform's URL handler without waiting for it.
+func defaultOpenBrowser(pageURL string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", pageURL)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", pageURL)
+	default:
+		c = exec.Command("xdg-open", pageURL)
+	}
+	if err := c.Start(); err != nil {
+		return err
+	}
+	go func() { _ = c.Wait() }()
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the URL instead of opening a browser")
+}
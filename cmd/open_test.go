@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengePageURL(t *testing.T) {
+	orig := constants.WebsiteURL
+	t.Cleanup(func() { constants.WebsiteURL = orig })
+
+	constants.WebsiteURL = "https://kubeasy.dev/"
+	got, err := challengePageURL("pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "https://kubeasy.dev/challenges/pod-evicted", got)
+}
+
+func TestOpenCmd(t *testing.T) {
+	origOpen, origPrint := openBrowser, openPrint
+	t.Cleanup(func() { openBrowser, openPrint = origOpen, origPrint })
+
+	var opened string
+	openBrowser = func(url string) error {
+		opened = url
+		return nil
+	}
+
+	openPrint = true
+	var out bytes.Buffer
+	openCmd.SetOut(&out)
+	t.Cleanup(func() { openCmd.SetOut(nil) })
+	require.NoError(t, openCmd.RunE(openCmd, []string{"pod-evicted"}))
+	assert.Contains(t, out.String(), "/challenges/pod-evicted")
+	assert.Empty(t, opened, "--print must not start a browser")
+
+	openPrint = false
+	require.NoError(t, openCmd.RunE(openCmd, []string{"pod-evicted"}))
+	assert.Contains(t, opened, "/challenges/pod-evicted")
+
+	openBrowser = func(string) error { return errors.New("no display") }
+	assert.NoError(t, openCmd.RunE(openCmd, []string{"pod-evicted"}), "a missing browser falls back to printing the URL")
+
+	assert.Error(t, openCmd.RunE(openCmd, []string{"INVALID_SLUG"}))
+}
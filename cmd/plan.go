@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+)
+
+// Multi-phase commands build their steps once, then either run them or, with
+// --dry-run, only print them, so the dry-run output always matches what the real
+// execution does.
+
+// clusterCleanupStep deletes the challenge namespace and restores the kubectl context.
+func clusterCleanupStep(slug string) steps.Step {
+	var actions []string
+	for _, step := range deployer.PlanCleanup(nil, slug) {
+		actions = append(actions, step.Description)
+	}
+	return steps.Step{
+		Name:    "cleanup",
+		Scope:   steps.ScopeCluster,
+		Actions: actions,
+		Run: func(ctx context.Context) error {
+			return deleteChallengeResources(ctx, slug)
+		},
+	}
+}
+
+// runSteps runs the steps of a command in order and logs their progress.
+func runSteps(ctx context.Context, command string, list []steps.Step) error {
+	return steps.Runner{OnEvent: logStepEvent(command)}.Run(ctx, list)
+}
+
+// logStepEvent returns a progress handler that writes step events to the debug log and
+// keeps the interrupt hint pointing at the step in progress.
+func logStepEvent(command string) func(steps.Event) {
+	started := map[string]time.Time{}
+	return func(e steps.Event) {
+		recordStepEvent(e, started)
+		switch e.Status {
+		case steps.StatusStarted:
+			setInterruptHint(fmt.Sprintf("%s stopped during step %q: run the same command again to finish it", command, e.Step))
+		case steps.StatusCompleted, steps.StatusSkipped:
+			if e.Index == e.Total {
+				setInterruptHint("")
+			}
+		}
+		if e.Err != nil {
+			logger.Debug("%s: step %d/%d %q %s: %v", command, e.Index, e.Total, e.Step, e.Status, e.Err)
+			return
+		}
+		logger.Debug("%s: step %d/%d %q %s", command, e.Index, e.Total, e.Step, e.Status)
+	}
+}
+
+// recordStepEvent adds a step event to the run log, with the step's duration once it ends.
+func recordStepEvent(e steps.Event, started map[string]time.Time) {
+	ev := runlog.Event{Type: runlog.EventStep, Step: e.Step, Status: string(e.Status)}
+	if e.Err != nil {
+		ev.Error = e.Err.Error()
+	}
+	if e.Status == steps.StatusStarted {
+		started[e.Step] = time.Now()
+	} else if t, ok := started[e.Step]; ok && e.Status != steps.StatusSkipped {
+		ev.DurationMs = time.Since(t).Milliseconds()
+	}
+	runlog.Record(ev)
+}
+
+// printPlan lists what the steps would do, without running them.
+func printPlan(list []steps.Step) {
+	ui.Println()
+	ui.Info("Dry run: nothing will be changed. The following actions would be performed:")
+	renderPlan(list)
+}
+
+// confirmPlan shows what the steps will do and asks the user to proceed. assumeYes
+// (--yes) skips both the listing and the prompt.
+func confirmPlan(list []steps.Step, question string, assumeYes bool) (bool, error) {
+	if !assumeYes {
+		ui.Println()
+		ui.Warning("The following actions will be performed:")
+		renderPlan(list)
+	}
+	return ui.Confirm(question, assumeYes)
+}
+
+func renderPlan(list []steps.Step) {
+	rows := make([][]string, 0, len(list))
+	for _, step := range list {
+		for _, action := range step.Actions {
+			rows = append(rows, []string{step.Scope, action})
+		}
+	}
+	if err := ui.Table([]string{"Scope", "Action"}, rows); err != nil {
+		for _, row := range rows {
+			ui.Text(fmt.Sprintf("  [%s] %s", row[0], row[1]))
+		}
+	}
+}
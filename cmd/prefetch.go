@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prefetchFromDocker bool
+	prefetchList       bool
+)
+
+// challengeImagesForPrefetch lists the images of a challenge; tests replace it.
+var challengeImagesForPrefetch = deployer.ChallengeImages
+
+var prefetchCmd = &cobra.Command{
+	Use:   "prefetch [challenge-slug]",
+	Short: "Pull the images of a challenge into the cluster ahead of time",
+	Long: `Reads the manifests of a challenge and pulls every container image they use, plus
+the probe pod image, into the kind cluster, so starting the challenge later does not
+wait on image pulls (e.g. before a class on a slow network).
+
+Images are pulled by the cluster nodes, through the registry mirrors configured at
+setup. Use --from-docker to pull them with the host's Docker instead and load them
+into the cluster, when Docker has a proxy or cache the cluster cannot reach.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		challengeSlug := args[0]
+		if err := validateChallengeSlug(challengeSlug); err != nil {
+			return err
+		}
+
+		var images []string
+		err := ui.WaitMessage("Reading challenge manifests", func() error {
+			var err error
+			images, err = challengeImagesForPrefetch(cmd.Context(), challengeSlug)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if prefetchList {
+			for _, image := range images {
+				if _, err := fmt.Fprintln(cmd.OutOrStdout(), image); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		exists, err := checkClusterExists()
+		if err != nil {
+			return err
+		}
+		if !exists {
+			ui.Error("Kind cluster 'kubeasy' does not exist")
+			ui.Info("Run 'kubeasy setup' first")
+			return fmt.Errorf("cluster %s not found", constants.KubeasyClusterName)
+		}
+		if clientset, err := commandContextFrom(cmd.Context()).Clientset(); err != nil {
+			logger.Debug("Could not resolve the probe image: %v", err)
+		} else {
+			images = append(images, deployer.ProbeImage(cmd.Context(), clientset))
+		}
+
+		pull := deployer.PullImageInCluster
+		if prefetchFromDocker {
+			pull = deployer.LoadImageFromDocker
+		}
+		return prefetchImages(cmd.Context(), images, pull)
+	},
+}
+
+// prefetchImages pulls each image with pull, going on after a failure so one bad
+// image does not keep the others out of the cluster.
+func prefetchImages(ctx context.Context, images []string, pull func(ctx context.Context, clusterName, image string) error) error {
+	ui.Section(fmt.Sprintf("Prefetching %d image(s)", len(images)))
+	var failed []string
+	for _, image := range images {
+		err := ui.TimedSpinner("Pulling "+image, func() error {
+			return pull(ctx, constants.KubeasyClusterName, image)
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warning("Failed to prefetch %s: %v", image, err)
+			failed = append(failed, image)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to prefetch %d of %d image(s): %s", len(failed), len(images), strings.Join(failed, ", "))
+	}
+	ui.Success("All images are in the cluster")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(prefetchCmd)
+	prefetchCmd.Flags().BoolVar(&prefetchFromDocker, "from-docker", false, "Pull with the host's Docker and load the images into the cluster")
+	prefetchCmd.Flags().BoolVar(&prefetchList, "list", false, "Only print the images of the challenge")
+}
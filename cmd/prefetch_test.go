@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefetchCmd_List(t *testing.T) {
+	origImages, origList := challengeImagesForPrefetch, prefetchList
+	t.Cleanup(func() { challengeImagesForPrefetch, prefetchList = origImages, origList })
+
+	challengeImagesForPrefetch = func(_ context.Context, slug string) ([]string, error) {
+		assert.Equal(t, "pod-evicted", slug)
+		return []string{"nginx:1.27", "redis:7"}, nil
+	}
+	prefetchList = true
+	var out bytes.Buffer
+	prefetchCmd.SetOut(&out)
+	t.Cleanup(func() { prefetchCmd.SetOut(nil) })
+
+	require.NoError(t, prefetchCmd.RunE(prefetchCmd, []string{"pod-evicted"}))
+	assert.Equal(t, "nginx:1.27\nredis:7\n", out.String())
+
+	assert.Error(t, prefetchCmd.RunE(prefetchCmd, []string{"INVALID_SLUG"}))
+}
+
+func TestPrefetchImages(t *testing.T) {
+	var pulled []string
+	pull := func(_ context.Context, clusterName, image string) error {
+		assert.Equal(t, "kubeasy", clusterName)
+		pulled = append(pulled, image)
+		if image == "broken:1" {
+			return errors.New("not found")
+		}
+		return nil
+	}
+
+	require.NoError(t, prefetchImages(context.Background(), []string{"nginx:1.27"}, pull))
+
+	pulled = nil
+	err := prefetchImages(context.Background(), []string{"broken:1", "redis:7"}, pull)
+	assert.EqualError(t, err, "failed to prefetch 1 of 2 image(s): broken:1")
+	assert.Equal(t, []string{"broken:1", "redis:7"}, pulled, "a failure does not stop the other pulls")
+}
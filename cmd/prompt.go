@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// defaultPromptFormat is the --format of 'kubeasy prompt'.
+const defaultPromptFormat = "{challenge} {objectives} {timer}"
+
+var promptFormat string
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt [challenge-slug]",
+	Short: "Print a compact challenge status for shell prompts",
+	Long: `Prints a one-line status of the active challenge for PS1 or starship, e.g.
+"pod-evicted 2/3 12m": the challenge, the objectives passed at the last
+submission and the elapsed time (or the remaining time, prefixed with "-", for
+time-boxed attempts).
+
+It only reads the local state under ~/.kubeasy: no network call is made, so it is
+fast enough to run on every prompt. It prints nothing, and never fails, when no
+challenge is started. With several started challenges, the one the kubeasy
+context points at (see 'kubeasy ns') is used.
+
+--format accepts the placeholders {challenge}, {objectives}, {passed}, {total},
+{elapsed}, {remaining} and {timer}; empty ones are dropped. Starship example:
+  [custom.kubeasy]
+  command = "kubeasy prompt"
+  when = true`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Annotations:   map[string]string{noRunLogAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slug := promptChallenge(args)
+		if slug == "" {
+			return nil
+		}
+		line := renderPrompt(promptFormat, loadPromptStatus(slug, time.Now()))
+		if line == "" {
+			return nil
+		}
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), line)
+		return err
+	},
+}
+
+// promptStatus is what 'kubeasy prompt' knows about a challenge from the local state.
+type promptStatus struct {
+	Slug string
+	// Passed and Total count the objectives of the last submission; Total is 0
+	// before the first one.
+	Passed, Total int
+	Elapsed       time.Duration
+	// Remaining is the time left of a time-boxed attempt.
+	Remaining    time.Duration
+	HasStart     bool
+	HasTimeLimit bool
+}
+
+// promptChallenge returns the challenge to describe, or "" when there is none. Errors
+// are only logged: a prompt must not print them.
+func promptChallenge(args []string) string {
+	if len(args) == 1 {
+		if err := validateChallengeSlug(args[0]); err != nil {
+			return ""
+		}
+		return args[0]
+	}
+	slugs, err := audit.ListStartedChallenges()
+	if err != nil {
+		logger.Debug("Could not list started challenges: %v", err)
+		return ""
+	}
+	switch len(slugs) {
+	case 0:
+		return ""
+	case 1:
+		return slugs[0]
+	}
+	namespace, err := kube.ContextNamespace(constants.KubeasyClusterContext)
+	if err != nil {
+		logger.Debug("Could not read the namespace of the kubeasy context: %v", err)
+		return ""
+	}
+	if slices.Contains(slugs, namespace) {
+		return namespace
+	}
+	return ""
+}
+
+// loadPromptStatus reads the status of slug from the local state at now.
+func loadPromptStatus(slug string, now time.Time) promptStatus {
+	status := promptStatus{Slug: slug}
+	if startedAt, err := audit.LoadStartTime(slug); err == nil {
+		status.HasStart = true
+		status.Elapsed = now.Sub(startedAt)
+		if limit, ok, err := audit.LoadTimeLimit(slug); err == nil && ok {
+			status.HasTimeLimit = true
+			status.Remaining = limit.Remaining(startedAt, now)
+		}
+	}
+	results, err := audit.LoadLastResults(slug)
+	if err != nil {
+		logger.Debug("Could not load last results for %s: %v", slug, err)
+	}
+	status.Total = len(results)
+	for _, r := range results {
+		if r.Passed {
+			status.Passed++
+		}
+	}
+	return status
+}
+
+// renderPrompt fills format with status, dropping the whitespace left by empty
+// placeholders.
+func renderPrompt(format string, status promptStatus) string {
+	var objectives, passed, total, elapsed, remaining, timer string
+	if status.Total > 0 {
+		passed, total = fmt.Sprint(status.Passed), fmt.Sprint(status.Total)
+		objectives = passed + "/" + total
+	}
+	if status.HasStart {
+		elapsed = formatPromptDuration(status.Elapsed)
+		timer = elapsed
+	}
+	if status.HasTimeLimit {
+		remaining = "-" + formatPromptDuration(status.Remaining)
+		if status.Remaining <= 0 {
+			remaining = "expired"
+		}
+		timer = remaining
+	}
+	line := strings.NewReplacer(
+		"{challenge}", status.Slug,
+		"{objectives}", objectives,
+		"{passed}", passed,
+		"{total}", total,
+		"{elapsed}", elapsed,
+		"{remaining}", remaining,
+		"{timer}", timer,
+	).Replace(format)
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// formatPromptDuration renders a duration to the minute, e.g. "1h05m" or "12m".
+func formatPromptDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Truncate(time.Minute)
+	h := int(d / time.Hour)
+	m := int(d % time.Hour / time.Minute)
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+	promptCmd.Flags().StringVar(&promptFormat, "format", defaultPromptFormat, "Layout of the status line (see the placeholders above)")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	status := promptStatus{Slug: "pod-evicted", Passed: 2, Total: 3, Elapsed: 65*time.Minute + 30*time.Second, HasStart: true}
+	assert.Equal(t, "pod-evicted 2/3 1h05m", renderPrompt(defaultPromptFormat, status))
+	assert.Equal(t, "pod-evicted 1h05m", renderPrompt(defaultPromptFormat, promptStatus{Slug: "pod-evicted", Elapsed: time.Hour + 5*time.Minute, HasStart: true}), "no submission yet")
+	assert.Equal(t, "k8s:pod-evicted(2 of 3)", renderPrompt("k8s:{challenge}({passed} of {total})", status))
+
+	status.HasTimeLimit, status.Remaining = true, 12*time.Minute+59*time.Second
+	assert.Equal(t, "pod-evicted 2/3 -12m", renderPrompt(defaultPromptFormat, status))
+	status.Remaining = -time.Minute
+	assert.Equal(t, "pod-evicted 2/3 expired", renderPrompt(defaultPromptFormat, status))
+}
+
+func TestPromptCmd(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	var out bytes.Buffer
+	promptCmd.SetOut(&out)
+	t.Cleanup(func() { promptCmd.SetOut(nil) })
+
+	require.NoError(t, promptCmd.RunE(promptCmd, nil))
+	assert.Empty(t, out.String(), "nothing started: nothing printed")
+
+	require.NoError(t, audit.SaveStartTime("pod-evicted", time.Now().Add(-3*time.Minute)))
+	require.NoError(t, audit.SaveLastResults("pod-evicted", []audit.ObservedResult{{Key: "a", Passed: true}, {Key: "b"}}))
+	require.NoError(t, promptCmd.RunE(promptCmd, nil))
+	assert.Equal(t, "pod-evicted 1/2 3m\n", out.String())
+
+	// With several challenges started, the namespace of the kubeasy context decides
+	require.NoError(t, audit.SaveStartTime("other", time.Now()))
+	out.Reset()
+	require.NoError(t, promptCmd.RunE(promptCmd, nil))
+	assert.Empty(t, out.String(), "no kubeconfig to choose from")
+
+	kubeconfigPath := filepath.Join(home, ".kube", "config")
+	require.NoError(t, os.MkdirAll(filepath.Dir(kubeconfigPath), 0o755))
+	config := clientcmdapi.NewConfig()
+	config.Contexts[constants.KubeasyClusterContext] = &clientcmdapi.Context{Cluster: "kind-kubeasy", Namespace: "other"}
+	require.NoError(t, clientcmd.WriteToFile(*config, kubeconfigPath))
+	require.NoError(t, promptCmd.RunE(promptCmd, nil))
+	assert.Equal(t, "other 0m\n", out.String())
+}
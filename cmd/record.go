@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// recordCluster makes the cluster clients of cc record every response they read in
+// namespace. It must be called before the first use of cc.Cluster.
+func (cc *commandContext) recordCluster(namespace string) *kube.Recorder {
+	recorder := kube.NewRecorder(namespace)
+	connect := cc.Connect
+	cc.Connect = func() (*sdk.Cluster, error) {
+		cluster, err := connect()
+		if err != nil {
+			return nil, err
+		}
+		return clusterFromConfig(recorder.Wrap(cluster.RestConfig))
+	}
+	return recorder
+}
+
+// replayCluster makes the cluster clients of cc answer from the recording in dir
+// instead of the cluster. It must be called before the first use of cc.Cluster.
+func (cc *commandContext) replayCluster(dir string) (*kube.Recording, error) {
+	recording, err := kube.LoadRecording(dir)
+	if err != nil {
+		return nil, err
+	}
+	cc.Connect = func() (*sdk.Cluster, error) {
+		return clusterFromConfig(recording.ReplayConfig())
+	}
+	return recording, nil
+}
+
+func clusterFromConfig(config *rest.Config) (*sdk.Cluster, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return &sdk.Cluster{Clientset: clientset, DynamicClient: dynamicClient, RestConfig: config}, nil
+}
+
+// saveRecording writes what recorder recorded to dir. A failure is reported but does
+// not fail the command: the run itself went through.
+func saveRecording(recorder *kube.Recorder, dir string, quiet bool) {
+	if err := recorder.Save(dir); err != nil {
+		logger.Debug("Could not save recording: %v", err)
+		if !quiet {
+			ui.Warning(fmt.Sprintf("Could not save the recording to %s: %v", dir, err))
+		}
+		return
+	}
+	if !quiet {
+		ui.Info(fmt.Sprintf("Recorded %d cluster responses to %s (replay with 'kubeasy dev validate <slug> --replay %s')", recorder.Len(), dir, dir))
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestCommandContextRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-evicted", UID: "uid-1"},
+		})
+	}))
+	defer server.Close()
+
+	cc := testCommandContext()
+	cc.Connect = func() (*sdk.Cluster, error) {
+		return &sdk.Cluster{RestConfig: &rest.Config{Host: server.URL}}, nil
+	}
+	recorder := cc.recordCluster("pod-evicted")
+	clientset, err := cc.Clientset()
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().Namespaces().Get(context.Background(), "pod-evicted", metav1.GetOptions{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	saveRecording(recorder, dir, true)
+	server.Close()
+
+	replayed := testCommandContext()
+	recording, err := replayed.replayCluster(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "pod-evicted", recording.Namespace)
+	clientset, err = replayed.Clientset()
+	require.NoError(t, err)
+	ns, err := clientset.CoreV1().Namespaces().Get(context.Background(), "pod-evicted", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "uid-1", string(ns.UID))
+
+	_, err = testCommandContext().replayCluster(t.TempDir())
+	assert.Error(t, err, "no recording in the directory")
+}
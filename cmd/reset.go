@@ -1,17 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-// getChallengeFn allows tests to inject a fake getChallenge implementation.
-var getChallengeFn = getChallenge
+var (
+	resetDryRun bool
+	resetYes    bool
+)
 
 var resetChallengeCmd = &cobra.Command{
 	Use:   "reset [challenge-slug]",
@@ -29,35 +30,29 @@ var resetChallengeCmd = &cobra.Command{
 		ui.Section(fmt.Sprintf("Resetting Challenge: %s", challengeSlug))
 
 		// Verify challenge exists
-		_, err := getChallengeFn(challengeSlug)
+		_, err := getChallenge(cmd.Context(), challengeSlug)
 		if err != nil {
 			ui.Error(err.Error())
 			return err
 		}
 
-		// Delete resources
-		if err := deleteChallengeResources(cmd.Context(), challengeSlug); err != nil {
-			return err
+		plan := resetPlan(cmd.Context(), challengeSlug)
+		if resetDryRun {
+			printPlan(plan)
+			return nil
 		}
 
-		// Reset progress on server
-		err = ui.WaitMessage("Resetting challenge progress on server", func() error {
-			result, err := api.ResetChallenge(cmd.Context(), challengeSlug)
-			if err != nil {
-				return err
-			}
-			if !result.Success {
-				return fmt.Errorf("reset failed: %s", result.Message)
-			}
-			return nil
-		})
+		confirmed, err := confirmPlan(plan, fmt.Sprintf("Reset challenge '%s'? Your progress and submissions will be lost", challengeSlug), resetYes)
 		if err != nil {
-			ui.Error("Failed to reset challenge progress")
-			return fmt.Errorf("failed to reset challenge progress: %w", err)
+			return err
+		}
+		if !confirmed {
+			ui.Info("Reset cancelled")
+			return nil
 		}
 
-		if err := audit.ClearState(challengeSlug); err != nil {
-			logger.Debug("Could not clear audit state: %v", err)
+		if err := runSteps(cmd.Context(), "reset", plan); err != nil {
+			return err
 		}
 
 		ui.Println()
@@ -68,6 +63,14 @@ var resetChallengeCmd = &cobra.Command{
 	},
 }
 
+// resetPlan deletes the challenge resources, resets progress on the server and
+// clears the local state, in that order.
+func resetPlan(ctx context.Context, challengeSlug string) []steps.Step {
+	return commandContextFrom(ctx).sdkClient(nil).ResetPlan(challengeSlug)
+}
+
 func init() {
 	challengeCmd.AddCommand(resetChallengeCmd)
+	resetChallengeCmd.Flags().BoolVarP(&resetYes, "yes", "y", false, "Skip the confirmation prompt (required when not running in a terminal)")
+	resetChallengeCmd.Flags().BoolVar(&resetDryRun, "dry-run", false, "Print what would be deleted and reset without changing anything")
 }
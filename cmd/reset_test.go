@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,17 +23,62 @@ func TestResetRunE_InvalidSlug(t *testing.T) {
 
 // TestResetRunE_APIFailure verifies that a getChallenge API failure returns a non-nil error without panic.
 func TestResetRunE_APIFailure(t *testing.T) {
-	orig := getChallengeFn
-	t.Cleanup(func() {
-		getChallengeFn = orig
-	})
-
-	getChallengeFn = func(slug string) (*api.ChallengeEntity, error) {
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(_ context.Context, slug string) (*api.ChallengeEntity, error) {
 		return nil, fmt.Errorf("challenge not found")
 	}
+	useCommandContext(t, resetChallengeCmd, cc)
 
 	assert.NotPanics(t, func() {
 		err := resetChallengeCmd.RunE(resetChallengeCmd, []string{"pod-evicted"})
 		require.Error(t, err)
 	})
 }
+
+// TestResetRunE_DryRunChangesNothing verifies that --dry-run keeps the local state.
+func TestResetRunE_DryRunChangesNothing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	origDryRun := resetDryRun
+	t.Cleanup(func() { resetDryRun = origDryRun })
+
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(_ context.Context, slug string) (*api.ChallengeEntity, error) {
+		return &api.ChallengeEntity{}, nil
+	}
+	useCommandContext(t, resetChallengeCmd, cc)
+	resetDryRun = true
+	require.NoError(t, audit.SaveTimestamp("pod-evicted"))
+
+	err := resetChallengeCmd.RunE(resetChallengeCmd, []string{"pod-evicted"})
+	require.NoError(t, err)
+	assert.DirExists(t, audit.GetStateDir("pod-evicted"))
+}
+
+// TestResetPlan_CoversClusterAPIAndLocalState verifies that the plan lists every kind of state reset touches.
+func TestResetPlan_CoversClusterAPIAndLocalState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	plan := resetPlan(withCommandContext(context.Background(), testCommandContext()), "pod-evicted")
+	require.Len(t, plan, 3)
+	assert.Equal(t, steps.ScopeCluster, plan[0].Scope)
+	assert.Contains(t, plan[0].Actions[0], kube.ValidatorServiceAccountName)
+	assert.Contains(t, plan[0].Actions[1], "namespace 'pod-evicted'")
+	assert.Equal(t, steps.ScopeAPI, plan[1].Scope)
+	assert.Equal(t, steps.ScopeLocal, plan[2].Scope)
+	assert.Contains(t, plan[2].Actions[0], audit.GetStateDir("pod-evicted"))
+}
+
+// TestResetRunE_RequiresYesWhenNonInteractive verifies that nothing is reset unattended without --yes.
+func TestResetRunE_RequiresYesWhenNonInteractive(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(_ context.Context, slug string) (*api.ChallengeEntity, error) {
+		return &api.ChallengeEntity{}, nil
+	}
+	useCommandContext(t, resetChallengeCmd, cc)
+	require.NoError(t, audit.SaveTimestamp("pod-evicted"))
+
+	err := resetChallengeCmd.RunE(resetChallengeCmd, []string{"pod-evicted"})
+	require.ErrorIs(t, err, ui.ErrConfirmationRequired)
+	assert.DirExists(t, audit.GetStateDir("pod-evicted"))
+}
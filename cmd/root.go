@@ -4,16 +4,29 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
-var noSpinner bool
+var (
+	noSpinner bool
+	noColor   bool
+)
+
+// noRunLogAnnotation marks commands run too often to record a run log for.
+const noRunLogAnnotation = "kubeasy/no-run-log"
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -25,7 +38,7 @@ examples and usage of using your application. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize logger globally here with INFO level
 		loggerOpts := logger.DefaultOptions()
 		loggerOpts.FilePath = constants.LogFilePath
@@ -34,10 +47,38 @@ to quickly create a Cobra application.`,
 		logger.Initialize(loggerOpts)
 		logger.Info("Kubeasy CLI started - logging to: %s", constants.LogFilePath)
 
+		// Shell completion requests run on every <Tab>, and prompt commands on every
+		// prompt: they are not worth a run log
+		if !strings.HasPrefix(cmd.Name(), "__") && cmd.Annotations[noRunLogAnnotation] == "" {
+			if run, err := runlog.Start(cmd.CommandPath(), args); err != nil {
+				logger.Debug("Could not create run log: %v", err)
+			} else {
+				logger.Info("Run %s - events recorded in %s", run.ID, run.Path)
+			}
+		}
+
 		// Enable CI mode if --no-spinner flag is set or stdout is not a TTY
-		if noSpinner || !term.IsTerminal(int(os.Stdout.Fd())) {
+		isTerminal := term.IsTerminal(int(os.Stdout.Fd()))
+		if noSpinner || !isTerminal {
 			ui.SetCIMode(true)
 		}
+		ui.ConfigureColor(noColor, isTerminal)
+		// Prompts need a terminal on both ends; otherwise destructive commands require --yes
+		ui.SetInteractive(isTerminal && term.IsTerminal(int(os.Stdin.Fd())))
+		kube.ServerWarning = ui.Warning
+
+		cc := newCommandContext()
+		api.SetCredentialStore(cc.Credentials)
+		if fakeClusterScenario != "" {
+			scenario, err := cc.useFakeCluster(fakeClusterScenario)
+			if err != nil {
+				ui.Error(fmt.Sprintf("Could not load the fake cluster scenario: %v", err))
+				return err
+			}
+			logger.Info("Using a fake in-memory cluster for challenge '%s' (scenario %s)", scenario.Challenge.Slug, fakeClusterScenario)
+		}
+		setCommandContext(cmd, cc)
+		return nil
 	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
@@ -47,9 +88,29 @@ to quickly create a Cobra application.`,
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, release := interruptContext(context.Background())
+	executed, err := rootCmd.ExecuteContextC(ctx)
+	// Watch modes stop on Ctrl-C by design and return nil: only a command that failed
+	// because of the interruption reports it
+	interrupted := ctx.Err() != nil && err != nil
+	release()
+
+	run := runlog.Current()
+	run.Finish(err, interrupted)
+	copyClusterAudit(run)
+	if err != nil {
+		reportErrorCode(executed, err, os.Stderr)
+	}
+	if err != nil && run != nil {
+		fmt.Fprintf(os.Stderr, "See run %s for details: %s\n", run.ID, run.Path)
+	}
+
+	if interrupted {
+		reportInterrupted()
+	}
+	logger.Sync()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err, interrupted))
 	}
 }
 
@@ -61,6 +122,13 @@ func init() {
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.kubeasy-cli.yaml)")
 
 	rootCmd.PersistentFlags().BoolVar(&noSpinner, "no-spinner", false, "Force plain text output (spinners are disabled automatically when stdout is not a TTY)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also disabled by NO_COLOR or when stdout is not a TTY)")
+	rootCmd.PersistentFlags().StringVar(&fakeClusterScenario, "fake-cluster", "", "Run against an in-memory cluster and API seeded from this scenario file, e.g. for demos (no kind or docker needed)")
+	// Unknown flags and invalid flag values are usage errors
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return kerrors.Wrap(kerrors.CodeInvalidInput, err)
+	})
+	rootCmd.PersistentFlags().BoolVar(&skipClusterGuard, "i-know-what-im-doing", false, "Modify the cluster of the kubeasy context even if 'kubeasy setup' did not mark it as kubeasy's")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+)
+
+// sdkClient returns an SDK client over the API functions and cluster of cc that
+// reports progress through the terminal UI. onStep, when set, receives the progress
+// of multi-step flows.
+func (cc *commandContext) sdkClient(onStep func(steps.Event)) *sdk.Client {
+	return sdk.New(sdk.Config{
+		API:          cc.API,
+		Connect:      cc.Cluster,
+		GuardCluster: guardKubeasyCluster,
+		Deploy:       cc.Deploy,
+		Reporter: sdk.Reporter{
+			Task:  ui.WaitMessage,
+			Info:  ui.Info,
+			Warn:  ui.Warning,
+			Step:  onStep,
+			Ready: reportReadyProgress,
+		},
+	})
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/dashboard"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/notify"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var (
+	servePort   int
+	serveOpen   bool
+	serveNotify bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [challenge-slug]",
+	Short: "Serve a local web dashboard for a challenge",
+	Long: `Starts a web dashboard on localhost showing the live status of the objectives
+of a started challenge, and the pods and events of its namespace. The same data is
+available as JSON under /api (status, pods, events).
+
+Without argument, serves the only challenge started on this machine. The server
+only listens on 127.0.0.1 and stops on Ctrl+C.
+
+With --notify, the objectives are checked every few seconds even without a
+browser open, and a desktop notification is shown when one starts or stops
+passing, or when the whole challenge passes.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		slug, err := activeChallenge(args)
+		if err != nil {
+			return err
+		}
+
+		config, err := loadUserValidations(ctx, slug)
+		if err != nil {
+			ui.Error("Failed to load validations")
+			return fmt.Errorf("failed to load validations: %w", err)
+		}
+
+		cluster, err := commandContextFrom(ctx).Cluster()
+		if err != nil {
+			return err
+		}
+
+		executor := validation.NewExecutor(cluster.Clientset, cluster.DynamicClient, cluster.RestConfig, slug)
+		executor.EnableCache()
+		defer executor.Close()
+
+		dashboardConfig := dashboard.Config{
+			Slug:        slug,
+			Namespace:   slug,
+			Validations: config.Validations,
+			Runner:      executor,
+			Clientset:   cluster.Clientset,
+		}
+		if serveNotify {
+			dashboardConfig.OnResults = notifyResults(notify.New(slug))
+		}
+		server := dashboard.New(dashboardConfig)
+		if serveNotify {
+			go server.Watch(ctx)
+		}
+		return serveDashboard(ctx, server.Handler(), servePort, serveOpen)
+	},
+}
+
+// serveDashboard serves handler on the loopback interface until ctx is cancelled.
+func serveDashboard(ctx context.Context, handler http.Handler, port int, open bool) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	pageURL := "http://" + listener.Addr().String()
+	ui.Success("Dashboard available at " + pageURL)
+	ui.Info("Press Ctrl+C to stop")
+	if open {
+		if err := openBrowser(pageURL); err != nil {
+			logger.Debug("Could not open browser: %v", err)
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Serve(listener) }()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("dashboard server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Debug("Dashboard shutdown: %v", err)
+	}
+	ui.Info("Dashboard stopped")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8420, "Port to listen on (0 picks a free port)")
+	serveCmd.Flags().BoolVar(&serveOpen, "open", false, "Open the dashboard in the default browser")
+	serveCmd.Flags().BoolVar(&serveNotify, "notify", false, "Show desktop notifications when objectives change state")
+}
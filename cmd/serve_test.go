@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeDashboard_StopsWithContext(t *testing.T) {
+	origOpen := openBrowser
+	t.Cleanup(func() { openBrowser = origOpen })
+	opened := make(chan string, 1)
+	openBrowser = func(url string) error {
+		opened <- url
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	go func() { done <- serveDashboard(ctx, handler, 0, true) }()
+
+	var url string
+	select {
+	case url = <-opened:
+	case <-time.After(5 * time.Second):
+		t.Fatal("dashboard did not start")
+	}
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("dashboard did not stop")
+	}
+}
@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path"
+	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/keystore"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/spf13/cobra"
 	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
@@ -33,14 +38,16 @@ func checkClusterExists() (bool, error) {
 	return clusterExists, nil
 }
 
-// kindClusterConfig returns the Kind cluster configuration with extraPortMappings for nginx-ingress
-// and ExtraMounts + KubeadmConfigPatches to enable API server audit logging.
+// kindClusterConfig returns the Kind cluster configuration with extraPortMappings for nginx-ingress,
+// ExtraMounts + KubeadmConfigPatches to enable API server audit logging, and the containerd
+// registry host configs (--registry-mirror).
 func kindClusterConfig() *kindv1alpha4.Cluster {
 	return &kindv1alpha4.Cluster{
 		TypeMeta: kindv1alpha4.TypeMeta{
 			Kind:       "Cluster",
 			APIVersion: "kind.x-k8s.io/v1alpha4",
 		},
+		ContainerdConfigPatches: []string{deployer.ContainerdConfigPatch},
 		Nodes: []kindv1alpha4.Node{
 			{
 				Role: kindv1alpha4.ControlPlaneRole,
@@ -59,6 +66,11 @@ func kindClusterConfig() *kindv1alpha4.Cluster {
 						ContainerPath: "/var/log/kubernetes/audit",
 						Readonly:      false,
 					},
+					{
+						HostPath:      constants.GetContainerdHostsDir(),
+						ContainerPath: deployer.ContainerdHostsPath,
+						Readonly:      true,
+					},
 				},
 				KubeadmConfigPatches: []string{`kind: ClusterConfiguration
 apiServer:
@@ -92,6 +104,11 @@ func createClusterWithConfig() error {
 		ui.Warning("Could not write audit policy file — audit logging may not be available (check permissions on " + audit.GetAuditDir() + ")")
 	}
 
+	// The registry host configs are mounted too: the directory must exist, even empty.
+	if err := os.MkdirAll(constants.GetContainerdHostsDir(), 0o750); err != nil {
+		return fmt.Errorf("failed to create registry mirrors dir: %w", err)
+	}
+
 	// Write config before creating cluster so it is available for future checks.
 	if err := deployer.WriteKindConfig(cfg); err != nil {
 		logger.Debug("Could not write kind config: %v", err)
@@ -117,6 +134,12 @@ func printComponentResult(r deployer.ComponentResult) {
 	}
 }
 
+var (
+	setupDryRun          bool
+	setupRegistryMirrors []string
+	setupManifestsDir    string
+)
+
 var setupCmd = &cobra.Command{
 	Use:   "setup",
 	Short: "Setup",
@@ -124,124 +147,298 @@ var setupCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ui.PrintLogo()
 		ui.Section("Kubeasy Environment Setup")
-		ui.Println()
 
-		// Require authentication
-		if token, err := keystore.Get(); err != nil || token == "" {
-			ui.Error("You must be logged in to set up Kubeasy")
-			ui.Info("Run 'kubeasy login' first")
-			return fmt.Errorf("authentication required: run 'kubeasy login' first")
+		mirrors, err := deployer.ParseContainerdMirrors(setupRegistryMirrors)
+		if err != nil {
+			return err
+		}
+		if setupManifestsDir != "" {
+			if err := checkManifestsDir(setupManifestsDir); err != nil {
+				return err
+			}
+			deployer.ManifestsDir = setupManifestsDir
 		}
 
-		// Step 1: Check/Create cluster
-		exists, err := checkClusterExists()
-		if err != nil {
+		plan := setupSteps(mirrors)
+		if setupDryRun {
+			printPlan(plan)
+			ui.Info("Installed components that are already ready are skipped")
+			previewComponents(cmd.Context())
+			return nil
+		}
+		ui.Println()
+
+		if err := runSteps(cmd.Context(), "setup", plan); err != nil {
 			return err
 		}
 
-		ref := kindClusterConfig()
+		ui.Success("Kubeasy environment is ready!")
+		ui.Info("You can now start challenges with 'kubeasy challenge start <slug>'")
+		return nil
+	},
+}
 
-		if !exists {
-			// Cluster does not exist — create with port mappings.
-			err := ui.TimedSpinner(
-				fmt.Sprintf("Creating kind cluster 'kubeasy' (Kubernetes %s)", constants.GetKubernetesVersion()),
-				createClusterWithConfig,
-			)
-			if err != nil {
-				ui.Error(fmt.Sprintf("Failed to create Kind cluster with image %s", constants.KindNodeImage))
-				ui.Info("Verify that the Kind node image is available")
-				ui.Info("You can manually pull: docker pull " + constants.KindNodeImage)
-				return fmt.Errorf("failed to create kind cluster with image %s: %w", constants.KindNodeImage, err)
-			}
-		} else {
-			// Cluster already exists — compare installed config against reference.
-			if !deployer.KindConfigMatches(ref) {
-				ui.Warning("Kind cluster 'kubeasy' configuration has drifted from the current reference")
-				ui.Info("The installed cluster was created with a different configuration (e.g. missing port mappings or updated settings)")
-				confirmed := ui.Confirmation("Recreate cluster with the updated configuration? (This will DELETE the existing cluster)")
-				if confirmed {
-					err := ui.TimedSpinner("Deleting existing cluster...", func() error {
-						return cluster.NewProvider().Delete("kubeasy", "")
-					})
-					if err != nil {
-						ui.Error("Failed to delete existing cluster")
-						return fmt.Errorf("failed to delete kind cluster: %w", err)
-					}
-					err = ui.TimedSpinner(
-						fmt.Sprintf("Recreating kind cluster 'kubeasy' (Kubernetes %s)", constants.GetKubernetesVersion()),
-						createClusterWithConfig,
-					)
-					if err != nil {
-						ui.Error(fmt.Sprintf("Failed to recreate Kind cluster with image %s", constants.KindNodeImage))
-						ui.Info("Verify that the Kind node image is available")
-						ui.Info("You can manually pull: docker pull " + constants.KindNodeImage)
-						return fmt.Errorf("failed to recreate kind cluster with image %s: %w", constants.KindNodeImage, err)
+// setupSteps configures the registry mirrors, creates (or checks) the kind cluster,
+// installs the infrastructure components and reports the setup to the API. Without
+// mirrors, the ones configured by a previous setup are kept.
+func setupSteps(mirrors []deployer.ContainerdMirror) []steps.Step {
+	clusterActions := []string{"Create or check the kind cluster 'kubeasy'"}
+	for _, m := range mirrors {
+		clusterActions = append(clusterActions, fmt.Sprintf("Pull %s images through %s", m.Registry, m.Endpoint))
+	}
+	return []steps.Step{
+		{
+			Name:         "cluster",
+			Scope:        steps.ScopeCluster,
+			Actions:      clusterActions,
+			Precondition: requireLogin,
+			Run: func(ctx context.Context) error {
+				if len(mirrors) > 0 {
+					if err := deployer.WriteContainerdMirrors(constants.GetContainerdHostsDir(), mirrors); err != nil {
+						ui.Error("Failed to configure the registry mirrors")
+						return err
 					}
-				} else {
-					ui.Warning("Skipping cluster recreation — some features may not work correctly")
 				}
-			} else {
-				// Detect actual cluster version and compare with expected
-				actualVersion, err := kube.GetServerVersion()
+				return ensureCluster(ctx)
+			},
+		},
+		{
+			Name:    "components",
+			Scope:   steps.ScopeCluster,
+			Actions: componentActions(),
+			Run:     installComponents,
+		},
+		{
+			Name:    "track",
+			Scope:   steps.ScopeAPI,
+			Actions: []string{"Report the setup to the API"},
+			Run: func(ctx context.Context) error {
+				api.TrackSetup(ctx)
+				return nil
+			},
+		},
+	}
+}
+
+// diffComponents is a variable so tests can preview without downloading manifests.
+var diffComponents = deployer.DiffComponents
+
+// previewComponents prints what installing the components would change in the kubeasy
+// cluster, from a server-side dry run of their manifests. Without a cluster there is
+// nothing to compare: every component would be installed.
+func previewComponents(ctx context.Context) {
+	cluster, err := commandContextFrom(ctx).Cluster()
+	if err != nil {
+		logger.Debug("Could not connect to the kubeasy cluster: %v", err)
+		ui.Info("No kubeasy cluster to compare with: every component would be installed")
+		return
+	}
+
+	var diffs []deployer.ComponentDiff
+	_ = ui.WaitMessage("Comparing component manifests with the cluster", func() error {
+		diffs = diffComponents(ctx, cluster.Clientset, cluster.DynamicClient)
+		return nil
+	})
+
+	for _, d := range diffs {
+		ui.Println()
+		title := d.Component
+		if d.Source != "" {
+			title += " (" + path.Base(d.Source) + ")"
+		}
+		ui.Section(title)
+		if d.Err != nil {
+			ui.Warning(fmt.Sprintf("Could not preview %s: %v", d.Component, d.Err))
+		}
+		printApplyDiffs(d.Results)
+		if len(d.Results) > 0 {
+			ui.Info(fmt.Sprintf("Manifests: %s", kube.SummarizeApply(d.Results)))
+		}
+	}
+	ui.Println()
+	ui.Info("Dry run: nothing was changed")
+}
+
+// componentActions returns the component plan, noting where the add-on manifests
+// are read from when they are not downloaded.
+func componentActions() []string {
+	actions := deployer.ComponentPlan()
+	dir := deployer.ManifestsDir
+	if dir == "" {
+		dir = os.Getenv(deployer.ManifestsDirEnv)
+	}
+	if dir != "" {
+		actions = append(actions, fmt.Sprintf("Read the add-on manifests from %s instead of downloading them", dir))
+	}
+	return actions
+}
+
+// checkManifestsDir fails when dir (--manifests-dir) is not a directory.
+func checkManifestsDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return kerrors.Wrap(kerrors.CodeInvalidInput, fmt.Errorf("invalid --manifests-dir: %w", err))
+	}
+	if !info.IsDir() {
+		return kerrors.New(kerrors.CodeInvalidInput, "invalid --manifests-dir: %s is not a directory", dir)
+	}
+	return nil
+}
+
+// requireLogin fails when no API token is stored.
+func requireLogin(ctx context.Context) error {
+	if token, err := api.CredentialStore().Get(); err != nil || token == "" {
+		ui.Error("You must be logged in to set up Kubeasy")
+		ui.Info("Run 'kubeasy login' first")
+		return fmt.Errorf("authentication required: run 'kubeasy login' first")
+	}
+	return nil
+}
+
+// ensureCluster creates the kind cluster, or checks the configuration and Kubernetes
+// version of the existing one.
+func ensureCluster(ctx context.Context) error {
+	exists, err := checkClusterExists()
+	if err != nil {
+		return err
+	}
+
+	ref := kindClusterConfig()
+
+	if !exists {
+		// Cluster does not exist — create with port mappings.
+		err := ui.TimedSpinner(
+			fmt.Sprintf("Creating kind cluster 'kubeasy' (Kubernetes %s)", constants.GetKubernetesVersion()),
+			createClusterWithConfig,
+		)
+		if err != nil {
+			ui.Error(fmt.Sprintf("Failed to create Kind cluster with image %s", constants.KindNodeImage))
+			ui.Info("Verify that the Kind node image is available")
+			ui.Info("You can manually pull: docker pull " + constants.KindNodeImage)
+			return fmt.Errorf("failed to create kind cluster with image %s: %w", constants.KindNodeImage, err)
+		}
+	} else {
+		// Cluster already exists — compare installed config against reference.
+		if !deployer.KindConfigMatches(ref) {
+			ui.Warning("Kind cluster 'kubeasy' configuration has drifted from the current reference")
+			ui.Info("The installed cluster was created with a different configuration (e.g. missing port mappings or updated settings)")
+			confirmed := ui.Confirmation("Recreate cluster with the updated configuration? (This will DELETE the existing cluster)")
+			if confirmed {
+				err := ui.TimedSpinner("Deleting existing cluster...", func() error {
+					return cluster.NewProvider().Delete("kubeasy", "")
+				})
 				if err != nil {
-					// Log at debug level for troubleshooting, but don't block setup
-					logger.Debug("Could not detect cluster version: %v", err)
-					ui.Success("Kind cluster 'kubeasy' already exists")
-					ui.Info("Could not verify cluster version - cluster may need configuration")
+					ui.Error("Failed to delete existing cluster")
+					return fmt.Errorf("failed to delete kind cluster: %w", err)
+				}
+				err = ui.TimedSpinner(
+					fmt.Sprintf("Recreating kind cluster 'kubeasy' (Kubernetes %s)", constants.GetKubernetesVersion()),
+					createClusterWithConfig,
+				)
+				if err != nil {
+					ui.Error(fmt.Sprintf("Failed to recreate Kind cluster with image %s", constants.KindNodeImage))
+					ui.Info("Verify that the Kind node image is available")
+					ui.Info("You can manually pull: docker pull " + constants.KindNodeImage)
+					return fmt.Errorf("failed to recreate kind cluster with image %s: %w", constants.KindNodeImage, err)
+				}
+			} else {
+				ui.Warning("Skipping cluster recreation — some features may not work correctly")
+			}
+		} else {
+			// Detect actual cluster version and compare with expected
+			actualVersion, err := kube.GetServerVersion()
+			if err != nil {
+				// Log at debug level for troubleshooting, but don't block setup
+				logger.Debug("Could not detect cluster version: %v", err)
+				ui.Success("Kind cluster 'kubeasy' already exists")
+				ui.Info("Could not verify cluster version - cluster may need configuration")
+			} else {
+				expectedVersion := constants.GetKubernetesVersion()
+				// Compare major.minor versions to handle build metadata (+k3s1, -eks) and patch differences
+				if !constants.VersionsCompatible(actualVersion, expectedVersion) {
+					actualMajorMinor := constants.GetMajorMinorVersion(actualVersion)
+					expectedMajorMinor := constants.GetMajorMinorVersion(expectedVersion)
+					ui.Warning(fmt.Sprintf("Kind cluster 'kubeasy' exists with Kubernetes %s (expected %s)", actualMajorMinor, expectedMajorMinor))
+					ui.Info("Consider recreating: kind delete cluster -n kubeasy && kubeasy setup")
 				} else {
-					expectedVersion := constants.GetKubernetesVersion()
-					// Compare major.minor versions to handle build metadata (+k3s1, -eks) and patch differences
-					if !constants.VersionsCompatible(actualVersion, expectedVersion) {
-						actualMajorMinor := constants.GetMajorMinorVersion(actualVersion)
-						expectedMajorMinor := constants.GetMajorMinorVersion(expectedVersion)
-						ui.Warning(fmt.Sprintf("Kind cluster 'kubeasy' exists with Kubernetes %s (expected %s)", actualMajorMinor, expectedMajorMinor))
-						ui.Info("Consider recreating: kind delete cluster -n kubeasy && kubeasy setup")
-					} else {
-						ui.Success(fmt.Sprintf("Kind cluster 'kubeasy' already exists (Kubernetes %s)", constants.GetMajorMinorVersion(actualVersion)))
-					}
+					ui.Success(fmt.Sprintf("Kind cluster 'kubeasy' already exists (Kubernetes %s)", constants.GetMajorMinorVersion(actualVersion)))
 				}
 			}
 		}
+	}
+	return nil
+}
 
-		// Step 2: Install all infrastructure components with per-component status output.
-		ui.Section("Installing Components")
+// installComponents installs all infrastructure components with per-component status output.
+func installComponents(ctx context.Context) error {
+	ui.Section("Installing Components")
 
-		clientset, err := kube.GetKubernetesClient()
-		if err != nil {
-			ui.Error("Failed to get Kubernetes client")
-			return fmt.Errorf("failed to get Kubernetes client: %w", err)
-		}
-		dynamicClient, err := kube.GetDynamicClient()
-		if err != nil {
-			ui.Error("Failed to get Kubernetes dynamic client")
-			return fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
-		}
+	cluster, err := commandContextFrom(ctx).Cluster()
+	if err != nil {
+		ui.Error("Failed to get Kubernetes clients")
+		return err
+	}
+	clientset, dynamicClient := cluster.Clientset, cluster.DynamicClient
 
-		results := deployer.SetupAllComponents(cmd.Context(), clientset, dynamicClient)
-		allReady := true
-		for _, r := range results {
-			printComponentResult(r)
-			if r.Status != deployer.StatusReady {
-				allReady = false
-			}
-		}
+	// Mark the cluster so commands that modify it can tell it is kubeasy's
+	if err := kube.EnsureClusterMarker(ctx, clientset); err != nil {
+		ui.Error("Failed to mark the cluster as kubeasy's")
+		return err
+	}
 
-		ui.Println()
+	// Slow manifest downloads would otherwise look like a hang
+	kube.DownloadProgress = reportDownloadProgress
+	defer func() { kube.DownloadProgress = nil }()
 
-		if !allReady {
-			ui.Error("Some components failed to install. Run 'kubeasy setup' again or check logs with --debug.")
-			return fmt.Errorf("setup incomplete: one or more components failed")
+	results := deployer.SetupAllComponents(ctx, clientset, dynamicClient)
+	allReady := true
+	var addons []string
+	for _, r := range results {
+		printComponentResult(r)
+		if r.Status != deployer.StatusReady {
+			allReady = false
+		} else {
+			addons = append(addons, r.Name)
 		}
+	}
 
-		ui.Success("Kubeasy environment is ready!")
-		ui.Info("You can now start challenges with 'kubeasy challenge start <slug>'")
+	arch, err := deployer.NodeArchitecture(ctx, clientset)
+	if err != nil {
+		logger.Debug("Could not detect node architecture: %v", err)
+	}
+	fp := kube.ClusterFingerprint{
+		CLIVersion:    constants.Version,
+		SetupAt:       time.Now(),
+		Addons:        addons,
+		Architecture:  arch,
+		SchemaVersion: kube.FingerprintSchemaVersion,
+	}
+	if err := kube.WriteClusterFingerprint(ctx, clientset, fp); err != nil {
+		logger.Debug("Could not write cluster fingerprint: %v", err)
+		ui.Warning("Could not record the environment fingerprint — compatibility checks will ask to run setup again")
+	}
 
-		api.TrackSetup(cmd.Context())
+	ui.Println()
 
-		return nil
-	},
+	if !allReady {
+		ui.Error("Some components failed to install. Run 'kubeasy setup' again or check logs with --debug.")
+		return fmt.Errorf("setup incomplete: one or more components failed")
+	}
+	return nil
+}
+
+// reportDownloadProgress prints the progress of a slow manifest download.
+func reportDownloadProgress(source string, received, total int64) {
+	progress := kube.FormatBytes(received)
+	if total > 0 {
+		progress += " / " + kube.FormatBytes(total)
+	}
+	ui.Info(fmt.Sprintf("Downloading %s: %s", path.Base(source), progress))
 }
 
 func init() {
 	rootCmd.AddCommand(setupCmd)
+	setupCmd.Flags().BoolVar(&setupDryRun, "dry-run", false, "Print what setup would do without changing anything")
+	setupCmd.Flags().StringArrayVar(&setupRegistryMirrors, "registry-mirror", nil, "Pull a registry's images through a mirror, as registry=http(s)://endpoint (e.g. docker.io=https://mirror.example.com); repeatable, replaces the mirrors of a previous setup")
+	setupCmd.Flags().StringVar(&setupManifestsDir, "manifests-dir", "", "Read the add-on manifests from this directory instead of downloading them (files named like their release assets, e.g. install.yaml); defaults to $"+deployer.ManifestsDirEnv)
 }
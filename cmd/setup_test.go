@@ -1,11 +1,21 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 )
 
 func TestKindClusterConfig_AuditExtraMounts(t *testing.T) {
@@ -13,7 +23,7 @@ func TestKindClusterConfig_AuditExtraMounts(t *testing.T) {
 	require.Len(t, cfg.Nodes, 1)
 	node := cfg.Nodes[0]
 
-	assert.Len(t, node.ExtraMounts, 2, "control-plane node must have exactly 2 ExtraMounts")
+	assert.Len(t, node.ExtraMounts, 3, "control-plane node must have exactly 3 ExtraMounts")
 
 	// Policy file mount
 	policyMount := node.ExtraMounts[0]
@@ -26,6 +36,22 @@ func TestKindClusterConfig_AuditExtraMounts(t *testing.T) {
 	assert.False(t, logMount.Readonly)
 }
 
+func TestKindClusterConfig_RegistryMirrors(t *testing.T) {
+	cfg := kindClusterConfig()
+	require.Len(t, cfg.ContainerdConfigPatches, 1)
+	assert.Contains(t, cfg.ContainerdConfigPatches[0], `config_path = "/etc/containerd/certs.d"`)
+
+	hostsMount := cfg.Nodes[0].ExtraMounts[2]
+	assert.Equal(t, constants.GetContainerdHostsDir(), hostsMount.HostPath)
+	assert.Equal(t, "/etc/containerd/certs.d", hostsMount.ContainerPath)
+	assert.True(t, hostsMount.Readonly)
+}
+
+func TestSetupSteps_RegistryMirrors(t *testing.T) {
+	plan := setupSteps([]deployer.ContainerdMirror{{Registry: "docker.io", Endpoint: "https://mirror.example.com"}})
+	assert.Contains(t, plan[0].Actions, "Pull docker.io images through https://mirror.example.com")
+}
+
 func TestKindClusterConfig_AuditKubeadmConfigPatches(t *testing.T) {
 	cfg := kindClusterConfig()
 	require.Len(t, cfg.Nodes, 1)
@@ -37,3 +63,60 @@ func TestKindClusterConfig_AuditKubeadmConfigPatches(t *testing.T) {
 	assert.Contains(t, patch, "audit-log-path")
 	assert.True(t, strings.HasPrefix(strings.TrimSpace(patch), "kind: ClusterConfiguration"))
 }
+
+func TestSetupSteps_Order(t *testing.T) {
+	var names []string
+	for _, step := range setupSteps(nil) {
+		names = append(names, step.Name)
+		assert.NotEmpty(t, step.Actions, "step %s must describe what it does", step.Name)
+	}
+	assert.Equal(t, []string{"cluster", "components", "track"}, names)
+}
+
+func TestSetupSteps_ManifestsDir(t *testing.T) {
+	t.Setenv(deployer.ManifestsDirEnv, "")
+	dir := t.TempDir()
+	deployer.ManifestsDir = dir
+	t.Cleanup(func() { deployer.ManifestsDir = "" })
+
+	plan := setupSteps(nil)
+	assert.Contains(t, plan[1].Actions, "Read the add-on manifests from "+dir+" instead of downloading them")
+}
+
+func TestCheckManifestsDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, checkManifestsDir(dir))
+
+	err := checkManifestsDir(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+	assert.Equal(t, kerrors.CodeInvalidInput, kerrors.CodeOf(err))
+
+	file := filepath.Join(dir, "install.yaml")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	err = checkManifestsDir(file)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a directory")
+}
+
+func TestPreviewComponents(t *testing.T) {
+	saved := diffComponents
+	t.Cleanup(func() { diffComponents = saved })
+
+	cc := testCommandContext()
+	var previewed kubernetes.Interface
+	diffComponents = func(_ context.Context, clientset kubernetes.Interface, _ dynamic.Interface) []deployer.ComponentDiff {
+		previewed = clientset
+		return []deployer.ComponentDiff{{Component: "kyverno", Err: errors.New("offline")}}
+	}
+	previewComponents(withCommandContext(context.Background(), cc))
+	cluster, err := cc.Cluster()
+	require.NoError(t, err)
+	assert.Same(t, cluster.Clientset, previewed, "the dry run should run against the kubeasy cluster")
+
+	// Without a cluster there is nothing to dry-run against
+	previewed = nil
+	cc = testCommandContext()
+	cc.Connect = func() (*sdk.Cluster, error) { return nil, errors.New("no cluster") }
+	previewComponents(withCommandContext(context.Background(), cc))
+	assert.Nil(t, previewed)
+}
@@ -1,31 +1,51 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
-	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/semver"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
 	"github.com/spf13/cobra"
 )
 
 var (
-	apiGetChallenge         = api.GetChallengeBySlug
-	apiGetChallengeProgress = api.GetChallengeStatus
-	apiStartChallenge       = api.StartChallengeWithResponse
+	startTimeLimit       time.Duration
+	startStrictTimeLimit bool
+	startKeepPartial     bool
+	startFromRepo        bool
+	startReadyTimeout    time.Duration
+	startNetIsolation    string
 )
 
 var startChallengeCmd = &cobra.Command{
 	Use:   "start [challenge-slug]",
 	Short: "Start a challenge",
-	Long:  `Starts a challenge by installing the necessary components into the local Kubernetes cluster.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Starts a challenge by installing the necessary components into the local Kubernetes cluster.
+
+The start time is recorded so 'kubeasy status' can show the elapsed time.
+Use --time-limit for exam-style practice: submitting after the limit prints a
+warning, or is refused when --strict-time-limit is set.
+
+If start fails after creating the namespace, what it created is removed so the
+next start begins from a clean slate. Use --keep-partial to keep it for debugging;
+the next start then resumes where this one stopped.
+
+Use --network-isolation to install a NetworkPolicy baseline in the challenge
+namespace before its manifests: 'namespace' denies traffic to and from other
+namespaces, 'strict' denies all traffic but cluster DNS.
+
+Use --from-repo when the Kubeasy platform cannot serve the challenge manifests:
+they are then fetched from the challenges repository on GitHub and applied
+directly. Progress is still recorded by the platform.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		challengeSlug := args[0]
 
@@ -33,148 +53,196 @@ var startChallengeCmd = &cobra.Command{
 		if err := validateChallengeSlug(challengeSlug); err != nil {
 			return err
 		}
-
-		ui.Section(fmt.Sprintf("Starting Challenge: %s", challengeSlug))
-
-		// Fetch challenge details
-		var challenge *api.ChallengeEntity
-		err := ui.WaitMessage("Fetching challenge details", func() error {
-			var err error
-			challenge, err = apiGetChallenge(cmd.Context(), challengeSlug)
-			return err
-		})
-		if err != nil {
-			ui.Error("Failed to fetch challenge")
-			return fmt.Errorf("failed to fetch challenge: %w", err)
+		if startTimeLimit < 0 {
+			return fmt.Errorf("--time-limit must be positive")
 		}
-
-		ui.Info(fmt.Sprintf("Challenge: %s", challenge.Title))
-
-		// Check progress
-		var progress *api.ChallengeStatusResponse
-		err = ui.WaitMessage("Checking challenge progress", func() error {
-			var err error
-			progress, err = apiGetChallengeProgress(cmd.Context(), challengeSlug)
-			return err
-		})
-		if err != nil {
-			ui.Error("Failed to fetch challenge progress")
-			return fmt.Errorf("failed to fetch challenge progress: %w", err)
+		if startStrictTimeLimit && startTimeLimit == 0 {
+			return fmt.Errorf("--strict-time-limit requires --time-limit")
 		}
-
-		if progress != nil && (progress.Status == "in_progress" || progress.Status == "completed") {
-			ui.Warning("Challenge already started")
-			ui.Info(fmt.Sprintf("Continue the challenge or reset it with 'kubeasy challenge reset %s'", challengeSlug))
-			return nil // Not an error, just already started
+		if startReadyTimeout < 0 {
+			return fmt.Errorf("--ready-timeout must be positive")
 		}
-
-		// Check minimum required CLI version
-		if err := checkMinRequiredVersion(challengeSlug); err != nil {
-			return err
-		}
-
-		// Setup environment - use context from command
-		ctx := cmd.Context()
-		ui.Println()
-
-		// Step 1: Create namespace
-		dynamicClient, err := kube.GetDynamicClient()
+		isolation, err := kube.ParseNetworkIsolation(startNetIsolation)
 		if err != nil {
-			ui.Error("Failed to get Kubernetes dynamic client")
-			return fmt.Errorf("failed to get dynamic client: %w", err)
+			return fmt.Errorf("--network-isolation: %w", err)
 		}
 
-		staticClient, err := kube.GetKubernetesClient()
-		if err != nil {
-			ui.Error("Failed to get Kubernetes static client")
-			return fmt.Errorf("failed to get static client: %w", err)
+		if startFromRepo {
+			cc := commandContextFrom(cmd.Context())
+			if cc.Deploy != nil {
+				return fmt.Errorf("--from-repo cannot be used with --fake-cluster")
+			}
+			cc.Deploy = deployFromRepo
 		}
 
-		err = ui.WaitMessage("Creating namespace", func() error {
-			return kube.CreateNamespace(ctx, staticClient, challengeSlug)
+		return runStart(cmd.Context(), challengeSlug, sdk.StartOptions{
+			TimeLimit:        audit.TimeLimit{Limit: startTimeLimit, Strict: startStrictTimeLimit},
+			KeepPartial:      startKeepPartial,
+			DeployBudgets:    sdk.DeployBudgets{Ready: startReadyTimeout},
+			NetworkIsolation: isolation,
 		})
-		if err != nil {
-			ui.Error("Failed to create namespace")
-			return fmt.Errorf("failed to create namespace: %w", err)
-		}
+	},
+}
 
-		// Step 2: Deploy challenge via API proxy
-		err = ui.WaitMessage("Deploying challenge", func() error {
-			_, err := deployer.DeployChallengeFromRegistry(ctx, staticClient, dynamicClient, challengeSlug)
-			return err
-		})
-		if err != nil {
-			ui.Error("Failed to deploy challenge")
-			return fmt.Errorf("failed to deploy challenge: %w", err)
+// runStart deploys a challenge and registers its progress. A zero time limit means
+// untimed. On failure, what was created is rolled back unless opts.KeepPartial is set.
+func runStart(ctx context.Context, challengeSlug string, opts sdk.StartOptions) error {
+	ui.Section(fmt.Sprintf("Starting Challenge: %s", challengeSlug))
+
+	cc := commandContextFrom(ctx)
+	cc.runWatchdog(ctx)
+
+	logStep := logStepEvent("start")
+	client := cc.sdkClient(func(e steps.Event) {
+		logStep(e)
+		switch e.Status {
+		case steps.StatusStarted:
+			setInterruptHint(fmt.Sprintf("Start stopped during step %q: run 'kubeasy challenge start %s' again to resume", e.Step, challengeSlug))
+		case steps.StatusFailed:
+			if !opts.KeepPartial {
+				ui.Warning("Start failed: removing what was created (use --keep-partial to keep it)")
+			}
+		case steps.StatusRollbackFailed:
+			ui.Warning(fmt.Sprintf("Could not undo step %q: %v", e.Step, e.Err))
 		}
+	})
 
-		// Step 3: Configure context
-		if err := kube.SetNamespaceForContext(constants.KubeasyClusterContext, challengeSlug); err != nil {
-			logger.Debug("Failed to set namespace for context: %v", err)
-			ui.Warning("Could not configure kubectl context namespace")
-		} else {
-			ui.Success("Kubectl context configured")
+	started, err := client.Start(ctx, challengeSlug, opts)
+	if err != nil {
+		var deployErr *sdk.DeployError
+		var timeoutErr *deployer.PhaseTimeoutError
+		if errors.As(err, &deployErr) {
+			reportDeployDiagnosis(deployErr.Diagnosis)
+		} else if errors.As(err, &timeoutErr) {
+			reportPhaseTimeout(timeoutErr)
 		}
-
-		// Step 4: Register progress
-		err = ui.WaitMessage("Registering challenge progress", func() error {
-			_, err = apiStartChallenge(cmd.Context(), challengeSlug)
-			return err
-		})
-		if err != nil {
+		var stepErr *steps.Error
+		if opts.KeepPartial && errors.As(err, &stepErr) {
+			ui.Info(fmt.Sprintf("Partial environment kept: run 'kubeasy challenge start %s' again to resume", challengeSlug))
+		} else if !errors.As(err, &stepErr) {
 			ui.Error("Failed to start challenge")
-			return fmt.Errorf("failed to start challenge: %w", err)
 		}
+		return err
+	}
+	switch started.Mode {
+	case sdk.StartAlreadyDone:
+		ui.Warning("Challenge already completed")
+		ui.Info(fmt.Sprintf("Reset it to start again with 'kubeasy challenge reset %s'", challengeSlug))
+		return nil // Not an error, just already done
+	case sdk.StartAlreadyStarted:
+		ui.Warning("Challenge already started")
+		ui.Info(fmt.Sprintf("Continue the challenge or reset it with 'kubeasy challenge reset %s'", challengeSlug))
+		return nil // Not an error, just already started
+	}
 
-		if err := audit.SaveTimestamp(challengeSlug); err != nil {
-			logger.Debug("Could not save start timestamp: %v", err)
-		}
+	ui.Println()
+	ui.Success("Challenge environment is ready!")
+	ui.KeyValue("Challenge", challengeSlug)
+	ui.KeyValue("Namespace", challengeSlug)
+	ui.KeyValue("Context", constants.KubeasyClusterContext)
+	if opts.TimeLimit.Limit > 0 {
+		ui.KeyValue("Time limit", opts.TimeLimit.Limit.String())
+	}
+	reportInventory(challengeSlug, started.Inventory)
+	ui.Println()
+	ui.Info("You can now start working on the challenge!")
+	return nil
+}
 
-		ui.Println()
-		ui.Success("Challenge environment is ready!")
-		ui.KeyValue("Challenge", challengeSlug)
-		ui.KeyValue("Namespace", challengeSlug)
-		ui.KeyValue("Context", "kind-kubeasy")
-		ui.Println()
-		ui.Info("You can now start working on the challenge!")
-		return nil
-	},
+// deployFromRepo deploys a challenge from the challenges repository (--from-repo).
+func deployFromRepo(ctx context.Context, cluster *sdk.Cluster, slug string) (string, error) {
+	return deployer.DeployChallengeFromRepo(ctx, cluster.Clientset, cluster.DynamicClient, slug)
 }
 
-// checkMinRequiredVersion loads challenge.yaml for the given slug and verifies
-// the running CLI version meets the minRequiredVersion constraint.
-// It is a no-op when the field is absent or the CLI is a pre-release build.
-func checkMinRequiredVersion(slug string) error {
-	spec, err := validation.LoadChallengeYamlForChallenge(slug)
-	if err != nil {
-		// Non-fatal: if challenge.yaml is unavailable we cannot block the user.
-		logger.Debug("Could not load challenge.yaml for version check: %v", err)
-		return nil
+// reportInventory prints what the challenge deployed and how to point kubectl at it,
+// so the learner knows where to start looking.
+func reportInventory(namespace string, inventory []sdk.ResourceStatus) {
+	if len(inventory) > 0 {
+		ui.Println()
+		ui.Info(fmt.Sprintf("Deployed in %s: %s", namespace, kube.SummarizeHealth(inventory)))
+		for _, s := range inventory {
+			sev := ui.SeveritySuccess
+			switch s.Health {
+			case kube.HealthProgressing:
+				sev = ui.SeverityWarning
+			case kube.HealthDegraded:
+				sev = ui.SeverityError
+			}
+			ui.Text(fmt.Sprintf("  %s/%s: %s %s", s.Kind, s.Name, ui.Colorize(sev, string(s.Health)), s.Message))
+		}
 	}
+	ui.Println()
+	ui.Info("Point kubectl at the challenge:")
+	ui.Text(fmt.Sprintf("  kubectl config use-context %s", constants.KubeasyClusterContext))
+	ui.Text(fmt.Sprintf("  kubectl config set-context --current --namespace=%s", namespace))
+	ui.Text(fmt.Sprintf("  kubectl get all -n %s", namespace))
+}
 
-	required := spec.MinRequiredVersion
-	if required == "" {
-		return nil
-	}
+// deployFailureLabels describes the causes of a failed deploy.
+var deployFailureLabels = map[sdk.FailureCause]string{
+	deployer.CauseInvalidManifest:      "invalid challenge manifest",
+	deployer.CausePolicyDenied:         "denied by a Kyverno policy",
+	deployer.CauseNamespaceMissing:     "challenge namespace deleted",
+	deployer.CauseImagePullQuota:       "image registry rate limit",
+	deployer.CauseImagePull:            "image pull failed",
+	deployer.CauseManifestsUnavailable: "challenge manifests unavailable",
+}
 
-	current := constants.Version
-	if semver.IsPreRelease(current) {
-		logger.Debug("Pre-release CLI build (%s), skipping minRequiredVersion check", current)
-		return nil
+// reportDeployDiagnosis prints the recognised cause of a failed deploy and what to do.
+func reportDeployDiagnosis(d sdk.Diagnosis) {
+	label := deployFailureLabels[d.Cause]
+	if label == "" {
+		label = string(d.Cause)
+	}
+	ui.Error(fmt.Sprintf("Deploy failed: %s", label))
+	if d.Detail != "" {
+		ui.KeyValue("Cause", d.Detail)
 	}
+	ui.Info(d.Guidance)
+}
 
-	curNorm := semver.Normalize(current)
-	reqNorm := semver.Normalize(required)
-	if semver.Compare(curNorm, reqNorm) < 0 {
-		return fmt.Errorf(
-			"this challenge requires kubeasy-cli >= %s (you have %s)\nPlease update: %s/kubeasy-cli/releases/latest",
-			required, current, constants.GithubRootURL,
-		)
+// phaseTimeoutHints tells what to do when a deploy phase runs out of time.
+var phaseTimeoutHints = map[string]string{
+	deployer.PhaseFetch: "Downloading the challenge manifests hung: check your connection, or retry with --from-repo.",
+	deployer.PhaseApply: "The cluster API server is slow to accept the manifests: check it with 'kubectl get --raw /readyz'.",
+	deployer.PhaseReady: "The challenge resources are slow to become ready (e.g. large image pulls): retry with a longer --ready-timeout.",
+}
+
+// reportPhaseTimeout prints which deploy phase ran out of time and what to do.
+func reportPhaseTimeout(e *deployer.PhaseTimeoutError) {
+	ui.Error(fmt.Sprintf("Deploy timed out in the %s phase (budget %s)", e.Phase, e.Budget))
+	if hint := phaseTimeoutHints[e.Phase]; hint != "" {
+		ui.Info(hint)
 	}
+}
 
-	return nil
+// reportReadyProgress prints the status of the challenge workloads that are not ready
+// yet, so a stuck rollout is not mistaken for a slow one.
+func reportReadyProgress(namespace string, statuses []kube.ResourceStatus) {
+	ui.Info(fmt.Sprintf("Waiting for resources in %s: %s", namespace, kube.SummarizeHealth(statuses)))
+	degraded := false
+	for _, s := range statuses {
+		sev := ui.SeverityWarning
+		switch s.Health {
+		case kube.HealthReady:
+			continue
+		case kube.HealthDegraded:
+			sev = ui.SeverityError
+			degraded = true
+		}
+		ui.Text(fmt.Sprintf("  %s/%s: %s %s", s.Kind, s.Name, ui.Colorize(sev, string(s.Health)), s.Message))
+	}
+	if degraded {
+		ui.Warning(fmt.Sprintf("Degraded resources will not become ready by waiting: inspect them with 'kubectl describe -n %s'", namespace))
+	}
 }
 
 func init() {
 	challengeCmd.AddCommand(startChallengeCmd)
+	startChallengeCmd.Flags().DurationVar(&startTimeLimit, "time-limit", 0, "Time box for the attempt (e.g. 45m); submitting after it expires prints a warning")
+	startChallengeCmd.Flags().BoolVar(&startStrictTimeLimit, "strict-time-limit", false, "Refuse submissions once --time-limit has expired")
+	startChallengeCmd.Flags().BoolVar(&startKeepPartial, "keep-partial", false, "Keep the namespace and resources created by a failed start instead of removing them")
+	startChallengeCmd.Flags().DurationVar(&startReadyTimeout, "ready-timeout", 0, fmt.Sprintf("How long to wait for the challenge resources to be ready (default %s)", deployer.DefaultDeployBudgets.Ready))
+	startChallengeCmd.Flags().StringVar(&startNetIsolation, "network-isolation", string(kube.NetworkIsolationNone), "NetworkPolicy baseline of the challenge namespace: none, namespace or strict")
+	startChallengeCmd.Flags().BoolVar(&startFromRepo, "from-repo", false, "Fetch the challenge manifests from the challenges repository instead of the Kubeasy platform")
 }
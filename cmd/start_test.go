@@ -6,9 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -25,88 +30,6 @@ func writeTempChallengeYaml(t *testing.T, slug, content string) func() {
 	return func() {}
 }
 
-// TestCheckMinRequiredVersion covers the four branches of the version gate.
-func TestCheckMinRequiredVersion(t *testing.T) {
-	origVersion := constants.Version
-	t.Cleanup(func() { constants.Version = origVersion })
-
-	tests := []struct {
-		name        string
-		cliVersion  string
-		yamlContent string
-		wantErr     bool
-		errContains string
-	}{
-		{
-			name:       "no minRequiredVersion field — always passes",
-			cliVersion: "2.0.0",
-			yamlContent: `title: "Test"
-type: fix
-theme: networking
-difficulty: easy
-estimatedTime: 30
-initialSituation: "test"
-description: "test"
-objective: "test"
-objectives: []
-`,
-			wantErr: false,
-		},
-		{
-			name:       "pre-release CLI build — skips check",
-			cliVersion: "dev",
-			yamlContent: `title: "Test"
-minRequiredVersion: "99.0.0"
-objectives: []
-`,
-			wantErr: false,
-		},
-		{
-			name:       "CLI version >= required — passes",
-			cliVersion: "2.1.0",
-			yamlContent: `title: "Test"
-minRequiredVersion: "2.0.0"
-objectives: []
-`,
-			wantErr: false,
-		},
-		{
-			name:       "CLI version == required — passes",
-			cliVersion: "2.0.0",
-			yamlContent: `title: "Test"
-minRequiredVersion: "2.0.0"
-objectives: []
-`,
-			wantErr: false,
-		},
-		{
-			name:       "CLI version < required — blocked",
-			cliVersion: "1.9.0",
-			yamlContent: `title: "Test"
-minRequiredVersion: "2.0.0"
-objectives: []
-`,
-			wantErr:     true,
-			errContains: "requires kubeasy-cli >= 2.0.0",
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			constants.Version = tc.cliVersion
-			writeTempChallengeYaml(t, "test-challenge", tc.yamlContent)
-
-			err := checkMinRequiredVersion("test-challenge")
-			if tc.wantErr {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.errContains)
-			} else {
-				assert.NoError(t, err)
-			}
-		})
-	}
-}
-
 // TestStartRunE_InvalidSlug verifies that an invalid slug is rejected before any API call.
 func TestStartRunE_InvalidSlug(t *testing.T) {
 	err := startChallengeCmd.RunE(startChallengeCmd, []string{"INVALID_SLUG"})
@@ -114,41 +37,74 @@ func TestStartRunE_InvalidSlug(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid challenge slug")
 }
 
+// TestStartRunE_InvalidNetworkIsolation verifies that an unknown isolation mode is
+// rejected before any API call.
+func TestStartRunE_InvalidNetworkIsolation(t *testing.T) {
+	startNetIsolation = "deny-all"
+	t.Cleanup(func() { startNetIsolation = "none" })
+
+	err := startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"})
+	assert.ErrorContains(t, err, "--network-isolation")
+}
+
 // TestStartRunE_AlreadyInProgress verifies that a challenge already in progress returns nil (no error).
 func TestStartRunE_AlreadyInProgress(t *testing.T) {
-	origGetChallenge := apiGetChallenge
-	origGetProgress := apiGetChallengeProgress
-	t.Cleanup(func() {
-		apiGetChallenge = origGetChallenge
-		apiGetChallengeProgress = origGetProgress
-	})
-
-	apiGetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+	cc := testCommandContext(challengeNamespace())
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
 		return &api.ChallengeEntity{Title: "Test"}, nil
 	}
-	apiGetChallengeProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
+	cc.API.GetProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
 		return &api.ChallengeStatusResponse{Status: "in_progress"}, nil
 	}
+	useCommandContext(t, startChallengeCmd, cc)
 
 	err := startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"})
 	assert.NoError(t, err)
 }
 
+// TestStartRunE_ResumesInterruptedRegistration verifies that a start interrupted after
+// registration finishes locally without calling the start endpoint again.
+func TestStartRunE_ResumesInterruptedRegistration(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	writeTempChallengeYaml(t, "pod-evicted", "title: \"Test\"\nobjectives: []\n")
+	startedAt := "2026-01-02T03:04:05Z"
+	cc := testCommandContext(challengeNamespace())
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+		return &api.ChallengeEntity{Title: "Test"}, nil
+	}
+	cc.API.GetProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
+		return &api.ChallengeStatusResponse{Status: "in_progress", StartedAt: &startedAt}, nil
+	}
+	cc.API.StartChallenge = func(ctx context.Context, slug string) (*api.ChallengeStartResponse, error) {
+		t.Fatal("the start endpoint must not be called again")
+		return nil, nil
+	}
+	useCommandContext(t, startChallengeCmd, cc)
+	require.NoError(t, state.Update(func(s *state.State) error {
+		s.Challenge("pod-evicted").StartStep = sdk.StartStepRegister
+		return nil
+	}))
+
+	require.NoError(t, startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"}))
+
+	s, err := state.Load()
+	require.NoError(t, err)
+	assert.Empty(t, s.Challenges["pod-evicted"].StartStep, "a completed start clears its step")
+	ts, err := audit.LoadStartTime("pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, startedAt, ts.UTC().Format(time.RFC3339), "the API start time is kept")
+}
+
 // TestStartRunE_AlreadyCompleted verifies that a completed challenge returns nil (no error).
 func TestStartRunE_AlreadyCompleted(t *testing.T) {
-	origGetChallenge := apiGetChallenge
-	origGetProgress := apiGetChallengeProgress
-	t.Cleanup(func() {
-		apiGetChallenge = origGetChallenge
-		apiGetChallengeProgress = origGetProgress
-	})
-
-	apiGetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
 		return &api.ChallengeEntity{Title: "Test"}, nil
 	}
-	apiGetChallengeProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
+	cc.API.GetProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
 		return &api.ChallengeStatusResponse{Status: "completed"}, nil
 	}
+	useCommandContext(t, startChallengeCmd, cc)
 
 	err := startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"})
 	assert.NoError(t, err)
@@ -156,17 +112,89 @@ func TestStartRunE_AlreadyCompleted(t *testing.T) {
 
 // TestStartRunE_APIFailure verifies that a GetChallenge API failure returns a non-nil error without panic.
 func TestStartRunE_APIFailure(t *testing.T) {
-	origGetChallenge := apiGetChallenge
-	t.Cleanup(func() {
-		apiGetChallenge = origGetChallenge
-	})
-
-	apiGetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
 		return nil, fmt.Errorf("network error")
 	}
+	useCommandContext(t, startChallengeCmd, cc)
 
 	assert.NotPanics(t, func() {
 		err := startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"})
 		require.Error(t, err)
 	})
 }
+
+// TestStartRunE_FromRepo verifies that --from-repo deploys from the challenges
+// repository and is refused when the deploy is already replaced.
+func TestStartRunE_FromRepo(t *testing.T) {
+	startFromRepo = true
+	t.Cleanup(func() { startFromRepo = false })
+
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+		return nil, fmt.Errorf("network error")
+	}
+	useCommandContext(t, startChallengeCmd, cc)
+	require.Error(t, startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"}))
+	assert.NotNil(t, cc.Deploy, "the deploy is replaced")
+
+	err := startChallengeCmd.RunE(startChallengeCmd, []string{"pod-evicted"})
+	assert.ErrorContains(t, err, "--fake-cluster")
+}
+
+func TestReportReadyProgress(t *testing.T) {
+	out := captureUI(t)
+	reportReadyProgress("pod-evicted", []kube.ResourceStatus{
+		{Kind: "Deployment", Name: "web", Health: kube.HealthReady, Message: "Ready=1/1"},
+		{Kind: "Deployment", Name: "api", Health: kube.HealthDegraded, Message: "Ready=0/1; pod api-x container app: ImagePullBackOff"},
+		{Kind: "StatefulSet", Name: "db", Health: kube.HealthProgressing, Message: "Ready=0/1"},
+	})
+
+	got := out.String()
+	assert.Contains(t, got, "Waiting for resources in pod-evicted:")
+	assert.Contains(t, got, "  Deployment/api: Degraded Ready=0/1; pod api-x container app: ImagePullBackOff\n")
+	assert.Contains(t, got, "  StatefulSet/db: Progressing Ready=0/1\n")
+	assert.NotContains(t, got, "Deployment/web", "ready workloads are not listed")
+	assert.Contains(t, got, "inspect them with 'kubectl describe -n pod-evicted'")
+}
+
+func TestReportDeployDiagnosis(t *testing.T) {
+	out := captureUI(t)
+	reportDeployDiagnosis(sdk.Diagnosis{Cause: deployer.CauseImagePullQuota, Detail: "pod web: toomanyrequests", Guidance: "Wait for the rate limit to reset"})
+	got := out.String()
+	assert.Contains(t, got, "Deploy failed: image registry rate limit")
+	assert.Contains(t, got, "Cause: pod web: toomanyrequests")
+	assert.Contains(t, got, "Wait for the rate limit to reset")
+
+	out.Reset()
+	reportDeployDiagnosis(sdk.Diagnosis{Cause: "unknown", Guidance: "Retry"})
+	got = out.String()
+	assert.Contains(t, got, "Deploy failed: unknown")
+	assert.NotContains(t, got, "Cause:")
+	assert.Contains(t, got, "Retry")
+
+	out.Reset()
+	reportPhaseTimeout(&deployer.PhaseTimeoutError{Phase: deployer.PhaseReady, Budget: time.Minute, Err: context.DeadlineExceeded})
+	got = out.String()
+	assert.Contains(t, got, "Deploy timed out in the ready phase (budget 1m0s)")
+	assert.Contains(t, got, phaseTimeoutHints[deployer.PhaseReady])
+}
+
+func TestReportInventory(t *testing.T) {
+	out := captureUI(t)
+	reportInventory("pod-evicted", []sdk.ResourceStatus{
+		{Kind: "Deployment", Name: "web", Health: kube.HealthReady, Message: "Ready=1/1"},
+		{Kind: "Service", Name: "web", Health: kube.HealthReady, Message: "ClusterIP 10.0.0.1, ports 80/TCP"},
+	})
+	got := out.String()
+	assert.Contains(t, got, "Deployed in pod-evicted:")
+	assert.Contains(t, got, "  Deployment/web: Ready Ready=1/1\n")
+	assert.Contains(t, got, "  Service/web: Ready ClusterIP 10.0.0.1, ports 80/TCP\n")
+	assert.Contains(t, got, "kubectl config set-context --current --namespace=pod-evicted")
+
+	out.Reset()
+	reportInventory("pod-evicted", nil)
+	got = out.String()
+	assert.NotContains(t, got, "Deployed in")
+	assert.Contains(t, got, "kubectl get all -n pod-evicted")
+}
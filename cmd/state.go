@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stateClearYes bool
+
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Inspect or clear the local CLI state",
+	Long: `Debug commands for the local state file (~/.kubeasy/state.json), where the CLI
+keeps what it needs to remember between runs, such as submission counts.`,
+}
+
+var stateShowCmd = &cobra.Command{
+	Use:           "show",
+	Short:         "Print the local state file",
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := commandContextFrom(cmd.Context()).State
+		s, err := store.Load()
+		if err != nil {
+			ui.Error("Failed to load local state")
+			return err
+		}
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode state: %w", err)
+		}
+
+		ui.KeyValue("Path", store.Path())
+		ui.KeyValue("Schema version", fmt.Sprintf("%d", s.Version))
+		ui.Println()
+		ui.Text(string(data))
+		return nil
+	},
+}
+
+var stateClearCmd = &cobra.Command{
+	Use:           "clear",
+	Short:         "Delete the local state file",
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := commandContextFrom(cmd.Context()).State
+		confirmed, err := ui.Confirm(fmt.Sprintf("Delete %s?", store.Path()), stateClearYes)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			ui.Info("Clear cancelled")
+			return nil
+		}
+
+		if err := store.Clear(); err != nil {
+			ui.Error("Failed to clear local state")
+			return err
+		}
+		ui.Success("Local state cleared")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stateCmd)
+	stateCmd.AddCommand(stateShowCmd)
+	stateCmd.AddCommand(stateClearCmd)
+	stateClearCmd.Flags().BoolVarP(&stateClearYes, "yes", "y", false, "Skip the confirmation prompt (required when not running in a terminal)")
+}
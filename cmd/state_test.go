@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateShow_EmptyState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	require.NoError(t, stateShowCmd.RunE(stateShowCmd, nil))
+}
+
+func TestStateClear(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	orig := stateClearYes
+	t.Cleanup(func() { stateClearYes = orig })
+
+	require.NoError(t, state.Update(func(s *state.State) error {
+		s.Challenge("pod-evicted").Submissions++
+		return nil
+	}))
+	require.FileExists(t, state.GetPath())
+
+	stateClearYes = false
+	err := stateClearCmd.RunE(stateClearCmd, nil)
+	require.ErrorIs(t, err, ui.ErrConfirmationRequired)
+	assert.FileExists(t, state.GetPath())
+
+	stateClearYes = true
+	require.NoError(t, stateClearCmd.RunE(stateClearCmd, nil))
+	assert.NoFileExists(t, state.GetPath())
+}
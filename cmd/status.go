@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status [challenge-slug]",
+	Short: "Show elapsed time for started challenges",
+	Long: `Shows how long you have been working on your started challenges.
+
+Without argument, lists every challenge started on this machine. With a slug,
+also shows the progress recorded by the Kubeasy API, the submissions left and
+any submission cooldown and, for time-boxed attempts (see 'kubeasy challenge
+start --time-limit'), the remaining time.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+
+		if len(args) == 0 {
+			slugs, err := audit.ListStartedChallenges()
+			if err != nil {
+				ui.Error("Failed to read local challenge state")
+				return err
+			}
+			if len(slugs) == 0 {
+				ui.Info("No started challenge found. Start one with 'kubeasy challenge start <slug>'")
+				return nil
+			}
+
+			rows := make([][]string, 0, len(slugs))
+			for _, slug := range slugs {
+				startedAt, err := audit.LoadStartTime(slug)
+				if err != nil {
+					logger.Debug("Could not load start time for %s: %v", slug, err)
+					continue
+				}
+				remaining := "-"
+				if limit, ok, _ := audit.LoadTimeLimit(slug); ok {
+					remaining = formatRemaining(limit.Remaining(startedAt, now))
+				}
+				rows = append(rows, []string{slug, startedAt.Local().Format(time.DateTime), formatElapsed(now.Sub(startedAt)), remaining})
+			}
+			ui.Section("Started Challenges")
+			return ui.Table([]string{"CHALLENGE", "STARTED", "ELAPSED", "REMAINING"}, rows)
+		}
+
+		challengeSlug := args[0]
+		if err := validateChallengeSlug(challengeSlug); err != nil {
+			return err
+		}
+
+		ui.Section(fmt.Sprintf("Challenge Status: %s", challengeSlug))
+
+		progress, err := commandContextFrom(cmd.Context()).API.GetProgress(cmd.Context(), challengeSlug)
+		if err != nil {
+			ui.Error("Failed to fetch challenge progress")
+			return fmt.Errorf("failed to fetch challenge progress: %w", err)
+		}
+		if progress == nil || progress.Status == "not_started" {
+			ui.Info("Challenge not started. Start it with 'kubeasy challenge start " + challengeSlug + "'")
+			return nil
+		}
+		ui.KeyValue("Status", ui.StatusLabel(progress.Status))
+		if progress.AttemptsRemaining != nil {
+			ui.KeyValue("Attempts left", strconv.Itoa(*progress.AttemptsRemaining))
+		}
+		if until, ok := parseAPITime(progress.CooldownUntil); ok && until.After(now) {
+			ui.KeyValue("Next submission in", formatElapsed(until.Sub(now)))
+		}
+
+		startedAt, ok := challengeStartTime(challengeSlug, progress)
+		if !ok {
+			ui.KeyValue("Elapsed", "unknown")
+			return nil
+		}
+		ui.KeyValue("Started", startedAt.Local().Format(time.DateTime))
+		if progress.Status == "completed" {
+			if completedAt, ok := parseAPITime(progress.CompletedAt); ok {
+				ui.KeyValue("Duration", formatElapsed(completedAt.Sub(startedAt)))
+			}
+			return nil
+		}
+		ui.KeyValue("Elapsed", formatElapsed(now.Sub(startedAt)))
+
+		if limit, ok, err := audit.LoadTimeLimit(challengeSlug); err != nil {
+			logger.Debug("Could not load time limit for %s: %v", challengeSlug, err)
+		} else if ok {
+			mode := "warn"
+			if limit.Strict {
+				mode = "strict"
+			}
+			ui.KeyValue("Time limit", fmt.Sprintf("%s (%s)", limit.Limit, mode))
+			ui.KeyValue("Remaining", formatRemaining(limit.Remaining(startedAt, now)))
+		}
+		return nil
+	},
+}
+
+// challengeStartTime returns when the challenge was started, preferring the time
+// recorded locally by 'kubeasy challenge start' over the one reported by the API.
+func challengeStartTime(slug string, progress *api.ChallengeStatusResponse) (time.Time, bool) {
+	return sdk.StartedAt(slug, progress)
+}
+
+func parseAPITime(s *string) (time.Time, bool) {
+	if s == nil || *s == "" {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		logger.Debug("Could not parse API time %q: %v", *s, err)
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
+// formatElapsed renders a duration to the second, e.g. "1h05m30s".
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	h := int(d / time.Hour)
+	m := int(d % time.Hour / time.Minute)
+	s := int(d % time.Minute / time.Second)
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm%02ds", h, m, s)
+	}
+	if m > 0 {
+		return fmt.Sprintf("%dm%02ds", m, s)
+	}
+	return fmt.Sprintf("%ds", s)
+}
+
+func formatRemaining(d time.Duration) string {
+	if d <= 0 {
+		return fmt.Sprintf("expired %s ago", formatElapsed(-d))
+	}
+	return formatElapsed(d)
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{-time.Minute, "0s"},
+		{42 * time.Second, "42s"},
+		{5*time.Minute + 3*time.Second, "5m03s"},
+		{time.Hour + 5*time.Minute + 30*time.Second, "1h05m30s"},
+		{26 * time.Hour, "26h00m00s"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatElapsed(tt.d))
+	}
+}
+
+func TestFormatRemaining(t *testing.T) {
+	assert.Equal(t, "10m00s", formatRemaining(10*time.Minute))
+	assert.Equal(t, "expired 1m30s ago", formatRemaining(-90*time.Second))
+}
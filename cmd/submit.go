@@ -1,20 +1,28 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/devutils"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/report"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
 	"github.com/spf13/cobra"
 )
 
 var (
-	apiGetChallengeForSubmit = api.GetChallengeBySlug
-	apiGetProgressForSubmit  = api.GetChallengeStatus
+	submitForce       bool
+	submitReports     []string
+	submitCertificate string
+	submitRecord      string
 )
 
 var submitCmd = &cobra.Command{
@@ -22,7 +30,12 @@ var submitCmd = &cobra.Command{
 	Short: "Submit a challenge solution",
 	Long: `Submit a challenge solution to Kubeasy. This command will run validations
 against your cluster and send the results to the Kubeasy API for evaluation.
-Make sure you have completed the challenge before submitting.`,
+Make sure you have completed the challenge before submitting.
+
+Use --record <dir> to also save every cluster response read while validating to
+<dir>/recording.json, so a maintainer can replay the run with 'kubeasy dev validate
+--replay'. It holds whatever the validations read, Secrets included: review it
+before sharing it.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		challengeSlug := args[0]
@@ -32,192 +45,332 @@ Make sure you have completed the challenge before submitting.`,
 			return err
 		}
 
-		ui.Section(fmt.Sprintf("Submitting Challenge: %s", challengeSlug))
-
-		// Verify challenge exists
-		err := ui.WaitMessage("Verifying challenge", func() error {
-			_, err := apiGetChallengeForSubmit(cmd.Context(), challengeSlug)
-			return err
-		})
+		reports, err := report.ParseTargets(submitReports)
 		if err != nil {
-			ui.Error("Failed to fetch challenge")
-			return fmt.Errorf("failed to fetch challenge: %w", err)
-		}
-
-		// Check progress
-		var progress *api.ChallengeStatusResponse
-		err = ui.WaitMessage("Checking progress", func() error {
-			var err error
-			progress, err = apiGetProgressForSubmit(cmd.Context(), challengeSlug)
 			return err
-		})
+		}
+		certificate, err := report.ParseCertificateFormat(submitCertificate)
 		if err != nil {
-			ui.Error("Failed to fetch challenge progress")
-			return fmt.Errorf("failed to fetch challenge progress: %w", err)
+			return err
 		}
 
-		if progress == nil {
-			ui.Error("Challenge not started")
-			ui.Info("Please start the challenge first with 'kubeasy challenge start " + challengeSlug + "'")
-			return nil
+		if submitRecord != "" {
+			cc := commandContextFrom(cmd.Context())
+			setCommandContext(cmd, cc)
+			recorder := cc.recordCluster(challengeSlug)
+			defer saveRecording(recorder, submitRecord, false)
 		}
 
-		if progress.Status == "completed" {
-			ui.Warning("Challenge already completed")
-			ui.Info("You can reset the challenge with 'kubeasy challenge reset " + challengeSlug + "'")
+		_, err = runSubmit(cmd.Context(), challengeSlug, submitOptions{Force: submitForce, Reports: reports, Certificate: certificate})
+		if errors.Is(err, errSubmitCancelled) {
 			return nil
 		}
+		return err
+	},
+}
 
-		// Load validations from challenges repo
-		var config *validation.ValidationConfig
-		err = ui.WaitMessage("Loading validations", func() error {
-			var loadErr error
-			config, loadErr = validation.LoadForChallenge(challengeSlug)
-			return loadErr
-		})
-		if err != nil {
-			ui.Error("Failed to load validations")
-			return fmt.Errorf("failed to load validations: %w", err)
-		}
+// errSubmitCancelled is returned by runSubmit when the user declines to submit
+// failing results.
+var errSubmitCancelled = errors.New("submission cancelled")
 
-		if len(config.Validations) == 0 {
-			ui.Warning("No validations found for this challenge")
-			return nil
-		}
+// submitOutcome is the result of a submission that reached the API.
+type submitOutcome struct {
+	AllPassed bool
+	Result    *api.ChallengeSubmitResponse
+}
 
-		// Get Kubernetes clients
-		clientset, err := kube.GetKubernetesClient()
-		if err != nil {
-			ui.Error("Failed to get Kubernetes client")
-			return fmt.Errorf("failed to get Kubernetes client: %w", err)
-		}
+// submitOptions are the flags of 'challenge submit'.
+type submitOptions struct {
+	// Force submits failing results without asking for confirmation.
+	Force bool
+	// Reports are written from the local results (--report).
+	Reports []report.Target
+	// Certificate saves a completion summary in this format on success; "" disables it.
+	Certificate report.CertificateFormat
+}
 
-		dynamicClient, err := kube.GetDynamicClient()
-		if err != nil {
-			ui.Error("Failed to get dynamic client")
-			return fmt.Errorf("failed to get dynamic client: %w", err)
-		}
+// runSubmit runs the validations of a started challenge and submits the results.
+// It returns a nil outcome when nothing was submitted (challenge not started,
+// already completed or without validations). Unless opts.Force is set, the user is
+// asked to confirm before failing results are submitted; declining returns
+// errSubmitCancelled.
+func runSubmit(ctx context.Context, challengeSlug string, opts submitOptions) (*submitOutcome, error) {
+	ui.Section(fmt.Sprintf("Submitting Challenge: %s", challengeSlug))
 
-		restConfig, err := kube.GetRestConfig()
-		if err != nil {
-			ui.Error("Failed to get REST config")
-			return fmt.Errorf("failed to get REST config: %w", err)
+	cc := commandContextFrom(ctx)
+	client := cc.sdkClient(nil)
+	challenge, progress, err := client.CheckSubmittable(ctx, challengeSlug)
+	switch {
+	case errors.Is(err, sdk.ErrNotStarted):
+		ui.Error("Challenge not started")
+		ui.Info("Please start the challenge first with 'kubeasy challenge start " + challengeSlug + "'")
+		return nil, nil
+	case errors.Is(err, sdk.ErrAlreadyCompleted):
+		ui.Warning("Challenge already completed")
+		ui.Info("You can reset the challenge with 'kubeasy challenge reset " + challengeSlug + "'")
+		return nil, nil
+	case err != nil && progress == nil:
+		// Submission limits come with the progress and are shown below
+		ui.Error("Failed to fetch the challenge or its progress")
+		return nil, err
+	}
+	if err := checkSubmitLimits(challengeSlug, progress, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Enforce the optional time box set at start
+	startedAt, hasStart := sdk.StartedAt(challengeSlug, progress)
+	if hasStart {
+		if err := checkTimeLimit(challengeSlug, startedAt, time.Now()); err != nil {
+			return nil, err
 		}
+	}
 
-		namespace := challengeSlug
+	// A crashed Kyverno or provisioner would fail the validations, not the learner
+	cc.runWatchdog(ctx)
 
-		// Create executor and run validations
-		executor := validation.NewExecutor(clientset, dynamicClient, restConfig, namespace)
+	// Forbidden actions are checked over the whole attempt, not the last audit window
+	var since time.Time
+	if hasStart {
+		since = startedAt
+	}
+	verification, err := verifyWithChecklist(ctx, client, challengeSlug, since)
+	if errors.Is(err, sdk.ErrNoValidations) {
+		ui.Warning("No validations found for this challenge")
+		return nil, nil
+	}
+	if err != nil {
+		ui.Error("Failed to run validations")
+		return nil, err
+	}
+	displayVerification(verification)
+	validations, results := verification.Config.Validations, verification.Results
 
-		ui.Info("Running validations...")
-		ui.Println()
+	devutils.DisplaySummary(verification.Summary())
+	if err := writeReports(opts.Reports, report.Run{Name: challengeSlug, Validations: validations, Results: results, Duration: verification.Duration}, false); err != nil {
+		return nil, err
+	}
 
-		results := executor.ExecuteAll(cmd.Context(), config.Validations)
+	confirmed, err := confirmFailedSubmit(verification.Failing(), opts.Force)
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		ui.Info("Submission cancelled, nothing was sent")
+		return nil, errSubmitCancelled
+	}
 
-		// Display results grouped by type
-		allPassed := true
-		var apiResults []api.ObjectiveResult
+	// Display overall result
+	ui.Section("Submission Result")
 
-		// Group validations by type for display
-		typeResults := make(map[validation.ValidationType][]validation.Result)
-		for i, v := range config.Validations {
-			typeResults[v.Type] = append(typeResults[v.Type], results[i])
-		}
+	submitOpts := sdk.SubmitOptions{}
+	if hasStart {
+		submitOpts.StartedAt = startedAt
+	}
+	submission, err := client.Submit(ctx, verification, submitOpts)
+	if submission == nil {
+		ui.Error("Failed to submit results")
+		return nil, err
+	}
+	if submission.EnvironmentRecreated {
+		ui.Warning(fmt.Sprintf("Namespace %s was recreated outside kubeasy since the challenge was started", challengeSlug))
+		ui.Info("Use 'kubeasy challenge reset " + challengeSlug + "' to start over")
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		typeLabels := map[validation.ValidationType]string{
-			validation.TypeStatus:       "Status Validation",
-			validation.TypeCondition:    "Condition Validation",
-			validation.TypeLog:          "Log Validation",
-			validation.TypeEvent:        "Event Validation",
-			validation.TypeConnectivity: "Connectivity Validation",
-			validation.TypeRbac:         "RBAC Validation",
-			validation.TypeSpec:         "Spec Validation",
-			validation.TypeTriggered:    "Triggered Validation",
+	submitResult := submission.Response
+	switch {
+	case submission.Completed():
+		ui.Success("All validations passed!")
+		ui.Println()
+		ui.Success(fmt.Sprintf("Congratulations! Challenge '%s' completed!", challengeSlug))
+		if submitResult.BadgeURL != nil {
+			ui.KeyValue("Badge", *submitResult.BadgeURL)
 		}
-
-		for valType, typeRes := range typeResults {
-			ui.Section(typeLabels[valType])
-			for _, r := range typeRes {
-				ui.ValidationResult(r.Key, r.Passed, []string{r.Message})
-				if !r.Passed {
-					allPassed = false
-				}
-
-				// Convert to API result
-				msg := r.Message
-				apiResults = append(apiResults, api.ObjectiveResult{
-					ObjectiveKey: r.Key,
-					Passed:       r.Passed,
-					Message:      &msg,
-				})
+		if opts.Certificate != "" {
+			cert := completionCertificate(challengeSlug, challenge, validations, results, submitResult, submission.Submissions, time.Now())
+			if hasStart {
+				cert.Duration = cert.CompletedAt.Sub(startedAt)
+			}
+			if path, err := report.SaveCertificate(opts.Certificate, cert); err != nil {
+				ui.Warning(fmt.Sprintf("Could not save the completion summary: %v", err))
+			} else {
+				ui.Info("Completion summary saved to " + path)
 			}
-			ui.Println()
 		}
+		ui.Info("You can clean up with 'kubeasy challenge clean " + challengeSlug + "'")
+	case len(verification.Violations()) > 0:
+		ui.Error("A forbidden action was taken")
+		ui.Info("Reset the challenge with 'kubeasy challenge reset " + challengeSlug + "' and fix it another way")
+	default:
+		ui.Error("Some validations failed")
+		ui.Info("Review the results above and try again")
+	}
+
+	return &submitOutcome{AllPassed: submission.Passed, Result: submitResult}, nil
+}
 
-		// Display overall result
-		ui.Section("Submission Result")
+// validationTypeLabels are the section titles of the results of each validation type.
+var validationTypeLabels = map[validation.ValidationType]string{
+	validation.TypeStatus:              "Status Validation",
+	validation.TypeCondition:           "Condition Validation",
+	validation.TypeLog:                 "Log Validation",
+	validation.TypeEvent:               "Event Validation",
+	validation.TypeConnectivity:        "Connectivity Validation",
+	validation.TypeRbac:                "RBAC Validation",
+	validation.TypeSpec:                "Spec Validation",
+	validation.TypeTriggered:           "Triggered Validation",
+	validation.TypePlugin:              "Plugin Validation",
+	validation.TypePromMetrics:         "Metrics Validation",
+	validation.TypeEndpoints:           "Endpoints Validation",
+	validation.TypeNetworkPolicy:       "NetworkPolicy Validation",
+	validation.TypeNode:                "Node Validation",
+	validation.TypePodDisruptionBudget: "PodDisruptionBudget Validation",
+	validation.TypeProbes:              "Probes Validation",
+	validation.TypeImages:              "Images Validation",
+	validation.TypeResources:           "Resources Validation",
+	validation.TypeMetadata:            "Metadata Validation",
+}
 
-		// Collect audit events recorded since the challenge was started.
-		// If no timestamp is available (e.g. challenge started before audit support),
-		// skip audit collection entirely to avoid leaking historical events.
-		var submitAuditEvents []api.SubmitAuditEvent
-		if ts, err := audit.LoadTimestamp(challengeSlug); err != nil {
-			logger.Debug("No audit timestamp for %s, skipping audit collection: %v", challengeSlug, err)
+// displayVerification shows the results phase by phase for challenges with phases,
+// by validation type otherwise, then the forbidden actions.
+func displayVerification(v *sdk.Verification) {
+	display := func(r validation.Result) {
+		if r.Skipped {
+			ui.ValidationSkipped(r.Key, r.Message)
 		} else {
-			rawAuditEvents, err := audit.ReadAndFilter(audit.GetAuditLogPath(), namespace, ts)
-			if err != nil {
-				logger.Debug("Could not read audit log: %v", err)
-			}
-			// Convert audit.AuditEvent → api.SubmitAuditEvent to keep the api package
-			// free of dependencies on internal implementation packages.
-			submitAuditEvents = make([]api.SubmitAuditEvent, len(rawAuditEvents))
-			for i, e := range rawAuditEvents {
-				submitAuditEvents[i] = api.SubmitAuditEvent{
-					Timestamp:    e.Timestamp,
-					Verb:         e.Verb,
-					Resource:     e.Resource,
-					Subresource:  e.Subresource,
-					Name:         e.Name,
-					Namespace:    e.Namespace,
-					UserAgent:    e.UserAgent,
-					ResponseCode: e.ResponseCode,
+			ui.ValidationResult(r.Key, r.Passed, []string{r.Message})
+		}
+	}
+
+	validations, results := v.Config.Validations, v.Results
+	if phases := validation.GroupByPhase(validations); phases != nil {
+		// Challenges with phases are displayed phase by phase, with a summary for each
+		for _, phase := range phases {
+			ui.Section(phase.Name)
+			passed := 0
+			for _, i := range phase.Indices {
+				display(results[i])
+				if results[i].Passed {
+					passed++
 				}
 			}
+			ui.PhaseSummary(phase.Name, passed, len(phase.Indices))
+			ui.Println()
 		}
-
-		submitReq := api.ChallengeSubmitRequest{Results: apiResults, AuditEvents: submitAuditEvents}
-		submitResult, err := api.SubmitChallenge(cmd.Context(), challengeSlug, submitReq)
-		if err != nil {
-			ui.Error("Failed to submit results")
-			return fmt.Errorf("failed to submit results: %w", err)
+	} else {
+		typeResults := make(map[validation.ValidationType][]validation.Result)
+		for i, val := range validations {
+			typeResults[val.Type] = append(typeResults[val.Type], results[i])
 		}
-
-		// Advance the audit window unconditionally (even on 422 / partial failure).
-		// This is deliberate: re-sending events from a failed window on retry would
-		// cause the backend to receive duplicates. Each submit window is self-contained.
-		if saveErr := audit.SaveTimestamp(challengeSlug); saveErr != nil {
-			logger.Debug("Could not save audit timestamp: %v", saveErr)
+		for valType, typeRes := range typeResults {
+			ui.Section(validationTypeLabels[valType])
+			for _, r := range typeRes {
+				display(r)
+			}
+			ui.Println()
 		}
+	}
 
-		if allPassed && submitResult.Success {
-			ui.Success("All validations passed!")
-			ui.Println()
-			ui.Success(fmt.Sprintf("Congratulations! Challenge '%s' completed!", challengeSlug))
-			ui.Info("You can clean up with 'kubeasy challenge clean " + challengeSlug + "'")
-		} else if !allPassed {
-			ui.Error("Some validations failed")
-			ui.Info("Review the results above and try again")
-		} else if !submitResult.Success {
-			if submitResult.Message != nil {
-				return fmt.Errorf("submission failed: %s", *submitResult.Message)
-			}
-			return fmt.Errorf("submission failed")
+	if len(v.Forbidden) > 0 {
+		ui.Section("Forbidden Actions")
+		for _, r := range v.Forbidden {
+			ui.ValidationResult(r.Key, r.Passed, []string{r.Message})
 		}
+		ui.Println()
+	}
+}
+
+// confirmFailedSubmit lists the objectives that did not pass locally and asks whether
+// to submit anyway, since every submission counts as an attempt. It returns true
+// without asking when nothing failed or force is set.
+func confirmFailedSubmit(failing []string, force bool) (bool, error) {
+	if len(failing) == 0 || force {
+		return true, nil
+	}
+
+	ui.Warning(fmt.Sprintf("%d objective(s) did not pass locally:", len(failing)))
+	_ = ui.BulletList(failing)
+	confirmed, err := ui.Confirm("Submit anyway? This counts as an attempt", false)
+	if errors.Is(err, ui.ErrConfirmationRequired) {
+		return false, fmt.Errorf("some objectives failed: re-run with --force to submit anyway")
+	}
+	return confirmed, err
+}
 
+// checkSubmitLimits refuses to run a submission the API would reject because the
+// challenge has no attempts left or is in a cooldown, and shows the attempts left.
+func checkSubmitLimits(slug string, progress *api.ChallengeStatusResponse, now time.Time) error {
+	var cooldown *sdk.CooldownError
+	switch err := sdk.CheckSubmitLimits(progress, now); {
+	case errors.As(err, &cooldown):
+		wait := formatElapsed(cooldown.Wait)
+		ui.Error(fmt.Sprintf("Submissions are on cooldown: try again in %s", wait))
+		ui.Info("Check the countdown with 'kubeasy status " + slug + "'")
+		return kerrors.New(kerrors.CodeSubmissionCooldown, "submission cooldown: next attempt in %s", wait)
+	case errors.Is(err, sdk.ErrNoAttemptsLeft):
+		ui.Error("No submission attempts left for this challenge")
+		return err
+	}
+	if progress.AttemptsRemaining != nil {
+		ui.Info(fmt.Sprintf("Attempts left: %d (this submission uses one)", *progress.AttemptsRemaining))
+	}
+	return nil
+}
+
+// checkTimeLimit warns when a time-boxed attempt is over its limit, and returns an
+// error instead when the limit is strict.
+func checkTimeLimit(slug string, startedAt, now time.Time) error {
+	limit, ok, err := audit.LoadTimeLimit(slug)
+	if err != nil {
+		logger.Debug("Could not load time limit for %s: %v", slug, err)
 		return nil
-	},
+	}
+	if !ok {
+		return nil
+	}
+	remaining := limit.Remaining(startedAt, now)
+	if remaining > 0 {
+		ui.Info(fmt.Sprintf("Time remaining: %s", formatElapsed(remaining)))
+		return nil
+	}
+	if limit.Strict {
+		ui.Error(fmt.Sprintf("Time limit of %s expired %s ago: submissions are closed", limit.Limit, formatElapsed(-remaining)))
+		ui.Info("Reset the challenge to try again with 'kubeasy challenge reset " + slug + "'")
+		return kerrors.New(kerrors.CodeTimeLimitExpired, "time limit of %s expired", limit.Limit)
+	}
+	ui.Warning(fmt.Sprintf("Time limit of %s expired %s ago", limit.Limit, formatElapsed(-remaining)))
+	return nil
+}
+
+// completionCertificate builds the completion summary of a successful submission.
+func completionCertificate(slug string, challenge *api.ChallengeEntity, validations []validation.Validation, results []validation.Result, res *api.ChallengeSubmitResponse, submissions int, now time.Time) report.Certificate {
+	cert := report.Certificate{
+		Slug:        slug,
+		CompletedAt: now,
+		Submissions: submissions,
+		XPAwarded:   res.XpAwarded,
+		Validations: validations,
+		Results:     results,
+	}
+	if challenge != nil {
+		cert.Title, cert.Difficulty = challenge.Title, challenge.Difficulty
+	}
+	if res.Rank != nil {
+		cert.Rank = *res.Rank
+	}
+	if res.BadgeURL != nil {
+		cert.BadgeURL = *res.BadgeURL
+	}
+	return cert
 }
 
 func init() {
 	challengeCmd.AddCommand(submitCmd)
+	submitCmd.Flags().StringVar(&submitCertificate, "certificate", "", "On completion, save a summary (md or html) under ~/.kubeasy/reports")
+	submitCmd.Flags().StringArrayVar(&submitReports, "report", nil, "Also write the local results as format=path, format being junit or sarif (repeatable)")
+	submitCmd.Flags().StringVar(&submitRecord, "record", "", "Save the cluster responses read by the validations to this directory, for 'kubeasy dev validate --replay'")
+	submitCmd.Flags().BoolVarP(&submitForce, "force", "f", false, "Submit without confirmation even when objectives fail locally (required when not running in a terminal)")
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,57 +21,114 @@ func TestSubmitRunE_InvalidSlug(t *testing.T) {
 
 // TestSubmitRunE_ProgressNil verifies that a nil progress response returns nil (challenge not started guard).
 func TestSubmitRunE_ProgressNil(t *testing.T) {
-	origGetChallenge := apiGetChallengeForSubmit
-	origGetProgress := apiGetProgressForSubmit
-	t.Cleanup(func() {
-		apiGetChallengeForSubmit = origGetChallenge
-		apiGetProgressForSubmit = origGetProgress
-	})
-
-	apiGetChallengeForSubmit = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
 		return &api.ChallengeEntity{Title: "Test"}, nil
 	}
-	apiGetProgressForSubmit = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
+	cc.API.GetProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
 		return nil, nil
 	}
 
+	useCommandContext(t, submitCmd, cc)
 	err := submitCmd.RunE(submitCmd, []string{"pod-evicted"})
 	assert.NoError(t, err)
 }
 
 // TestSubmitRunE_AlreadyCompleted verifies that a completed challenge returns nil (already completed guard).
 func TestSubmitRunE_AlreadyCompleted(t *testing.T) {
-	origGetChallenge := apiGetChallengeForSubmit
-	origGetProgress := apiGetProgressForSubmit
-	t.Cleanup(func() {
-		apiGetChallengeForSubmit = origGetChallenge
-		apiGetProgressForSubmit = origGetProgress
-	})
-
-	apiGetChallengeForSubmit = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
 		return &api.ChallengeEntity{Title: "Test"}, nil
 	}
-	apiGetProgressForSubmit = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
+	cc.API.GetProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
 		return &api.ChallengeStatusResponse{Status: "completed"}, nil
 	}
 
+	useCommandContext(t, submitCmd, cc)
 	err := submitCmd.RunE(submitCmd, []string{"pod-evicted"})
 	assert.NoError(t, err)
 }
 
 // TestSubmitRunE_APIFailure verifies that a GetChallenge API failure returns a non-nil error without panic.
 func TestSubmitRunE_APIFailure(t *testing.T) {
-	origGetChallenge := apiGetChallengeForSubmit
-	t.Cleanup(func() {
-		apiGetChallengeForSubmit = origGetChallenge
-	})
-
-	apiGetChallengeForSubmit = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
 		return nil, fmt.Errorf("network error")
 	}
 
 	assert.NotPanics(t, func() {
+		useCommandContext(t, submitCmd, cc)
 		err := submitCmd.RunE(submitCmd, []string{"pod-evicted"})
 		require.Error(t, err)
 	})
 }
+
+// TestSubmitRunE_StrictTimeLimitExpired verifies that a strict, expired time limit blocks the submission.
+func TestSubmitRunE_StrictTimeLimitExpired(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cc := testCommandContext()
+	cc.API.GetChallenge = func(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+		return &api.ChallengeEntity{Title: "Test"}, nil
+	}
+	cc.API.GetProgress = func(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
+		return &api.ChallengeStatusResponse{Status: "in_progress"}, nil
+	}
+	require.NoError(t, audit.SaveStartTime("pod-evicted", time.Now().Add(-2*time.Hour)))
+	require.NoError(t, audit.SaveTimeLimit("pod-evicted", audit.TimeLimit{Limit: time.Hour, Strict: true}))
+
+	useCommandContext(t, submitCmd, cc)
+	err := submitCmd.RunE(submitCmd, []string{"pod-evicted"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "time limit")
+}
+
+func TestConfirmFailedSubmit(t *testing.T) {
+	ok, err := confirmFailedSubmit(nil, false)
+	require.NoError(t, err)
+	assert.True(t, ok, "nothing to confirm when everything passed")
+
+	ok, err = confirmFailedSubmit([]string{"logs"}, true)
+	require.NoError(t, err)
+	assert.True(t, ok, "--force skips the prompt")
+
+	// Tests run non-interactively, so a needed prompt fails instead of hanging
+	_, err = confirmFailedSubmit([]string{"forbidden action: no-delete"}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+}
+
+func TestCheckSubmitLimits(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	attempts := func(n int) *int { return &n }
+	at := func(ts time.Time) *string { s := ts.Format(time.RFC3339); return &s }
+
+	assert.NoError(t, checkSubmitLimits("demo", &api.ChallengeStatusResponse{}, now), "no limits reported")
+	assert.NoError(t, checkSubmitLimits("demo", &api.ChallengeStatusResponse{AttemptsRemaining: attempts(1)}, now))
+	assert.NoError(t, checkSubmitLimits("demo", &api.ChallengeStatusResponse{CooldownUntil: at(now.Add(-time.Second))}, now), "cooldown over")
+
+	err := checkSubmitLimits("demo", &api.ChallengeStatusResponse{AttemptsRemaining: attempts(0)}, now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no submission attempts left")
+
+	err = checkSubmitLimits("demo", &api.ChallengeStatusResponse{AttemptsRemaining: attempts(3), CooldownUntil: at(now.Add(90 * time.Second))}, now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "next attempt in 1m30s")
+}
+
+func TestCompletionCertificate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	xp, rank, badge := 50, "Apprentice", "https://kubeasy.dev/badges/pod-evicted.svg"
+	res := &api.ChallengeSubmitResponse{Success: true, XpAwarded: &xp, Rank: &rank, BadgeURL: &badge}
+
+	cert := completionCertificate("pod-evicted", &api.ChallengeEntity{Title: "Pod Evicted", Difficulty: "easy"}, nil, nil, res, 3, now)
+	assert.Equal(t, "pod-evicted", cert.Slug)
+	assert.Equal(t, "Pod Evicted", cert.Title)
+	assert.Equal(t, 3, cert.Submissions)
+	assert.Equal(t, &xp, cert.XPAwarded)
+	assert.Equal(t, "Apprentice", cert.Rank)
+	assert.Equal(t, badge, cert.BadgeURL)
+
+	cert = completionCertificate("pod-evicted", nil, nil, nil, &api.ChallengeSubmitResponse{Success: true}, 0, now)
+	assert.Empty(t, cert.Title)
+	assert.Empty(t, cert.BadgeURL)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/telemetry"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var telemetryCmd = &cobra.Command{
+	Use:       "telemetry [on|off]",
+	Short:     "Show or change whether environment details are sent with submissions",
+	ValidArgs: []string{"on", "off"},
+	Long: `With telemetry on, each submission includes an anonymized description of your
+environment: CLI version, OS and architecture, Kubernetes version and cluster
+provider (e.g. kind). It helps correlate failing challenges with environments.
+No host names, user names or addresses are sent.
+
+Telemetry is off until you turn it on. DO_NOT_TRACK=1 or KUBEASY_TELEMETRY=off
+disable it regardless of this setting.`,
+	Args:          cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			if err := telemetry.SetConsent(args[0] == "on"); err != nil {
+				ui.Error("Failed to save telemetry setting")
+				return fmt.Errorf("failed to save telemetry setting: %w", err)
+			}
+		}
+
+		switch {
+		case telemetry.DisabledByEnv():
+			ui.Info("Telemetry is off (disabled by DO_NOT_TRACK or KUBEASY_TELEMETRY)")
+		case telemetry.Enabled():
+			ui.Info("Telemetry is on: submissions include anonymized environment details")
+		default:
+			ui.Info("Telemetry is off: turn it on with 'kubeasy telemetry on'")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(telemetryCmd)
+}
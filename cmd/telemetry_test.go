@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetryCmd_TogglesConsent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("KUBEASY_TELEMETRY", "")
+
+	require.NoError(t, telemetryCmd.RunE(telemetryCmd, nil))
+	assert.False(t, telemetry.Enabled(), "telemetry is opt-in")
+
+	require.NoError(t, telemetryCmd.RunE(telemetryCmd, []string{"on"}))
+	assert.True(t, telemetry.Enabled())
+
+	require.NoError(t, telemetryCmd.RunE(telemetryCmd, []string{"off"}))
+	assert.False(t, telemetry.Enabled())
+}
+
+func TestTelemetryCmd_RejectsUnknownValue(t *testing.T) {
+	require.Error(t, telemetryCmd.Args(telemetryCmd, []string{"maybe"}))
+}
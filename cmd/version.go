@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/semver"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +23,7 @@ var versionCmd = &cobra.Command{
 		current := constants.Version
 		fmt.Printf("kubeasy-cli %s\n", current)
 		fmt.Printf("Go %s - %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+		printClusterFingerprint(cmd.Context())
 
 		if semver.IsPreRelease(current) {
 			fmt.Printf("Pre-release build (%s), skipping update check.\n", current)
@@ -85,3 +88,40 @@ func fetchLatestVersion() (string, error) {
 	}
 	return version, nil
 }
+
+// printClusterFingerprint prints the environment recorded by 'kubeasy setup', for bug
+// reports. It prints nothing when the cluster is unreachable or was never set up.
+func printClusterFingerprint(ctx context.Context) {
+	cc := commandContextFrom(ctx)
+	clientset, err := cc.Clientset()
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	fp, err := kube.ReadClusterFingerprint(ctx, clientset)
+	if err != nil {
+		cc.Log.Debug("Could not read cluster fingerprint: %v", err)
+		return
+	}
+	fmt.Println(formatClusterFingerprint(*fp))
+	for _, issue := range fp.CompatibilityIssues(constants.Version) {
+		fmt.Printf("Warning: %s\n", issue)
+	}
+}
+
+// formatClusterFingerprint describes fp on one line.
+func formatClusterFingerprint(fp kube.ClusterFingerprint) string {
+	if fp.SchemaVersion == 0 {
+		return "Cluster: set up by an older kubeasy-cli"
+	}
+	line := fmt.Sprintf("Cluster: set up by kubeasy-cli %s on %s (schema %d)",
+		fp.CLIVersion, fp.SetupAt.Local().Format(time.DateTime), fp.SchemaVersion)
+	if fp.Architecture != "" {
+		line += ", " + fp.Architecture
+	}
+	if len(fp.Addons) > 0 {
+		line += ", add-ons: " + strings.Join(fp.Addons, ", ")
+	}
+	return line
+}
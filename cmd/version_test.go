@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/semver"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestIsPreRelease(t *testing.T) {
@@ -29,3 +32,15 @@ func TestIsPreRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatClusterFingerprint(t *testing.T) {
+	fp := kube.ClusterFingerprint{
+		CLIVersion:    "v2.8.0",
+		SetupAt:       time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local),
+		Addons:        []string{"kyverno", "cert-manager"},
+		Architecture:  "arm64",
+		SchemaVersion: 1,
+	}
+	assert.Equal(t, "Cluster: set up by kubeasy-cli v2.8.0 on 2026-03-01 10:00:00 (schema 1), arm64, add-ons: kyverno, cert-manager", formatClusterFingerprint(fp))
+	assert.Equal(t, "Cluster: set up by an older kubeasy-cli", formatClusterFingerprint(kube.ClusterFingerprint{}))
+}
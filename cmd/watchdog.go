@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+)
+
+// healPlatform is the default commandContext.HealPlatform.
+func healPlatform(ctx context.Context, cluster *sdk.Cluster) ([]deployer.Repair, error) {
+	return deployer.HealPlatform(ctx, cluster.Clientset, cluster.DynamicClient)
+}
+
+// runWatchdog repairs the platform components of the kubeasy cluster before a flow
+// depends on them, printing what was repaired. Only clusters set up by 'kubeasy
+// setup' are touched. It never fails the flow: connection errors are left for the
+// flow to report, and failed repairs are only warned about.
+func (cc *commandContext) runWatchdog(ctx context.Context) {
+	if cc.HealPlatform == nil {
+		return
+	}
+	cluster, err := cc.Cluster()
+	if err != nil {
+		logger.Debug("Watchdog skipped: %v", err)
+		return
+	}
+	if err := kube.VerifyClusterMarker(ctx, cluster.Clientset); err != nil {
+		logger.Debug("Watchdog skipped: %v", err)
+		return
+	}
+	repairs, err := cc.HealPlatform(ctx, cluster)
+	for _, r := range repairs {
+		ui.Info(fmt.Sprintf("Repaired %s: %s", r.Component, r.Action))
+	}
+	if err != nil {
+		ui.Warning(fmt.Sprintf("Some platform components are unhealthy (%v): run 'kubeasy setup' if the challenge misbehaves", err))
+	}
+}
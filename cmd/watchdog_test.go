@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunWatchdog(t *testing.T) {
+	ctx := context.Background()
+	cc := testCommandContext()
+	calls := 0
+	cc.HealPlatform = func(context.Context, *sdk.Cluster) ([]deployer.Repair, error) {
+		calls++
+		return []deployer.Repair{{Component: "kyverno", Action: "reinstalled"}}, errors.New("local-path-provisioner: still not ready")
+	}
+
+	cc.runWatchdog(ctx)
+	assert.Equal(t, 0, calls, "clusters not set up by kubeasy are left alone")
+
+	clientset, err := cc.Clientset()
+	require.NoError(t, err)
+	require.NoError(t, kube.EnsureClusterMarker(ctx, clientset))
+	assert.NotPanics(t, func() { cc.runWatchdog(ctx) })
+	assert.Equal(t, 1, calls)
+
+	cc.HealPlatform = nil
+	assert.NotPanics(t, func() { cc.runWatchdog(ctx) }, "a nil HealPlatform skips the watchdog")
+}
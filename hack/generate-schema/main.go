@@ -0,0 +1,32 @@
+// Command generate-schema writes the challenge.yaml JSON Schema that is embedded
+// into the CLI (internal/validation/schema.json).
+//
+// Usage:
+//
+//	go generate ./internal/validation
+//	go run ./hack/generate-schema -o internal/validation/schema.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+)
+
+func main() {
+	out := flag.String("o", "internal/validation/schema.json", "output path for the JSON Schema")
+	flag.Parse()
+
+	data, err := validation.GenerateJSONSchema()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generate-schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*out, data, 0o644); err != nil { //nolint:gosec // generated source file, world-readable by design
+		fmt.Fprintf(os.Stderr, "generate-schema: failed to write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+}
@@ -3,24 +3,51 @@ package api
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/apigen"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/keystore"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
 )
 
-// getAuthToken retrieves the API token from available storage
+var (
+	credentialsMu sync.RWMutex
+	credentials   = keystore.Auto()
+)
+
+// SetCredentialStore sets where authenticated clients read the API key from. It
+// defaults to keystore.Auto.
+func SetCredentialStore(store keystore.CredentialStore) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	credentials = store
+}
+
+// CredentialStore returns the store set by SetCredentialStore.
+func CredentialStore() keystore.CredentialStore {
+	credentialsMu.RLock()
+	defer credentialsMu.RUnlock()
+	return credentials
+}
+
+// getAuthToken retrieves the API token from the credential store
 func getAuthToken() (string, error) {
-	token, err := keystore.Get()
+	token, err := CredentialStore().Get()
 	if err != nil {
-		return "", err
+		// No stored key, or a store that cannot be read: either way the user must log in
+		return "", kerrors.Wrap(kerrors.CodeAPIUnauthorized, err)
 	}
 	return token, nil
 }
 
 // NewAuthenticatedClient creates an apigen.ClientWithResponses with Bearer token authentication.
 func NewAuthenticatedClient() (*apigen.ClientWithResponses, error) {
+	if err := constants.ValidateWebsiteURL(constants.WebsiteURL); err != nil {
+		return nil, err
+	}
 	token, err := getAuthToken()
 	if err != nil {
 		return nil, err
@@ -32,15 +59,18 @@ func NewAuthenticatedClient() (*apigen.ClientWithResponses, error) {
 			req.Header.Set("Authorization", "Bearer "+token)
 			return nil
 		}),
-		apigen.WithHTTPClient(&http.Client{Timeout: 30 * time.Second}),
+		apigen.WithHTTPClient(&http.Client{Timeout: 30 * time.Second, Transport: runlog.Transport(runlog.ScopeAPI, nil)}),
 	)
 }
 
 // NewPublicClient creates an apigen.ClientWithResponses without authentication.
 // Use this for public endpoints that don't require a Bearer token.
 func NewPublicClient() (*apigen.ClientWithResponses, error) {
+	if err := constants.ValidateWebsiteURL(constants.WebsiteURL); err != nil {
+		return nil, err
+	}
 	return apigen.NewClientWithResponses(
 		constants.WebsiteURL,
-		apigen.WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
+		apigen.WithHTTPClient(&http.Client{Timeout: 10 * time.Second, Transport: runlog.Transport(runlog.ScopeAPI, nil)}),
 	)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/apigen"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
 )
 
@@ -29,13 +30,34 @@ func timeToString(t *time.Time) string {
 	return t.Format(time.RFC3339)
 }
 
-// parseErrorResponse extracts an error message from a generated response.
+// parseErrorResponse extracts an error message from a generated response, with the
+// code of its status.
 func parseErrorResponse(resp *http.Response, body []byte) error {
+	code := statusCode(resp.StatusCode)
 	var errResp ErrorResponse
 	if err := json.Unmarshal(body, &errResp); err != nil {
-		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+		return kerrors.New(code, "request failed with status %d", resp.StatusCode)
 	}
-	return fmt.Errorf("API error: %s", errResp.Error)
+	return kerrors.New(code, "API error: %s", errResp.Error)
+}
+
+// statusCode returns the error code of an HTTP error status.
+func statusCode(status int) kerrors.Code {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return kerrors.CodeAPIUnauthorized
+	case status == http.StatusNotFound:
+		return kerrors.CodeAPINotFound
+	case status >= http.StatusInternalServerError:
+		return kerrors.CodeAPIUnavailable
+	}
+	return kerrors.CodeAPIError
+}
+
+// requestError is the error of a request that got no response. Cancellations and
+// deadlines keep their own code (see kerrors.Wrap).
+func requestError(err error) error {
+	return kerrors.Wrap(kerrors.CodeAPIUnreachable, fmt.Errorf("failed to make request: %w", err))
 }
 
 // GetProfile fetches the current user's profile via GET /api/user/me
@@ -47,7 +69,7 @@ func GetProfile(ctx context.Context) (*UserProfile, error) {
 
 	resp, err := client.GetUserMeWithResponse(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, requestError(err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -55,7 +77,7 @@ func GetProfile(ctx context.Context) (*UserProfile, error) {
 	}
 
 	if resp.JSON200 == nil {
-		return nil, fmt.Errorf("not authenticated")
+		return nil, kerrors.New(kerrors.CodeAPIUnauthorized, "not authenticated")
 	}
 
 	user := *resp.JSON200
@@ -67,6 +89,7 @@ func GetProfile(ctx context.Context) (*UserProfile, error) {
 	}
 
 	profile := &UserProfile{
+		ID:        user.Id,
 		FirstName: firstName,
 		LastName:  lastName,
 	}
@@ -87,7 +110,7 @@ func Login(ctx context.Context) (*LoginResponse, error) {
 		Arch:       runtime.GOARCH,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to track login: %w", err)
+		return nil, kerrors.Wrap(kerrors.CodeAPIUnreachable, fmt.Errorf("failed to track login: %w", err))
 	}
 	if trackResp.StatusCode() != http.StatusOK {
 		return nil, parseErrorResponse(trackResp.HTTPResponse, trackResp.Body)
@@ -116,11 +139,11 @@ func GetChallengeBySlug(ctx context.Context, slug string) (*ChallengeEntity, err
 
 	resp, err := client.GetChallengeWithResponse(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, requestError(err)
 	}
 
 	if resp.StatusCode() == http.StatusNotFound {
-		return nil, fmt.Errorf("challenge '%s' not found", slug)
+		return nil, kerrors.New(kerrors.CodeAPINotFound, "challenge '%s' not found", slug)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Challenge == nil {
@@ -139,6 +162,45 @@ func GetChallengeBySlug(ctx context.Context, slug string) (*ChallengeEntity, err
 	return challenge, nil
 }
 
+// GetBundleBySlug fetches an exam bundle via GET /api/bundles/:slug
+func GetBundleBySlug(ctx context.Context, slug string) (*BundleResponse, error) {
+	client, err := NewPublicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetBundleWithResponse(ctx, slug)
+	if err != nil {
+		return nil, requestError(err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, kerrors.New(kerrors.CodeAPINotFound, "bundle '%s' not found", slug)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, parseErrorResponse(resp.HTTPResponse, resp.Body)
+	}
+
+	b := resp.JSON200.Bundle
+	bundle := &BundleResponse{
+		Slug:             b.Slug,
+		Title:            b.Title,
+		Description:      b.Description,
+		TimeLimitMinutes: b.TimeLimitMinutes,
+		Challenges:       make([]BundleChallenge, len(b.Challenges)),
+	}
+	for i, c := range b.Challenges {
+		bundle.Challenges[i] = BundleChallenge{
+			Slug:          c.Slug,
+			Title:         c.Title,
+			Difficulty:    c.Difficulty,
+			EstimatedTime: c.EstimatedTime,
+		}
+	}
+	return bundle, nil
+}
+
 // GetChallengeStatus fetches the user's progress status via GET /api/progress/:slug
 func GetChallengeStatus(ctx context.Context, slug string) (*ChallengeStatusResponse, error) {
 	client, err := NewAuthenticatedClient()
@@ -148,11 +210,11 @@ func GetChallengeStatus(ctx context.Context, slug string) (*ChallengeStatusRespo
 
 	resp, err := client.GetChallengeStatusWithResponse(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, requestError(err)
 	}
 
 	if resp.StatusCode() == http.StatusNotFound {
-		return nil, fmt.Errorf("challenge '%s' not found", slug)
+		return nil, kerrors.New(kerrors.CodeAPINotFound, "challenge '%s' not found", slug)
 	}
 
 	if resp.JSON200 == nil {
@@ -160,9 +222,11 @@ func GetChallengeStatus(ctx context.Context, slug string) (*ChallengeStatusRespo
 	}
 
 	status := &ChallengeStatusResponse{
-		Status:      string(resp.JSON200.Status),
-		StartedAt:   timeToStringPtr(resp.JSON200.StartedAt),
-		CompletedAt: timeToStringPtr(resp.JSON200.CompletedAt),
+		Status:            string(resp.JSON200.Status),
+		StartedAt:         timeToStringPtr(resp.JSON200.StartedAt),
+		CompletedAt:       timeToStringPtr(resp.JSON200.CompletedAt),
+		AttemptsRemaining: resp.JSON200.AttemptsRemaining,
+		CooldownUntil:     timeToStringPtr(resp.JSON200.CooldownUntil),
 	}
 	return status, nil
 }
@@ -176,11 +240,11 @@ func StartChallengeWithResponse(ctx context.Context, slug string) (*ChallengeSta
 
 	resp, err := client.StartChallengeWithResponse(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, requestError(err)
 	}
 
 	if resp.StatusCode() == http.StatusNotFound {
-		return nil, fmt.Errorf("challenge '%s' not found", slug)
+		return nil, kerrors.New(kerrors.CodeAPINotFound, "challenge '%s' not found", slug)
 	}
 
 	if resp.JSON200 == nil {
@@ -202,87 +266,18 @@ func SubmitChallenge(ctx context.Context, slug string, req ChallengeSubmitReques
 		return nil, err
 	}
 
-	// Map CLI types to generated types
-	results := make([]struct {
-		Message      *string `json:"message,omitempty"`
-		ObjectiveKey string  `json:"objectiveKey"`
-		Passed       bool    `json:"passed"`
-	}, len(req.Results))
-	for i, r := range req.Results {
-		r := r
-		results[i] = struct {
-			Message      *string `json:"message,omitempty"`
-			ObjectiveKey string  `json:"objectiveKey"`
-			Passed       bool    `json:"passed"`
-		}{
-			ObjectiveKey: r.ObjectiveKey,
-			Passed:       r.Passed,
-			Message:      r.Message,
-		}
+	if req.Results == nil {
+		// The API requires "results" to be an array, never null.
+		req.Results = []ObjectiveResult{}
 	}
 
-	auditEvents := make([]struct {
-		Name         *string   `json:"name,omitempty"`
-		Namespace    *string   `json:"namespace,omitempty"`
-		Resource     string    `json:"resource"`
-		ResponseCode *int      `json:"responseCode,omitempty"`
-		Subresource  *string   `json:"subresource,omitempty"`
-		Timestamp    time.Time `json:"timestamp"`
-		UserAgent    *string   `json:"userAgent,omitempty"`
-		Verb         string    `json:"verb"`
-	}, len(req.AuditEvents))
-	for i, e := range req.AuditEvents {
-		e := e
-		var subresource, name, namespace, userAgent *string
-		if e.Subresource != "" {
-			subresource = &e.Subresource
-		}
-		if e.Name != "" {
-			name = &e.Name
-		}
-		if e.Namespace != "" {
-			namespace = &e.Namespace
-		}
-		if e.UserAgent != "" {
-			userAgent = &e.UserAgent
-		}
-		var rcPtr *int
-		if e.ResponseCode != 0 {
-			rc := e.ResponseCode
-			rcPtr = &rc
-		}
-
-		auditEvents[i] = struct {
-			Name         *string   `json:"name,omitempty"`
-			Namespace    *string   `json:"namespace,omitempty"`
-			Resource     string    `json:"resource"`
-			ResponseCode *int      `json:"responseCode,omitempty"`
-			Subresource  *string   `json:"subresource,omitempty"`
-			Timestamp    time.Time `json:"timestamp"`
-			UserAgent    *string   `json:"userAgent,omitempty"`
-			Verb         string    `json:"verb"`
-		}{
-			Timestamp:    e.Timestamp,
-			Verb:         e.Verb,
-			Resource:     e.Resource,
-			Subresource:  subresource,
-			Name:         name,
-			Namespace:    namespace,
-			UserAgent:    userAgent,
-			ResponseCode: rcPtr,
-		}
-	}
-
-	resp, err := client.SubmitChallengeWithResponse(ctx, slug, apigen.SubmitChallengeJSONRequestBody{
-		Results:     results,
-		AuditEvents: &auditEvents,
-	})
+	resp, err := client.SubmitChallengeWithResponse(ctx, slug, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, requestError(err)
 	}
 
 	if resp.StatusCode() == http.StatusNotFound {
-		return nil, fmt.Errorf("challenge '%s' not found", slug)
+		return nil, kerrors.New(kerrors.CodeAPINotFound, "challenge '%s' not found", slug)
 	}
 
 	if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusUnprocessableEntity {
@@ -305,11 +300,11 @@ func ResetChallenge(ctx context.Context, slug string) (*ChallengeResetResponse,
 
 	resp, err := client.ResetChallengeWithResponse(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, requestError(err)
 	}
 
 	if resp.StatusCode() == http.StatusNotFound {
-		return nil, fmt.Errorf("challenge '%s' not found", slug)
+		return nil, kerrors.New(kerrors.CodeAPINotFound, "challenge '%s' not found", slug)
 	}
 
 	if resp.JSON200 == nil {
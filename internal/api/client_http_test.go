@@ -3,12 +3,15 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/keystore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zalando/go-keyring"
@@ -91,6 +94,7 @@ func TestGetProfile_APIError(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, profile)
 	assert.Contains(t, err.Error(), "API error: Unauthorized")
+	assert.Equal(t, kerrors.CodeAPIUnauthorized, kerrors.CodeOf(err))
 }
 
 func TestGetProfile_InvalidJSON(t *testing.T) {
@@ -161,6 +165,58 @@ func TestGetChallengeBySlug_NotFound(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, challenge)
 	assert.Contains(t, err.Error(), "challenge 'nonexistent' not found")
+	assert.Equal(t, kerrors.CodeAPINotFound, kerrors.CodeOf(err))
+}
+
+func TestGetBundleBySlug_Success(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/bundles/cka-warmup", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		response := map[string]interface{}{
+			"bundle": map[string]interface{}{
+				"slug":             "cka-warmup",
+				"title":            "CKA Warm-up",
+				"description":      "Three quick troubleshooting tasks",
+				"timeLimitMinutes": 60,
+				"challenges": []map[string]interface{}{
+					{"slug": "pod-evicted", "title": "Pod Evicted", "difficulty": "easy", "estimatedTime": 15},
+					{"slug": "rbac-101", "title": "RBAC 101", "difficulty": "medium", "estimatedTime": 20},
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	})
+	defer server.Close()
+	defer overrideServerURL(t, server.URL)()
+
+	bundle, err := GetBundleBySlug(context.Background(), "cka-warmup")
+
+	require.NoError(t, err)
+	assert.Equal(t, "CKA Warm-up", bundle.Title)
+	require.NotNil(t, bundle.TimeLimitMinutes)
+	assert.Equal(t, 60, *bundle.TimeLimitMinutes)
+	require.Len(t, bundle.Challenges, 2)
+	assert.Equal(t, "rbac-101", bundle.Challenges[1].Slug)
+	assert.Equal(t, 20, bundle.Challenges[1].EstimatedTime)
+}
+
+func TestGetBundleBySlug_NotFound(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "Not found"})
+	})
+	defer server.Close()
+	defer overrideServerURL(t, server.URL)()
+
+	bundle, err := GetBundleBySlug(context.Background(), "nonexistent")
+
+	require.Error(t, err)
+	assert.Nil(t, bundle)
+	assert.Contains(t, err.Error(), "bundle 'nonexistent' not found")
 }
 
 func TestGetChallengeStatus_Success(t *testing.T) {
@@ -189,6 +245,31 @@ func TestGetChallengeStatus_Success(t *testing.T) {
 	assert.NotNil(t, status.StartedAt)
 }
 
+func TestGetChallengeStatus_AttemptLimits(t *testing.T) {
+	setupKeyring(t, "test-token")
+	defer cleanupKeyring(t)
+
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":            "in_progress",
+			"attemptsRemaining": 2,
+			"cooldownUntil":     "2024-01-01T00:10:00Z",
+		})
+	})
+	defer server.Close()
+	defer overrideServerURL(t, server.URL)()
+
+	status, err := GetChallengeStatus(context.Background(), "pod-evicted")
+
+	require.NoError(t, err)
+	require.NotNil(t, status.AttemptsRemaining)
+	assert.Equal(t, 2, *status.AttemptsRemaining)
+	require.NotNil(t, status.CooldownUntil)
+	assert.Equal(t, "2024-01-01T00:10:00Z", *status.CooldownUntil)
+}
+
 func TestStartChallengeWithResponse_Success(t *testing.T) {
 	setupKeyring(t, "test-token")
 	defer cleanupKeyring(t)
@@ -249,6 +330,27 @@ func TestSubmitChallenge_Success(t *testing.T) {
 	assert.True(t, response.Success)
 }
 
+func TestSubmitChallenge_NoResults(t *testing.T) {
+	setupKeyring(t, "test-token")
+	defer cleanupKeyring(t)
+
+	var body map[string]interface{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(ChallengeSubmitResponse{Success: true})
+	})
+	defer server.Close()
+	defer overrideServerURL(t, server.URL)()
+
+	_, err := SubmitChallenge(context.Background(), "pod-evicted", ChallengeSubmitRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"results": []interface{}{}}, body,
+		"results is sent as an empty array and unset optional fields are omitted")
+}
+
 func TestResetChallenge_Success(t *testing.T) {
 	setupKeyring(t, "test-token")
 	defer cleanupKeyring(t)
@@ -334,7 +436,7 @@ func TestTrackSetup_Success(t *testing.T) {
 	assert.True(t, called, "expected tracking request to be sent")
 }
 
-// Helper function for string pointers
+// Helper functions for pointers
 func strPtr(s string) *string {
 	return &s
 }
@@ -345,3 +447,46 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 	os.Exit(code)
 }
+
+// stubStore is a CredentialStore holding a fixed token.
+type stubStore string
+
+func (s stubStore) Get() (string, error)                     { return string(s), nil }
+func (s stubStore) Set(string) (keystore.StorageType, error) { return "", keystore.ErrReadOnly }
+func (s stubStore) Delete() error                            { return nil }
+
+func TestSetCredentialStore(t *testing.T) {
+	setupKeyring(t, "keyring-token")
+	defer cleanupKeyring(t)
+	SetCredentialStore(stubStore("injected-token"))
+	defer SetCredentialStore(keystore.Auto())
+
+	var auth string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "u1", "firstName": "Ada"})
+	})
+	defer server.Close()
+	defer overrideServerURL(t, server.URL)()
+
+	_, err := GetProfile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer injected-token", auth)
+}
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, kerrors.CodeAPIUnauthorized, statusCode(http.StatusForbidden))
+	assert.Equal(t, kerrors.CodeAPINotFound, statusCode(http.StatusNotFound))
+	assert.Equal(t, kerrors.CodeAPIUnavailable, statusCode(http.StatusBadGateway))
+	assert.Equal(t, kerrors.CodeAPIError, statusCode(http.StatusConflict))
+}
+
+func TestRequestError(t *testing.T) {
+	err := requestError(errors.New("connection refused"))
+	assert.Equal(t, kerrors.CodeAPIUnreachable, kerrors.CodeOf(err))
+	assert.Equal(t, "failed to make request: connection refused", err.Error())
+
+	// A cancelled request is an interruption, not an unreachable API
+	assert.Equal(t, kerrors.CodeInterrupted, kerrors.CodeOf(requestError(context.Canceled)))
+}
@@ -1,27 +1,31 @@
 // Package api provides the HTTP client layer for the Kubeasy CLI API.
 //
-// Response types are kept here as stable interfaces for the rest of the CLI.
-// The generated client (internal/apigen) uses inline anonymous structs, so
-// these named types provide backward compatibility and a cleaner API.
+// All HTTP calls go through the client generated from the backend OpenAPI spec
+// (internal/apigen, see `go generate ./internal/apigen`). The submit
+// request and the error body are component schemas of the spec, so they are
+// aliases of the generated models. The other responses are declared inline, so
+// the generated client only exposes anonymous structs for them; the named
+// response types below are the view used by the rest of the CLI.
 
 package api
 
-import "time"
+import "github.com/kubeasy-dev/kubeasy-cli/internal/apigen"
 
-// UserResponse represents the response from GET /api/cli/user
+// UserResponse represents the response from GET /api/user/me
 type UserResponse struct {
+	ID        string  `json:"id"`
 	FirstName string  `json:"firstName"`
 	LastName  *string `json:"lastName,omitempty"`
 }
 
-// LoginResponse represents the response from POST /api/cli/user
+// LoginResponse combines POST /api/cli/track/login with the GET /api/user/me profile
 type LoginResponse struct {
 	FirstName  string  `json:"firstName"`
 	LastName   *string `json:"lastName,omitempty"`
 	FirstLogin *bool   `json:"firstLogin,omitempty"`
 }
 
-// ChallengeResponse represents the response from GET /api/cli/challenge/[slug]
+// ChallengeResponse represents the response from GET /api/challenges/:slug
 type ChallengeResponse struct {
 	ID               int    `json:"id"`
 	Title            string `json:"title"`
@@ -32,45 +36,44 @@ type ChallengeResponse struct {
 	InitialSituation string `json:"initial_situation"`
 }
 
-// ChallengeStatusResponse represents the response from GET /api/cli/challenge/[slug]/status
+// ChallengeStatusResponse represents the response from GET /api/progress/:slug
 type ChallengeStatusResponse struct {
 	Status      string  `json:"status"`                // "not_started" | "in_progress" | "completed"
 	StartedAt   *string `json:"startedAt,omitempty"`   // ISO 8601 date string
 	CompletedAt *string `json:"completedAt,omitempty"` // ISO 8601 date string
+	// AttemptsRemaining is the number of submissions left, nil when unlimited.
+	AttemptsRemaining *int `json:"attemptsRemaining,omitempty"`
+	// CooldownUntil is when the next submission will be accepted, nil without cooldown.
+	CooldownUntil *string `json:"cooldownUntil,omitempty"` // ISO 8601 date string
 }
 
-// ChallengeStartResponse represents the response from POST /api/cli/challenge/[slug]/start
+// ChallengeStartResponse represents the response from POST /api/progress/:slug/start
 type ChallengeStartResponse struct {
 	Status    string  `json:"status"`    // "in_progress" | "completed"
 	StartedAt string  `json:"startedAt"` // ISO 8601 date string
 	Message   *string `json:"message,omitempty"`
 }
 
-// ObjectiveResult represents the raw validation result from a CRD
-type ObjectiveResult struct {
-	ObjectiveKey string  `json:"objectiveKey"`      // CRD metadata.name
-	Passed       bool    `json:"passed"`            // CRD status.allPassed
-	Message      *string `json:"message,omitempty"` // CRD status message or error
-}
-
-// SubmitAuditEvent is the audit event payload sent alongside validation results.
-// Fields are a subset of the Kubernetes audit event — enough for session replay and coaching.
-type SubmitAuditEvent struct {
-	Timestamp    time.Time `json:"timestamp"`
-	Verb         string    `json:"verb"`
-	Resource     string    `json:"resource"`
-	Subresource  string    `json:"subresource,omitempty"`
-	Name         string    `json:"name,omitempty"`
-	Namespace    string    `json:"namespace,omitempty"`
-	UserAgent    string    `json:"userAgent,omitempty"`
-	ResponseCode int       `json:"responseCode,omitempty"`
-}
-
-// ChallengeSubmitRequest represents the request body for POST /api/cli/challenge/[slug]/submit
-type ChallengeSubmitRequest struct {
-	Results     []ObjectiveResult  `json:"results"`
-	AuditEvents []SubmitAuditEvent `json:"auditEvents,omitempty"`
-}
+// Types of the submit request body of POST /api/challenges/:slug/submit.
+type (
+	// ChallengeSubmitRequest is the submit request body.
+	ChallengeSubmitRequest = apigen.SubmitChallengeJSONRequestBody
+	// ObjectiveResult is the raw validation result of an objective.
+	ObjectiveResult = apigen.ObjectiveResult
+	// ObjectiveComparison is one failed comparison of an objective, so the website
+	// can render observed and expected values as a diff.
+	ObjectiveComparison = apigen.ObjectiveComparison
+	// ObjectRef names the Kubernetes object a comparison was made on.
+	ObjectRef = apigen.ObjectRef
+	// SubmitAuditEvent is a subset of a Kubernetes audit event, enough for session
+	// replay and coaching.
+	SubmitAuditEvent = apigen.SubmitAuditEvent
+	// ForbiddenActionViolation is a forbidden action of a challenge that was taken.
+	ForbiddenActionViolation = apigen.ForbiddenActionViolation
+	// SubmitEnvironment is an anonymized fingerprint of the environment a submission
+	// was validated in. It holds no host names, user names or addresses.
+	SubmitEnvironment = apigen.SubmitEnvironment
+)
 
 // ChallengeSubmitResponse is a union type that can be either success or failure.
 // Check the Success field to determine which type it is.
@@ -82,20 +85,37 @@ type ChallengeSubmitResponse struct {
 	RankUp         *bool   `json:"rankUp,omitempty"`
 	FirstChallenge *bool   `json:"firstChallenge,omitempty"`
 	Message        *string `json:"message,omitempty"`
+	// BadgeURL is the shareable completion badge, set on success.
+	BadgeURL *string `json:"badgeUrl,omitempty"`
 }
 
-// ChallengeResetResponse represents the response from POST /api/cli/challenge/[slug]/reset
+// ChallengeResetResponse represents the response from POST /api/progress/:slug/reset
 type ChallengeResetResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
 
-// ErrorResponse represents a standard error response from the API
-type ErrorResponse struct {
-	Error   string  `json:"error"`
-	Details *string `json:"details,omitempty"`
+// BundleResponse represents the response from GET /api/bundles/:slug.
+// A bundle is an ordered sequence of challenges taken as one assessment.
+type BundleResponse struct {
+	Slug             string            `json:"slug"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	TimeLimitMinutes *int              `json:"timeLimitMinutes,omitempty"`
+	Challenges       []BundleChallenge `json:"challenges"`
+}
+
+// BundleChallenge is one challenge of a bundle, in the order it must be taken.
+type BundleChallenge struct {
+	Slug          string `json:"slug"`
+	Title         string `json:"title"`
+	Difficulty    string `json:"difficulty"`    // "easy" | "medium" | "hard"
+	EstimatedTime int    `json:"estimatedTime"` // minutes
 }
 
+// ErrorResponse represents a standard error response from the API
+type ErrorResponse = apigen.ErrorResponse
+
 // Type aliases for backward compatibility
 type UserProfile = UserResponse
 type ChallengeEntity = ChallengeResponse
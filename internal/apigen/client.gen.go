@@ -29,6 +29,63 @@ const (
 	Medium ListChallengesParamsDifficulty = "medium"
 )
 
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Details *string `json:"details,omitempty"`
+	Error   string  `json:"error"`
+}
+
+// ForbiddenActionViolation defines model for ForbiddenActionViolation.
+type ForbiddenActionViolation struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// ObjectRef defines model for ObjectRef.
+type ObjectRef struct {
+	Kind      string  `json:"kind"`
+	Name      string  `json:"name"`
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// ObjectiveComparison defines model for ObjectiveComparison.
+type ObjectiveComparison struct {
+	Expected  *interface{} `json:"expected,omitempty"`
+	Field     string       `json:"field"`
+	ObjectRef *ObjectRef   `json:"objectRef,omitempty"`
+	Observed  *interface{} `json:"observed,omitempty"`
+	Operator  string       `json:"operator"`
+}
+
+// ObjectiveResult defines model for ObjectiveResult.
+type ObjectiveResult struct {
+	Comparisons  *[]ObjectiveComparison `json:"comparisons,omitempty"`
+	Message      *string                `json:"message,omitempty"`
+	ObjectiveKey string                 `json:"objectiveKey"`
+	Passed       bool                   `json:"passed"`
+}
+
+// SubmitAuditEvent defines model for SubmitAuditEvent.
+type SubmitAuditEvent struct {
+	Name         *string   `json:"name,omitempty"`
+	Namespace    *string   `json:"namespace,omitempty"`
+	Resource     string    `json:"resource"`
+	ResponseCode *int      `json:"responseCode,omitempty"`
+	Subresource  *string   `json:"subresource,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	UserAgent    *string   `json:"userAgent,omitempty"`
+	Verb         string    `json:"verb"`
+}
+
+// SubmitEnvironment defines model for SubmitEnvironment.
+type SubmitEnvironment struct {
+	Arch              string  `json:"arch"`
+	CliVersion        string  `json:"cliVersion"`
+	KubernetesVersion *string `json:"kubernetesVersion,omitempty"`
+	Os                string  `json:"os"`
+	Provider          *string `json:"provider,omitempty"`
+}
+
 // ListChallengesParams defines parameters for ListChallenges.
 type ListChallengesParams struct {
 	Difficulty    *ListChallengesParamsDifficulty `form:"difficulty,omitempty" json:"difficulty,omitempty"`
@@ -43,21 +100,14 @@ type ListChallengesParamsDifficulty string
 
 // SubmitChallengeJSONBody defines parameters for SubmitChallenge.
 type SubmitChallengeJSONBody struct {
-	AuditEvents *[]struct {
-		Name         *string   `json:"name,omitempty"`
-		Namespace    *string   `json:"namespace,omitempty"`
-		Resource     string    `json:"resource"`
-		ResponseCode *int      `json:"responseCode,omitempty"`
-		Subresource  *string   `json:"subresource,omitempty"`
-		Timestamp    time.Time `json:"timestamp"`
-		UserAgent    *string   `json:"userAgent,omitempty"`
-		Verb         string    `json:"verb"`
-	} `json:"auditEvents,omitempty"`
-	Results []struct {
-		Message      *string `json:"message,omitempty"`
-		ObjectiveKey string  `json:"objectiveKey"`
-		Passed       bool    `json:"passed"`
-	} `json:"results"`
+	AuditEvents          *[]SubmitAuditEvent         `json:"auditEvents,omitempty"`
+	DurationSeconds      *int                        `json:"durationSeconds,omitempty"`
+	Environment          *SubmitEnvironment          `json:"environment,omitempty"`
+	EnvironmentRecreated *bool                       `json:"environmentRecreated,omitempty"`
+	ForbiddenActions     *[]ForbiddenActionViolation `json:"forbiddenActions,omitempty"`
+	ManifestsHash        *string                     `json:"manifestsHash,omitempty"`
+	Results              []ObjectiveResult           `json:"results"`
+	Variant              *string                     `json:"variant,omitempty"`
 }
 
 // TrackCliLoginJSONBody defines parameters for TrackCliLogin.
@@ -156,6 +206,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetBundle request
+	GetBundle(ctx context.Context, slug string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListChallenges request
 	ListChallenges(ctx context.Context, params *ListChallengesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -199,6 +252,18 @@ type ClientInterface interface {
 	GetUserMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) GetBundle(ctx context.Context, slug string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBundleRequest(c.Server, slug)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListChallenges(ctx context.Context, params *ListChallengesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListChallengesRequest(c.Server, params)
 	if err != nil {
@@ -379,6 +444,40 @@ func (c *Client) GetUserMe(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
+// NewGetBundleRequest generates requests for GetBundle
+func NewGetBundleRequest(server string, slug string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "slug", runtime.ParamLocationPath, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/bundles/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewListChallengesRequest generates requests for ListChallenges
 func NewListChallengesRequest(server string, params *ListChallengesParams) (*http.Request, error) {
 	var err error
@@ -920,6 +1019,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// GetBundleWithResponse request
+	GetBundleWithResponse(ctx context.Context, slug string, reqEditors ...RequestEditorFn) (*GetBundleResponse, error)
+
 	// ListChallengesWithResponse request
 	ListChallengesWithResponse(ctx context.Context, params *ListChallengesParams, reqEditors ...RequestEditorFn) (*ListChallengesResponse, error)
 
@@ -963,6 +1065,43 @@ type ClientWithResponsesInterface interface {
 	GetUserMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUserMeResponse, error)
 }
 
+type GetBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Bundle struct {
+			Challenges []struct {
+				Difficulty    string `json:"difficulty"`
+				EstimatedTime int    `json:"estimatedTime"`
+				Slug          string `json:"slug"`
+				Title         string `json:"title"`
+			} `json:"challenges"`
+			Description      string `json:"description"`
+			Slug             string `json:"slug"`
+			TimeLimitMinutes *int   `json:"timeLimitMinutes,omitempty"`
+			Title            string `json:"title"`
+		} `json:"bundle"`
+	}
+	JSON404 *ErrorResponse
+	JSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListChallengesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -984,18 +1123,9 @@ type ListChallengesResponse struct {
 		} `json:"challenges"`
 		Count int `json:"count"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 type ListChallenges200ChallengesDifficulty string
 
@@ -1033,18 +1163,9 @@ type GetChallengeMetaResponse struct {
 			Slug        string `json:"slug"`
 		} `json:"types"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -1083,18 +1204,9 @@ type GetChallengeResponse struct {
 			TypeSlug         string                             `json:"typeSlug"`
 		} `json:"challenge"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 type GetChallenge200ChallengeDifficulty string
 
@@ -1117,22 +1229,10 @@ func (r GetChallengeResponse) StatusCode() int {
 type GetChallengeManifestsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON400      *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON404 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -1155,6 +1255,8 @@ type SubmitChallengeResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *struct {
+		// BadgeUrl Shareable completion badge of the challenge
+		BadgeUrl   *string `json:"badgeUrl,omitempty"`
 		Objectives []struct {
 			Category    SubmitChallenge200ObjectivesCategory `json:"category"`
 			Description *string                              `json:"description,omitempty"`
@@ -1165,29 +1267,14 @@ type SubmitChallengeResponse struct {
 		} `json:"objectives"`
 		Success SubmitChallenge200Success `json:"success"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON404 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON409 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON404 *ErrorResponse
+	JSON409 *ErrorResponse
 	JSON422 *struct {
 		union json.RawMessage
 	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON500 *ErrorResponse
 }
 type SubmitChallenge200ObjectivesCategory string
 type SubmitChallenge200Success bool
@@ -1209,10 +1296,6 @@ type SubmitChallenge4220 struct {
 }
 type SubmitChallenge4220ObjectivesCategory string
 type SubmitChallenge4220Success bool
-type SubmitChallenge4221 struct {
-	Details *string `json:"details,omitempty"`
-	Error   string  `json:"error"`
-}
 
 // Status returns HTTPResponse.Status
 func (r SubmitChallengeResponse) Status() string {
@@ -1233,22 +1316,10 @@ func (r SubmitChallengeResponse) StatusCode() int {
 type GetChallengeYamlResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON400      *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON404 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -1273,18 +1344,9 @@ type TrackCliLoginResponse struct {
 	JSON200      *struct {
 		FirstLogin bool `json:"firstLogin"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -1310,18 +1372,9 @@ type TrackSetupResponse struct {
 		FirstTime bool `json:"firstTime"`
 		Success   bool `json:"success"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -1344,26 +1397,19 @@ type GetChallengeStatusResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *struct {
-		CompletedAt *time.Time                  `json:"completedAt"`
-		StartedAt   *time.Time                  `json:"startedAt"`
-		Status      GetChallengeStatus200Status `json:"status"`
-	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON404 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
+		// AttemptsRemaining Submissions left for this challenge; null when attempts are unlimited
+		AttemptsRemaining *int       `json:"attemptsRemaining"`
+		CompletedAt       *time.Time `json:"completedAt"`
+
+		// CooldownUntil No submission is accepted before this time
+		CooldownUntil *time.Time                  `json:"cooldownUntil"`
+		StartedAt     *time.Time                  `json:"startedAt"`
+		Status        GetChallengeStatus200Status `json:"status"`
 	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON404 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 type GetChallengeStatus200Status string
 
@@ -1390,22 +1436,10 @@ type ResetChallengeResponse struct {
 		Message string `json:"message"`
 		Success bool   `json:"success"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON404 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON404 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -1432,22 +1466,10 @@ type StartChallengeResponse struct {
 		StartedAt *time.Time              `json:"startedAt"`
 		Status    StartChallenge200Status `json:"status"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON404 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON404 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 type StartChallenge200Status string
 
@@ -1476,18 +1498,9 @@ type GetUserMeResponse struct {
 		Image *string `json:"image"`
 		Name  string  `json:"name"`
 	}
-	JSON400 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON401 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
-	JSON500 *struct {
-		Details *string `json:"details,omitempty"`
-		Error   string  `json:"error"`
-	}
+	JSON400 *ErrorResponse
+	JSON401 *ErrorResponse
+	JSON500 *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
@@ -1506,6 +1519,15 @@ func (r GetUserMeResponse) StatusCode() int {
 	return 0
 }
 
+// GetBundleWithResponse request returning *GetBundleResponse
+func (c *ClientWithResponses) GetBundleWithResponse(ctx context.Context, slug string, reqEditors ...RequestEditorFn) (*GetBundleResponse, error) {
+	rsp, err := c.GetBundle(ctx, slug, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBundleResponse(rsp)
+}
+
 // ListChallengesWithResponse request returning *ListChallengesResponse
 func (c *ClientWithResponses) ListChallengesWithResponse(ctx context.Context, params *ListChallengesParams, reqEditors ...RequestEditorFn) (*ListChallengesResponse, error) {
 	rsp, err := c.ListChallenges(ctx, params, reqEditors...)
@@ -1638,6 +1660,59 @@ func (c *ClientWithResponses) GetUserMeWithResponse(ctx context.Context, reqEdit
 	return ParseGetUserMeResponse(rsp)
 }
 
+// ParseGetBundleResponse parses an HTTP response from a GetBundleWithResponse call
+func ParseGetBundleResponse(rsp *http.Response) (*GetBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Bundle struct {
+				Challenges []struct {
+					Difficulty    string `json:"difficulty"`
+					EstimatedTime int    `json:"estimatedTime"`
+					Slug          string `json:"slug"`
+					Title         string `json:"title"`
+				} `json:"challenges"`
+				Description      string `json:"description"`
+				Slug             string `json:"slug"`
+				TimeLimitMinutes *int   `json:"timeLimitMinutes,omitempty"`
+				Title            string `json:"title"`
+			} `json:"bundle"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseListChallengesResponse parses an HTTP response from a ListChallengesWithResponse call
 func ParseListChallengesResponse(rsp *http.Response) (*ListChallengesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1677,30 +1752,21 @@ func ParseListChallengesResponse(rsp *http.Response) (*ListChallengesResponse, e
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1747,30 +1813,21 @@ func ParseGetChallengeMetaResponse(rsp *http.Response) (*GetChallengeMetaRespons
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1819,30 +1876,21 @@ func ParseGetChallengeResponse(rsp *http.Response) (*GetChallengeResponse, error
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1868,40 +1916,28 @@ func ParseGetChallengeManifestsResponse(rsp *http.Response) (*GetChallengeManife
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1928,6 +1964,8 @@ func ParseSubmitChallengeResponse(rsp *http.Response) (*SubmitChallengeResponse,
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
 		var dest struct {
+			// BadgeUrl Shareable completion badge of the challenge
+			BadgeUrl   *string `json:"badgeUrl,omitempty"`
 			Objectives []struct {
 				Category    SubmitChallenge200ObjectivesCategory `json:"category"`
 				Description *string                              `json:"description,omitempty"`
@@ -1944,40 +1982,28 @@ func ParseSubmitChallengeResponse(rsp *http.Response) (*SubmitChallengeResponse,
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1993,10 +2019,7 @@ func ParseSubmitChallengeResponse(rsp *http.Response) (*SubmitChallengeResponse,
 		response.JSON422 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2022,40 +2045,28 @@ func ParseGetChallengeYamlResponse(rsp *http.Response) (*GetChallengeYamlRespons
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2090,30 +2101,21 @@ func ParseTrackCliLoginResponse(rsp *http.Response) (*TrackCliLoginResponse, err
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2149,30 +2151,21 @@ func ParseTrackSetupResponse(rsp *http.Response) (*TrackSetupResponse, error) {
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2199,9 +2192,14 @@ func ParseGetChallengeStatusResponse(rsp *http.Response) (*GetChallengeStatusRes
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
 		var dest struct {
-			CompletedAt *time.Time                  `json:"completedAt"`
-			StartedAt   *time.Time                  `json:"startedAt"`
-			Status      GetChallengeStatus200Status `json:"status"`
+			// AttemptsRemaining Submissions left for this challenge; null when attempts are unlimited
+			AttemptsRemaining *int       `json:"attemptsRemaining"`
+			CompletedAt       *time.Time `json:"completedAt"`
+
+			// CooldownUntil No submission is accepted before this time
+			CooldownUntil *time.Time                  `json:"cooldownUntil"`
+			StartedAt     *time.Time                  `json:"startedAt"`
+			Status        GetChallengeStatus200Status `json:"status"`
 		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
@@ -2209,40 +2207,28 @@ func ParseGetChallengeStatusResponse(rsp *http.Response) (*GetChallengeStatusRes
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2278,40 +2264,28 @@ func ParseResetChallengeResponse(rsp *http.Response) (*ResetChallengeResponse, e
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2348,40 +2322,28 @@ func ParseStartChallengeResponse(rsp *http.Response) (*StartChallengeResponse, e
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2419,30 +2381,21 @@ func ParseGetUserMeResponse(rsp *http.Response) (*GetUserMeResponse, error) {
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON401 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Details *string `json:"details,omitempty"`
-			Error   string  `json:"error"`
-		}
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,7 @@
+package apigen
+
+// client.gen.go is generated from the backend OpenAPI spec (openapi.json at the
+// repository root) and committed. Regenerate with `go generate ./internal/apigen`
+// (or `mise run generate:api`) after updating openapi.json; never edit it by hand.
+
+//go:generate oapi-codegen -config ../../oapi-codegen.yaml -o client.gen.go ../../openapi.json
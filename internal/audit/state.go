@@ -1,6 +1,8 @@
 package audit
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -44,3 +46,170 @@ func LoadTimestamp(slug string) (time.Time, error) {
 func ClearState(slug string) error {
 	return os.RemoveAll(GetStateDir(slug))
 }
+
+// SaveStartTime records when the challenge was started. Unlike the audit timestamp,
+// which advances on every submit, it stays fixed until the state is cleared.
+func SaveStartTime(slug string, t time.Time) error {
+	dir := GetStateDir(slug)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, "started_at"), []byte(t.UTC().Format(time.RFC3339)), 0o600)
+}
+
+// LoadStartTime reads the start time recorded by SaveStartTime.
+func LoadStartTime(slug string) (time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(GetStateDir(slug), "started_at"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	ts, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse start time: %w", err)
+	}
+	return ts, nil
+}
+
+// Baseline describes the environment deployed at start, so resets made outside the
+// CLI (e.g. deleting and recreating the namespace by hand) can be detected at submit.
+type Baseline struct {
+	// ManifestsHash is the SHA-256 of the manifests archive that was applied. It is
+	// sent with submissions.
+	ManifestsHash string `json:"manifestsHash,omitempty"`
+	// NamespaceUID is the UID of the challenge namespace after deployment.
+	NamespaceUID string `json:"namespaceUID"`
+}
+
+// SaveBaseline stores the environment recorded at start.
+func SaveBaseline(slug string, baseline Baseline) error {
+	dir := GetStateDir(slug)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, "baseline.json"), data, 0o600)
+}
+
+// LoadBaseline returns the stored baseline, or ok=false when none was recorded
+// (e.g. a challenge started before baselines were recorded).
+func LoadBaseline(slug string) (baseline Baseline, ok bool, err error) {
+	data, err := os.ReadFile(filepath.Join(GetStateDir(slug), "baseline.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Baseline{}, false, nil
+	}
+	if err != nil {
+		return Baseline{}, false, err
+	}
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return Baseline{}, false, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return baseline, true, nil
+}
+
+// TimeLimit is the optional time box of a challenge attempt.
+// When Strict is set, submissions after expiry are blocked instead of only warned about.
+type TimeLimit struct {
+	Limit  time.Duration `json:"limit"`
+	Strict bool          `json:"strict,omitempty"`
+}
+
+// Remaining returns the time left at now for an attempt started at startedAt.
+// It is negative once the limit has expired.
+func (l TimeLimit) Remaining(startedAt, now time.Time) time.Duration {
+	return l.Limit - now.Sub(startedAt)
+}
+
+// SaveTimeLimit stores the time limit chosen at start.
+func SaveTimeLimit(slug string, limit TimeLimit) error {
+	dir := GetStateDir(slug)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	data, err := json.Marshal(limit)
+	if err != nil {
+		return fmt.Errorf("failed to encode time limit: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, "time_limit.json"), data, 0o600)
+}
+
+// LoadTimeLimit returns the stored time limit, or ok=false when the attempt is not time-boxed.
+func LoadTimeLimit(slug string) (limit TimeLimit, ok bool, err error) {
+	data, err := os.ReadFile(filepath.Join(GetStateDir(slug), "time_limit.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return TimeLimit{}, false, nil
+	}
+	if err != nil {
+		return TimeLimit{}, false, err
+	}
+	if err := json.Unmarshal(data, &limit); err != nil {
+		return TimeLimit{}, false, fmt.Errorf("failed to parse time limit: %w", err)
+	}
+	return limit, limit.Limit > 0, nil
+}
+
+// ListStartedChallenges returns the slugs that have a recorded start time.
+func ListStartedChallenges() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(constants.GetKubeasyConfigDir(), "state"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state dir: %w", err)
+	}
+	var slugs []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(GetStateDir(e.Name()), "started_at")); err == nil {
+			slugs = append(slugs, e.Name())
+		}
+	}
+	return slugs, nil
+}
+
+// ObservedResult is the outcome of one objective at the last submission.
+type ObservedResult struct {
+	Key        string    `json:"key"`
+	Passed     bool      `json:"passed"`
+	Message    string    `json:"message"`
+	ObservedAt time.Time `json:"observedAt"`
+}
+
+// GetLastResultsPath returns the file written by SaveLastResults.
+func GetLastResultsPath(slug string) string {
+	return filepath.Join(GetStateDir(slug), "last_results.json")
+}
+
+// SaveLastResults stores the objective results of the latest submission.
+func SaveLastResults(slug string, results []ObservedResult) error {
+	dir := GetStateDir(slug)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	return os.WriteFile(GetLastResultsPath(slug), data, 0o600)
+}
+
+// LoadLastResults returns the results stored by SaveLastResults, or nil when the
+// challenge has not been submitted yet.
+func LoadLastResults(slug string) ([]ObservedResult, error) {
+	data, err := os.ReadFile(GetLastResultsPath(slug))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var results []ObservedResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse results: %w", err)
+	}
+	return results, nil
+}
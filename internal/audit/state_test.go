@@ -52,3 +52,75 @@ func TestGetStateDir_ContainsSlug(t *testing.T) {
 	t.Setenv("HOME", dir)
 	assert.Equal(t, filepath.Join(dir, ".kubeasy", "state", "my-slug"), GetStateDir("my-slug"))
 }
+
+func TestStartTime_SurvivesTimestampUpdates(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	startedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, SaveStartTime("test-slug", startedAt))
+	require.NoError(t, SaveTimestamp("test-slug"))
+
+	ts, err := LoadStartTime("test-slug")
+	require.NoError(t, err)
+	assert.True(t, startedAt.Equal(ts))
+
+	slugs, err := ListStartedChallenges()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-slug"}, slugs)
+}
+
+func TestTimeLimit_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	_, ok, err := LoadTimeLimit("test-slug")
+	require.NoError(t, err)
+	assert.False(t, ok, "no time limit expected before SaveTimeLimit")
+
+	require.NoError(t, SaveTimeLimit("test-slug", TimeLimit{Limit: 45 * time.Minute, Strict: true}))
+	limit, ok, err := LoadTimeLimit("test-slug")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, TimeLimit{Limit: 45 * time.Minute, Strict: true}, limit)
+
+	start := time.Now()
+	assert.Equal(t, 15*time.Minute, limit.Remaining(start, start.Add(30*time.Minute)))
+	assert.Negative(t, limit.Remaining(start, start.Add(time.Hour)))
+}
+
+func TestLastResults_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	results, err := LoadLastResults("test-slug")
+	require.NoError(t, err)
+	assert.Nil(t, results, "no results expected before SaveLastResults")
+
+	at := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	saved := []ObservedResult{
+		{Key: "pod-ready", Passed: false, Message: "Ready: got False", ObservedAt: at},
+		{Key: "logs", Passed: true, Message: "Found all expected strings", ObservedAt: at},
+	}
+	require.NoError(t, SaveLastResults("test-slug", saved))
+
+	results, err = LoadLastResults("test-slug")
+	require.NoError(t, err)
+	assert.Equal(t, saved, results)
+}
+
+func TestBaseline_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	_, ok, err := LoadBaseline("test-slug")
+	require.NoError(t, err)
+	assert.False(t, ok, "no baseline expected before SaveBaseline")
+
+	saved := Baseline{ManifestsHash: "abc123", NamespaceUID: "0b6d-uid"}
+	require.NoError(t, SaveBaseline("test-slug", saved))
+	baseline, ok, err := LoadBaseline("test-slug")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, saved, baseline)
+}
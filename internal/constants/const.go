@@ -1,6 +1,9 @@
 package constants
 
 import (
+	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +20,31 @@ func init() {
 	}
 }
 
+// ValidateWebsiteURL checks a configured API base URL: the CLI sends its API key there
+// and runs the validations it serves, so only HTTPS is accepted, except on loopback
+// hosts for local development.
+func ValidateWebsiteURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid API URL %q: %w", raw, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid API URL %q: missing host", raw)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil
+		}
+		return fmt.Errorf("API URL %q must use https (http is only allowed for localhost)", raw)
+	default:
+		return fmt.Errorf("API URL %q must use https", raw)
+	}
+}
+
 var KeyringServiceName = "kubeasy-cli"
 
 var GithubRootURL = "https://github.com/kubeasy-dev"
@@ -30,6 +58,13 @@ var DownloadBaseURL = "https://github.com/kubeasy-dev/kubeasy-cli/releases/downl
 // It is set at build time via LDFLAGS.
 var Version = "dev"
 
+// ChallengePublicKeys lists the minisign public keys trusted to sign the challenge.yaml
+// files served by the API, separated by commas. When set, unsigned files are rejected.
+// It is set at build time via LDFLAGS; release builds take it from the
+// KUBEASY_CHALLENGE_PUBLIC_KEYS repository variable and fail without it, so only
+// development builds verify nothing.
+var ChallengePublicKeys = ""
+
 // LogFilePath is the path where CLI logs are stored.
 // It is set at build time via LDFLAGS.
 var LogFilePath = "/tmp/kubeasy-cli.log"
@@ -109,11 +144,24 @@ func GetKindConfigPath() string {
 	return filepath.Join(GetKubeasyConfigDir(), "kind-config.yaml")
 }
 
+// GetContainerdHostsDir returns the directory of the containerd registry host configs
+// (one <registry>/hosts.toml per mirrored registry), mounted at /etc/containerd/certs.d
+// in the kind node.
+func GetContainerdHostsDir() string {
+	return filepath.Join(GetKubeasyConfigDir(), "containerd", "certs.d")
+}
+
 // GetCloudProviderKindBinPath returns the path to the cloud-provider-kind binary.
 func GetCloudProviderKindBinPath() string {
 	return filepath.Join(GetKubeasyConfigDir(), "bin", "cloud-provider-kind")
 }
 
+// GetLearnerKubeconfigPath returns the path of the namespace-scoped kubeconfig
+// generated for a challenge by 'kubeasy kubeconfig'.
+func GetLearnerKubeconfigPath(slug string) string {
+	return filepath.Join(GetKubeasyConfigDir(), "kubeconfigs", slug+".yaml")
+}
+
 const (
 	// KubeasyCASecretNamespace is the namespace where the Kubeasy CA Secret is stored.
 	KubeasyCASecretNamespace = "cert-manager"
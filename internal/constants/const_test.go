@@ -288,3 +288,12 @@ func TestWebsiteURL_NoEnv_Retains_Default(t *testing.T) {
 	}
 	assert.Equal(t, "https://kubeasy.dev", WebsiteURL)
 }
+
+func TestValidateWebsiteURL(t *testing.T) {
+	for _, valid := range []string{"https://kubeasy.dev", "https://challenges.example.edu/kubeasy", "http://localhost:3000", "http://127.0.0.1:8080"} {
+		assert.NoError(t, ValidateWebsiteURL(valid), valid)
+	}
+	for _, invalid := range []string{"http://kubeasy.example.com", "ftp://kubeasy.dev", "kubeasy.dev", "https://"} {
+		assert.Error(t, ValidateWebsiteURL(invalid), invalid)
+	}
+}
@@ -0,0 +1,281 @@
+// Package dashboard serves the local web dashboard of 'kubeasy serve': the live
+// status of the objectives of a challenge, and the pods and events of its namespace.
+package dashboard
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+const (
+	// MinRefresh is the minimum time between two validation runs: status requests
+	// arriving sooner get the previous results, however many browser tabs poll.
+	MinRefresh = 5 * time.Second
+	// MaxEvents is the number of most recent events returned by /api/events.
+	MaxEvents = 50
+)
+
+// Runner runs validations; *validation.Executor implements it.
+type Runner interface {
+	ExecuteAll(ctx context.Context, validations []validation.Validation) []validation.Result
+}
+
+// Config describes the challenge served by the dashboard.
+type Config struct {
+	Slug        string
+	Namespace   string
+	Validations []validation.Validation
+	Runner      Runner
+	Clientset   kubernetes.Interface
+	// OnResults, when set, is called after each validation run, under the lock that
+	// serializes runs.
+	OnResults func(validations []validation.Validation, results []validation.Result)
+}
+
+// Server answers the dashboard page and its JSON API under /api.
+type Server struct {
+	cfg Config
+	now func() time.Time
+
+	mu   sync.Mutex
+	last *Status
+}
+
+// New returns a Server for cfg.
+func New(cfg Config) *Server {
+	return &Server{cfg: cfg, now: time.Now}
+}
+
+// Status is the body of /api/status.
+type Status struct {
+	Slug       string             `json:"slug"`
+	Namespace  string             `json:"namespace"`
+	CheckedAt  time.Time          `json:"checkedAt"`
+	Summary    validation.Summary `json:"summary"`
+	Objectives []Objective        `json:"objectives"`
+}
+
+// Objective is the status of one objective.
+type Objective struct {
+	Key         string                  `json:"key"`
+	Title       string                  `json:"title"`
+	Type        string                  `json:"type"`
+	Phase       string                  `json:"phase,omitempty"`
+	Passed      bool                    `json:"passed"`
+	Skipped     bool                    `json:"skipped,omitempty"`
+	Message     string                  `json:"message"`
+	Comparisons []validation.Comparison `json:"comparisons,omitempty"`
+}
+
+// Pod is one entry of /api/pods.
+type Pod struct {
+	Name      string    `json:"name"`
+	Phase     string    `json:"phase"`
+	Ready     string    `json:"ready"`
+	Restarts  int32     `json:"restarts"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Event is one entry of /api/events.
+type Event struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Object   string    `json:"object"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// Handler returns the HTTP handler of the dashboard. It only answers requests
+// addressed to a loopback host, so a web page cannot reach it through DNS rebinding.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(indexHTML)
+	})
+	mux.HandleFunc("GET /api/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, s.status(r.Context()))
+	})
+	mux.HandleFunc("GET /api/pods", func(w http.ResponseWriter, r *http.Request) {
+		pods, err := s.pods(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, pods)
+	})
+	mux.HandleFunc("GET /api/events", func(w http.ResponseWriter, r *http.Request) {
+		events, err := s.events(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, events)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// status runs the validations, or returns the previous results when they are
+// more recent than MinRefresh.
+func (s *Server) status(ctx context.Context) *Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last != nil && s.now().Sub(s.last.CheckedAt) < MinRefresh {
+		return s.last
+	}
+
+	start := s.now()
+	results := s.cfg.Runner.ExecuteAll(ctx, s.cfg.Validations)
+	status := &Status{
+		Slug:       s.cfg.Slug,
+		Namespace:  s.cfg.Namespace,
+		CheckedAt:  s.now(),
+		Summary:    validation.Summarize(s.cfg.Validations, results, s.now().Sub(start)),
+		Objectives: make([]Objective, len(s.cfg.Validations)),
+	}
+	for i, v := range s.cfg.Validations {
+		r := results[i]
+		status.Objectives[i] = Objective{
+			Key:         v.Key,
+			Title:       v.Title,
+			Type:        string(v.Type),
+			Phase:       v.Phase,
+			Passed:      r.Passed,
+			Skipped:     r.Skipped,
+			Message:     r.Message,
+			Comparisons: r.Comparisons,
+		}
+	}
+	s.last = status
+	if s.cfg.OnResults != nil {
+		s.cfg.OnResults(s.cfg.Validations, results)
+	}
+	return status
+}
+
+// Watch runs the validations every MinRefresh until ctx is cancelled, so OnResults
+// is called even when no browser polls the dashboard.
+func (s *Server) Watch(ctx context.Context) {
+	ticker := time.NewTicker(MinRefresh)
+	defer ticker.Stop()
+	for {
+		s.status(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) pods(ctx context.Context) ([]Pod, error) {
+	list, err := s.cfg.Clientset.CoreV1().Pods(s.cfg.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods := make([]Pod, 0, len(list.Items))
+	for _, p := range list.Items {
+		ready, restarts := 0, int32(0)
+		for _, cs := range p.Status.ContainerStatuses {
+			if cs.Ready {
+				ready++
+			}
+			restarts += cs.RestartCount
+		}
+		pods = append(pods, Pod{
+			Name:      p.Name,
+			Phase:     string(p.Status.Phase),
+			Ready:     fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers)),
+			Restarts:  restarts,
+			CreatedAt: p.CreationTimestamp.Time,
+		})
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
+
+func (s *Server) events(ctx context.Context) ([]Event, error) {
+	list, err := shared.ListEvents(ctx, s.cfg.Clientset, s.cfg.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	events := make([]Event, 0, len(list))
+	for _, e := range list {
+		events = append(events, Event{
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Object:   e.InvolvedObject.Kind + "/" + e.InvolvedObject.Name,
+			Message:  e.Message,
+			Count:    e.Count,
+			LastSeen: eventTime(e),
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].LastSeen.After(events[j].LastSeen) })
+	if len(events) > MaxEvents {
+		events = events[:MaxEvents]
+	}
+	return events, nil
+}
+
+// eventTime returns when an event was last seen, whichever API filled it.
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+func isLoopbackHost(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Debug("Could not write dashboard response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	logger.Debug("Dashboard request failed: %v", err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadGateway)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
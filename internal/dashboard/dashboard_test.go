@@ -0,0 +1,156 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeRunner struct {
+	calls   int
+	results []validation.Result
+}
+
+func (f *fakeRunner) ExecuteAll(_ context.Context, _ []validation.Validation) []validation.Result {
+	f.calls++
+	return f.results
+}
+
+func newTestServer(runner *fakeRunner) *Server {
+	clientset := fake.NewClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "demo"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: true, RestartCount: 2}},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "old", Namespace: "demo"},
+			Reason:         "Scheduled",
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web"},
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "new", Namespace: "demo"},
+			Reason:         "BackOff",
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web"},
+			LastTimestamp:  metav1.NewTime(time.Now()),
+		},
+	)
+	return New(Config{
+		Slug:      "demo",
+		Namespace: "demo",
+		Validations: []validation.Validation{
+			{Key: "pod-ready", Title: "Pod Ready", Type: validation.TypeCondition},
+			{Key: "no-crash", Title: "No Crash", Type: validation.TypeEvent},
+		},
+		Runner:    runner,
+		Clientset: clientset,
+	})
+}
+
+func get(t *testing.T, h http.Handler, host, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Host = host
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Status(t *testing.T) {
+	runner := &fakeRunner{results: []validation.Result{
+		{Key: "pod-ready", Passed: true, Message: "ok"},
+		{Key: "no-crash", Passed: false, Message: "BackOff"},
+	}}
+	s := newTestServer(runner)
+	h := s.Handler()
+
+	rec := get(t, h, "127.0.0.1:8420", "/api/status")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, "demo", status.Slug)
+	assert.Equal(t, 1, status.Summary.Passed)
+	assert.Equal(t, 1, status.Summary.Failed)
+	require.Len(t, status.Objectives, 2)
+	assert.Equal(t, "Pod Ready", status.Objectives[0].Title)
+	assert.False(t, status.Objectives[1].Passed)
+
+	get(t, h, "localhost:8420", "/api/status")
+	assert.Equal(t, 1, runner.calls, "a second request within MinRefresh reuses the results")
+
+	s.now = func() time.Time { return time.Now().Add(MinRefresh) }
+	get(t, h, "localhost:8420", "/api/status")
+	assert.Equal(t, 2, runner.calls)
+}
+
+func TestHandler_PodsAndEvents(t *testing.T) {
+	h := newTestServer(&fakeRunner{}).Handler()
+
+	rec := get(t, h, "localhost", "/api/pods")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var pods []Pod
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pods))
+	require.Len(t, pods, 1)
+	assert.Equal(t, "web", pods[0].Name)
+	assert.Equal(t, "Running", pods[0].Phase)
+	assert.Equal(t, "1/1", pods[0].Ready)
+	assert.Equal(t, int32(2), pods[0].Restarts)
+
+	rec = get(t, h, "localhost", "/api/events")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var events []Event
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, "BackOff", events[0].Reason, "most recent first")
+	assert.Equal(t, "Pod/web", events[0].Object)
+}
+
+func TestHandler_Index(t *testing.T) {
+	rec := get(t, newTestServer(&fakeRunner{}).Handler(), "[::1]:8420", "/")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), "/api/status")
+}
+
+func TestHandler_RejectsForeignHosts(t *testing.T) {
+	h := newTestServer(&fakeRunner{}).Handler()
+	assert.Equal(t, http.StatusForbidden, get(t, h, "evil.example.com", "/api/status").Code)
+	assert.Equal(t, http.StatusForbidden, get(t, h, "evil.example.com:8420", "/").Code)
+}
+
+func TestStatus_OnResults(t *testing.T) {
+	runner := &fakeRunner{results: []validation.Result{{Key: "pod-ready", Passed: true}, {Key: "no-crash"}}}
+	s := newTestServer(runner)
+	var calls int
+	s.cfg.OnResults = func(validations []validation.Validation, results []validation.Result) {
+		calls++
+		assert.Len(t, validations, 2)
+		assert.Equal(t, runner.results, results)
+	}
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	s.status(context.Background())
+	s.status(context.Background())
+	assert.Equal(t, 1, calls, "cached results are not reported again")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	now = now.Add(MinRefresh)
+	s.Watch(ctx)
+	assert.Equal(t, 2, calls, "Watch runs the validations before checking ctx")
+}
@@ -0,0 +1,81 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+)
+
+// Deploy phases, as named by PhaseTimeoutError.
+const (
+	PhaseFetch = "fetch"
+	PhaseApply = "apply"
+	PhaseReady = "ready"
+)
+
+// DeployBudgets bounds each phase of a challenge deploy, so a hang is attributed to
+// the phase it happened in: downloading the manifests (Fetch), applying them (Apply)
+// or waiting for the challenge resources to be ready (Ready). Zero fields use
+// DefaultDeployBudgets.
+type DeployBudgets struct {
+	Fetch time.Duration
+	Apply time.Duration
+	Ready time.Duration
+}
+
+// DefaultDeployBudgets are the budgets of a deploy when none are set.
+var DefaultDeployBudgets = DeployBudgets{Fetch: 2 * time.Minute, Apply: 2 * time.Minute, Ready: 5 * time.Minute}
+
+// PhaseTimeoutError is a deploy that failed because a phase used up its budget.
+type PhaseTimeoutError struct {
+	Phase  string
+	Budget time.Duration
+	Err    error
+}
+
+func (e *PhaseTimeoutError) Error() string {
+	return fmt.Sprintf("%s phase did not finish within its %s budget: %v", e.Phase, e.Budget, e.Err)
+}
+
+func (e *PhaseTimeoutError) Unwrap() error { return e.Err }
+
+// ErrorCode implements kerrors.Coder: a phase out of budget is a deploy timeout.
+func (e *PhaseTimeoutError) ErrorCode() kerrors.Code { return kerrors.CodeDeployTimeout }
+
+type deployBudgetsKey struct{}
+
+// WithDeployBudgets returns a copy of ctx whose deploys use budgets.
+func WithDeployBudgets(ctx context.Context, budgets DeployBudgets) context.Context {
+	return context.WithValue(ctx, deployBudgetsKey{}, budgets)
+}
+
+// deployBudgetsFrom returns the budgets of ctx, zero fields filled from
+// DefaultDeployBudgets.
+func deployBudgetsFrom(ctx context.Context) DeployBudgets {
+	b, _ := ctx.Value(deployBudgetsKey{}).(DeployBudgets)
+	if b.Fetch <= 0 {
+		b.Fetch = DefaultDeployBudgets.Fetch
+	}
+	if b.Apply <= 0 {
+		b.Apply = DefaultDeployBudgets.Apply
+	}
+	if b.Ready <= 0 {
+		b.Ready = DefaultDeployBudgets.Ready
+	}
+	return b
+}
+
+// runPhase runs fn with a context bounded by budget. When fn fails because the
+// budget ran out, and not because ctx ended, the error is a *PhaseTimeoutError.
+func runPhase(ctx context.Context, phase string, budget time.Duration, fn func(ctx context.Context) error) error {
+	phaseCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+	err := fn(phaseCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(phaseCtx.Err(), context.DeadlineExceeded) {
+		return &PhaseTimeoutError{Phase: phase, Budget: budget, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployBudgetsFrom(t *testing.T) {
+	assert.Equal(t, DefaultDeployBudgets, deployBudgetsFrom(context.Background()))
+
+	ctx := WithDeployBudgets(context.Background(), DeployBudgets{Ready: 10 * time.Minute})
+	b := deployBudgetsFrom(ctx)
+	assert.Equal(t, 10*time.Minute, b.Ready)
+	assert.Equal(t, DefaultDeployBudgets.Fetch, b.Fetch, "zero budgets use the defaults")
+	assert.Equal(t, DefaultDeployBudgets.Apply, b.Apply)
+}
+
+func TestRunPhase(t *testing.T) {
+	waitForDeadline := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	err := runPhase(context.Background(), PhaseReady, 10*time.Millisecond, waitForDeadline)
+	var timeoutErr *PhaseTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, PhaseReady, timeoutErr.Phase)
+	assert.Equal(t, "ready phase did not finish within its 10ms budget: context deadline exceeded", err.Error())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = runPhase(ctx, PhaseFetch, time.Minute, waitForDeadline)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, errors.As(err, &timeoutErr), "an interrupted deploy is not a phase timeout")
+
+	failure := errors.New("invalid manifest")
+	assert.Equal(t, failure, runPhase(context.Background(), PhaseApply, time.Minute, func(context.Context) error { return failure }))
+}
@@ -18,7 +18,7 @@ import (
 )
 
 // DeployChallenge pulls the challenge OCI artifact and applies manifests to the cluster.
-func DeployChallenge(ctx context.Context, clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, slug string) error {
+func DeployChallenge(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, slug string) error {
 	logger.Info("Deploying challenge '%s' from OCI registry...", slug)
 
 	// Create temporary directory for extracted artifacts
@@ -44,9 +44,11 @@ func DeployChallenge(ctx context.Context, clientset *kubernetes.Clientset, dynam
 	mapper := restmapper.NewDiscoveryRESTMapper(groups)
 
 	// Find and apply YAML files from manifests/ and policies/
-	if err := applyManifestDirs(ctx, tmpDir, slug, mapper, dynamicClient); err != nil {
+	results, err := applyManifestDirs(ctx, tmpDir, slug, mapper, dynamicClient, kube.ApplyOptions{})
+	if err != nil {
 		return err
 	}
+	logger.Info("Challenge manifests applied (%s).", kube.SummarizeApply(results))
 
 	// Wait for Deployments and StatefulSets to be ready
 	logger.Info("Waiting for challenge resources to be ready...")
@@ -80,7 +82,7 @@ func pullOCIArtifact(ctx context.Context, ref string, targetDir string) error {
 }
 
 // WaitForChallengeReady waits for all Deployments and StatefulSets in the namespace to be ready.
-func WaitForChallengeReady(ctx context.Context, clientset *kubernetes.Clientset, namespace string) error {
+func WaitForChallengeReady(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
 	// List Deployments
 	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
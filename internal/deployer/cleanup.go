@@ -3,6 +3,7 @@ package deployer
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
@@ -10,18 +11,69 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-// CleanupChallenge deletes the challenge namespace and restores the kubectl context.
-func CleanupChallenge(ctx context.Context, clientset kubernetes.Interface, slug string) error {
-	logger.Info("Cleaning up challenge '%s'...", slug)
+// CleanupStep is one action of a challenge cleanup. Description names exactly what the
+// step touches, so the same steps can be listed by --dry-run without running them.
+type CleanupStep struct {
+	Description string
+	Run         func(ctx context.Context) error
+}
 
-	// Delete the namespace (cascades to all namespaced resources)
-	if err := kube.DeleteNamespace(ctx, clientset, slug); err != nil {
-		return fmt.Errorf("failed to delete namespace '%s': %w", slug, err)
+// PlanCleanup returns the steps CleanupChallenge performs, in order. The clientset is
+// only used when a step runs, so it may be nil when the plan is only printed.
+func PlanCleanup(clientset kubernetes.Interface, slug string) []CleanupStep {
+	kubeconfigPath := constants.GetLearnerKubeconfigPath(slug)
+	return []CleanupStep{
+		{
+			Description: fmt.Sprintf("Remove validation ServiceAccount '%s' and its Role", kube.ValidatorServiceAccountName),
+			Run: func(ctx context.Context) error {
+				// Revoke the validation credentials first, even if the namespace deletion stalls.
+				if err := kube.DeleteValidatorSandbox(ctx, clientset, slug); err != nil {
+					return fmt.Errorf("failed to remove validation ServiceAccount: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			Description: fmt.Sprintf("Delete namespace '%s' and every resource in it", slug),
+			Run: func(ctx context.Context) error {
+				// Deleting the namespace cascades to all namespaced resources
+				if err := kube.DeleteNamespace(ctx, clientset, slug); err != nil {
+					return fmt.Errorf("failed to delete namespace '%s': %w", slug, err)
+				}
+				return nil
+			},
+		},
+		{
+			Description: fmt.Sprintf("Remove learner kubeconfig %s", kubeconfigPath),
+			Run: func(ctx context.Context) error {
+				// The learner kubeconfig points to a ServiceAccount that died with the namespace.
+				if err := os.Remove(kubeconfigPath); err != nil && !os.IsNotExist(err) {
+					logger.Warning("Failed to remove learner kubeconfig for '%s': %v", slug, err)
+				}
+				return nil
+			},
+		},
+		{
+			Description: fmt.Sprintf("Switch kubectl context '%s' back to namespace 'default'", constants.KubeasyClusterContext),
+			Run: func(ctx context.Context) error {
+				if err := kube.SetNamespaceForContext(constants.KubeasyClusterContext, "default"); err != nil {
+					return fmt.Errorf("failed to switch to default namespace: %w", err)
+				}
+				return nil
+			},
+		},
 	}
+}
+
+// CleanupChallenge removes the validator sandbox, deletes the challenge namespace, removes the learner kubeconfig
+// and restores the kubectl context.
+func CleanupChallenge(ctx context.Context, clientset kubernetes.Interface, slug string) error {
+	logger.Info("Cleaning up challenge '%s'...", slug)
 
-	// Restore kubectl context to default namespace
-	if err := kube.SetNamespaceForContext(constants.KubeasyClusterContext, "default"); err != nil {
-		return fmt.Errorf("failed to switch to default namespace: %w", err)
+	for _, step := range PlanCleanup(clientset, slug) {
+		if err := step.Run(ctx); err != nil {
+			return err
+		}
 	}
 
 	logger.Info("Challenge '%s' cleaned up successfully.", slug)
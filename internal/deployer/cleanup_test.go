@@ -2,8 +2,12 @@ package deployer
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -44,3 +48,34 @@ func TestCleanupChallenge_NamespaceNotFound(t *testing.T) {
 	_, err := clientset.CoreV1().Namespaces().Get(ctx, "nonexistent", metav1.GetOptions{})
 	assert.True(t, apierrors.IsNotFound(err), "namespace should not exist")
 }
+
+func TestCleanupChallenge_RemovesLearnerKubeconfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+
+	path := constants.GetLearnerKubeconfigPath("test-challenge")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("apiVersion: v1\n"), 0o600))
+
+	clientset := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-challenge"}})
+
+	// Restoring the kubectl context fails without a real kubeconfig; the learner
+	// kubeconfig must be removed before that step.
+	_ = CleanupChallenge(context.Background(), clientset, "test-challenge")
+
+	assert.NoFileExists(t, path)
+}
+
+func TestPlanCleanup_DescribesEveryStep(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	steps := PlanCleanup(nil, "test-challenge")
+	require.Len(t, steps, 4)
+	assert.Contains(t, steps[0].Description, kube.ValidatorServiceAccountName)
+	assert.Contains(t, steps[1].Description, "namespace 'test-challenge'")
+	assert.Contains(t, steps[2].Description, constants.GetLearnerKubeconfigPath("test-challenge"))
+	assert.Contains(t, steps[3].Description, constants.KubeasyClusterContext)
+	for _, step := range steps {
+		assert.NotNil(t, step.Run)
+	}
+}
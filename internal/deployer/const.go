@@ -5,6 +5,10 @@ import "fmt"
 // ChallengesOCIRegistry is the base OCI registry for challenge artifacts.
 var ChallengesOCIRegistry = "ghcr.io/kubeasy-dev/challenges"
 
+// ChallengesRepoArchiveURL is the tar.gz archive of the challenges repository, which
+// DeployChallengeFromRepo deploys from.
+var ChallengesRepoArchiveURL = "https://codeload.github.com/kubeasy-dev/challenges/tar.gz/refs/heads/main"
+
 // ProbePodName is the fixed name of the CLI-managed curl probe pod.
 // Fixed (not random) so labels are stable and challenge authors can target it in NetworkPolicy.
 const ProbePodName = "kubeasy-probe"
@@ -0,0 +1,98 @@
+package deployer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContainerdHostsPath is where containerd reads the registry host configs in the kind
+// node; the containerd config patch of the cluster points config_path at it.
+const ContainerdHostsPath = "/etc/containerd/certs.d"
+
+// ContainerdConfigPatch makes containerd read per-registry host configs, so mirrors can
+// be changed without recreating the cluster.
+const ContainerdConfigPatch = `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "` + ContainerdHostsPath + `"
+`
+
+// ContainerdMirror is a pull-through mirror containerd uses for Registry before
+// falling back to the registry itself.
+type ContainerdMirror struct {
+	Registry string
+	Endpoint string
+}
+
+// ParseContainerdMirrors parses --registry-mirror values of the form
+// registry=http(s)://endpoint, e.g. docker.io=https://mirror.example.com.
+func ParseContainerdMirrors(values []string) ([]ContainerdMirror, error) {
+	mirrors := make([]ContainerdMirror, 0, len(values))
+	for _, v := range values {
+		registry, endpoint, ok := strings.Cut(v, "=")
+		registry, endpoint = strings.TrimSpace(registry), strings.TrimSpace(endpoint)
+		if !ok || registry == "" || endpoint == "" {
+			return nil, fmt.Errorf("invalid registry mirror %q: expected registry=endpoint", v)
+		}
+		if strings.ContainsAny(registry, `/\`) || registry == "." || registry == ".." {
+			return nil, fmt.Errorf("invalid registry mirror %q: %q is not a registry host", v, registry)
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid registry mirror %q: endpoint must be an http(s) URL", v)
+		}
+		mirrors = append(mirrors, ContainerdMirror{Registry: registry, Endpoint: strings.TrimSuffix(endpoint, "/")})
+	}
+	return mirrors, nil
+}
+
+// registryServer returns the upstream URL of a registry host. Docker Hub is served by
+// registry-1.docker.io.
+func registryServer(registry string) string {
+	if registry == defaultRegistry {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + registry
+}
+
+// hostsTOML renders the containerd host config of a registry with its mirrors, tried in
+// order before the registry itself.
+func hostsTOML(registry string, endpoints []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "server = %q\n", registryServer(registry))
+	for _, e := range endpoints {
+		fmt.Fprintf(&b, "\n[host.%q]\n  capabilities = [\"pull\", \"resolve\"]\n", e)
+	}
+	return b.String()
+}
+
+// WriteContainerdMirrors replaces the registry host configs in dir with mirrors. The
+// kind node mounts dir read-only and containerd reads it at each pull, so the mirrors
+// apply to the running cluster.
+func WriteContainerdMirrors(dir string, mirrors []ContainerdMirror) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear registry mirrors: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create registry mirrors dir: %w", err)
+	}
+	var registries []string
+	endpoints := map[string][]string{}
+	for _, m := range mirrors {
+		if _, seen := endpoints[m.Registry]; !seen {
+			registries = append(registries, m.Registry)
+		}
+		endpoints[m.Registry] = append(endpoints[m.Registry], m.Endpoint)
+	}
+	for _, registry := range registries {
+		regDir := filepath.Join(dir, registry)
+		if err := os.MkdirAll(regDir, 0o750); err != nil {
+			return fmt.Errorf("failed to create registry mirrors dir: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(regDir, "hosts.toml"), []byte(hostsTOML(registry, endpoints[registry])), 0o644); err != nil { //nolint:gosec // read by containerd in the kind node
+			return fmt.Errorf("failed to write registry mirror for %s: %w", registry, err)
+		}
+	}
+	return nil
+}
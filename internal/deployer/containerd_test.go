@@ -0,0 +1,50 @@
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainerdMirrors(t *testing.T) {
+	mirrors, err := ParseContainerdMirrors([]string{"docker.io=https://mirror.example.com/", "ghcr.io = http://10.0.0.5:5000"})
+	require.NoError(t, err)
+	assert.Equal(t, []ContainerdMirror{
+		{Registry: "docker.io", Endpoint: "https://mirror.example.com"},
+		{Registry: "ghcr.io", Endpoint: "http://10.0.0.5:5000"},
+	}, mirrors)
+
+	for _, v := range []string{"docker.io", "=https://m", "docker.io=mirror.example.com", "../etc=https://m", "docker.io=ftp://m"} {
+		_, err := ParseContainerdMirrors([]string{v})
+		assert.Error(t, err, v)
+	}
+}
+
+func TestWriteContainerdMirrors(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "certs.d")
+	require.NoError(t, WriteContainerdMirrors(dir, []ContainerdMirror{
+		{Registry: "quay.io", Endpoint: "https://old.example.com"},
+	}))
+
+	require.NoError(t, WriteContainerdMirrors(dir, []ContainerdMirror{
+		{Registry: "docker.io", Endpoint: "https://mirror.example.com"},
+		{Registry: "docker.io", Endpoint: "http://fallback.example.com"},
+	}))
+
+	data, err := os.ReadFile(filepath.Join(dir, "docker.io", "hosts.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, `server = "https://registry-1.docker.io"
+
+[host."https://mirror.example.com"]
+  capabilities = ["pull", "resolve"]
+
+[host."http://fallback.example.com"]
+  capabilities = ["pull", "resolve"]
+`, string(data))
+
+	_, err = os.Stat(filepath.Join(dir, "quay.io"))
+	assert.True(t, os.IsNotExist(err), "mirrors of a previous setup are replaced")
+}
@@ -0,0 +1,212 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FailureCause is a known reason for a challenge deploy to fail.
+type FailureCause string
+
+const (
+	// CauseInvalidManifest: the API server rejected a manifest of the challenge.
+	CauseInvalidManifest FailureCause = "invalid-manifest"
+	// CausePolicyDenied: an admission policy (Kyverno) denied a resource.
+	CausePolicyDenied FailureCause = "policy-denied"
+	// CauseNamespaceMissing: the challenge namespace disappeared during the deploy.
+	CauseNamespaceMissing FailureCause = "namespace-missing"
+	// CauseImagePullQuota: the registry rate-limited image pulls.
+	CauseImagePullQuota FailureCause = "image-pull-quota"
+	// CauseImagePull: an image could not be pulled.
+	CauseImagePull FailureCause = "image-pull"
+	// CauseManifestsUnavailable: the API could not serve the manifests of the challenge.
+	CauseManifestsUnavailable FailureCause = "manifests-unavailable"
+)
+
+// policyGuidance is the guidance for CausePolicyDenied.
+const policyGuidance = "A Kyverno policy denied a challenge resource. List the policies of the cluster with " +
+	"'kubectl get clusterpolicies,policies -A', remove the ones you added, then start again."
+
+// maxDetailLength truncates the Kubernetes messages quoted in a Diagnosis.
+const maxDetailLength = 200
+
+// Diagnosis explains why a challenge deploy failed.
+type Diagnosis struct {
+	Cause FailureCause
+	// Detail is the evidence: the error or event message the cause was recognised in.
+	Detail string
+	// Guidance tells the user what to do about it.
+	Guidance string
+}
+
+// DeployError is a failed deploy whose cause was recognised.
+type DeployError struct {
+	Err       error
+	Diagnosis Diagnosis
+}
+
+func (e *DeployError) Error() string { return e.Err.Error() }
+
+func (e *DeployError) Unwrap() error { return e.Err }
+
+// Diagnosed returns err as a *DeployError when DiagnoseDeployFailure recognises its
+// cause, and err unchanged otherwise.
+func Diagnosed(ctx context.Context, clientset kubernetes.Interface, namespace string, err error) error {
+	if err == nil {
+		return nil
+	}
+	d := DiagnoseDeployFailure(ctx, clientset, namespace, err)
+	if d == nil {
+		return err
+	}
+	return &DeployError{Err: err, Diagnosis: *d}
+}
+
+// DiagnoseDeployFailure looks for the cause of a failed deploy of a challenge in
+// namespace: first in the error, then in the warning events of the namespace. It
+// returns nil when no known cause is found. It is best effort: lookup errors are only
+// logged.
+func DiagnoseDeployFailure(ctx context.Context, clientset kubernetes.Interface, namespace string, deployErr error) *Diagnosis {
+	if d := diagnoseError(namespace, deployErr); d != nil {
+		return d
+	}
+	if clientset == nil {
+		return nil
+	}
+	// The deploy context may be the one that expired
+	ctx = context.WithoutCancel(ctx)
+	if _, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return namespaceMissing(namespace, fmt.Sprintf("namespace %q not found", namespace))
+	}
+	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Debug("Deploy diagnosis: failed to list events: %v", err)
+	} else if d := diagnoseEvents(namespace, events.Items); d != nil {
+		return d
+	}
+	// Readiness errors name stuck pods (see kube.ResourceStatus), but events tell
+	// a rate limit apart from other pull failures, so they are checked first
+	if msg := deployErr.Error(); strings.Contains(msg, "ErrImagePull") || strings.Contains(msg, "ImagePullBackOff") {
+		return imagePull(namespace, msg)
+	}
+	return nil
+}
+
+// diagnoseError recognises the causes reported by the API server when applying.
+func diagnoseError(namespace string, err error) *Diagnosis {
+	msg := err.Error()
+	lower := strings.ToLower(msg)
+	switch {
+	case errors.Is(err, ErrManifestsUnavailable):
+		return &Diagnosis{
+			Cause:  CauseManifestsUnavailable,
+			Detail: truncateDetail(msg),
+			Guidance: fmt.Sprintf("The Kubeasy platform cannot serve the manifests of the challenge right now. Run "+
+				"'kubeasy challenge start %s --from-repo' to fetch them from the challenges repository instead.", namespace),
+		}
+	case isPolicyDenial(lower):
+		return &Diagnosis{
+			Cause:    CausePolicyDenied,
+			Detail:   truncateDetail(msg),
+			Guidance: policyGuidance,
+		}
+	case strings.Contains(lower, fmt.Sprintf("namespaces %q not found", namespace)):
+		return namespaceMissing(namespace, msg)
+	}
+
+	var status apierrors.APIStatus
+	invalid := errors.As(err, &status) && (apierrors.IsInvalid(err) || apierrors.IsBadRequest(err))
+	for _, marker := range []string{"error converting yaml", "yaml:", "json: cannot unmarshal", "no matches for kind", "unknown field", "is invalid"} {
+		invalid = invalid || strings.Contains(lower, marker)
+	}
+	if invalid {
+		return &Diagnosis{
+			Cause:  CauseInvalidManifest,
+			Detail: truncateDetail(msg),
+			Guidance: "A manifest of the challenge was rejected by the API server: this is a problem with the challenge, " +
+				"not with your work. Report it to the challenge authors, or run 'kubeasy dev lint' if you are authoring it.",
+		}
+	}
+	return nil
+}
+
+func isPolicyDenial(lower string) bool {
+	return strings.Contains(lower, "kyverno") && (strings.Contains(lower, "denied") || strings.Contains(lower, "blocked"))
+}
+
+func namespaceMissing(namespace, detail string) *Diagnosis {
+	return &Diagnosis{
+		Cause:    CauseNamespaceMissing,
+		Detail:   truncateDetail(detail),
+		Guidance: fmt.Sprintf("The namespace %s was deleted while the challenge was deployed. Run 'kubeasy challenge start %s' again.", namespace, namespace),
+	}
+}
+
+// diagnoseEvents recognises causes in the warning events of the namespace, newest first.
+func diagnoseEvents(namespace string, events []corev1.Event) *Diagnosis {
+	var warnings []corev1.Event
+	for _, ev := range events {
+		if ev.Type == corev1.EventTypeWarning {
+			warnings = append(warnings, ev)
+		}
+	}
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].LastTimestamp.After(warnings[j].LastTimestamp.Time)
+	})
+
+	var pullFailure *corev1.Event
+	for i, ev := range warnings {
+		lower := strings.ToLower(ev.Message)
+		switch {
+		case ev.Reason == "PolicyViolation" || isPolicyDenial(lower):
+			return &Diagnosis{
+				Cause:    CausePolicyDenied,
+				Detail:   eventDetail(ev),
+				Guidance: policyGuidance,
+			}
+		case strings.Contains(lower, "toomanyrequests") || strings.Contains(lower, "rate limit") || strings.Contains(lower, "429 too many requests"):
+			return &Diagnosis{
+				Cause:  CauseImagePullQuota,
+				Detail: eventDetail(ev),
+				Guidance: fmt.Sprintf("The image registry rate-limited pulls (e.g. the Docker Hub quota). Wait and start again, "+
+					"set up a mirror with 'kubeasy setup --registry-mirror', or pull the images once with 'kubeasy prefetch %s --from-docker'.", namespace),
+			}
+		case pullFailure == nil && (strings.Contains(lower, "failed to pull image") || strings.Contains(ev.Message, "ErrImagePull") || strings.Contains(ev.Message, "ImagePullBackOff")):
+			pullFailure = &warnings[i]
+		}
+	}
+	if pullFailure != nil {
+		return imagePull(namespace, eventDetail(*pullFailure))
+	}
+	return nil
+}
+
+func imagePull(namespace, detail string) *Diagnosis {
+	return &Diagnosis{
+		Cause:  CauseImagePull,
+		Detail: truncateDetail(detail),
+		Guidance: fmt.Sprintf("An image of the challenge could not be pulled. Check your network connection, "+
+			"then preload the images with 'kubeasy prefetch %s' and start again.", namespace),
+	}
+}
+
+func eventDetail(ev corev1.Event) string {
+	return truncateDetail(fmt.Sprintf("%s %s: %s", strings.ToLower(ev.InvolvedObject.Kind), ev.InvolvedObject.Name, ev.Message))
+}
+
+func truncateDetail(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= maxDetailLength {
+		return s
+	}
+	return s[:maxDetailLength] + "..."
+}
@@ -0,0 +1,111 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func warningEvent(name, reason, message string, age time.Duration) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "demo"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+		LastTimestamp:  metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func TestDiagnoseDeployFailure_FromError(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo"}})
+
+	tests := []struct {
+		name string
+		err  error
+		want FailureCause
+	}{
+		{"kyverno webhook", errors.New(`admission webhook "validate.kyverno.svc-fail" denied the request: policy disallow-latest`), CausePolicyDenied},
+		{"namespace deleted", errors.New(`failed to apply Deployment web: namespaces "demo" not found`), CauseNamespaceMissing},
+		{"invalid object", apierrors.NewInvalid(schema.GroupKind{Kind: "Deployment"}, "web", nil), CauseInvalidManifest},
+		{"unknown kind", errors.New(`no matches for kind "Widget" in version "example.com/v1"`), CauseInvalidManifest},
+		{"manifests endpoint down", fmt.Errorf("%w: API returned HTTP 503 for challenge \"demo\"", ErrManifestsUnavailable), CauseManifestsUnavailable},
+		{"broken yaml", fmt.Errorf("failed to parse web.yaml: %w", errors.New("yaml: line 3: mapping values are not allowed")), CauseInvalidManifest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiagnoseDeployFailure(ctx, clientset, "demo", tt.err)
+			require.NotNil(t, d)
+			assert.Equal(t, tt.want, d.Cause)
+			assert.NotEmpty(t, d.Detail)
+			assert.NotEmpty(t, d.Guidance)
+		})
+	}
+}
+
+func TestDiagnoseDeployFailure_FromEvents(t *testing.T) {
+	ctx := context.Background()
+	timeout := errors.New("timeout waiting for Deployment demo/web to be ready: context deadline exceeded")
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo"}}
+
+	clientset := fake.NewClientset(namespace,
+		warningEvent("pull", "Failed", `Failed to pull image "nginx:1.99": not found`, time.Minute),
+	)
+	d := DiagnoseDeployFailure(ctx, clientset, "demo", timeout)
+	require.NotNil(t, d)
+	assert.Equal(t, CauseImagePull, d.Cause)
+	assert.Contains(t, d.Detail, "pod web-1")
+	assert.Contains(t, d.Guidance, "kubeasy prefetch demo")
+
+	clientset = fake.NewClientset(namespace,
+		warningEvent("pull", "Failed", `Failed to pull image "nginx:1.99": not found`, time.Minute),
+		warningEvent("quota", "Failed", `Failed to pull image "redis": 429 Too Many Requests - toomanyrequests: You have reached your pull rate limit`, 2*time.Minute),
+	)
+	d = DiagnoseDeployFailure(ctx, clientset, "demo", timeout)
+	require.NotNil(t, d)
+	assert.Equal(t, CauseImagePullQuota, d.Cause, "a rate limit is more specific than any pull failure")
+
+	clientset = fake.NewClientset(namespace, warningEvent("policy", "PolicyViolation", "policy require-labels/check fail", time.Minute))
+	d = DiagnoseDeployFailure(ctx, clientset, "demo", timeout)
+	require.NotNil(t, d)
+	assert.Equal(t, CausePolicyDenied, d.Cause)
+
+	clientset = fake.NewClientset(namespace, warningEvent("probe", "Unhealthy", "Readiness probe failed", time.Minute))
+	assert.Nil(t, DiagnoseDeployFailure(ctx, clientset, "demo", timeout), "unknown causes are not guessed")
+
+	d = DiagnoseDeployFailure(ctx, clientset, "demo", errors.New("not ready: web (Ready=0/1; pod web-1 container app: ImagePullBackOff)"))
+	require.NotNil(t, d)
+	assert.Equal(t, CauseImagePull, d.Cause, "readiness errors name stuck pods")
+}
+
+func TestDiagnoseDeployFailure_NamespaceGone(t *testing.T) {
+	d := DiagnoseDeployFailure(context.Background(), fake.NewClientset(), "demo", errors.New("context deadline exceeded"))
+	require.NotNil(t, d)
+	assert.Equal(t, CauseNamespaceMissing, d.Cause)
+	assert.Contains(t, d.Guidance, "kubeasy challenge start demo")
+}
+
+func TestDiagnosed(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, Diagnosed(ctx, nil, "demo", nil))
+
+	plain := errors.New("connection refused")
+	assert.Same(t, plain, Diagnosed(ctx, nil, "demo", plain), "errors without a known cause are unchanged")
+
+	err := Diagnosed(ctx, nil, "demo", fmt.Errorf("apply: %w", errors.New(`admission webhook "kyverno" denied the request`)))
+	var deployErr *DeployError
+	require.ErrorAs(t, err, &deployErr)
+	assert.Equal(t, CausePolicyDenied, deployErr.Diagnosis.Cause)
+	assert.Contains(t, err.Error(), "apply:", "the original message is kept")
+}
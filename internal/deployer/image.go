@@ -32,7 +32,25 @@ func BuildAndLoadImage(ctx context.Context, imageDir string, imageTag string, cl
 	}
 	logger.Info("Docker image '%s' built successfully", imageTag)
 
-	// 2. Get Kind nodes using the Kind library
+	// 2. Load it into the Kind nodes
+	if err := loadDockerImage(ctx, imageTag, clusterName); err != nil {
+		return err
+	}
+
+	logger.Info("Image '%s' loaded into Kind cluster '%s'", imageTag, clusterName)
+	return nil
+}
+
+// HasImageDir checks if a challenge directory contains an image/ directory with a Dockerfile.
+func HasImageDir(challengeDir string) bool {
+	dockerfile := filepath.Join(challengeDir, "image", "Dockerfile")
+	_, err := os.Stat(dockerfile)
+	return err == nil
+}
+
+// loadDockerImage saves an image of the host's Docker and loads it into each node of
+// the kind cluster.
+func loadDockerImage(ctx context.Context, image, clusterName string) error {
 	provider := cluster.NewProvider()
 	nodeList, err := provider.ListInternalNodes(clusterName)
 	if err != nil {
@@ -42,7 +60,6 @@ func BuildAndLoadImage(ctx context.Context, imageDir string, imageTag string, cl
 		return fmt.Errorf("no Kind nodes found for cluster '%s'", clusterName)
 	}
 
-	// 3. Save Docker image to a temporary tar file
 	dir, err := fs.TempDir("", "kubeasy-image-*")
 	if err != nil {
 		return fmt.Errorf("failed to create temp directory: %w", err)
@@ -51,14 +68,13 @@ func BuildAndLoadImage(ctx context.Context, imageDir string, imageTag string, cl
 
 	imageTarPath := filepath.Join(dir, "image.tar")
 	logger.Info("Saving Docker image to %s...", imageTarPath)
-	saveCmd := exec.CommandContext(ctx, "docker", "save", "-o", imageTarPath, imageTag)
+	saveCmd := exec.CommandContext(ctx, "docker", "save", "-o", imageTarPath, image)
 	if err := saveCmd.Run(); err != nil {
 		return fmt.Errorf("docker save failed: %w", err)
 	}
 
-	// 4. Load image into each Kind node
 	for _, node := range nodeList {
-		logger.Info("Loading image '%s' into Kind node '%s'...", imageTag, node.String())
+		logger.Info("Loading image '%s' into Kind node '%s'...", image, node.String())
 		f, err := os.Open(imageTarPath)
 		if err != nil {
 			return fmt.Errorf("failed to open image tar: %w", err)
@@ -69,14 +85,5 @@ func BuildAndLoadImage(ctx context.Context, imageDir string, imageTag string, cl
 			return fmt.Errorf("failed to load image into node %s: %w", node.String(), loadErr)
 		}
 	}
-
-	logger.Info("Image '%s' loaded into Kind cluster '%s'", imageTag, clusterName)
 	return nil
 }
-
-// HasImageDir checks if a challenge directory contains an image/ directory with a Dockerfile.
-func HasImageDir(challengeDir string) bool {
-	dockerfile := filepath.Join(challengeDir, "image", "Dockerfile")
-	_, err := os.Stat(dockerfile)
-	return err == nil
-}
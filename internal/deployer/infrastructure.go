@@ -173,8 +173,44 @@ func isLocalPathProvisionerReadyWithClient(ctx context.Context, clientset kubern
 	return true, nil
 }
 
-// installKyverno installs Kyverno into the cluster. If already ready, returns StatusReady immediately.
-// Returns ComponentResult — never an error. Called by SetupAllComponents.
+// kyvernoWebhookService is the Service of the Kyverno admission webhook.
+const kyvernoWebhookService = "kyverno-svc"
+
+// kyvernoWebhookBackoff spaces the probes of waitForKyvernoWebhook: about a minute
+// in total, so a webhook that is only slow to get endpoints is not reported broken.
+var kyvernoWebhookBackoff = wait.Backoff{Duration: time.Second, Factor: 2, Jitter: 0.1, Steps: 8, Cap: 15 * time.Second}
+
+// probeKyvernoWebhook calls the liveness endpoint of the Kyverno admission webhook
+// through the API server service proxy, the path admission requests take. It fails
+// when the Service has no ready endpoint or the webhook does not answer.
+func probeKyvernoWebhook(ctx context.Context, clientset kubernetes.Interface) error {
+	resp := clientset.CoreV1().Services(kyvernoNamespace).ProxyGet("https", kyvernoWebhookService, "443", "/health/liveness", nil)
+	if resp == nil {
+		return fmt.Errorf("service proxy unavailable")
+	}
+	_, err := resp.DoRaw(ctx)
+	return err
+}
+
+// waitForKyvernoWebhook probes the Kyverno admission webhook with kyvernoWebhookBackoff
+// until it answers, returning the last probe error when it never does.
+func waitForKyvernoWebhook(ctx context.Context, clientset kubernetes.Interface) error {
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, kyvernoWebhookBackoff, func(ctx context.Context) (bool, error) {
+		lastErr = probeKyvernoWebhook(ctx, clientset)
+		if lastErr != nil {
+			logger.Debug("Kyverno webhook not reachable yet: %v", lastErr)
+		}
+		return lastErr == nil, nil
+	})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("kyverno admission webhook is unreachable: %w", lastErr)
+	}
+	return err
+}
+
+// installKyverno installs Kyverno into the cluster. If already ready, returns StatusReady once its
+// admission webhook answers. Returns ComponentResult — never an error. Called by SetupAllComponents.
 func installKyverno(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, mapper meta.RESTMapper) ComponentResult {
 	const name = "kyverno"
 
@@ -183,6 +219,10 @@ func installKyverno(ctx context.Context, clientset kubernetes.Interface, dynamic
 		return notReady(name, err)
 	}
 	if ready {
+		// Ready replicas do not mean the API server can call the webhook yet
+		if err := waitForKyvernoWebhook(ctx, clientset); err != nil {
+			return notReady(name, err)
+		}
 		logger.Info("Kyverno is already installed and ready, skipping installation")
 		return ComponentResult{Name: name, Status: StatusReady, Message: "already installed"}
 	}
@@ -194,13 +234,13 @@ func installKyverno(ctx context.Context, clientset kubernetes.Interface, dynamic
 
 	kyvernoURL := kyvernoInstallURL()
 	logger.Debug("Fetching Kyverno manifest from %s", kyvernoURL)
-	kyvernoManifest, err := kube.FetchManifest(kyvernoURL)
+	kyvernoManifest, err := fetchAddonManifest(ctx, clientset, kyvernoURL)
 	if err != nil {
 		return notReady(name, fmt.Errorf("failed to download Kyverno manifest: %w", err))
 	}
 	logger.Debug("Kyverno manifest fetched (%d bytes)", len(kyvernoManifest))
 
-	if err := kube.ApplyManifest(ctx, kyvernoManifest, kyvernoNamespace, mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, kyvernoManifest, kyvernoNamespace, mapper, dynamicClient); err != nil {
 		return notReady(name, fmt.Errorf("failed to apply Kyverno manifest: %w", err))
 	}
 	logger.Info("Kyverno manifest applied.")
@@ -212,14 +252,12 @@ func installKyverno(ctx context.Context, clientset kubernetes.Interface, dynamic
 		"kyverno-reports-controller",
 	}
 
-	// kube.WaitForDeploymentsReady requires *kubernetes.Clientset.
-	cs, ok := clientset.(*kubernetes.Clientset)
-	if !ok {
-		return notReady(name, fmt.Errorf("internal error: clientset is not *kubernetes.Clientset"))
-	}
-	if err := kube.WaitForDeploymentsReady(ctx, cs, kyvernoNamespace, kyvernoDeployments); err != nil {
+	if err := kube.WaitForDeploymentsReady(ctx, clientset, kyvernoNamespace, kyvernoDeployments); err != nil {
 		return notReady(name, fmt.Errorf("kyverno deployments failed to become ready: %w", err))
 	}
+	if err := waitForKyvernoWebhook(ctx, clientset); err != nil {
+		return notReady(name, err)
+	}
 
 	logger.Info("Kyverno installed and ready.")
 	return ComponentResult{Name: name, Status: StatusReady, Message: "installed successfully"}
@@ -246,23 +284,18 @@ func installLocalPathProvisioner(ctx context.Context, clientset kubernetes.Inter
 
 	localPathURL := localPathProvisionerInstallURL()
 	logger.Debug("Fetching local-path-provisioner manifest from %s", localPathURL)
-	localPathManifest, err := kube.FetchManifest(localPathURL)
+	localPathManifest, err := fetchAddonManifest(ctx, clientset, localPathURL)
 	if err != nil {
 		return notReady(name, fmt.Errorf("failed to download local-path-provisioner manifest: %w", err))
 	}
 	logger.Debug("local-path-provisioner manifest fetched (%d bytes)", len(localPathManifest))
 
-	if err := kube.ApplyManifest(ctx, localPathManifest, localPathStorageNamespace, mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, localPathManifest, localPathStorageNamespace, mapper, dynamicClient); err != nil {
 		return notReady(name, fmt.Errorf("failed to apply local-path-provisioner manifest: %w", err))
 	}
 	logger.Info("local-path-provisioner manifest applied.")
 
-	// kube.WaitForDeploymentsReady requires *kubernetes.Clientset.
-	cs, ok := clientset.(*kubernetes.Clientset)
-	if !ok {
-		return notReady(name, fmt.Errorf("internal error: clientset is not *kubernetes.Clientset"))
-	}
-	if err := kube.WaitForDeploymentsReady(ctx, cs, localPathStorageNamespace, []string{"local-path-provisioner"}); err != nil {
+	if err := kube.WaitForDeploymentsReady(ctx, clientset, localPathStorageNamespace, []string{"local-path-provisioner"}); err != nil {
 		return notReady(name, fmt.Errorf("local-path-provisioner deployment failed to become ready: %w", err))
 	}
 
@@ -373,7 +406,7 @@ func installKubeasyCA(ctx context.Context, clientset kubernetes.Interface, dynam
 	freshMapper := restmapper.NewDiscoveryRESTMapper(freshGroups)
 
 	// Apply the ClusterIssuer that references the CA Secret.
-	if err := kube.ApplyManifest(ctx, []byte(clusterIssuerManifest), "", freshMapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, []byte(clusterIssuerManifest), "", freshMapper, dynamicClient); err != nil {
 		return notReady(name, fmt.Errorf("failed to apply kubeasy-ca ClusterIssuer: %w", err))
 	}
 	logger.Info("kubeasy-ca ClusterIssuer created.")
@@ -381,10 +414,24 @@ func installKubeasyCA(ctx context.Context, clientset kubernetes.Interface, dynam
 	return ComponentResult{Name: name, Status: StatusReady, Message: "CA generated and ClusterIssuer created"}
 }
 
+// ComponentPlan describes what SetupAllComponents installs, in order, for dry runs and
+// confirmations. Components that are already ready are left untouched.
+func ComponentPlan() []string {
+	return []string{
+		"Install Kyverno " + KyvernoVersion,
+		"Install local-path-provisioner " + LocalPathProvisionerVersion,
+		"Install ingress-nginx " + NginxIngressVersion,
+		"Install the Gateway API CRDs " + GatewayAPICRDsVersion,
+		"Install cert-manager " + CertManagerVersion,
+		"Create the kubeasy-ca ClusterIssuer",
+		"Start cloud-provider-kind " + CloudProviderKindVersion,
+	}
+}
+
 // SetupAllComponents installs all infrastructure components and returns a ComponentResult for each.
 // The order is: kyverno, local-path-provisioner, nginx-ingress, gateway-api, cert-manager, kubeasy-ca, cloud-provider-kind.
 // Execution continues regardless of individual component failures — all seven results are always returned.
-func SetupAllComponents(ctx context.Context, clientset *kubernetes.Clientset, dynamicClient dynamic.Interface) []ComponentResult {
+func SetupAllComponents(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface) []ComponentResult {
 	// Build REST mapper from API discovery — used for components that don't rebuild their own mapper.
 	// Gateway API rebuilds its mapper internally after CRD install (two-pass apply).
 	groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
@@ -412,10 +459,10 @@ func SetupAllComponents(ctx context.Context, clientset *kubernetes.Clientset, dy
 
 // SetupInfrastructure installs Kyverno and local-path-provisioner directly into the cluster.
 // Use SetupAllComponents for per-component status across all 7 infrastructure components.
-func SetupInfrastructure() error {
+func SetupInfrastructure(ctx context.Context) error {
 	logger.Info("Starting infrastructure setup (Kyverno + local-path-provisioner)...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultInfrastructureTimeout)
+	ctx, cancel := context.WithTimeout(ctx, defaultInfrastructureTimeout)
 	defer cancel()
 
 	clientset, err := kube.GetKubernetesClient()
@@ -447,13 +494,13 @@ func SetupInfrastructure() error {
 
 	kyvernoURL := kyvernoInstallURL()
 	logger.Debug("Fetching Kyverno manifest from %s", kyvernoURL)
-	kyvernoManifest, err := kube.FetchManifest(kyvernoURL)
+	kyvernoManifest, err := fetchAddonManifest(ctx, clientset, kyvernoURL)
 	if err != nil {
 		return fmt.Errorf("failed to download Kyverno manifest: %w", err)
 	}
 	logger.Debug("Kyverno manifest fetched (%d bytes)", len(kyvernoManifest))
 
-	if err := kube.ApplyManifest(ctx, kyvernoManifest, kyvernoNamespace, mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, kyvernoManifest, kyvernoNamespace, mapper, dynamicClient); err != nil {
 		return fmt.Errorf("failed to apply Kyverno manifest: %w", err)
 	}
 	logger.Info("Kyverno manifest applied.")
@@ -466,13 +513,13 @@ func SetupInfrastructure() error {
 
 	localPathURL := localPathProvisionerInstallURL()
 	logger.Debug("Fetching local-path-provisioner manifest from %s", localPathURL)
-	localPathManifest, err := kube.FetchManifest(localPathURL)
+	localPathManifest, err := fetchAddonManifest(ctx, clientset, localPathURL)
 	if err != nil {
 		return fmt.Errorf("failed to download local-path-provisioner manifest: %w", err)
 	}
 	logger.Debug("local-path-provisioner manifest fetched (%d bytes)", len(localPathManifest))
 
-	if err := kube.ApplyManifest(ctx, localPathManifest, localPathStorageNamespace, mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, localPathManifest, localPathStorageNamespace, mapper, dynamicClient); err != nil {
 		return fmt.Errorf("failed to apply local-path-provisioner manifest: %w", err)
 	}
 	logger.Info("local-path-provisioner manifest applied.")
@@ -489,6 +536,9 @@ func SetupInfrastructure() error {
 	if err := kube.WaitForDeploymentsReady(ctx, clientset, kyvernoNamespace, kyvernoDeployments); err != nil {
 		return fmt.Errorf("kyverno deployments failed to become ready: %w", err)
 	}
+	if err := waitForKyvernoWebhook(ctx, clientset); err != nil {
+		return err
+	}
 	logger.Info("Kyverno is ready.")
 
 	localPathDeployments := []string{"local-path-provisioner"}
@@ -502,7 +552,7 @@ func SetupInfrastructure() error {
 }
 
 // IsInfrastructureReady checks if Kyverno and local-path-provisioner are installed and ready.
-func IsInfrastructureReady() (bool, error) {
+func IsInfrastructureReady(ctx context.Context) (bool, error) {
 	logger.Debug("Checking if infrastructure is already installed...")
 
 	clientset, err := kube.GetKubernetesClient()
@@ -510,7 +560,7 @@ func IsInfrastructureReady() (bool, error) {
 		return false, fmt.Errorf("failed to get Kubernetes client: %w", err)
 	}
 
-	return IsInfrastructureReadyWithClient(context.Background(), clientset)
+	return IsInfrastructureReadyWithClient(ctx, clientset)
 }
 
 // IsInfrastructureReadyWithClient checks infrastructure readiness using the provided client.
@@ -597,7 +647,7 @@ func waitForCertManagerWebhookEndpoints(ctx context.Context, clientset kubernete
 // controller manifest. After the deployments are ready it polls the webhook Endpoints
 // until at least one address is present. Returns a ComponentResult — never an error.
 // Called by setup.go (plan 04) when setting up the infrastructure.
-func installCertManager(ctx context.Context, clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, mapper meta.RESTMapper) ComponentResult {
+func installCertManager(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, mapper meta.RESTMapper) ComponentResult {
 	// Idempotency check
 	ready, err := isCertManagerReadyWithClient(ctx, clientset)
 	if err != nil {
@@ -609,24 +659,24 @@ func installCertManager(ctx context.Context, clientset *kubernetes.Clientset, dy
 
 	// Pass 1: CRDs
 	logger.Info("Installing cert-manager %s (pass 1: CRDs)...", CertManagerVersion)
-	crdsManifest, err := kube.FetchManifest(certManagerCRDsURL())
+	crdsManifest, err := fetchAddonManifest(ctx, clientset, certManagerCRDsURL())
 	if err != nil {
 		return notReady("cert-manager", err)
 	}
 	if err := kube.CreateNamespace(ctx, clientset, certManagerNamespace); err != nil {
 		return notReady("cert-manager", err)
 	}
-	if err := kube.ApplyManifest(ctx, crdsManifest, certManagerNamespace, mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, crdsManifest, certManagerNamespace, mapper, dynamicClient); err != nil {
 		return notReady("cert-manager", err)
 	}
 
 	// Pass 2: controller (cert-manager.yaml includes CRDs too — apply is idempotent)
 	logger.Info("Installing cert-manager %s (pass 2: controller)...", CertManagerVersion)
-	ctrlManifest, err := kube.FetchManifest(certManagerInstallURL())
+	ctrlManifest, err := fetchAddonManifest(ctx, clientset, certManagerInstallURL())
 	if err != nil {
 		return notReady("cert-manager", err)
 	}
-	if err := kube.ApplyManifest(ctx, ctrlManifest, certManagerNamespace, mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, ctrlManifest, certManagerNamespace, mapper, dynamicClient); err != nil {
 		return notReady("cert-manager", err)
 	}
 
@@ -736,21 +786,16 @@ func installNginxIngress(ctx context.Context, clientset kubernetes.Interface, dy
 
 	manifestURL := nginxIngressKindManifestURL()
 	logger.Debug("Fetching nginx-ingress manifest from %s", manifestURL)
-	manifest, err := kube.FetchManifest(manifestURL)
+	manifest, err := fetchAddonManifest(ctx, clientset, manifestURL)
 	if err != nil {
 		return notReady(name, fmt.Errorf("failed to download nginx-ingress manifest: %w", err))
 	}
 
-	if err := kube.ApplyManifest(ctx, manifest, nginxIngressNamespace, mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, manifest, nginxIngressNamespace, mapper, dynamicClient); err != nil {
 		return notReady(name, fmt.Errorf("failed to apply nginx-ingress manifest: %w", err))
 	}
 
-	// kube.WaitForDeploymentsReady requires *kubernetes.Clientset.
-	cs, ok := clientset.(*kubernetes.Clientset)
-	if !ok {
-		return notReady(name, fmt.Errorf("internal error: clientset is not *kubernetes.Clientset"))
-	}
-	if err := kube.WaitForDeploymentsReady(ctx, cs, nginxIngressNamespace, []string{"ingress-nginx-controller"}); err != nil {
+	if err := kube.WaitForDeploymentsReady(ctx, clientset, nginxIngressNamespace, []string{"ingress-nginx-controller"}); err != nil {
 		return notReady(name, fmt.Errorf("nginx-ingress deployment failed to become ready: %w", err))
 	}
 
@@ -779,7 +824,7 @@ func installGatewayAPI(ctx context.Context, clientset kubernetes.Interface, dyna
 	// Pass 1: Apply CRDs manifest (cluster-scoped, empty namespace)
 	crdsURL := gatewayAPICRDsURL()
 	logger.Debug("Fetching Gateway API CRDs manifest from %s", crdsURL)
-	crdsManifest, err := kube.FetchManifest(crdsURL)
+	crdsManifest, err := fetchAddonManifest(ctx, clientset, crdsURL)
 	if err != nil {
 		return notReady(name, fmt.Errorf("failed to download Gateway API CRDs manifest: %w", err))
 	}
@@ -791,7 +836,7 @@ func installGatewayAPI(ctx context.Context, clientset kubernetes.Interface, dyna
 	}
 	mapper := restmapper.NewDiscoveryRESTMapper(groups)
 
-	if err := kube.ApplyManifest(ctx, crdsManifest, "", mapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, crdsManifest, "", mapper, dynamicClient); err != nil {
 		return notReady(name, fmt.Errorf("failed to apply Gateway API CRDs: %w", err))
 	}
 	logger.Info("Gateway API CRDs applied.")
@@ -803,7 +848,7 @@ func installGatewayAPI(ctx context.Context, clientset kubernetes.Interface, dyna
 	}
 	freshMapper := restmapper.NewDiscoveryRESTMapper(freshGroups)
 
-	if err := kube.ApplyManifest(ctx, []byte(gatewayClassManifest), "", freshMapper, dynamicClient); err != nil {
+	if _, err := kube.ApplyManifest(ctx, []byte(gatewayClassManifest), "", freshMapper, dynamicClient); err != nil {
 		return notReady(name, fmt.Errorf("failed to apply GatewayClass manifest: %w", err))
 	}
 	logger.Info("GatewayClass cloud-provider-kind created.")
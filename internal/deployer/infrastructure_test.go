@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,11 +16,14 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
 	k8stesting "k8s.io/client-go/testing"
 	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
@@ -499,6 +505,10 @@ func TestInstallKyverno_AlreadyReady(t *testing.T) {
 		makeDeployment(kyvernoNamespace, "kyverno-cleanup-controller", 1, true),
 		makeDeployment(kyvernoNamespace, "kyverno-reports-controller", 1, true),
 	)
+	// The admission webhook answers
+	clientset.PrependProxyReactor("services", func(k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+		return true, proxyResponse{}, nil
+	})
 
 	result := installKyverno(context.Background(), clientset, nil, nil)
 	assert.Equal(t, StatusReady, result.Status, "installKyverno should return StatusReady when already ready")
@@ -643,3 +653,101 @@ func TestInstallKubeasyCA_GetError(t *testing.T) {
 
 // Blank import to satisfy the schema.GroupVersionResource reference used by dynamicfake.
 var _ = schema.GroupVersionResource{}
+
+// proxyResponse is the response of a stubbed service proxy call.
+type proxyResponse struct{ err error }
+
+func (r proxyResponse) DoRaw(context.Context) ([]byte, error) { return []byte("ok"), r.err }
+
+func (r proxyResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("ok")), r.err
+}
+
+func TestWaitForKyvernoWebhook(t *testing.T) {
+	saved := kyvernoWebhookBackoff
+	kyvernoWebhookBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	t.Cleanup(func() { kyvernoWebhookBackoff = saved })
+
+	t.Run("answers after a failure", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		calls := 0
+		clientset.PrependProxyReactor("services", func(action k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+			proxy := action.(k8stesting.ProxyGetAction)
+			assert.Equal(t, kyvernoWebhookService, proxy.GetName())
+			assert.Equal(t, "/health/liveness", proxy.GetPath())
+			calls++
+			if calls == 1 {
+				return true, proxyResponse{err: errors.New("no endpoints available for service \"kyverno-svc\"")}, nil
+			}
+			return true, proxyResponse{}, nil
+		})
+		require.NoError(t, waitForKyvernoWebhook(context.Background(), clientset))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("never answers", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		clientset.PrependProxyReactor("services", func(k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+			return true, proxyResponse{err: errors.New("connection refused")}, nil
+		})
+		err := waitForKyvernoWebhook(context.Background(), clientset)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kyverno admission webhook is unreachable: connection refused")
+	})
+}
+
+func TestInstallKyverno_ManifestsDir(t *testing.T) {
+	t.Setenv(ManifestsDirEnv, "")
+	t.Setenv(RegistryMirrorsEnv, "")
+	dir := t.TempDir()
+	ManifestsDir = dir
+	t.Cleanup(func() { ManifestsDir = "" })
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme)
+	activeNamespace := func() *corev1.Namespace {
+		ns := makeNamespace(kyvernoNamespace)
+		ns.Status.Phase = corev1.NamespaceActive
+		return ns
+	}
+
+	t.Run("missing manifest", func(t *testing.T) {
+		clientset := fake.NewClientset(activeNamespace())
+		dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+
+		result := installKyverno(context.Background(), clientset, dynamicClient, mapper)
+		assert.Equal(t, StatusNotReady, result.Status)
+		assert.Contains(t, result.Message, filepath.Join(dir, "install.yaml"))
+	})
+
+	t.Run("manifest read from the directory", func(t *testing.T) {
+		manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: offline\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "install.yaml"), []byte(manifest), 0o600))
+		clientset := fake.NewClientset(activeNamespace())
+		dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+
+		// The deployments never become ready: only the apply matters here
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		installKyverno(ctx, clientset, dynamicClient, mapper)
+
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		_, err := dynamicClient.Resource(gvr).Namespace(kyvernoNamespace).Get(context.Background(), "offline", metav1.GetOptions{})
+		assert.NoError(t, err, "the manifest from the directory should be applied")
+	})
+}
+
+func TestAddonManifestSource(t *testing.T) {
+	const url = "https://github.com/kyverno/kyverno/releases/download/v1.0.0/install.yaml"
+
+	t.Setenv(ManifestsDirEnv, "")
+	assert.Equal(t, url, addonManifestSource(url))
+
+	t.Setenv(ManifestsDirEnv, "/env/manifests")
+	assert.Equal(t, "file:///env/manifests/install.yaml", addonManifestSource(url))
+
+	ManifestsDir = "/flag/manifests"
+	t.Cleanup(func() { ManifestsDir = "" })
+	assert.Equal(t, "file:///flag/manifests/install.yaml", addonManifestSource(url), "the flag wins over the env var")
+}
@@ -3,7 +3,9 @@ package deployer
 import (
 	"context"
 	"fmt"
+	"os"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -12,27 +14,104 @@ import (
 
 // DeployLocalChallenge applies manifests from a local challenge directory to the cluster.
 // Unlike DeployChallenge, it reads from the local filesystem instead of pulling from OCI.
-func DeployLocalChallenge(ctx context.Context, clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, challengeDir string, namespace string) error {
+// It returns what was done with every manifest document, also when a later step fails.
+func DeployLocalChallenge(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, challengeDir string, namespace string) ([]kube.ApplyResult, error) {
 	logger.Info("Deploying local challenge from '%s'...", challengeDir)
 
 	// Build REST mapper from API discovery
 	groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
 	if err != nil {
-		return fmt.Errorf("failed to discover API resources: %w", err)
+		return nil, fmt.Errorf("failed to discover API resources: %w", err)
 	}
 	mapper := restmapper.NewDiscoveryRESTMapper(groups)
 
 	// Find and apply YAML files from manifests/ and policies/
-	if err := applyManifestDirs(ctx, challengeDir, namespace, mapper, dynamicClient); err != nil {
-		return err
+	results, err := applyManifestDirs(ctx, challengeDir, namespace, mapper, dynamicClient, kube.ApplyOptions{})
+	if err != nil {
+		return results, err
 	}
 
 	// Wait for Deployments and StatefulSets to be ready
 	logger.Info("Waiting for challenge resources to be ready...")
 	if err := WaitForChallengeReady(ctx, clientset, namespace); err != nil {
-		return fmt.Errorf("challenge resources failed to become ready: %w", err)
+		return results, fmt.Errorf("challenge resources failed to become ready: %w", err)
 	}
 
 	logger.Info("Local challenge deployed successfully.")
+	return results, nil
+}
+
+// DiffLocalChallenge previews what DeployLocalChallenge would change: every manifest is
+// sent as a server-side dry run and each result carries a diff against the live object.
+// Nothing is persisted.
+func DiffLocalChallenge(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, challengeDir string, namespace string) ([]kube.ApplyResult, error) {
+	groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API resources: %w", err)
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groups)
+
+	return applyManifestDirs(ctx, challengeDir, namespace, mapper, dynamicClient, kube.ApplyOptions{DryRun: true, Diff: true})
+}
+
+// SolutionDirName is the optional directory of a local challenge holding manifests
+// that fix the broken initial state. It is never deployed to learners.
+const SolutionDirName = "solution"
+
+// ApplySolution applies every manifest under solutionDir on top of a deployed challenge.
+// It does not wait for readiness: callers poll validations until the fix converges.
+func ApplySolution(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, solutionDir string, namespace string) error {
+	logger.Info("Applying solution from '%s'...", solutionDir)
+
+	groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
+	if err != nil {
+		return fmt.Errorf("failed to discover API resources: %w", err)
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groups)
+
+	results, err := applyYAMLDir(ctx, solutionDir, namespace, mapper, dynamicClient, kube.ApplyOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to apply solution: %w", err)
+	}
+	logger.Info("Solution applied (%s).", kube.SummarizeApply(results))
 	return nil
 }
+
+// ApplySolutionOverlay snapshots the objects declared in solutionDir, then applies them.
+// The returned rollback function restores the snapshot: objects created by the
+// solution are deleted and modified ones are put back to their previous state.
+func ApplySolutionOverlay(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, solutionDir string, namespace string) (func(context.Context) error, error) {
+	logger.Info("Applying solution overlay from '%s'...", solutionDir)
+
+	groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API resources: %w", err)
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groups)
+
+	files, err := listYAMLFiles(solutionDir)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := kube.NewManifestSnapshot(dynamicClient)
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %s: %w", f, err)
+		}
+		if err := snapshot.Add(ctx, data, namespace, mapper); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := applyYAMLDir(ctx, solutionDir, namespace, mapper, dynamicClient, kube.ApplyOptions{}); err != nil {
+		// Undo whatever was applied before the failure.
+		if rbErr := snapshot.Restore(ctx); rbErr != nil {
+			logger.Warning("Failed to roll back partial solution overlay: %v", rbErr)
+		}
+		return nil, fmt.Errorf("failed to apply solution: %w", err)
+	}
+
+	return snapshot.Restore, nil
+}
@@ -0,0 +1,173 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RegistryMirrorsEnv configures the registry mirrors used for the images the CLI deploys
+// (probe pod and add-ons), as comma-separated registry=mirror pairs, e.g.
+// "docker.io=mirror.example.com/dockerhub,ghcr.io=mirror.example.com/ghcr". A mirror may
+// contain "{arch}", replaced by the node architecture, for mirrors that publish one
+// single-architecture repository per platform.
+const RegistryMirrorsEnv = "KUBEASY_REGISTRY_MIRRORS"
+
+// defaultRegistry is the registry of image references without a registry host.
+const defaultRegistry = "docker.io"
+
+// RegistryMirrors maps a registry host to the mirror that replaces it.
+type RegistryMirrors map[string]string
+
+// ParseRegistryMirrors parses the value of RegistryMirrorsEnv.
+func ParseRegistryMirrors(v string) (RegistryMirrors, error) {
+	mirrors := RegistryMirrors{}
+	for pair := range strings.SplitSeq(v, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		registry, mirror, ok := strings.Cut(pair, "=")
+		registry, mirror = strings.TrimSpace(registry), strings.TrimSuffix(strings.TrimSpace(mirror), "/")
+		if !ok || registry == "" || mirror == "" {
+			return nil, fmt.Errorf("invalid registry mirror %q: expected registry=mirror", pair)
+		}
+		mirrors[registry] = mirror
+	}
+	return mirrors, nil
+}
+
+// registryMirrorsFromEnv returns the mirrors of RegistryMirrorsEnv; an invalid value is
+// logged and ignored so setup still works with the upstream registries.
+func registryMirrorsFromEnv() RegistryMirrors {
+	mirrors, err := ParseRegistryMirrors(os.Getenv(RegistryMirrorsEnv))
+	if err != nil {
+		logger.Warning("Ignoring %s: %v", RegistryMirrorsEnv, err)
+		return nil
+	}
+	return mirrors
+}
+
+// Resolve returns image pulled through its registry's mirror, if any. arch replaces
+// "{arch}" in the mirror.
+func (m RegistryMirrors) Resolve(image, arch string) string {
+	registry, path := splitImageRegistry(image)
+	mirror, ok := m[registry]
+	if !ok {
+		return image
+	}
+	if arch != "" {
+		mirror = strings.ReplaceAll(mirror, "{arch}", arch)
+	}
+	return mirror + "/" + path
+}
+
+// splitImageRegistry splits an image reference into its registry host and the rest,
+// with Docker's defaults: no host means docker.io, and single-name images live in
+// library/.
+func splitImageRegistry(image string) (string, string) {
+	first, rest, ok := strings.Cut(image, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return first, rest
+	}
+	if !ok {
+		return defaultRegistry, "library/" + image
+	}
+	return defaultRegistry, image
+}
+
+// manifestImageLine matches the image fields of a YAML manifest.
+var manifestImageLine = regexp.MustCompile(`(?m)^(\s*(?:-\s+)?image:\s*)(["']?)([^\s"'#]+)(["']?)`)
+
+// ResolveManifestImages rewrites the images of a YAML manifest through the mirrors.
+func (m RegistryMirrors) ResolveManifestImages(manifest []byte, arch string) []byte {
+	if len(m) == 0 {
+		return manifest
+	}
+	return manifestImageLine.ReplaceAllFunc(manifest, func(line []byte) []byte {
+		sub := manifestImageLine.FindSubmatch(line)
+		return fmt.Appendf(nil, "%s%s%s%s", sub[1], sub[2], m.Resolve(string(sub[3]), arch), sub[4])
+	})
+}
+
+// NodeArchitecture returns the CPU architecture of the cluster nodes (e.g. "arm64" for
+// kind on Apple silicon), or "" when it is unknown or the nodes do not share one.
+func NodeArchitecture(ctx context.Context, clientset kubernetes.Interface) (string, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+	arch := ""
+	for _, n := range nodes.Items {
+		switch a := nodeArch(n); {
+		case a == "":
+		case arch == "":
+			arch = a
+		case a != arch:
+			return "", nil
+		}
+	}
+	return arch, nil
+}
+
+// nodeArch reads the architecture reported by the kubelet, or the well-known label.
+func nodeArch(n corev1.Node) string {
+	if n.Status.NodeInfo.Architecture != "" {
+		return n.Status.NodeInfo.Architecture
+	}
+	return n.Labels[corev1.LabelArchStable]
+}
+
+// ManifestsDirEnv points setup at a directory holding the add-on manifests, for
+// offline setups. Each manifest is read from the file named like the last element of
+// its URL (install.yaml, local-path-storage.yaml, deploy.yaml, standard-install.yaml,
+// cert-manager.crds.yaml, cert-manager.yaml) instead of being downloaded.
+const ManifestsDirEnv = "KUBEASY_MANIFESTS_DIR"
+
+// ManifestsDir overrides ManifestsDirEnv; kubeasy setup sets it from --manifests-dir.
+var ManifestsDir string
+
+// addonManifestSource returns where to read the add-on manifest published at url:
+// a file:// URL in the manifests directory when one is configured, url otherwise.
+func addonManifestSource(url string) string {
+	dir := ManifestsDir
+	if dir == "" {
+		dir = os.Getenv(ManifestsDirEnv)
+	}
+	if dir == "" {
+		return url
+	}
+	return "file://" + filepath.Join(dir, path.Base(url))
+}
+
+// fetchAddonManifest fetches an add-on manifest, from the manifests directory when one
+// is configured (see ManifestsDirEnv), and points its images at the configured mirrors.
+func fetchAddonManifest(ctx context.Context, clientset kubernetes.Interface, url string) ([]byte, error) {
+	source := addonManifestSource(url)
+	if source != url {
+		logger.Debug("Reading the manifest of %s from %s", url, source)
+	}
+	manifest, err := kube.FetchManifest(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	mirrors := registryMirrorsFromEnv()
+	if len(mirrors) == 0 {
+		return manifest, nil
+	}
+	arch, err := NodeArchitecture(ctx, clientset)
+	if err != nil {
+		logger.Debug("Could not detect node architecture: %v", err)
+	}
+	return mirrors.ResolveManifestImages(manifest, arch), nil
+}
@@ -0,0 +1,93 @@
+package deployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseRegistryMirrors(t *testing.T) {
+	mirrors, err := ParseRegistryMirrors(" docker.io=mirror.example.com/hub/ , ghcr.io=ghcr.example.com,")
+	require.NoError(t, err)
+	assert.Equal(t, RegistryMirrors{"docker.io": "mirror.example.com/hub", "ghcr.io": "ghcr.example.com"}, mirrors)
+
+	mirrors, err = ParseRegistryMirrors("")
+	require.NoError(t, err)
+	assert.Empty(t, mirrors)
+
+	_, err = ParseRegistryMirrors("docker.io")
+	assert.ErrorContains(t, err, "expected registry=mirror")
+}
+
+func TestRegistryMirrors_Resolve(t *testing.T) {
+	m := RegistryMirrors{
+		"docker.io":       "mirror.example.com/hub",
+		"registry.k8s.io": "mirror.example.com/k8s-{arch}",
+	}
+	tests := []struct {
+		image string
+		arch  string
+		want  string
+	}{
+		{"curlimages/curl:8.18.0", "", "mirror.example.com/hub/curlimages/curl:8.18.0"},
+		{"busybox", "", "mirror.example.com/hub/library/busybox"},
+		{"docker.io/library/nginx:1.27", "", "mirror.example.com/hub/library/nginx:1.27"},
+		{"registry.k8s.io/ingress-nginx/controller:v1.15.0@sha256:abc", "arm64", "mirror.example.com/k8s-arm64/ingress-nginx/controller:v1.15.0@sha256:abc"},
+		{"ghcr.io/kyverno/kyverno:v1.17.1", "", "ghcr.io/kyverno/kyverno:v1.17.1"},
+		{"localhost:5000/app", "", "localhost:5000/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.Resolve(tt.image, tt.arch))
+		})
+	}
+}
+
+func TestRegistryMirrors_ResolveManifestImages(t *testing.T) {
+	manifest := []byte(`spec:
+  containers:
+    - name: controller
+      image: "registry.k8s.io/ingress-nginx/controller:v1.15.0"
+      imagePullPolicy: IfNotPresent
+  initContainers:
+  - image: busybox # init
+`)
+	got := RegistryMirrors{"docker.io": "hub.example.com", "registry.k8s.io": "k8s.example.com/{arch}"}.ResolveManifestImages(manifest, "amd64")
+	assert.Equal(t, `spec:
+  containers:
+    - name: controller
+      image: "k8s.example.com/amd64/ingress-nginx/controller:v1.15.0"
+      imagePullPolicy: IfNotPresent
+  initContainers:
+  - image: hub.example.com/library/busybox # init
+`, string(got))
+}
+
+func TestNodeArchitecture(t *testing.T) {
+	node := func(name, arch, label string) *corev1.Node {
+		n := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		n.Status.NodeInfo.Architecture = arch
+		if label != "" {
+			n.Labels = map[string]string{corev1.LabelArchStable: label}
+		}
+		return n
+	}
+	ctx := context.Background()
+
+	arch, err := NodeArchitecture(ctx, fake.NewClientset(node("a", "arm64", ""), node("b", "", "arm64")))
+	require.NoError(t, err)
+	assert.Equal(t, "arm64", arch)
+
+	arch, err = NodeArchitecture(ctx, fake.NewClientset(node("a", "arm64", ""), node("b", "amd64", "")))
+	require.NoError(t, err)
+	assert.Empty(t, arch, "mixed architectures")
+
+	arch, err = NodeArchitecture(ctx, fake.NewClientset())
+	require.NoError(t, err)
+	assert.Empty(t, arch)
+}
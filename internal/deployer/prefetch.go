@@ -0,0 +1,146 @@
+package deployer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// containerListKeys are the pod spec fields holding containers with an image.
+var containerListKeys = []string{"containers", "initContainers", "ephemeralContainers"}
+
+// ChallengeImages pulls the OCI artifact of a challenge and returns the container
+// images its manifests reference, sorted and deduplicated.
+func ChallengeImages(ctx context.Context, slug string) ([]string, error) {
+	tmpDir, err := os.MkdirTemp("", "kubeasy-challenge-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	ref := fmt.Sprintf("%s/%s:latest", ChallengesOCIRegistry, slug)
+	logger.Debug("Pulling OCI artifact: %s", ref)
+	if err := pullOCIArtifact(ctx, ref, tmpDir); err != nil {
+		return nil, fmt.Errorf("failed to pull challenge artifact from %s: %w", ref, err)
+	}
+	return ManifestImages(tmpDir)
+}
+
+// ManifestImages returns the container images referenced by the manifests under the
+// "manifests" and "policies" subdirectories of baseDir, sorted and deduplicated. Any
+// object with a pod spec counts: Pods, workloads, CronJobs and custom resources alike.
+func ManifestImages(baseDir string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, dir := range []string{"manifests", "policies"} {
+		dirPath := filepath.Join(baseDir, dir)
+		if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+			continue
+		}
+		files, err := listYAMLFiles(dirPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			data, err := os.ReadFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read manifest %s: %w", f, err)
+			}
+			if err := documentImages(data, seen); err != nil {
+				return nil, fmt.Errorf("failed to parse manifest %s: %w", filepath.Base(f), err)
+			}
+		}
+	}
+	images := make([]string, 0, len(seen))
+	for image := range seen {
+		images = append(images, image)
+	}
+	slices.Sort(images)
+	return images, nil
+}
+
+// documentImages adds the images of every YAML document of data to seen.
+func documentImages(data []byte, seen map[string]bool) error {
+	dec := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		var doc any
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		collectImages(doc, seen)
+	}
+}
+
+// collectImages walks a decoded document and adds the image of every container found
+// in a container list to seen.
+func collectImages(v any, seen map[string]bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if slices.Contains(containerListKeys, key) {
+				if containers, ok := child.([]any); ok {
+					for _, c := range containers {
+						if c, ok := c.(map[string]any); ok {
+							if image, ok := c["image"].(string); ok && image != "" {
+								seen[image] = true
+							}
+						}
+					}
+				}
+			}
+			collectImages(child, seen)
+		}
+	case []any:
+		for _, child := range v {
+			collectImages(child, seen)
+		}
+	}
+}
+
+// PullImageInCluster makes every node of the kind cluster pull image with crictl, so
+// pods using it start without waiting on the network. Pulls go through the node's
+// containerd, and therefore through its registry mirrors.
+func PullImageInCluster(ctx context.Context, clusterName, image string) error {
+	nodeList, err := cluster.NewProvider().ListInternalNodes(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to list Kind nodes: %w", err)
+	}
+	if len(nodeList) == 0 {
+		return fmt.Errorf("no Kind nodes found for cluster '%s'", clusterName)
+	}
+	for _, node := range nodeList {
+		var out bytes.Buffer
+		cmd := node.CommandContext(ctx, "crictl", "pull", image)
+		cmd.SetStdout(&out)
+		cmd.SetStderr(&out)
+		if err := cmd.Run(); err != nil {
+			logger.Debug("crictl pull output: %s", out.String())
+			return fmt.Errorf("failed to pull %s in node %s: %w", image, node.String(), err)
+		}
+	}
+	return nil
+}
+
+// LoadImageFromDocker pulls image with the host's Docker, which may use a proxy or
+// image cache the cluster cannot reach, then loads it into every node of the kind
+// cluster like 'kind load docker-image'.
+func LoadImageFromDocker(ctx context.Context, clusterName, image string) error {
+	pullCmd := exec.CommandContext(ctx, "docker", "pull", image)
+	if out, err := pullCmd.CombinedOutput(); err != nil {
+		logger.Debug("Docker pull output: %s", string(out))
+		return fmt.Errorf("docker pull failed: %w", err)
+	}
+	return loadDockerImage(ctx, image, clusterName)
+}
@@ -0,0 +1,79 @@
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestImages(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write("manifests/deployment.yaml", `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: init
+          image: busybox:1.36
+      containers:
+        - name: web
+          image: nginx:1.27
+        - name: sidecar
+          image: busybox:1.36
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: report
+              image: ghcr.io/kubeasy-dev/report:v1
+`)
+	write("manifests/nested/pod.yml", `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+    - name: debug
+      image: curlimages/curl:8.18.0
+`)
+	write("manifests/config.yaml", `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  image: not-a-container
+`)
+	write("README.md", "image: ignored")
+
+	images, err := ManifestImages(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"busybox:1.36", "curlimages/curl:8.18.0", "ghcr.io/kubeasy-dev/report:v1", "nginx:1.27"}, images)
+}
+
+func TestManifestImages_InvalidYAML(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "manifests"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests", "bad.yaml"), []byte("kind: [unclosed"), 0o600))
+
+	_, err := ManifestImages(dir)
+	assert.ErrorContains(t, err, "bad.yaml")
+}
@@ -0,0 +1,80 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// componentManifest is one manifest SetupAllComponents applies: downloaded from url, or
+// the inline manifest.
+type componentManifest struct {
+	component string
+	namespace string
+	url       func() string
+	manifest  string
+}
+
+// componentManifests lists the manifests of SetupAllComponents, in install order.
+// cloud-provider-kind is a host process, not a manifest.
+var componentManifests = []componentManifest{
+	{component: "kyverno", namespace: kyvernoNamespace, url: kyvernoInstallURL},
+	{component: "local-path-provisioner", namespace: localPathStorageNamespace, url: localPathProvisionerInstallURL},
+	{component: "nginx-ingress", namespace: nginxIngressNamespace, url: nginxIngressKindManifestURL},
+	{component: "gateway-api", url: gatewayAPICRDsURL},
+	{component: "gateway-api", manifest: gatewayClassManifest},
+	{component: "cert-manager", namespace: certManagerNamespace, url: certManagerCRDsURL},
+	{component: "cert-manager", namespace: certManagerNamespace, url: certManagerInstallURL},
+	{component: "kubeasy-ca", manifest: clusterIssuerManifest},
+}
+
+// ComponentDiff is what applying one component manifest would change.
+type ComponentDiff struct {
+	Component string
+	// Source is the URL of the manifest, empty for the manifests built into the CLI.
+	Source string
+	// Results are the server-side dry-run results, with diffs, of the manifest documents.
+	Results []kube.ApplyResult
+	// Err is set when the manifest could not be fetched or dry-run.
+	Err error
+}
+
+// DiffComponents dry-runs every component manifest of SetupAllComponents against the
+// cluster (kube.ApplyOptions DryRun and Diff) and returns what each would change.
+// Nothing is changed. Like SetupAllComponents, it goes on when a manifest fails.
+// Objects whose CRD is installed by an earlier manifest are reported as skipped.
+func DiffComponents(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface) []ComponentDiff {
+	var mapper meta.RESTMapper
+	groups, discoveryErr := restmapper.GetAPIGroupResources(clientset.Discovery())
+	if discoveryErr == nil {
+		mapper = restmapper.NewDiscoveryRESTMapper(groups)
+	}
+
+	diffs := make([]ComponentDiff, 0, len(componentManifests))
+	for _, m := range componentManifests {
+		diff := ComponentDiff{Component: m.component}
+		manifest := []byte(m.manifest)
+		if m.url != nil {
+			var err error
+			diff.Source = m.url()
+			manifest, err = fetchAddonManifest(ctx, clientset, diff.Source)
+			if err != nil {
+				diff.Err = fmt.Errorf("failed to download the %s manifest: %w", m.component, err)
+				diffs = append(diffs, diff)
+				continue
+			}
+		}
+		if discoveryErr != nil {
+			diff.Err = fmt.Errorf("failed to discover API resources: %w", discoveryErr)
+		} else {
+			diff.Results, diff.Err = kube.ApplyManifestWithOptions(ctx, manifest, m.namespace, mapper, dynamicClient, kube.ApplyOptions{DryRun: true, Diff: true})
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
@@ -0,0 +1,60 @@
+package deployer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDiffComponents(t *testing.T) {
+	t.Setenv(ManifestsDirEnv, "")
+	t.Setenv(RegistryMirrorsEnv, "")
+	dir := t.TempDir()
+	ManifestsDir = dir
+	t.Cleanup(func() { ManifestsDir = "" })
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: kyverno-config\ndata:\n  key: value\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "install.yaml"), []byte(manifest), 0o600))
+
+	clientset := fake.NewClientset()
+	clientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: metav1.Verbs{"create", "get", "update"}}},
+	}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+
+	diffs := DiffComponents(context.Background(), clientset, dynamicClient)
+	require.Len(t, diffs, len(componentManifests))
+
+	kyverno := diffs[0]
+	assert.Equal(t, "kyverno", kyverno.Component)
+	assert.Equal(t, kyvernoInstallURL(), kyverno.Source)
+	require.NoError(t, kyverno.Err)
+	require.Len(t, kyverno.Results, 1)
+	assert.Equal(t, kube.ApplyCreated, kyverno.Results[0].Action)
+	assert.Equal(t, kyvernoNamespace, kyverno.Results[0].Namespace)
+	assert.Contains(t, kyverno.Results[0].Diff, "+  key: value")
+
+	// The other manifests are missing from the directory: reported, not fatal
+	localPath := diffs[1]
+	assert.Equal(t, "local-path-provisioner", localPath.Component)
+	assert.ErrorContains(t, localPath.Err, "local-path-storage.yaml")
+
+	// The inline ClusterIssuer needs the cert-manager CRDs
+	ca := diffs[len(diffs)-1]
+	assert.Equal(t, "kubeasy-ca", ca.Component)
+	assert.Empty(t, ca.Source)
+	require.Len(t, ca.Results, 1)
+	assert.Equal(t, kube.ApplySkipped, ca.Results[0].Action)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -18,7 +20,9 @@ func ptr[T any](v T) *T { return &v }
 // CreateProbePod creates the kubeasy-probe pod in the given namespace.
 // If a stale probe pod already exists it is deleted before the new pod is created.
 // The pod runs curlimages/curl:VERSION with RestartPolicy:Never and minimal resource requests
-// so it can be used as a connectivity probe from within the cluster.
+// so it can be used as a connectivity probe from within the cluster. The image goes
+// through the registry mirrors of RegistryMirrorsEnv, and the pod is scheduled on nodes
+// of the cluster's architecture when it is known.
 func CreateProbePod(ctx context.Context, clientset kubernetes.Interface, namespace string) (*corev1.Pod, error) {
 	// Delete any stale pod first (ignore error — it might not exist).
 	_ = deleteProbePodWithCtx(ctx, clientset, namespace)
@@ -30,13 +34,16 @@ func CreateProbePod(ctx context.Context, clientset kubernetes.Interface, namespa
 			return apierrors.IsNotFound(err), nil
 		})
 
+	// The image is multi-arch upstream; a mirror may not be, so pin the architecture
+	image, arch := resolveProbeImage(ctx, clientset)
+
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ProbePodName,
 			Namespace: namespace,
 			Labels: map[string]string{
-				"app":        "kubeasy-probe",
-				"managed-by": "kubeasy",
+				kube.ProbePodLabelKey: kube.ProbePodLabelValue,
+				"managed-by":          "kubeasy",
 			},
 		},
 		Spec: corev1.PodSpec{
@@ -44,7 +51,7 @@ func CreateProbePod(ctx context.Context, clientset kubernetes.Interface, namespa
 			Containers: []corev1.Container{
 				{
 					Name:            "curl",
-					Image:           probePodImage(),
+					Image:           image,
 					Command:         []string{"sleep", "infinity"},
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Resources: corev1.ResourceRequirements{
@@ -58,20 +65,41 @@ func CreateProbePod(ctx context.Context, clientset kubernetes.Interface, namespa
 		},
 	}
 
+	if arch != "" {
+		pod.Spec.NodeSelector = map[string]string{corev1.LabelArchStable: arch}
+	}
+
 	return clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
 }
 
+// ProbeImage returns the probe pod image CreateProbePod uses in the cluster.
+func ProbeImage(ctx context.Context, clientset kubernetes.Interface) string {
+	image, _ := resolveProbeImage(ctx, clientset)
+	return image
+}
+
+// resolveProbeImage returns the probe pod image through the registry mirrors, and the
+// architecture of the nodes ("" if unknown).
+func resolveProbeImage(ctx context.Context, clientset kubernetes.Interface) (string, string) {
+	image := probePodImage()
+	arch, err := NodeArchitecture(ctx, clientset)
+	if err != nil {
+		logger.Debug("Could not detect node architecture: %v", err)
+	}
+	if mirrors := registryMirrorsFromEnv(); len(mirrors) > 0 {
+		image = mirrors.Resolve(image, arch)
+	}
+	return image, arch
+}
+
 // DeleteProbePod deletes the kubeasy-probe pod from the given namespace.
 // Returns nil if the pod does not exist (idempotent).
 //
-// PROBE-03 contract: uses an independent context.Background()+10s timeout internally
-// rather than the caller's context, to guarantee cleanup even when the caller context
-// has been cancelled (e.g., during error teardown or test cleanup).
-// The context.Context parameter is accepted for API consistency but deliberately ignored.
-//
-//nolint:revive // context is intentionally discarded — see PROBE-03 contract above
-func DeleteProbePod(_ context.Context, clientset kubernetes.Interface, namespace string) error {
-	cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// PROBE-03 contract: the caller's cancellation is ignored (context.WithoutCancel) and an
+// independent 10s timeout is used instead, to guarantee cleanup even when the caller
+// context has been cancelled (e.g., during error teardown, Ctrl-C or test cleanup).
+func DeleteProbePod(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
 	defer cancel()
 	return deleteProbePodWithCtx(cleanupCtx, clientset, namespace)
 }
@@ -45,6 +45,22 @@ func TestCreateProbePod(t *testing.T) {
 	assert.Equal(t, "curl", pod.Spec.Containers[0].Name)
 }
 
+// TestCreateProbePod_MirrorAndArchitecture verifies that the probe image goes through
+// the configured mirror and the pod is pinned to the nodes' architecture.
+func TestCreateProbePod_MirrorAndArchitecture(t *testing.T) {
+	t.Setenv(RegistryMirrorsEnv, "docker.io=mirror.example.com/{arch}")
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubeasy-control-plane"},
+		Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{Architecture: "arm64"}},
+	}
+	clientset := fake.NewClientset(node)
+
+	pod, err := CreateProbePod(context.Background(), clientset, testNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, "mirror.example.com/arm64/curlimages/curl:"+ProbePodImageVersion, pod.Spec.Containers[0].Image)
+	assert.Equal(t, map[string]string{corev1.LabelArchStable: "arm64"}, pod.Spec.NodeSelector)
+}
+
 // TestCreateProbePod_StaleExists verifies that CreateProbePod deletes a stale pod
 // before creating a fresh one.
 func TestCreateProbePod_StaleExists(t *testing.T) {
@@ -14,6 +14,8 @@ import (
 	"strings"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -28,10 +30,16 @@ func FetchManifestHash(ctx context.Context, slug string) (string, error) {
 
 // DeployChallengeFromRegistry fetches challenge manifests from the API and applies them.
 // Returns the content hash of the tar.gz for change detection.
-func DeployChallengeFromRegistry(ctx context.Context, clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, slug string) (string, error) {
+func DeployChallengeFromRegistry(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, slug string) (string, error) {
 	logger.Info("Fetching manifests for '%s'...", slug)
 
-	data, hash, err := fetchManifestsTarGz(ctx, slug)
+	var data []byte
+	var hash string
+	err := runPhase(ctx, PhaseFetch, deployBudgetsFrom(ctx).Fetch, func(ctx context.Context) error {
+		var err error
+		data, hash, err = fetchManifestsTarGz(ctx, slug)
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
@@ -46,24 +54,48 @@ func DeployChallengeFromRegistry(ctx context.Context, clientset *kubernetes.Clie
 		return "", fmt.Errorf("failed to extract manifests: %w", err)
 	}
 
-	groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
-	if err != nil {
-		return "", fmt.Errorf("failed to discover API resources: %w", err)
-	}
-	mapper := restmapper.NewDiscoveryRESTMapper(groups)
-
-	if err := applyManifestDirs(ctx, tmpDir, slug, mapper, dynamicClient); err != nil {
+	if err := applyChallengeDir(ctx, clientset, dynamicClient, tmpDir, slug); err != nil {
 		return "", err
 	}
+	return hash, nil
+}
 
-	logger.Info("Waiting for challenge resources to be ready...")
-	if err := WaitForChallengeReady(ctx, clientset, slug); err != nil {
-		return "", fmt.Errorf("challenge resources failed to become ready: %w", err)
+// applyChallengeDir applies the manifests/ and policies/ of dir in the namespace of
+// the challenge and waits for its resources to be ready, each within its budget.
+func applyChallengeDir(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, dir, slug string) error {
+	budgets := deployBudgetsFrom(ctx)
+	err := runPhase(ctx, PhaseApply, budgets.Apply, func(ctx context.Context) error {
+		groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
+		if err != nil {
+			return fmt.Errorf("failed to discover API resources: %w", err)
+		}
+		mapper := restmapper.NewDiscoveryRESTMapper(groups)
+
+		results, err := applyManifestDirs(ctx, dir, slug, mapper, dynamicClient, kube.ApplyOptions{})
+		if err != nil {
+			return err
+		}
+		logger.Info("Challenge manifests applied (%s).", kube.SummarizeApply(results))
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return hash, nil
+	logger.Info("Waiting for challenge resources to be ready...")
+	return runPhase(ctx, PhaseReady, budgets.Ready, func(ctx context.Context) error {
+		if err := WaitForChallengeReady(ctx, clientset, slug); err != nil {
+			return fmt.Errorf("challenge resources failed to become ready: %w", err)
+		}
+		return nil
+	})
 }
 
+// ErrManifestsUnavailable is wrapped by the errors of a deploy whose manifests the
+// API could not serve: it was unreachable or failed with a server error.
+// DeployChallengeFromRepo does not depend on it.
+var ErrManifestsUnavailable = kerrors.New(kerrors.CodeManifestsUnavailable, "challenge manifests unavailable")
+
 func fetchManifestsTarGz(ctx context.Context, slug string) ([]byte, string, error) {
 	client, err := api.NewPublicClient()
 	if err != nil {
@@ -72,9 +104,15 @@ func fetchManifestsTarGz(ctx context.Context, slug string) ([]byte, string, erro
 
 	resp, err := client.GetChallengeManifestsWithResponse(ctx, slug)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to reach API: %w", err)
+		if ctx.Err() != nil {
+			return nil, "", fmt.Errorf("failed to reach API: %w", err)
+		}
+		return nil, "", fmt.Errorf("%w: failed to reach API: %w", ErrManifestsUnavailable, err)
 	}
 
+	if resp.StatusCode() >= 500 {
+		return nil, "", fmt.Errorf("%w: API returned HTTP %d for challenge %q", ErrManifestsUnavailable, resp.StatusCode(), slug)
+	}
 	if resp.StatusCode() != 200 {
 		return nil, "", fmt.Errorf("API returned HTTP %d for challenge %q", resp.StatusCode(), slug)
 	}
@@ -0,0 +1,136 @@
+package deployer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxRepoArchiveSize bounds the download of the challenges repository archive.
+const maxRepoArchiveSize = 256 << 20
+
+// DeployChallengeFromRepo fetches the challenge manifests from the challenges
+// repository archive (ChallengesRepoArchiveURL) instead of the API and applies them,
+// so a challenge can be started while the API cannot serve its manifests. Returns a
+// hash of the applied files for change detection; it differs from the hash of
+// DeployChallengeFromRegistry for the same manifests.
+func DeployChallengeFromRepo(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, slug string) (string, error) {
+	logger.Info("Fetching manifests for '%s' from the challenges repository...", slug)
+
+	tmpDir, err := os.MkdirTemp("", "kubeasy-repo-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	var hash string
+	err = runPhase(ctx, PhaseFetch, deployBudgetsFrom(ctx).Fetch, func(ctx context.Context) error {
+		var err error
+		hash, err = fetchRepoChallenge(ctx, ChallengesRepoArchiveURL, slug, tmpDir)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := applyChallengeDir(ctx, clientset, dynamicClient, tmpDir, slug); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// fetchRepoChallenge downloads the repository archive at url and extracts the
+// manifests/ and policies/ of the challenge slug into destDir.
+func fetchRepoChallenge(ctx context.Context, url, slug, destDir string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download the challenges repository: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("challenges repository returned HTTP %d", resp.StatusCode)
+	}
+
+	hash, found, err := extractRepoChallenge(io.LimitReader(resp.Body, maxRepoArchiveSize), slug, destDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract manifests: %w", err)
+	}
+	if !found {
+		return "", fmt.Errorf("challenge %q not found in the challenges repository", slug)
+	}
+	return hash, nil
+}
+
+// extractRepoChallenge extracts <root>/<slug>/manifests and <root>/<slug>/policies of
+// a repository archive into destDir, without the <root>/<slug> prefix. It reports
+// whether any file was extracted and returns a hash of their names and contents.
+func extractRepoChallenge(r io.Reader, slug, destDir string) (string, bool, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer func() { _ = gr.Close() }()
+
+	tr := tar.NewReader(gr)
+	cleanDest := filepath.Clean(destDir) + string(os.PathSeparator)
+	sum := sha256.New()
+	found := false
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read tar entry: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// <root>/<slug>/<manifests|policies>/...
+		parts := strings.SplitN(path.Clean(header.Name), "/", 4)
+		if len(parts) < 4 || parts[1] != slug || (parts[2] != "manifests" && parts[2] != "policies") {
+			continue
+		}
+		name := parts[2] + "/" + parts[3]
+
+		target := filepath.Join(destDir, filepath.Clean("/"+name))
+		if !strings.HasPrefix(target, cleanDest) {
+			return "", false, fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return "", false, fmt.Errorf("failed to create directory: %w", err)
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to create file: %w", err)
+		}
+		_, _ = io.WriteString(sum, name+"\x00")
+		if _, err := io.Copy(io.MultiWriter(f, sum), tr); err != nil { //nolint:gosec
+			f.Close()
+			return "", false, fmt.Errorf("failed to write file: %w", err)
+		}
+		f.Close()
+		found = true
+	}
+
+	return hex.EncodeToString(sum.Sum(nil)), found, nil
+}
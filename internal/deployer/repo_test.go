@@ -0,0 +1,76 @@
+package deployer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoArchive returns a tar.gz of files, like the archives GitHub serves.
+func repoArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestFetchRepoChallenge(t *testing.T) {
+	archive := repoArchive(t, map[string]string{
+		"challenges-main/pod-evicted/challenge.yaml":         "title: Pod Evicted\n",
+		"challenges-main/pod-evicted/manifests/pod.yaml":     "kind: Pod\n",
+		"challenges-main/pod-evicted/policies/deny.yaml":     "kind: ClusterPolicy\n",
+		"challenges-main/pod-evicted/solution/fix.yaml":      "kind: Pod\n",
+		"challenges-main/other/manifests/deployment.yaml":    "kind: Deployment\n",
+		"challenges-main/pod-evicted-two/manifests/pod.yaml": "kind: Pod\n",
+		"challenges-main/README.md":                          "# Challenges\n",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	hash, err := fetchRepoChallenge(context.Background(), server.URL, "pod-evicted", dir)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	var files []string
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	}))
+	assert.ElementsMatch(t, []string{"manifests/pod.yaml", "policies/deny.yaml"}, files, "only the manifests and policies of the challenge are extracted")
+
+	_, err = fetchRepoChallenge(context.Background(), server.URL, "missing", t.TempDir())
+	assert.ErrorContains(t, err, `challenge "missing" not found`)
+}
+
+func TestFetchRepoChallenge_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := fetchRepoChallenge(context.Background(), server.URL, "pod-evicted", t.TempDir())
+	assert.ErrorContains(t, err, "HTTP 502")
+}
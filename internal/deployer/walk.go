@@ -14,14 +14,17 @@ import (
 )
 
 // applyManifestDirs walks the "manifests" and "policies" subdirectories of baseDir
-// and applies every .yaml/.yml file to the cluster namespace.
+// and applies every .yaml/.yml file to the cluster namespace. It returns the result of
+// every applied document, including those applied before a failure.
 func applyManifestDirs(
 	ctx context.Context,
 	baseDir string,
 	namespace string,
 	mapper meta.RESTMapper,
 	dynamicClient dynamic.Interface,
-) error {
+	opts kube.ApplyOptions,
+) ([]kube.ApplyResult, error) {
+	var results []kube.ApplyResult
 	dirs := []string{"manifests", "policies"}
 	for _, dir := range dirs {
 		dirPath := filepath.Join(baseDir, dir)
@@ -30,29 +33,61 @@ func applyManifestDirs(
 			continue
 		}
 
-		var files []string
-		if err := filepath.WalkDir(dirPath, func(path string, d os.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-			if !d.IsDir() && (strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
-				files = append(files, path)
-			}
-			return nil
-		}); err != nil {
-			return fmt.Errorf("failed to walk %s: %w", dir, err)
+		dirResults, err := applyYAMLDir(ctx, dirPath, namespace, mapper, dynamicClient, opts)
+		results = append(results, dirResults...)
+		if err != nil {
+			return results, err
 		}
+	}
+	return results, nil
+}
+
+// applyYAMLDir applies every .yaml/.yml file found under dirPath, recursively.
+func applyYAMLDir(
+	ctx context.Context,
+	dirPath string,
+	namespace string,
+	mapper meta.RESTMapper,
+	dynamicClient dynamic.Interface,
+	opts kube.ApplyOptions,
+) ([]kube.ApplyResult, error) {
+	files, err := listYAMLFiles(dirPath)
+	if err != nil {
+		return nil, err
+	}
 
-		for _, f := range files {
-			logger.Debug("Applying manifest: %s", f)
-			data, err := os.ReadFile(f)
-			if err != nil {
-				return fmt.Errorf("failed to read manifest %s: %w", f, err)
-			}
-			if err := kube.ApplyManifest(ctx, data, namespace, mapper, dynamicClient); err != nil {
-				return fmt.Errorf("failed to apply manifest %s: %w", filepath.Base(f), err)
-			}
+	var results []kube.ApplyResult
+	for _, f := range files {
+		logger.Debug("Applying manifest: %s", f)
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return results, fmt.Errorf("failed to read manifest %s: %w", f, err)
+		}
+		fileResults, err := kube.ApplyManifestWithOptions(ctx, data, namespace, mapper, dynamicClient, opts)
+		for i := range fileResults {
+			fileResults[i].Source = f
+		}
+		results = append(results, fileResults...)
+		if err != nil {
+			return results, fmt.Errorf("failed to apply manifest %s: %w", filepath.Base(f), err)
+		}
+	}
+	return results, nil
+}
+
+// listYAMLFiles returns the .yaml/.yml files under dirPath in lexical order.
+func listYAMLFiles(dirPath string) ([]string, error) {
+	var files []string
+	if err := filepath.WalkDir(dirPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && (strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
+			files = append(files, path)
 		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", filepath.Base(dirPath), err)
 	}
-	return nil
+	return files, nil
 }
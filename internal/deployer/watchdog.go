@@ -0,0 +1,191 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// watchdogRepairTimeout bounds the wait for repaired components to be ready again.
+const watchdogRepairTimeout = 2 * time.Minute
+
+// Repair is a fix HealPlatform applied to a platform component.
+type Repair struct {
+	Component string
+	Action    string
+}
+
+// watchedComponent is a platform component the challenges depend on.
+type watchedComponent struct {
+	name        string
+	namespace   string
+	deployments []string
+	install     func(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, mapper meta.RESTMapper) ComponentResult
+}
+
+// watchedComponents are checked by HealPlatform: policies are enforced by Kyverno and
+// volumes are provisioned by local-path-provisioner.
+var watchedComponents = []watchedComponent{
+	{
+		name:      "kyverno",
+		namespace: kyvernoNamespace,
+		deployments: []string{
+			"kyverno-admission-controller",
+			"kyverno-background-controller",
+			"kyverno-cleanup-controller",
+			"kyverno-reports-controller",
+		},
+		install: installKyverno,
+	},
+	{
+		name:        "local-path-provisioner",
+		namespace:   localPathStorageNamespace,
+		deployments: []string{"local-path-provisioner"},
+		install:     installLocalPathProvisioner,
+	},
+}
+
+// waitForRepair waits for the deployments of a repaired component; tests replace it.
+var waitForRepair = kube.WaitForDeploymentsReady
+
+// HealPlatform checks the platform components installed by 'kubeasy setup' and
+// repairs the common ways they break: a missing component is reinstalled, a
+// deployment scaled to zero is scaled back up and crash-looping pods are restarted.
+// Healthy components cost a few reads. It returns the repairs made, and an error
+// for those that failed or did not bring the component back.
+func HealPlatform(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface) ([]Repair, error) {
+	var repairs []Repair
+	var errs []error
+	for _, c := range watchedComponents {
+		componentRepairs, err := healComponent(ctx, clientset, dynamicClient, c)
+		repairs = append(repairs, componentRepairs...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+		}
+	}
+	return repairs, errors.Join(errs...)
+}
+
+func healComponent(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, c watchedComponent) ([]Repair, error) {
+	missing, err := componentMissing(ctx, clientset, c)
+	if err != nil {
+		return nil, err
+	}
+	if missing {
+		logger.Info("Watchdog: %s is missing, reinstalling it", c.name)
+		groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover API resources: %w", err)
+		}
+		result := c.install(ctx, clientset, dynamicClient, restmapper.NewDiscoveryRESTMapper(groups))
+		if result.Status != StatusReady {
+			return nil, fmt.Errorf("reinstall failed: %s", result.Message)
+		}
+		return []Repair{{Component: c.name, Action: "reinstalled"}}, nil
+	}
+
+	var repairs []Repair
+	for _, name := range c.deployments {
+		dep, err := clientset.AppsV1().Deployments(c.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return repairs, fmt.Errorf("error checking deployment '%s': %w", name, err)
+		}
+		if dep.Spec.Replicas != nil && *dep.Spec.Replicas == 0 {
+			patch := []byte(`{"spec":{"replicas":1}}`)
+			if _, err := clientset.AppsV1().Deployments(c.namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+				return repairs, fmt.Errorf("failed to scale up deployment '%s': %w", name, err)
+			}
+			logger.Info("Watchdog: scaled %s/%s back to 1 replica", c.namespace, name)
+			repairs = append(repairs, Repair{Component: c.name, Action: fmt.Sprintf("scaled %s back to 1 replica", name)})
+			continue
+		}
+		if dep.Status.ReadyReplicas > 0 && dep.Status.ReadyReplicas == dep.Status.Replicas {
+			continue
+		}
+		restarted, err := restartCrashLoopingPods(ctx, clientset, c.namespace, dep.Spec.Selector)
+		if err != nil {
+			return repairs, fmt.Errorf("failed to restart pods of deployment '%s': %w", name, err)
+		}
+		for _, pod := range restarted {
+			logger.Info("Watchdog: restarted crash-looping pod %s/%s", c.namespace, pod)
+			repairs = append(repairs, Repair{Component: c.name, Action: fmt.Sprintf("restarted crash-looping pod %s", pod)})
+		}
+	}
+	if len(repairs) == 0 {
+		return nil, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, watchdogRepairTimeout)
+	defer cancel()
+	if err := waitForRepair(waitCtx, clientset, c.namespace, c.deployments); err != nil {
+		return repairs, fmt.Errorf("still not ready after repair: %w", err)
+	}
+	return repairs, nil
+}
+
+// componentMissing reports whether the namespace or a deployment of c is missing.
+func componentMissing(ctx context.Context, clientset kubernetes.Interface, c watchedComponent) (bool, error) {
+	if _, err := clientset.CoreV1().Namespaces().Get(ctx, c.namespace, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("error checking namespace '%s': %w", c.namespace, err)
+	}
+	for _, name := range c.deployments {
+		if _, err := clientset.AppsV1().Deployments(c.namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("error checking deployment '%s': %w", name, err)
+		}
+	}
+	return false, nil
+}
+
+// restartCrashLoopingPods deletes the pods matching selector with a container in
+// CrashLoopBackOff, so their controller recreates them, and returns their names.
+func restartCrashLoopingPods(ctx context.Context, clientset kubernetes.Interface, namespace string, selector *metav1.LabelSelector) ([]string, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return nil, err
+	}
+	var restarted []string
+	for _, pod := range pods.Items {
+		if !crashLooping(pod) {
+			continue
+		}
+		if err := clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return restarted, err
+		}
+		restarted = append(restarted, pod.Name)
+	}
+	return restarted, nil
+}
+
+func crashLooping(pod corev1.Pod) bool {
+	for _, cs := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
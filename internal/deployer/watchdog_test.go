@@ -0,0 +1,122 @@
+package deployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// platformObjects returns healthy watched components.
+func platformObjects() []runtime.Object {
+	var objects []runtime.Object
+	for _, c := range watchedComponents {
+		objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: c.namespace}})
+		for _, name := range c.deployments {
+			one := int32(1)
+			objects = append(objects, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: c.namespace},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &one,
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+				},
+				Status: appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1},
+			})
+		}
+	}
+	return objects
+}
+
+func stubRepairWait(t *testing.T) *[]string {
+	t.Helper()
+	var waited []string
+	saved := waitForRepair
+	waitForRepair = func(_ context.Context, _ kubernetes.Interface, namespace string, _ []string) error {
+		waited = append(waited, namespace)
+		return nil
+	}
+	t.Cleanup(func() { waitForRepair = saved })
+	return &waited
+}
+
+func TestHealPlatform_Healthy(t *testing.T) {
+	waited := stubRepairWait(t)
+	repairs, err := HealPlatform(context.Background(), fake.NewClientset(platformObjects()...), nil)
+	require.NoError(t, err)
+	assert.Empty(t, repairs)
+	assert.Empty(t, *waited, "healthy components are not waited for")
+}
+
+func TestHealPlatform_Repairs(t *testing.T) {
+	ctx := context.Background()
+	waited := stubRepairWait(t)
+	clientset := fake.NewClientset(platformObjects()...)
+
+	zero := int32(0)
+	scaled, err := clientset.AppsV1().Deployments(kyvernoNamespace).Get(ctx, "kyverno-cleanup-controller", metav1.GetOptions{})
+	require.NoError(t, err)
+	scaled.Spec.Replicas, scaled.Status = &zero, appsv1.DeploymentStatus{}
+	_, err = clientset.AppsV1().Deployments(kyvernoNamespace).Update(ctx, scaled, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	crashing, err := clientset.AppsV1().Deployments(localPathStorageNamespace).Get(ctx, "local-path-provisioner", metav1.GetOptions{})
+	require.NoError(t, err)
+	crashing.Status.ReadyReplicas = 0
+	_, err = clientset.AppsV1().Deployments(localPathStorageNamespace).Update(ctx, crashing, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	for name, reason := range map[string]string{"provisioner-a": "CrashLoopBackOff", "provisioner-b": "ContainerCreating"} {
+		_, err := clientset.CoreV1().Pods(localPathStorageNamespace).Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: localPathStorageNamespace, Labels: map[string]string{"app": "local-path-provisioner"}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "provisioner", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+			}}},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	repairs, err := HealPlatform(ctx, clientset, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Repair{
+		{Component: "kyverno", Action: "scaled kyverno-cleanup-controller back to 1 replica"},
+		{Component: "local-path-provisioner", Action: "restarted crash-looping pod provisioner-a"},
+	}, repairs)
+	assert.Equal(t, []string{kyvernoNamespace, localPathStorageNamespace}, *waited)
+
+	scaled, err = clientset.AppsV1().Deployments(kyvernoNamespace).Get(ctx, "kyverno-cleanup-controller", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *scaled.Spec.Replicas)
+	pods, err := clientset.CoreV1().Pods(localPathStorageNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1, "only the crash-looping pod is deleted")
+	assert.Equal(t, "provisioner-b", pods.Items[0].Name)
+}
+
+func TestHealPlatform_ReinstallsMissingComponent(t *testing.T) {
+	stubRepairWait(t)
+	var installed []string
+	saved := watchedComponents
+	t.Cleanup(func() { watchedComponents = saved })
+	watchedComponents = append([]watchedComponent(nil), saved...)
+	for i := range watchedComponents {
+		name := watchedComponents[i].name
+		watchedComponents[i].install = func(context.Context, kubernetes.Interface, dynamic.Interface, meta.RESTMapper) ComponentResult {
+			installed = append(installed, name)
+			return ComponentResult{Name: name, Status: StatusReady}
+		}
+	}
+
+	objects := platformObjects()
+	repairs, err := HealPlatform(context.Background(), fake.NewClientset(objects[len(watchedComponents[0].deployments)+1:]...), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Repair{{Component: "kyverno", Action: "reinstalled"}}, repairs)
+	assert.Equal(t, []string{"kyverno"}, installed)
+}
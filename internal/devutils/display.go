@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/ui"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
 )
@@ -17,8 +18,13 @@ func formatDuration(d interface{ Milliseconds() int64 }) string {
 	return fmt.Sprintf("%.1fs", float64(ms)/1000)
 }
 
-// DisplayValidationResults renders validation results grouped by type and returns whether all passed.
+// DisplayValidationResults renders validation results grouped by phase, or by type when
+// the challenge has no phases, and returns whether all passed.
 func DisplayValidationResults(validations []validation.Validation, results []validation.Result) bool {
+	if phases := validation.GroupByPhase(validations); phases != nil {
+		return displayPhaseResults(phases, results)
+	}
+
 	allPassed := true
 
 	// Group validations by type for display
@@ -57,7 +63,11 @@ func DisplayValidationResults(validations []validation.Validation, results []val
 			if r.Duration > 0 {
 				detail = fmt.Sprintf("%s (%s)", r.Message, formatDuration(r.Duration))
 			}
-			ui.ValidationResult(r.Key, r.Passed, []string{detail})
+			if r.Skipped {
+				ui.ValidationSkipped(r.Key, detail)
+			} else {
+				ui.ValidationResult(r.Key, r.Passed, []string{detail})
+			}
 			if !r.Passed {
 				allPassed = false
 			}
@@ -76,7 +86,11 @@ func DisplayValidationResults(validations []validation.Validation, results []val
 			if r.Duration > 0 {
 				detail = fmt.Sprintf("%s (%s)", r.Message, formatDuration(r.Duration))
 			}
-			ui.ValidationResult(r.Key, r.Passed, []string{detail})
+			if r.Skipped {
+				ui.ValidationSkipped(r.Key, detail)
+			} else {
+				ui.ValidationResult(r.Key, r.Passed, []string{detail})
+			}
 			if !r.Passed {
 				allPassed = false
 			}
@@ -86,3 +100,65 @@ func DisplayValidationResults(validations []validation.Validation, results []val
 
 	return allPassed
 }
+
+// displayPhaseResults renders results phase by phase, each followed by a summary.
+// Results missing after a fail-fast stop count as failed.
+func displayPhaseResults(phases []validation.Phase, results []validation.Result) bool {
+	allPassed := true
+	for _, phase := range phases {
+		ui.Section(phase.Name)
+		passed := 0
+		for _, i := range phase.Indices {
+			if i >= len(results) {
+				continue
+			}
+			r := results[i]
+			detail := r.Message
+			if r.Duration > 0 {
+				detail = fmt.Sprintf("%s (%s)", r.Message, formatDuration(r.Duration))
+			}
+			if r.Skipped {
+				ui.ValidationSkipped(r.Key, detail)
+			} else {
+				ui.ValidationResult(r.Key, r.Passed, []string{detail})
+			}
+			if r.Passed {
+				passed++
+			}
+		}
+		ui.PhaseSummary(phase.Name, passed, len(phase.Indices))
+		if passed < len(phase.Indices) {
+			allPassed = false
+		}
+		ui.Println()
+	}
+	return allPassed
+}
+
+// DisplaySummary renders the counts of a validation run, with a per-type breakdown
+// when it covers several validation types.
+func DisplaySummary(summary validation.Summary) {
+	ui.Section("Summary")
+	line := fmt.Sprintf("%d objectives: %d passed, %d failed", summary.Total, summary.Passed, summary.Failed)
+	if summary.Skipped > 0 {
+		line += fmt.Sprintf(", %d skipped", summary.Skipped)
+	}
+	ui.Text(fmt.Sprintf("%s in %s", line, formatDuration(summary.Duration)))
+	if len(summary.ByType) < 2 {
+		return
+	}
+
+	rows := make([][]string, 0, len(summary.ByType))
+	for _, t := range summary.ByType {
+		rows = append(rows, []string{
+			string(t.Type),
+			fmt.Sprintf("%d/%d", t.Passed, t.Total),
+			fmt.Sprint(t.Failed),
+			fmt.Sprint(t.Skipped),
+			formatDuration(t.Duration),
+		})
+	}
+	if err := ui.Table([]string{"Type", "Passed", "Failed", "Skipped", "Time"}, rows); err != nil {
+		logger.Debug("Failed to render summary table: %v", err)
+	}
+}
@@ -73,3 +73,25 @@ func TestDisplayValidationResults(t *testing.T) {
 		assert.True(t, allPassed)
 	})
 }
+
+func TestDisplayValidationResults_Phases(t *testing.T) {
+	validations := []validation.Validation{
+		{Key: "find-cause", Type: validation.TypeLog, Phase: "Investigate"},
+		{Key: "pod-ready", Type: validation.TypeCondition, Phase: "Fix"},
+		{Key: "stable", Type: validation.TypeStatus, Phase: "Verify"},
+	}
+
+	allPassed := DisplayValidationResults(validations, []validation.Result{
+		{Key: "find-cause", Passed: true},
+		{Key: "pod-ready", Passed: true},
+		{Key: "stable", Passed: true},
+	})
+	assert.True(t, allPassed)
+
+	// A phase whose results are missing after a fail-fast stop fails
+	allPassed = DisplayValidationResults(validations, []validation.Result{
+		{Key: "find-cause", Passed: true},
+		{Key: "pod-ready", Passed: true},
+	})
+	assert.False(t, allPassed)
+}
@@ -13,55 +13,76 @@ type JSONValidationOutput struct {
 	Total     int               `json:"total"`
 	Passed    int               `json:"passed"`
 	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
 	Duration  string            `json:"duration"`
+	ByType    []JSONTypeSummary `json:"byType"`
 	Results   []JSONResultEntry `json:"results"`
 }
 
+// JSONTypeSummary holds the counts of one validation type in JSON output.
+type JSONTypeSummary struct {
+	Type     string `json:"type"`
+	Total    int    `json:"total"`
+	Passed   int    `json:"passed"`
+	Failed   int    `json:"failed"`
+	Skipped  int    `json:"skipped"`
+	Duration string `json:"duration"`
+}
+
 // JSONResultEntry is a single validation result in JSON output.
 type JSONResultEntry struct {
 	Key      string `json:"key"`
 	Type     string `json:"type"`
 	Title    string `json:"title"`
+	Phase    string `json:"phase,omitempty"`
 	Passed   bool   `json:"passed"`
+	Skipped  bool   `json:"skipped,omitempty"`
 	Message  string `json:"message"`
 	Duration string `json:"duration"`
 }
 
 // FormatValidationJSON builds a JSONValidationOutput from validations and results.
+// Validations left without a result by a fail-fast stop count as failed.
 func FormatValidationJSON(slug string, validations []validation.Validation, results []validation.Result, totalDuration time.Duration) JSONValidationOutput {
+	summary := validation.Summarize(validations, results, totalDuration)
 	out := JSONValidationOutput{
 		Slug:      slug,
-		AllPassed: true,
-		Total:     len(validations),
+		AllPassed: summary.AllPassed(),
+		Total:     summary.Total,
+		Passed:    summary.Passed,
+		Failed:    summary.Failed,
+		Skipped:   summary.Skipped,
 		Duration:  totalDuration.Round(time.Millisecond).String(),
+		ByType:    make([]JSONTypeSummary, 0, len(summary.ByType)),
 		Results:   make([]JSONResultEntry, 0, len(results)),
 	}
 
+	for _, t := range summary.ByType {
+		out.ByType = append(out.ByType, JSONTypeSummary{
+			Type:     string(t.Type),
+			Total:    t.Total,
+			Passed:   t.Passed,
+			Failed:   t.Failed,
+			Skipped:  t.Skipped,
+			Duration: t.Duration.Round(time.Millisecond).String(),
+		})
+	}
+
 	for i, r := range results {
 		entry := JSONResultEntry{
 			Key:      r.Key,
 			Passed:   r.Passed,
+			Skipped:  r.Skipped,
 			Message:  r.Message,
 			Duration: r.Duration.Round(time.Millisecond).String(),
 		}
 		if i < len(validations) {
 			entry.Type = string(validations[i].Type)
 			entry.Title = validations[i].Title
-		}
-		if r.Passed {
-			out.Passed++
-		} else {
-			out.Failed++
-			out.AllPassed = false
+			entry.Phase = validations[i].Phase
 		}
 		out.Results = append(out.Results, entry)
 	}
 
-	// If fail-fast stopped early, count remaining as failed
-	if len(results) < len(validations) {
-		out.Failed += len(validations) - len(results)
-		out.AllPassed = false
-	}
-
 	return out
 }
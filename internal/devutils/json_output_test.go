@@ -6,11 +6,12 @@ import (
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFormatValidationJSON_AllPassed(t *testing.T) {
 	validations := []validation.Validation{
-		{Key: "pod-ready", Title: "Pod Ready", Type: validation.TypeCondition},
+		{Key: "pod-ready", Title: "Pod Ready", Type: validation.TypeCondition, Phase: "Fix"},
 		{Key: "no-crashes", Title: "Stable Operation", Type: validation.TypeEvent},
 	}
 	results := []validation.Result{
@@ -28,6 +29,10 @@ func TestFormatValidationJSON_AllPassed(t *testing.T) {
 	assert.Len(t, out.Results, 2)
 	assert.Equal(t, "condition", out.Results[0].Type)
 	assert.Equal(t, "Pod Ready", out.Results[0].Title)
+	assert.Equal(t, "Fix", out.Results[0].Phase)
+	assert.Empty(t, out.Results[1].Phase)
+	require.Len(t, out.ByType, 2)
+	assert.Equal(t, JSONTypeSummary{Type: "condition", Total: 1, Passed: 1, Duration: "100ms"}, out.ByType[0])
 }
 
 func TestFormatValidationJSON_SomeFailed(t *testing.T) {
@@ -47,6 +52,25 @@ func TestFormatValidationJSON_SomeFailed(t *testing.T) {
 	assert.Equal(t, 1, out.Failed)
 }
 
+func TestFormatValidationJSON_Skipped(t *testing.T) {
+	validations := []validation.Validation{
+		{Key: "cpu-usage", Title: "CPU Usage", Type: validation.TypeSpec},
+		{Key: "pod-ready", Title: "Pod Ready", Type: validation.TypeCondition},
+	}
+	results := []validation.Result{
+		{Key: "cpu-usage", Skipped: true, Message: "Skipped: metrics-server is not installed"},
+		{Key: "pod-ready", Passed: true, Message: "OK"},
+	}
+
+	out := FormatValidationJSON("test", validations, results, time.Second)
+
+	assert.False(t, out.AllPassed)
+	assert.Equal(t, 1, out.Passed)
+	assert.Equal(t, 0, out.Failed)
+	assert.Equal(t, 1, out.Skipped)
+	assert.True(t, out.Results[0].Skipped)
+}
+
 func TestFormatValidationJSON_FailFastPartialResults(t *testing.T) {
 	validations := []validation.Validation{
 		{Key: "a", Title: "A", Type: validation.TypeCondition},
@@ -1,6 +1,7 @@
 package devutils
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -30,11 +31,15 @@ type LintIssue struct {
 
 // LintChallengeFile validates a challenge.yaml file structure without requiring a cluster.
 // Also checks that the manifests/ directory exists next to the file.
-func LintChallengeFile(path string) ([]LintIssue, error) {
+func LintChallengeFile(ctx context.Context, path string) ([]LintIssue, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
+	data, err = validation.ResolveIncludes(ctx, data, path)
+	if err != nil {
+		return nil, err
+	}
 	issues, err := LintChallengeData(data)
 	if err != nil {
 		return nil, err
@@ -48,7 +53,50 @@ func LintChallengeFile(path string) ([]LintIssue, error) {
 }
 
 // LintChallengeData validates challenge.yaml bytes without requiring a file on disk.
+// Each variant of a challenge with variants is linted; issues found only in a later
+// variant are prefixed with its name.
 func LintChallengeData(data []byte) ([]LintIssue, error) {
+	names, err := validation.VariantNames(data)
+	if err != nil {
+		return []LintIssue{{Field: "variants", Severity: SeverityError, Message: err.Error()}}, nil
+	}
+	if len(names) == 0 {
+		return lintChallengeVariant(data)
+	}
+
+	var issues []LintIssue
+	seen := map[LintIssue]bool{}
+	for i, name := range names {
+		variantData, err := validation.ApplyVariant(data, name)
+		if err != nil {
+			return nil, err
+		}
+		variantIssues, err := lintChallengeVariant(variantData)
+		if err != nil {
+			return nil, fmt.Errorf("variant %q: %w", name, err)
+		}
+		for _, issue := range variantIssues {
+			if seen[issue] {
+				continue
+			}
+			seen[issue] = true
+			if i > 0 {
+				issue.Message = fmt.Sprintf("variant %q: %s", name, issue.Message)
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// lintChallengeVariant lints challenge.yaml bytes without variants.
+func lintChallengeVariant(data []byte) ([]LintIssue, error) {
+	// Lint the file as the loader sees it, with template variables resolved.
+	data, err := validation.ExpandVars(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand vars: %w", err)
+	}
+
 	// Unmarshal into the canonical ChallengeYamlSpec struct — single source of truth.
 	var spec validation.ChallengeYamlSpec
 	if err := yaml.Unmarshal(data, &spec); err != nil {
@@ -89,6 +137,23 @@ func LintChallengeData(data []byte) ([]LintIssue, error) {
 		})
 	}
 
+	// Validate structure against the embedded JSON Schema (unknown fields, wrong types, enums).
+	violations, schemaErr := validation.ValidateAgainstSchema(data)
+	if schemaErr != nil {
+		return nil, schemaErr
+	}
+	for _, v := range violations {
+		// Top-level required fields, difficulty and type are already reported above.
+		if isTopLevelSchemaPath(v.Path) && !strings.HasPrefix(v.Message, "unknown field") && v.Path != "theme" {
+			continue
+		}
+		issues = append(issues, LintIssue{
+			Field:    v.Path,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("schema: %s", v.Message),
+		})
+	}
+
 	// Check objective keys are unique and orders are sequential.
 	if len(spec.Objectives) > 0 {
 		keys := make(map[string]bool)
@@ -132,6 +197,11 @@ func LintChallengeData(data []byte) ([]LintIssue, error) {
 	return issues, nil
 }
 
+// isTopLevelSchemaPath reports whether a schema violation path targets a top-level field.
+func isTopLevelSchemaPath(path string) bool {
+	return !strings.ContainsAny(path, ".[")
+}
+
 // validateRequiredFields inspects a ChallengeYamlSpec via reflection and returns
 // lint errors for any required field that is missing or zero-valued.
 // Required = no "omitempty" in yaml tag. Slice fields are skipped (validated elsewhere).
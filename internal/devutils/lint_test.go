@@ -1,8 +1,10 @@
 package devutils
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,7 +51,7 @@ objectives:
           status: "True"
 `)
 
-	issues, err := LintChallengeFile(path)
+	issues, err := LintChallengeFile(context.Background(), path)
 	require.NoError(t, err)
 
 	errors := filterBySeverity(issues, SeverityError)
@@ -63,7 +65,7 @@ title: ""
 estimatedTime: 0
 `)
 
-	issues, err := LintChallengeFile(path)
+	issues, err := LintChallengeFile(context.Background(), path)
 	require.NoError(t, err)
 
 	errors := filterBySeverity(issues, SeverityError)
@@ -92,7 +94,7 @@ objective: "obj"
 objectives: []
 `)
 
-	issues, err := LintChallengeFile(path)
+	issues, err := LintChallengeFile(context.Background(), path)
 	require.NoError(t, err)
 
 	found := false
@@ -119,7 +121,7 @@ objective: "obj"
 objectives: []
 `)
 
-	issues, err := LintChallengeFile(path)
+	issues, err := LintChallengeFile(context.Background(), path)
 	require.NoError(t, err)
 
 	found := false
@@ -173,7 +175,7 @@ objectives:
           status: "True"
 `)
 
-	issues, err := LintChallengeFile(path)
+	issues, err := LintChallengeFile(context.Background(), path)
 	require.NoError(t, err)
 
 	found := false
@@ -200,7 +202,7 @@ objective: "obj"
 objectives: []
 `)
 
-	issues, err := LintChallengeFile(path)
+	issues, err := LintChallengeFile(context.Background(), path)
 	require.NoError(t, err)
 
 	errors := filterBySeverity(issues, SeverityError)
@@ -223,3 +225,124 @@ func filterBySeverity(issues []LintIssue, severity LintSeverity) []LintIssue {
 	}
 	return result
 }
+
+func TestLintChallengeData_SchemaViolations(t *testing.T) {
+	issues, err := LintChallengeData([]byte(`
+title: "Test Challenge"
+type: "fix"
+theme: "not-a-theme"
+difficulty: "easy"
+estimatedTime: 15
+description: "Something is broken."
+initialSituation: "A pod is running."
+objectives:
+  - key: pod-ready
+    title: "Pod Ready"
+    order: 1
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        labelSelektor:
+          app: test
+      checks:
+        - type: Ready
+          status: "True"
+`))
+	require.NoError(t, err)
+
+	errors := filterBySeverity(issues, SeverityError)
+	fields := make(map[string]string)
+	for _, issue := range errors {
+		fields[issue.Field] = issue.Message
+	}
+	assert.Contains(t, fields["objectives[0].spec.target.labelSelektor"], "unknown field")
+	assert.Contains(t, fields["theme"], "invalid value")
+}
+
+func TestLintChallengeData_Vars(t *testing.T) {
+	challenge := `
+vars:
+  app: web
+title: "Test Challenge"
+type: "fix"
+theme: "networking"
+difficulty: "easy"
+estimatedTime: ${minutes:-15}
+description: "Something is broken."
+initialSituation: "A pod is running."
+objectives:
+  - key: pod-ready
+    title: "Pod Ready"
+    order: 1
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        labelSelector:
+          app: ${app}
+      checks:
+        - type: Ready
+          status: "True"
+`
+	issues, err := LintChallengeData([]byte(challenge))
+	require.NoError(t, err)
+	assert.Empty(t, filterBySeverity(issues, SeverityError))
+
+	_, err = LintChallengeData([]byte(strings.Replace(challenge, "${app}", "${ap}", 1)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `undefined variable "ap"`)
+}
+
+func TestLintChallengeData_Variants(t *testing.T) {
+	challenge := `
+title: "Test Challenge"
+type: "fix"
+theme: "networking"
+difficulty: "easy"
+estimatedTime: 15
+description: "Something is broken."
+initialSituation: "A pod is running."
+objectives: []
+variants:
+  - name: ready
+    objectives:
+      - key: pod-ready
+        title: "Pod Ready"
+        order: 1
+        type: condition
+        spec:
+          target:
+            kind: Pod
+            labelSelector:
+              app: web
+          checks:
+            - type: Ready
+              status: "True"
+  - name: scheduled
+    objectives:
+      - key: pod-ready
+        title: "Pod Ready"
+        order: 1
+        type: conditon
+        spec:
+          target:
+            kind: Pod
+          checks:
+            - type: Ready
+              status: "True"
+`
+	issues, err := LintChallengeData([]byte(challenge))
+	require.NoError(t, err)
+	errors := filterBySeverity(issues, SeverityError)
+	require.NotEmpty(t, errors)
+	for _, issue := range errors {
+		assert.Contains(t, issue.Message, `variant "scheduled": `)
+	}
+
+	issues, err = LintChallengeData([]byte(strings.Replace(challenge, "name: scheduled", "name: ready", 1)))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "variants", issues[0].Field)
+	assert.Contains(t, issues[0].Message, "duplicate name")
+}
@@ -14,8 +14,9 @@ import (
 )
 
 // TickerWatchLoop runs fn immediately, then repeats every interval with screen clear.
-// Stops on SIGINT/SIGTERM. header is displayed at the top of each iteration.
-func TickerWatchLoop(ctx context.Context, interval time.Duration, header string, fn func()) error {
+// Stops on SIGINT/SIGTERM, which also cancels the ctx passed to a running fn.
+// header is displayed at the top of each iteration.
+func TickerWatchLoop(ctx context.Context, interval time.Duration, header string, fn func(ctx context.Context)) error {
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
@@ -27,7 +28,7 @@ func TickerWatchLoop(ctx context.Context, interval time.Duration, header string,
 	ui.Section(header)
 	ui.Info(fmt.Sprintf("Last run: %s — Press Ctrl+C to stop", time.Now().Format("15:04:05")))
 	ui.Println()
-	fn()
+	fn(ctx)
 
 	for {
 		select {
@@ -40,14 +41,15 @@ func TickerWatchLoop(ctx context.Context, interval time.Duration, header string,
 			ui.Section(header)
 			ui.Info(fmt.Sprintf("Last run: %s — Press Ctrl+C to stop", time.Now().Format("15:04:05")))
 			ui.Println()
-			fn()
+			fn(ctx)
 		}
 	}
 }
 
 // FsWatchLoop watches challengeDir (and manifests/, policies/ subdirs) for changes.
-// On each change (debounced), calls onChange. Stops on SIGINT/SIGTERM.
-func FsWatchLoop(ctx context.Context, challengeDir string, onChange func()) error {
+// On each change (debounced), calls onChange. Stops on SIGINT/SIGTERM, which also cancels
+// the ctx passed to a running onChange.
+func FsWatchLoop(ctx context.Context, challengeDir string, onChange func(ctx context.Context)) error {
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
@@ -93,7 +95,7 @@ func FsWatchLoop(ctx context.Context, challengeDir string, onChange func()) erro
 				n := redeployCount.Add(1)
 				ui.Println()
 				ui.Info(fmt.Sprintf("Change detected: %s (redeploy #%d)", event.Name, n))
-				onChange()
+				onChange(ctx)
 			})
 		case watchErr, ok := <-watcher.Errors:
 			if !ok {
@@ -0,0 +1,167 @@
+// Package errors gives the errors of the CLI stable codes, such as KUBE_CTX_NOT_FOUND
+// or API_UNAUTHORIZED, so tools and support can key off a code instead of a message.
+// Packages attach a code where the failure is understood, with New, Wrap or an error
+// type implementing Coder; CodeOf finds it anywhere in the chain, and ExitCode maps it
+// to the exit status of the CLI. pkg/errors exposes it to SDK users.
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Code is a stable identifier of a class of failure. Codes are never renamed or
+// reused: add a new one instead.
+type Code string
+
+// Codes. CodeUnknown is reported for errors no package gave a code to.
+const (
+	CodeUnknown Code = "UNKNOWN"
+
+	// Local input and environment
+	CodeInvalidInput         Code = "INVALID_INPUT"
+	CodeConfirmationRequired Code = "CONFIRMATION_REQUIRED"
+	CodeInterrupted          Code = "INTERRUPTED"
+	CodeTimeout              Code = "TIMEOUT"
+
+	// Cluster
+	CodeKubeContextNotFound Code = "KUBE_CTX_NOT_FOUND"
+	CodeKubeClient          Code = "KUBE_CLIENT"
+	CodeClusterNotKubeasy   Code = "CLUSTER_NOT_KUBEASY"
+
+	// Challenge deployment. ArgoCD is not used: challenges are applied directly, so a
+	// deploy phase running out of budget is the sync timeout.
+	CodeDeployTimeout        Code = "DEPLOY_TIMEOUT"
+	CodeManifestsUnavailable Code = "MANIFESTS_UNAVAILABLE"
+
+	// Kubeasy API
+	CodeAPIUnauthorized Code = "API_UNAUTHORIZED"
+	CodeAPINotFound     Code = "API_NOT_FOUND"
+	CodeAPIUnreachable  Code = "API_UNREACHABLE"
+	CodeAPIUnavailable  Code = "API_UNAVAILABLE"
+	CodeAPIError        Code = "API_ERROR"
+
+	// Challenge lifecycle
+	CodeChallengeNotStarted Code = "CHALLENGE_NOT_STARTED"
+	CodeChallengeCompleted  Code = "CHALLENGE_COMPLETED"
+	CodeNoAttemptsLeft      Code = "NO_ATTEMPTS_LEFT"
+	CodeSubmissionCooldown  Code = "SUBMISSION_COOLDOWN"
+	CodeTimeLimitExpired    Code = "TIME_LIMIT_EXPIRED"
+	CodeNoValidations       Code = "NO_VALIDATIONS"
+	CodeValidationFailed    Code = "VALIDATION_FAILED"
+)
+
+// Exit statuses of the CLI, by family of codes. 130 is kept for interruptions, as
+// for any process stopped by SIGINT.
+const (
+	ExitFailure     = 1
+	ExitUsage       = 2
+	ExitCluster     = 3
+	ExitAPI         = 4
+	ExitAuth        = 5
+	ExitDeploy      = 6
+	ExitChallenge   = 7
+	ExitValidation  = 8
+	ExitInterrupted = 130
+)
+
+var exitCodes = map[Code]int{
+	CodeInvalidInput:         ExitUsage,
+	CodeConfirmationRequired: ExitUsage,
+	CodeInterrupted:          ExitInterrupted,
+	CodeKubeContextNotFound:  ExitCluster,
+	CodeKubeClient:           ExitCluster,
+	CodeClusterNotKubeasy:    ExitCluster,
+	CodeDeployTimeout:        ExitDeploy,
+	CodeManifestsUnavailable: ExitDeploy,
+	CodeAPIUnauthorized:      ExitAuth,
+	CodeAPINotFound:          ExitAPI,
+	CodeAPIUnreachable:       ExitAPI,
+	CodeAPIUnavailable:       ExitAPI,
+	CodeAPIError:             ExitAPI,
+	CodeChallengeNotStarted:  ExitChallenge,
+	CodeChallengeCompleted:   ExitChallenge,
+	CodeNoAttemptsLeft:       ExitChallenge,
+	CodeSubmissionCooldown:   ExitChallenge,
+	CodeTimeLimitExpired:     ExitChallenge,
+	CodeNoValidations:        ExitChallenge,
+	CodeValidationFailed:     ExitValidation,
+}
+
+// Coder is implemented by errors that carry a code, such as *Error. Error types of
+// other packages implement it to get a code without being wrapped.
+type Coder interface {
+	ErrorCode() Code
+}
+
+// Error is an error with a code.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+// New returns an error with code and a message formatted like fmt.Errorf, so %w
+// also wraps. The result can be used as a sentinel with errors.Is.
+func New(code Code, format string, args ...any) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap gives err the code, keeping its message. A nil err stays nil, and an err that
+// already has a code keeps it: the code set closest to the failure is the most precise.
+func Wrap(code Code, err error) error {
+	if err == nil || CodeOf(err) != CodeUnknown {
+		return err
+	}
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error { return e.Err }
+
+// ErrorCode implements Coder.
+func (e *Error) ErrorCode() Code { return e.Code }
+
+// CodeOf returns the first code found in the chain of err. Context cancellation and
+// deadlines are CodeInterrupted and CodeTimeout; anything else is CodeUnknown.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CodeInterrupted
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	}
+	return CodeUnknown
+}
+
+// ExitCode returns the exit status for err: 0 without error, the status of the
+// family of its code, and ExitFailure for codes without one.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if code, ok := exitCodes[CodeOf(err)]; ok {
+		return code
+	}
+	return ExitFailure
+}
+
+// JSON is the representation of an error in the JSON output of commands.
+type JSON struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+}
+
+// ToJSON returns the JSON representation of err.
+func ToJSON(err error) JSON {
+	return JSON{Code: CodeOf(err), Message: err.Error()}
+}
@@ -0,0 +1,77 @@
+package errors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type budgetError struct{}
+
+func (budgetError) Error() string           { return "ready phase out of budget" }
+func (budgetError) ErrorCode() kerrors.Code { return kerrors.CodeDeployTimeout }
+
+func TestCodeOf(t *testing.T) {
+	sentinel := kerrors.New(kerrors.CodeNoAttemptsLeft, "no submission attempts left")
+
+	tests := []struct {
+		name string
+		err  error
+		want kerrors.Code
+	}{
+		{"nil", nil, ""},
+		{"uncoded", errors.New("boom"), kerrors.CodeUnknown},
+		{"coded", sentinel, kerrors.CodeNoAttemptsLeft},
+		{"wrapped sentinel", fmt.Errorf("submit: %w", sentinel), kerrors.CodeNoAttemptsLeft},
+		{"coder type", fmt.Errorf("deploy: %w", budgetError{}), kerrors.CodeDeployTimeout},
+		{"cancelled", fmt.Errorf("wait: %w", context.Canceled), kerrors.CodeInterrupted},
+		{"deadline", fmt.Errorf("wait: %w", context.DeadlineExceeded), kerrors.CodeTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, kerrors.CodeOf(tt.err))
+		})
+	}
+}
+
+func TestNew_IsASentinel(t *testing.T) {
+	sentinel := kerrors.New(kerrors.CodeChallengeNotStarted, "challenge not started")
+	assert.ErrorIs(t, fmt.Errorf("submit: %w", sentinel), sentinel)
+	assert.Equal(t, "challenge not started", sentinel.Error())
+
+	cause := errors.New("connection refused")
+	err := kerrors.New(kerrors.CodeAPIUnreachable, "failed to make request: %w", cause)
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestWrap_KeepsTheInnermostCode(t *testing.T) {
+	assert.NoError(t, kerrors.Wrap(kerrors.CodeKubeClient, nil))
+
+	err := kerrors.Wrap(kerrors.CodeKubeClient, errors.New("boom"))
+	assert.Equal(t, kerrors.CodeKubeClient, kerrors.CodeOf(err))
+	assert.Equal(t, "boom", err.Error())
+
+	precise := kerrors.New(kerrors.CodeKubeContextNotFound, "no context")
+	assert.Equal(t, kerrors.CodeKubeContextNotFound, kerrors.CodeOf(kerrors.Wrap(kerrors.CodeKubeClient, precise)))
+	assert.Equal(t, kerrors.CodeInterrupted, kerrors.CodeOf(kerrors.Wrap(kerrors.CodeAPIUnreachable, context.Canceled)))
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, kerrors.ExitCode(nil))
+	assert.Equal(t, kerrors.ExitFailure, kerrors.ExitCode(errors.New("boom")))
+	assert.Equal(t, kerrors.ExitUsage, kerrors.ExitCode(kerrors.New(kerrors.CodeInvalidInput, "bad slug")))
+	assert.Equal(t, kerrors.ExitCluster, kerrors.ExitCode(kerrors.New(kerrors.CodeKubeContextNotFound, "no context")))
+	assert.Equal(t, kerrors.ExitAuth, kerrors.ExitCode(kerrors.New(kerrors.CodeAPIUnauthorized, "not authenticated")))
+	assert.Equal(t, kerrors.ExitDeploy, kerrors.ExitCode(budgetError{}))
+	assert.Equal(t, kerrors.ExitInterrupted, kerrors.ExitCode(context.Canceled))
+	assert.Equal(t, kerrors.ExitFailure, kerrors.ExitCode(context.DeadlineExceeded), "TIMEOUT has no family")
+}
+
+func TestToJSON(t *testing.T) {
+	got := kerrors.ToJSON(kerrors.New(kerrors.CodeNoValidations, "no validations found for this challenge"))
+	assert.Equal(t, kerrors.JSON{Code: kerrors.CodeNoValidations, Message: "no validations found for this challenge"}, got)
+}
@@ -0,0 +1,219 @@
+// Package exam keeps the local state of an exam session: a bundle of challenges
+// taken one after another as a single, optionally time-boxed, assessment.
+package exam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+)
+
+// ChallengeStatus is the state of one challenge within a session.
+type ChallengeStatus string
+
+const (
+	StatusPending    ChallengeStatus = "pending"
+	StatusInProgress ChallengeStatus = "in_progress"
+	StatusPassed     ChallengeStatus = "passed"
+	StatusFailed     ChallengeStatus = "failed"
+	StatusSkipped    ChallengeStatus = "skipped"
+)
+
+// ChallengeAttempt tracks one challenge of the bundle.
+type ChallengeAttempt struct {
+	Slug        string          `json:"slug"`
+	Title       string          `json:"title"`
+	Status      ChallengeStatus `json:"status"`
+	StartedAt   *time.Time      `json:"startedAt,omitempty"`
+	SubmittedAt *time.Time      `json:"submittedAt,omitempty"`
+	Submissions int             `json:"submissions"`
+	XP          int             `json:"xp"`
+	// Closed is set when the learner moves on from a failed challenge, which then is
+	// no longer current. A failed challenge stays current otherwise, to be submitted again.
+	Closed bool `json:"closed,omitempty"`
+}
+
+// Attempted reports whether the challenge was graded at least once.
+func (a ChallengeAttempt) Attempted() bool {
+	return a.Submissions > 0
+}
+
+// open reports whether the challenge can still be worked on.
+func (a ChallengeAttempt) open() bool {
+	switch a.Status {
+	case StatusPending, StatusInProgress:
+		return true
+	case StatusFailed:
+		return !a.Closed
+	}
+	return false
+}
+
+// GiveUp moves on from an open challenge: failed when it was attempted, skipped when
+// it never was.
+func (a *ChallengeAttempt) GiveUp() {
+	if !a.open() {
+		return
+	}
+	if a.Attempted() {
+		a.Status = StatusFailed
+		a.Closed = true
+		return
+	}
+	a.Status = StatusSkipped
+}
+
+// Duration returns the time spent on the challenge, up to its last submission or now.
+func (a ChallengeAttempt) Duration(now time.Time) time.Duration {
+	if a.StartedAt == nil {
+		return 0
+	}
+	if a.SubmittedAt != nil && !a.open() {
+		return a.SubmittedAt.Sub(*a.StartedAt)
+	}
+	return now.Sub(*a.StartedAt)
+}
+
+// Session is the persisted state of an exam.
+type Session struct {
+	Bundle     string             `json:"bundle"`
+	Title      string             `json:"title"`
+	StartedAt  time.Time          `json:"startedAt"`
+	TimeLimit  time.Duration      `json:"timeLimit,omitempty"`
+	FinishedAt *time.Time         `json:"finishedAt,omitempty"`
+	Challenges []ChallengeAttempt `json:"challenges"`
+}
+
+// Score aggregates the results of a session.
+type Score struct {
+	Passed int
+	Total  int
+	XP     int
+}
+
+// Percent returns the share of passed challenges, rounded down.
+func (s Score) Percent() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return s.Passed * 100 / s.Total
+}
+
+// NewSession creates a session for bundle, started at now. A zero limit means untimed.
+func NewSession(bundle *api.BundleResponse, now time.Time, limit time.Duration) *Session {
+	s := &Session{
+		Bundle:     bundle.Slug,
+		Title:      bundle.Title,
+		StartedAt:  now,
+		TimeLimit:  limit,
+		Challenges: make([]ChallengeAttempt, len(bundle.Challenges)),
+	}
+	for i, c := range bundle.Challenges {
+		s.Challenges[i] = ChallengeAttempt{Slug: c.Slug, Title: c.Title, Status: StatusPending}
+	}
+	return s
+}
+
+// Current returns the challenge to work on, or nil when every challenge is done.
+func (s *Session) Current() *ChallengeAttempt {
+	for i := range s.Challenges {
+		if s.Challenges[i].open() {
+			return &s.Challenges[i]
+		}
+	}
+	return nil
+}
+
+// Position returns the 1-based index of a challenge in the bundle, or 0 if unknown.
+func (s *Session) Position(slug string) int {
+	for i, c := range s.Challenges {
+		if c.Slug == slug {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Remaining returns the time left at now. ok is false for untimed sessions.
+func (s *Session) Remaining(now time.Time) (remaining time.Duration, ok bool) {
+	if s.TimeLimit <= 0 {
+		return 0, false
+	}
+	return s.TimeLimit - now.Sub(s.StartedAt), true
+}
+
+// Expired reports whether the time limit has passed at now.
+func (s *Session) Expired(now time.Time) bool {
+	remaining, ok := s.Remaining(now)
+	return ok && remaining <= 0
+}
+
+// Finish closes the session at now. Challenges not passed are given up: failed when
+// they were attempted, skipped otherwise.
+func (s *Session) Finish(now time.Time) {
+	for i := range s.Challenges {
+		s.Challenges[i].GiveUp()
+	}
+	s.FinishedAt = &now
+}
+
+// Score aggregates passed challenges and earned XP.
+func (s *Session) Score() Score {
+	score := Score{Total: len(s.Challenges)}
+	for _, c := range s.Challenges {
+		if c.Status == StatusPassed {
+			score.Passed++
+		}
+		score.XP += c.XP
+	}
+	return score
+}
+
+// GetSessionPath returns the session file of a bundle (~/.kubeasy/exams/<bundle>.json).
+func GetSessionPath(bundle string) string {
+	return filepath.Join(constants.GetKubeasyConfigDir(), "exams", bundle+".json")
+}
+
+// Save writes the session to its file.
+func (s *Session) Save() error {
+	path := GetSessionPath(s.Bundle)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create exams dir: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode exam session: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Load reads the session of a bundle. It returns nil without error when none exists.
+func Load(bundle string) (*Session, error) {
+	data, err := os.ReadFile(GetSessionPath(bundle))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse exam session: %w", err)
+	}
+	return &s, nil
+}
+
+// Delete removes the session of a bundle, if any.
+func Delete(bundle string) error {
+	err := os.Remove(GetSessionPath(bundle))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
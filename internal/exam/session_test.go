@@ -0,0 +1,117 @@
+package exam
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBundle() *api.BundleResponse {
+	return &api.BundleResponse{
+		Slug:  "cka-warmup",
+		Title: "CKA Warm-up",
+		Challenges: []api.BundleChallenge{
+			{Slug: "pod-evicted", Title: "Pod Evicted"},
+			{Slug: "rbac-101", Title: "RBAC 101"},
+			{Slug: "svc-selector", Title: "Service Selector"},
+		},
+	}
+}
+
+func TestSession_Progression(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	s := NewSession(testBundle(), start, time.Hour)
+
+	cur := s.Current()
+	require.NotNil(t, cur)
+	assert.Equal(t, "pod-evicted", cur.Slug)
+	assert.Equal(t, 1, s.Position(cur.Slug))
+
+	cur.Status = StatusPassed
+	cur.XP = 50
+	cur = s.Current()
+	require.NotNil(t, cur)
+	assert.Equal(t, "rbac-101", cur.Slug)
+
+	cur.Status = StatusInProgress
+	assert.Equal(t, "rbac-101", s.Current().Slug, "an in-progress challenge stays current")
+
+	remaining, ok := s.Remaining(start.Add(45 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, 15*time.Minute, remaining)
+	assert.False(t, s.Expired(start.Add(45*time.Minute)))
+	assert.True(t, s.Expired(start.Add(time.Hour)))
+
+	s.Finish(start.Add(time.Hour))
+	assert.Nil(t, s.Current())
+	assert.Equal(t, StatusSkipped, s.Challenges[1].Status)
+	assert.Equal(t, StatusSkipped, s.Challenges[2].Status)
+
+	score := s.Score()
+	assert.Equal(t, Score{Passed: 1, Total: 3, XP: 50}, score)
+	assert.Equal(t, 33, score.Percent())
+}
+
+func TestSession_FailedAttempts(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	s := NewSession(testBundle(), start, time.Hour)
+
+	first := s.Current()
+	first.Status = StatusFailed
+	first.Submissions = 1
+	assert.Equal(t, "pod-evicted", s.Current().Slug, "a failed challenge stays current to be submitted again")
+
+	first.GiveUp()
+	assert.Equal(t, StatusFailed, first.Status, "giving up an attempted challenge fails it")
+	assert.True(t, first.Closed)
+	assert.Equal(t, "rbac-101", s.Current().Slug)
+
+	s.Current().GiveUp()
+	assert.Equal(t, StatusSkipped, s.Challenges[1].Status, "a challenge never attempted is skipped")
+
+	third := s.Current()
+	third.Status = StatusInProgress
+	third.Submissions = 2
+	s.Finish(start.Add(time.Hour))
+	assert.Equal(t, StatusFailed, third.Status, "time up fails an attempted challenge")
+	assert.Nil(t, s.Current())
+	assert.Equal(t, Score{Passed: 0, Total: 3}, s.Score())
+}
+
+func TestSession_Untimed(t *testing.T) {
+	s := NewSession(testBundle(), time.Now(), 0)
+	_, ok := s.Remaining(time.Now())
+	assert.False(t, ok)
+	assert.False(t, s.Expired(time.Now().Add(24*time.Hour)))
+}
+
+func TestSession_SaveLoadDelete(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	assert.Equal(t, filepath.Join(dir, ".kubeasy", "exams", "cka-warmup.json"), GetSessionPath("cka-warmup"))
+
+	loaded, err := Load("cka-warmup")
+	require.NoError(t, err)
+	assert.Nil(t, loaded, "no session expected before Save")
+
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	s := NewSession(testBundle(), start, 90*time.Minute)
+	s.Challenges[0].Status = StatusFailed
+	s.Challenges[0].Submissions = 2
+	require.NoError(t, s.Save())
+
+	loaded, err = Load("cka-warmup")
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, s, loaded)
+
+	require.NoError(t, Delete("cka-warmup"))
+	require.NoError(t, Delete("cka-warmup"))
+	loaded, err = Load("cka-warmup")
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+}
@@ -0,0 +1,147 @@
+package fakecluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+)
+
+// progressFile is the file of the state dir the progress of the challenge is kept in.
+const progressFile = "progress.json"
+
+// API answers the Kubeasy API calls of the challenge flows for the challenge of a
+// scenario, keeping its progress in dir.
+type API struct {
+	scenario *Scenario
+	dir      string
+	now      func() time.Time
+}
+
+// NewAPI returns the API of scenario, keeping the progress in its StateDir.
+func NewAPI(scenario *Scenario) *API {
+	return &API{scenario: scenario, dir: scenario.StateDir(), now: time.Now}
+}
+
+// GetChallenge returns the challenge of the scenario.
+func (a *API) GetChallenge(ctx context.Context, slug string) (*api.ChallengeEntity, error) {
+	if err := a.checkSlug(slug); err != nil {
+		return nil, err
+	}
+	challenge := a.scenario.Challenge
+	return &challenge, nil
+}
+
+// GetProgress returns the progress recorded by the previous calls, or the status of
+// the scenario before the first start.
+func (a *API) GetProgress(ctx context.Context, slug string) (*api.ChallengeStatusResponse, error) {
+	if err := a.checkSlug(slug); err != nil {
+		return nil, err
+	}
+	return a.loadProgress()
+}
+
+// StartChallenge marks the challenge in progress.
+func (a *API) StartChallenge(ctx context.Context, slug string) (*api.ChallengeStartResponse, error) {
+	progress, err := a.GetProgress(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if progress.Status == "completed" {
+		return &api.ChallengeStartResponse{Status: progress.Status, StartedAt: deref(progress.StartedAt)}, nil
+	}
+	if progress.Status != "in_progress" || progress.StartedAt == nil {
+		startedAt := a.now().UTC().Format(time.RFC3339)
+		progress = &api.ChallengeStatusResponse{Status: "in_progress", StartedAt: &startedAt}
+		if err := a.saveProgress(progress); err != nil {
+			return nil, err
+		}
+	}
+	return &api.ChallengeStartResponse{Status: progress.Status, StartedAt: *progress.StartedAt}, nil
+}
+
+// SubmitChallenge completes the challenge when every objective passed and no
+// forbidden action was taken, like the API does.
+func (a *API) SubmitChallenge(ctx context.Context, slug string, req api.ChallengeSubmitRequest) (*api.ChallengeSubmitResponse, error) {
+	progress, err := a.GetProgress(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if progress.Status != "in_progress" {
+		return nil, fmt.Errorf("challenge '%s' is %s", slug, progress.Status)
+	}
+	passed := len(req.Results) > 0 && (req.ForbiddenActions == nil || len(*req.ForbiddenActions) == 0)
+	for _, r := range req.Results {
+		passed = passed && r.Passed
+	}
+	if !passed {
+		msg := "Some objectives are not met yet"
+		return &api.ChallengeSubmitResponse{Success: false, Message: &msg}, nil
+	}
+	completedAt := a.now().UTC().Format(time.RFC3339)
+	progress.Status, progress.CompletedAt = "completed", &completedAt
+	if err := a.saveProgress(progress); err != nil {
+		return nil, err
+	}
+	msg := "Challenge completed (fake cluster: no XP awarded)"
+	return &api.ChallengeSubmitResponse{Success: true, Message: &msg}, nil
+}
+
+// ResetChallenge forgets the progress, back to the status of the scenario.
+func (a *API) ResetChallenge(ctx context.Context, slug string) (*api.ChallengeResetResponse, error) {
+	if err := a.checkSlug(slug); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(filepath.Join(a.dir, progressFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to reset progress: %w", err)
+	}
+	return &api.ChallengeResetResponse{Success: true, Message: "Progress reset"}, nil
+}
+
+// UserID returns no user: challenges with variants use their first one.
+func (a *API) UserID(ctx context.Context) (string, error) { return "", nil }
+
+func (a *API) checkSlug(slug string) error {
+	if slug != a.scenario.Challenge.Slug {
+		return fmt.Errorf("challenge '%s' not found", slug)
+	}
+	return nil
+}
+
+func (a *API) loadProgress() (*api.ChallengeStatusResponse, error) {
+	data, err := os.ReadFile(filepath.Join(a.dir, progressFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &api.ChallengeStatusResponse{Status: a.scenario.Status}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress: %w", err)
+	}
+	var progress api.ChallengeStatusResponse
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("failed to parse progress: %w", err)
+	}
+	return &progress, nil
+}
+
+func (a *API) saveProgress(progress *api.ChallengeStatusResponse) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(a.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", a.dir, err)
+	}
+	return os.WriteFile(filepath.Join(a.dir, progressFile), data, 0o600)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
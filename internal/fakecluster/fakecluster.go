@@ -0,0 +1,228 @@
+// Package fakecluster runs the challenge flows against an in-memory cluster and a
+// local stand-in for the Kubeasy API, both described by a scenario file, so start,
+// verify and submit can be demoed and tested without kind or docker.
+//
+// The cluster is seeded from the scenario on every invocation: what a command
+// changes in it is gone at the next one, which keeps runs deterministic. The
+// progress of the challenge, like the real API's, is kept across invocations.
+package fakecluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/yaml"
+)
+
+// Host is the API server address of the fake cluster; it is never dialed.
+const Host = "https://kubeasy-fake.invalid"
+
+// Scenario describes a fake cluster and the challenge played on it.
+type Scenario struct {
+	// Challenge is what the API returns for the challenge; its slug is required.
+	Challenge api.ChallengeEntity `json:"challenge"`
+	// Status is the progress of the challenge before it is first started:
+	// "not_started" (the default), "in_progress" or "completed".
+	Status string `json:"status,omitempty"`
+	// ChallengesDir holds the challenge.yaml of the challenge, in <slug>/, relative
+	// to the scenario file. It defaults to the directory of the scenario file.
+	ChallengesDir string `json:"challengesDir,omitempty"`
+	// Objects seed the cluster, e.g. the challenge namespace and what the challenge
+	// deploys in it.
+	Objects []unstructured.Unstructured `json:"objects,omitempty"`
+
+	// hash identifies the content of the scenario file.
+	hash string
+}
+
+// Load reads the scenario file at path. ChallengesDir is made absolute.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	var s Scenario
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+	}
+	if s.Challenge.Slug == "" {
+		return nil, fmt.Errorf("scenario %s: challenge.slug is required", path)
+	}
+	switch s.Status {
+	case "":
+		s.Status = "not_started"
+	case "not_started", "in_progress", "completed":
+	default:
+		return nil, fmt.Errorf("scenario %s: unknown status %q (want not_started, in_progress or completed)", path, s.Status)
+	}
+	for i, obj := range s.Objects {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("scenario %s: object %d needs apiVersion, kind and metadata.name", path, i)
+		}
+	}
+	if !filepath.IsAbs(s.ChallengesDir) {
+		s.ChallengesDir = filepath.Join(filepath.Dir(path), s.ChallengesDir)
+	}
+	if s.ChallengesDir, err = filepath.Abs(s.ChallengesDir); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	s.hash = hex.EncodeToString(sum[:])
+	return &s, nil
+}
+
+// Hash returns the SHA-256 of the scenario file, which stands for the hash of the
+// manifests a real deployment applies.
+func (s *Scenario) Hash() string { return s.hash }
+
+// StateDir is where the progress and the kubeconfig of the scenario's challenge are
+// kept, under ~/.kubeasy/fake-cluster.
+func (s *Scenario) StateDir() string {
+	return filepath.Join(constants.GetKubeasyConfigDir(), "fake-cluster", filepath.Base(s.Challenge.Slug))
+}
+
+// Cluster holds the clients of a fake cluster.
+type Cluster struct {
+	Clientset     kubernetes.Interface
+	DynamicClient dynamic.Interface
+	RestConfig    *rest.Config
+}
+
+// Cluster returns clients over a new in-memory cluster seeded with the objects of
+// the scenario and the marker of a cluster set up by this CLI. Objects of kinds client-go knows are
+// served by both clients, others by the dynamic client only. The two clients do not
+// share their storage: what one writes, the other does not see.
+func (s *Scenario) Cluster(ctx context.Context) (*Cluster, error) {
+	var typed []runtime.Object
+	dynamicObjects := make([]runtime.Object, 0, len(s.Objects))
+	listKinds := builtinListKinds()
+	for i := range s.Objects {
+		obj := s.Objects[i].DeepCopy()
+		seed(obj)
+		dynamicObjects = append(dynamicObjects, obj)
+
+		gvk := obj.GroupVersionKind()
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		if _, ok := listKinds[gvr]; !ok {
+			listKinds[gvr] = gvk.Kind + "List"
+		}
+		if !scheme.Scheme.Recognizes(gvk) {
+			continue
+		}
+		typedObj, err := scheme.Scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typedObj); err != nil {
+			return nil, fmt.Errorf("invalid %s %s: %w", gvk.Kind, obj.GetName(), err)
+		}
+		typed = append(typed, typedObj)
+	}
+
+	clientset := fake.NewClientset(typed...)
+	// Namespaces are created Active, without a namespace controller to do it
+	clientset.PrependReactor("create", "namespaces", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if ns, ok := action.(clienttesting.CreateAction).GetObject().(*corev1.Namespace); ok {
+			if ns.Status.Phase == "" {
+				ns.Status.Phase = corev1.NamespaceActive
+			}
+			if ns.UID == "" {
+				ns.UID = objectUID("Namespace", "", ns.Name)
+			}
+		}
+		return false, nil, nil
+	})
+	fingerprint := kube.ClusterFingerprint{CLIVersion: constants.Version, SchemaVersion: kube.FingerprintSchemaVersion}
+	if err := kube.WriteClusterFingerprint(ctx, clientset, fingerprint); err != nil {
+		return nil, fmt.Errorf("failed to mark the fake cluster: %w", err)
+	}
+
+	return &Cluster{
+		Clientset:     clientset,
+		DynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, dynamicObjects...),
+		RestConfig:    &rest.Config{Host: Host},
+	}, nil
+}
+
+// seed fills in what the API server would have set on a stored object: a UID, and
+// the phase of namespaces. UIDs are derived from the object, so they are the same on
+// every run.
+func seed(obj *unstructured.Unstructured) {
+	if obj.GetUID() == "" {
+		obj.SetUID(objectUID(obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+	}
+	if obj.GetAPIVersion() == "v1" && obj.GetKind() == "Namespace" {
+		if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase == "" {
+			_ = unstructured.SetNestedField(obj.Object, string(corev1.NamespaceActive), "status", "phase")
+		}
+	}
+}
+
+func objectUID(kind, namespace, name string) types.UID {
+	sum := sha256.Sum256([]byte(kind + "/" + namespace + "/" + name))
+	h := hex.EncodeToString(sum[:16])
+	return types.UID(h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32])
+}
+
+// builtinListKinds maps the resources of the kinds client-go knows to their list
+// kind, which the fake dynamic client needs to list them.
+func builtinListKinds() map[schema.GroupVersionResource]string {
+	listKinds := map[schema.GroupVersionResource]string{}
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		kind, ok := strings.CutSuffix(gvk.Kind, "List")
+		if !ok || kind == "" {
+			continue
+		}
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk.GroupVersion().WithKind(kind))
+		listKinds[gvr] = gvk.Kind
+	}
+	return listKinds
+}
+
+// EnsureKubeconfig returns the path of a kubeconfig in dir whose kubeasy context
+// points at the fake cluster, writing it when missing. The flows that edit the
+// kubeconfig edit it instead of the user's.
+func EnsureKubeconfig(dir string) (string, error) {
+	path := filepath.Join(dir, "kubeconfig")
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	config := clientcmdapi.NewConfig()
+	config.Clusters[constants.KubeasyClusterContext] = &clientcmdapi.Cluster{Server: Host}
+	config.AuthInfos[constants.KubeasyClusterContext] = &clientcmdapi.AuthInfo{}
+	config.Contexts[constants.KubeasyClusterContext] = &clientcmdapi.Context{
+		Cluster:  constants.KubeasyClusterContext,
+		AuthInfo: constants.KubeasyClusterContext,
+	}
+	config.CurrentContext = constants.KubeasyClusterContext
+	if err := clientcmd.WriteToFile(*config, path); err != nil {
+		return "", fmt.Errorf("failed to write the fake kubeconfig: %w", err)
+	}
+	return path, nil
+}
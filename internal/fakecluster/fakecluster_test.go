@@ -0,0 +1,175 @@
+package fakecluster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const testScenario = `challenge:
+  slug: pod-evicted
+  title: Pod Evicted
+objects:
+  - apiVersion: v1
+    kind: Namespace
+    metadata: {name: pod-evicted}
+  - apiVersion: v1
+    kind: Pod
+    metadata: {name: web, namespace: pod-evicted, labels: {app: web}}
+    status: {phase: Running}
+  - apiVersion: example.com/v1
+    kind: Widget
+    metadata: {name: w, namespace: pod-evicted}
+`
+
+func writeScenario(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeScenario(t, testScenario)
+
+	s, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "pod-evicted", s.Challenge.Slug)
+	assert.Equal(t, "not_started", s.Status, "the status defaults to not_started")
+	assert.Equal(t, filepath.Dir(path), s.ChallengesDir, "challenges are looked up next to the scenario by default")
+	assert.Len(t, s.Objects, 3)
+	assert.Len(t, s.Hash(), 64)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing slug":   "challenge: {title: T}\n",
+		"unknown status": "challenge: {slug: s}\nstatus: done\n",
+		"unknown field":  "challenge: {slug: s}\nobject: []\n",
+		"unnamed object": "challenge: {slug: s}\nobjects: [{apiVersion: v1, kind: Pod}]\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writeScenario(t, content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestScenario_Cluster(t *testing.T) {
+	ctx := context.Background()
+	s, err := Load(writeScenario(t, testScenario))
+	require.NoError(t, err)
+
+	cluster, err := s.Cluster(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, kube.VerifyClusterMarker(ctx, cluster.Clientset), "the fake cluster passes the cluster guard")
+
+	ns, err := cluster.Clientset.CoreV1().Namespaces().Get(ctx, "pod-evicted", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.NamespaceActive, ns.Status.Phase)
+	assert.NotEmpty(t, ns.UID)
+
+	pods, err := cluster.Clientset.CoreV1().Pods("pod-evicted").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	assert.Equal(t, corev1.PodRunning, pods.Items[0].Status.Phase)
+
+	dynPods, err := cluster.DynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"}).
+		Namespace("pod-evicted").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, dynPods.Items, 1)
+	widgets, err := cluster.DynamicClient.Resource(schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}).
+		Namespace("pod-evicted").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "custom kinds are listed by the dynamic client")
+	assert.Len(t, widgets.Items, 1)
+	_, err = cluster.DynamicClient.Resource(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).
+		Namespace("pod-evicted").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err, "built-in kinds without objects can be listed")
+
+	require.NoError(t, kube.CreateNamespace(ctx, cluster.Clientset, "other"), "created namespaces become Active")
+
+	again, err := s.Cluster(ctx)
+	require.NoError(t, err)
+	_, err = again.Clientset.CoreV1().Namespaces().Get(ctx, "other", metav1.GetOptions{})
+	assert.Error(t, err, "each cluster is seeded afresh")
+	nsAgain, err := again.Clientset.CoreV1().Namespaces().Get(ctx, "pod-evicted", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, ns.UID, nsAgain.UID, "UIDs are the same on every run")
+}
+
+func TestEnsureKubeconfig(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+	path, err := EnsureKubeconfig(dir)
+	require.NoError(t, err)
+
+	kube.UseKubeconfig(path)
+	t.Cleanup(func() { kube.UseKubeconfig("") })
+	require.NoError(t, kube.SetNamespaceForContext("kind-kubeasy", "pod-evicted"))
+
+	again, err := EnsureKubeconfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, path, again)
+	namespace, err := kube.ContextNamespace("kind-kubeasy")
+	require.NoError(t, err)
+	assert.Equal(t, "pod-evicted", namespace, "an existing kubeconfig is kept")
+}
+
+func TestAPI_Progress(t *testing.T) {
+	ctx := context.Background()
+	s, err := Load(writeScenario(t, testScenario))
+	require.NoError(t, err)
+	a := &API{scenario: s, dir: t.TempDir(), now: func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }}
+
+	_, err = a.GetChallenge(ctx, "other")
+	assert.Error(t, err, "only the challenge of the scenario exists")
+	challenge, err := a.GetChallenge(ctx, "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "Pod Evicted", challenge.Title)
+
+	progress, err := a.GetProgress(ctx, "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "not_started", progress.Status)
+
+	started, err := a.StartChallenge(ctx, "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-02T03:04:05Z", started.StartedAt)
+
+	failed, err := a.SubmitChallenge(ctx, "pod-evicted", api.ChallengeSubmitRequest{
+		Results: []api.ObjectiveResult{{ObjectiveKey: "a", Passed: true}, {ObjectiveKey: "b", Passed: false}},
+	})
+	require.NoError(t, err)
+	assert.False(t, failed.Success)
+
+	forbidden, err := a.SubmitChallenge(ctx, "pod-evicted", api.ChallengeSubmitRequest{
+		Results:          []api.ObjectiveResult{{ObjectiveKey: "a", Passed: true}},
+		ForbiddenActions: &[]api.ForbiddenActionViolation{{}},
+	})
+	require.NoError(t, err)
+	assert.False(t, forbidden.Success, "forbidden actions fail the submission")
+
+	passed, err := a.SubmitChallenge(ctx, "pod-evicted", api.ChallengeSubmitRequest{
+		Results: []api.ObjectiveResult{{ObjectiveKey: "a", Passed: true}},
+	})
+	require.NoError(t, err)
+	assert.True(t, passed.Success)
+	progress, err = a.GetProgress(ctx, "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "completed", progress.Status)
+
+	_, err = a.ResetChallenge(ctx, "pod-evicted")
+	require.NoError(t, err)
+	progress, err = a.GetProgress(ctx, "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "not_started", progress.Status, "a reset goes back to the scenario status")
+}
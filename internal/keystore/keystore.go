@@ -2,12 +2,15 @@
 // credentials with automatic fallback for headless environments.
 //
 // The storage priority is:
-//  1. Environment variable (KUBEASY_API_KEY) - read only, useful for CI/CD
+//  1. Environment variable (KUBEASY_TOKEN, then KUBEASY_API_KEY) - read only, useful for CI/CD
 //  2. System keyring (go-keyring) - preferred for GUI environments
 //  3. File-based storage - fallback for headless environments:
 //     - Linux/macOS: ~/.config/kubeasy-cli/credentials (XDG spec)
 //     - Windows: %APPDATA%/kubeasy-cli/credentials
 //
+// Each backend is also a CredentialStore on its own, and KUBEASY_CREDENTIAL_STORE can
+// restrict the CLI to one of them (see Select).
+//
 // # Security Notes
 //
 // Unix/Linux/macOS:
@@ -40,6 +43,9 @@ const (
 	// This is NOT a credential itself, just the variable name to look up.
 	EnvVarName = "KUBEASY_API_KEY"
 
+	// TokenEnvVarName is an alternative to EnvVarName, checked first.
+	TokenEnvVarName = "KUBEASY_TOKEN"
+
 	// credentialsFileName is the name of the file used for file-based storage
 	credentialsFileName = "credentials"
 
@@ -78,8 +84,8 @@ func Get() (string, error) {
 	// Environment variables take precedence to support CI/CD pipelines
 	// and containerized environments where keyring/file storage may not
 	// be appropriate or available.
-	if envKey := os.Getenv(EnvVarName); envKey != "" {
-		logger.Debug("Using API key from %s environment variable", EnvVarName)
+	if envKey, name := getFromEnv(); envKey != "" {
+		logger.Debug("Using API key from %s environment variable", name)
 		return envKey, nil
 	}
 
@@ -158,7 +164,7 @@ func Delete() error {
 // variable and keyring checks are not mutex-protected. Only file storage
 // access is protected by the mutex.
 func GetStorageType() StorageType {
-	if os.Getenv(EnvVarName) != "" {
+	if key, _ := getFromEnv(); key != "" {
 		return StorageEnv
 	}
 
@@ -188,6 +194,16 @@ func GetConfigDirPath() (string, error) {
 	return getConfigDir()
 }
 
+// getFromEnv returns the API key set in the environment and the variable holding it.
+func getFromEnv() (string, string) {
+	for _, name := range []string{TokenEnvVarName, EnvVarName} {
+		if key := os.Getenv(name); key != "" {
+			return key, name
+		}
+	}
+	return "", ""
+}
+
 // getCredentialsPath returns the full path to the credentials file
 func getCredentialsPath() (string, error) {
 	configDir, err := getConfigDir()
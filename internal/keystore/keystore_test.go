@@ -20,6 +20,7 @@ func cleanupTestEnv(t *testing.T) {
 	t.Helper()
 	// Clear environment variable
 	_ = os.Unsetenv(EnvVarName)
+	_ = os.Unsetenv(TokenEnvVarName)
 	// Clear keyring
 	_ = keyring.Delete("kubeasy-cli", "api_key")
 	// Clear file storage
@@ -89,6 +90,7 @@ func TestGet_Priority(t *testing.T) {
 
 	// Remove env, keyring should be next
 	_ = os.Unsetenv(EnvVarName)
+	_ = os.Unsetenv(TokenEnvVarName)
 	token, err = Get()
 	require.NoError(t, err)
 	assert.Equal(t, "keyring-token", token)
@@ -0,0 +1,135 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/zalando/go-keyring"
+)
+
+// StoreEnvVarName selects the credential store of the CLI: "auto" (the default),
+// "env", "keyring" or "file".
+const StoreEnvVarName = "KUBEASY_CREDENTIAL_STORE"
+
+// ErrReadOnly is returned when saving to a store that cannot be written, e.g. the
+// environment.
+var ErrReadOnly = errors.New("credential store is read-only")
+
+// CredentialStore is where the API key is read from and saved to. Get returns an
+// error wrapping ErrNotFound when the store holds no key.
+type CredentialStore interface {
+	Get() (string, error)
+	// Set saves the key and returns the backend that holds it.
+	Set(apiKey string) (StorageType, error)
+	Delete() error
+}
+
+// Auto returns the default store: it reads the environment, the keyring and the
+// credentials file in that order, and saves to the keyring, or to the file when no
+// keyring is available.
+func Auto() CredentialStore { return autoStore{} }
+
+// Env returns a read-only store over KUBEASY_TOKEN and KUBEASY_API_KEY.
+func Env() CredentialStore { return envStore{} }
+
+// Keyring returns a store over the system keyring only.
+func Keyring() CredentialStore { return keyringStore{} }
+
+// File returns a store over the credentials file only.
+func File() CredentialStore { return fileStore{} }
+
+// Select returns the store named by name, as accepted by KUBEASY_CREDENTIAL_STORE;
+// "" selects Auto.
+func Select(name string) (CredentialStore, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return Auto(), nil
+	case string(StorageEnv), "env":
+		return Env(), nil
+	case string(StorageKeyring):
+		return Keyring(), nil
+	case string(StorageFile):
+		return File(), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %q (available: auto, env, keyring, file)", name)
+	}
+}
+
+// FromEnv returns the store selected by KUBEASY_CREDENTIAL_STORE.
+func FromEnv() (CredentialStore, error) {
+	store, err := Select(os.Getenv(StoreEnvVarName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", StoreEnvVarName, err)
+	}
+	return store, nil
+}
+
+type autoStore struct{}
+
+func (autoStore) Get() (string, error)                   { return Get() }
+func (autoStore) Set(apiKey string) (StorageType, error) { return Set(apiKey) }
+func (autoStore) Delete() error                          { return Delete() }
+
+type envStore struct{}
+
+func (envStore) Get() (string, error) {
+	if key, _ := getFromEnv(); key != "" {
+		return key, nil
+	}
+	return "", fmt.Errorf("neither %s nor %s is set: %w", TokenEnvVarName, EnvVarName, ErrNotFound)
+}
+
+func (envStore) Set(string) (StorageType, error) {
+	return "", fmt.Errorf("set %s instead: %w", TokenEnvVarName, ErrReadOnly)
+}
+
+func (envStore) Delete() error { return nil }
+
+type keyringStore struct{}
+
+func (keyringStore) Get() (string, error) {
+	key, err := keyring.Get(constants.KeyringServiceName, "api_key")
+	if errors.Is(err, keyring.ErrNotFound) || (err == nil && key == "") {
+		return "", fmt.Errorf("no API key in the system keyring: %w", ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the system keyring: %w", err)
+	}
+	return key, nil
+}
+
+func (keyringStore) Set(apiKey string) (StorageType, error) {
+	if err := keyring.Set(constants.KeyringServiceName, "api_key", apiKey); err != nil {
+		return "", fmt.Errorf("failed to store API key in the system keyring: %w", err)
+	}
+	return StorageKeyring, nil
+}
+
+func (keyringStore) Delete() error {
+	if err := keyring.Delete(constants.KeyringServiceName, "api_key"); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("keyring: %w", err)
+	}
+	return nil
+}
+
+type fileStore struct{}
+
+func (fileStore) Get() (string, error) {
+	key, err := getFromFile()
+	if errors.Is(err, ErrNotFound) {
+		return "", fmt.Errorf("no API key in the credentials file: %w", ErrNotFound)
+	}
+	return key, err
+}
+
+func (fileStore) Set(apiKey string) (StorageType, error) {
+	if err := setToFile(apiKey); err != nil {
+		return "", fmt.Errorf("failed to store API key: %w", err)
+	}
+	return StorageFile, nil
+}
+
+func (fileStore) Delete() error { return deleteFromFile() }
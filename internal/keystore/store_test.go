@@ -0,0 +1,118 @@
+package keystore
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestSelect(t *testing.T) {
+	for name, want := range map[string]CredentialStore{
+		"":         Auto(),
+		"auto":     Auto(),
+		"env":      Env(),
+		" Keyring": Keyring(),
+		"file":     File(),
+	} {
+		store, err := Select(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, store, name)
+	}
+
+	_, err := Select("vault")
+	assert.ErrorContains(t, err, `unknown credential store "vault"`)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(StoreEnvVarName, "file")
+	store, err := FromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, File(), store)
+
+	t.Setenv(StoreEnvVarName, "bogus")
+	_, err = FromEnv()
+	assert.ErrorContains(t, err, StoreEnvVarName)
+}
+
+func TestEnvStore(t *testing.T) {
+	cleanupTestEnv(t)
+	defer cleanupTestEnv(t)
+
+	_, err := Env().Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	os.Setenv(EnvVarName, "api-key")
+	token, err := Env().Get()
+	require.NoError(t, err)
+	assert.Equal(t, "api-key", token)
+
+	os.Setenv(TokenEnvVarName, "token")
+	token, err = Env().Get()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token, "KUBEASY_TOKEN takes precedence")
+
+	_, err = Env().Set("other")
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.NoError(t, Env().Delete())
+}
+
+func TestGet_TokenEnvVar(t *testing.T) {
+	cleanupTestEnv(t)
+	defer cleanupTestEnv(t)
+
+	require.NoError(t, keyring.Set("kubeasy-cli", "api_key", "keyring-token"))
+	os.Setenv(TokenEnvVarName, "ci-token")
+
+	token, err := Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ci-token", token)
+	assert.Equal(t, StorageEnv, GetStorageType())
+}
+
+func TestKeyringStore(t *testing.T) {
+	cleanupTestEnv(t)
+	defer cleanupTestEnv(t)
+
+	// The keyring store ignores the other backends.
+	os.Setenv(TokenEnvVarName, "ci-token")
+	require.NoError(t, setToFile("file-token"))
+	_, err := Keyring().Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	storageType, err := Keyring().Set("keyring-token")
+	require.NoError(t, err)
+	assert.Equal(t, StorageKeyring, storageType)
+	token, err := Keyring().Get()
+	require.NoError(t, err)
+	assert.Equal(t, "keyring-token", token)
+
+	require.NoError(t, Keyring().Delete())
+	_, err = Keyring().Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+	token, err = getFromFile()
+	require.NoError(t, err)
+	assert.Equal(t, "file-token", token, "the file is left alone")
+}
+
+func TestFileStore(t *testing.T) {
+	cleanupTestEnv(t)
+	defer cleanupTestEnv(t)
+
+	require.NoError(t, keyring.Set("kubeasy-cli", "api_key", "keyring-token"))
+	_, err := File().Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	storageType, err := File().Set("file-token")
+	require.NoError(t, err)
+	assert.Equal(t, StorageFile, storageType)
+	token, err := File().Get()
+	require.NoError(t, err)
+	assert.Equal(t, "file-token", token)
+
+	require.NoError(t, File().Delete())
+	_, err = File().Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+}
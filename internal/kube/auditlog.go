@@ -0,0 +1,110 @@
+package kube
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AuditConfigMapName is the ConfigMap, next to the cluster marker, that keeps a copy
+// of the cluster mutations of recent runs when runlog.ClusterAuditEnabled.
+const AuditConfigMapName = "kubeasy-audit"
+
+// Limits that keep the audit ConfigMap far below the 1MiB object size limit.
+const (
+	maxAuditRuns         = 50
+	maxAuditEventsPerRun = 200
+)
+
+// AppendClusterAudit stores the mutations of run runID in the audit ConfigMap, one
+// key per run holding one JSON mutation per line. Only the newest runs are kept.
+func AppendClusterAudit(ctx context.Context, clientset kubernetes.Interface, runID string, mutations []runlog.Mutation) error {
+	if len(mutations) == 0 {
+		return nil
+	}
+	if len(mutations) > maxAuditEventsPerRun {
+		mutations = mutations[len(mutations)-maxAuditEventsPerRun:]
+	}
+	var buf bytes.Buffer
+	for _, m := range mutations {
+		line, err := json.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit event: %w", err)
+		}
+		buf.Write(append(line, '\n'))
+	}
+
+	cms := clientset.CoreV1().ConfigMaps(MarkerNamespace)
+	cm, err := cms.Get(ctx, AuditConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      AuditConfigMapName,
+				Namespace: MarkerNamespace,
+				Labels:    map[string]string{markerManagedByKey: markerManagedBy},
+			},
+			Data: map[string]string{runID: buf.String()},
+		}
+		if _, err := cms.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create audit ConfigMap: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read audit ConfigMap: %w", err)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[runID] = buf.String()
+	// Run IDs sort chronologically
+	ids := make([]string, 0, len(cm.Data))
+	for id := range cm.Data {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids[:max(0, len(ids)-maxAuditRuns)] {
+		delete(cm.Data, id)
+	}
+	if _, err := cms.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update audit ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// ReadClusterAudit returns the mutations kept in the audit ConfigMap, oldest first.
+// A cluster without the ConfigMap has none.
+func ReadClusterAudit(ctx context.Context, clientset kubernetes.Interface) ([]runlog.Mutation, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(MarkerNamespace).Get(ctx, AuditConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit ConfigMap: %w", err)
+	}
+
+	var mutations []runlog.Mutation
+	for runID, data := range cm.Data {
+		scanner := bufio.NewScanner(bytes.NewBufferString(data))
+		for scanner.Scan() {
+			var m runlog.Mutation
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				continue
+			}
+			m.RunID = runID
+			mutations = append(mutations, m)
+		}
+	}
+	sort.SliceStable(mutations, func(i, j int) bool { return mutations[i].Time.Before(mutations[j].Time) })
+	return mutations, nil
+}
@@ -0,0 +1,62 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClusterAudit_AppendAndRead(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	mutation := func(runID string, offset time.Duration, verb string) runlog.Mutation {
+		return runlog.Mutation{RunID: runID, Command: "kubeasy challenge start pod-evicted", Event: runlog.Event{
+			Time: start.Add(offset), Type: runlog.EventMutation, Scope: runlog.ScopeCluster, Verb: verb, Target: "/api/v1/namespaces", Status: runlog.OutcomeOK,
+		}}
+	}
+	require.NoError(t, AppendClusterAudit(ctx, clientset, "run-1", []runlog.Mutation{mutation("run-1", 0, "create")}))
+	require.NoError(t, AppendClusterAudit(ctx, clientset, "run-2", []runlog.Mutation{mutation("run-2", time.Minute, "delete")}))
+	require.NoError(t, AppendClusterAudit(ctx, clientset, "run-3", nil), "a run without mutations is not stored")
+
+	mutations, err := ReadClusterAudit(ctx, clientset)
+	require.NoError(t, err)
+	require.Len(t, mutations, 2)
+	assert.Equal(t, "run-1", mutations[0].RunID)
+	assert.Equal(t, "create", mutations[0].Verb)
+	assert.Equal(t, "kubeasy challenge start pod-evicted", mutations[0].Command)
+	assert.Equal(t, "delete", mutations[1].Verb)
+
+	cm, err := clientset.CoreV1().ConfigMaps(MarkerNamespace).Get(ctx, AuditConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, markerManagedBy, cm.Labels[markerManagedByKey])
+}
+
+func TestClusterAudit_KeepsNewestRuns(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+	for i := range maxAuditRuns + 3 {
+		runID := fmt.Sprintf("2024-06-01T10-00-%02d.000Z", i)
+		require.NoError(t, AppendClusterAudit(ctx, clientset, runID, []runlog.Mutation{{RunID: runID, Event: runlog.Event{Type: runlog.EventMutation}}}))
+	}
+
+	cm, err := clientset.CoreV1().ConfigMaps(MarkerNamespace).Get(ctx, AuditConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, cm.Data, maxAuditRuns)
+	assert.NotContains(t, cm.Data, "2024-06-01T10-00-00.000Z")
+	assert.Contains(t, cm.Data, fmt.Sprintf("2024-06-01T10-00-%02d.000Z", maxAuditRuns+2))
+}
+
+func TestReadClusterAudit_NoConfigMap(t *testing.T) {
+	mutations, err := ReadClusterAudit(context.Background(), fake.NewClientset())
+	require.NoError(t, err)
+	assert.Empty(t, mutations)
+}
@@ -2,16 +2,21 @@ package kube
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/client-go/rest"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/runlog"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,10 +66,11 @@ func GetKubernetesClient() (*kubernetes.Clientset, error) {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		logger.Error("Error creating Kubernetes client: %v", err)
-		return nil, fmt.Errorf("error creating Kubernetes client: %w", err)
+		return nil, kerrors.New(kerrors.CodeKubeClient, "error creating Kubernetes client: %w", err)
 	}
 
 	logger.Info("Kubernetes clientset obtained successfully for context %s.", constants.KubeasyClusterContext)
+	detectServerCapabilities(config)
 	return clientset, nil
 }
 
@@ -84,7 +90,7 @@ func getRestConfig() (*rest.Config, error) {
 		configOverrides,
 	).ClientConfig()
 	if err != nil {
-		return nil, err
+		return nil, kubeconfigError(err)
 	}
 
 	// Enable HTTP request/response logging in debug mode
@@ -95,6 +101,10 @@ func getRestConfig() (*rest.Config, error) {
 			return &LoggingRoundTripper{rt: rt}
 		}
 	}
+	// Record every mutating request in the run log (see 'kubeasy audit')
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return runlog.Transport(runlog.ScopeCluster, rt)
+	})
 
 	return config, nil
 }
@@ -131,7 +141,7 @@ func GetDynamicClient() (dynamic.Interface, error) {
 	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
 		logger.Error("Error creating dynamic client: %v", err)
-		return nil, fmt.Errorf("error creating dynamic client: %w", err)
+		return nil, kerrors.New(kerrors.CodeKubeClient, "error creating dynamic client: %w", err)
 	}
 
 	logger.Info("Kubernetes dynamic client obtained successfully for context %s.", constants.KubeasyClusterContext)
@@ -180,50 +190,56 @@ func CreateNamespace(ctx context.Context, clientset kubernetes.Interface, namesp
 }
 
 // WaitForNamespaceActive waits for a namespace to reach the Active phase.
-// This is important to avoid race conditions when ArgoCD tries to sync resources
-// to a namespace that isn't fully ready yet.
+// This is important to avoid race conditions when resources are applied
+// to a namespace that isn't fully ready yet. The phase is checked right away, then
+// every namespacePollInterval; without a deadline on ctx the wait gives up after 30s.
 func WaitForNamespaceActive(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
 	logger.Debug("Waiting for namespace '%s' to become Active...", namespace)
 
-	// Use a default timeout if context has no deadline
 	waitCtx := ctx
-	var cancel context.CancelFunc
 	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
 		waitCtx, cancel = context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
 	}
 
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-waitCtx.Done():
-			logger.Error("Timeout waiting for namespace '%s' to become Active", namespace)
-			return fmt.Errorf("timeout waiting for namespace '%s' to become Active: %w", namespace, waitCtx.Err())
-		case <-ticker.C:
-			ns, err := clientset.CoreV1().Namespaces().Get(waitCtx, namespace, metav1.GetOptions{})
-			if err != nil {
-				logger.Warning("Error checking namespace '%s' status: %v (retrying...)", namespace, err)
-				continue
-			}
-
-			logger.Debug("Namespace '%s' phase: %s", namespace, ns.Status.Phase)
-
-			if ns.Status.Phase == corev1.NamespaceActive {
-				logger.Info("Namespace '%s' is now Active", namespace)
-				return nil
-			}
+	err := wait.PollUntilContextCancel(waitCtx, namespacePollInterval, true, func(ctx context.Context) (bool, error) {
+		ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			logger.Warning("Error checking namespace '%s' status: %v (retrying...)", namespace, err)
+			return false, nil
+		}
 
+		logger.Debug("Namespace '%s' phase: %s", namespace, ns.Status.Phase)
+		switch ns.Status.Phase {
+		case corev1.NamespaceActive:
+			return true, nil
+		case corev1.NamespaceTerminating:
 			// If namespace is terminating, something is wrong
-			if ns.Status.Phase == corev1.NamespaceTerminating {
-				logger.Error("Namespace '%s' is Terminating unexpectedly", namespace)
-				return fmt.Errorf("namespace '%s' is Terminating", namespace)
-			}
+			return false, errNamespaceTerminating
+		default:
+			return false, nil
 		}
+	})
+	switch {
+	case errors.Is(err, errNamespaceTerminating):
+		logger.Error("Namespace '%s' is Terminating unexpectedly", namespace)
+		return fmt.Errorf("namespace '%s' is Terminating", namespace)
+	case err != nil:
+		logger.Error("Timeout waiting for namespace '%s' to become Active", namespace)
+		return fmt.Errorf("timeout waiting for namespace '%s' to become Active: %w", namespace, err)
 	}
+	logger.Info("Namespace '%s' is now Active", namespace)
+	return nil
 }
 
+// namespacePollInterval is how often WaitForNamespaceActive re-reads the namespace phase.
+const namespacePollInterval = 500 * time.Millisecond
+
+// errNamespaceTerminating stops WaitForNamespaceActive early: a Terminating namespace
+// never becomes Active again.
+var errNamespaceTerminating = errors.New("namespace is terminating")
+
 // DeleteNamespace deletes a namespace if it exists
 func DeleteNamespace(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
 	logger.Debug("Checking if namespace '%s' exists for deletion...", namespace)
@@ -256,83 +272,198 @@ func DeleteNamespace(ctx context.Context, clientset kubernetes.Interface, namesp
 	return nil
 }
 
-// WaitForDeploymentsReady waits for deployments to become ready in a namespace
-func WaitForDeploymentsReady(ctx context.Context, clientset *kubernetes.Clientset, namespace string, deploymentNames []string) error {
-	logger.Info("Waiting for Deployments in namespace '%s' to be ready: %s", namespace, strings.Join(deploymentNames, ", "))
-	for _, deploymentName := range deploymentNames {
-		logger.Debug("Waiting for Deployment %s/%s to become ready...", namespace, deploymentName)
-		err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
-			deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					logger.Warning("Deployment %s/%s not found, retrying...", namespace, deploymentName)
-					return false, nil
-				}
-				return false, fmt.Errorf("error getting Deployment %s/%s: %w", namespace, deploymentName, err)
-			}
-			if deployment.Spec.Replicas == nil {
-				logger.Debug("Deployment %s/%s spec.replicas not set yet, retrying...", namespace, deploymentName)
-				return false, nil
-			}
-			desired := *deployment.Spec.Replicas
-			logger.Debug("Deployment %s/%s: Ready=%d/%d, Updated=%d, Available=%d",
-				namespace, deploymentName,
-				deployment.Status.ReadyReplicas, desired,
-				deployment.Status.UpdatedReplicas, deployment.Status.AvailableReplicas)
-			if deployment.Generation <= deployment.Status.ObservedGeneration &&
-				deployment.Status.UpdatedReplicas >= desired &&
-				deployment.Status.AvailableReplicas >= desired &&
-				deployment.Status.ReadyReplicas >= desired {
-				logger.Info("Deployment %s/%s is ready.", namespace, deploymentName)
-				return true, nil
+// readyTimeout bounds how long WaitForDeploymentsReady and WaitForStatefulSetsReady
+// wait for all of their objects together.
+const readyTimeout = 5 * time.Minute
+
+// readyPollInterval is how often each object's readiness is polled.
+const readyPollInterval = 2 * time.Second
+
+// WaitForDeploymentsReady waits for deployments to become ready in a namespace. They are
+// waited for concurrently, under a shared deadline.
+func WaitForDeploymentsReady(ctx context.Context, clientset kubernetes.Interface, namespace string, deploymentNames []string) error {
+	return waitForAllReady(ctx, "Deployment", namespace, deploymentNames, func(ctx context.Context, name string) (ResourceStatus, error) {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return ResourceStatus{Health: HealthProgressing, Message: "not found"}, nil
 			}
-			return false, nil
-		})
+			return ResourceStatus{}, fmt.Errorf("error getting Deployment %s/%s: %w", namespace, name, err)
+		}
+		ready, status := deploymentReady(deployment)
+		if ready {
+			return ResourceStatus{Health: HealthReady, Message: status}, nil
+		}
+		reason := deploymentDegraded(deployment)
+		if reason == "" {
+			reason = podsDegraded(ctx, clientset, namespace, deployment.Spec.Selector)
+		}
+		if reason != "" {
+			return ResourceStatus{Health: HealthDegraded, Message: status + "; " + reason}, nil
+		}
+		return ResourceStatus{Health: HealthProgressing, Message: status}, nil
+	})
+}
+
+// WaitForStatefulSetsReady waits for statefulsets to become ready in a namespace. They are
+// waited for concurrently, under a shared deadline.
+func WaitForStatefulSetsReady(ctx context.Context, clientset kubernetes.Interface, namespace string, stsNames []string) error {
+	return waitForAllReady(ctx, "StatefulSet", namespace, stsNames, func(ctx context.Context, name string) (ResourceStatus, error) {
+		sts, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			return fmt.Errorf("timeout waiting for Deployment %s/%s to be ready: %w", namespace, deploymentName, err)
+			if apierrors.IsNotFound(err) {
+				return ResourceStatus{Health: HealthProgressing, Message: "not found"}, nil
+			}
+			return ResourceStatus{}, fmt.Errorf("error getting StatefulSet %s/%s: %w", namespace, name, err)
+		}
+		ready, status := statefulSetReady(sts)
+		if ready {
+			return ResourceStatus{Health: HealthReady, Message: status}, nil
 		}
+		if reason := podsDegraded(ctx, clientset, namespace, sts.Spec.Selector); reason != "" {
+			return ResourceStatus{Health: HealthDegraded, Message: status + "; " + reason}, nil
+		}
+		return ResourceStatus{Health: HealthProgressing, Message: status}, nil
+	})
+}
+
+// deploymentReady reports whether a Deployment has rolled out, with a one-line status.
+func deploymentReady(d *appsv1.Deployment) (bool, string) {
+	if d.Spec.Replicas == nil {
+		return false, "spec.replicas not set yet"
 	}
-	logger.Info("All specified Deployments in namespace %s are ready.", namespace)
-	return nil
+	desired := *d.Spec.Replicas
+	status := fmt.Sprintf("Ready=%d/%d, Updated=%d, Available=%d",
+		d.Status.ReadyReplicas, desired, d.Status.UpdatedReplicas, d.Status.AvailableReplicas)
+	ready := d.Generation <= d.Status.ObservedGeneration &&
+		d.Status.UpdatedReplicas >= desired &&
+		d.Status.AvailableReplicas >= desired &&
+		d.Status.ReadyReplicas >= desired
+	return ready, status
 }
 
-// WaitForStatefulSetsReady waits for statefulsets to become ready in a namespace
-func WaitForStatefulSetsReady(ctx context.Context, clientset *kubernetes.Clientset, namespace string, stsNames []string) error {
-	logger.Info("Waiting for StatefulSets in namespace '%s' to be ready: %s", namespace, strings.Join(stsNames, ", "))
-	for _, stsName := range stsNames {
-		logger.Debug("Waiting for StatefulSet %s/%s to become ready...", namespace, stsName)
-		err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
-			sts, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, stsName, metav1.GetOptions{})
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					logger.Warning("StatefulSet %s/%s not found, retrying...", namespace, stsName)
-					return false, nil
+// statefulSetReady reports whether a StatefulSet has rolled out, with a one-line status.
+func statefulSetReady(sts *appsv1.StatefulSet) (bool, string) {
+	if sts.Spec.Replicas == nil {
+		return false, "spec.replicas not set yet"
+	}
+	desired := *sts.Spec.Replicas
+	status := fmt.Sprintf("Ready=%d/%d, Updated=%d, CurrentRevision=%s, UpdateRevision=%s",
+		sts.Status.ReadyReplicas, desired, sts.Status.UpdatedReplicas,
+		sts.Status.CurrentRevision, sts.Status.UpdateRevision)
+	ready := sts.Generation <= sts.Status.ObservedGeneration &&
+		sts.Status.ReadyReplicas >= desired &&
+		sts.Status.UpdatedReplicas >= desired &&
+		sts.Status.CurrentRevision == sts.Status.UpdateRevision
+	return ready, status
+}
+
+// readyCheck returns the status of the named object; its Message is used in logs and
+// error reports. The object is ready when its Health is HealthReady. A non-nil error
+// stops the wait.
+type readyCheck func(ctx context.Context, name string) (ResourceStatus, error)
+
+// waitForAllReady polls every named object concurrently until all are ready, readyTimeout
+// elapses or a check fails, reporting their statuses to the ReadyProgressFunc of ctx
+// meanwhile. The
+// error names the first failure and lists the last known status of every object that
+// was not ready, or the error of its last check when that check failed.
+func waitForAllReady(ctx context.Context, kind, namespace string, names []string, check readyCheck) error {
+	if len(names) == 0 {
+		return nil
+	}
+	logger.Info("Waiting for %ss in namespace '%s' to be ready: %s", kind, namespace, strings.Join(names, ", "))
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		mu       sync.Mutex
+		statuses = make([]ResourceStatus, len(names))
+		failed   = make([]bool, len(names))
+		// checkErrs holds the error of each object's last check, when it failed
+		checkErrs = make([]error, len(names))
+	)
+	for i, name := range names {
+		statuses[i] = ResourceStatus{Kind: kind, Name: name, Health: HealthProgressing}
+	}
+	snapshot := func() []ResourceStatus {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]ResourceStatus(nil), statuses...)
+	}
+	if report := readyProgressFrom(parent); report != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			ticker := time.NewTicker(readyProgressInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					report(namespace, snapshot())
+				case <-done:
+					return
 				}
-				return false, fmt.Errorf("error getting StatefulSet %s/%s: %w", namespace, stsName, err)
 			}
-			if sts.Spec.Replicas == nil {
-				logger.Debug("StatefulSet %s/%s spec.replicas not set yet, retrying...", namespace, stsName)
-				return false, nil
-			}
-			desired := *sts.Spec.Replicas
-			logger.Debug("StatefulSet %s/%s: Ready=%d/%d, Updated=%d, CurrentRevision=%s, UpdateRevision=%s",
-				namespace, stsName,
-				sts.Status.ReadyReplicas, desired,
-				sts.Status.UpdatedReplicas,
-				sts.Status.CurrentRevision, sts.Status.UpdateRevision)
-			if sts.Generation <= sts.Status.ObservedGeneration &&
-				sts.Status.ReadyReplicas >= desired &&
-				sts.Status.UpdatedReplicas >= desired &&
-				sts.Status.CurrentRevision == sts.Status.UpdateRevision {
-				logger.Info("StatefulSet %s/%s is ready.", namespace, stsName)
-				return true, nil
+		}()
+	}
+	for i, name := range names {
+		wg.Go(func() {
+			err := wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+				status, err := check(ctx, name)
+				if err != nil {
+					mu.Lock()
+					checkErrs[i] = err
+					mu.Unlock()
+					return false, err
+				}
+				status.Kind, status.Name = kind, name
+				mu.Lock()
+				statuses[i] = status
+				mu.Unlock()
+				logger.Debug("%s %s/%s: %s (%s)", kind, namespace, name, status.Health, status.Message)
+				return status.Health == HealthReady, nil
+			})
+			if err != nil {
+				failed[i] = true
+				once.Do(func() {
+					reason := "timeout"
+					if parent.Err() != nil {
+						reason = "interrupted"
+					}
+					firstErr = fmt.Errorf("%s waiting for %s %s/%s to be ready: %w", reason, kind, namespace, name, err)
+				})
+				// One failure fails the whole wait, so stop polling the others
+				cancel()
+				return
 			}
-			return false, nil
+			logger.Info("%s %s/%s is ready.", kind, namespace, name)
 		})
-		if err != nil {
-			return fmt.Errorf("timeout waiting for StatefulSet %s/%s to be ready: %w", namespace, stsName, err)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		logger.Info("All specified %ss in namespace %s are ready.", kind, namespace)
+		return nil
+	}
+	var pending []string
+	for i, name := range names {
+		if !failed[i] {
+			continue
+		}
+		status := statuses[i].Message
+		switch {
+		case checkErrs[i] != nil:
+			status = fmt.Sprintf("check failed: %v", checkErrs[i])
+		case status == "":
+			status = "not checked yet"
 		}
+		pending = append(pending, fmt.Sprintf("%s (%s)", name, status))
 	}
-	logger.Info("All specified StatefulSets in namespace %s are ready.", namespace)
-	return nil
+	return fmt.Errorf("%w; not ready: %s", firstErr, strings.Join(pending, ", "))
 }
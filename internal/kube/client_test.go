@@ -2,7 +2,9 @@ package kube
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -168,8 +170,10 @@ func TestWaitForNamespaceActive(t *testing.T) {
 		clientset := fake.NewClientset(ns)
 		ctx := context.Background()
 
+		start := time.Now()
 		err := WaitForNamespaceActive(ctx, clientset, "active-namespace")
 		require.NoError(t, err)
+		assert.Less(t, time.Since(start), namespacePollInterval, "the phase is checked before the first poll interval")
 	})
 
 	t.Run("returns error when namespace is Terminating", func(t *testing.T) {
@@ -452,11 +456,7 @@ func TestWaitForDeploymentsReady_Logic(t *testing.T) {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				desiredReplicas := *tt.deployment.Spec.Replicas
-				ready := tt.deployment.Generation <= tt.deployment.Status.ObservedGeneration &&
-					tt.deployment.Status.UpdatedReplicas >= desiredReplicas &&
-					tt.deployment.Status.AvailableReplicas >= desiredReplicas &&
-					tt.deployment.Status.ReadyReplicas >= desiredReplicas
+				ready, _ := deploymentReady(tt.deployment)
 
 				assert.Equal(t, tt.isReady, ready, "Readiness check failed: %s", tt.reason)
 			})
@@ -537,11 +537,7 @@ func TestWaitForStatefulSetsReady_Logic(t *testing.T) {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				desiredReplicas := *tt.statefulSet.Spec.Replicas
-				ready := tt.statefulSet.Generation <= tt.statefulSet.Status.ObservedGeneration &&
-					tt.statefulSet.Status.ReadyReplicas >= desiredReplicas &&
-					tt.statefulSet.Status.UpdatedReplicas >= desiredReplicas &&
-					tt.statefulSet.Status.CurrentRevision == tt.statefulSet.Status.UpdateRevision
+				ready, _ := statefulSetReady(tt.statefulSet)
 
 				assert.Equal(t, tt.isReady, ready, "Readiness check failed: %s", tt.reason)
 			})
@@ -549,6 +545,92 @@ func TestWaitForStatefulSetsReady_Logic(t *testing.T) {
 	})
 }
 
+// TestWaitForAllReady_PollsConcurrently verifies every object is checked without waiting
+// for the previous one to become ready.
+func TestWaitForAllReady_PollsConcurrently(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var checked sync.WaitGroup
+	checked.Add(2)
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	err := waitForAllReady(ctx, "Deployment", "ns", []string{"web", "api"}, func(ctx context.Context, name string) (ResourceStatus, error) {
+		mu.Lock()
+		if !seen[name] {
+			seen[name] = true
+			checked.Done()
+		}
+		mu.Unlock()
+		// Neither becomes ready until both have been checked once
+		checked.Wait()
+		return ResourceStatus{Health: HealthReady, Message: "Ready=1/1"}, nil
+	})
+
+	require.NoError(t, err)
+}
+
+// TestWaitForAllReady_ReportsEveryPendingObject verifies the error names the first failure
+// and the last status, or check error, of each object that was not ready.
+func TestWaitForAllReady_ReportsEveryPendingObject(t *testing.T) {
+	err := waitForAllReady(context.Background(), "Deployment", "ns", []string{"web", "db"}, func(ctx context.Context, name string) (ResourceStatus, error) {
+		if name == "db" {
+			return ResourceStatus{}, errors.New("forbidden")
+		}
+		return ResourceStatus{Health: HealthProgressing, Message: "Ready=0/2, Updated=2, Available=0"}, nil
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout waiting for Deployment ns/db to be ready: forbidden")
+	assert.Contains(t, err.Error(), "web (Ready=0/2, Updated=2, Available=0)")
+	assert.Contains(t, err.Error(), "db (check failed: forbidden)")
+}
+
+// TestWaitForAllReady_ReportsLastCheckError verifies an object whose check failed after
+// earlier statuses is reported with the error, not its stale status.
+func TestWaitForAllReady_ReportsLastCheckError(t *testing.T) {
+	var calls int
+	err := waitForAllReady(context.Background(), "Deployment", "ns", []string{"web"}, func(ctx context.Context, name string) (ResourceStatus, error) {
+		calls++
+		if calls > 1 {
+			return ResourceStatus{}, errors.New("connection refused")
+		}
+		return ResourceStatus{Health: HealthProgressing, Message: "Ready=0/1"}, nil
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not ready: web (check failed: connection refused)")
+	assert.NotContains(t, err.Error(), "Ready=0/1")
+}
+
+// TestWaitForAllReady_InterruptedReportsProgress verifies cancelling the caller's context
+// stops the wait promptly and reports which objects were still not ready.
+func TestWaitForAllReady_InterruptedReportsProgress(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+	err := waitForAllReady(ctx, "Deployment", "ns", []string{"web", "api"}, func(ctx context.Context, name string) (ResourceStatus, error) {
+		if name == "api" {
+			return ResourceStatus{Health: HealthReady, Message: "Ready=1/1"}, nil
+		}
+		cancel()
+		return ResourceStatus{Health: HealthProgressing, Message: "Ready=0/1"}, nil
+	})
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), readyPollInterval, "the wait must not outlive the cancellation")
+	assert.Contains(t, err.Error(), "interrupted waiting for Deployment ns/web")
+	assert.Contains(t, err.Error(), "not ready: web (Ready=0/1)")
+	assert.NotContains(t, err.Error(), "api (")
+}
+
+func TestWaitForAllReady_EmptyList(t *testing.T) {
+	err := waitForAllReady(context.Background(), "StatefulSet", "ns", nil, func(ctx context.Context, name string) (ResourceStatus, error) {
+		t.Fatal("no object should be checked")
+		return ResourceStatus{}, nil
+	})
+	assert.NoError(t, err)
+}
+
 // TestLoggingRoundTripper tests the HTTP logging wrapper
 func TestLoggingRoundTripper(t *testing.T) {
 	t.Run("wraps transport correctly", func(t *testing.T) {
@@ -1,19 +1,34 @@
 package kube
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
+// kubeconfigOverride is the kubeconfig set by UseKubeconfig.
+var kubeconfigOverride string
+
+// UseKubeconfig makes every kubeconfig read and write of this package use path
+// instead of KUBECONFIG and ~/.kube/config, e.g. a scratch kubeconfig that must not
+// touch the user's. An empty path restores the defaults.
+func UseKubeconfig(path string) { kubeconfigOverride = path }
+
 // GetKubeConfigPath returns the path to the kubeconfig file
 func GetKubeConfigPath() string {
+	if kubeconfigOverride != "" {
+		return kubeconfigOverride
+	}
 	if envPath := os.Getenv("KUBECONFIG"); envPath != "" {
 		return envPath
 	}
@@ -37,14 +52,28 @@ func GetRestConfig() (*rest.Config, error) {
 		configOverrides,
 	).ClientConfig()
 	if err != nil {
-		return nil, fmt.Errorf("error building kubeconfig with context %s: %w", constants.KubeasyClusterContext, err)
+		return nil, fmt.Errorf("error building kubeconfig with context %s: %w", constants.KubeasyClusterContext, kubeconfigError(err))
 	}
 
 	return config, nil
 }
 
+// kubeconfigError gives an error loading the kubeasy context its code: a missing
+// kubeconfig or context is KUBE_CTX_NOT_FOUND, anything else KUBE_CLIENT.
+func kubeconfigError(err error) error {
+	// A context override naming a missing context fails with an untyped error
+	missingContext := strings.Contains(err.Error(), fmt.Sprintf("context %q does not exist", constants.KubeasyClusterContext))
+	if missingContext || clientcmd.IsContextNotFound(err) || clientcmd.IsEmptyConfig(err) || errors.Is(err, fs.ErrNotExist) {
+		return kerrors.Wrap(kerrors.CodeKubeContextNotFound, err)
+	}
+	return kerrors.Wrap(kerrors.CodeKubeClient, err)
+}
+
 // GetDefaultKubeconfigPath returns the default path for the kubeconfig file.
 func GetDefaultKubeconfigPath() string {
+	if kubeconfigOverride != "" {
+		return kubeconfigOverride
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		// Handle error appropriately, maybe return empty or log
@@ -97,3 +126,20 @@ func SetNamespaceForContext(contextName, namespace string) error {
 	logger.Info("Successfully set namespace to '%s' and current-context to '%s' in kubeconfig '%s'", namespace, contextName, configPath)
 	return nil
 }
+
+// ContextNamespace returns the default namespace of a context of the kubeconfig
+// SetNamespaceForContext edits, or "" when the context has none or does not exist.
+func ContextNamespace(contextName string) (string, error) {
+	configPath := GetDefaultKubeconfigPath()
+	if configPath == "" {
+		return "", fmt.Errorf("could not determine default kubeconfig path")
+	}
+	config, err := clientcmd.LoadFromFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig from '%s': %w", configPath, err)
+	}
+	if context, ok := config.Contexts[contextName]; ok {
+		return context.Namespace, nil
+	}
+	return "", nil
+}
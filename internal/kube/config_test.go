@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/tools/clientcmd"
@@ -260,3 +261,21 @@ func TestGetRestConfig_WithTempKubeconfig(t *testing.T) {
 	assert.Equal(t, "https://localhost:6443", restConfig.Host)
 	assert.Equal(t, "test-token", restConfig.BearerToken)
 }
+
+func TestGetRestConfig_ErrorCodes(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("KUBECONFIG", filepath.Join(dir, "missing"))
+	_, err := GetRestConfig()
+	require.Error(t, err)
+	assert.Equal(t, kerrors.CodeKubeContextNotFound, kerrors.CodeOf(err), "no kubeconfig")
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["other"] = &clientcmdapi.Cluster{Server: "https://localhost:6443"}
+	config.Contexts["other"] = &clientcmdapi.Context{Cluster: "other"}
+	path := filepath.Join(dir, "config")
+	require.NoError(t, clientcmd.WriteToFile(*config, path))
+	t.Setenv("KUBECONFIG", path)
+	_, err = GetRestConfig()
+	require.Error(t, err)
+	assert.Equal(t, kerrors.CodeKubeContextNotFound, kerrors.CodeOf(err), "no kubeasy context")
+}
@@ -0,0 +1,259 @@
+package kube
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+)
+
+// Downloaded manifests are cached under ~/.kubeasy/cache/manifests, addressed by the
+// SHA-256 of their content:
+//
+//	blobs/<sha256>      manifest content
+//	urls/<sha256(url)>  digest of the content last downloaded from the URL, and its ETag
+//	partial/<sha256(url)>[.json]  interrupted download and its ETag / encoding
+//
+// A cached manifest is revalidated with If-None-Match on its ETag, so branch URLs
+// and remote includes pick up upstream changes. Delete the directory to clear the cache.
+func manifestCacheDir() string {
+	return filepath.Join(constants.GetKubeasyConfigDir(), "cache", "manifests")
+}
+
+// DownloadProgress, when set, is called while a manifest downloads slowly, with the
+// bytes received so far and the total (-1 if unknown). Fast downloads never report.
+var DownloadProgress func(source string, received, total int64)
+
+// downloadProgressInterval is the delay before the first progress report and between reports.
+var downloadProgressInterval = 2 * time.Second
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedManifest is the cached content of a URL and the ETag it was served with.
+type cachedManifest struct {
+	data []byte
+	etag string
+}
+
+// readManifestCache returns the cached content of source, or nil. A blob whose
+// checksum no longer matches is discarded.
+func readManifestCache(source string) *cachedManifest {
+	dir := manifestCacheDir()
+	entry, err := os.ReadFile(filepath.Join(dir, "urls", sha256Hex([]byte(source))))
+	if err != nil {
+		return nil
+	}
+	// The ETag line is missing from entries written by older versions
+	digest, etag, _ := strings.Cut(strings.TrimSpace(string(entry)), "\n")
+	blobPath := filepath.Join(dir, "blobs", digest)
+	data, err := os.ReadFile(blobPath) //nolint:gosec // path built from a hex digest
+	if err != nil {
+		return nil
+	}
+	if sha256Hex(data) != digest {
+		logger.Debug("Cached manifest for %s is corrupted, downloading it again", source)
+		_ = os.Remove(blobPath)
+		return nil
+	}
+	return &cachedManifest{data: data, etag: strings.TrimSpace(etag)}
+}
+
+// writeManifestCache stores data, served with etag, as the content of source.
+func writeManifestCache(source string, data []byte, etag string) error {
+	dir := manifestCacheDir()
+	digest := sha256Hex(data)
+	if err := writeFileAtomic(filepath.Join(dir, "blobs", digest), data); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, "urls", sha256Hex([]byte(source))), []byte(digest+"\n"+etag+"\n"))
+}
+
+// writeFileAtomic writes data to path through a temp file and a rename, so concurrent
+// readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+// partialDownload describes the bytes saved by an interrupted download.
+type partialDownload struct {
+	ETag     string `json:"etag"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// errNotModified is returned by downloadManifest when the server answers 304 Not
+// Modified to the ETag of the cached content.
+var errNotModified = errors.New("manifest not modified")
+
+// downloadManifest performs a single download attempt. It asks for a gzip-compressed
+// transfer and saves the body as it arrives, so an attempt that fails midway is
+// resumed from where it stopped (Range + If-Range on the ETag) by the next attempt,
+// or by the next command. A non-empty cachedETag is sent as If-None-Match. It returns
+// the ETag of the content; retryable reports whether the failure is transient.
+func downloadManifest(ctx context.Context, url, cachedETag string) (manifestBytes []byte, etag string, retryable bool, err error) {
+	partDir := filepath.Join(manifestCacheDir(), "partial")
+	if err := os.MkdirAll(partDir, 0o750); err != nil {
+		return nil, "", false, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	key := sha256Hex([]byte(url))
+	partPath, metaPath := filepath.Join(partDir, key), filepath.Join(partDir, key+".json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error building request for %s: %w", url, err)
+	}
+	// Setting the header ourselves turns off the transport's transparent decompression:
+	// byte ranges must address the compressed body that is saved on disk.
+	req.Header.Set("Accept-Encoding", "gzip")
+	if cachedETag != "" {
+		req.Header.Set("If-None-Match", cachedETag)
+	}
+	var meta partialDownload
+	var offset int64
+	if data, err := os.ReadFile(metaPath); err == nil && json.Unmarshal(data, &meta) == nil && meta.ETag != "" {
+		if fi, err := os.Stat(partPath); err == nil && fi.Size() > 0 {
+			offset = fi.Size()
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			req.Header.Set("If-Range", meta.ETag)
+		}
+	}
+
+	resp, err := manifestHTTPClient.Do(req) //nolint:gosec // URL validated against fetchManifestAllowedPrefixes
+	if err != nil {
+		return nil, "", isTransient(ctx, err), fmt.Errorf("error downloading manifest from %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cachedETag != "":
+		return nil, cachedETag, false, errNotModified
+	case resp.StatusCode == http.StatusPartialContent && offset > 0 && strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)):
+		logger.Debug("Resuming download of %s at byte %d", url, offset)
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		offset = 0
+		flags |= os.O_TRUNC
+		meta = partialDownload{ETag: resp.Header.Get("ETag"), Encoding: resp.Header.Get("Content-Encoding")}
+		data, _ := json.Marshal(meta)
+		if err := os.WriteFile(metaPath, data, 0o600); err != nil {
+			return nil, "", false, fmt.Errorf("failed to save download state: %w", err)
+		}
+	default:
+		// The saved bytes cannot be resumed (e.g. 416): start over on the next attempt
+		if resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			_ = os.Remove(partPath)
+			return nil, "", true, fmt.Errorf("error resuming download of manifest from %s: HTTP %d", url, resp.StatusCode)
+		}
+		retryable = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, "", retryable, fmt.Errorf("error downloading manifest from %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	f, err := os.OpenFile(partPath, flags, 0o600) //nolint:gosec // path built from a hex digest
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to save download: %w", err)
+	}
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	body := &progressReader{r: resp.Body, source: url, received: offset, total: total, next: time.Now().Add(downloadProgressInterval)}
+	_, copyErr := io.Copy(f, io.LimitReader(body, maxManifestBytes+1-offset))
+	if err := f.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+	if copyErr != nil {
+		return nil, "", isTransient(ctx, copyErr), fmt.Errorf("error downloading manifest from %s: %w", url, copyErr)
+	}
+
+	raw, err := os.ReadFile(partPath) //nolint:gosec // path built from a hex digest
+	_ = os.Remove(partPath)
+	_ = os.Remove(metaPath)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to read download: %w", err)
+	}
+	if meta.Encoding == "gzip" {
+		zr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, "", false, fmt.Errorf("error decompressing manifest from %s: %w", url, err)
+		}
+		manifestBytes, err = readLimited(zr, url)
+		return manifestBytes, meta.ETag, false, err
+	}
+	manifestBytes, err = readLimited(bytes.NewReader(raw), url)
+	return manifestBytes, meta.ETag, false, err
+}
+
+// isTransient reports whether a network error is worth another attempt.
+func isTransient(ctx context.Context, err error) bool {
+	var netErr net.Error
+	return ctx.Err() == nil && (errors.As(err, &netErr) && netErr.Timeout() ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF))
+}
+
+// progressReader reports DownloadProgress every downloadProgressInterval.
+type progressReader struct {
+	r        io.Reader
+	source   string
+	received int64
+	total    int64
+	next     time.Time
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.received += int64(n)
+	if DownloadProgress != nil && time.Now().After(p.next) {
+		DownloadProgress(p.source, p.received, p.total)
+		p.next = time.Now().Add(downloadProgressInterval)
+	}
+	return n, err
+}
+
+// FormatBytes renders a byte count for progress messages, e.g. "1.5 MiB".
+func FormatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return strconv.FormatFloat(float64(n)/(1<<20), 'f', 1, 64) + " MiB"
+	case n >= 1<<10:
+		return strconv.FormatFloat(float64(n)/(1<<10), 'f', 1, 64) + " KiB"
+	default:
+		return strconv.FormatInt(n, 10) + " B"
+	}
+}
@@ -0,0 +1,168 @@
+package kube
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchManifest_Cache(t *testing.T) {
+	var calls, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(simpleConfigMapManifest))
+	}))
+	defer srv.Close()
+	allowTestServer(t, srv)
+	url := srv.URL + "/v1.0.0/install.yaml"
+
+	for range 2 {
+		data, err := FetchManifest(context.Background(), url)
+		require.NoError(t, err)
+		assert.Equal(t, simpleConfigMapManifest, string(data))
+	}
+	assert.Equal(t, int32(1), notModified.Load(), "the second fetch is revalidated and served from the cache")
+
+	// A blob that no longer matches its checksum is downloaded again
+	blobs, err := filepath.Glob(filepath.Join(manifestCacheDir(), "blobs", "*"))
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+	require.NoError(t, os.WriteFile(blobs[0], []byte("tampered"), 0o600))
+
+	data, err := FetchManifest(context.Background(), url)
+	require.NoError(t, err)
+	assert.Equal(t, simpleConfigMapManifest, string(data))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+}
+
+func TestFetchManifest_CacheRevalidation(t *testing.T) {
+	content, etag := simpleConfigMapManifest, `"v1"`
+	unavailable := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+	allowTestServer(t, srv)
+	url := srv.URL + "/main/install.yaml"
+
+	data, err := FetchManifest(context.Background(), url)
+	require.NoError(t, err)
+	assert.Equal(t, simpleConfigMapManifest, string(data))
+
+	// The branch moved: the new content replaces the cached one
+	content, etag = strings.ReplaceAll(simpleConfigMapManifest, "test-config", "updated-config"), `"v2"`
+	data, err = FetchManifest(context.Background(), url)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	// Offline: the cached copy is used
+	unavailable = true
+	data, err = FetchManifest(context.Background(), url)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
+func TestFetchManifest_Gzip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write([]byte(simpleConfigMapManifest))
+		_ = zw.Close()
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+	allowTestServer(t, srv)
+
+	data, err := FetchManifest(context.Background(), srv.URL+"/install.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, simpleConfigMapManifest, string(data))
+}
+
+func TestFetchManifest_ResumesInterruptedDownload(t *testing.T) {
+	manifest := strings.Repeat(simpleConfigMapManifest+"---\n", 50)
+	half := len(manifest) / 2
+	var ranges []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if rng := r.Header.Get("Range"); rng != "" {
+			ranges = append(ranges, rng)
+			assert.Equal(t, `"v1"`, r.Header.Get("If-Range"))
+			w.Header().Set("Content-Range", "bytes "+strings.TrimPrefix(rng, "bytes=")+"*")
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(manifest[half:]))
+			return
+		}
+		// Announce the whole body but drop the connection halfway
+		w.Header().Set("Content-Length", strconv.Itoa(len(manifest)))
+		_, _ = w.Write([]byte(manifest[:half]))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer srv.Close()
+	allowTestServer(t, srv)
+
+	data, err := FetchManifest(context.Background(), srv.URL+"/install.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, manifest, string(data))
+	assert.Equal(t, []string{"bytes=" + strconv.Itoa(half) + "-"}, ranges)
+
+	leftovers, err := filepath.Glob(filepath.Join(manifestCacheDir(), "partial", "*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers, "completed downloads leave no partial file")
+}
+
+func TestFetchManifest_ReportsSlowDownloads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(simpleConfigMapManifest))
+	}))
+	defer srv.Close()
+	allowTestServer(t, srv)
+
+	origProgress, origInterval := DownloadProgress, downloadProgressInterval
+	t.Cleanup(func() { DownloadProgress, downloadProgressInterval = origProgress, origInterval })
+	downloadProgressInterval = 0
+	var received int64
+	DownloadProgress = func(source string, n, total int64) {
+		assert.Equal(t, srv.URL+"/install.yaml", source)
+		received = n
+	}
+
+	_, err := FetchManifest(context.Background(), srv.URL+"/install.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(simpleConfigMapManifest)), received)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "3.2 MiB", FormatBytes(3355443))
+}
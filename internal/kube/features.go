@@ -0,0 +1,113 @@
+package kube
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/semver"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// MinSupportedKubernetesVersion is the oldest Kubernetes minor release the CLI supports.
+const MinSupportedKubernetesVersion = "1.25"
+
+// Feature is a Kubernetes feature the CLI relies on, available from MinVersion.
+type Feature struct {
+	Name       string
+	MinVersion string
+}
+
+// Features gated on the server version.
+var (
+	FeatureEndpointSlices       = Feature{Name: "EndpointSlices (discovery.k8s.io/v1)", MinVersion: "1.21"}
+	FeatureEphemeralContainers  = Feature{Name: "ephemeral containers", MinVersion: "1.25"}
+	FeaturePodSecurityAdmission = Feature{Name: "Pod Security Admission labels", MinVersion: "1.25"}
+)
+
+// gatedFeatures lists the features reported by ServerCapabilities.Warnings.
+var gatedFeatures = []Feature{FeatureEndpointSlices, FeatureEphemeralContainers, FeaturePodSecurityAdmission}
+
+// ServerCapabilities describes what the connected API server supports.
+type ServerCapabilities struct {
+	// Version is the server version without the "v" prefix (e.g. "1.35.0"); empty when
+	// unknown, in which case every feature is assumed to be available.
+	Version string
+}
+
+// olderThan reports whether the server is older than the min major.minor version.
+func (c ServerCapabilities) olderThan(min string) bool {
+	if c.Version == "" {
+		return false
+	}
+	return semver.Compare(semver.Normalize(c.Version), semver.Normalize(min)) < 0
+}
+
+// Supports reports whether the server version provides f.
+func (c ServerCapabilities) Supports(f Feature) bool {
+	return !c.olderThan(f.MinVersion)
+}
+
+// Warnings describes, for a server older than MinSupportedKubernetesVersion, which
+// features are unavailable. It returns nothing for a supported server.
+func (c ServerCapabilities) Warnings() []string {
+	if !c.olderThan(MinSupportedKubernetesVersion) {
+		return nil
+	}
+	warnings := []string{fmt.Sprintf("Kubernetes %s is older than the oldest supported version (%s): some challenges and validations will not work", c.Version, MinSupportedKubernetesVersion)}
+	var missing []string
+	for _, f := range gatedFeatures {
+		if !c.Supports(f) {
+			missing = append(missing, fmt.Sprintf("%s (needs %s)", f.Name, f.MinVersion))
+		}
+	}
+	if len(missing) > 0 {
+		warnings = append(warnings, "Unavailable on this cluster: "+strings.Join(missing, ", "))
+	}
+	return warnings
+}
+
+// ServerWarning displays the warnings about the server version found at client
+// creation. Commands replace it with a UI function; it logs by default.
+var ServerWarning = func(msg string) { logger.Warning("%s", msg) }
+
+// serverVersionTimeout bounds the version query at client creation, so an unreachable
+// cluster fails the command's own request instead of hanging here.
+const serverVersionTimeout = 5 * time.Second
+
+var (
+	serverCapsOnce sync.Once
+	serverCaps     ServerCapabilities
+)
+
+// detectServerCapabilities queries the server version once per process, the first time
+// a client is created, and reports the warnings of an unsupported version.
+func detectServerCapabilities(config *rest.Config) {
+	serverCapsOnce.Do(func() {
+		cfg := rest.CopyConfig(config)
+		cfg.Timeout = serverVersionTimeout
+		dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			logger.Debug("Could not create discovery client: %v", err)
+			return
+		}
+		info, err := dc.ServerVersion()
+		if err != nil {
+			logger.Debug("Could not detect server version: %v", err)
+			return
+		}
+		serverCaps = ServerCapabilities{Version: strings.TrimPrefix(info.GitVersion, "v")}
+		for _, w := range serverCaps.Warnings() {
+			ServerWarning(w)
+		}
+	})
+}
+
+// DetectedServerCapabilities returns the capabilities of the server queried at client
+// creation. Its Version is empty when no client was created or the query failed.
+func DetectedServerCapabilities() ServerCapabilities {
+	return serverCaps
+}
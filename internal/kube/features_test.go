@@ -0,0 +1,39 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerCapabilities_Supports(t *testing.T) {
+	tests := []struct {
+		version string
+		feature Feature
+		want    bool
+	}{
+		{"1.35.0", FeatureEphemeralContainers, true},
+		{"1.25.0", FeaturePodSecurityAdmission, true},
+		{"1.24.17", FeaturePodSecurityAdmission, false},
+		{"1.21.0-eks-1234", FeatureEndpointSlices, true},
+		{"1.20.15+k3s1", FeatureEndpointSlices, false},
+		{"", FeatureEphemeralContainers, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+"/"+tt.feature.Name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ServerCapabilities{Version: tt.version}.Supports(tt.feature))
+		})
+	}
+}
+
+func TestServerCapabilities_Warnings(t *testing.T) {
+	assert.Empty(t, ServerCapabilities{Version: "1.35.0"}.Warnings())
+	assert.Empty(t, ServerCapabilities{}.Warnings(), "unknown version")
+
+	warnings := ServerCapabilities{Version: "1.23.4"}.Warnings()
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "older than the oldest supported version (1.25)")
+	assert.Contains(t, warnings[1], "ephemeral containers (needs 1.25)")
+	assert.NotContains(t, warnings[1], "EndpointSlices")
+}
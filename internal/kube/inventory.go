@@ -0,0 +1,180 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// InventoryNamespace lists what a challenge deployed in namespace — workloads
+// (Deployments, StatefulSets, DaemonSets), Services and NetworkPolicies — with
+// their health, in that order and by name within each kind. Workloads are classified
+// like WaitForDeploymentsReady does; Services are Ready once they have an address and
+// NetworkPolicies once they exist. A kind that cannot be listed is skipped and its
+// error returned alongside what could be listed.
+func InventoryNamespace(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]ResourceStatus, error) {
+	var (
+		statuses []ResourceStatus
+		firstErr error
+	)
+	collect := func(kind string, list func() ([]ResourceStatus, error)) {
+		items, err := list()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to list %ss in %s: %w", kind, namespace, err)
+			}
+			return
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+		for i := range items {
+			items[i].Kind = kind
+		}
+		statuses = append(statuses, items...)
+	}
+
+	collect("Deployment", func() ([]ResourceStatus, error) {
+		list, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]ResourceStatus, 0, len(list.Items))
+		for i := range list.Items {
+			d := &list.Items[i]
+			s := ResourceStatus{Name: d.Name, Health: HealthProgressing}
+			ready, msg := deploymentReady(d)
+			s.Message = msg
+			if ready {
+				s.Health = HealthReady
+			} else if reason := deploymentDegraded(d); reason != "" {
+				s.Health, s.Message = HealthDegraded, msg+"; "+reason
+			} else if reason := podsDegraded(ctx, clientset, namespace, d.Spec.Selector); reason != "" {
+				s.Health, s.Message = HealthDegraded, msg+"; "+reason
+			}
+			items = append(items, s)
+		}
+		return items, nil
+	})
+	collect("StatefulSet", func() ([]ResourceStatus, error) {
+		list, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]ResourceStatus, 0, len(list.Items))
+		for i := range list.Items {
+			sts := &list.Items[i]
+			s := ResourceStatus{Name: sts.Name, Health: HealthProgressing}
+			ready, msg := statefulSetReady(sts)
+			s.Message = msg
+			if ready {
+				s.Health = HealthReady
+			} else if reason := podsDegraded(ctx, clientset, namespace, sts.Spec.Selector); reason != "" {
+				s.Health, s.Message = HealthDegraded, msg+"; "+reason
+			}
+			items = append(items, s)
+		}
+		return items, nil
+	})
+	collect("DaemonSet", func() ([]ResourceStatus, error) {
+		list, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]ResourceStatus, 0, len(list.Items))
+		for i := range list.Items {
+			ds := &list.Items[i]
+			s := ResourceStatus{Name: ds.Name, Health: HealthProgressing}
+			ready, msg := daemonSetReady(ds)
+			s.Message = msg
+			if ready {
+				s.Health = HealthReady
+			} else if reason := podsDegraded(ctx, clientset, namespace, ds.Spec.Selector); reason != "" {
+				s.Health, s.Message = HealthDegraded, msg+"; "+reason
+			}
+			items = append(items, s)
+		}
+		return items, nil
+	})
+	collect("Service", func() ([]ResourceStatus, error) {
+		list, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]ResourceStatus, 0, len(list.Items))
+		for i := range list.Items {
+			items = append(items, serviceStatus(&list.Items[i]))
+		}
+		return items, nil
+	})
+	collect("NetworkPolicy", func() ([]ResourceStatus, error) {
+		list, err := clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]ResourceStatus, 0, len(list.Items))
+		for _, np := range list.Items {
+			selector := metav1.FormatLabelSelector(&np.Spec.PodSelector)
+			if selector == "<none>" || selector == "" {
+				selector = "all pods"
+			}
+			items = append(items, ResourceStatus{Name: np.Name, Health: HealthReady, Message: "selects " + selector})
+		}
+		return items, nil
+	})
+	return statuses, firstErr
+}
+
+// daemonSetReady reports whether a DaemonSet has rolled out, with a one-line status.
+func daemonSetReady(ds *appsv1.DaemonSet) (bool, string) {
+	desired := ds.Status.DesiredNumberScheduled
+	status := fmt.Sprintf("Ready=%d/%d, Updated=%d", ds.Status.NumberReady, desired, ds.Status.UpdatedNumberScheduled)
+	ready := ds.Generation <= ds.Status.ObservedGeneration &&
+		ds.Status.UpdatedNumberScheduled >= desired &&
+		ds.Status.NumberReady >= desired
+	return ready, status
+}
+
+// serviceStatus describes a Service by its type, address and ports. A LoadBalancer
+// without an ingress address yet is progressing.
+func serviceStatus(svc *corev1.Service) ResourceStatus {
+	s := ResourceStatus{Name: svc.Name, Health: HealthReady}
+	var ports []string
+	for _, p := range svc.Spec.Ports {
+		port := fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+		if p.NodePort != 0 {
+			port = fmt.Sprintf("%d:%d/%s", p.Port, p.NodePort, p.Protocol)
+		}
+		ports = append(ports, port)
+	}
+	address := svc.Spec.ClusterIP
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeExternalName:
+		address = svc.Spec.ExternalName
+	case corev1.ServiceTypeLoadBalancer:
+		address = "<pending>"
+		if ingress := svc.Status.LoadBalancer.Ingress; len(ingress) > 0 {
+			if ingress[0].IP != "" {
+				address = ingress[0].IP
+			} else if ingress[0].Hostname != "" {
+				address = ingress[0].Hostname
+			}
+		}
+		if address == "<pending>" {
+			s.Health = HealthProgressing
+		}
+	}
+	typ := svc.Spec.Type
+	if typ == "" {
+		typ = corev1.ServiceTypeClusterIP
+	}
+	s.Message = fmt.Sprintf("%s %s", typ, address)
+	if len(ports) > 0 {
+		s.Message += ", ports " + strings.Join(ports, ",")
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInventoryNamespace(t *testing.T) {
+	replicas := int32(1)
+	meta := func(name string) metav1.ObjectMeta { return metav1.ObjectMeta{Name: name, Namespace: "ns"} }
+	ready := &appsv1.Deployment{ObjectMeta: meta("web"), Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	ready.Status = appsv1.DeploymentStatus{ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+	stuck := &appsv1.Deployment{ObjectMeta: meta("api"), Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	stuck.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+	}}
+	db := &appsv1.StatefulSet{ObjectMeta: meta("db"), Spec: appsv1.StatefulSetSpec{Replicas: &replicas}}
+	svc := &corev1.Service{ObjectMeta: meta("web"), Spec: corev1.ServiceSpec{
+		ClusterIP: "10.0.0.1", Ports: []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolTCP}},
+	}}
+	lb := &corev1.Service{ObjectMeta: meta("public"), Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.2"}}
+	np := &networkingv1.NetworkPolicy{ObjectMeta: meta("deny-all")}
+	other := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other"}}
+
+	clientset := fake.NewClientset(ready, stuck, db, svc, lb, np, other)
+	statuses, err := InventoryNamespace(context.Background(), clientset, "ns")
+	require.NoError(t, err)
+
+	assert.Equal(t, []ResourceStatus{
+		{Kind: "Deployment", Name: "api", Health: HealthDegraded, Message: "Ready=0/1, Updated=0, Available=0; ProgressDeadlineExceeded"},
+		{Kind: "Deployment", Name: "web", Health: HealthReady, Message: "Ready=1/1, Updated=1, Available=1"},
+		{Kind: "StatefulSet", Name: "db", Health: HealthProgressing, Message: "Ready=0/1, Updated=0, CurrentRevision=, UpdateRevision="},
+		{Kind: "Service", Name: "public", Health: HealthProgressing, Message: "LoadBalancer <pending>"},
+		{Kind: "Service", Name: "web", Health: HealthReady, Message: "ClusterIP 10.0.0.1, ports 80/TCP"},
+		{Kind: "NetworkPolicy", Name: "deny-all", Health: HealthReady, Message: "selects all pods"},
+	}, statuses)
+	assert.Equal(t, "3 Ready, 2 Progressing, 1 Degraded", SummarizeHealth(statuses))
+}
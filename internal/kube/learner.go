@@ -0,0 +1,133 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// LearnerServiceAccountName is the ServiceAccount (and RoleBinding) created in a
+// challenge namespace to give learners namespace-scoped credentials.
+const LearnerServiceAccountName = "kubeasy-learner"
+
+// DefaultLearnerClusterRole is the ClusterRole bound in the challenge namespace
+// when none is specified: read/write on namespaced workloads, no RBAC changes.
+const DefaultLearnerClusterRole = "edit"
+
+// EnsureLearnerAccess creates the learner ServiceAccount in namespace and binds it
+// to clusterRole with a RoleBinding, so its permissions never leave the namespace.
+// It is idempotent; an existing RoleBinding to another role is replaced.
+func EnsureLearnerAccess(ctx context.Context, clientset kubernetes.Interface, namespace, clusterRole string) error {
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LearnerServiceAccountName,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "kubeasy"},
+		},
+	}
+	if _, err := clientset.CoreV1().ServiceAccounts(namespace).Create(ctx, sa, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create service account %s/%s: %w", namespace, LearnerServiceAccountName, err)
+	}
+
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LearnerServiceAccountName,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "kubeasy"},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      LearnerServiceAccountName,
+			Namespace: namespace,
+		}},
+	}
+	bindings := clientset.RbacV1().RoleBindings(namespace)
+	existing, err := bindings.Get(ctx, rb.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := bindings.Create(ctx, rb, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create role binding %s/%s: %w", namespace, rb.Name, err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get role binding %s/%s: %w", namespace, rb.Name, err)
+	case existing.RoleRef != rb.RoleRef:
+		// roleRef is immutable: recreate the binding to switch roles.
+		logger.Debug("Role binding %s/%s points to %s, recreating for %s", namespace, rb.Name, existing.RoleRef.Name, clusterRole)
+		if err := bindings.Delete(ctx, rb.Name, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("failed to delete role binding %s/%s: %w", namespace, rb.Name, err)
+		}
+		if _, err := bindings.Create(ctx, rb, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create role binding %s/%s: %w", namespace, rb.Name, err)
+		}
+	}
+
+	logger.Info("Learner access ready in namespace '%s' (ClusterRole %s)", namespace, clusterRole)
+	return nil
+}
+
+// CreateLearnerToken requests a short-lived token for the learner ServiceAccount.
+func CreateLearnerToken(ctx context.Context, clientset kubernetes.Interface, namespace string, ttl time.Duration) (string, error) {
+	seconds := int64(ttl.Seconds())
+	req := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &seconds},
+	}
+	resp, err := clientset.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, LearnerServiceAccountName, req, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to create token for %s/%s: %w", namespace, LearnerServiceAccountName, err)
+	}
+	if resp.Status.Token == "" {
+		return "", fmt.Errorf("empty token returned for %s/%s", namespace, LearnerServiceAccountName)
+	}
+	return resp.Status.Token, nil
+}
+
+// BuildLearnerKubeconfig renders a standalone kubeconfig that talks to the same API
+// server as restConfig, authenticates with token and defaults to namespace.
+func BuildLearnerKubeconfig(restConfig *rest.Config, namespace, token string) ([]byte, error) {
+	caData := restConfig.CAData
+	if len(caData) == 0 && restConfig.CAFile != "" {
+		data, err := os.ReadFile(restConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cluster CA %s: %w", restConfig.CAFile, err)
+		}
+		caData = data
+	}
+
+	name := "kubeasy-" + namespace
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   restConfig.Host,
+		CertificateAuthorityData: caData,
+		InsecureSkipTLSVerify:    restConfig.Insecure,
+	}
+	cfg.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: token}
+	cfg.Contexts[name] = &clientcmdapi.Context{
+		Cluster:   name,
+		AuthInfo:  name,
+		Namespace: namespace,
+	}
+	cfg.CurrentContext = name
+
+	data, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize kubeconfig: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,63 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestEnsureLearnerAccess(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+
+	require.NoError(t, EnsureLearnerAccess(ctx, clientset, "pod-evicted", "edit"))
+	// Idempotent
+	require.NoError(t, EnsureLearnerAccess(ctx, clientset, "pod-evicted", "edit"))
+
+	_, err := clientset.CoreV1().ServiceAccounts("pod-evicted").Get(ctx, LearnerServiceAccountName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	rb, err := clientset.RbacV1().RoleBindings("pod-evicted").Get(ctx, LearnerServiceAccountName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "ClusterRole", rb.RoleRef.Kind)
+	assert.Equal(t, "edit", rb.RoleRef.Name)
+	require.Len(t, rb.Subjects, 1)
+	assert.Equal(t, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: LearnerServiceAccountName, Namespace: "pod-evicted"}, rb.Subjects[0])
+
+	// Switching role recreates the binding since roleRef is immutable.
+	require.NoError(t, EnsureLearnerAccess(ctx, clientset, "pod-evicted", "view"))
+	rb, err = clientset.RbacV1().RoleBindings("pod-evicted").Get(ctx, LearnerServiceAccountName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "view", rb.RoleRef.Name)
+}
+
+func TestBuildLearnerKubeconfig(t *testing.T) {
+	restConfig := &rest.Config{
+		Host:            "https://127.0.0.1:6443",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca-data")},
+	}
+
+	data, err := BuildLearnerKubeconfig(restConfig, "pod-evicted", "secret-token")
+	require.NoError(t, err)
+
+	cfg, err := clientcmd.Load(data)
+	require.NoError(t, err)
+	assert.Equal(t, "kubeasy-pod-evicted", cfg.CurrentContext)
+
+	ctx := cfg.Contexts[cfg.CurrentContext]
+	require.NotNil(t, ctx)
+	assert.Equal(t, "pod-evicted", ctx.Namespace)
+
+	cluster := cfg.Clusters[ctx.Cluster]
+	require.NotNil(t, cluster)
+	assert.Equal(t, "https://127.0.0.1:6443", cluster.Server)
+	assert.Equal(t, []byte("ca-data"), cluster.CertificateAuthorityData)
+	assert.Equal(t, "secret-token", cfg.AuthInfos[ctx.AuthInfo].Token)
+}
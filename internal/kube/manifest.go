@@ -3,18 +3,27 @@ package kube
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/pmezard/go-difflib/difflib"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 	"k8s.io/client-go/dynamic"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
 // fetchManifestAllowedPrefixes lists the trusted domain prefixes for FetchManifest.
@@ -24,136 +33,425 @@ var fetchManifestAllowedPrefixes = []string{
 	"https://raw.githubusercontent.com/",
 }
 
-// FetchManifest downloads a manifest from the given URL
-func FetchManifest(url string) ([]byte, error) {
+const (
+	// maxManifestBytes caps the size of a fetched manifest. The largest upstream install
+	// manifests (cert-manager, Kyverno) are a few MiB.
+	maxManifestBytes = 32 * 1024 * 1024
+	// manifestFetchAttempts is how many times a transient download failure is tried.
+	manifestFetchAttempts = 3
+)
+
+// manifestRetryBackoff is the delay before the first retry; it doubles on each attempt.
+var manifestRetryBackoff = time.Second
+
+// manifestHTTPClient is shared by every FetchManifest call so connections to GitHub are
+// reused across the components installed by setup.
+var manifestHTTPClient = &http.Client{Timeout: 2 * time.Minute}
+
+// FetchManifest returns the manifest at source, which is either a URL from a trusted
+// domain (see fetchManifestAllowedPrefixes) or a file:// URL. Downloads
+// are cached (see manifestCacheDir), compressed, resumed after an interruption and
+// retried on timeouts, 429 and 5xx responses; manifests larger than maxManifestBytes
+// are rejected. A cached manifest is only downloaded again when it changed upstream,
+// and is used as is when the download fails.
+func FetchManifest(ctx context.Context, source string) ([]byte, error) {
+	if path, ok := strings.CutPrefix(source, "file://"); ok {
+		return readManifestFile(path)
+	}
+
 	allowed := false
 	for _, prefix := range fetchManifestAllowedPrefixes {
-		if strings.HasPrefix(url, prefix) {
+		if strings.HasPrefix(source, prefix) {
 			allowed = true
 			break
 		}
 	}
 	if !allowed {
-		return nil, fmt.Errorf("FetchManifest: URL %q is not from a trusted domain (allowed: %v)", url, fetchManifestAllowedPrefixes)
+		return nil, fmt.Errorf("FetchManifest: URL %q is not from a trusted domain (allowed: %v)", source, fetchManifestAllowedPrefixes)
 	}
 
-	resp, err := http.Get(url) //nolint:gosec // URL validated against fetchManifestAllowedPrefixes
-	if err != nil {
-		return nil, fmt.Errorf("error downloading manifest from %s: %w", url, err)
+	cached := readManifestCache(source)
+	var cachedETag string
+	if cached != nil {
+		cachedETag = cached.etag
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	manifestBytes, err := io.ReadAll(resp.Body)
+	backoff := manifestRetryBackoff
+	for attempt := 1; ; attempt++ {
+		manifestBytes, etag, retryable, err := downloadManifest(ctx, source, cachedETag)
+		if errors.Is(err, errNotModified) {
+			logger.Debug("FetchManifest: cached %s is up to date", source)
+			return cached.data, nil
+		}
+		if err == nil {
+			if err := writeManifestCache(source, manifestBytes, etag); err != nil {
+				logger.Debug("FetchManifest: could not cache %s: %v", source, err)
+			}
+			return manifestBytes, nil
+		}
+		if cached != nil && ctx.Err() == nil {
+			logger.Warning("Could not download %s (%v), using the cached copy", source, err)
+			return cached.data, nil
+		}
+		if !retryable || attempt == manifestFetchAttempts {
+			return nil, err
+		}
+		logger.Debug("FetchManifest: attempt %d/%d failed: %v (retrying in %s)", attempt, manifestFetchAttempts, err, backoff)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error downloading manifest from %s: %w", source, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func readManifestFile(path string) ([]byte, error) {
+	f, err := os.Open(filepath.Clean(path))
 	if err != nil {
-		return nil, fmt.Errorf("error reading manifest from %s: %w", url, err)
+		return nil, fmt.Errorf("error reading manifest %s: %w", path, err)
 	}
+	defer func() { _ = f.Close() }()
+	return readLimited(f, path)
+}
 
+// readLimited reads a manifest, failing instead of truncating when it exceeds maxManifestBytes.
+func readLimited(r io.Reader, source string) ([]byte, error) {
+	manifestBytes, err := io.ReadAll(io.LimitReader(r, maxManifestBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest from %s: %w", source, err)
+	}
+	if len(manifestBytes) > maxManifestBytes {
+		return nil, fmt.Errorf("manifest from %s exceeds %d MiB", source, maxManifestBytes/(1024*1024))
+	}
 	return manifestBytes, nil
 }
 
-// ApplyManifest applies a Kubernetes manifest to the cluster
-func ApplyManifest(ctx context.Context, manifestBytes []byte, namespace string, mapper meta.RESTMapper, dynamicClient dynamic.Interface) error {
-	logger.Debug("ApplyManifest: Starting application of manifest in namespace '%s'", namespace)
-	// Create decoder for YAML content
-	decoder := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+// applyManifestParallelism bounds how many documents ApplyManifest sends to the API
+// server at once.
+const applyManifestParallelism = 8
 
-	// Split manifest into separate documents
-	documents := bytes.Split(manifestBytes, []byte("\n---\n"))
-	logger.Debug("ApplyManifest: Manifest split into %d documents", len(documents))
+// manifestDocument is a decoded manifest document awaiting apply.
+type manifestDocument struct {
+	num  int // 1-based position in the manifest, used in logs
+	line int // line the document starts on, used in errors
+	obj  *unstructured.Unstructured
+	gvk  *schema.GroupVersionKind
+}
+
+// applyTier orders documents so that what others depend on is applied first.
+// Documents of the same tier are independent and are applied concurrently.
+func applyTier(kind string) int {
+	switch kind {
+	case "CustomResourceDefinition", "Namespace":
+		return 0
+	case "MutatingWebhookConfiguration", "ValidatingWebhookConfiguration", "APIService":
+		// Registered last: until their backing Deployment is up they would reject
+		// or break the creation of the other resources.
+		return 2
+	default:
+		return 1
+	}
+}
+
+// ApplyAction is what ApplyManifest did with a manifest document.
+type ApplyAction string
+
+const (
+	// ApplyCreated means the object did not exist and was created.
+	ApplyCreated ApplyAction = "created"
+	// ApplyUpdated means the object existed and the update changed it.
+	ApplyUpdated ApplyAction = "updated"
+	// ApplyUnchanged means the object existed and already matched the document.
+	ApplyUnchanged ApplyAction = "unchanged"
+	// ApplySkipped means the document could not be decoded or its kind is not served.
+	ApplySkipped ApplyAction = "skipped"
+	// ApplyFailed means creating or updating the object failed.
+	ApplyFailed ApplyAction = "failed"
+)
+
+// ApplyResult describes how one manifest document was applied.
+type ApplyResult struct {
+	Source    string // file the manifest was read from; set by callers that apply files
+	Document  int    // 1-based position in the manifest
+	Line      int    // line the document starts on
+	GVK       schema.GroupVersionKind
+	Name      string
+	Namespace string
+	Action    ApplyAction
+	// Err explains why the document was skipped or failed.
+	Err error
+	// Diff is a unified diff from the live object to the applied one, set when
+	// ApplyOptions.Diff is on and the document creates or changes an object.
+	Diff string
+}
 
-	// Apply each document
-	for i, doc := range documents {
-		docNum := i + 1
-		// Skip empty documents
-		if len(bytes.TrimSpace(doc)) == 0 {
-			logger.Debug("ApplyManifest: Skipping empty document #%d", docNum)
-			continue
+// ApplyOptions changes how ApplyManifestWithOptions applies documents.
+type ApplyOptions struct {
+	// DryRun sends every create and update as a server-side dry run: the API server
+	// validates and defaults the objects, runs admission, and persists nothing.
+	DryRun bool
+	// Diff fills ApplyResult.Diff. Combined with DryRun it previews what applying
+	// the manifest would change.
+	Diff bool
+}
+
+// SummarizeApply counts results by action, e.g. "3 created, 1 unchanged, 1 skipped".
+func SummarizeApply(results []ApplyResult) string {
+	counts := map[ApplyAction]int{}
+	for _, r := range results {
+		counts[r.Action]++
+	}
+	var parts []string
+	for _, action := range []ApplyAction{ApplyCreated, ApplyUpdated, ApplyUnchanged, ApplySkipped, ApplyFailed} {
+		if counts[action] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[action], action))
 		}
+	}
+	if len(parts) == 0 {
+		return "nothing to apply"
+	}
+	return strings.Join(parts, ", ")
+}
 
+// ApplyManifest applies a Kubernetes manifest to the cluster and returns what was done
+// with each document, in manifest order. CRDs and namespaces are applied first and
+// webhook configurations last; the documents in between are applied concurrently (at
+// most applyManifestParallelism at a time). On failure, the error of the earliest
+// failing document is returned and later tiers are not applied (nor reported).
+func ApplyManifest(ctx context.Context, manifestBytes []byte, namespace string, mapper meta.RESTMapper, dynamicClient dynamic.Interface) ([]ApplyResult, error) {
+	return ApplyManifestWithOptions(ctx, manifestBytes, namespace, mapper, dynamicClient, ApplyOptions{})
+}
+
+// ApplyManifestWithOptions is ApplyManifest with a dry-run and diff mode (see ApplyOptions).
+func ApplyManifestWithOptions(ctx context.Context, manifestBytes []byte, namespace string, mapper meta.RESTMapper, dynamicClient dynamic.Interface, opts ApplyOptions) ([]ApplyResult, error) {
+	logger.Debug("ApplyManifest: Starting application of manifest in namespace '%s'", namespace)
+	// Create decoder for YAML content
+	decoder := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+
+	var (
+		tiers   [3][]manifestDocument
+		results []ApplyResult
+	)
+	docNum := 0
+	err := eachYAMLDocument(bytes.NewReader(manifestBytes), func(line int, doc []byte) error {
+		docNum++
 		// Decode YAML to unstructured object
 		obj := &unstructured.Unstructured{}
 		_, gvk, err := decoder.Decode(doc, nil, obj)
 		if err != nil {
 			// Log error and continue with next document
-			logger.Warning("ApplyManifest: Skipping document #%d, error decoding: %v", docNum, err)
-			continue
+			logger.Warning("ApplyManifest: Skipping document #%d (line %d), error decoding: %v", docNum, line, err)
+			results = append(results, ApplyResult{Document: docNum, Line: line, Action: ApplySkipped, Err: err})
+			return nil
 		}
+		tier := applyTier(obj.GetKind())
+		tiers[tier] = append(tiers[tier], manifestDocument{num: docNum, line: line, obj: obj, gvk: gvk})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("ApplyManifest: Manifest contains %d documents", docNum)
 
-		// Log which object is being processed
-		objName := obj.GetName()
-		objKind := obj.GetKind()
-		logger.Debug("ApplyManifest: Processing document #%d - Kind: %s, Name: %s", docNum, objKind, objName)
-
-		// Get the GVR and scope via the REST mapper
-		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
-		if err != nil {
-			logger.Warning("ApplyManifest: Could not find mapping for Kind: %s, Group: %s, Version: %s in document #%d. Skipping.", objKind, gvk.Group, gvk.Version, docNum)
-			continue
+	var applyErr error
+	for _, tier := range tiers {
+		tierResults := make([]ApplyResult, len(tier))
+		sem := make(chan struct{}, applyManifestParallelism)
+		var wg sync.WaitGroup
+		for i, doc := range tier {
+			sem <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				tierResults[i] = applyDocument(ctx, doc, namespace, mapper, dynamicClient, opts)
+			})
+		}
+		wg.Wait()
+		results = append(results, tierResults...)
+		for _, r := range tierResults {
+			if r.Action == ApplyFailed {
+				applyErr = r.Err
+				break
+			}
+		}
+		if applyErr != nil {
+			break
 		}
-		gvr := mapping.Resource
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Document < results[j].Document })
+	if applyErr != nil {
+		return results, applyErr
+	}
 
-		// Set namespace for namespaced resources
-		isNamespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	logger.Debug("ApplyManifest: Finished applying manifest in namespace '%s' (%s)", namespace, SummarizeApply(results))
+	return results, nil
+}
 
-		// Apply the resource
-		var createdOrUpdated *unstructured.Unstructured
-		var resourceClient dynamic.ResourceInterface
+// applyDocument creates the object of doc, or updates it when it already exists.
+// Documents whose kind is unknown to the mapper or the API server are skipped.
+func applyDocument(ctx context.Context, doc manifestDocument, namespace string, mapper meta.RESTMapper, dynamicClient dynamic.Interface, opts ApplyOptions) ApplyResult {
+	obj, gvk, docNum, line := doc.obj, doc.gvk, doc.num, doc.line
+	result := ApplyResult{Document: docNum, Line: line, GVK: *gvk, Name: obj.GetName()}
 
-		if isNamespaced {
-			// Set namespace if not already set
-			if obj.GetNamespace() == "" {
-				obj.SetNamespace(namespace)
-				logger.Debug("ApplyManifest: Setting namespace '%s' for %s/%s", namespace, objKind, objName)
-			}
-			resourceClient = dynamicClient.Resource(gvr).Namespace(obj.GetNamespace())
-			logger.Debug("ApplyManifest: Attempting to create namespaced resource %s/%s (GVR: %v) in namespace %s", objKind, objName, gvr, obj.GetNamespace())
-		} else {
-			resourceClient = dynamicClient.Resource(gvr)
-			logger.Debug("ApplyManifest: Attempting to create cluster-scoped resource %s/%s (GVR: %v)", objKind, objName, gvr)
-		}
+	// Log which object is being processed
+	objName := obj.GetName()
+	objKind := obj.GetKind()
+	logger.Debug("ApplyManifest: Processing document #%d - Kind: %s, Name: %s", docNum, objKind, objName)
 
-		createdOrUpdated, err = resourceClient.Create(ctx, obj, metav1.CreateOptions{})
+	// Get the GVR and scope via the REST mapper
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		logger.Warning("ApplyManifest: Could not find mapping for Kind: %s, Group: %s, Version: %s in document #%d (line %d). Skipping.", objKind, gvk.Group, gvk.Version, docNum, line)
+		result.Action, result.Err = ApplySkipped, err
+		return result
+	}
+	gvr := mapping.Resource
 
-		if err != nil {
-			// If the resource doesn't exist (API not available yet), continue
-			if apierrors.IsNotFound(err) || strings.Contains(err.Error(), "the server could not find the requested resource") {
-				logger.Warning("ApplyManifest: API for %s/%s not available, skipping document #%d. Error: %v", objKind, objName, docNum, err)
-				continue
-			}
+	// Set namespace for namespaced resources
+	isNamespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
 
-			// If the resource already exists, try to update it
-			if apierrors.IsAlreadyExists(err) {
-				logger.Debug("ApplyManifest: Resource %s/%s already exists, attempting update...", objKind, objName)
-				var updateErr error
-				// Get the existing resource to retrieve the resourceVersion for update
-				var existingObj *unstructured.Unstructured
-				existingObj, updateErr = resourceClient.Get(ctx, objName, metav1.GetOptions{})
+	// Apply the resource
+	var resourceClient dynamic.ResourceInterface
 
-				if updateErr != nil {
-					return fmt.Errorf("failed to get %s/%s for update: %w", objKind, objName, updateErr)
-				}
+	if isNamespaced {
+		// Set namespace if not already set
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+			logger.Debug("ApplyManifest: Setting namespace '%s' for %s/%s", namespace, objKind, objName)
+		}
+		result.Namespace = obj.GetNamespace()
+		resourceClient = dynamicClient.Resource(gvr).Namespace(obj.GetNamespace())
+		logger.Debug("ApplyManifest: Attempting to create namespaced resource %s/%s (GVR: %v) in namespace %s", objKind, objName, gvr, obj.GetNamespace())
+	} else {
+		resourceClient = dynamicClient.Resource(gvr)
+		logger.Debug("ApplyManifest: Attempting to create cluster-scoped resource %s/%s (GVR: %v)", objKind, objName, gvr)
+	}
 
-				// Set the resourceVersion from the existing object
-				obj.SetResourceVersion(existingObj.GetResourceVersion())
+	var dryRun []string
+	if opts.DryRun {
+		dryRun = []string{metav1.DryRunAll}
+	}
 
-				_, updateErr = resourceClient.Update(ctx, obj, metav1.UpdateOptions{})
+	createdOrUpdated, err := resourceClient.Create(ctx, obj, metav1.CreateOptions{DryRun: dryRun})
 
-				if updateErr != nil {
-					return fmt.Errorf("failed to update %s/%s: %w", objKind, objName, updateErr)
-				}
-				logger.Info("ApplyManifest: Resource %s/%s updated successfully (document #%d).", objKind, objName, docNum)
-				continue // Continue with the next document after successful update
+	if err != nil {
+		// A dry run cannot create objects in a namespace that does not exist yet
+		if opts.DryRun && isNamespaceNotFound(err) {
+			logger.Debug("ApplyManifest: Namespace of %s/%s does not exist yet, it would be created (document #%d).", objKind, objName, docNum)
+			result.Action = ApplyCreated
+			if opts.Diff {
+				result.Diff = objectDiff(nil, obj)
 			}
+			return result
+		}
 
-			return fmt.Errorf("failed to create %s/%s: %w", objKind, objName, err)
+		// If the resource doesn't exist (API not available yet), continue
+		if apierrors.IsNotFound(err) || strings.Contains(err.Error(), "the server could not find the requested resource") {
+			logger.Warning("ApplyManifest: API for %s/%s not available, skipping document #%d. Error: %v", objKind, objName, docNum, err)
+			result.Action, result.Err = ApplySkipped, err
+			return result
 		}
 
-		// Log success if createdOrUpdated is not nil (which it should be on success)
-		if createdOrUpdated != nil {
-			logger.Info("ApplyManifest: Resource %s/%s created successfully (document #%d).", objKind, objName, docNum)
+		// If the resource already exists, try to update it
+		if apierrors.IsAlreadyExists(err) {
+			logger.Debug("ApplyManifest: Resource %s/%s already exists, attempting update...", objKind, objName)
+			// Get the existing resource to retrieve the resourceVersion for update
+			existingObj, updateErr := resourceClient.Get(ctx, objName, metav1.GetOptions{})
+			if updateErr != nil {
+				result.Action, result.Err = ApplyFailed, fmt.Errorf("failed to get %s/%s (line %d) for update: %w", objKind, objName, line, updateErr)
+				return result
+			}
+
+			// Set the resourceVersion from the existing object
+			obj.SetResourceVersion(existingObj.GetResourceVersion())
+
+			updated, updateErr := resourceClient.Update(ctx, obj, metav1.UpdateOptions{DryRun: dryRun})
+			if updateErr != nil {
+				result.Action, result.Err = ApplyFailed, fmt.Errorf("failed to update %s/%s (line %d): %w", objKind, objName, line, updateErr)
+				return result
+			}
+			if opts.Diff && updated != nil {
+				result.Diff = objectDiff(existingObj, updated)
+			}
+			// The API server keeps the resourceVersion of an update that changes nothing.
+			// A dry run never bumps it, so the diff tells whether anything would change.
+			unchanged := updated != nil && updated.GetResourceVersion() == existingObj.GetResourceVersion()
+			if opts.DryRun && opts.Diff {
+				unchanged = result.Diff == ""
+			}
+			if unchanged {
+				logger.Debug("ApplyManifest: Resource %s/%s unchanged (document #%d).", objKind, objName, docNum)
+				result.Action = ApplyUnchanged
+				return result
+			}
+			logger.Info("ApplyManifest: Resource %s/%s updated successfully (document #%d).", objKind, objName, docNum)
+			result.Action = ApplyUpdated
+			return result
 		}
+
+		result.Action, result.Err = ApplyFailed, fmt.Errorf("failed to create %s/%s (line %d): %w", objKind, objName, line, err)
+		return result
+	}
+
+	// Log success if createdOrUpdated is not nil (which it should be on success)
+	if createdOrUpdated != nil {
+		logger.Info("ApplyManifest: Resource %s/%s created successfully (document #%d).", objKind, objName, docNum)
+	}
+	result.Action = ApplyCreated
+	if opts.Diff {
+		if createdOrUpdated == nil {
+			createdOrUpdated = obj
+		}
+		result.Diff = objectDiff(nil, createdOrUpdated)
+	}
+	return result
+}
+
+// isNamespaceNotFound reports whether err says the target namespace does not exist.
+func isNamespaceNotFound(err error) bool {
+	var status apierrors.APIStatus
+	if !apierrors.IsNotFound(err) || !errors.As(err, &status) {
+		return false
 	}
+	details := status.Status().Details
+	return details != nil && details.Kind == "namespaces"
+}
 
-	logger.Debug("ApplyManifest: Finished applying manifest in namespace '%s'", namespace)
-	return nil
+// objectDiff returns a unified diff between the YAML of live (nil when the object does
+// not exist) and desired, ignoring status and the metadata the API server manages.
+// It returns "" when they match.
+func objectDiff(live, desired *unstructured.Unstructured) string {
+	from, to := "", diffableYAML(desired)
+	if live != nil {
+		from = diffableYAML(live)
+	}
+	if from == to {
+		return ""
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "live",
+		ToFile:   "manifest",
+		Context:  3,
+	})
+	if err != nil {
+		logger.Debug("ApplyManifest: failed to diff %s/%s: %v", desired.GetKind(), desired.GetName(), err)
+		return ""
+	}
+	return diff
+}
+
+// diffableYAML renders obj as YAML without the fields that change on every write.
+func diffableYAML(obj *unstructured.Unstructured) string {
+	c := obj.DeepCopy()
+	unstructured.RemoveNestedField(c.Object, "status")
+	for _, field := range []string{"resourceVersion", "generation", "uid", "creationTimestamp", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(c.Object, "metadata", field)
+	}
+	out, err := sigsyaml.Marshal(c.Object)
+	if err != nil {
+		return fmt.Sprintf("%v", c.Object)
+	}
+	return string(out)
 }
@@ -3,10 +3,20 @@ package kube
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -47,7 +57,7 @@ func TestApplyManifest_DocumentSplitting(t *testing.T) {
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(simpleConfigMapManifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(simpleConfigMapManifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 	})
 
@@ -71,7 +81,7 @@ data:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 	})
 
@@ -87,7 +97,7 @@ data:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 	})
 }
@@ -100,7 +110,7 @@ func TestApplyManifest_NamespaceInjection(t *testing.T) {
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(simpleConfigMapManifest), "custom-namespace", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(simpleConfigMapManifest), "custom-namespace", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Verify the ConfigMap was created in the correct namespace
@@ -124,7 +134,7 @@ data:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Verify the ConfigMap was created in the original namespace, not the default
@@ -145,7 +155,7 @@ metadata:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Verify the Namespace was created without a namespace field
@@ -164,7 +174,7 @@ func TestApplyManifest_ResourceCreation(t *testing.T) {
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(simpleConfigMapManifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(simpleConfigMapManifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Verify the ConfigMap was created
@@ -189,7 +199,7 @@ data:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(initialManifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(initialManifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Now update with new data
@@ -200,7 +210,7 @@ metadata:
 data:
   key: updated-value`
 
-		err = ApplyManifest(ctx, []byte(updatedManifest), "default", mapper, dynamicClient)
+		_, err = ApplyManifest(ctx, []byte(updatedManifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Verify the ConfigMap was updated
@@ -227,7 +237,7 @@ func TestApplyManifest_ErrorHandling(t *testing.T) {
 		ctx := context.Background()
 
 		// Should not error - invalid documents are logged and skipped
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		assert.NoError(t, err, "ApplyManifest should continue processing even with invalid YAML")
 	})
 
@@ -253,7 +263,7 @@ data:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Verify the valid ConfigMaps were created
@@ -279,7 +289,7 @@ metadata:
 		ctx := context.Background()
 
 		// Should not error - unknown kinds are logged and skipped
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		assert.NoError(t, err)
 	})
 }
@@ -307,7 +317,7 @@ data:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "default", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		// Verify ConfigMap was created
@@ -381,7 +391,7 @@ metadata:
 			dynamicClient := fake.NewSimpleDynamicClient(scheme)
 			ctx := context.Background()
 
-			err := ApplyManifest(ctx, []byte(tt.manifest), "default", mapper, dynamicClient)
+			_, err := ApplyManifest(ctx, []byte(tt.manifest), "default", mapper, dynamicClient)
 			require.NoError(t, err)
 
 			// Verify the resource landed at the correct GVR
@@ -419,7 +429,7 @@ spec:
 	})
 
 	ctx := context.Background()
-	err := ApplyManifest(ctx, []byte(podManifest), "default", mapper, dynamicClient)
+	_, err := ApplyManifest(ctx, []byte(podManifest), "default", mapper, dynamicClient)
 	require.Error(t, err, "ApplyManifest should return an error on critical create failure")
 	assert.Contains(t, err.Error(), "failed to create", "error message should contain 'failed to create'")
 }
@@ -455,11 +465,218 @@ spec:
 	})
 
 	ctx := context.Background()
-	err := ApplyManifest(ctx, []byte(podManifest), "default", mapper, dynamicClient)
+	_, err := ApplyManifest(ctx, []byte(podManifest), "default", mapper, dynamicClient)
 	require.Error(t, err, "ApplyManifest should return an error on critical update failure")
 	assert.Contains(t, err.Error(), "failed to update", "error message should contain 'failed to update'")
 }
 
+// TestApplyManifest_AppliesDependenciesFirst verifies namespaces are created before the
+// resources that may live in them, and webhook configurations after everything else.
+func TestApplyManifest_AppliesDependenciesFirst(t *testing.T) {
+	const manifest = `apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: validate
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: app
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: app`
+
+	scheme := newTestScheme()
+	_ = admissionregistrationv1.AddToScheme(scheme)
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme)
+	dynamicClient := fake.NewSimpleDynamicClient(scheme)
+
+	var mu sync.Mutex
+	var created []string
+	dynamicClient.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		created = append(created, action.GetResource().Resource)
+		return false, nil, nil
+	})
+
+	_, err := ApplyManifest(context.Background(), []byte(manifest), "default", mapper, dynamicClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"namespaces", "configmaps", "validatingwebhookconfigurations"}, created)
+}
+
+// TestApplyManifest_ParallelFailure_ReportsFirstDocument verifies that when several
+// concurrently applied documents fail, the earliest one in the manifest is reported.
+func TestApplyManifest_ParallelFailure_ReportsFirstDocument(t *testing.T) {
+	var manifest []string
+	for i := range 10 {
+		manifest = append(manifest, fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm-%d", i))
+	}
+
+	scheme := newTestScheme()
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme)
+	dynamicClient := fake.NewSimpleDynamicClient(scheme)
+	dynamicClient.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewInternalError(fmt.Errorf("etcd unavailable"))
+	})
+
+	_, err := ApplyManifest(context.Background(), []byte(strings.Join(manifest, "\n---\n")), "default", mapper, dynamicClient)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create ConfigMap/cm-0 (line 1)")
+}
+
+// TestApplyManifest_ReportsEachDocument verifies the per-document results, in manifest order.
+func TestApplyManifest_ReportsEachDocument(t *testing.T) {
+	const manifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: created
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: unknown
+---
+not: [valid`
+
+	scheme := newTestScheme()
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme)
+	existing := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName(name)
+		obj.SetNamespace("default")
+		obj.SetResourceVersion("1")
+		return obj
+	}
+	dynamicClient := fake.NewSimpleDynamicClient(scheme, existing("unchanged"), existing("changed"))
+	// The fake client does not bump resourceVersion; emulate the API server for "changed".
+	dynamicClient.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.UpdateAction).GetObject().(*unstructured.Unstructured).DeepCopy()
+		if obj.GetName() == "changed" {
+			obj.SetResourceVersion("2")
+		}
+		return true, obj, nil
+	})
+
+	results, err := ApplyManifest(context.Background(), []byte(manifest), "default", mapper, dynamicClient)
+	require.NoError(t, err)
+
+	var actions []ApplyAction
+	for _, r := range results {
+		actions = append(actions, r.Action)
+	}
+	assert.Equal(t, []ApplyAction{ApplyCreated, ApplyUnchanged, ApplyUpdated, ApplySkipped, ApplySkipped}, actions)
+	assert.Equal(t, ApplyResult{
+		Document:  1,
+		Line:      1,
+		GVK:       schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Name:      "created",
+		Namespace: "default",
+		Action:    ApplyCreated,
+	}, results[0])
+	assert.Equal(t, 16, results[3].Line)
+	assert.Error(t, results[3].Err, "skipped documents say why")
+	assert.Equal(t, "1 created, 1 updated, 1 unchanged, 2 skipped", SummarizeApply(results))
+}
+
+func TestSummarizeApply_Empty(t *testing.T) {
+	assert.Equal(t, "nothing to apply", SummarizeApply(nil))
+}
+
+// TestApplyManifestWithOptions_DryRunDiff verifies a dry run sends every write with
+// DryRun=All and describes what would change.
+func TestApplyManifestWithOptions_DryRunDiff(t *testing.T) {
+	const manifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  mode: fixed
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: fresh
+  namespace: not-yet`
+
+	scheme := newTestScheme()
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme)
+	live := &unstructured.Unstructured{}
+	live.SetAPIVersion("v1")
+	live.SetKind("ConfigMap")
+	live.SetName("settings")
+	live.SetNamespace("default")
+	live.SetResourceVersion("7")
+	require.NoError(t, unstructured.SetNestedField(live.Object, "broken", "data", "mode"))
+	dynamicClient := fake.NewSimpleDynamicClient(scheme, live)
+
+	var dryRuns [][]string
+	dynamicClient.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create := action.(k8stesting.CreateActionImpl)
+		dryRuns = append(dryRuns, create.CreateOptions.DryRun)
+		if action.GetNamespace() == "not-yet" {
+			return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "not-yet")
+		}
+		return true, nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, "settings")
+	})
+	dynamicClient.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		update := action.(k8stesting.UpdateActionImpl)
+		dryRuns = append(dryRuns, update.UpdateOptions.DryRun)
+		return true, update.GetObject(), nil
+	})
+
+	results, err := ApplyManifestWithOptions(context.Background(), []byte(manifest), "default", mapper, dynamicClient,
+		ApplyOptions{DryRun: true, Diff: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	for _, dryRun := range dryRuns {
+		assert.Equal(t, []string{metav1.DryRunAll}, dryRun)
+	}
+	assert.Equal(t, ApplyUpdated, results[0].Action)
+	assert.Contains(t, results[0].Diff, "-  mode: broken")
+	assert.Contains(t, results[0].Diff, "+  mode: fixed")
+	assert.NotContains(t, results[0].Diff, "resourceVersion")
+
+	assert.Equal(t, ApplyCreated, results[1].Action, "a missing namespace would be created first")
+	assert.Contains(t, results[1].Diff, "+  name: fresh")
+
+	cm, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("default").Get(context.Background(), "settings", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "broken", cm.Object["data"].(map[string]interface{})["mode"], "a dry run changes nothing")
+}
+
+func TestObjectDiff_IgnoresServerManagedFields(t *testing.T) {
+	live := &unstructured.Unstructured{}
+	live.SetAPIVersion("v1")
+	live.SetKind("ConfigMap")
+	live.SetName("settings")
+	live.SetResourceVersion("7")
+	live.SetGeneration(3)
+	desired := live.DeepCopy()
+	desired.SetResourceVersion("")
+	desired.SetGeneration(0)
+
+	assert.Empty(t, objectDiff(live, desired))
+}
+
 // TestApplyManifest_DecodeError_Skipped verifies that malformed YAML (not valid Kubernetes YAML)
 // is skipped gracefully without returning an error.
 func TestApplyManifest_DecodeError_Skipped(t *testing.T) {
@@ -470,7 +687,7 @@ func TestApplyManifest_DecodeError_Skipped(t *testing.T) {
 
 	// Malformed YAML that cannot be decoded as a Kubernetes object
 	badYAML := []byte("not: valid: kubernetes: yaml\nwith: bad: structure")
-	err := ApplyManifest(ctx, badYAML, "default", mapper, dynamicClient)
+	_, err := ApplyManifest(ctx, badYAML, "default", mapper, dynamicClient)
 	assert.NoError(t, err, "decode errors should be skipped (return nil)")
 }
 
@@ -497,7 +714,7 @@ spec:
 	})
 
 	ctx := context.Background()
-	err := ApplyManifest(ctx, []byte(podManifest), "default", mapper, dynamicClient)
+	_, err := ApplyManifest(ctx, []byte(podManifest), "default", mapper, dynamicClient)
 	assert.NoError(t, err, "IsNotFound on create should be skipped (return nil)")
 }
 
@@ -543,7 +760,7 @@ func TestFetchManifest_Allowlist(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := FetchManifest(tt.url)
+			_, err := FetchManifest(context.Background(), tt.url)
 			if tt.wantAllowErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), "not from a trusted domain")
@@ -556,6 +773,81 @@ func TestFetchManifest_Allowlist(t *testing.T) {
 	}
 }
 
+// allowTestServer lets FetchManifest download from srv and disables retry backoff.
+func allowTestServer(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir()) // isolates the manifest cache
+	prefixes, backoff := fetchManifestAllowedPrefixes, manifestRetryBackoff
+	fetchManifestAllowedPrefixes = []string{srv.URL + "/"}
+	manifestRetryBackoff = time.Millisecond
+	t.Cleanup(func() { fetchManifestAllowedPrefixes, manifestRetryBackoff = prefixes, backoff })
+}
+
+func TestFetchManifest_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < manifestFetchAttempts {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(simpleConfigMapManifest))
+	}))
+	defer srv.Close()
+	allowTestServer(t, srv)
+
+	data, err := FetchManifest(context.Background(), srv.URL+"/install.yaml")
+
+	require.NoError(t, err)
+	assert.Equal(t, simpleConfigMapManifest, string(data))
+	assert.Equal(t, int32(manifestFetchAttempts), calls.Load())
+}
+
+func TestFetchManifest_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	allowTestServer(t, srv)
+
+	_, err := FetchManifest(context.Background(), srv.URL+"/missing.yaml")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 404")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFetchManifest_LocalFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(simpleConfigMapManifest), 0o600))
+
+	data, err := FetchManifest(context.Background(), "file://"+path)
+	require.NoError(t, err)
+	assert.Equal(t, simpleConfigMapManifest, string(data))
+
+	_, err = FetchManifest(context.Background(), "file://"+filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	// A bare path is neither a trusted URL nor an explicit local source
+	_, err = FetchManifest(context.Background(), path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not from a trusted domain")
+}
+
+func TestReadLimited_RejectsOversizedManifests(t *testing.T) {
+	_, err := readLimited(io.LimitReader(zeroReader{}, maxManifestBytes+1), "big.yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds")
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
 // TestApplyManifest_RESTMapperScope verifies that namespace injection is driven by mapper scope,
 // not by a hardcoded list.
 func TestApplyManifest_RESTMapperScope(t *testing.T) {
@@ -570,7 +862,7 @@ metadata:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "injected-ns", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "injected-ns", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		gvr := schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}
@@ -590,7 +882,7 @@ metadata:
 		dynamicClient := fake.NewSimpleDynamicClient(scheme)
 		ctx := context.Background()
 
-		err := ApplyManifest(ctx, []byte(manifest), "injected-ns", mapper, dynamicClient)
+		_, err := ApplyManifest(ctx, []byte(manifest), "injected-ns", mapper, dynamicClient)
 		require.NoError(t, err)
 
 		gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "serviceaccounts"}
@@ -599,3 +891,51 @@ metadata:
 		assert.Equal(t, "injected-ns", obj.GetNamespace())
 	})
 }
+
+func TestManifestSnapshot_Restore(t *testing.T) {
+	scheme := newTestScheme()
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme)
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "app-config", "namespace": "default"},
+		"data":       map[string]interface{}{"mode": "broken"},
+	}}
+	dynamicClient := fake.NewSimpleDynamicClient(scheme, existing)
+	ctx := context.Background()
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	solution := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  mode: fixed
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: extra-config
+data:
+  key: value`
+
+	snapshot := NewManifestSnapshot(dynamicClient)
+	require.NoError(t, snapshot.Add(ctx, []byte(solution), "default", mapper))
+	_, err := ApplyManifest(ctx, []byte(solution), "default", mapper, dynamicClient)
+	require.NoError(t, err)
+
+	cm, err := dynamicClient.Resource(gvr).Namespace("default").Get(ctx, "app-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	mode, _, _ := unstructured.NestedString(cm.Object, "data", "mode")
+	require.Equal(t, "fixed", mode)
+
+	require.NoError(t, snapshot.Restore(ctx))
+
+	cm, err = dynamicClient.Resource(gvr).Namespace("default").Get(ctx, "app-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	mode, _, _ = unstructured.NestedString(cm.Object, "data", "mode")
+	assert.Equal(t, "broken", mode, "modified object must be restored")
+
+	_, err = dynamicClient.Resource(gvr).Namespace("default").Get(ctx, "extra-config", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "object created by the manifest must be deleted")
+}
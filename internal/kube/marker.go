@@ -0,0 +1,183 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/semver"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The marker ConfigMap is installed by 'kubeasy setup' and identifies the clusters
+// the CLI manages, whatever the name of the kubeconfig context pointing at them. It
+// also holds the environment fingerprint (see ClusterFingerprint).
+const (
+	MarkerNamespace     = "kube-system"
+	MarkerConfigMapName = "kubeasy-system"
+	markerManagedByKey  = "app.kubernetes.io/managed-by"
+	markerManagedBy     = "kubeasy-cli"
+)
+
+// FingerprintSchemaVersion is the version of the environment set up by this CLI.
+// Bump it when setup changes in a way older clusters must be set up again for.
+const FingerprintSchemaVersion = 1
+
+// Data keys of the environment fingerprint in the marker ConfigMap.
+const (
+	fingerprintCLIVersionKey    = "cliVersion"
+	fingerprintSetupAtKey       = "setupAt"
+	fingerprintAddonsKey        = "addons"
+	fingerprintArchitectureKey  = "architecture"
+	fingerprintSchemaVersionKey = "schemaVersion"
+)
+
+// ErrNotKubeasyCluster is returned by VerifyClusterMarker when the cluster has no marker.
+var ErrNotKubeasyCluster = kerrors.New(kerrors.CodeClusterNotKubeasy, "cluster is not managed by kubeasy")
+
+// EnsureClusterMarker creates or updates the marker ConfigMap of the cluster.
+func EnsureClusterMarker(ctx context.Context, clientset kubernetes.Interface) error {
+	cms := clientset.CoreV1().ConfigMaps(MarkerNamespace)
+	existing, err := cms.Get(ctx, MarkerConfigMapName, metav1.GetOptions{})
+	if err == nil {
+		if existing.Labels[markerManagedByKey] == markerManagedBy && existing.Data["cluster"] == constants.KubeasyClusterName {
+			return nil
+		}
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		existing.Labels[markerManagedByKey] = markerManagedBy
+		if existing.Data == nil {
+			existing.Data = map[string]string{}
+		}
+		existing.Data["cluster"] = constants.KubeasyClusterName
+		if _, err := cms.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update cluster marker: %w", err)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to read cluster marker: %w", err)
+	}
+
+	marker := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MarkerConfigMapName,
+			Namespace: MarkerNamespace,
+			Labels:    map[string]string{markerManagedByKey: markerManagedBy},
+		},
+		Data: map[string]string{
+			"cluster":   constants.KubeasyClusterName,
+			"createdAt": time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	if _, err := cms.Create(ctx, marker, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create cluster marker: %w", err)
+	}
+	return nil
+}
+
+// VerifyClusterMarker checks that the cluster carries the marker installed by
+// EnsureClusterMarker, and returns ErrNotKubeasyCluster when it does not.
+func VerifyClusterMarker(ctx context.Context, clientset kubernetes.Interface) error {
+	marker, err := clientset.CoreV1().ConfigMaps(MarkerNamespace).Get(ctx, MarkerConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: no ConfigMap %s/%s", ErrNotKubeasyCluster, MarkerNamespace, MarkerConfigMapName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cluster marker: %w", err)
+	}
+	if marker.Labels[markerManagedByKey] != markerManagedBy {
+		return fmt.Errorf("%w: ConfigMap %s/%s is not labelled %s=%s", ErrNotKubeasyCluster, MarkerNamespace, MarkerConfigMapName, markerManagedByKey, markerManagedBy)
+	}
+	return nil
+}
+
+// ClusterFingerprint describes the environment the last 'kubeasy setup' installed.
+// A cluster marked before fingerprints existed reads as SchemaVersion 0.
+type ClusterFingerprint struct {
+	CLIVersion string
+	SetupAt    time.Time
+	Addons     []string
+	// Architecture is the CPU architecture of the nodes (e.g. "arm64"), "" if unknown.
+	Architecture  string
+	SchemaVersion int
+}
+
+// WriteClusterFingerprint records fp in the marker ConfigMap, creating it if needed.
+func WriteClusterFingerprint(ctx context.Context, clientset kubernetes.Interface, fp ClusterFingerprint) error {
+	if err := EnsureClusterMarker(ctx, clientset); err != nil {
+		return err
+	}
+	cms := clientset.CoreV1().ConfigMaps(MarkerNamespace)
+	marker, err := cms.Get(ctx, MarkerConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read cluster marker: %w", err)
+	}
+	if marker.Data == nil {
+		marker.Data = map[string]string{}
+	}
+	marker.Data[fingerprintCLIVersionKey] = fp.CLIVersion
+	marker.Data[fingerprintSetupAtKey] = fp.SetupAt.UTC().Format(time.RFC3339)
+	marker.Data[fingerprintAddonsKey] = strings.Join(fp.Addons, ",")
+	marker.Data[fingerprintArchitectureKey] = fp.Architecture
+	marker.Data[fingerprintSchemaVersionKey] = strconv.Itoa(fp.SchemaVersion)
+	if _, err := cms.Update(ctx, marker, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to write cluster fingerprint: %w", err)
+	}
+	return nil
+}
+
+// ReadClusterFingerprint reads the environment fingerprint of the cluster. It returns
+// an error wrapping ErrNotKubeasyCluster when the cluster has no marker.
+func ReadClusterFingerprint(ctx context.Context, clientset kubernetes.Interface) (*ClusterFingerprint, error) {
+	if err := VerifyClusterMarker(ctx, clientset); err != nil {
+		return nil, err
+	}
+	marker, err := clientset.CoreV1().ConfigMaps(MarkerNamespace).Get(ctx, MarkerConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster marker: %w", err)
+	}
+	fp := &ClusterFingerprint{
+		CLIVersion:   marker.Data[fingerprintCLIVersionKey],
+		Architecture: marker.Data[fingerprintArchitectureKey],
+	}
+	if v := marker.Data[fingerprintSetupAtKey]; v != "" {
+		if fp.SetupAt, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, fmt.Errorf("invalid %s in cluster fingerprint: %w", fingerprintSetupAtKey, err)
+		}
+	}
+	if v := marker.Data[fingerprintAddonsKey]; v != "" {
+		fp.Addons = strings.Split(v, ",")
+	}
+	if v := marker.Data[fingerprintSchemaVersionKey]; v != "" {
+		if fp.SchemaVersion, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("invalid %s in cluster fingerprint: %w", fingerprintSchemaVersionKey, err)
+		}
+	}
+	return fp, nil
+}
+
+// CompatibilityIssues compares the fingerprint with the running CLI and returns a
+// human-readable description of each mismatch; none means the environment is up to date.
+func (fp ClusterFingerprint) CompatibilityIssues(cliVersion string) []string {
+	var issues []string
+	switch {
+	case fp.SchemaVersion < FingerprintSchemaVersion:
+		issues = append(issues, "the cluster was set up by an older kubeasy: run 'kubeasy setup' to update it")
+	case fp.SchemaVersion > FingerprintSchemaVersion:
+		issues = append(issues, "the cluster was set up by a newer kubeasy: upgrade the CLI")
+	}
+	if fp.CLIVersion != "" && !semver.IsPreRelease(cliVersion) && !semver.IsPreRelease(fp.CLIVersion) &&
+		semver.Compare(semver.Normalize(fp.CLIVersion), semver.Normalize(cliVersion)) > 0 {
+		issues = append(issues, fmt.Sprintf("the cluster was set up by kubeasy-cli %s, newer than this CLI (%s)", fp.CLIVersion, cliVersion))
+	}
+	return issues
+}
@@ -0,0 +1,90 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClusterMarker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing marker", func(t *testing.T) {
+		err := VerifyClusterMarker(ctx, fake.NewClientset())
+		assert.ErrorIs(t, err, ErrNotKubeasyCluster)
+	})
+
+	t.Run("ensure then verify", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		require.NoError(t, EnsureClusterMarker(ctx, clientset))
+		require.NoError(t, VerifyClusterMarker(ctx, clientset))
+		// Idempotent
+		require.NoError(t, EnsureClusterMarker(ctx, clientset))
+	})
+
+	t.Run("unlabelled ConfigMap is rejected then repaired", func(t *testing.T) {
+		clientset := fake.NewClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: MarkerConfigMapName, Namespace: MarkerNamespace},
+			Data:       map[string]string{"createdAt": "2026-01-01T00:00:00Z"},
+		})
+		assert.ErrorIs(t, VerifyClusterMarker(ctx, clientset), ErrNotKubeasyCluster)
+
+		require.NoError(t, EnsureClusterMarker(ctx, clientset))
+		require.NoError(t, VerifyClusterMarker(ctx, clientset))
+		cm, err := clientset.CoreV1().ConfigMaps(MarkerNamespace).Get(ctx, MarkerConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "2026-01-01T00:00:00Z", cm.Data["createdAt"], "existing data is kept")
+	})
+}
+
+func TestClusterFingerprint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("round trip", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		fp := ClusterFingerprint{
+			CLIVersion:    "v2.8.0",
+			SetupAt:       time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+			Addons:        []string{"kyverno", "cert-manager"},
+			Architecture:  "arm64",
+			SchemaVersion: FingerprintSchemaVersion,
+		}
+		require.NoError(t, WriteClusterFingerprint(ctx, clientset, fp))
+		require.NoError(t, VerifyClusterMarker(ctx, clientset), "writing a fingerprint marks the cluster")
+
+		got, err := ReadClusterFingerprint(ctx, clientset)
+		require.NoError(t, err)
+		assert.Equal(t, fp, *got)
+	})
+
+	t.Run("marker without fingerprint", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		require.NoError(t, EnsureClusterMarker(ctx, clientset))
+		got, err := ReadClusterFingerprint(ctx, clientset)
+		require.NoError(t, err)
+		assert.Equal(t, 0, got.SchemaVersion)
+	})
+
+	t.Run("no marker", func(t *testing.T) {
+		_, err := ReadClusterFingerprint(ctx, fake.NewClientset())
+		assert.ErrorIs(t, err, ErrNotKubeasyCluster)
+	})
+}
+
+func TestClusterFingerprint_CompatibilityIssues(t *testing.T) {
+	current := ClusterFingerprint{CLIVersion: "v2.8.0", SchemaVersion: FingerprintSchemaVersion}
+	assert.Empty(t, current.CompatibilityIssues("v2.8.0"))
+	assert.Empty(t, current.CompatibilityIssues("v2.9.1"))
+	assert.Empty(t, current.CompatibilityIssues("dev"), "development builds are not compared")
+
+	assert.Len(t, current.CompatibilityIssues("v2.7.0"), 1, "cluster set up by a newer CLI")
+	assert.Len(t, ClusterFingerprint{}.CompatibilityIssues("v2.8.0"), 1, "marker without fingerprint")
+	newer := ClusterFingerprint{CLIVersion: "v3.0.0", SchemaVersion: FingerprintSchemaVersion + 1}
+	assert.Len(t, newer.CompatibilityIssues("v2.8.0"), 2)
+}
@@ -0,0 +1,157 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NetworkIsolation is the baseline of NetworkPolicies installed in a challenge
+// namespace before its manifests are applied.
+type NetworkIsolation string
+
+const (
+	// NetworkIsolationNone installs no NetworkPolicy.
+	NetworkIsolationNone NetworkIsolation = "none"
+	// NetworkIsolationNamespace denies traffic to and from other namespaces; the pods
+	// of the namespace reach each other and cluster DNS.
+	NetworkIsolationNamespace NetworkIsolation = "namespace"
+	// NetworkIsolationStrict denies all traffic but cluster DNS, the baseline of
+	// network-themed challenges.
+	NetworkIsolationStrict NetworkIsolation = "strict"
+)
+
+// Names of the NetworkPolicies installed by EnsureNetworkIsolation.
+const (
+	DefaultDenyPolicyName    = "kubeasy-default-deny"
+	AllowDNSPolicyName       = "kubeasy-allow-dns"
+	AllowNamespacePolicyName = "kubeasy-allow-same-namespace"
+)
+
+// Label of the CLI-managed probe pod (see deployer.CreateProbePod), which the baseline
+// does not isolate.
+const (
+	ProbePodLabelKey   = "app"
+	ProbePodLabelValue = "kubeasy-probe"
+)
+
+// ParseNetworkIsolation returns the NetworkIsolation named s; "" is
+// NetworkIsolationNone.
+func ParseNetworkIsolation(s string) (NetworkIsolation, error) {
+	switch NetworkIsolation(s) {
+	case "", NetworkIsolationNone:
+		return NetworkIsolationNone, nil
+	case NetworkIsolationNamespace, NetworkIsolationStrict:
+		return NetworkIsolation(s), nil
+	}
+	return "", kerrors.New(kerrors.CodeInvalidInput, "unknown network isolation %q (want none, namespace or strict)", s)
+}
+
+// EnsureNetworkIsolation installs the NetworkPolicies of mode in namespace and removes
+// the allowance of a looser mode a previous start installed. It is idempotent.
+// NetworkIsolationNone leaves the namespace untouched.
+func EnsureNetworkIsolation(ctx context.Context, clientset kubernetes.Interface, namespace string, mode NetworkIsolation) error {
+	if mode == NetworkIsolationNone || mode == "" {
+		return nil
+	}
+	policies := []*networkingv1.NetworkPolicy{defaultDenyPolicy(namespace), allowDNSPolicy(namespace)}
+	if mode == NetworkIsolationNamespace {
+		policies = append(policies, allowNamespacePolicy(namespace))
+	}
+
+	client := clientset.NetworkingV1().NetworkPolicies(namespace)
+	for _, policy := range policies {
+		existing, err := client.Get(ctx, policy.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			if _, err := client.Create(ctx, policy, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create network policy %s/%s: %w", namespace, policy.Name, err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to get network policy %s/%s: %w", namespace, policy.Name, err)
+		default:
+			existing.Spec = policy.Spec
+			if _, err := client.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("failed to update network policy %s/%s: %w", namespace, policy.Name, err)
+			}
+		}
+	}
+	if mode == NetworkIsolationStrict {
+		if err := client.Delete(ctx, AllowNamespacePolicyName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete network policy %s/%s: %w", namespace, AllowNamespacePolicyName, err)
+		}
+	}
+
+	logger.Info("Network isolation '%s' ready in namespace '%s'", mode, namespace)
+	return nil
+}
+
+func networkPolicy(namespace, name string, spec networkingv1.NetworkPolicySpec) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "kubeasy"},
+		},
+		Spec: spec,
+	}
+}
+
+// isolatedPods selects the pods the baseline applies to: every pod but the probe pod.
+// The probe pod runs promMetrics scrapes, probe exercises and connectivity checks, which
+// must not fail because of the baseline. A policy selecting a pod isolates it for its
+// policy types, so the allow policies use this selector too. The pods the probe pod
+// reaches stay isolated.
+func isolatedPods() metav1.LabelSelector {
+	return metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key:      ProbePodLabelKey,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{ProbePodLabelValue},
+	}}}
+}
+
+// defaultDenyPolicy selects every isolated pod for ingress and egress without allowing
+// anything.
+func defaultDenyPolicy(namespace string) *networkingv1.NetworkPolicy {
+	return networkPolicy(namespace, DefaultDenyPolicyName, networkingv1.NetworkPolicySpec{
+		PodSelector: isolatedPods(),
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+	})
+}
+
+// allowDNSPolicy lets every isolated pod query the cluster DNS in kube-system.
+func allowDNSPolicy(namespace string) *networkingv1.NetworkPolicy {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	port := intstr.FromInt32(53)
+	return networkPolicy(namespace, AllowDNSPolicyName, networkingv1.NetworkPolicySpec{
+		PodSelector: isolatedPods(),
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		Egress: []networkingv1.NetworkPolicyEgressRule{{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"}},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "kube-dns"}},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &port}, {Protocol: &tcp, Port: &port}},
+		}},
+	})
+}
+
+// allowNamespacePolicy lets the pods of the namespace, the probe pod included, reach
+// each other.
+func allowNamespacePolicy(namespace string) *networkingv1.NetworkPolicy {
+	sameNamespace := []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}
+	return networkPolicy(namespace, AllowNamespacePolicyName, networkingv1.NetworkPolicySpec{
+		PodSelector: isolatedPods(),
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		Ingress:     []networkingv1.NetworkPolicyIngressRule{{From: sameNamespace}},
+		Egress:      []networkingv1.NetworkPolicyEgressRule{{To: sameNamespace}},
+	})
+}
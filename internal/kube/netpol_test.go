@@ -0,0 +1,70 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseNetworkIsolation(t *testing.T) {
+	for in, want := range map[string]NetworkIsolation{
+		"":          NetworkIsolationNone,
+		"none":      NetworkIsolationNone,
+		"namespace": NetworkIsolationNamespace,
+		"strict":    NetworkIsolationStrict,
+	} {
+		got, err := ParseNetworkIsolation(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseNetworkIsolation("deny-all")
+	assert.ErrorContains(t, err, "unknown network isolation")
+}
+
+func policyNames(t *testing.T, clientset kubernetes.Interface) []string {
+	t.Helper()
+	list, err := clientset.NetworkingV1().NetworkPolicies("demo").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	names := make([]string, 0, len(list.Items))
+	for _, p := range list.Items {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestEnsureNetworkIsolation(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+
+	require.NoError(t, EnsureNetworkIsolation(ctx, clientset, "demo", NetworkIsolationNone))
+	assert.Empty(t, policyNames(t, clientset), "none installs nothing")
+
+	require.NoError(t, EnsureNetworkIsolation(ctx, clientset, "demo", NetworkIsolationNamespace))
+	assert.ElementsMatch(t, []string{DefaultDenyPolicyName, AllowDNSPolicyName, AllowNamespacePolicyName}, policyNames(t, clientset))
+	require.NoError(t, EnsureNetworkIsolation(ctx, clientset, "demo", NetworkIsolationNamespace), "it is idempotent")
+
+	require.NoError(t, EnsureNetworkIsolation(ctx, clientset, "demo", NetworkIsolationStrict))
+	assert.ElementsMatch(t, []string{DefaultDenyPolicyName, AllowDNSPolicyName}, policyNames(t, clientset), "strict drops the same-namespace allowance")
+
+	deny, err := clientset.NetworkingV1().NetworkPolicies("demo").Get(ctx, DefaultDenyPolicyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, deny.Spec.PodSelector.MatchLabels, "every pod is selected")
+	assert.Equal(t, []metav1.LabelSelectorRequirement{{
+		Key: ProbePodLabelKey, Operator: metav1.LabelSelectorOpNotIn, Values: []string{ProbePodLabelValue},
+	}}, deny.Spec.PodSelector.MatchExpressions, "but the probe pod")
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, deny.Spec.PolicyTypes)
+	assert.Empty(t, deny.Spec.Ingress)
+	assert.Empty(t, deny.Spec.Egress)
+
+	dns, err := clientset.NetworkingV1().NetworkPolicies("demo").Get(ctx, AllowDNSPolicyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, dns.Spec.Egress, 1)
+	assert.Len(t, dns.Spec.Egress[0].Ports, 2, "DNS over UDP and TCP")
+	assert.Equal(t, "kube-system", dns.Spec.Egress[0].To[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"])
+}
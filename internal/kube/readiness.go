@@ -0,0 +1,146 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ResourceHealth classifies an object while it is waited for.
+type ResourceHealth string
+
+const (
+	// HealthReady means the object has rolled out.
+	HealthReady ResourceHealth = "Ready"
+	// HealthProgressing means the object is rolling out: waiting is enough.
+	HealthProgressing ResourceHealth = "Progressing"
+	// HealthDegraded means the rollout is stuck (crash loop, image pull error, progress
+	// deadline exceeded...): it needs investigating, waiting will not help.
+	HealthDegraded ResourceHealth = "Degraded"
+)
+
+// ResourceStatus is the status of an object being waited for.
+type ResourceStatus struct {
+	Kind   string
+	Name   string
+	Health ResourceHealth
+	// Message is a one-line status, e.g. the replica counts or why it is degraded.
+	Message string
+}
+
+// ReadyProgressFunc receives the last status of every object a readiness wait is
+// waiting for in namespace.
+type ReadyProgressFunc func(namespace string, statuses []ResourceStatus)
+
+type readyProgressKey struct{}
+
+// WithReadyProgress returns a copy of ctx whose WaitForDeploymentsReady and
+// WaitForStatefulSetsReady calls report to fn every readyProgressInterval, so
+// callers can render the wait instead of reading the logs. A nil fn reports nothing.
+func WithReadyProgress(ctx context.Context, fn ReadyProgressFunc) context.Context {
+	return context.WithValue(ctx, readyProgressKey{}, fn)
+}
+
+// readyProgressFrom returns the ReadyProgressFunc of ctx, or nil.
+func readyProgressFrom(ctx context.Context) ReadyProgressFunc {
+	fn, _ := ctx.Value(readyProgressKey{}).(ReadyProgressFunc)
+	return fn
+}
+
+// readyProgressInterval is how often the ReadyProgressFunc of a wait is called.
+var readyProgressInterval = 5 * time.Second
+
+// stuckWaitingReasons are the container waiting reasons that do not resolve on their own.
+var stuckWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// deploymentDegraded returns why a Deployment's rollout is stuck, from its conditions,
+// or "" when it is not.
+func deploymentDegraded(d *appsv1.Deployment) string {
+	for _, c := range d.Status.Conditions {
+		switch {
+		case c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse:
+			return conditionMessage(c.Reason, c.Message)
+		case c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue:
+			return conditionMessage(c.Reason, c.Message)
+		}
+	}
+	return ""
+}
+
+func conditionMessage(reason, message string) string {
+	if message == "" {
+		return reason
+	}
+	return fmt.Sprintf("%s: %s", reason, message)
+}
+
+// podsDegraded returns why a pod selected by selector in namespace is stuck, or ""
+// when none is. Errors listing the pods are not reported: the rollout is then simply
+// considered progressing.
+func podsDegraded(ctx context.Context, clientset kubernetes.Interface, namespace string, selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return ""
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return ""
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	for _, pod := range pods.Items {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if w := cs.State.Waiting; w != nil && stuckWaitingReasons[w.Reason] {
+				msg := fmt.Sprintf("pod %s container %s: %s", pod.Name, cs.Name, w.Reason)
+				if w.Message != "" {
+					msg += " (" + firstLine(w.Message) + ")"
+				}
+				return msg
+			}
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+				return fmt.Sprintf("pod %s: Unschedulable (%s)", pod.Name, firstLine(c.Message))
+			}
+		}
+	}
+	return ""
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// SummarizeHealth counts statuses by health, e.g. "2 Ready, 1 Degraded".
+func SummarizeHealth(statuses []ResourceStatus) string {
+	counts := map[ResourceHealth]int{}
+	for _, s := range statuses {
+		counts[s.Health]++
+	}
+	var parts []string
+	for _, h := range []ResourceHealth{HealthReady, HealthProgressing, HealthDegraded} {
+		if counts[h] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[h], h))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
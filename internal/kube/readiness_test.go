@@ -0,0 +1,99 @@
+package kube
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentDegraded(t *testing.T) {
+	d := &appsv1.Deployment{}
+	assert.Empty(t, deploymentDegraded(d))
+
+	d.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse,
+		Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "web-1" has timed out progressing.`,
+	}}
+	assert.Equal(t, `ProgressDeadlineExceeded: ReplicaSet "web-1" has timed out progressing.`, deploymentDegraded(d))
+
+	d.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionTrue, Reason: "FailedCreate",
+	}}
+	assert.Equal(t, "FailedCreate", deploymentDegraded(d))
+}
+
+func TestPodsDegraded(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	pod := func(name string, labels map[string]string, waiting string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels}}
+		p.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app"}}
+		if waiting != "" {
+			p.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: waiting, Message: "back-off pulling image\nmore"}
+		}
+		return p
+	}
+	ctx := context.Background()
+
+	clientset := fake.NewClientset(
+		pod("web-a", map[string]string{"app": "web"}, "ContainerCreating"),
+		pod("other", map[string]string{"app": "other"}, "CrashLoopBackOff"),
+	)
+	assert.Empty(t, podsDegraded(ctx, clientset, "ns", selector), "creating containers and other apps' pods are not stuck")
+
+	clientset = fake.NewClientset(pod("web-b", map[string]string{"app": "web"}, "ImagePullBackOff"))
+	assert.Equal(t, "pod web-b container app: ImagePullBackOff (back-off pulling image)", podsDegraded(ctx, clientset, "ns", selector))
+	assert.Empty(t, podsDegraded(ctx, clientset, "ns", nil))
+}
+
+func TestWaitForDeploymentsReady_ReportsProgress(t *testing.T) {
+	origInterval := readyProgressInterval
+	t.Cleanup(func() { readyProgressInterval = origInterval })
+	readyProgressInterval = 10 * time.Millisecond
+
+	var (
+		mu       sync.Mutex
+		reported []ResourceStatus
+	)
+	progress := func(namespace string, statuses []ResourceStatus) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "ns", namespace)
+		reported = statuses
+	}
+
+	replicas := int32(1)
+	clientset := fake.NewClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+			Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+		}}},
+	})
+	ctx, cancel := context.WithTimeout(WithReadyProgress(context.Background(), progress), 100*time.Millisecond)
+	defer cancel()
+	err := WaitForDeploymentsReady(ctx, clientset, "ns", []string{"web"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ProgressDeadlineExceeded", "the error carries why it is degraded")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, reported, 1)
+	assert.Equal(t, "Deployment", reported[0].Kind)
+	assert.Equal(t, "web", reported[0].Name)
+	assert.Equal(t, HealthDegraded, reported[0].Health)
+}
+
+func TestSummarizeHealth(t *testing.T) {
+	assert.Equal(t, "2 Ready, 1 Degraded", SummarizeHealth([]ResourceStatus{
+		{Health: HealthDegraded}, {Health: HealthReady}, {Health: HealthReady},
+	}))
+	assert.Empty(t, SummarizeHealth(nil))
+}
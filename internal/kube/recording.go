@@ -0,0 +1,256 @@
+package kube
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// recordingVersion is bumped when the format of recording.json changes.
+const recordingVersion = 1
+
+// RecordingFile is the file a recording is saved as in its directory.
+const RecordingFile = "recording.json"
+
+// replayHost is the API server address of replayed clients; it is never dialed.
+const replayHost = "https://kubeasy-replay.invalid"
+
+// Recording holds the API server responses read during a validation run, so the run
+// can be replayed offline (see ReplayConfig).
+type Recording struct {
+	Version    int        `json:"version"`
+	Namespace  string     `json:"namespace"`
+	CLIVersion string     `json:"cliVersion"`
+	RecordedAt time.Time  `json:"recordedAt"`
+	Exchanges  []Exchange `json:"exchanges"`
+}
+
+// Exchange is one recorded request and its response.
+type Exchange struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Query is the encoded query, its keys sorted.
+	Query string `json:"query,omitempty"`
+	// RequestSHA256 identifies the body of requests that have one (e.g. access reviews).
+	RequestSHA256 string `json:"requestSha256,omitempty"`
+	Status        int    `json:"status"`
+	ContentType   string `json:"contentType,omitempty"`
+	// Body is the response body when it is text, BinaryBody otherwise.
+	Body       string `json:"body,omitempty"`
+	BinaryBody []byte `json:"binaryBody,omitempty"`
+}
+
+func (e *Exchange) setBody(body []byte) {
+	if utf8.Valid(body) {
+		e.Body = string(body)
+	} else {
+		e.BinaryBody = body
+	}
+}
+
+func (e Exchange) body() []byte {
+	if e.BinaryBody != nil {
+		return e.BinaryBody
+	}
+	return []byte(e.Body)
+}
+
+func (e Exchange) key() string {
+	return e.Method + " " + e.Path + "?" + e.Query + "#" + e.RequestSHA256
+}
+
+// Recorder records the responses of the clients built from the configs it wraps.
+// Streaming requests (watches, followed logs) are passed through unrecorded.
+type Recorder struct {
+	namespace string
+
+	mu        sync.Mutex
+	exchanges map[string]Exchange
+}
+
+// NewRecorder returns a Recorder for a run in namespace.
+func NewRecorder(namespace string) *Recorder {
+	return &Recorder{namespace: namespace, exchanges: map[string]Exchange{}}
+}
+
+// Wrap returns a copy of config whose clients record through r. They talk JSON, so
+// the recording can be read.
+func (r *Recorder) Wrap(config *rest.Config) *rest.Config {
+	config = rest.CopyConfig(config)
+	useJSON(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &recordingRoundTripper{rt: rt, recorder: r}
+	})
+	return config
+}
+
+// Len returns the number of distinct exchanges recorded so far.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.exchanges)
+}
+
+// Save writes the recording to dir/RecordingFile, creating dir. The file holds the
+// objects, logs and events of the namespace: it is written readable by the user only.
+func (r *Recorder) Save(dir string) error {
+	r.mu.Lock()
+	recording := Recording{
+		Version:    recordingVersion,
+		Namespace:  r.namespace,
+		CLIVersion: constants.Version,
+		RecordedAt: time.Now().UTC(),
+		Exchanges:  make([]Exchange, 0, len(r.exchanges)),
+	}
+	for _, e := range r.exchanges {
+		recording.Exchanges = append(recording.Exchanges, e)
+	}
+	r.mu.Unlock()
+	sort.Slice(recording.Exchanges, func(i, j int) bool {
+		return recording.Exchanges[i].key() < recording.Exchanges[j].key()
+	})
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, RecordingFile), data, 0o600)
+}
+
+// LoadRecording reads the recording saved in dir.
+func LoadRecording(dir string) (*Recording, error) {
+	data, err := os.ReadFile(filepath.Join(dir, RecordingFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, fmt.Errorf("failed to parse recording: %w", err)
+	}
+	if recording.Version != recordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d (this CLI reads version %d)", recording.Version, recordingVersion)
+	}
+	return &recording, nil
+}
+
+// ReplayConfig returns a config whose clients answer from the recording instead of an
+// API server. Requests that were not recorded get a NotFound error, so checks that
+// need a live cluster (exec-based connectivity checks, for instance) fail on replay.
+func (rec *Recording) ReplayConfig() *rest.Config {
+	exchanges := make(map[string]Exchange, len(rec.Exchanges))
+	for _, e := range rec.Exchanges {
+		exchanges[e.key()] = e
+	}
+	config := &rest.Config{Host: replayHost, Transport: replayRoundTripper(exchanges)}
+	// Request bodies must be encoded like when recording to match
+	useJSON(config)
+	return config
+}
+
+func useJSON(config *rest.Config) {
+	config.ContentType = "application/json"
+	config.AcceptContentTypes = "application/json"
+}
+
+type recordingRoundTripper struct {
+	rt       http.RoundTripper
+	recorder *Recorder
+}
+
+func (t *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	if query.Get("watch") == "true" || query.Get("follow") == "true" {
+		return t.rt.RoundTrip(req)
+	}
+	exchange, err := requestExchange(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	exchange.Status = resp.StatusCode
+	exchange.ContentType = resp.Header.Get("Content-Type")
+	exchange.setBody(body)
+	t.recorder.mu.Lock()
+	t.recorder.exchanges[exchange.key()] = exchange
+	t.recorder.mu.Unlock()
+	return resp, nil
+}
+
+// requestExchange returns the exchange identifying req, restoring its body.
+func requestExchange(req *http.Request) (Exchange, error) {
+	exchange := Exchange{Method: req.Method, Path: req.URL.Path, Query: req.URL.Query().Encode()}
+	if req.Body == nil || req.Body == http.NoBody {
+		return exchange, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return exchange, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		exchange.RequestSHA256 = hex.EncodeToString(sum[:])
+	}
+	return exchange, nil
+}
+
+type replayRoundTripper map[string]Exchange
+
+func (t replayRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	requested, err := requestExchange(req)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	e, ok := t[requested.key()]
+	if !ok {
+		status := apierrors.NewNotFound(schema.GroupResource{}, req.URL.Path).ErrStatus
+		status.Kind, status.APIVersion = "Status", "v1"
+		status.Message = fmt.Sprintf("%s %s was not recorded", req.Method, req.URL.RequestURI())
+		body, err := json.Marshal(status)
+		if err != nil {
+			return nil, err
+		}
+		e = Exchange{Status: http.StatusNotFound, ContentType: "application/json"}
+		e.setBody(body)
+	}
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	return &http.Response{
+		StatusCode: e.Status,
+		Status:     fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(e.body())),
+		Request:    req,
+	}, nil
+}
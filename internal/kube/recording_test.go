@@ -0,0 +1,111 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/demo/pods/web":
+			_ = json.NewEncoder(w).Encode(&corev1.Pod{
+				TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "demo"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			})
+		case "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews":
+			var review authorizationv1.SelfSubjectAccessReview
+			_ = json.NewDecoder(r.Body).Decode(&review)
+			review.TypeMeta = metav1.TypeMeta{Kind: "SelfSubjectAccessReview", APIVersion: "authorization.k8s.io/v1"}
+			review.Status.Allowed = review.Spec.ResourceAttributes.Verb == "get"
+			_ = json.NewEncoder(w).Encode(&review)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(apierrors.NewNotFound(corev1.Resource("pods"), "gone").ErrStatus)
+		}
+	}))
+	defer server.Close()
+
+	review := func(clientset kubernetes.Interface, verb string) bool {
+		r, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(context.Background(), &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: verb, Resource: "pods"}},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		return r.Status.Allowed
+	}
+
+	recorder := NewRecorder("demo")
+	recording, err := kubernetes.NewForConfig(recorder.Wrap(&rest.Config{Host: server.URL}))
+	require.NoError(t, err)
+	pod, err := recording.CoreV1().Pods("demo").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.PodRunning, pod.Status.Phase)
+	_, err = recording.CoreV1().Pods("demo").Get(context.Background(), "gone", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.True(t, review(recording, "get"))
+	assert.False(t, review(recording, "delete"))
+	assert.Equal(t, 4, recorder.Len())
+
+	dir := filepath.Join(t.TempDir(), "rec")
+	require.NoError(t, recorder.Save(dir))
+	info, err := os.Stat(filepath.Join(dir, RecordingFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := LoadRecording(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "demo", loaded.Namespace)
+	server.Close()
+	recorded := requests
+
+	replay, err := kubernetes.NewForConfig(loaded.ReplayConfig())
+	require.NoError(t, err)
+	pod, err = replay.CoreV1().Pods("demo").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.PodRunning, pod.Status.Phase)
+	_, err = replay.CoreV1().Pods("demo").Get(context.Background(), "gone", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "recorded errors are replayed")
+	assert.True(t, review(replay, "get"), "requests with a body are matched by body")
+	assert.False(t, review(replay, "delete"))
+
+	_, err = replay.CoreV1().Pods("demo").Get(context.Background(), "never-read", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err))
+	assert.Contains(t, err.Error(), "was not recorded")
+	assert.Equal(t, recorded, requests, "replay never reaches the server")
+}
+
+func TestLoadRecording_Version(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, RecordingFile), []byte(`{"version": 99}`), 0o600))
+	_, err := LoadRecording(dir)
+	assert.ErrorContains(t, err, "unsupported recording version 99")
+}
+
+func TestExchange_BinaryBody(t *testing.T) {
+	var e Exchange
+	e.setBody([]byte{0x6b, 0x38, 0x73, 0x00, 0xff})
+	assert.Empty(t, e.Body)
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+	var decoded Exchange
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []byte{0x6b, 0x38, 0x73, 0x00, 0xff}, decoded.body())
+}
@@ -0,0 +1,117 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ValidatorServiceAccountName is the ServiceAccount (and its Role and RoleBinding)
+// created in a challenge namespace to run exec and log validations.
+const ValidatorServiceAccountName = "kubeasy-validator"
+
+// DefaultValidatorTokenTTL is the lifetime of the token requested for a validation run.
+const DefaultValidatorTokenTTL = time.Hour
+
+// validatorRules is everything exec and log validations need: find pods, read their
+// logs and exec into them. Nothing else in the namespace, and nothing outside it.
+var validatorRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
+	{APIGroups: []string{""}, Resources: []string{"pods/log"}, Verbs: []string{"get"}},
+	{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create", "get"}},
+}
+
+// EnsureValidatorSandbox creates the validator ServiceAccount in namespace with a
+// Role limited to pod logs and exec, and binds them. It is idempotent; a Role whose
+// rules were changed is restored.
+func EnsureValidatorSandbox(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	labels := map[string]string{"app.kubernetes.io/managed-by": "kubeasy"}
+	meta := metav1.ObjectMeta{Name: ValidatorServiceAccountName, Namespace: namespace, Labels: labels}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: meta}
+	if _, err := clientset.CoreV1().ServiceAccounts(namespace).Create(ctx, sa, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create service account %s/%s: %w", namespace, ValidatorServiceAccountName, err)
+	}
+
+	role := &rbacv1.Role{ObjectMeta: meta, Rules: validatorRules}
+	roles := clientset.RbacV1().Roles(namespace)
+	if _, err := roles.Create(ctx, role, metav1.CreateOptions{}); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create role %s/%s: %w", namespace, role.Name, err)
+		}
+		existing, err := roles.Get(ctx, role.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get role %s/%s: %w", namespace, role.Name, err)
+		}
+		existing.Rules = validatorRules
+		if _, err := roles.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update role %s/%s: %w", namespace, role.Name, err)
+		}
+	}
+
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     ValidatorServiceAccountName,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      ValidatorServiceAccountName,
+			Namespace: namespace,
+		}},
+	}
+	if _, err := clientset.RbacV1().RoleBindings(namespace).Create(ctx, rb, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create role binding %s/%s: %w", namespace, rb.Name, err)
+	}
+
+	logger.Debug("Validator sandbox ready in namespace '%s'", namespace)
+	return nil
+}
+
+// DeleteValidatorSandbox removes the validator RoleBinding, Role and ServiceAccount
+// from namespace. Missing objects are not an error.
+func DeleteValidatorSandbox(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	if err := clientset.RbacV1().RoleBindings(namespace).Delete(ctx, ValidatorServiceAccountName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete role binding %s/%s: %w", namespace, ValidatorServiceAccountName, err)
+	}
+	if err := clientset.RbacV1().Roles(namespace).Delete(ctx, ValidatorServiceAccountName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete role %s/%s: %w", namespace, ValidatorServiceAccountName, err)
+	}
+	if err := clientset.CoreV1().ServiceAccounts(namespace).Delete(ctx, ValidatorServiceAccountName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete service account %s/%s: %w", namespace, ValidatorServiceAccountName, err)
+	}
+	return nil
+}
+
+// ValidatorRestConfig requests a token for the validator ServiceAccount of namespace
+// and returns a copy of restConfig that authenticates with it instead of the admin
+// credentials.
+func ValidatorRestConfig(ctx context.Context, clientset kubernetes.Interface, restConfig *rest.Config, namespace string, ttl time.Duration) (*rest.Config, error) {
+	seconds := int64(ttl.Seconds())
+	req := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &seconds},
+	}
+	resp, err := clientset.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, ValidatorServiceAccountName, req, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token for %s/%s: %w", namespace, ValidatorServiceAccountName, err)
+	}
+	if resp.Status.Token == "" {
+		return nil, fmt.Errorf("empty token returned for %s/%s", namespace, ValidatorServiceAccountName)
+	}
+
+	// Keep the server and its CA, drop every admin credential.
+	sandbox := rest.AnonymousClientConfig(restConfig)
+	sandbox.BearerToken = resp.Status.Token
+	return sandbox, nil
+}
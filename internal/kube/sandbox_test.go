@@ -0,0 +1,95 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestEnsureValidatorSandbox(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+
+	require.NoError(t, EnsureValidatorSandbox(ctx, clientset, "pod-evicted"))
+
+	_, err := clientset.CoreV1().ServiceAccounts("pod-evicted").Get(ctx, ValidatorServiceAccountName, metav1.GetOptions{})
+	require.NoError(t, err)
+	role, err := clientset.RbacV1().Roles("pod-evicted").Get(ctx, ValidatorServiceAccountName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, validatorRules, role.Rules)
+	rb, err := clientset.RbacV1().RoleBindings("pod-evicted").Get(ctx, ValidatorServiceAccountName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Role", rb.RoleRef.Kind)
+	assert.Equal(t, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: ValidatorServiceAccountName, Namespace: "pod-evicted"}, rb.Subjects[0])
+
+	// A widened Role is restored on the next call.
+	role.Rules = append(role.Rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}})
+	_, err = clientset.RbacV1().Roles("pod-evicted").Update(ctx, role, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, EnsureValidatorSandbox(ctx, clientset, "pod-evicted"))
+	role, err = clientset.RbacV1().Roles("pod-evicted").Get(ctx, ValidatorServiceAccountName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, validatorRules, role.Rules)
+}
+
+func TestValidatorRules_LeastPrivilege(t *testing.T) {
+	for _, rule := range validatorRules {
+		assert.Equal(t, []string{""}, rule.APIGroups)
+		for _, resource := range rule.Resources {
+			assert.Contains(t, []string{"pods", "pods/log", "pods/exec"}, resource)
+		}
+		assert.NotContains(t, rule.Verbs, "*")
+		assert.NotContains(t, rule.Verbs, "delete")
+	}
+}
+
+func TestDeleteValidatorSandbox(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+	require.NoError(t, EnsureValidatorSandbox(ctx, clientset, "pod-evicted"))
+
+	require.NoError(t, DeleteValidatorSandbox(ctx, clientset, "pod-evicted"))
+	_, err := clientset.CoreV1().ServiceAccounts("pod-evicted").Get(ctx, ValidatorServiceAccountName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = clientset.RbacV1().Roles("pod-evicted").Get(ctx, ValidatorServiceAccountName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = clientset.RbacV1().RoleBindings("pod-evicted").Get(ctx, ValidatorServiceAccountName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// Already gone
+	require.NoError(t, DeleteValidatorSandbox(ctx, clientset, "pod-evicted"))
+}
+
+func TestValidatorRestConfig(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "validator-token"}}, nil
+	})
+	admin := &rest.Config{
+		Host:            "https://127.0.0.1:6443",
+		BearerToken:     "admin-token",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca-data"), CertData: []byte("cert"), KeyData: []byte("key")},
+	}
+
+	cfg, err := ValidatorRestConfig(context.Background(), clientset, admin, "pod-evicted", DefaultValidatorTokenTTL)
+	require.NoError(t, err)
+	assert.Equal(t, admin.Host, cfg.Host)
+	assert.Equal(t, "validator-token", cfg.BearerToken)
+	assert.Equal(t, []byte("ca-data"), cfg.CAData)
+	assert.Empty(t, cfg.CertData)
+	assert.Empty(t, cfg.KeyData)
+	assert.Equal(t, "admin-token", admin.BearerToken)
+}
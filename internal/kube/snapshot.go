@@ -0,0 +1,116 @@
+package kube
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/client-go/dynamic"
+)
+
+// ManifestSnapshot records the live state of the objects a manifest touches,
+// so that the changes made by applying it can be rolled back with Restore.
+type ManifestSnapshot struct {
+	dynamicClient dynamic.Interface
+	entries       []snapshotEntry
+}
+
+type snapshotEntry struct {
+	client   dynamic.ResourceInterface
+	kind     string
+	name     string
+	previous *unstructured.Unstructured // nil when the object did not exist
+}
+
+// NewManifestSnapshot returns an empty snapshot; record manifests with Add before applying them.
+func NewManifestSnapshot(dynamicClient dynamic.Interface) *ManifestSnapshot {
+	return &ManifestSnapshot{dynamicClient: dynamicClient}
+}
+
+// Add captures the current state of every object declared in manifestBytes.
+// Documents are decoded and mapped like ApplyManifest does; undecodable or unmapped
+// documents are skipped since ApplyManifest skips them too.
+func (s *ManifestSnapshot) Add(ctx context.Context, manifestBytes []byte, namespace string, mapper meta.RESTMapper) error {
+	decoder := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+
+	return eachYAMLDocument(bytes.NewReader(manifestBytes), func(_ int, doc []byte) error {
+		obj := &unstructured.Unstructured{}
+		_, gvk, err := decoder.Decode(doc, nil, obj)
+		if err != nil {
+			return nil
+		}
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil
+		}
+
+		var client dynamic.ResourceInterface
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			ns := obj.GetNamespace()
+			if ns == "" {
+				ns = namespace
+			}
+			client = s.dynamicClient.Resource(mapping.Resource).Namespace(ns)
+		} else {
+			client = s.dynamicClient.Resource(mapping.Resource)
+		}
+
+		entry := snapshotEntry{client: client, kind: obj.GetKind(), name: obj.GetName()}
+		existing, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		switch {
+		case err == nil:
+			entry.previous = existing
+		case apierrors.IsNotFound(err):
+			// Created by the manifest: Restore deletes it.
+		default:
+			return fmt.Errorf("failed to snapshot %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		s.entries = append(s.entries, entry)
+		return nil
+	})
+}
+
+// Restore puts every snapshotted object back to its recorded state, in reverse order:
+// objects that did not exist are deleted, objects that existed are updated back.
+// It keeps going on failure and returns all errors joined.
+func (s *ManifestSnapshot) Restore(ctx context.Context) error {
+	var errs []error
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		e := s.entries[i]
+
+		if e.previous == nil {
+			err := e.client.Delete(ctx, e.name, metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to delete %s/%s: %w", e.kind, e.name, err))
+				continue
+			}
+			logger.Debug("Restore: deleted %s/%s", e.kind, e.name)
+			continue
+		}
+
+		restored := e.previous.DeepCopy()
+		current, err := e.client.Get(ctx, e.name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			restored.SetResourceVersion(current.GetResourceVersion())
+			_, err = e.client.Update(ctx, restored, metav1.UpdateOptions{})
+		case apierrors.IsNotFound(err):
+			restored.SetResourceVersion("")
+			restored.SetUID("")
+			_, err = e.client.Create(ctx, restored, metav1.CreateOptions{})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s/%s: %w", e.kind, e.name, err))
+			continue
+		}
+		logger.Debug("Restore: restored %s/%s", e.kind, e.name)
+	}
+	return errors.Join(errs...)
+}
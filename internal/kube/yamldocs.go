@@ -0,0 +1,73 @@
+package kube
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// eachYAMLDocument reads a multi-document YAML stream one line at a time and calls fn
+// with every non-empty document and the line it starts on (1-based). Documents are
+// separated by a "---" line, optionally followed by a comment or by the start of the
+// document's content; a "..." line ends a document. Separators must start at column 0,
+// so "---" inside an indented block scalar is kept as content. Lines of any length are
+// supported and only one document is held in memory at a time.
+func eachYAMLDocument(r io.Reader, fn func(line int, doc []byte) error) error {
+	reader := bufio.NewReader(r)
+	var (
+		doc       bytes.Buffer
+		lineNum   int
+		startLine = 1
+	)
+	flush := func() error {
+		defer doc.Reset()
+		if len(bytes.TrimSpace(doc.Bytes())) == 0 {
+			return nil
+		}
+		return fn(startLine, bytes.Clone(doc.Bytes()))
+	}
+
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return fmt.Errorf("error reading manifest: %w", readErr)
+		}
+		if len(line) > 0 {
+			lineNum++
+			content := bytes.TrimRight(line, "\r\n")
+			switch {
+			case bytes.Equal(content, []byte("...")):
+				if err := flush(); err != nil {
+					return err
+				}
+				startLine = lineNum + 1
+			case isDocumentStart(content):
+				if err := flush(); err != nil {
+					return err
+				}
+				startLine = lineNum + 1
+				// "--- {inline: content}" starts the document on the separator line
+				if rest := bytes.TrimSpace(content[3:]); len(rest) > 0 && rest[0] != '#' {
+					doc.Write(rest)
+					doc.WriteByte('\n')
+					startLine = lineNum
+				}
+			default:
+				doc.Write(line)
+			}
+		}
+		if readErr != nil {
+			return flush()
+		}
+	}
+}
+
+// isDocumentStart reports whether line is a "---" document separator.
+func isDocumentStart(line []byte) bool {
+	if !bytes.HasPrefix(line, []byte("---")) {
+		return false
+	}
+	return len(line) == 3 || line[3] == ' ' || line[3] == '\t'
+}
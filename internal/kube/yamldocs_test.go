@@ -0,0 +1,90 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEachYAMLDocument(t *testing.T) {
+	type document struct {
+		line int
+		body string
+	}
+	tests := []struct {
+		name     string
+		manifest string
+		want     []document
+	}{
+		{
+			name:     "single document",
+			manifest: "a: 1\nb: 2\n",
+			want:     []document{{1, "a: 1\nb: 2\n"}},
+		},
+		{
+			name:     "leading separator and trailing separator",
+			manifest: "---\na: 1\n---\n",
+			want:     []document{{2, "a: 1\n"}},
+		},
+		{
+			name:     "separator followed by a comment",
+			manifest: "a: 1\n--- # second\nb: 2",
+			want:     []document{{1, "a: 1\n"}, {3, "b: 2"}},
+		},
+		{
+			name:     "windows line endings",
+			manifest: "a: 1\r\n---\r\nb: 2\r\n",
+			want:     []document{{1, "a: 1\r\n"}, {3, "b: 2\r\n"}},
+		},
+		{
+			name:     "separator inside an indented block scalar is content",
+			manifest: "script: |\n  echo start\n  ---\n  echo end\n---\nb: 2\n",
+			want:     []document{{1, "script: |\n  echo start\n  ---\n  echo end\n"}, {6, "b: 2\n"}},
+		},
+		{
+			name:     "dashes that are not a separator",
+			manifest: "a: 1\n----\n",
+			want:     []document{{1, "a: 1\n----\n"}},
+		},
+		{
+			name:     "document end marker",
+			manifest: "a: 1\n...\nb: 2\n",
+			want:     []document{{1, "a: 1\n"}, {3, "b: 2\n"}},
+		},
+		{
+			name:     "empty documents are skipped",
+			manifest: "---\n\n---\n  \n---\na: 1\n",
+			want:     []document{{6, "a: 1\n"}},
+		},
+		{
+			name:     "inline content on the separator line",
+			manifest: "--- {a: 1}\n",
+			want:     []document{{1, "{a: 1}\n"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []document
+			err := eachYAMLDocument(strings.NewReader(tt.manifest), func(line int, doc []byte) error {
+				got = append(got, document{line, string(doc)})
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEachYAMLDocument_LongLines(t *testing.T) {
+	long := "data: " + strings.Repeat("x", 1<<20)
+	var got []string
+	err := eachYAMLDocument(strings.NewReader(long+"\n---\nb: 2\n"), func(line int, doc []byte) error {
+		got = append(got, string(doc))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{long + "\n", "b: 2\n"}, got)
+}
@@ -207,6 +207,20 @@ func (l *Logger) log(level LogLevel, format string, args ...interface{}) {
 	}
 }
 
+// Sync flushes buffered Kubernetes client logs and commits the log file to disk, so
+// nothing is lost when the process exits right after (e.g. on Ctrl-C).
+func Sync() {
+	klog.Flush()
+	if defaultLogger == nil {
+		return
+	}
+	defaultLogger.mu.Lock()
+	defer defaultLogger.mu.Unlock()
+	if defaultLogger.file != nil {
+		_ = defaultLogger.file.Sync()
+	}
+}
+
 // Debug logs a message at the DEBUG level
 func (l *Logger) Debug(format string, args ...interface{}) {
 	l.log(DEBUG, format, args...)
@@ -0,0 +1,135 @@
+// Package notify sends desktop notifications when the objectives of a challenge
+// change state in the watch modes (dev validate/test --watch, serve --notify), so
+// learners can work in another window.
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+)
+
+// Environment variables carrying the notification to the OS command, so its text
+// never needs quoting in a script.
+const (
+	titleEnvVar   = "KUBEASY_NOTIFY_TITLE"
+	messageEnvVar = "KUBEASY_NOTIFY_MESSAGE"
+)
+
+// Notification is one desktop notification.
+type Notification struct {
+	Title   string
+	Message string
+}
+
+// Send shows n with the notifier of the OS: osascript on macOS, a PowerShell balloon
+// tip on Windows and notify-send elsewhere. It does not wait for the notification
+// to be shown.
+func Send(n Notification) error {
+	c := command(runtime.GOOS, n)
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", c.Path, err)
+	}
+	go func() { _ = c.Wait() }()
+	return nil
+}
+
+func command(goos string, n Notification) *exec.Cmd {
+	var c *exec.Cmd
+	switch goos {
+	case "darwin":
+		c = exec.Command("osascript", "-e",
+			fmt.Sprintf(`display notification (system attribute %q) with title (system attribute %q)`, messageEnvVar, titleEnvVar))
+	case "windows":
+		c = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+			"Add-Type -AssemblyName System.Windows.Forms; "+
+				"$n = New-Object System.Windows.Forms.NotifyIcon; "+
+				"$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; "+
+				fmt.Sprintf("$n.ShowBalloonTip(5000, $env:%s, $env:%s, 'Info'); ", titleEnvVar, messageEnvVar)+
+				"Start-Sleep -Seconds 6; $n.Dispose()")
+	default:
+		c = exec.Command("notify-send", "--app-name=kubeasy", n.Title, n.Message)
+	}
+	c.Env = append(os.Environ(), titleEnvVar+"="+n.Title, messageEnvVar+"="+n.Message)
+	return c
+}
+
+// Notifier turns successive validation runs of a challenge into notifications: one
+// per objective that starts or stops passing, or a single one when the whole
+// challenge passes. The first run only sets the baseline. It is not safe for
+// concurrent use.
+type Notifier struct {
+	slug string
+	send func(Notification) error
+
+	passed    map[string]bool
+	allPassed bool
+	disabled  bool
+}
+
+// New returns a Notifier for the challenge slug that shows notifications with Send.
+func New(slug string) *Notifier {
+	return &Notifier{slug: slug, send: Send}
+}
+
+// Observe records the results of a run and sends the notifications for what changed
+// since the previous one. When sending fails, the Notifier disables itself and returns
+// the error, once.
+func (n *Notifier) Observe(validations []validation.Validation, results []validation.Result) error {
+	notifications := n.changes(validations, results)
+	if n.disabled {
+		return nil
+	}
+	for _, notification := range notifications {
+		if err := n.send(notification); err != nil {
+			n.disabled = true
+			return err
+		}
+	}
+	return nil
+}
+
+// changes updates the recorded state with results and returns what to notify.
+func (n *Notifier) changes(validations []validation.Validation, results []validation.Result) []Notification {
+	first := n.passed == nil
+	previous := n.passed
+	n.passed = make(map[string]bool, len(results))
+	allPassed := len(results) > 0
+	for _, r := range results {
+		n.passed[r.Key] = r.Passed
+		allPassed = allPassed && r.Passed
+	}
+	wasAllPassed := n.allPassed
+	n.allPassed = allPassed
+	if first {
+		return nil
+	}
+
+	title := "Kubeasy: " + n.slug
+	if allPassed {
+		if wasAllPassed {
+			return nil
+		}
+		return []Notification{{Title: title, Message: "All objectives pass: submit with 'kubeasy challenge submit " + n.slug + "'"}}
+	}
+	var notifications []Notification
+	for i, r := range results {
+		was, seen := previous[r.Key]
+		if !seen || was == r.Passed {
+			continue
+		}
+		name := r.Key
+		if i < len(validations) && validations[i].Title != "" {
+			name = validations[i].Title
+		}
+		msg := "Objective passes: " + name
+		if !r.Passed {
+			msg = "Objective no longer passes: " + name
+		}
+		notifications = append(notifications, Notification{Title: title, Message: msg})
+	}
+	return notifications
+}
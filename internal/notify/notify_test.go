@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Observe(t *testing.T) {
+	validations := []validation.Validation{{Key: "pod-ready", Title: "Pod Ready"}, {Key: "no-crash"}}
+	results := func(podReady, noCrash bool) []validation.Result {
+		return []validation.Result{{Key: "pod-ready", Passed: podReady}, {Key: "no-crash", Passed: noCrash}}
+	}
+	var sent []Notification
+	n := &Notifier{slug: "pod-evicted", send: func(notification Notification) error {
+		sent = append(sent, notification)
+		return nil
+	}}
+
+	require.NoError(t, n.Observe(validations, results(true, false)))
+	assert.Empty(t, sent, "the first run only sets the baseline")
+
+	require.NoError(t, n.Observe(validations, results(true, false)))
+	assert.Empty(t, sent, "nothing changed")
+
+	require.NoError(t, n.Observe(validations, results(false, false)))
+	require.Len(t, sent, 1)
+	assert.Equal(t, Notification{Title: "Kubeasy: pod-evicted", Message: "Objective no longer passes: Pod Ready"}, sent[0])
+
+	require.NoError(t, n.Observe(validations, results(false, true)))
+	require.Len(t, sent, 2)
+	assert.Equal(t, "Objective passes: no-crash", sent[1].Message, "untitled objectives are named by key")
+
+	require.NoError(t, n.Observe(validations, results(true, true)))
+	require.Len(t, sent, 3, "the whole challenge passing is a single notification")
+	assert.Contains(t, sent[2].Message, "All objectives pass")
+
+	require.NoError(t, n.Observe(validations, results(true, true)))
+	assert.Len(t, sent, 3)
+}
+
+func TestNotifier_DisabledAfterFailure(t *testing.T) {
+	validations := []validation.Validation{{Key: "a"}}
+	calls := 0
+	n := &Notifier{slug: "demo", send: func(Notification) error {
+		calls++
+		return errors.New("notify-send not found")
+	}}
+	require.NoError(t, n.Observe(validations, []validation.Result{{Key: "a"}}))
+	require.Error(t, n.Observe(validations, []validation.Result{{Key: "a", Passed: true}}))
+	require.NoError(t, n.Observe(validations, []validation.Result{{Key: "a"}}), "the error is returned once")
+	assert.Equal(t, 1, calls)
+}
+
+func TestCommand(t *testing.T) {
+	n := Notification{Title: `Kubeasy: "demo"`, Message: "Objective passes: it's $HOME"}
+	for _, goos := range []string{"darwin", "linux", "windows"} {
+		c := command(goos, n)
+		assert.Contains(t, c.Env, titleEnvVar+"="+n.Title, goos)
+		assert.Contains(t, c.Env, messageEnvVar+"="+n.Message, goos)
+	}
+	assert.Equal(t, []string{"notify-send", "--app-name=kubeasy", n.Title, n.Message}, command("linux", n).Args)
+	for _, arg := range command("darwin", n).Args {
+		assert.NotContains(t, arg, n.Message, "the text is passed through the environment, never quoted in the script")
+	}
+}
@@ -0,0 +1,225 @@
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+)
+
+// CertificateFormat is the file format of a completion summary.
+type CertificateFormat string
+
+const (
+	CertificateMarkdown CertificateFormat = "md"
+	CertificateHTML     CertificateFormat = "html"
+)
+
+// ParseCertificateFormat parses the --certificate flag; "" disables the summary.
+func ParseCertificateFormat(v string) (CertificateFormat, error) {
+	switch f := CertificateFormat(v); f {
+	case "", CertificateMarkdown, CertificateHTML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid certificate format %q (available: md, html)", v)
+	}
+}
+
+// Certificate is the completion summary of a challenge.
+type Certificate struct {
+	Slug        string
+	Title       string
+	Difficulty  string
+	CompletedAt time.Time
+	// Duration is the time from start to completion, 0 when unknown.
+	Duration    time.Duration
+	Submissions int
+	XPAwarded   *int
+	Rank        string
+	// BadgeURL is the shareable completion badge returned by the API, if any.
+	BadgeURL    string
+	Validations []validation.Validation
+	Results     []validation.Result
+}
+
+// certificateObjective is an objective row of the rendered summary.
+type certificateObjective struct {
+	Title  string
+	Type   string
+	Status string
+}
+
+func (c Certificate) objectives() []certificateObjective {
+	run := Run{Validations: c.Validations, Results: c.Results}
+	rows := make([]certificateObjective, len(c.Validations))
+	for i, v := range c.Validations {
+		title := v.Title
+		if title == "" {
+			title = v.Key
+		}
+		status := "failed"
+		if r := run.result(i); r.Passed {
+			status = "passed"
+		} else if r.Skipped {
+			status = "skipped"
+		}
+		rows[i] = certificateObjective{Title: title, Type: string(v.Type), Status: status}
+	}
+	return rows
+}
+
+// certificateData is what the templates render.
+type certificateData struct {
+	Certificate
+	Name        string
+	Completed   string
+	TimeSpent   string
+	Score       string
+	Objectives  []certificateObjective
+	PassedCount int
+}
+
+func (c Certificate) data() certificateData {
+	d := certificateData{
+		Certificate: c,
+		Name:        c.Title,
+		Completed:   c.CompletedAt.Local().Format(time.DateTime),
+		Objectives:  c.objectives(),
+	}
+	if d.Name == "" {
+		d.Name = c.Slug
+	}
+	if c.Duration > 0 {
+		d.TimeSpent = c.Duration.Round(time.Second).String()
+	}
+	if c.XPAwarded != nil {
+		d.Score = fmt.Sprintf("%d XP", *c.XPAwarded)
+	}
+	for _, o := range d.Objectives {
+		if o.Status == "passed" {
+			d.PassedCount++
+		}
+	}
+	return d
+}
+
+// markdownFuncs escapes the pipes that would break a table row.
+var markdownFuncs = template.FuncMap{"cell": func(s string) string { return strings.ReplaceAll(s, "|", `\|`) }}
+
+var markdownCertificate = template.Must(template.New("md").Funcs(markdownFuncs).Parse(`# {{.Name}} completed
+
+{{if .BadgeURL}}![Kubeasy badge]({{.BadgeURL}})
+
+{{end}}| | |
+|---|---|
+| Challenge | ` + "`{{.Slug}}`" + ` |
+{{- if .Difficulty}}
+| Difficulty | {{cell .Difficulty}} |
+{{- end}}
+| Completed | {{.Completed}} |
+{{- if .TimeSpent}}
+| Time spent | {{.TimeSpent}} |
+{{- end}}
+{{- if .Submissions}}
+| Submissions | {{.Submissions}} |
+{{- end}}
+{{- if .Score}}
+| Score | {{.Score}} |
+{{- end}}
+{{- if .Rank}}
+| Rank | {{cell .Rank}} |
+{{- end}}
+
+## Objectives ({{.PassedCount}}/{{len .Objectives}})
+
+| Objective | Type | Result |
+|---|---|---|
+{{- range .Objectives}}
+| {{cell .Title}} | {{.Type}} | {{.Status}} |
+{{- end}}
+`))
+
+var htmlCertificate = htmltemplate.Must(htmltemplate.New("html").Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} completed</title>
+<style>
+  body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 48rem; padding: 0 1rem; color: #1f2933; }
+  table { border-collapse: collapse; margin-bottom: 1.5rem; }
+  th, td { text-align: left; padding: .35rem .75rem; border-bottom: 1px solid #e4e7eb; }
+  .passed { color: #1b873f; }
+  .failed { color: #c62828; }
+  .skipped { color: #9a6700; }
+</style>
+</head>
+<body>
+<h1>{{.Name}} completed</h1>
+{{if .BadgeURL}}<p><a href="{{.BadgeURL}}"><img src="{{.BadgeURL}}" alt="Kubeasy badge"></a></p>{{end}}
+<table>
+  <tr><th>Challenge</th><td><code>{{.Slug}}</code></td></tr>
+  {{if .Difficulty}}<tr><th>Difficulty</th><td>{{.Difficulty}}</td></tr>{{end}}
+  <tr><th>Completed</th><td>{{.Completed}}</td></tr>
+  {{if .TimeSpent}}<tr><th>Time spent</th><td>{{.TimeSpent}}</td></tr>{{end}}
+  {{if .Submissions}}<tr><th>Submissions</th><td>{{.Submissions}}</td></tr>{{end}}
+  {{if .Score}}<tr><th>Score</th><td>{{.Score}}</td></tr>{{end}}
+  {{if .Rank}}<tr><th>Rank</th><td>{{.Rank}}</td></tr>{{end}}
+</table>
+<h2>Objectives ({{.PassedCount}}/{{len .Objectives}})</h2>
+<table>
+  <tr><th>Objective</th><th>Type</th><th>Result</th></tr>
+  {{range .Objectives}}<tr><td>{{.Title}}</td><td>{{.Type}}</td><td class="{{.Status}}">{{.Status}}</td></tr>
+  {{end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteCertificate renders c in format.
+func WriteCertificate(w io.Writer, format CertificateFormat, c Certificate) error {
+	var err error
+	switch format {
+	case CertificateMarkdown:
+		err = markdownCertificate.Execute(w, c.data())
+	case CertificateHTML:
+		err = htmlCertificate.Execute(w, c.data())
+	default:
+		return fmt.Errorf("unknown certificate format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render certificate: %w", err)
+	}
+	return nil
+}
+
+// GetReportsDir returns the directory of saved completion summaries (~/.kubeasy/reports).
+func GetReportsDir() string {
+	return filepath.Join(constants.GetKubeasyConfigDir(), "reports")
+}
+
+// SaveCertificate writes c under GetReportsDir as <slug>-<completion time>.<format>
+// and returns the path of the file.
+func SaveCertificate(format CertificateFormat, c Certificate) (string, error) {
+	dir := GetReportsDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create reports dir: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.%s", c.Slug, c.CompletedAt.UTC().Format("20060102-150405"), format)
+	path := filepath.Join(dir, name)
+
+	var b strings.Builder
+	if err := WriteCertificate(&b, format, c); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write certificate: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCertificate() Certificate {
+	xp := 50
+	return Certificate{
+		Slug:        "pod-evicted",
+		Title:       "Pod <Evicted>",
+		Difficulty:  "easy",
+		CompletedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Duration:    42 * time.Minute,
+		Submissions: 2,
+		XPAwarded:   &xp,
+		BadgeURL:    "https://kubeasy.dev/badges/pod-evicted.svg",
+		Validations: []validation.Validation{
+			{Key: "pod-ready", Title: "Pod | Ready", Type: validation.TypeCondition},
+			{Key: "cpu", Type: validation.TypeSpec},
+		},
+		Results: []validation.Result{{Key: "pod-ready", Passed: true}, {Key: "cpu", Skipped: true}},
+	}
+}
+
+func TestParseCertificateFormat(t *testing.T) {
+	for _, v := range []string{"", "md", "html"} {
+		f, err := ParseCertificateFormat(v)
+		require.NoError(t, err)
+		assert.Equal(t, CertificateFormat(v), f)
+	}
+	_, err := ParseCertificateFormat("pdf")
+	assert.ErrorContains(t, err, "invalid certificate format")
+}
+
+func TestWriteCertificate_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCertificate(&buf, CertificateMarkdown, testCertificate()))
+	out := buf.String()
+
+	assert.Contains(t, out, "# Pod <Evicted> completed")
+	assert.Contains(t, out, "![Kubeasy badge](https://kubeasy.dev/badges/pod-evicted.svg)")
+	assert.Contains(t, out, "| Time spent | 42m0s |")
+	assert.Contains(t, out, "| Score | 50 XP |")
+	assert.Contains(t, out, "## Objectives (1/2)")
+	assert.Contains(t, out, "| cpu | spec | skipped |")
+	assert.Contains(t, out, `| Pod \| Ready | condition | passed |`)
+	assert.NotContains(t, out, "| Rank |", "unknown fields are left out")
+}
+
+func TestWriteCertificate_HTMLEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCertificate(&buf, CertificateHTML, testCertificate()))
+	out := buf.String()
+
+	assert.Contains(t, out, "<h1>Pod &lt;Evicted&gt; completed</h1>")
+	assert.Contains(t, out, `<td class="passed">passed</td>`)
+}
+
+func TestSaveCertificate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := SaveCertificate(CertificateMarkdown, testCertificate())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(GetReportsDir(), "pod-evicted-20240101-120000.md"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Pod <Evicted>")
+}
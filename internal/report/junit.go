@@ -0,0 +1,108 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes run as JUnit XML: one test suite for the challenge, one test
+// case per objective, classed by validation type.
+func WriteJUnit(w io.Writer, run Run) error {
+	summary := validation.Summarize(run.Validations, run.Results, run.Duration)
+	suite := junitSuite{
+		Name:     run.Name,
+		Tests:    summary.Total,
+		Failures: summary.Failed,
+		Skipped:  summary.Skipped,
+		Time:     seconds(run.Duration),
+		Cases:    make([]junitCase, len(run.Validations)),
+	}
+	for i, v := range run.Validations {
+		r := run.result(i)
+		c := junitCase{
+			Name:      v.Key,
+			ClassName: fmt.Sprintf("%s.%s", run.Name, v.Type),
+			Time:      seconds(r.Duration),
+		}
+		switch {
+		case r.Passed:
+		case r.Skipped:
+			c.Skipped = &junitMessage{Message: r.Message}
+		default:
+			c.Failure = &junitMessage{Message: r.Message, Text: failureText(v, r)}
+		}
+		suite.Cases[i] = c
+	}
+	doc := junitSuites{
+		Name:     "kubeasy",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// failureText details a failure: the objective title and its failed comparisons.
+func failureText(v validation.Validation, r validation.Result) string {
+	text := v.Title
+	for _, c := range r.Comparisons {
+		line := fmt.Sprintf("%s %s %v, observed %v", c.Field, c.Operator, c.Expected, c.Observed)
+		if c.ObjectRef != nil {
+			line = fmt.Sprintf("%s/%s: %s", c.ObjectRef.Kind, c.ObjectRef.Name, line)
+		}
+		text += "\n" + line
+	}
+	return text
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
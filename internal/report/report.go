@@ -0,0 +1,91 @@
+// Package report exports validation results in formats read by CI and grading
+// tools: JUnit XML and SARIF.
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+)
+
+// Format is a report format.
+type Format string
+
+const (
+	FormatJUnit Format = "junit"
+	FormatSARIF Format = "sarif"
+)
+
+// Target is a report to write, parsed from a --report flag value "format=path".
+type Target struct {
+	Format Format
+	Path   string
+}
+
+// Run is a validation run to report.
+type Run struct {
+	// Name identifies the run, usually the challenge slug.
+	Name        string
+	Validations []validation.Validation
+	// Results are in the order of Validations; validations without a result (after
+	// a fail-fast stop) are reported as failed.
+	Results  []validation.Result
+	Duration time.Duration
+}
+
+// ParseTargets parses --report flag values such as "junit=report.xml".
+func ParseTargets(values []string) ([]Target, error) {
+	targets := make([]Target, 0, len(values))
+	for _, v := range values {
+		format, path, ok := strings.Cut(v, "=")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("invalid report %q: expected format=path, e.g. junit=report.xml", v)
+		}
+		switch Format(format) {
+		case FormatJUnit, FormatSARIF:
+		default:
+			return nil, fmt.Errorf("invalid report %q: unknown format %q (available: junit, sarif)", v, format)
+		}
+		targets = append(targets, Target{Format: Format(format), Path: path})
+	}
+	return targets, nil
+}
+
+// Write writes the report of run to the file of t, creating its directory.
+func Write(t Target, run Run) error {
+	if dir := filepath.Dir(t.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	f, err := os.Create(t.Path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer f.Close()
+
+	switch t.Format {
+	case FormatJUnit:
+		err = WriteJUnit(f, run)
+	case FormatSARIF:
+		err = WriteSARIF(f, run)
+	default:
+		err = fmt.Errorf("unknown report format %q", t.Format)
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// result returns the result of the i-th validation, or a failure when it did not run.
+func (r Run) result(i int) validation.Result {
+	if i < len(r.Results) {
+		return r.Results[i]
+	}
+	return validation.Result{Key: r.Validations[i].Key, Message: "Not run: an earlier validation failed"}
+}
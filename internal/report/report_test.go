@@ -0,0 +1,103 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRun() Run {
+	return Run{
+		Name: "pod-evicted",
+		Validations: []validation.Validation{
+			{Key: "pod-ready", Title: "Pod Ready", Type: validation.TypeCondition},
+			{Key: "replicas", Title: "Replicas", Type: validation.TypeStatus},
+			{Key: "cpu", Title: "CPU", Type: validation.TypeSpec},
+			{Key: "logs", Title: "Logs", Type: validation.TypeLog},
+		},
+		Results: []validation.Result{
+			{Key: "pod-ready", Passed: true, Message: "ok", Duration: 1500 * time.Millisecond},
+			{Key: "replicas", Message: "1 check failed", Comparisons: []validation.Comparison{{
+				Field: "readyReplicas", Operator: ">=", Expected: 3, Observed: 1,
+				ObjectRef: &validation.ObjectRef{Kind: "Deployment", Namespace: "pod-evicted", Name: "web"},
+			}}},
+			{Key: "cpu", Skipped: true, Message: "metrics-server is not installed"},
+		},
+		Duration: 2 * time.Second,
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	targets, err := ParseTargets([]string{"junit=out/report.xml", "sarif=results.sarif"})
+	require.NoError(t, err)
+	assert.Equal(t, []Target{{FormatJUnit, "out/report.xml"}, {FormatSARIF, "results.sarif"}}, targets)
+
+	_, err = ParseTargets([]string{"junit"})
+	assert.ErrorContains(t, err, "expected format=path")
+	_, err = ParseTargets([]string{"html=report.html"})
+	assert.ErrorContains(t, err, "unknown format")
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, testRun()))
+
+	var doc junitSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, 4, doc.Tests)
+	assert.Equal(t, 2, doc.Failures, "a validation without result counts as failed")
+	assert.Equal(t, 1, doc.Skipped)
+	require.Len(t, doc.Suites, 1)
+
+	cases := doc.Suites[0].Cases
+	require.Len(t, cases, 4)
+	assert.Equal(t, "pod-evicted.condition", cases[0].ClassName)
+	assert.Equal(t, "1.500", cases[0].Time)
+	assert.Nil(t, cases[0].Failure)
+	require.NotNil(t, cases[1].Failure)
+	assert.Equal(t, "1 check failed", cases[1].Failure.Message)
+	assert.Contains(t, cases[1].Failure.Text, "Deployment/web: readyReplicas >= 3, observed 1")
+	require.NotNil(t, cases[2].Skipped)
+	require.NotNil(t, cases[3].Failure)
+	assert.Contains(t, cases[3].Failure.Message, "Not run")
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, testRun()))
+
+	var doc sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "2.1.0", doc.Version)
+	require.Len(t, doc.Runs, 1)
+	run := doc.Runs[0]
+	assert.Equal(t, "kubeasy", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 4)
+	assert.Equal(t, "status", run.Tool.Driver.Rules[1].Properties["type"])
+
+	require.Len(t, run.Results, 4)
+	assert.Equal(t, "pass", run.Results[0].Kind)
+	assert.Equal(t, "fail", run.Results[1].Kind)
+	assert.Equal(t, "error", run.Results[1].Level)
+	require.Len(t, run.Results[1].Locations, 1)
+	assert.Equal(t, "pod-evicted/Deployment/web", run.Results[1].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "notApplicable", run.Results[2].Kind)
+	assert.Equal(t, "fail", run.Results[3].Kind)
+}
+
+func TestWrite_CreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	require.NoError(t, Write(Target{Format: FormatJUnit, Path: path}, testRun()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<testsuites")
+}
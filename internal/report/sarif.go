@@ -0,0 +1,140 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name,omitempty"`
+	ShortDescription *sarifMessage     `json:"shortDescription,omitempty"`
+	FullDescription  *sarifMessage     `json:"fullDescription,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// WriteSARIF writes run as a SARIF 2.1.0 log: one rule per objective and one result
+// per objective, of kind "pass", "fail" or "notApplicable" (skipped). Failed
+// comparisons on a Kubernetes object become logical locations
+// ("namespace/Kind/name").
+func WriteSARIF(w io.Writer, run Run) error {
+	driver := sarifDriver{
+		Name:           "kubeasy",
+		Version:        constants.Version,
+		InformationURI: constants.WebsiteURL,
+		Rules:          make([]sarifRule, len(run.Validations)),
+	}
+	results := make([]sarifResult, len(run.Validations))
+	for i, v := range run.Validations {
+		rule := sarifRule{ID: v.Key, Name: v.Title, Properties: map[string]string{"type": string(v.Type)}}
+		if v.Title != "" {
+			rule.ShortDescription = &sarifMessage{Text: v.Title}
+		}
+		if v.Description != "" {
+			rule.FullDescription = &sarifMessage{Text: v.Description}
+		}
+		if v.Phase != "" {
+			rule.Properties["phase"] = v.Phase
+		}
+		driver.Rules[i] = rule
+
+		r := run.result(i)
+		res := sarifResult{RuleID: v.Key, RuleIndex: i, Message: sarifMessage{Text: r.Message}}
+		switch {
+		case r.Passed:
+			res.Kind, res.Level = "pass", "none"
+		case r.Skipped:
+			res.Kind, res.Level = "notApplicable", "none"
+		default:
+			res.Kind, res.Level = "fail", "error"
+			res.Locations = sarifLocations(r.Comparisons)
+		}
+		if res.Message.Text == "" {
+			res.Message.Text = res.Kind
+		}
+		results[i] = res
+	}
+
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode SARIF report: %w", err)
+	}
+	return nil
+}
+
+// sarifLocations returns the distinct objects of the comparisons, in order.
+func sarifLocations(comparisons []validation.Comparison) []sarifLocation {
+	var locations []sarifLocation
+	seen := make(map[string]bool)
+	for _, c := range comparisons {
+		if c.ObjectRef == nil {
+			continue
+		}
+		name := strings.Join([]string{c.ObjectRef.Namespace, c.ObjectRef.Kind, c.ObjectRef.Name}, "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		locations = append(locations, sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: name, Kind: "resource"}},
+		})
+	}
+	return locations
+}
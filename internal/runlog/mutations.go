@@ -0,0 +1,162 @@
+package runlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Scopes of a mutation: the kubeasy cluster or the Kubeasy API.
+const (
+	ScopeCluster = "cluster"
+	ScopeAPI     = "api"
+)
+
+// Mutation outcomes.
+const (
+	OutcomeOK     = "ok"
+	OutcomeFailed = "failed"
+)
+
+// ClusterAuditEnv turns on the copy of cluster mutations to the kubeasy-audit ConfigMap.
+const ClusterAuditEnv = "KUBEASY_AUDIT_CLUSTER"
+
+// mutationVerbs maps the HTTP methods that change state to the verb recorded.
+var mutationVerbs = map[string]string{
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "patch",
+	http.MethodDelete: "delete",
+}
+
+// Transport returns a round tripper that records every mutating request sent through
+// base (POST, PUT, PATCH, DELETE) in the current run log, with its outcome. Reads are
+// not recorded. scope is ScopeCluster or ScopeAPI.
+func Transport(scope string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &mutationTransport{scope: scope, base: base}
+}
+
+type mutationTransport struct {
+	scope string
+	base  http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *mutationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	verb, ok := mutationVerbs[req.Method]
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	if req.URL.Query().Has("dryRun") {
+		verb += " (dry run)"
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	e := Event{Type: EventMutation, Scope: t.scope, Verb: verb, Target: req.URL.Path, Status: OutcomeOK}
+	switch {
+	case err != nil:
+		e.Status = OutcomeFailed
+		e.Error = err.Error()
+	case resp.StatusCode >= 400:
+		e.Status = OutcomeFailed
+		e.Code = resp.StatusCode
+		e.Error = resp.Status
+	default:
+		e.Code = resp.StatusCode
+	}
+	Record(e)
+	return resp, err
+}
+
+// Mutation is a mutation event with the run it belongs to.
+type Mutation struct {
+	RunID   string `json:"runId"`
+	Command string `json:"command,omitempty"`
+	Event
+}
+
+// Mutations returns the mutations recorded so far by r.
+func (r *Run) Mutations() []Mutation {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Mutation(nil), r.mutations...)
+}
+
+// ReadMutations returns the mutations of every kept run log, oldest first. Logs that
+// cannot be read are skipped.
+func ReadMutations() ([]Mutation, error) {
+	entries, err := os.ReadDir(Dir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run logs: %w", err)
+	}
+
+	var mutations []Mutation
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		runMutations, err := readRunMutations(filepath.Join(Dir(), entry.Name()))
+		if err != nil {
+			continue
+		}
+		mutations = append(mutations, runMutations...)
+	}
+	sort.SliceStable(mutations, func(i, j int) bool { return mutations[i].Time.Before(mutations[j].Time) })
+	return mutations, nil
+}
+
+func readRunMutations(path string) ([]Mutation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	runID := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	var command string
+	var mutations []Mutation
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// A run killed mid-write leaves a truncated last line
+			continue
+		}
+		switch e.Type {
+		case EventRunStart:
+			command = commandLine(e.Command, e.Args)
+		case EventMutation:
+			mutations = append(mutations, Mutation{RunID: runID, Command: command, Event: e})
+		}
+	}
+	return mutations, scanner.Err()
+}
+
+func commandLine(command string, args []string) string {
+	return strings.TrimSpace(strings.Join(append([]string{command}, args...), " "))
+}
+
+// ClusterAuditEnabled reports whether KUBEASY_AUDIT_CLUSTER asks for cluster mutations
+// to be copied to the kubeasy-audit ConfigMap.
+func ClusterAuditEnabled() bool {
+	switch strings.ToLower(os.Getenv(ClusterAuditEnv)) {
+	case "1", "on", "true", "yes":
+		return true
+	}
+	return false
+}
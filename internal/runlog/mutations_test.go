@@ -0,0 +1,92 @@
+package runlog
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_RecordsMutationsOnly(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	run, err := Start("kubeasy challenge reset", []string{"pod-evicted"})
+	require.NoError(t, err)
+	client := &http.Client{Transport: Transport(ScopeCluster, nil)}
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/namespaces/pod-evicted"},
+		{http.MethodPost, "/api/v1/namespaces"},
+		{http.MethodPatch, "/apis/apps/v1/namespaces/pod-evicted/deployments/web?dryRun=All"},
+		{http.MethodDelete, "/api/v1/namespaces/pod-evicted"},
+	} {
+		r, err := http.NewRequest(req.method, server.URL+req.path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(r)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	mutations := run.Mutations()
+	require.Len(t, mutations, 3, "reads are not recorded")
+	assert.Equal(t, "create", mutations[0].Verb)
+	assert.Equal(t, "/api/v1/namespaces", mutations[0].Target)
+	assert.Equal(t, OutcomeOK, mutations[0].Status)
+	assert.Equal(t, http.StatusOK, mutations[0].Code)
+	assert.Equal(t, "patch (dry run)", mutations[1].Verb)
+	assert.Equal(t, "delete", mutations[2].Verb)
+	assert.Equal(t, OutcomeFailed, mutations[2].Status)
+	assert.Equal(t, http.StatusForbidden, mutations[2].Code)
+	assert.Equal(t, run.ID, mutations[2].RunID)
+	assert.Equal(t, "kubeasy challenge reset pod-evicted", mutations[2].Command)
+	run.Finish(nil, false)
+
+	read, err := ReadMutations()
+	require.NoError(t, err)
+	require.Len(t, read, 3)
+	for i := range read {
+		assert.Equal(t, mutations[i].RunID, read[i].RunID)
+		assert.Equal(t, mutations[i].Command, read[i].Command)
+		assert.Equal(t, mutations[i].Verb, read[i].Verb)
+		assert.Equal(t, mutations[i].Target, read[i].Target)
+		assert.True(t, mutations[i].Time.Equal(read[i].Time))
+	}
+}
+
+func TestTransport_RecordsTransportErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	run, err := Start("kubeasy challenge submit", nil)
+	require.NoError(t, err)
+	defer run.Finish(nil, false)
+
+	failing := roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, errors.New("connection refused") })
+	req, err := http.NewRequest(http.MethodPost, "https://kubeasy.dev/api/submit", nil)
+	require.NoError(t, err)
+	_, err = Transport(ScopeAPI, failing).RoundTrip(req)
+	require.Error(t, err)
+
+	mutations := run.Mutations()
+	require.Len(t, mutations, 1)
+	assert.Equal(t, ScopeAPI, mutations[0].Scope)
+	assert.Equal(t, OutcomeFailed, mutations[0].Status)
+	assert.Equal(t, "connection refused", mutations[0].Error)
+}
+
+func TestClusterAuditEnabled(t *testing.T) {
+	t.Setenv(ClusterAuditEnv, "")
+	assert.False(t, ClusterAuditEnabled())
+	t.Setenv(ClusterAuditEnv, "on")
+	assert.True(t, ClusterAuditEnabled())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,184 @@
+// Package runlog writes a machine-readable event log of every CLI run: one JSON object
+// per line (steps, durations, errors, mutating requests) in ~/.kubeasy/runs/<run-id>.jsonl. Error messages
+// point at the file so a user can attach it to a bug report.
+package runlog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+)
+
+// maxRuns is how many run logs are kept; older ones are deleted when a run starts.
+const maxRuns = 50
+
+// idLayout formats run IDs. It sorts chronologically and is a valid file name everywhere.
+const idLayout = "2006-01-02T15-04-05.000Z"
+
+// Event types.
+const (
+	EventRunStart = "run_start"
+	EventStep     = "step"
+	EventRunEnd   = "run_end"
+	EventMutation = "mutation"
+)
+
+// Event is one line of a run log. Fields that do not apply to a type are omitted.
+type Event struct {
+	Time        time.Time `json:"time"`
+	Type        string    `json:"type"`
+	Command     string    `json:"command,omitempty"`
+	Args        []string  `json:"args,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	Step        string    `json:"step,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	DurationMs  int64     `json:"durationMs,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Interrupted bool      `json:"interrupted,omitempty"`
+	Scope       string    `json:"scope,omitempty"`
+	Verb        string    `json:"verb,omitempty"`
+	Target      string    `json:"target,omitempty"`
+	Code        int       `json:"code,omitempty"`
+	ErrorCode   string    `json:"errorCode,omitempty"`
+}
+
+// Run is the event log of one CLI run. A nil *Run discards events.
+type Run struct {
+	ID    string
+	Path  string
+	start time.Time
+
+	command string
+
+	mu        sync.Mutex
+	file      *os.File
+	mutations []Mutation
+}
+
+var (
+	currentMu sync.Mutex
+	current   *Run
+)
+
+// Dir returns the directory holding the run logs.
+func Dir() string {
+	return filepath.Join(constants.GetKubeasyConfigDir(), "runs")
+}
+
+// Start creates the log of a new run of command, records its start and makes it the
+// current run. Only positional args are recorded, never flag values.
+func Start(command string, args []string) (*Run, error) {
+	dir := Dir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create run log directory: %w", err)
+	}
+	prune(dir, maxRuns-1)
+
+	now := time.Now()
+	id := now.UTC().Format(idLayout)
+	path := filepath.Join(dir, id+".jsonl")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		// Two runs started within the same millisecond
+		id = fmt.Sprintf("%s-%d", id, os.Getpid())
+		path = filepath.Join(dir, id+".jsonl")
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run log: %w", err)
+	}
+
+	r := &Run{ID: id, Path: path, start: now, file: file, command: commandLine(command, args)}
+	r.Record(Event{Type: EventRunStart, Command: command, Args: args, Version: constants.Version})
+
+	currentMu.Lock()
+	current = r
+	currentMu.Unlock()
+	return r, nil
+}
+
+// Current returns the run started by Start, or nil.
+func Current() *Run {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	return current
+}
+
+// Record appends e to the current run log, if any.
+func Record(e Event) {
+	Current().Record(e)
+}
+
+// Record appends e to the log. Time defaults to now. Write errors are ignored: the run
+// log must never make a command fail.
+func (r *Run) Record(e Event) {
+	if r == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		_, _ = r.file.Write(append(line, '\n'))
+		if e.Type == EventMutation {
+			r.mutations = append(r.mutations, Mutation{RunID: r.ID, Command: r.command, Event: e})
+		}
+	}
+}
+
+// Finish records the end of the run with its outcome and closes the log.
+func (r *Run) Finish(runErr error, interrupted bool) {
+	if r == nil {
+		return
+	}
+	e := Event{Type: EventRunEnd, DurationMs: time.Since(r.start).Milliseconds(), Interrupted: interrupted}
+	if runErr != nil {
+		e.Error = runErr.Error()
+		e.ErrorCode = string(kerrors.CodeOf(runErr))
+	}
+	r.Record(e)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+}
+
+// prune deletes the oldest run logs so that at most keep remain.
+func prune(dir string, keep int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var logs []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".jsonl") {
+			logs = append(logs, entry.Name())
+		}
+	}
+	if len(logs) <= keep {
+		return
+	}
+	sort.Strings(logs)
+	for _, name := range logs[:len(logs)-keep] {
+		_ = os.Remove(filepath.Join(dir, name))
+	}
+}
@@ -0,0 +1,82 @@
+package runlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEvents(t *testing.T, path string) []Event {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e), "every line is a JSON object")
+		events = append(events, e)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestRun_RecordsStartStepsAndEnd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	run, err := Start("kubeasy challenge start", []string{"pod-evicted"})
+	require.NoError(t, err)
+	assert.Equal(t, run, Current())
+	assert.Equal(t, filepath.Join(Dir(), run.ID+".jsonl"), run.Path)
+
+	Record(Event{Type: EventStep, Step: "namespace", Status: "started"})
+	Record(Event{Type: EventStep, Step: "namespace", Status: "failed", DurationMs: 12, Error: "forbidden"})
+	run.Finish(errors.New("step namespace failed"), true)
+	run.Record(Event{Type: EventStep, Step: "ignored"}) // after Finish: dropped
+
+	events := readEvents(t, run.Path)
+	require.Len(t, events, 4)
+	assert.Equal(t, EventRunStart, events[0].Type)
+	assert.Equal(t, "kubeasy challenge start", events[0].Command)
+	assert.Equal(t, []string{"pod-evicted"}, events[0].Args)
+	assert.Equal(t, "forbidden", events[2].Error)
+	assert.Equal(t, EventRunEnd, events[3].Type)
+	assert.Equal(t, "step namespace failed", events[3].Error)
+	assert.True(t, events[3].Interrupted)
+	assert.False(t, events[3].Time.IsZero())
+}
+
+func TestRun_NilDiscardsEvents(t *testing.T) {
+	var run *Run
+	assert.NotPanics(t, func() {
+		run.Record(Event{Type: EventStep})
+		run.Finish(nil, false)
+	})
+}
+
+func TestPrune_KeepsNewestRuns(t *testing.T) {
+	dir := t.TempDir()
+	for i := range 5 {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("2024-06-0%dT10-00-00.000Z.jsonl", i+1)), nil, 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o600))
+
+	prune(dir, 2)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"2024-06-04T10-00-00.000Z.jsonl", "2024-06-05T10-00-00.000Z.jsonl", "notes.txt"}, names)
+}
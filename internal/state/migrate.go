@@ -0,0 +1,39 @@
+package state
+
+import "fmt"
+
+// migrations upgrade the raw state file from version i to i+1. A file without a
+// version field is version 0. When the schema changes, bump CurrentVersion and add
+// the step that converts the previous version.
+var migrations = []func(raw map[string]interface{}) error{
+	// 0 -> 1: files written before the schema was versioned.
+	func(raw map[string]interface{}) error {
+		if _, ok := raw["challenges"]; !ok {
+			raw["challenges"] = map[string]interface{}{}
+		}
+		return nil
+	},
+}
+
+// migrate upgrades raw in place to CurrentVersion.
+func migrate(raw map[string]interface{}) error {
+	version := 0
+	if v, ok := raw["version"]; ok {
+		n, ok := v.(float64)
+		if !ok || n != float64(int(n)) || n < 0 {
+			return fmt.Errorf("invalid state file version %v", v)
+		}
+		version = int(n)
+	}
+	if version > CurrentVersion {
+		return fmt.Errorf("state file version %d was written by a newer kubeasy; upgrade the CLI or run 'kubeasy state clear'", version)
+	}
+
+	for ; version < CurrentVersion; version++ {
+		if err := migrations[version](raw); err != nil {
+			return fmt.Errorf("failed to migrate state file from version %d: %w", version, err)
+		}
+	}
+	raw["version"] = CurrentVersion
+	return nil
+}
@@ -0,0 +1,176 @@
+// Package state is the durable local state of the CLI, kept in a single JSON file
+// (~/.kubeasy/state.json). Every change goes through Update, which holds an exclusive
+// file lock while the file is read, modified and atomically replaced, so concurrent
+// kubeasy processes never lose each other's writes. The file carries a schema version;
+// older files are migrated on load and files from a newer CLI are refused.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+)
+
+// CurrentVersion is the schema version written by this CLI.
+const CurrentVersion = 1
+
+// State is the content of the state file.
+type State struct {
+	Version    int                        `json:"version"`
+	Challenges map[string]*ChallengeState `json:"challenges,omitempty"`
+	// TelemetryConsent is the user's answer to 'kubeasy telemetry on|off'; nil until asked.
+	TelemetryConsent *bool `json:"telemetryConsent,omitempty"`
+}
+
+// ChallengeState is what the CLI remembers about one challenge.
+type ChallengeState struct {
+	// StartStep is the step 'challenge start' was running; empty once start completed.
+	StartStep       string     `json:"startStep,omitempty"`
+	Submissions     int        `json:"submissions,omitempty"`
+	LastSubmittedAt *time.Time `json:"lastSubmittedAt,omitempty"`
+}
+
+// Challenge returns the state of a challenge, creating it if needed.
+func (s *State) Challenge(slug string) *ChallengeState {
+	if s.Challenges == nil {
+		s.Challenges = map[string]*ChallengeState{}
+	}
+	c, ok := s.Challenges[slug]
+	if !ok {
+		c = &ChallengeState{}
+		s.Challenges[slug] = c
+	}
+	return c
+}
+
+// GetPath returns the path of the state file (~/.kubeasy/state.json).
+func GetPath() string {
+	return filepath.Join(constants.GetKubeasyConfigDir(), "state.json")
+}
+
+// Load reads the state file under a lock. It returns an empty state when none exists.
+func Load() (*State, error) {
+	var s *State
+	err := withLock(func() error {
+		var err error
+		s, err = read()
+		return err
+	})
+	return s, err
+}
+
+// Update loads the state, applies fn and writes the result, all under one lock.
+// Nothing is written when fn returns an error.
+func Update(fn func(*State) error) error {
+	return withLock(func() error {
+		s, err := read()
+		if err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+		return write(s)
+	})
+}
+
+// Clear removes the state file, if any.
+func Clear() error {
+	return withLock(func() error {
+		if err := os.Remove(GetPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove state file: %w", err)
+		}
+		return nil
+	})
+}
+
+// withLock runs fn while holding the exclusive lock on state.json.lock.
+func withLock(fn func() error) error {
+	path := GetPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open state lock: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("failed to lock state file: %w", err)
+	}
+	defer func() { _ = unlockFile(f) }()
+
+	return fn()
+}
+
+// read loads and migrates the state file. The caller must hold the lock.
+func read() (*State, error) {
+	data, err := os.ReadFile(GetPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{Version: CurrentVersion}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", GetPath(), err)
+	}
+	if err := migrate(raw); err != nil {
+		return nil, err
+	}
+
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode migrated state: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(migrated, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", GetPath(), err)
+	}
+	return &s, nil
+}
+
+// write atomically replaces the state file: the content goes to a temporary file in
+// the same directory, which is synced and then renamed over the old file, so a crash
+// never leaves a truncated state.json behind. The caller must hold the lock.
+func write(s *State) error {
+	s.Version = CurrentVersion
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	path := GetPath()
+	tmp, err := os.CreateTemp(filepath.Dir(path), "state-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary state file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return fmt.Errorf("failed to set state file permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+	return nil
+}
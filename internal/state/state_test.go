@@ -0,0 +1,135 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	s, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, s.Version)
+	assert.Empty(t, s.Challenges)
+}
+
+func TestUpdate_PersistsState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	err := Update(func(s *State) error {
+		c := s.Challenge("pod-evicted")
+		c.Submissions++
+		c.LastSubmittedAt = &now
+		return nil
+	})
+	require.NoError(t, err)
+
+	s, err := Load()
+	require.NoError(t, err)
+	require.Contains(t, s.Challenges, "pod-evicted")
+	assert.Equal(t, 1, s.Challenges["pod-evicted"].Submissions)
+	assert.True(t, now.Equal(*s.Challenges["pod-evicted"].LastSubmittedAt))
+
+	info, err := os.Stat(GetPath())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(GetPath()))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".tmp", "temporary files must not be left behind")
+	}
+}
+
+func TestUpdate_ErrorWritesNothing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	err := Update(func(s *State) error {
+		s.Challenge("pod-evicted").Submissions = 3
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.NoFileExists(t, GetPath())
+}
+
+func TestUpdate_ConcurrentWritersDoNotLoseUpdates(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	const writers = 20
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Go(func() {
+			assert.NoError(t, Update(func(s *State) error {
+				s.Challenge("pod-evicted").Submissions++
+				return nil
+			}))
+		})
+	}
+	wg.Wait()
+
+	s, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, writers, s.Challenges["pod-evicted"].Submissions)
+}
+
+func TestLoad_MigratesUnversionedFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	writeRaw(t, `{"challenges":{"pod-evicted":{"submissions":2}}}`)
+
+	s, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, s.Version)
+	assert.Equal(t, 2, s.Challenges["pod-evicted"].Submissions)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "newer version", content: `{"version":99}`, wantErr: "written by a newer kubeasy"},
+		{name: "invalid version", content: `{"version":"one"}`, wantErr: "invalid state file version"},
+		{name: "invalid JSON", content: `{`, wantErr: "failed to parse state file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			writeRaw(t, tt.content)
+
+			_, err := Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestMigrations_CoverEveryVersion(t *testing.T) {
+	assert.Len(t, migrations, CurrentVersion)
+}
+
+func TestClear(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	require.NoError(t, Update(func(s *State) error {
+		s.Challenge("pod-evicted").Submissions = 1
+		return nil
+	}))
+
+	require.NoError(t, Clear())
+	assert.NoFileExists(t, GetPath())
+	require.NoError(t, Clear(), "clearing twice is not an error")
+}
+
+func writeRaw(t *testing.T, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(GetPath()), 0o750))
+	require.NoError(t, os.WriteFile(GetPath(), []byte(content), 0o600))
+}
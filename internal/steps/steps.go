@@ -0,0 +1,162 @@
+// Package steps runs multi-phase commands (setup, start, reset) as a sequence of named
+// steps. Naming the phases lets a command report progress, say exactly where it
+// failed, skip work that is already done when it is run again, undo what it did on
+// failure, list what it would do (--dry-run), and be tested one step at a time.
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Scopes of a step, i.e. what kind of state it changes.
+const (
+	ScopeCluster = "cluster"
+	ScopeAPI     = "api"
+	ScopeLocal   = "local"
+)
+
+// Step is one phase of a command.
+type Step struct {
+	// Name identifies the step in progress events and errors, e.g. "deploy".
+	Name string
+	// Scope is what kind of state the step changes (ScopeCluster, ScopeAPI or ScopeLocal).
+	Scope string
+	// Actions describe exactly what the step touches, one line each. They are shown
+	// by --dry-run and confirmation prompts.
+	Actions []string
+
+	// Precondition, when set, must succeed before the step runs.
+	Precondition func(ctx context.Context) error
+	// Done, when set, reports whether the effect of the step is already in place, in
+	// which case the step is skipped. It makes re-running a command idempotent.
+	Done func(ctx context.Context) (bool, error)
+	// Run performs the step.
+	Run func(ctx context.Context) error
+	// Rollback, when set, undoes Run. It is only called for steps that completed.
+	Rollback func(ctx context.Context) error
+}
+
+// Status is the progress of a step.
+type Status string
+
+const (
+	StatusStarted        Status = "started"
+	StatusSkipped        Status = "skipped"
+	StatusCompleted      Status = "completed"
+	StatusFailed         Status = "failed"
+	StatusRolledBack     Status = "rolled-back"
+	StatusRollbackFailed Status = "rollback-failed"
+)
+
+// Event reports the progress of a step. Index is 1-based.
+type Event struct {
+	Step   string
+	Index  int
+	Total  int
+	Status Status
+	Err    error
+}
+
+// Error is returned by Run when a step fails.
+type Error struct {
+	// Step is the name of the failed step.
+	Step string
+	Err  error
+	// RollbackErr holds the errors of rollback hooks that failed, if any.
+	RollbackErr error
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("step %q failed: %v", e.Step, e.Err)
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf(" (rollback failed: %v)", e.RollbackErr)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Runner runs steps in order and stops at the first failure.
+type Runner struct {
+	// OnEvent, when set, receives progress events.
+	OnEvent func(Event)
+	// Rollback undoes the completed steps, newest first, when a step fails.
+	Rollback bool
+}
+
+// Run runs the steps. A failing step is reported as an *Error naming it.
+func (r Runner) Run(ctx context.Context, steps []Step) error {
+	var completed []int
+	for i, step := range steps {
+		r.emit(Event{Step: step.Name, Index: i + 1, Total: len(steps), Status: StatusStarted})
+
+		skip, err := r.prepare(ctx, step)
+		if err == nil && !skip {
+			err = step.Run(ctx)
+		}
+		if err != nil {
+			r.emit(Event{Step: step.Name, Index: i + 1, Total: len(steps), Status: StatusFailed, Err: err})
+			stepErr := &Error{Step: step.Name, Err: err}
+			if r.Rollback {
+				stepErr.RollbackErr = r.rollback(ctx, steps, completed)
+			}
+			return stepErr
+		}
+
+		if skip {
+			r.emit(Event{Step: step.Name, Index: i + 1, Total: len(steps), Status: StatusSkipped})
+			continue
+		}
+		completed = append(completed, i)
+		r.emit(Event{Step: step.Name, Index: i + 1, Total: len(steps), Status: StatusCompleted})
+	}
+	return nil
+}
+
+// prepare checks the precondition of a step and whether it is already done.
+func (r Runner) prepare(ctx context.Context, step Step) (skip bool, err error) {
+	if step.Precondition != nil {
+		if err := step.Precondition(ctx); err != nil {
+			return false, fmt.Errorf("precondition not met: %w", err)
+		}
+	}
+	if step.Done == nil {
+		return false, nil
+	}
+	done, err := step.Done(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether the step is done: %w", err)
+	}
+	return done, nil
+}
+
+// rollback undoes the completed steps, newest first, and joins their errors. It runs
+// even when ctx was canceled (e.g. by Ctrl-C), since that is often why a step failed.
+func (r Runner) rollback(ctx context.Context, steps []Step, completed []int) error {
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for j := len(completed) - 1; j >= 0; j-- {
+		i := completed[j]
+		step := steps[i]
+		if step.Rollback == nil {
+			continue
+		}
+		if err := step.Rollback(ctx); err != nil {
+			r.emit(Event{Step: step.Name, Index: i + 1, Total: len(steps), Status: StatusRollbackFailed, Err: err})
+			errs = append(errs, fmt.Errorf("%s: %w", step.Name, err))
+			continue
+		}
+		r.emit(Event{Step: step.Name, Index: i + 1, Total: len(steps), Status: StatusRolledBack})
+	}
+	return errors.Join(errs...)
+}
+
+func (r Runner) emit(e Event) {
+	if r.OnEvent != nil {
+		r.OnEvent(e)
+	}
+}
@@ -0,0 +1,160 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder builds steps that log their calls.
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) step(name string, runErr error) Step {
+	return Step{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			r.calls = append(r.calls, "run "+name)
+			return runErr
+		},
+		Rollback: func(ctx context.Context) error {
+			r.calls = append(r.calls, "rollback "+name)
+			return nil
+		},
+	}
+}
+
+func TestRun_RunsStepsInOrder(t *testing.T) {
+	rec := &recorder{}
+	var events []string
+	runner := Runner{OnEvent: func(e Event) {
+		events = append(events, e.Step+":"+string(e.Status))
+	}}
+
+	err := runner.Run(context.Background(), []Step{rec.step("first", nil), rec.step("second", nil)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run first", "run second"}, rec.calls)
+	assert.Equal(t, []string{"first:started", "first:completed", "second:started", "second:completed"}, events)
+}
+
+func TestRun_StopsAtFirstFailure(t *testing.T) {
+	rec := &recorder{}
+	boom := errors.New("boom")
+
+	err := Runner{}.Run(context.Background(), []Step{
+		rec.step("first", nil),
+		rec.step("second", boom),
+		rec.step("third", nil),
+	})
+
+	var stepErr *Error
+	require.ErrorAs(t, err, &stepErr)
+	assert.Equal(t, "second", stepErr.Step)
+	assert.ErrorIs(t, err, boom)
+	assert.EqualError(t, err, `step "second" failed: boom`)
+	assert.Equal(t, []string{"run first", "run second"}, rec.calls, "no rollback unless requested")
+}
+
+func TestRun_RollsBackCompletedStepsNewestFirst(t *testing.T) {
+	rec := &recorder{}
+	skipped := rec.step("skipped", nil)
+	skipped.Done = func(ctx context.Context) (bool, error) { return true, nil }
+	var events []string
+
+	err := Runner{Rollback: true, OnEvent: func(e Event) {
+		if e.Status == StatusRolledBack {
+			events = append(events, e.Step)
+		}
+	}}.Run(context.Background(), []Step{
+		rec.step("first", nil),
+		skipped,
+		rec.step("second", nil),
+		rec.step("third", errors.New("boom")),
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"run first", "run second", "run third", "rollback second", "rollback first"}, rec.calls,
+		"skipped and failed steps are not rolled back")
+	assert.Equal(t, []string{"second", "first"}, events)
+}
+
+func TestRun_ReportsRollbackFailures(t *testing.T) {
+	first := Step{
+		Name:     "first",
+		Run:      func(ctx context.Context) error { return nil },
+		Rollback: func(ctx context.Context) error { return errors.New("stuck") },
+	}
+	second := Step{Name: "second", Run: func(ctx context.Context) error { return errors.New("boom") }}
+
+	err := Runner{Rollback: true}.Run(context.Background(), []Step{first, second})
+
+	var stepErr *Error
+	require.ErrorAs(t, err, &stepErr)
+	require.Error(t, stepErr.RollbackErr)
+	assert.Contains(t, err.Error(), "rollback failed: first: stuck")
+}
+
+func TestRun_SkipsDoneSteps(t *testing.T) {
+	rec := &recorder{}
+	done := rec.step("done", nil)
+	done.Done = func(ctx context.Context) (bool, error) { return true, nil }
+	var statuses []Status
+
+	err := Runner{OnEvent: func(e Event) { statuses = append(statuses, e.Status) }}.Run(context.Background(), []Step{done})
+	require.NoError(t, err)
+	assert.Empty(t, rec.calls)
+	assert.Equal(t, []Status{StatusStarted, StatusSkipped}, statuses)
+}
+
+func TestRun_PreconditionAndDoneErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    Step
+		wantErr string
+	}{
+		{
+			name: "precondition fails",
+			step: Step{
+				Name:         "deploy",
+				Precondition: func(ctx context.Context) error { return errors.New("not logged in") },
+				Run:          func(ctx context.Context) error { t.Fatal("must not run"); return nil },
+			},
+			wantErr: `step "deploy" failed: precondition not met: not logged in`,
+		},
+		{
+			name: "done check fails",
+			step: Step{
+				Name: "deploy",
+				Done: func(ctx context.Context) (bool, error) { return false, errors.New("cluster unreachable") },
+				Run:  func(ctx context.Context) error { t.Fatal("must not run"); return nil },
+			},
+			wantErr: `step "deploy" failed: failed to check whether the step is done: cluster unreachable`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Runner{}.Run(context.Background(), []Step{tt.step})
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRun_RollsBackAfterCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var rollbackErr error
+	first := Step{
+		Name:     "first",
+		Run:      func(ctx context.Context) error { return nil },
+		Rollback: func(ctx context.Context) error { rollbackErr = ctx.Err(); return nil },
+	}
+	second := Step{Name: "second", Run: func(ctx context.Context) error { cancel(); return ctx.Err() }}
+
+	err := Runner{Rollback: true}.Run(ctx, []Step{first, second})
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, rollbackErr, "rollback must not inherit the cancellation")
+}
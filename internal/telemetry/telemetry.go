@@ -0,0 +1,94 @@
+// Package telemetry decides whether anonymized environment data may be sent to the
+// API, and collects it. Nothing is sent unless the user opted in with
+// 'kubeasy telemetry on'.
+package telemetry
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Enabled reports whether the user consented to telemetry. DO_NOT_TRACK
+// (https://consoledonottrack.com) and KUBEASY_TELEMETRY=off override the saved consent.
+func Enabled() bool {
+	if DisabledByEnv() {
+		return false
+	}
+	s, err := state.Load()
+	if err != nil {
+		logger.Debug("Could not read telemetry consent: %v", err)
+		return false
+	}
+	return s.TelemetryConsent != nil && *s.TelemetryConsent
+}
+
+// DisabledByEnv reports whether the environment forbids telemetry.
+func DisabledByEnv() bool {
+	if v := os.Getenv("DO_NOT_TRACK"); v != "" && v != "0" {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("KUBEASY_TELEMETRY")) {
+	case "0", "off", "false", "no":
+		return true
+	}
+	return false
+}
+
+// SetConsent saves the user's telemetry choice.
+func SetConsent(enabled bool) error {
+	return state.Update(func(s *state.State) error {
+		s.TelemetryConsent = &enabled
+		return nil
+	})
+}
+
+// Environment returns the anonymized fingerprint of the CLI host and cluster. Cluster
+// details are best effort and left empty when they cannot be read.
+func Environment(ctx context.Context, clientset kubernetes.Interface) api.SubmitEnvironment {
+	env := api.SubmitEnvironment{
+		CliVersion: constants.Version,
+		Os:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+	if clientset == nil {
+		return env
+	}
+
+	if version, err := clientset.Discovery().ServerVersion(); err != nil {
+		logger.Debug("Could not read Kubernetes version: %v", err)
+	} else {
+		env.KubernetesVersion = &version.GitVersion
+	}
+	if provider := detectProvider(ctx, clientset); provider != "" {
+		env.Provider = &provider
+	}
+	return env
+}
+
+// detectProvider names the cluster provider from the scheme of a node's provider ID
+// (e.g. "kind://docker/kubeasy/kubeasy-control-plane" -> "kind"). Only the scheme is
+// kept, never the node name.
+func detectProvider(ctx context.Context, clientset kubernetes.Interface) string {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		logger.Debug("Could not list nodes: %v", err)
+		return ""
+	}
+	if len(nodes.Items) == 0 {
+		return ""
+	}
+	scheme, _, found := strings.Cut(nodes.Items[0].Spec.ProviderID, "://")
+	if !found || scheme == "" {
+		return "unknown"
+	}
+	return scheme
+}
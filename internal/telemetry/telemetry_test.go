@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		consent *bool
+		env     map[string]string
+		want    bool
+	}{
+		{name: "not asked yet", want: false},
+		{name: "opted in", consent: boolPtr(true), want: true},
+		{name: "opted out", consent: boolPtr(false), want: false},
+		{name: "DO_NOT_TRACK overrides consent", consent: boolPtr(true), env: map[string]string{"DO_NOT_TRACK": "1"}, want: false},
+		{name: "DO_NOT_TRACK=0 is ignored", consent: boolPtr(true), env: map[string]string{"DO_NOT_TRACK": "0"}, want: true},
+		{name: "KUBEASY_TELEMETRY=off overrides consent", consent: boolPtr(true), env: map[string]string{"KUBEASY_TELEMETRY": "off"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("DO_NOT_TRACK", "")
+			t.Setenv("KUBEASY_TELEMETRY", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if tt.consent != nil {
+				require.NoError(t, SetConsent(*tt.consent))
+			}
+
+			assert.Equal(t, tt.want, Enabled())
+		})
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubeasy-control-plane"},
+		Spec:       corev1.NodeSpec{ProviderID: "kind://docker/kubeasy/kubeasy-control-plane"},
+	})
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.35.0"}
+
+	env := Environment(context.Background(), clientset)
+	assert.Equal(t, constants.Version, env.CliVersion)
+	assert.Equal(t, runtime.GOOS, env.Os)
+	assert.Equal(t, runtime.GOARCH, env.Arch)
+	require.NotNil(t, env.KubernetesVersion)
+	assert.Equal(t, "v1.35.0", *env.KubernetesVersion)
+	require.NotNil(t, env.Provider)
+	assert.Equal(t, "kind", *env.Provider)
+
+	env = Environment(context.Background(), nil)
+	assert.Nil(t, env.KubernetesVersion, "unknown versions are omitted")
+	assert.Nil(t, env.Provider)
+}
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		providerID string
+		noNodes    bool
+		want       string
+	}{
+		{name: "kind", providerID: "kind://docker/kubeasy/kubeasy-control-plane", want: "kind"},
+		{name: "k3d", providerID: "k3s://k3d-kubeasy-server-0", want: "k3s"},
+		{name: "no provider ID", want: "unknown"},
+		{name: "no nodes", noNodes: true, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewClientset()
+			if !tt.noNodes {
+				clientset = fake.NewClientset(&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node"},
+					Spec:       corev1.NodeSpec{ProviderID: tt.providerID},
+				})
+			}
+			assert.Equal(t, tt.want, detectProvider(context.Background(), clientset))
+		})
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
@@ -0,0 +1,30 @@
+package ui
+
+import kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+
+// ErrConfirmationRequired is returned by Confirm when a prompt is needed but nobody can answer it.
+var ErrConfirmationRequired = kerrors.New(kerrors.CodeConfirmationRequired, "confirmation required: re-run with --yes to proceed without a prompt")
+
+// interactive reports whether prompts can be answered, i.e. stdin and stdout are terminals.
+var interactive = true
+
+// confirmPrompt asks the question; tests replace it to answer without a terminal.
+var confirmPrompt = Confirmation
+
+// SetInteractive enables or disables interactive prompts.
+func SetInteractive(v bool) {
+	interactive = v
+}
+
+// Confirm asks the user to confirm an action. assumeYes (the --yes flag) skips the
+// prompt. In non-interactive mode the prompt cannot be answered, so Confirm fails
+// with ErrConfirmationRequired instead of hanging or proceeding silently.
+func Confirm(question string, assumeYes bool) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if !interactive {
+		return false, ErrConfirmationRequired
+	}
+	return confirmPrompt(question), nil
+}
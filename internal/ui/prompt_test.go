@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	origInteractive, origPrompt := interactive, confirmPrompt
+	t.Cleanup(func() {
+		interactive = origInteractive
+		confirmPrompt = origPrompt
+	})
+
+	prompted := false
+	answer := false
+	confirmPrompt = func(string) bool {
+		prompted = true
+		return answer
+	}
+
+	tests := []struct {
+		name        string
+		interactive bool
+		assumeYes   bool
+		answer      bool
+		want        bool
+		wantPrompt  bool
+		wantErr     error
+	}{
+		{name: "yes flag skips the prompt", interactive: true, assumeYes: true, want: true},
+		{name: "yes flag works non-interactively", interactive: false, assumeYes: true, want: true},
+		{name: "user confirms", interactive: true, answer: true, want: true, wantPrompt: true},
+		{name: "user declines", interactive: true, answer: false, want: false, wantPrompt: true},
+		{name: "non-interactive without yes", interactive: false, wantErr: ErrConfirmationRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompted = false
+			answer = tt.answer
+			SetInteractive(tt.interactive)
+
+			got, err := Confirm("Proceed?", tt.assumeYes)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPrompt, prompted)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/pterm/pterm"
+)
+
+// Severity classifies a piece of output so it is colored the same way in every command.
+type Severity int
+
+const (
+	SeverityNone Severity = iota
+	SeverityInfo
+	SeveritySuccess
+	SeverityWarning
+	SeverityError
+	SeverityMuted
+)
+
+// colorEnabled mirrors the pterm color setting chosen by ConfigureColor.
+var colorEnabled = true
+
+// ConfigureColor enables or disables colored output for the whole process.
+// Colors are disabled by --no-color, by a non-empty NO_COLOR environment variable
+// (https://no-color.org), by TERM=dumb, and when stdout is not a terminal.
+func ConfigureColor(noColorFlag, isTerminal bool) {
+	SetColorEnabled(shouldUseColor(noColorFlag, isTerminal, os.Getenv))
+}
+
+// SetColorEnabled forces colored output on or off.
+func SetColorEnabled(v bool) {
+	colorEnabled = v
+	if v {
+		pterm.EnableColor()
+	} else {
+		pterm.DisableColor()
+	}
+}
+
+// ColorEnabled reports whether output is currently colored.
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
+func shouldUseColor(noColorFlag, isTerminal bool, getenv func(string) string) bool {
+	switch {
+	case noColorFlag, !isTerminal:
+		return false
+	case getenv("NO_COLOR") != "":
+		return false
+	case getenv("TERM") == "dumb":
+		return false
+	default:
+		return true
+	}
+}
+
+// Colorize returns text styled for sev, or text unchanged when colors are disabled.
+func Colorize(sev Severity, text string) string {
+	if !colorEnabled {
+		return text
+	}
+	switch sev {
+	case SeverityInfo:
+		return pterm.LightCyan(text)
+	case SeveritySuccess:
+		return pterm.Green(text)
+	case SeverityWarning:
+		return pterm.Yellow(text)
+	case SeverityError:
+		return pterm.Red(text)
+	case SeverityMuted:
+		return pterm.Gray(text)
+	default:
+		return text
+	}
+}
+
+// PassFail returns a colored check mark or cross for a validation outcome.
+func PassFail(passed bool) string {
+	if passed {
+		return Colorize(SeveritySuccess, "✓")
+	}
+	return Colorize(SeverityError, "✗")
+}
+
+// StatusLabel colors a status word such as "passed", "failed" or "skipped" by its meaning.
+func StatusLabel(status string) string {
+	switch status {
+	case "passed", "completed", "success":
+		return Colorize(SeveritySuccess, status)
+	case "failed", "error", "expired":
+		return Colorize(SeverityError, status)
+	case "skipped", "in_progress", "pending":
+		return Colorize(SeverityWarning, status)
+	default:
+		return status
+	}
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldUseColor(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+
+	tests := []struct {
+		name       string
+		noColor    bool
+		isTerminal bool
+		env        map[string]string
+		want       bool
+	}{
+		{"terminal", false, true, nil, true},
+		{"--no-color", true, true, nil, false},
+		{"not a terminal", false, false, nil, false},
+		{"NO_COLOR set", false, true, map[string]string{"NO_COLOR": "1"}, false},
+		{"NO_COLOR empty", false, true, map[string]string{"NO_COLOR": ""}, true},
+		{"dumb terminal", false, true, map[string]string{"TERM": "dumb"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, shouldUseColor(tt.noColor, tt.isTerminal, env(tt.env)))
+		})
+	}
+}
+
+func TestColorize(t *testing.T) {
+	t.Cleanup(func() { SetColorEnabled(true) })
+
+	SetColorEnabled(false)
+	assert.False(t, ColorEnabled())
+	assert.Equal(t, "failed", Colorize(SeverityError, "failed"))
+	assert.Equal(t, "✓", PassFail(true))
+	assert.Equal(t, "skipped", StatusLabel("skipped"))
+
+	SetColorEnabled(true)
+	assert.NotEqual(t, "failed", Colorize(SeverityError, "failed"))
+	assert.Contains(t, Colorize(SeverityError, "failed"), "failed")
+	assert.Equal(t, "plain", Colorize(SeverityNone, "plain"))
+	assert.Equal(t, "unknown", StatusLabel("unknown"))
+}
+
+func TestRenderSteps(t *testing.T) {
+	SetColorEnabled(false)
+	defer SetColorEnabled(true)
+
+	got := renderSteps([]Step{
+		{Name: "pods ready", Status: "success"},
+		{Name: "logs", Status: "running"},
+		{Name: "rbac", Status: "error"},
+		{Name: "events", Status: "pending"},
+		{Name: "cpu usage", Status: "skipped"},
+	})
+	assert.Equal(t, "1. ✓ pods ready\n2. ⟳ logs\n3. ✗ rbac\n4. ○ events\n5. ○ cpu usage\n", got)
+}
@@ -2,7 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pterm/pterm"
@@ -17,6 +20,15 @@ func SetCIMode(v bool) {
 	ciMode = v
 }
 
+// SetOutput sends the messages, tables and panels printed by this package to w instead
+// of stdout; nil restores stdout. Tests use it to check what a command prints.
+func SetOutput(w io.Writer) {
+	if w == nil {
+		w = os.Stdout
+	}
+	pterm.SetDefaultOutput(w)
+}
+
 // Spinner creates and starts a spinner with the given text.
 // Note: does not respect ciMode — use WaitMessage or TimedSpinner for CI-safe output.
 func Spinner(text string) (*pterm.SpinnerPrinter, error) {
@@ -52,6 +64,11 @@ func Println() {
 	pterm.Println()
 }
 
+// Text prints a plain paragraph
+func Text(text string) {
+	pterm.Println(text)
+}
+
 // Header displays a section header
 func Header(text string) {
 	pterm.DefaultHeader.WithFullWidth().WithBackgroundStyle(pterm.NewStyle(pterm.BgLightBlue)).WithMargin(10).Println(text)
@@ -108,19 +125,80 @@ type Step struct {
 }
 
 func StepList(steps []Step) {
+	pterm.Print(renderSteps(steps))
+}
+
+func renderSteps(steps []Step) string {
+	var b strings.Builder
 	for i, step := range steps {
 		prefix := fmt.Sprintf("%d.", i+1)
+		prefix = Colorize(SeverityInfo, prefix)
 		switch step.Status {
 		case "running":
-			pterm.Printf("%s %s %s\n", pterm.LightBlue(prefix), pterm.Cyan("⟳"), step.Name)
+			fmt.Fprintf(&b, "%s %s %s\n", prefix, Colorize(SeverityInfo, "⟳"), step.Name)
 		case "success":
-			pterm.Printf("%s %s %s\n", pterm.LightBlue(prefix), pterm.Green("✓"), pterm.Gray(step.Name))
+			fmt.Fprintf(&b, "%s %s %s\n", prefix, PassFail(true), Colorize(SeverityMuted, step.Name))
 		case "error":
-			pterm.Printf("%s %s %s\n", pterm.LightBlue(prefix), pterm.Red("✗"), step.Name)
+			fmt.Fprintf(&b, "%s %s %s\n", prefix, PassFail(false), step.Name)
+		case "skipped":
+			fmt.Fprintf(&b, "%s %s %s\n", prefix, Colorize(SeverityWarning, "○"), step.Name)
 		default: // pending
-			pterm.Printf("%s %s %s\n", pterm.LightBlue(prefix), pterm.Gray("○"), pterm.Gray(step.Name))
+			fmt.Fprintf(&b, "%s %s %s\n", prefix, Colorize(SeverityMuted, "○"), Colorize(SeverityMuted, step.Name))
 		}
 	}
+	return b.String()
+}
+
+// Checklist is a StepList that is redrawn in place as the status of its steps changes,
+// for showing the progress of tasks that run concurrently. It is removed from the
+// screen by Stop. In CI mode nothing is redrawn: each finished step is printed as one line.
+type Checklist struct {
+	mu    sync.Mutex
+	steps []Step
+	area  *pterm.AreaPrinter
+}
+
+// NewChecklist starts a checklist of pending steps with the given names.
+func NewChecklist(names []string) *Checklist {
+	c := &Checklist{steps: make([]Step, len(names))}
+	for i, name := range names {
+		c.steps[i] = Step{Name: name, Status: "pending"}
+	}
+	if !ciMode {
+		if area, err := pterm.DefaultArea.WithRemoveWhenDone().Start(renderSteps(c.steps)); err == nil {
+			c.area = area
+		}
+	}
+	return c
+}
+
+// Set changes the status of step i ("running", "success", "error" or "skipped") and
+// redraws the list.
+func (c *Checklist) Set(i int, status string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i < 0 || i >= len(c.steps) {
+		return
+	}
+	c.steps[i].Status = status
+	switch {
+	case c.area != nil:
+		c.area.Update(renderSteps(c.steps))
+	case status == "success" || status == "error":
+		fmt.Printf("%s %s\n", PassFail(status == "success"), c.steps[i].Name)
+	case status == "skipped":
+		fmt.Printf("%s %s\n", Colorize(SeverityWarning, "○"), c.steps[i].Name)
+	}
+}
+
+// Stop removes the checklist from the screen.
+func (c *Checklist) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.area != nil {
+		_ = c.area.Stop()
+		c.area = nil
+	}
 }
 
 // Confirmation asks user for yes/no confirmation
@@ -146,7 +224,7 @@ func Select(label string, options []string) (string, error) {
 
 // KeyValue displays a key-value pair in a styled format
 func KeyValue(key, value string) {
-	pterm.Printf("%s %s\n", pterm.LightCyan(key+":"), value)
+	pterm.Printf("%s %s\n", Colorize(SeverityInfo, key+":"), value)
 }
 
 // MultiSpinner manages multiple spinners for parallel tasks.
@@ -287,13 +365,22 @@ func ValidationResult(name string, passed bool, details []string) {
 		pterm.Error.Printf("%s: Some checks failed\n", name)
 	}
 
-	if len(details) > 0 {
-		for _, detail := range details {
-			if passed {
-				pterm.Printf("  %s %s\n", pterm.Green("✓"), detail)
-			} else {
-				pterm.Printf("  %s %s\n", pterm.Red("✗"), detail)
-			}
-		}
+	for _, detail := range details {
+		pterm.Printf("  %s %s\n", PassFail(passed), detail)
+	}
+}
+
+// ValidationSkipped displays a validation that could not run, with the reason.
+func ValidationSkipped(name, reason string) {
+	pterm.Warning.Printf("%s: Skipped\n", name)
+	pterm.Printf("  %s %s\n", Colorize(SeverityWarning, "○"), reason)
+}
+
+// PhaseSummary displays the outcome of a phase of objectives, e.g. "Fix: 1/2 objectives passed".
+func PhaseSummary(name string, passed, total int) {
+	if passed == total {
+		pterm.Success.Printf("%s: %d/%d objectives passed\n", name, passed, total)
+	} else {
+		pterm.Error.Printf("%s: %d/%d objectives passed\n", name, passed, total)
 	}
 }
@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+)
+
+// challengeCacheDir returns the directory caching the challenge.yaml files fetched
+// from the API (~/.kubeasy/cache/challenges).
+func challengeCacheDir() string {
+	return filepath.Join(constants.GetKubeasyConfigDir(), "cache", "challenges")
+}
+
+// challengeFile is a challenge.yaml served by the API, with its ETag and detached
+// signature (see ChallengeSignatureHeader) when the API sent them.
+type challengeFile struct {
+	data      []byte
+	etag      string
+	signature string
+}
+
+// fetchChallengeYaml returns the challenge.yaml of slug from the API, once its
+// signature is verified against the trusted keys (see verifyChallengeSignature).
+func fetchChallengeYaml(ctx context.Context, slug string) ([]byte, error) {
+	file, err := fetchChallengeFile(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChallengeSignature(file.data, file.signature); err != nil {
+		return nil, fmt.Errorf("challenge %q: %w", slug, err)
+	}
+	return file.data, nil
+}
+
+// fetchChallengeFile downloads the challenge.yaml of slug. Each response is cached
+// with its ETag and revalidated with If-None-Match, so an unchanged file is not
+// downloaded again; when the API cannot be reached, the cached copy is used so
+// recently started challenges can still be validated offline.
+func fetchChallengeFile(ctx context.Context, slug string) (*challengeFile, error) {
+	slug = filepath.Base(slug) // prevent path traversal
+	cached := readCachedChallenge(slug)
+
+	client, err := api.NewPublicClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.GetChallengeYamlWithResponse(ctx, slug, func(_ context.Context, req *http.Request) error {
+		if cached != nil && cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		return nil
+	})
+	if err != nil {
+		if cached != nil && ctx.Err() == nil {
+			logger.Warning("Could not reach the API (%v), using the cached challenge.yaml of %s", err, slug)
+			return cached, nil
+		}
+		return nil, fmt.Errorf("failed to load challenge %q from API: %w", slug, err)
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusNotModified:
+		if cached == nil {
+			return nil, fmt.Errorf("API returned HTTP %d for challenge %q without a cached copy", resp.StatusCode(), slug)
+		}
+		logger.Debug("Cached challenge.yaml of %s is up to date", slug)
+		return cached, nil
+	case http.StatusOK:
+		file := &challengeFile{
+			data:      resp.Body,
+			etag:      resp.HTTPResponse.Header.Get("ETag"),
+			signature: resp.HTTPResponse.Header.Get(ChallengeSignatureHeader),
+		}
+		writeCachedChallenge(slug, file)
+		return file, nil
+	default:
+		return nil, fmt.Errorf("API returned HTTP %d for challenge %q", resp.StatusCode(), slug)
+	}
+}
+
+// readCachedChallenge returns the cached challenge.yaml of slug, or nil.
+func readCachedChallenge(slug string) *challengeFile {
+	dir := challengeCacheDir()
+	data, err := os.ReadFile(filepath.Join(dir, slug+".yaml"))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Debug("Could not read cached challenge.yaml of %s: %v", slug, err)
+		}
+		return nil
+	}
+	return &challengeFile{
+		data:      data,
+		etag:      readCacheEntry(filepath.Join(dir, slug+".etag")),
+		signature: readCacheEntry(filepath.Join(dir, slug+".sig")),
+	}
+}
+
+func readCacheEntry(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Debug("Could not read %s: %v", path, err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// writeCachedChallenge caches file as the challenge.yaml of slug. The cache is an
+// optimization, so failures are only logged.
+func writeCachedChallenge(slug string, file *challengeFile) {
+	dir := challengeCacheDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		logger.Debug("Could not create challenge cache: %v", err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(dir, slug+".yaml"), file.data, 0o600); err != nil {
+		logger.Debug("Could not cache challenge.yaml of %s: %v", slug, err)
+		return
+	}
+	writeCacheEntry(filepath.Join(dir, slug+".etag"), file.etag)
+	writeCacheEntry(filepath.Join(dir, slug+".sig"), file.signature)
+}
+
+// writeCacheEntry stores value at path, or removes path when value is empty.
+func writeCacheEntry(path, value string) {
+	if value == "" {
+		_ = os.Remove(path)
+		return
+	}
+	if err := os.WriteFile(path, []byte(value), 0o600); err != nil {
+		logger.Debug("Could not write %s: %v", path, err)
+	}
+}
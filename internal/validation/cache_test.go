@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchChallengeYaml_Cache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	body := "objectives: []\n"
+	var requests, revalidations int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/challenges/pod-evicted/yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(body))
+	}))
+	orig := constants.WebsiteURL
+	t.Cleanup(func() { constants.WebsiteURL = orig })
+	constants.WebsiteURL = srv.URL
+
+	data, err := fetchChallengeYaml(context.Background(), "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, body, string(data))
+
+	// An unchanged file is revalidated, not downloaded again
+	data, err = fetchChallengeYaml(context.Background(), "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, body, string(data))
+	assert.Equal(t, 1, revalidations)
+
+	// API errors are reported, even with a cached copy
+	_, err = fetchChallengeYaml(context.Background(), "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API returned HTTP 404")
+
+	// Offline, the cached copy is used
+	srv.Close()
+	data, err = fetchChallengeYaml(context.Background(), "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, body, string(data))
+	assert.Equal(t, 3, requests)
+
+	_, err = fetchChallengeYaml(context.Background(), "never-fetched")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load challenge")
+}
+
+func TestFetchChallengeYaml_Signature(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	body := []byte("objectives: []\n")
+	signer := newTestSigner(t, 1)
+	signature := signer.sign("Ed", body, "timestamp:1760000000")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/challenges/signed/yaml" {
+			w.Header().Set(ChallengeSignatureHeader, signature)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(body)
+	}))
+	orig := constants.WebsiteURL
+	t.Cleanup(func() { constants.WebsiteURL = orig })
+	constants.WebsiteURL = srv.URL
+	t.Setenv(ChallengePublicKeysEnv, signer.public)
+
+	data, err := fetchChallengeYaml(context.Background(), "signed")
+	require.NoError(t, err)
+	assert.Equal(t, body, data)
+
+	_, err = fetchChallengeYaml(context.Background(), "unsigned")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "challenge.yaml is not signed")
+
+	// The signature is cached with the file and checked offline too
+	srv.Close()
+	data, err = fetchChallengeYaml(context.Background(), "signed")
+	require.NoError(t, err)
+	assert.Equal(t, body, data)
+}
@@ -0,0 +1,112 @@
+// Package engine is the registry of validation types. Each executor sub-package
+// registers a Validator for its type at init, and the validation Executor dispatches
+// to the registered Validator instead of switching over the known types.
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+)
+
+// Env is the runtime environment a Validator runs in.
+type Env struct {
+	shared.Deps
+	// Execute runs a nested validation through the registry, for composite
+	// types (e.g. triggered) whose spec embeds other validations.
+	Execute func(ctx context.Context, v vtypes.Validation) vtypes.Result
+}
+
+// Validator checks one validation type. spec is the typed spec of the validation
+// (e.g. vtypes.StatusSpec). Only Passed, Message, Skipped and Comparisons of the returned Result are used:
+// the Executor fills in the key and duration.
+type Validator interface {
+	Validate(ctx context.Context, env Env, spec interface{}) vtypes.Result
+}
+
+// ValidatorFunc adapts a function to the Validator interface.
+type ValidatorFunc func(ctx context.Context, env Env, spec interface{}) vtypes.Result
+
+// Validate calls f(ctx, env, spec).
+func (f ValidatorFunc) Validate(ctx context.Context, env Env, spec interface{}) vtypes.Result {
+	return f(ctx, env, spec)
+}
+
+// Typed adapts an executor taking a concrete spec type S to a Validator.
+// A spec of another type yields a failed result instead of a panic, a
+// *shared.SkipError a skipped one and a *shared.CompareError a failed one with
+// its comparisons.
+func Typed[S any](fn func(ctx context.Context, spec S, deps shared.Deps) (bool, string, error)) Validator {
+	return TypedWithEnv(func(ctx context.Context, spec S, env Env) (bool, string, error) {
+		return fn(ctx, spec, env.Deps)
+	})
+}
+
+// TypedWithEnv is like Typed for executors that need the whole Env, such as
+// composite types running nested validations through Env.Execute.
+func TypedWithEnv[S any](fn func(ctx context.Context, spec S, env Env) (bool, string, error)) Validator {
+	return ValidatorFunc(func(ctx context.Context, env Env, spec interface{}) vtypes.Result {
+		s, ok := spec.(S)
+		if !ok {
+			var zero S
+			return vtypes.Result{Message: fmt.Sprintf("internal error: expected %T, got %T", zero, spec)}
+		}
+		passed, msg, err := fn(ctx, s, env)
+		var skip *shared.SkipError
+		if errors.As(err, &skip) {
+			return vtypes.Result{Skipped: true, Message: skip.Reason}
+		}
+		var compare *shared.CompareError
+		if errors.As(err, &compare) {
+			return vtypes.Result{Message: compare.Message, Comparisons: compare.Comparisons}
+		}
+		if err != nil {
+			return vtypes.Result{Message: err.Error()}
+		}
+		return vtypes.Result{Passed: passed, Message: msg}
+	})
+}
+
+var (
+	mu         sync.RWMutex
+	validators = map[vtypes.ValidationType]Validator{}
+)
+
+// Register makes a Validator available for a validation type.
+// It panics if the type is registered twice or the validator is nil.
+func Register(t vtypes.ValidationType, v Validator) {
+	mu.Lock()
+	defer mu.Unlock()
+	if v == nil {
+		panic(fmt.Sprintf("engine: Register validator for %q is nil", t))
+	}
+	if _, dup := validators[t]; dup {
+		panic(fmt.Sprintf("engine: Register called twice for type %q", t))
+	}
+	validators[t] = v
+}
+
+// Lookup returns the Validator registered for a validation type.
+func Lookup(t vtypes.ValidationType) (Validator, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	v, ok := validators[t]
+	return v, ok
+}
+
+// Types returns the registered validation types, sorted.
+func Types() []vtypes.ValidationType {
+	mu.RLock()
+	defer mu.RUnlock()
+	types := make([]vtypes.ValidationType, 0, len(validators))
+	for t := range validators {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
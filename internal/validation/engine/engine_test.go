@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSpec struct{ Want bool }
+
+func fakeExecute(_ context.Context, spec fakeSpec, deps shared.Deps) (bool, string, error) {
+	if deps.Namespace == "broken" {
+		return false, "", errors.New("cluster unreachable")
+	}
+	if spec.Want {
+		return true, "ok in " + deps.Namespace, nil
+	}
+	return false, "not ok", nil
+}
+
+func TestRegisterAndLookup(t *testing.T) {
+	const typ vtypes.ValidationType = "engine-test-register"
+	Register(typ, Typed(fakeExecute))
+
+	v, ok := Lookup(typ)
+	require.True(t, ok)
+	assert.Contains(t, Types(), typ)
+
+	_, ok = Lookup("engine-test-missing")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { Register(typ, Typed(fakeExecute)) }, "duplicate registration must panic")
+	assert.Panics(t, func() { Register("engine-test-nil", nil) })
+
+	res := v.Validate(context.Background(), Env{Deps: shared.Deps{Namespace: "demo"}}, fakeSpec{Want: true})
+	assert.True(t, res.Passed)
+	assert.Equal(t, "ok in demo", res.Message)
+}
+
+func TestTyped(t *testing.T) {
+	v := Typed(fakeExecute)
+	env := Env{Deps: shared.Deps{Namespace: "demo"}}
+
+	tests := []struct {
+		name       string
+		env        Env
+		spec       interface{}
+		wantPassed bool
+		wantMsg    string
+	}{
+		{"passes", env, fakeSpec{Want: true}, true, "ok in demo"},
+		{"fails", env, fakeSpec{}, false, "not ok"},
+		{"error becomes message", Env{Deps: shared.Deps{Namespace: "broken"}}, fakeSpec{}, false, "cluster unreachable"},
+		{"wrong spec type", env, "not a spec", false, "internal error: expected engine.fakeSpec, got string"},
+		{"nil spec", env, nil, false, "internal error: expected engine.fakeSpec, got <nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := v.Validate(context.Background(), tt.env, tt.spec)
+			assert.Equal(t, tt.wantPassed, res.Passed)
+			assert.Equal(t, tt.wantMsg, res.Message)
+		})
+	}
+}
+
+func TestTypedWithEnv_NestedExecute(t *testing.T) {
+	v := TypedWithEnv(func(ctx context.Context, spec fakeSpec, env Env) (bool, string, error) {
+		res := env.Execute(ctx, vtypes.Validation{Key: "nested"})
+		return res.Passed, res.Message, nil
+	})
+	env := Env{Execute: func(_ context.Context, nested vtypes.Validation) vtypes.Result {
+		return vtypes.Result{Key: nested.Key, Passed: true, Message: "ran " + nested.Key}
+	}}
+
+	res := v.Validate(context.Background(), env, fakeSpec{})
+	assert.True(t, res.Passed)
+	assert.Equal(t, "ran nested", res.Message)
+}
+
+func TestTyped_SkipError(t *testing.T) {
+	v := Typed(func(_ context.Context, _ fakeSpec, _ shared.Deps) (bool, string, error) {
+		return false, "", fmt.Errorf("failed to get resource: %w", &shared.SkipError{Reason: "Skipped: add-on missing"})
+	})
+
+	res := v.Validate(context.Background(), Env{}, fakeSpec{})
+	assert.False(t, res.Passed)
+	assert.True(t, res.Skipped)
+	assert.Equal(t, "Skipped: add-on missing", res.Message)
+}
+
+func TestTyped_CompareError(t *testing.T) {
+	comparisons := []vtypes.Comparison{{Field: "readyReplicas", Operator: ">=", Expected: int64(3), Observed: int64(1)}}
+	v := Typed(func(_ context.Context, _ fakeSpec, _ shared.Deps) (bool, string, error) {
+		return shared.CompareFailure("readyReplicas: got 1, expected >= 3", comparisons)
+	})
+
+	res := v.Validate(context.Background(), Env{}, fakeSpec{})
+	assert.False(t, res.Passed)
+	assert.False(t, res.Skipped)
+	assert.Equal(t, "readyReplicas: got 1, expected >= 3", res.Message)
+	assert.Equal(t, comparisons, res.Comparisons)
+}
@@ -3,22 +3,38 @@ package validation
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/condition"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/connectivity"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/event"
-	executorlog "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/log"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/rbac"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/spec"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/status"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/triggered"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+
+	// Validation types register their Validator with the engine at init.
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/condition"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/connectivity"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/endpoints"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/event"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/images"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/log"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/metadata"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/networkpolicy"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/node"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/plugin"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/poddisruptionbudget"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/probes"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/prommetrics"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/rbac"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/resources"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/spec"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/status"
+	_ "github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/triggered"
 )
 
 // Executor executes validations against a Kubernetes cluster.
@@ -36,127 +52,125 @@ func NewExecutor(clientset kubernetes.Interface, dynamicClient dynamic.Interface
 		RestConfig:    restConfig,
 		Namespace:     namespace,
 		ProbeMu:       &e.probeMu,
+		ExecSem:       shared.NewExecLimiter(),
+		Server:        kube.DetectedServerCapabilities(),
 	}
 	return e
 }
 
+// EnableCache makes the executor read the objects it validates from shared informers
+// instead of the API server, which suits repeated evaluations such as watch mode and
+// retry polls. Cached reads are eventually consistent: a change is seen once its watch
+// event arrives. Call Close to stop the informers.
+func (e *Executor) EnableCache() {
+	if e.deps.Cache == nil && e.deps.DynamicClient != nil {
+		e.deps.Cache = shared.NewObjectCache(e.deps.DynamicClient, e.deps.Namespace)
+	}
+}
+
+// EnableSandbox makes exec and log validations of the challenge namespace run as its
+// validator ServiceAccount (see kube.EnsureValidatorSandbox) instead of the admin
+// credentials. On error the executor keeps using the admin clients: the error is
+// shared.ErrExecUnavailable without a REST config, and a NotFound error when the
+// namespace was started before the sandbox existed.
+func (e *Executor) EnableSandbox(ctx context.Context) error {
+	if e.deps.RestConfig == nil || e.deps.RestConfig.Host == "" {
+		return shared.ErrExecUnavailable
+	}
+	restConfig, err := kube.ValidatorRestConfig(ctx, e.deps.Clientset, e.deps.RestConfig, e.deps.Namespace, kube.DefaultValidatorTokenTTL)
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create sandbox client: %w", err)
+	}
+	e.deps.Sandbox = &shared.Sandbox{Clientset: clientset, RestConfig: restConfig}
+	return nil
+}
+
+// Close stops the informers started by EnableCache. It is safe to call on an executor
+// without a cache.
+func (e *Executor) Close() {
+	e.deps.Cache.Stop()
+}
+
 // Execute runs a single validation and returns the result.
+// The validation is dispatched to the Validator registered for its type. A RawSpec is
+// decoded when Spec is nil. An undecodable spec or a validator that panics yields a
+// failed result.
 func (e *Executor) Execute(ctx context.Context, v vtypes.Validation) vtypes.Result {
 	start := time.Now()
-	result := vtypes.Result{
-		Key:     v.Key,
-		Passed:  false,
-		Message: "Unknown validation type",
-	}
-
-	var (
-		passed bool
-		msg    string
-		err    error
-	)
 
-	switch v.Type {
-	case TypeStatus:
-		s, ok := v.Spec.(vtypes.StatusSpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected StatusSpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = status.Execute(ctx, s, e.deps)
-
-	case TypeCondition:
-		s, ok := v.Spec.(vtypes.ConditionSpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected ConditionSpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = condition.Execute(ctx, s, e.deps)
-
-	case TypeLog:
-		s, ok := v.Spec.(vtypes.LogSpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected LogSpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = executorlog.Execute(ctx, s, e.deps)
-
-	case TypeEvent:
-		s, ok := v.Spec.(vtypes.EventSpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected EventSpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = event.Execute(ctx, s, e.deps)
-
-	case TypeConnectivity:
-		s, ok := v.Spec.(vtypes.ConnectivitySpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected ConnectivitySpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = connectivity.Execute(ctx, s, e.deps)
-
-	case TypeRbac:
-		s, ok := v.Spec.(vtypes.RbacSpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected RbacSpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = rbac.Execute(ctx, s, e.deps)
-
-	case TypeSpec:
-		s, ok := v.Spec.(vtypes.SpecSpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected SpecSpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = spec.Execute(ctx, s, e.deps)
-
-	case TypeTriggered:
-		s, ok := v.Spec.(vtypes.TriggeredSpec)
-		if !ok {
-			result.Message = fmt.Sprintf("internal error: expected TriggeredSpec, got %T", v.Spec)
-			result.Duration = time.Since(start)
-			return result
-		}
-		passed, msg, err = triggered.Execute(ctx, s, e.deps, e.Execute)
+	result := e.validate(ctx, v)
+	result.Key = v.Key
+	result.Duration = time.Since(start)
+	return result
+}
 
-	default:
-		result.Message = fmt.Sprintf("Unknown validation type: %s", v.Type)
-		result.Duration = time.Since(start)
-		return result
+func (e *Executor) validate(ctx context.Context, v vtypes.Validation) (result vtypes.Result) {
+	validator, ok := engine.Lookup(v.Type)
+	if !ok {
+		return vtypes.Result{Message: fmt.Sprintf("Unknown validation type: %s", v.Type)}
 	}
 
-	if err != nil {
-		result.Passed = false
-		result.Message = err.Error()
-	} else {
-		result.Passed = passed
-		result.Message = msg
+	spec := v.Spec
+	if spec == nil && len(v.RawSpec) > 0 {
+		decoded, err := DecodeSpec(v.Type, v.RawSpec)
+		if err != nil {
+			return vtypes.Result{Message: fmt.Sprintf("Invalid spec: %v", err)}
+		}
+		spec = decoded
 	}
 
-	result.Duration = time.Since(start)
-	return result
+	// One broken validator must not take down the other validations running in parallel.
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("Validation %q of type %s panicked: %v\n%s", v.Key, v.Type, r, debug.Stack())
+			result = vtypes.Result{Message: fmt.Sprintf("internal error: %s validator panicked: %v", v.Type, r)}
+		}
+	}()
+	return validator.Validate(ctx, engine.Env{Deps: e.deps, Execute: e.Execute}, spec)
+}
+
+// ProgressEvent reports that a validation started or finished during
+// ExecuteAllWithProgress. Index is the validation's position in the input slice.
+type ProgressEvent struct {
+	Index      int
+	Validation vtypes.Validation
+	Done       bool
+	Result     vtypes.Result // set when Done
 }
 
 // ExecuteAll runs all validations in parallel and returns results in input order.
 func (e *Executor) ExecuteAll(ctx context.Context, validations []vtypes.Validation) []vtypes.Result {
+	return e.ExecuteAllWithProgress(ctx, validations, nil)
+}
+
+// ExecuteAllWithProgress is ExecuteAll with onEvent called when each validation starts
+// and finishes, so callers can render progress while long validations run. Calls to
+// onEvent are serialized; it may be nil.
+func (e *Executor) ExecuteAllWithProgress(ctx context.Context, validations []vtypes.Validation, onEvent func(ProgressEvent)) []vtypes.Result {
 	results := make([]vtypes.Result, len(validations))
-	var wg sync.WaitGroup
+	var (
+		wg      sync.WaitGroup
+		eventMu sync.Mutex
+	)
+	emit := func(ev ProgressEvent) {
+		if onEvent == nil {
+			return
+		}
+		eventMu.Lock()
+		defer eventMu.Unlock()
+		onEvent(ev)
+	}
 
 	for i, v := range validations {
-		wg.Add(1)
-		go func(idx int, val vtypes.Validation) {
-			defer wg.Done()
-			results[idx] = e.Execute(ctx, val)
-		}(i, v)
+		wg.Go(func() {
+			emit(ProgressEvent{Index: i, Validation: v})
+			results[i] = e.Execute(ctx, v)
+			emit(ProgressEvent{Index: i, Validation: v, Done: true, Result: results[i]})
+		})
 	}
 
 	wg.Wait()
@@ -164,13 +178,13 @@ func (e *Executor) ExecuteAll(ctx context.Context, validations []vtypes.Validati
 }
 
 // ExecuteSequential runs validations one by one.
-// If failFast is true, it stops at the first failure.
+// If failFast is true, it stops at the first failure. Skipped validations are not failures.
 func (e *Executor) ExecuteSequential(ctx context.Context, validations []vtypes.Validation, failFast bool) []vtypes.Result {
 	var results []vtypes.Result
 	for _, v := range validations {
 		result := e.Execute(ctx, v)
 		results = append(results, result)
-		if failFast && !result.Passed {
+		if failFast && !result.Passed && !result.Skipped {
 			break
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -61,6 +62,28 @@ func TestExecute_WrongSpecType(t *testing.T) {
 	assert.Contains(t, result.Message, "internal error")
 }
 
+// TestRegisteredTypes_HaveValidator ensures every type of the challenge.yaml schema
+// can be executed.
+func TestRegisteredTypes_HaveValidator(t *testing.T) {
+	for _, reg := range validation.RegisteredTypes {
+		_, ok := engine.Lookup(reg.Type)
+		assert.True(t, ok, "no validator registered for type %q", reg.Type)
+	}
+}
+
+func TestExecute_DispatchesToRegisteredValidator(t *testing.T) {
+	const typ validation.ValidationType = "executor-test-custom"
+	engine.Register(typ, engine.ValidatorFunc(func(_ context.Context, env engine.Env, spec interface{}) validation.Result {
+		return validation.Result{Key: "ignored", Passed: true, Message: env.Namespace}
+	}))
+
+	result := newTestExecutor().Execute(context.Background(), validation.Validation{Key: "custom", Type: typ})
+
+	assert.True(t, result.Passed)
+	assert.Equal(t, "test-ns", result.Message)
+	assert.Equal(t, "custom", result.Key, "the executor sets the key")
+}
+
 func TestExecuteAll(t *testing.T) {
 	// condition executor now uses the dynamic client for all resource types
 	pod := &unstructured.Unstructured{Object: map[string]interface{}{
@@ -108,6 +131,31 @@ func TestExecuteAll(t *testing.T) {
 	assert.False(t, results[1].Passed)
 }
 
+func TestExecuteAllWithProgress_ReportsStartAndFinish(t *testing.T) {
+	e := newTestExecutor()
+	validations := []validation.Validation{
+		{Key: "a", Type: "invalid", Spec: validation.StatusSpec{}},
+		{Key: "b", Type: "invalid", Spec: validation.StatusSpec{}},
+	}
+
+	started := map[int]bool{}
+	var finished []string
+	results := e.ExecuteAllWithProgress(context.Background(), validations, func(ev validation.ProgressEvent) {
+		if !ev.Done {
+			started[ev.Index] = true
+			return
+		}
+		assert.True(t, started[ev.Index], "validation %d finished before it started", ev.Index)
+		assert.Equal(t, validations[ev.Index].Key, ev.Result.Key)
+		finished = append(finished, ev.Validation.Key)
+	})
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].Key)
+	assert.Equal(t, "b", results[1].Key)
+	assert.ElementsMatch(t, []string{"a", "b"}, finished)
+}
+
 func TestExecuteSequential(t *testing.T) {
 	e := newTestExecutor()
 
@@ -145,3 +193,78 @@ func TestExecute_ResultHasDuration(t *testing.T) {
 
 	assert.Greater(t, result.Duration.Nanoseconds(), int64(0))
 }
+
+func TestExecute_DecodesRawSpec(t *testing.T) {
+	result := newTestExecutor().Execute(context.Background(), validation.Validation{
+		Key:     "raw",
+		Type:    validation.TypeStatus,
+		RawSpec: []byte(`{"target": {"kind": "Deployment", "name": "web"}, "checks": [{"field": "readyReplicas", "operator": ">=", "value": 1}]}`),
+	})
+
+	// The decoded spec reaches the status validator, which finds no deployment
+	assert.False(t, result.Passed)
+	assert.NotContains(t, result.Message, "internal error")
+	assert.NotContains(t, result.Message, "Invalid spec")
+	assert.Equal(t, "raw", result.Key)
+}
+
+func TestExecute_SpecTakesPrecedenceOverRawSpec(t *testing.T) {
+	const typ validation.ValidationType = "executor-test-raw"
+	var got interface{}
+	engine.Register(typ, engine.ValidatorFunc(func(_ context.Context, _ engine.Env, spec interface{}) validation.Result {
+		got = spec
+		return validation.Result{Passed: true}
+	}))
+
+	result := newTestExecutor().Execute(context.Background(), validation.Validation{
+		Key:     "raw",
+		Type:    typ,
+		Spec:    "typed",
+		RawSpec: []byte(`not decoded`),
+	})
+
+	assert.True(t, result.Passed)
+	assert.Equal(t, "typed", got)
+}
+
+func TestExecute_InvalidRawSpec(t *testing.T) {
+	result := newTestExecutor().Execute(context.Background(), validation.Validation{
+		Key:     "raw",
+		Type:    validation.TypeStatus,
+		RawSpec: []byte(`[not, a, mapping]`),
+	})
+
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Message, "Invalid spec")
+	assert.Equal(t, "raw", result.Key)
+}
+
+func TestExecute_MissingSpec(t *testing.T) {
+	result := newTestExecutor().Execute(context.Background(), validation.Validation{
+		Key:  "no-spec",
+		Type: validation.TypeStatus,
+	})
+
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Message, "internal error")
+}
+
+func TestExecute_RecoversFromValidatorPanic(t *testing.T) {
+	const typ validation.ValidationType = "executor-test-panic"
+	engine.Register(typ, engine.ValidatorFunc(func(context.Context, engine.Env, interface{}) validation.Result {
+		var spec map[string]interface{}
+		spec["boom"] = true // nil map write
+		return validation.Result{Passed: true}
+	}))
+
+	results := newTestExecutor().ExecuteAll(context.Background(), []validation.Validation{
+		{Key: "panics", Type: typ, Spec: struct{}{}},
+		{Key: "unknown", Type: "invalid", Spec: struct{}{}},
+	})
+
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Passed)
+	assert.Equal(t, "panics", results[0].Key)
+	assert.Contains(t, results[0].Message, "panicked")
+	assert.Contains(t, results[1].Message, "Unknown validation type")
+}
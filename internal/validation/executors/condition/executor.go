@@ -8,11 +8,10 @@ import (
 	"strings"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -21,6 +20,10 @@ const (
 	msgAllConditionsMet  = "All checks passed"
 )
 
+func init() {
+	engine.Register(vtypes.TypeCondition, engine.Typed(shared.WithDiagnosis(Execute, func(s vtypes.ConditionSpec) vtypes.Target { return s.Target })))
+}
+
 // Execute validates .status.conditions on any Kubernetes resource.
 func Execute(ctx context.Context, spec vtypes.ConditionSpec, deps shared.Deps) (bool, string, error) {
 	logger.Debug("Executing condition validation for %s", spec.Target.Kind)
@@ -38,23 +41,21 @@ func Execute(ctx context.Context, spec vtypes.ConditionSpec, deps shared.Deps) (
 
 	switch {
 	case spec.Target.Name != "":
-		obj, err := deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).Get(ctx, spec.Target.Name, metav1.GetOptions{})
+		obj, err := shared.GetObject(ctx, deps, gvr, spec.Target.Name)
 		if err != nil {
 			return false, "", fmt.Errorf("failed to get %s %s: %w", spec.Target.Kind, spec.Target.Name, err)
 		}
 		objs = []unstructured.Unstructured{*obj}
 
 	case len(spec.Target.LabelSelector) > 0:
-		list, err := deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).List(ctx, metav1.ListOptions{
-			LabelSelector: labels.SelectorFromSet(spec.Target.LabelSelector).String(),
-		})
+		items, err := shared.ListObjects(ctx, deps, gvr, spec.Target.LabelSelector)
 		if err != nil {
 			return false, "", fmt.Errorf("failed to list %s: %w", spec.Target.Kind, err)
 		}
-		if len(list.Items) == 0 {
+		if len(items) == 0 {
 			return false, errNoMatchingObjects, nil
 		}
-		objs = list.Items
+		objs = items
 
 	default:
 		return false, "No target name or labelSelector specified", nil
@@ -62,9 +63,11 @@ func Execute(ctx context.Context, spec vtypes.ConditionSpec, deps shared.Deps) (
 
 	allPassed := true
 	var messages []string
+	var comparisons []vtypes.Comparison
 
 	for _, obj := range objs {
 		name := obj.GetName()
+		ref := &vtypes.ObjectRef{Kind: spec.Target.Kind, Namespace: obj.GetNamespace(), Name: name}
 		rawConditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
 		if err != nil || !found {
 			allPassed = false
@@ -75,6 +78,7 @@ func Execute(ctx context.Context, spec vtypes.ConditionSpec, deps shared.Deps) (
 		for _, check := range spec.Checks {
 			conditionFound := false
 			passed := false
+			var condStatus string
 			for _, raw := range rawConditions {
 				cond, ok := raw.(map[string]interface{})
 				if !ok {
@@ -85,7 +89,7 @@ func Execute(ctx context.Context, spec vtypes.ConditionSpec, deps shared.Deps) (
 					continue
 				}
 				conditionFound = true
-				condStatus, _ := cond["status"].(string)
+				condStatus, _ = cond["status"].(string)
 				passed = condStatus == check.Status
 				break
 			}
@@ -93,9 +97,11 @@ func Execute(ctx context.Context, spec vtypes.ConditionSpec, deps shared.Deps) (
 				logger.Debug("%s %s: condition %s not found", spec.Target.Kind, name, check.Type)
 				allPassed = false
 				messages = append(messages, fmt.Sprintf("%s %s: condition %s not found", spec.Target.Kind, name, check.Type))
+				comparisons = append(comparisons, vtypes.Comparison{Field: check.Type, Operator: "==", Expected: check.Status, ObjectRef: ref})
 			} else if !passed {
 				allPassed = false
 				messages = append(messages, fmt.Sprintf("%s %s: condition %s is not %s", spec.Target.Kind, name, check.Type, check.Status))
+				comparisons = append(comparisons, vtypes.Comparison{Field: check.Type, Operator: "==", Expected: check.Status, Observed: condStatus, ObjectRef: ref})
 			}
 		}
 	}
@@ -103,5 +109,5 @@ func Execute(ctx context.Context, spec vtypes.ConditionSpec, deps shared.Deps) (
 	if allPassed {
 		return true, msgAllConditionsMet, nil
 	}
-	return false, strings.Join(messages, "; "), nil
+	return shared.CompareFailure(strings.Join(messages, "; "), comparisons)
 }
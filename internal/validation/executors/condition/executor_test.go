@@ -18,6 +18,14 @@ func deps(dc *dynamicfake.FakeDynamicClient) shared.Deps {
 	return shared.Deps{DynamicClient: dc, Namespace: "test-ns"}
 }
 
+// compareMessage returns the message of the *shared.CompareError a failed check returns.
+func compareMessage(t *testing.T, err error) string {
+	t.Helper()
+	var compare *shared.CompareError
+	require.ErrorAs(t, err, &compare)
+	return compare.Message
+}
+
 func resource(kind, apiVersion, name string, conditions []map[string]interface{}) *unstructured.Unstructured {
 	obj := map[string]interface{}{
 		"apiVersion": apiVersion,
@@ -58,10 +66,17 @@ func TestExecute_Pod_ConditionFalse(t *testing.T) {
 		Checks: []vtypes.ConditionCheck{{Type: "Ready", Status: "True"}},
 	}
 
-	passed, msg, err := condition.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), pod)))
-	require.NoError(t, err)
+	passed, _, err := condition.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), pod)))
+	msg := compareMessage(t, err)
 	assert.False(t, passed)
 	assert.Contains(t, msg, "condition Ready is not True")
+
+	var compare *shared.CompareError
+	require.ErrorAs(t, err, &compare)
+	assert.Equal(t, []vtypes.Comparison{{
+		Field: "Ready", Operator: "==", Expected: "True", Observed: "False",
+		ObjectRef: &vtypes.ObjectRef{Kind: "Pod", Namespace: "test-ns", Name: "test-pod"},
+	}}, compare.Comparisons)
 }
 
 func TestExecute_Pod_ConditionNotFound(t *testing.T) {
@@ -73,8 +88,8 @@ func TestExecute_Pod_ConditionNotFound(t *testing.T) {
 		Checks: []vtypes.ConditionCheck{{Type: "Ready", Status: "True"}},
 	}
 
-	passed, msg, err := condition.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), pod)))
-	require.NoError(t, err)
+	passed, _, err := condition.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), pod)))
+	msg := compareMessage(t, err)
 	assert.False(t, passed)
 	assert.Contains(t, msg, "condition Ready not found")
 }
@@ -104,8 +119,8 @@ func TestExecute_Deployment_ConditionFalse(t *testing.T) {
 		Checks: []vtypes.ConditionCheck{{Type: "Available", Status: "True"}},
 	}
 
-	passed, msg, err := condition.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
-	require.NoError(t, err)
+	passed, _, err := condition.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
+	msg := compareMessage(t, err)
 	assert.False(t, passed)
 	assert.Contains(t, msg, "condition Available is not True")
 }
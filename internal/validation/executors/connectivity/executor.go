@@ -3,10 +3,10 @@
 package connectivity
 
 import (
-	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -16,15 +16,13 @@ import (
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
-	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/remotecommand"
 )
 
 const (
@@ -35,6 +33,10 @@ const (
 	defaultTimeoutSeconds = 5
 )
 
+func init() {
+	engine.Register(vtypes.TypeConnectivity, engine.Typed(Execute))
+}
+
 // Execute tests network connectivity according to the spec.
 func Execute(ctx context.Context, spec vtypes.ConnectivitySpec, deps shared.Deps) (bool, string, error) {
 	logger.Debug("Executing connectivity validation")
@@ -79,27 +81,29 @@ func Execute(ctx context.Context, spec vtypes.ConnectivitySpec, deps shared.Deps
 		}
 	default:
 		// Probe mode (PROBE-01): empty SourcePod — deploy a CLI-managed kubeasy-probe pod.
-		// Serialize to avoid concurrent CreateProbePod collisions (fixed pod name).
-		deps.ProbeMu.Lock()
-		defer deps.ProbeMu.Unlock()
-		pod, err := deployer.CreateProbePod(ctx, deps.Clientset, sourceNamespace)
+		var passed bool
+		var msg string
+		err := shared.WithProbePod(ctx, deps, sourceNamespace, func(pod *corev1.Pod) error {
+			passed, msg = checkAllTargets(ctx, deps, pod, spec.Targets)
+			return nil
+		})
 		if err != nil {
-			return false, "", fmt.Errorf("failed to create probe pod: %w", err)
-		}
-		defer func() {
-			_ = deployer.DeleteProbePod(context.Background(), deps.Clientset, sourceNamespace)
-		}()
-		if err := deployer.WaitForProbePodReady(ctx, deps.Clientset, sourceNamespace); err != nil {
-			return false, "", fmt.Errorf("probe pod failed to become ready: %w", err)
+			return false, "", err
 		}
-		sourcePod = pod
+		return passed, msg, nil
 	}
 
+	passed, msg := checkAllTargets(ctx, deps, sourcePod, spec.Targets)
+	return passed, msg, nil
+}
+
+// checkAllTargets runs every connectivity check from pod.
+func checkAllTargets(ctx context.Context, deps shared.Deps, pod *corev1.Pod, targets []vtypes.ConnectivityCheck) (bool, string) {
 	allPassed := true
 	var messages []string
 
-	for _, target := range spec.Targets {
-		passed, msg := checkConnectivity(ctx, deps, sourcePod, target)
+	for _, target := range targets {
+		passed, msg := checkConnectivity(ctx, deps, pod, target)
 		if !passed {
 			allPassed = false
 			messages = append(messages, msg)
@@ -107,9 +111,9 @@ func Execute(ctx context.Context, spec vtypes.ConnectivitySpec, deps shared.Deps
 	}
 
 	if allPassed {
-		return true, msgAllConnectivityPassed, nil
+		return true, msgAllConnectivityPassed
 	}
-	return false, strings.Join(messages, "; "), nil
+	return false, strings.Join(messages, "; ")
 }
 
 func checkExternalConnectivityAll(ctx context.Context, spec vtypes.ConnectivitySpec, deps shared.Deps) (bool, string, error) {
@@ -307,38 +311,18 @@ func checkConnectivity(ctx context.Context, deps shared.Deps, pod *corev1.Pod, t
 
 	cmd := buildCurlCommand(target.URL, timeout)
 
-	// Guard: fake clientsets have a non-nil RESTClient but internally nil client.
-	// If restConfig has no host, we are running in a test environment — return
-	// a deterministic error so the status-0 guard can be applied.
-	if deps.RestConfig == nil || deps.RestConfig.Host == "" {
+	stdout, _, err := shared.ExecInPod(ctx, deps, pod, cmd)
+	if errors.Is(err, shared.ErrExecUnavailable) {
+		// Deterministic result in test environments, so the status-0 guard can be applied
 		if target.ExpectedStatusCode == 0 {
 			return true, fmt.Sprintf("Connection to %s blocked as expected", target.URL)
 		}
 		return false, fmt.Sprintf("Connection to %s failed: exec not available in test environment", target.URL)
 	}
-
-	req := deps.Clientset.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod.Name).
-		Namespace(pod.Namespace).
-		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Command: cmd,
-			Stdout:  true,
-			Stderr:  true,
-		}, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(deps.RestConfig, "POST", req.URL())
-	if err != nil {
-		return false, fmt.Sprintf("Failed to create executor: %v", err)
+	if errors.Is(err, shared.ErrCommandNotAllowed) {
+		// Not a blocked connection: the request was never sent
+		return false, fmt.Sprintf("Connection to %s not checked: %v", target.URL, err)
 	}
-
-	var stdout, stderr bytes.Buffer
-	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
-
 	if err != nil {
 		if target.ExpectedStatusCode == 0 {
 			return true, fmt.Sprintf("Connection to %s blocked as expected", target.URL)
@@ -346,7 +330,7 @@ func checkConnectivity(ctx context.Context, deps shared.Deps, pod *corev1.Pod, t
 		return false, fmt.Sprintf("Connection to %s failed: %v", target.URL, err)
 	}
 
-	statusCode := strings.TrimSpace(stdout.String())
+	statusCode := strings.TrimSpace(stdout)
 	code, err := strconv.Atoi(statusCode)
 	if err != nil {
 		return false, fmt.Sprintf("Invalid response from %s: %s", target.URL, statusCode)
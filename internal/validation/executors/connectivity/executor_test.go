@@ -93,6 +93,23 @@ func TestExecute_InternalMode_BlockedExpected(t *testing.T) {
 	assert.Contains(t, msg, "All connectivity checks passed")
 }
 
+func TestExecute_InternalMode_CommandNotAllowed(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "source-pod", Namespace: "test-ns"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	// A refused command is not a blocked connection, even when one is expected
+	spec := vtypes.ConnectivitySpec{
+		SourcePod: vtypes.SourcePod{Name: "source-pod"},
+		Targets:   []vtypes.ConnectivityCheck{{URL: "--config=/etc/passwd", ExpectedStatusCode: 0}},
+	}
+
+	passed, msg, err := connectivity.Execute(context.Background(), spec, depsWithPod(pod))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Contains(t, msg, "command not allowed")
+}
+
 func TestExecute_ExternalMode_Success(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,166 @@
+// Package endpoints implements the "endpoints" validation type.
+// It counts the ready endpoints of a Service from its EndpointSlices and, when the
+// count is wrong, explains why the selected pods are not ready endpoints.
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// maxDiagnoses caps how many per-pod explanations are added to a failure message.
+const maxDiagnoses = 3
+
+func init() {
+	engine.Register(vtypes.TypeEndpoints, engine.Typed(Execute))
+}
+
+// Execute compares the number of ready endpoints of spec.Service with the expectation.
+func Execute(ctx context.Context, spec vtypes.EndpointsSpec, deps shared.Deps) (bool, string, error) {
+	logger.Debug("Executing endpoints validation for Service %s", spec.Service)
+	if err := shared.RequireFeature(deps, kube.FeatureEndpointSlices); err != nil {
+		return false, "", err
+	}
+
+	svc, err := deps.Clientset.CoreV1().Services(deps.Namespace).Get(ctx, spec.Service, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, fmt.Sprintf("Service %s not found", spec.Service), nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get service %s: %w", spec.Service, err)
+	}
+
+	slices, err := deps.Clientset.DiscoveryV1().EndpointSlices(deps.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + spec.Service,
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("failed to list endpoint slices of service %s: %w", spec.Service, err)
+	}
+	ready := countReady(slices.Items)
+
+	passed, err := shared.CompareTypedValues(int64(ready), spec.Operator, int64(spec.ReadyEndpoints))
+	if err != nil {
+		return false, "", err
+	}
+	msg := fmt.Sprintf("Service %s has %d ready endpoint(s)", spec.Service, ready)
+	if passed {
+		return true, msg, nil
+	}
+
+	msg = fmt.Sprintf("%s, expected %s %d", msg, spec.Operator, spec.ReadyEndpoints)
+	if diagnosis := diagnose(ctx, deps, svc); diagnosis != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, diagnosis)
+	}
+	return false, msg, nil
+}
+
+// countReady counts the distinct ready endpoints across slices. Dual-stack Services
+// have one slice per address family, so endpoints are keyed by the pod they point to.
+func countReady(slices []discoveryv1.EndpointSlice) int {
+	seen := map[string]bool{}
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			// A nil Ready condition means ready
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue
+			}
+			key := strings.Join(ep.Addresses, ",")
+			if ep.TargetRef != nil {
+				key = ep.TargetRef.Kind + "/" + ep.TargetRef.Name
+			}
+			seen[key] = true
+		}
+	}
+	return len(seen)
+}
+
+// diagnose explains why the pods of svc are not ready endpoints, or returns "".
+// It is best effort: lookup errors are only logged.
+func diagnose(ctx context.Context, deps shared.Deps, svc *corev1.Service) string {
+	if len(svc.Spec.Selector) == 0 {
+		return "the Service has no selector, so its endpoints are not managed by Kubernetes"
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector).String()
+	pods, err := deps.Clientset.CoreV1().Pods(deps.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logger.Debug("Endpoints diagnosis: failed to list pods: %v", err)
+		return ""
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Sprintf("selector %s matches no pods", selector)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	var reasons []string
+	for i := range pods.Items {
+		if reason := podProblem(&pods.Items[i], svc); reason != "" {
+			reasons = append(reasons, fmt.Sprintf("pod %s %s", pods.Items[i].Name, reason))
+		}
+	}
+	if len(reasons) == 0 {
+		return fmt.Sprintf("selector %s matches %d ready pod(s)", selector, len(pods.Items))
+	}
+	if len(reasons) > maxDiagnoses {
+		reasons = append(reasons[:maxDiagnoses], fmt.Sprintf("%d more", len(reasons)-maxDiagnoses))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// podProblem returns why pod is not a ready endpoint of svc, or "".
+func podProblem(pod *corev1.Pod, svc *corev1.Service) string {
+	if pod.DeletionTimestamp != nil {
+		return "is terminating"
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Sprintf("is %s", strings.ToLower(string(pod.Status.Phase)))
+	}
+	if port := missingNamedPort(pod, svc); port != "" {
+		return fmt.Sprintf("has no container port named %q", port)
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status != corev1.ConditionTrue {
+			for _, cs := range pod.Status.ContainerStatuses {
+				if !cs.Ready {
+					return fmt.Sprintf("is not ready: container %s is not ready", cs.Name)
+				}
+			}
+			return "is not ready"
+		}
+	}
+	return ""
+}
+
+// missingNamedPort returns the first named targetPort of svc that pod does not expose.
+func missingNamedPort(pod *corev1.Pod, svc *corev1.Service) string {
+	for _, sp := range svc.Spec.Ports {
+		if sp.TargetPort.Type != intstr.String {
+			continue
+		}
+		found := false
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name == sp.TargetPort.StrVal {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return sp.TargetPort.StrVal
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package endpoints_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/endpoints"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func service(selector map[string]string, targetPort intstr.IntOrString) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-ns"},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: targetPort}},
+		},
+	}
+}
+
+func slice(name string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "test-ns",
+			Labels: map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		Endpoints: endpoints,
+	}
+}
+
+func endpoint(pod, address string, ready bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{address},
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: pod},
+	}
+}
+
+func pod(name string, phase corev1.PodPhase, ready bool, portName string) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: ready}},
+		},
+	}
+	if portName != "" {
+		p.Spec.Containers[0].Ports = []corev1.ContainerPort{{Name: portName, ContainerPort: 8080}}
+	}
+	return p
+}
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+}
+
+func TestExecute_CountsReadyEndpoints(t *testing.T) {
+	d := deps(
+		service(map[string]string{"app": "web"}, intstr.FromInt32(8080)),
+		// Dual-stack: the same pods appear in an IPv4 and an IPv6 slice
+		slice("web-ipv4", endpoint("web-a", "10.0.0.1", true), endpoint("web-b", "10.0.0.2", true), endpoint("web-c", "10.0.0.3", false)),
+		slice("web-ipv6", endpoint("web-a", "fd00::1", true), endpoint("web-b", "fd00::2", true)),
+	)
+
+	passed, msg, err := endpoints.Execute(context.Background(), vtypes.EndpointsSpec{Service: "web", Operator: "==", ReadyEndpoints: 2}, d)
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Equal(t, "Service web has 2 ready endpoint(s)", msg)
+
+	passed, _, err = endpoints.Execute(context.Background(), vtypes.EndpointsSpec{Service: "web", Operator: ">=", ReadyEndpoints: 3}, d)
+	require.NoError(t, err)
+	assert.False(t, passed)
+}
+
+func TestExecute_ZeroEndpoints(t *testing.T) {
+	d := deps(service(map[string]string{"app": "other"}, intstr.FromInt32(8080)), pod("web-a", corev1.PodRunning, true, ""))
+
+	passed, msg, err := endpoints.Execute(context.Background(), vtypes.EndpointsSpec{Service: "web", Operator: "==", ReadyEndpoints: 0}, d)
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Equal(t, "Service web has 0 ready endpoint(s)", msg)
+}
+
+func TestExecute_Diagnosis(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    string
+	}{
+		{
+			name:    "service not found",
+			objects: nil,
+			want:    "Service web not found",
+		},
+		{
+			name:    "no selector",
+			objects: []runtime.Object{service(nil, intstr.FromInt32(8080))},
+			want:    "Service web has 0 ready endpoint(s), expected >= 1 (the Service has no selector, so its endpoints are not managed by Kubernetes)",
+		},
+		{
+			name:    "selector matches nothing",
+			objects: []runtime.Object{service(map[string]string{"app": "wbe"}, intstr.FromInt32(8080)), pod("web-a", corev1.PodRunning, true, "")},
+			want:    "Service web has 0 ready endpoint(s), expected >= 1 (selector app=wbe matches no pods)",
+		},
+		{
+			name: "pods not ready",
+			objects: []runtime.Object{
+				service(map[string]string{"app": "web"}, intstr.FromInt32(8080)),
+				pod("web-a", corev1.PodRunning, false, ""),
+				pod("web-b", corev1.PodPending, false, ""),
+			},
+			want: "Service web has 0 ready endpoint(s), expected >= 1 (pod web-a is not ready: container app is not ready; pod web-b is pending)",
+		},
+		{
+			name: "named target port missing",
+			objects: []runtime.Object{
+				service(map[string]string{"app": "web"}, intstr.FromString("http")),
+				pod("web-a", corev1.PodRunning, true, "web"),
+			},
+			want: `Service web has 0 ready endpoint(s), expected >= 1 (pod web-a has no container port named "http")`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := vtypes.EndpointsSpec{Service: "web", Operator: ">=", ReadyEndpoints: 1}
+			passed, msg, err := endpoints.Execute(context.Background(), spec, deps(tt.objects...))
+			require.NoError(t, err)
+			assert.False(t, passed)
+			assert.Equal(t, tt.want, msg)
+		})
+	}
+}
+
+func TestExecute_SkippedOnOldServer(t *testing.T) {
+	d := deps(service(map[string]string{"app": "web"}, intstr.FromInt32(8080)))
+	d.Server = kube.ServerCapabilities{Version: "1.20.7"}
+
+	_, _, err := endpoints.Execute(context.Background(), vtypes.EndpointsSpec{Service: "web", Operator: "==", ReadyEndpoints: 0}, d)
+	var skip *shared.SkipError
+	require.ErrorAs(t, err, &skip)
+	assert.Contains(t, skip.Reason, "require Kubernetes 1.21")
+}
@@ -10,9 +10,9 @@ import (
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -21,6 +21,10 @@ const (
 	kindPod              = "Pod"
 )
 
+func init() {
+	engine.Register(vtypes.TypeEvent, engine.Typed(Execute))
+}
+
 // Execute checks that no forbidden events exist for the target resource and that all
 // required events are present within the time window.
 //
@@ -66,7 +70,7 @@ func Execute(ctx context.Context, spec vtypes.EventSpec, deps shared.Deps) (bool
 		}
 	}
 
-	events, err := deps.Clientset.CoreV1().Events(deps.Namespace).List(ctx, metav1.ListOptions{})
+	events, err := shared.ListEvents(ctx, deps.Clientset, deps.Namespace)
 	if err != nil {
 		return false, "", fmt.Errorf("failed to list events: %w", err)
 	}
@@ -82,7 +86,7 @@ func Execute(ctx context.Context, spec vtypes.EventSpec, deps shared.Deps) (bool
 	var forbiddenFound []string
 	foundReasons := make(map[string]bool)
 
-	for _, event := range events.Items {
+	for _, event := range events {
 		if !matchEvent(event.InvolvedObject.Kind, event.InvolvedObject.Name) {
 			continue
 		}
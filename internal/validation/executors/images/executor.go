@@ -0,0 +1,129 @@
+// Package images implements the "images" validation type.
+// It checks the image references and pull policies of containers against
+// supply-chain rules: no latest tag, pinned digests and allowed registries.
+package images
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	msgAllImagesPassed = "All image checks passed"
+	errNoMatchingPods  = "No matching pods found"
+
+	// dockerHub is the registry of image references without a registry host.
+	dockerHub = "docker.io"
+)
+
+func init() {
+	engine.Register(vtypes.TypeImages, engine.Typed(Execute))
+}
+
+// imageRef is a parsed image reference. repository is fully qualified, e.g.
+// docker.io/library/nginx for "nginx".
+type imageRef struct {
+	repository string
+	tag        string
+	digest     string
+}
+
+// Execute checks every selected container of the pods of spec.Target.
+func Execute(ctx context.Context, spec vtypes.ImagesSpec, deps shared.Deps) (bool, string, error) {
+	logger.Debug("Executing images validation")
+
+	pods, err := shared.GetTargetPods(ctx, deps, spec.Target)
+	if err != nil {
+		return false, "", err
+	}
+	if len(pods) == 0 {
+		return false, errNoMatchingPods, nil
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	failures, found := shared.CheckContainers(pods, spec.Container, true, func(c *corev1.Container) []string {
+		return checkContainer(c, spec)
+	})
+	if !found {
+		return false, fmt.Sprintf("Container %s not found in the matching pods", spec.Container), nil
+	}
+
+	if len(failures) == 0 {
+		return true, msgAllImagesPassed, nil
+	}
+	return false, strings.Join(failures, "; "), nil
+}
+
+func checkContainer(c *corev1.Container, spec vtypes.ImagesSpec) []string {
+	ref := parseImage(c.Image)
+	var problems []string
+
+	if spec.ForbidLatest {
+		switch {
+		case ref.tag == "latest":
+			problems = append(problems, fmt.Sprintf("image %s uses the latest tag", c.Image))
+		case ref.tag == "" && ref.digest == "":
+			problems = append(problems, fmt.Sprintf("image %s has no tag, so it uses latest", c.Image))
+		}
+	}
+	if spec.RequireDigest && ref.digest == "" {
+		problems = append(problems, fmt.Sprintf("image %s is not pinned to a digest", c.Image))
+	}
+	if len(spec.AllowedRegistries) > 0 && !allowedRegistry(ref, spec.AllowedRegistries) {
+		problems = append(problems, fmt.Sprintf("image %s is not from an allowed registry (%s)", c.Image, strings.Join(spec.AllowedRegistries, ", ")))
+	}
+	if spec.PullPolicy != "" && string(c.ImagePullPolicy) != spec.PullPolicy {
+		actual := string(c.ImagePullPolicy)
+		if actual == "" {
+			actual = "not set"
+		}
+		problems = append(problems, fmt.Sprintf("imagePullPolicy is %s, expected %s", actual, spec.PullPolicy))
+	}
+	return problems
+}
+
+// allowedRegistry reports whether ref is in one of the allowed registries or
+// repository prefixes.
+func allowedRegistry(ref imageRef, allowed []string) bool {
+	for _, prefix := range allowed {
+		if ref.repository == prefix || strings.HasPrefix(ref.repository, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseImage splits an image reference the way the container runtime resolves it.
+// The first path component is a registry host only when it contains a "." or a ":",
+// or is "localhost"; other references are Docker Hub images.
+func parseImage(image string) imageRef {
+	var ref imageRef
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.tag = name[:i], name[i+1:]
+	}
+
+	host, path, found := strings.Cut(name, "/")
+	if !found || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		host, path = dockerHub, name
+	}
+	if host == "index.docker.io" {
+		host = dockerHub
+	}
+	if host == dockerHub && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+	ref.repository = host + "/" + path
+	return ref
+}
@@ -0,0 +1,138 @@
+package images
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		image string
+		want  imageRef
+	}{
+		{"nginx", imageRef{repository: "docker.io/library/nginx"}},
+		{"nginx:1.27", imageRef{repository: "docker.io/library/nginx", tag: "1.27"}},
+		{"bitnami/redis:7.2", imageRef{repository: "docker.io/bitnami/redis", tag: "7.2"}},
+		{"index.docker.io/nginx:latest", imageRef{repository: "docker.io/library/nginx", tag: "latest"}},
+		{"ghcr.io/kubeasy-dev/app@" + digest, imageRef{repository: "ghcr.io/kubeasy-dev/app", digest: digest}},
+		{"registry.local:5000/team/app:v1@" + digest, imageRef{repository: "registry.local:5000/team/app", tag: "v1", digest: digest}},
+		{"localhost/app", imageRef{repository: "localhost/app"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseImage(tt.image))
+		})
+	}
+}
+
+func testPod(name string, initImage string, images ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "web"}}}
+	if initImage != "" {
+		pod.Spec.InitContainers = []corev1.Container{{Name: "migrate", Image: initImage, ImagePullPolicy: corev1.PullIfNotPresent}}
+	}
+	for i, image := range images {
+		names := []string{"app", "proxy"}
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: names[i], Image: image, ImagePullPolicy: corev1.PullIfNotPresent})
+	}
+	return pod
+}
+
+// webPods selects the pods of testPod.
+var webPods = vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}}
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+}
+
+func TestExecute_Rules(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    vtypes.ImagesSpec
+		pod     *corev1.Pod
+		message string
+	}{
+		{
+			name: "compliant",
+			spec: vtypes.ImagesSpec{Target: webPods, ForbidLatest: true, RequireDigest: true, AllowedRegistries: []string{"ghcr.io/kubeasy-dev", "docker.io/library"}, PullPolicy: "IfNotPresent"},
+			pod:  testPod("web-0", "ghcr.io/kubeasy-dev/migrate:v2@"+digest, "nginx:1.27@"+digest),
+		},
+		{
+			name:    "latest tag and untagged",
+			spec:    vtypes.ImagesSpec{Target: webPods, ForbidLatest: true},
+			pod:     testPod("web-0", "", "nginx:latest", "envoyproxy/envoy"),
+			message: "container app: image nginx:latest uses the latest tag; container proxy: image envoyproxy/envoy has no tag, so it uses latest",
+		},
+		{
+			name:    "digest only is not latest",
+			spec:    vtypes.ImagesSpec{Target: webPods, ForbidLatest: true},
+			pod:     testPod("web-0", "", "nginx@"+digest),
+			message: "",
+		},
+		{
+			name:    "digest required in init containers too",
+			spec:    vtypes.ImagesSpec{Target: webPods, RequireDigest: true},
+			pod:     testPod("web-0", "busybox:1.36", "nginx@"+digest),
+			message: "init container migrate: image busybox:1.36 is not pinned to a digest",
+		},
+		{
+			name:    "registry prefix does not match a longer organization",
+			spec:    vtypes.ImagesSpec{Target: webPods, AllowedRegistries: []string{"ghcr.io/kubeasy"}},
+			pod:     testPod("web-0", "", "ghcr.io/kubeasy-dev/app:v1"),
+			message: "container app: image ghcr.io/kubeasy-dev/app:v1 is not from an allowed registry (ghcr.io/kubeasy)",
+		},
+		{
+			name:    "several problems in one container",
+			spec:    vtypes.ImagesSpec{Target: webPods, ForbidLatest: true, PullPolicy: "Always"},
+			pod:     testPod("web-0", "", "nginx:latest"),
+			message: "container app: image nginx:latest uses the latest tag, imagePullPolicy is IfNotPresent, expected Always",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, msg, err := Execute(context.Background(), tt.spec, deps(tt.pod))
+			require.NoError(t, err)
+			if tt.message == "" {
+				assert.True(t, passed, msg)
+				assert.Equal(t, msgAllImagesPassed, msg)
+				return
+			}
+			assert.False(t, passed)
+			assert.Equal(t, tt.message, msg)
+		})
+	}
+}
+
+func TestExecute_ContainerSelection(t *testing.T) {
+	pods := []runtime.Object{testPod("web-0", "", "nginx:1.27", "envoy:latest"), testPod("web-1", "", "nginx:1.27", "envoy:latest")}
+
+	passed, msg, err := Execute(context.Background(), vtypes.ImagesSpec{Target: webPods, Container: "app", ForbidLatest: true}, deps(pods...))
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+
+	// The same failure in two pods is reported once
+	passed, msg, err = Execute(context.Background(), vtypes.ImagesSpec{Target: webPods, ForbidLatest: true}, deps(pods...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "container proxy: image envoy:latest uses the latest tag", msg)
+
+	passed, msg, err = Execute(context.Background(), vtypes.ImagesSpec{Target: webPods, Container: "sidecar", ForbidLatest: true}, deps(pods...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Container sidecar not found in the matching pods", msg)
+
+	passed, msg, err = Execute(context.Background(), vtypes.ImagesSpec{Target: webPods, ForbidLatest: true}, deps())
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, errNoMatchingPods, msg)
+}
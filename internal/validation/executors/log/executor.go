@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
 	corev1 "k8s.io/api/core/v1"
@@ -19,6 +20,10 @@ const (
 	msgFoundAnyExpectedString  = "Found at least one expected string in logs"
 )
 
+func init() {
+	engine.Register(vtypes.TypeLog, engine.Typed(Execute))
+}
+
 // Execute searches container logs for expected strings.
 func Execute(ctx context.Context, spec vtypes.LogSpec, deps shared.Deps) (bool, string, error) {
 	logger.Debug("Executing log validation")
@@ -39,28 +44,46 @@ func Execute(ctx context.Context, spec vtypes.LogSpec, deps shared.Deps) (bool,
 
 	var logErrors []string
 
+	// The log bytes of all pods share one budget, so a wide label selector cannot
+	// make a single validation read unbounded logs.
+	maxBytes := capLimit(int64(spec.MaxBytes), shared.MaxLogBytes)
+	budget := maxBytes
+	tailLines := capLimit(int64(spec.TailLines), shared.MaxLogTailLines)
+	truncated := false
+
 	podLogs := make(map[string]string)
 	for _, pod := range pods {
+		if budget <= 0 {
+			truncated = true
+			break
+		}
 		container := spec.Container
 		if container == "" && len(pod.Spec.Containers) > 0 {
 			container = pod.Spec.Containers[0].Name
 		}
 
+		limitBytes := budget
 		opts := &corev1.PodLogOptions{
 			Container:    container,
 			SinceSeconds: sinceSecondsPtr,
 			Previous:     spec.Previous,
+			TailLines:    &tailLines,
+			LimitBytes:   &limitBytes,
 		}
 
-		req := deps.Clientset.CoreV1().Pods(deps.Namespace).GetLogs(pod.Name, opts)
-		logs, err := req.Do(ctx).Raw()
+		logs, more, err := fetchLogs(ctx, deps, pod.Name, opts, limitBytes)
 		if err != nil {
 			errMsg := fmt.Sprintf("pod %s: %v", pod.Name, err)
 			logger.Debug("Failed to get logs for %s", errMsg)
 			logErrors = append(logErrors, errMsg)
 			continue
 		}
-		podLogs[pod.Name] = string(logs)
+		podLogs[pod.Name] = logs
+		budget -= int64(len(logs))
+		truncated = truncated || more
+	}
+	if truncated {
+		logger.Debug("Log validation reached the %d bytes limit, remaining logs were not read", maxBytes)
 	}
 
 	errSuffix := ""
@@ -99,3 +122,27 @@ func Execute(ctx context.Context, spec vtypes.LogSpec, deps shared.Deps) (bool,
 	}
 	return false, fmt.Sprintf("Missing strings in logs: %v%s", missingStrings, errSuffix), nil
 }
+
+// fetchLogs reads the logs of podName, at most limit bytes whatever the server sends,
+// and reports whether they were cut.
+func fetchLogs(ctx context.Context, deps shared.Deps, podName string, opts *corev1.PodLogOptions, limit int64) (string, bool, error) {
+	clientset, _ := deps.PodClients(deps.Namespace)
+	stream, err := clientset.CoreV1().Pods(deps.Namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	defer func() { _ = stream.Close() }()
+	data, more, err := shared.ReadLimited(stream, limit)
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), more, nil
+}
+
+// capLimit returns value, or limit when value is unset or above it.
+func capLimit(value, limit int64) int64 {
+	if value <= 0 || value > limit {
+		return limit
+	}
+	return value
+}
@@ -139,3 +139,26 @@ func TestExecute_MatchMode_AllOf_Explicit(t *testing.T) {
 	assert.False(t, passed)
 	assert.Contains(t, msg, "Missing strings in logs")
 }
+
+func TestExecute_MaxBytesBoundsLogsRead(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	// The fake clientset serves "fake logs"; only its first 4 bytes are read.
+	spec := vtypes.LogSpec{
+		Target:          vtypes.Target{Kind: "Pod", Name: "test-pod"},
+		ExpectedStrings: []string{"fake"},
+		TailLines:       10,
+		MaxBytes:        4,
+	}
+	passed, _, err := executorlog.Execute(context.Background(), spec, deps(fake.NewClientset(pod)))
+	require.NoError(t, err)
+	assert.True(t, passed)
+
+	spec.ExpectedStrings = []string{"logs"}
+	passed, msg, err := executorlog.Execute(context.Background(), spec, deps(fake.NewClientset(pod)))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Contains(t, msg, "Missing strings in logs")
+}
@@ -0,0 +1,104 @@
+// Package metadata implements the "metadata" validation type.
+// It checks the presence, absence and values of labels and annotations on any
+// resource, e.g. prometheus.io/scrape=true on a Service.
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	msgAllMetadataPassed   = "All metadata checks passed"
+	errNoMatchingResources = "No matching resources found"
+	errNoTargetSpecified   = "No target name or labelSelector specified"
+)
+
+func init() {
+	engine.Register(vtypes.TypeMetadata, engine.Typed(Execute))
+}
+
+// Execute checks the labels and annotations of the named resource, or of every
+// resource matching the labelSelector.
+func Execute(ctx context.Context, spec vtypes.MetadataSpec, deps shared.Deps) (bool, string, error) {
+	logger.Debug("Executing metadata validation for %s", spec.Target.Kind)
+
+	gvr, err := shared.GetGVRForKind(spec.Target.Kind)
+	if err != nil {
+		return false, "", err
+	}
+
+	var objects []unstructured.Unstructured
+	switch {
+	case spec.Target.Name != "":
+		obj, getErr := shared.GetObject(ctx, deps, gvr, spec.Target.Name)
+		if apierrors.IsNotFound(getErr) {
+			return false, fmt.Sprintf("%s %s not found", spec.Target.Kind, spec.Target.Name), nil
+		}
+		if getErr != nil {
+			return false, "", fmt.Errorf("failed to get resource: %w", getErr)
+		}
+		objects = []unstructured.Unstructured{*obj}
+	case len(spec.Target.LabelSelector) > 0:
+		objects, err = shared.ListObjects(ctx, deps, gvr, spec.Target.LabelSelector)
+		if err != nil {
+			return false, "", err
+		}
+		if len(objects) == 0 {
+			return false, errNoMatchingResources, nil
+		}
+	default:
+		return false, errNoTargetSpecified, nil
+	}
+
+	var failures []string
+	for i := range objects {
+		problems := append(checkAll("label", objects[i].GetLabels(), spec.Labels),
+			checkAll("annotation", objects[i].GetAnnotations(), spec.Annotations)...)
+		if len(problems) > 0 {
+			failures = append(failures, fmt.Sprintf("%s %s: %s", spec.Target.Kind, objects[i].GetName(), strings.Join(problems, ", ")))
+		}
+	}
+
+	if len(failures) == 0 {
+		return true, msgAllMetadataPassed, nil
+	}
+	return false, strings.Join(failures, "; "), nil
+}
+
+// checkAll applies checks to the labels or annotations of a resource, e.g.
+// `missing annotation prometheus.io/scrape` or `label tier is "db", expected "web"`.
+func checkAll(kind string, values map[string]string, checks []vtypes.MetadataCheck) []string {
+	var problems []string
+	for _, c := range checks {
+		actual, found := values[c.Key]
+		switch {
+		case c.Exists != nil && !*c.Exists:
+			if found {
+				problems = append(problems, fmt.Sprintf("%s %s is set to %q, expected it to be absent", kind, c.Key, actual))
+			}
+		case !found:
+			problems = append(problems, fmt.Sprintf("missing %s %s", kind, c.Key))
+		case c.Value != "" && actual != c.Value:
+			problems = append(problems, fmt.Sprintf("%s %s is %q, expected %q", kind, c.Key, actual, c.Value))
+		case c.Matches != "" && !matches(c.Matches, actual):
+			problems = append(problems, fmt.Sprintf("%s %s is %q, expected to match %q", kind, c.Key, actual, c.Matches))
+		}
+	}
+	return problems
+}
+
+// matches reports whether pattern matches the whole value. Patterns are validated
+// by the loader.
+func matches(pattern, value string) bool {
+	return regexp.MustCompile("^(?:" + pattern + ")$").MatchString(value)
+}
@@ -0,0 +1,112 @@
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/metadata"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func service(name string, labels, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "v1", "kind": "Service"}}
+	obj.SetName(name)
+	obj.SetNamespace("test-ns")
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{DynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...), Namespace: "test-ns"}
+}
+
+func TestExecute_Checks(t *testing.T) {
+	svc := service("web",
+		map[string]string{"app": "web", "tier": "frontend"},
+		map[string]string{"prometheus.io/scrape": "true", "prometheus.io/port": "9090"})
+	tests := []struct {
+		name        string
+		labels      []vtypes.MetadataCheck
+		annotations []vtypes.MetadataCheck
+		message     string
+	}{
+		{
+			name:        "present, value and pattern",
+			labels:      []vtypes.MetadataCheck{{Key: "app"}, {Key: "tier", Matches: "frontend|backend"}},
+			annotations: []vtypes.MetadataCheck{{Key: "prometheus.io/scrape", Value: "true"}, {Key: "debug", Exists: boolPtr(false)}},
+		},
+		{
+			name:        "missing keys",
+			labels:      []vtypes.MetadataCheck{{Key: "team", Exists: boolPtr(true)}},
+			annotations: []vtypes.MetadataCheck{{Key: "prometheus.io/path"}},
+			message:     "Service web: missing label team, missing annotation prometheus.io/path",
+		},
+		{
+			name:        "wrong value",
+			annotations: []vtypes.MetadataCheck{{Key: "prometheus.io/port", Value: "8080"}},
+			message:     `Service web: annotation prometheus.io/port is "9090", expected "8080"`,
+		},
+		{
+			name:    "pattern must match the whole value",
+			labels:  []vtypes.MetadataCheck{{Key: "tier", Matches: "front"}},
+			message: `Service web: label tier is "frontend", expected to match "front"`,
+		},
+		{
+			name:    "forbidden key",
+			labels:  []vtypes.MetadataCheck{{Key: "app", Exists: boolPtr(false)}},
+			message: `Service web: label app is set to "web", expected it to be absent`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := vtypes.MetadataSpec{Target: vtypes.Target{Kind: "Service", Name: "web"}, Labels: tt.labels, Annotations: tt.annotations}
+			passed, msg, err := metadata.Execute(context.Background(), spec, deps(svc))
+			require.NoError(t, err)
+			if tt.message == "" {
+				assert.True(t, passed, msg)
+				assert.Equal(t, "All metadata checks passed", msg)
+				return
+			}
+			assert.False(t, passed)
+			assert.Equal(t, tt.message, msg)
+		})
+	}
+}
+
+func TestExecute_Targets(t *testing.T) {
+	checks := []vtypes.MetadataCheck{{Key: "team", Value: "payments"}}
+	objects := []runtime.Object{
+		service("api", map[string]string{"app": "shop", "team": "payments"}, nil),
+		service("web", map[string]string{"app": "shop"}, nil),
+		service("other", map[string]string{"app": "other"}, nil),
+	}
+
+	// Every resource matching the selector is checked
+	passed, msg, err := metadata.Execute(context.Background(), vtypes.MetadataSpec{Target: vtypes.Target{Kind: "Service", LabelSelector: map[string]string{"app": "shop"}}, Labels: checks}, deps(objects...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Service web: missing label team", msg)
+
+	passed, msg, err = metadata.Execute(context.Background(), vtypes.MetadataSpec{Target: vtypes.Target{Kind: "Service", Name: "api"}, Labels: checks}, deps(objects...))
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+
+	passed, msg, err = metadata.Execute(context.Background(), vtypes.MetadataSpec{Target: vtypes.Target{Kind: "Service", Name: "missing"}, Labels: checks}, deps(objects...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Service missing not found", msg)
+
+	passed, msg, err = metadata.Execute(context.Background(), vtypes.MetadataSpec{Target: vtypes.Target{Kind: "Service", LabelSelector: map[string]string{"app": "none"}}, Labels: checks}, deps(objects...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No matching resources found", msg)
+}
@@ -0,0 +1,352 @@
+// Package networkpolicy implements the "networkPolicy" validation type.
+// It evaluates the NetworkPolicies of the source and destination namespaces the way
+// the Kubernetes NetworkPolicy API defines them, without sending any traffic, so the
+// verdict does not depend on the CNI plugin or on the pods being up.
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func init() {
+	engine.Register(vtypes.TypeNetworkPolicy, engine.Typed(Execute))
+}
+
+// peer is one end of the evaluated connection.
+type peer struct {
+	namespace string
+	nsLabels  labels.Set
+	labels    labels.Set
+}
+
+// connection is the traffic being evaluated. namedPorts maps the container port names
+// of the destination pods to their number, to resolve named ports in policy rules.
+type connection struct {
+	port       int32
+	protocol   corev1.Protocol
+	namedPorts map[string]int32
+}
+
+// verdict is the outcome of one direction (egress of the source, ingress of the destination).
+// ipBlockBy names the selecting policies with an ipBlock rule on the connection's port,
+// which may allow the traffic depending on the pod IPs.
+type verdict struct {
+	isolatedBy []string
+	allowedBy  string
+	ipBlockBy  []string
+}
+
+func (v verdict) allowed() bool {
+	return len(v.isolatedBy) == 0 || v.allowedBy != ""
+}
+
+// inconclusive reports whether only an ipBlock rule could allow the traffic.
+func (v verdict) inconclusive() bool {
+	return !v.allowed() && len(v.ipBlockBy) > 0
+}
+
+// Execute evaluates whether traffic from spec.From to spec.To is allowed and compares
+// the verdict with spec.Expect. When the traffic is not allowed by the pod and namespace
+// rules but an ipBlock rule might allow it, there is no verdict: pod IPs are not part
+// of the model, so a *shared.SkipError is returned.
+func Execute(ctx context.Context, spec vtypes.NetworkPolicySpec, deps shared.Deps) (bool, string, error) {
+	from, err := resolvePeer(ctx, deps, spec.From)
+	if err != nil {
+		return false, "", err
+	}
+	to, err := resolvePeer(ctx, deps, spec.To)
+	if err != nil {
+		return false, "", err
+	}
+	logger.Debug("Executing networkPolicy validation from %s/%s to %s/%s", from.namespace, from.labels, to.namespace, to.labels)
+
+	conn := connection{port: int32(spec.Port), protocol: corev1.Protocol(spec.Protocol)}
+	if conn.namedPorts, err = namedPorts(ctx, deps, to, conn.protocol); err != nil {
+		return false, "", err
+	}
+
+	egressPolicies, err := listPolicies(ctx, deps, from.namespace)
+	if err != nil {
+		return false, "", err
+	}
+	ingressPolicies, err := listPolicies(ctx, deps, to.namespace)
+	if err != nil {
+		return false, "", err
+	}
+	egress := evaluate(egressPolicies, networkingv1.PolicyTypeEgress, from, to, conn)
+	ingress := evaluate(ingressPolicies, networkingv1.PolicyTypeIngress, to, from, conn)
+
+	traffic := fmt.Sprintf("from %s to %s on %s/%d",
+		describePeer(from, deps.Namespace), describePeer(to, deps.Namespace), spec.Protocol, spec.Port)
+	result := vtypes.NetworkPolicyDenied
+	switch {
+	case egress.allowed() && ingress.allowed():
+		result = vtypes.NetworkPolicyAllowed
+	case denied(egress) || denied(ingress):
+		// Denied whatever the pod IPs
+	default:
+		var policies []string
+		for _, v := range []verdict{egress, ingress} {
+			if v.inconclusive() {
+				policies = append(policies, v.ipBlockBy...)
+			}
+		}
+		return false, "", &shared.SkipError{Reason: fmt.Sprintf(
+			"Skipped: traffic %s depends on the ipBlock rules of %s, which are not evaluated: pod IPs are not part of the model",
+			traffic, strings.Join(policies, ", "))}
+	}
+
+	msg := fmt.Sprintf("Traffic %s is %s", traffic, result)
+	if result != spec.Expect {
+		msg = fmt.Sprintf("%s, expected %s", msg, spec.Expect)
+	}
+	msg = fmt.Sprintf("%s (%s)", msg, explain(egress, ingress))
+	return result == spec.Expect, msg, nil
+}
+
+// resolvePeer fills in the namespace of p and reads the labels of that namespace,
+// which namespaceSelectors match against.
+func resolvePeer(ctx context.Context, deps shared.Deps, p vtypes.NetworkPolicyPeer) (peer, error) {
+	resolved := peer{namespace: p.Namespace, labels: labels.Set(p.Labels)}
+	if resolved.namespace == "" {
+		resolved.namespace = deps.Namespace
+	}
+
+	resolved.nsLabels = labels.Set{}
+	ns, err := deps.Clientset.CoreV1().Namespaces().Get(ctx, resolved.namespace, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return peer{}, fmt.Errorf("failed to get namespace %s: %w", resolved.namespace, err)
+	default:
+		for k, v := range ns.Labels {
+			resolved.nsLabels[k] = v
+		}
+	}
+	// Set by the API server on every namespace; fake clients and missing namespaces lack it
+	resolved.nsLabels[corev1.LabelMetadataName] = resolved.namespace
+	return resolved, nil
+}
+
+// namedPorts collects the container ports of the pods matching dst, so rules that
+// refer to a port by name can be resolved. Without such pods named ports never match.
+func namedPorts(ctx context.Context, deps shared.Deps, dst peer, protocol corev1.Protocol) (map[string]int32, error) {
+	ports := map[string]int32{}
+	if len(dst.labels) == 0 {
+		return ports, nil
+	}
+	pods, err := deps.Clientset.CoreV1().Pods(dst.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(dst.labels).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", dst.namespace, err)
+	}
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name != "" && containerPortProtocol(cp) == protocol {
+					ports[cp.Name] = cp.ContainerPort
+				}
+			}
+		}
+	}
+	return ports, nil
+}
+
+func containerPortProtocol(cp corev1.ContainerPort) corev1.Protocol {
+	if cp.Protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return cp.Protocol
+}
+
+func listPolicies(ctx context.Context, deps shared.Deps, namespace string) ([]networkingv1.NetworkPolicy, error) {
+	list, err := deps.Clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies in namespace %s: %w", namespace, err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	return list.Items, nil
+}
+
+// evaluate applies the policies of subject's namespace in direction dir. A pod that no
+// policy of that direction selects is not isolated; an isolated pod only accepts
+// traffic that at least one rule of a selecting policy allows.
+func evaluate(policies []networkingv1.NetworkPolicy, dir networkingv1.PolicyType, subject, other peer, conn connection) verdict {
+	var v verdict
+	for i := range policies {
+		p := &policies[i]
+		if !hasPolicyType(p, dir) || !selectorMatches(&p.Spec.PodSelector, subject.labels) {
+			continue
+		}
+		v.isolatedBy = append(v.isolatedBy, p.Name)
+		if v.allowedBy != "" {
+			continue
+		}
+		var rules []rule
+		if dir == networkingv1.PolicyTypeIngress {
+			for _, r := range p.Spec.Ingress {
+				rules = append(rules, rule{peers: r.From, ports: r.Ports})
+			}
+		} else {
+			for _, r := range p.Spec.Egress {
+				rules = append(rules, rule{peers: r.To, ports: r.Ports})
+			}
+		}
+		for _, r := range rules {
+			if !portsMatch(r.ports, conn) {
+				continue
+			}
+			if peersMatch(r.peers, p.Namespace, other) {
+				v.allowedBy = p.Name
+				break
+			}
+			if hasIPBlock(r.peers) && !slices.Contains(v.ipBlockBy, p.Name) {
+				v.ipBlockBy = append(v.ipBlockBy, p.Name)
+			}
+		}
+	}
+	return v
+}
+
+// rule is an ingress or egress rule: its peers are the sources or destinations.
+type rule struct {
+	peers []networkingv1.NetworkPolicyPeer
+	ports []networkingv1.NetworkPolicyPort
+}
+
+// denied reports whether v denies the traffic whatever the pod IPs.
+func denied(v verdict) bool {
+	return !v.allowed() && !v.inconclusive()
+}
+
+func hasIPBlock(peers []networkingv1.NetworkPolicyPeer) bool {
+	for _, np := range peers {
+		if np.IPBlock != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPolicyType applies the API defaulting: without policyTypes a policy affects
+// ingress, and egress too when it has egress rules.
+func hasPolicyType(p *networkingv1.NetworkPolicy, dir networkingv1.PolicyType) bool {
+	if len(p.Spec.PolicyTypes) == 0 {
+		return dir == networkingv1.PolicyTypeIngress || len(p.Spec.Egress) > 0
+	}
+	for _, t := range p.Spec.PolicyTypes {
+		if t == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// peersMatch reports whether a rule's peers include p. An empty list matches every
+// peer. ipBlock peers never match: pod IPs are not part of the model (see hasIPBlock).
+func peersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, p peer) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, np := range peers {
+		if np.PodSelector == nil && np.NamespaceSelector == nil {
+			continue
+		}
+		if np.NamespaceSelector == nil {
+			if p.namespace != policyNamespace {
+				continue
+			}
+		} else if !selectorMatches(np.NamespaceSelector, p.nsLabels) {
+			continue
+		}
+		if np.PodSelector != nil && !selectorMatches(np.PodSelector, p.labels) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// portsMatch reports whether a rule's ports include the connection. An empty list
+// matches every port.
+func portsMatch(ports []networkingv1.NetworkPolicyPort, conn connection) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, np := range ports {
+		protocol := corev1.ProtocolTCP
+		if np.Protocol != nil {
+			protocol = *np.Protocol
+		}
+		if protocol != conn.protocol {
+			continue
+		}
+		switch {
+		case np.Port == nil:
+			return true
+		case np.Port.Type == intstr.String:
+			if number, ok := conn.namedPorts[np.Port.StrVal]; ok && number == conn.port {
+				return true
+			}
+		case np.EndPort != nil:
+			if conn.port >= np.Port.IntVal && conn.port <= *np.EndPort {
+				return true
+			}
+		case np.Port.IntVal == conn.port:
+			return true
+		}
+	}
+	return false
+}
+
+func selectorMatches(selector *metav1.LabelSelector, set labels.Set) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		logger.Debug("Ignoring invalid label selector %v: %v", selector, err)
+		return false
+	}
+	return s.Matches(set)
+}
+
+func describePeer(p peer, defaultNamespace string) string {
+	desc := "pods without labels"
+	if len(p.labels) > 0 {
+		desc = p.labels.String()
+	}
+	if p.namespace != defaultNamespace {
+		desc = fmt.Sprintf("%s in namespace %s", desc, p.namespace)
+	}
+	return desc
+}
+
+// explain summarises both directions, e.g. "egress not restricted; ingress isolated
+// by default-deny with no rule allowing it".
+func explain(egress, ingress verdict) string {
+	describe := func(dir string, v verdict) string {
+		switch {
+		case len(v.isolatedBy) == 0:
+			return dir + " not restricted"
+		case v.allowedBy != "":
+			return fmt.Sprintf("%s allowed by %s", dir, v.allowedBy)
+		default:
+			return fmt.Sprintf("%s isolated by %s with no rule allowing it", dir, strings.Join(v.isolatedBy, ", "))
+		}
+	}
+	return describe("egress", egress) + "; " + describe("ingress", ingress)
+}
@@ -0,0 +1,259 @@
+package networkpolicy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/networkpolicy"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func policy(name, namespace string, selector map[string]string, spec networkingv1.NetworkPolicySpec) *networkingv1.NetworkPolicy {
+	spec.PodSelector = metav1.LabelSelector{MatchLabels: selector}
+	return &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+}
+
+func defaultDenyIngress() *networkingv1.NetworkPolicy {
+	return policy("default-deny", "test-ns", nil, networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	})
+}
+
+func allowIngress(name string, from networkingv1.NetworkPolicyPeer, ports ...networkingv1.NetworkPolicyPort) *networkingv1.NetworkPolicy {
+	return policy(name, "test-ns", map[string]string{"app": "backend"}, networkingv1.NetworkPolicySpec{
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{From: []networkingv1.NetworkPolicyPeer{from}, Ports: ports}},
+	})
+}
+
+func podPeer(labels map[string]string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: labels}}
+}
+
+func tcpPort(port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{Port: &port}
+}
+
+func frontendToBackend(expect string) vtypes.NetworkPolicySpec {
+	return vtypes.NetworkPolicySpec{
+		From:     vtypes.NetworkPolicyPeer{Labels: map[string]string{"app": "frontend"}},
+		To:       vtypes.NetworkPolicyPeer{Labels: map[string]string{"app": "backend"}},
+		Port:     8080,
+		Protocol: "TCP",
+		Expect:   expect,
+	}
+}
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+}
+
+func TestExecute_NoPolicies(t *testing.T) {
+	passed, msg, err := networkpolicy.Execute(context.Background(), frontendToBackend(vtypes.NetworkPolicyAllowed), deps())
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Equal(t, "Traffic from app=frontend to app=backend on TCP/8080 is allowed (egress not restricted; ingress not restricted)", msg)
+}
+
+func TestExecute_DefaultDeny(t *testing.T) {
+	passed, msg, err := networkpolicy.Execute(context.Background(), frontendToBackend(vtypes.NetworkPolicyAllowed), deps(defaultDenyIngress()))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Traffic from app=frontend to app=backend on TCP/8080 is denied, expected allowed (egress not restricted; ingress isolated by default-deny with no rule allowing it)", msg)
+
+	passed, _, err = networkpolicy.Execute(context.Background(), frontendToBackend(vtypes.NetworkPolicyDenied), deps(defaultDenyIngress()))
+	require.NoError(t, err)
+	assert.True(t, passed)
+}
+
+func TestExecute_IngressRules(t *testing.T) {
+	udp := corev1.ProtocolUDP
+	endPort := int32(8090)
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		allowed bool
+	}{
+		{
+			name:    "pod selector and port",
+			objects: []runtime.Object{defaultDenyIngress(), allowIngress("allow-frontend", podPeer(map[string]string{"app": "frontend"}), tcpPort(intstr.FromInt32(8080)))},
+			allowed: true,
+		},
+		{
+			name:    "other pod",
+			objects: []runtime.Object{defaultDenyIngress(), allowIngress("allow-admin", podPeer(map[string]string{"app": "admin"}))},
+		},
+		{
+			name:    "other port",
+			objects: []runtime.Object{defaultDenyIngress(), allowIngress("allow-frontend", podPeer(map[string]string{"app": "frontend"}), tcpPort(intstr.FromInt32(9090)))},
+		},
+		{
+			name: "other protocol",
+			objects: []runtime.Object{defaultDenyIngress(), allowIngress("allow-frontend", podPeer(map[string]string{"app": "frontend"}),
+				networkingv1.NetworkPolicyPort{Protocol: &udp, Port: &intstr.IntOrString{IntVal: 8080}})},
+		},
+		{
+			name: "port range",
+			objects: []runtime.Object{allowIngress("allow-range", podPeer(map[string]string{"app": "frontend"}),
+				networkingv1.NetworkPolicyPort{Port: &intstr.IntOrString{IntVal: 8000}, EndPort: &endPort})},
+			allowed: true,
+		},
+		{
+			name: "named port on destination pod",
+			objects: []runtime.Object{
+				allowIngress("allow-http", podPeer(map[string]string{"app": "frontend"}), tcpPort(intstr.FromString("http"))),
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "backend-0", Namespace: "test-ns", Labels: map[string]string{"app": "backend"}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}}}},
+				},
+			},
+			allowed: true,
+		},
+		{
+			name:    "named port without pods",
+			objects: []runtime.Object{allowIngress("allow-http", podPeer(map[string]string{"app": "frontend"}), tcpPort(intstr.FromString("http")))},
+		},
+		{
+			name:    "empty rule allows everything",
+			objects: []runtime.Object{defaultDenyIngress(), policy("allow-all", "test-ns", nil, networkingv1.NetworkPolicySpec{Ingress: []networkingv1.NetworkPolicyIngressRule{{}}})},
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expect := vtypes.NetworkPolicyDenied
+			if tt.allowed {
+				expect = vtypes.NetworkPolicyAllowed
+			}
+			passed, msg, err := networkpolicy.Execute(context.Background(), frontendToBackend(expect), deps(tt.objects...))
+			require.NoError(t, err)
+			assert.True(t, passed, msg)
+		})
+	}
+}
+
+func TestExecute_IPBlock(t *testing.T) {
+	cidr := networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}
+	denyEgress := policy("deny-egress", "test-ns", map[string]string{"app": "frontend"}, networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+	})
+	execute := func(objects ...runtime.Object) (bool, string, error) {
+		deps := shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+		return networkpolicy.Execute(context.Background(), frontendToBackend(vtypes.NetworkPolicyDenied), deps)
+	}
+
+	// Only an ipBlock rule could allow the traffic: no verdict
+	_, _, err := execute(allowIngress("allow-cidr", cidr))
+	var skip *shared.SkipError
+	require.ErrorAs(t, err, &skip)
+	assert.Equal(t, "Skipped: traffic from app=frontend to app=backend on TCP/8080 depends on the ipBlock rules of allow-cidr, which are not evaluated: pod IPs are not part of the model", skip.Reason)
+
+	// An ipBlock rule on another port cannot allow it
+	passed, msg, err := execute(allowIngress("allow-cidr", cidr, tcpPort(intstr.FromInt32(9090))))
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+
+	// Pod rules decide before ipBlock rules
+	passed, msg, err = execute(allowIngress("allow-frontend", podPeer(map[string]string{"app": "frontend"})), allowIngress("allow-cidr", cidr))
+	require.NoError(t, err)
+	assert.False(t, passed, "the traffic is allowed by the pod selector: %s", msg)
+
+	// The other direction denies it whatever the pod IPs
+	passed, msg, err = execute(denyEgress, allowIngress("allow-cidr", cidr))
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+}
+
+func TestExecute_Egress(t *testing.T) {
+	denyEgress := policy("deny-egress", "test-ns", map[string]string{"app": "frontend"}, networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+	})
+	// No policyTypes: egress is inferred from the egress rules
+	allowEgress := policy("allow-backend", "test-ns", map[string]string{"app": "frontend"}, networkingv1.NetworkPolicySpec{
+		Egress: []networkingv1.NetworkPolicyEgressRule{{To: []networkingv1.NetworkPolicyPeer{podPeer(map[string]string{"app": "backend"})}}},
+	})
+
+	passed, msg, err := networkpolicy.Execute(context.Background(), frontendToBackend(vtypes.NetworkPolicyDenied), deps(denyEgress))
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Contains(t, msg, "egress isolated by deny-egress with no rule allowing it")
+
+	passed, msg, err = networkpolicy.Execute(context.Background(), frontendToBackend(vtypes.NetworkPolicyAllowed), deps(denyEgress, allowEgress))
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Contains(t, msg, "egress allowed by allow-backend")
+}
+
+func TestExecute_CrossNamespace(t *testing.T) {
+	monitoring := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: map[string]string{"team": "observability"}}}
+	spec := vtypes.NetworkPolicySpec{
+		From:     vtypes.NetworkPolicyPeer{Namespace: "monitoring", Labels: map[string]string{"app": "prometheus"}},
+		To:       vtypes.NetworkPolicyPeer{Labels: map[string]string{"app": "backend"}},
+		Port:     8080,
+		Protocol: "TCP",
+		Expect:   vtypes.NetworkPolicyAllowed,
+	}
+
+	// A pod selector alone only matches pods in the policy's namespace
+	samePodOtherNamespace := allowIngress("allow-prometheus", podPeer(map[string]string{"app": "prometheus"}))
+	passed, msg, err := networkpolicy.Execute(context.Background(), spec, deps(monitoring, samePodOtherNamespace))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Traffic from app=prometheus in namespace monitoring to app=backend on TCP/8080 is denied, expected allowed (egress not restricted; ingress isolated by allow-prometheus with no rule allowing it)", msg)
+
+	byTeam := allowIngress("allow-observability", networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "observability"}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+	})
+	passed, _, err = networkpolicy.Execute(context.Background(), spec, deps(monitoring, byTeam))
+	require.NoError(t, err)
+	assert.True(t, passed)
+
+	// kubernetes.io/metadata.name is always set, even when the namespace object is missing
+	byName := allowIngress("allow-monitoring", networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "monitoring"}},
+	})
+	passed, _, err = networkpolicy.Execute(context.Background(), spec, deps(byName))
+	require.NoError(t, err)
+	assert.True(t, passed)
+}
+
+// TestExecute_IsolationBaselineExemptsProbePod checks the kubeasy baseline against the
+// traffic of the probe pod, which runs promMetrics scrapes and connectivity checks.
+func TestExecute_IsolationBaselineExemptsProbePod(t *testing.T) {
+	for _, mode := range []kube.NetworkIsolation{kube.NetworkIsolationNamespace, kube.NetworkIsolationStrict} {
+		t.Run(string(mode), func(t *testing.T) {
+			clientset := fake.NewClientset()
+			require.NoError(t, kube.EnsureNetworkIsolation(context.Background(), clientset, "test-ns", mode))
+			deps := shared.Deps{Clientset: clientset, Namespace: "test-ns"}
+
+			toOtherNamespace := func(from map[string]string, expect string) vtypes.NetworkPolicySpec {
+				return vtypes.NetworkPolicySpec{
+					From:     vtypes.NetworkPolicyPeer{Labels: from},
+					To:       vtypes.NetworkPolicyPeer{Namespace: "other", Labels: map[string]string{"app": "api"}},
+					Port:     443,
+					Protocol: "TCP",
+					Expect:   expect,
+				}
+			}
+
+			probe := map[string]string{kube.ProbePodLabelKey: kube.ProbePodLabelValue}
+			passed, msg, err := networkpolicy.Execute(context.Background(), toOtherNamespace(probe, vtypes.NetworkPolicyAllowed), deps)
+			require.NoError(t, err)
+			assert.True(t, passed, msg)
+
+			passed, msg, err = networkpolicy.Execute(context.Background(), toOtherNamespace(map[string]string{"app": "web"}, vtypes.NetworkPolicyDenied), deps)
+			require.NoError(t, err)
+			assert.True(t, passed, "challenge pods stay isolated: %s", msg)
+		})
+	}
+}
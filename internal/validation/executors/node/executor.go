@@ -0,0 +1,225 @@
+// Package node implements the "node" validation type.
+// It checks the labels, taints, conditions and allocatable resources of nodes, and
+// where the pods of a target were scheduled, for affinity and taint/toleration challenges.
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	msgAllNodeChecksPassed = "All node checks passed"
+	errNoMatchingPods      = "No matching pods found"
+
+	// maxSchedulerMessageLength caps the scheduler message quoted for unscheduled pods.
+	maxSchedulerMessageLength = 200
+)
+
+func init() {
+	engine.Register(vtypes.TypeNode, engine.Typed(Execute))
+}
+
+// Execute runs the node checks and the pod placement checks of spec.
+func Execute(ctx context.Context, spec vtypes.NodeSpec, deps shared.Deps) (bool, string, error) {
+	logger.Debug("Executing node validation")
+
+	var failures []string
+	if hasNodeChecks(spec) {
+		msgs, err := checkNodes(ctx, deps, spec)
+		if err != nil {
+			return false, "", err
+		}
+		failures = append(failures, msgs...)
+	}
+	if spec.Target != nil {
+		msgs, err := checkPlacement(ctx, deps, spec)
+		if err != nil {
+			return false, "", err
+		}
+		failures = append(failures, msgs...)
+	}
+
+	if len(failures) == 0 {
+		return true, msgAllNodeChecksPassed, nil
+	}
+	return false, strings.Join(failures, "; "), nil
+}
+
+func hasNodeChecks(spec vtypes.NodeSpec) bool {
+	return len(spec.Labels) > 0 || len(spec.Taints) > 0 || len(spec.Conditions) > 0 || len(spec.MinAllocatable) > 0
+}
+
+// checkNodes checks every node matching spec.NodeSelector.
+func checkNodes(ctx context.Context, deps shared.Deps, spec vtypes.NodeSpec) ([]string, error) {
+	opts := metav1.ListOptions{}
+	if len(spec.NodeSelector) > 0 {
+		opts.LabelSelector = labels.SelectorFromSet(spec.NodeSelector).String()
+	}
+	nodes, err := deps.Clientset.CoreV1().Nodes().List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	if len(nodes.Items) == 0 {
+		if len(spec.NodeSelector) > 0 {
+			return []string{fmt.Sprintf("No nodes match %s", labels.Set(spec.NodeSelector))}, nil
+		}
+		return []string{"No nodes found"}, nil
+	}
+
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+	var failures []string
+	for i := range nodes.Items {
+		if problems := nodeProblems(&nodes.Items[i], spec); len(problems) > 0 {
+			failures = append(failures, fmt.Sprintf("node %s: %s", nodes.Items[i].Name, strings.Join(problems, ", ")))
+		}
+	}
+	return failures, nil
+}
+
+func nodeProblems(node *corev1.Node, spec vtypes.NodeSpec) []string {
+	var problems []string
+
+	for _, k := range sortedKeys(spec.Labels) {
+		actual, ok := node.Labels[k]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("missing label %s=%s", k, spec.Labels[k]))
+		case actual != spec.Labels[k]:
+			problems = append(problems, fmt.Sprintf("label %s is %q, expected %q", k, actual, spec.Labels[k]))
+		}
+	}
+
+	for _, want := range spec.Taints {
+		if !hasTaint(node.Spec.Taints, want) {
+			problems = append(problems, "missing taint "+describeTaint(want))
+		}
+	}
+
+	for _, want := range spec.Conditions {
+		problems = append(problems, conditionProblem(node, want)...)
+	}
+
+	for _, name := range sortedKeys(spec.MinAllocatable) {
+		// Validated by the loader
+		minimum := resource.MustParse(spec.MinAllocatable[name])
+		actual, ok := node.Status.Allocatable[corev1.ResourceName(name)]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("no allocatable %s", name))
+		case actual.Cmp(minimum) < 0:
+			problems = append(problems, fmt.Sprintf("allocatable %s is %s, expected at least %s", name, actual.String(), minimum.String()))
+		}
+	}
+	return problems
+}
+
+func hasTaint(taints []corev1.Taint, want vtypes.NodeTaint) bool {
+	for _, t := range taints {
+		if t.Key == want.Key &&
+			(want.Value == "" || t.Value == want.Value) &&
+			(want.Effect == "" || string(t.Effect) == want.Effect) {
+			return true
+		}
+	}
+	return false
+}
+
+func conditionProblem(node *corev1.Node, want vtypes.ConditionCheck) []string {
+	for _, c := range node.Status.Conditions {
+		if string(c.Type) == want.Type {
+			if string(c.Status) != want.Status {
+				return []string{fmt.Sprintf("condition %s is %s, expected %s", want.Type, c.Status, want.Status)}
+			}
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("condition %s not found", want.Type)}
+}
+
+// checkPlacement checks that every pod of spec.Target is scheduled, on a node with the
+// spec.ScheduledOn labels when they are set.
+func checkPlacement(ctx context.Context, deps shared.Deps, spec vtypes.NodeSpec) ([]string, error) {
+	pods, err := shared.GetTargetPods(ctx, deps, *spec.Target)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return []string{errNoMatchingPods}, nil
+	}
+
+	nodeLabels := map[string]labels.Set{}
+	var failures []string
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" {
+			failures = append(failures, unscheduledMessage(pod))
+			continue
+		}
+		if len(spec.ScheduledOn) == 0 {
+			continue
+		}
+
+		nl, ok := nodeLabels[pod.Spec.NodeName]
+		if !ok {
+			node, err := deps.Clientset.CoreV1().Nodes().Get(ctx, pod.Spec.NodeName, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+			}
+			nl = labels.Set(node.Labels)
+			nodeLabels[pod.Spec.NodeName] = nl
+		}
+		if !labels.SelectorFromSet(spec.ScheduledOn).Matches(nl) {
+			failures = append(failures, fmt.Sprintf("pod %s runs on node %s, which does not match %s",
+				pod.Name, pod.Spec.NodeName, labels.Set(spec.ScheduledOn)))
+		}
+	}
+	return failures, nil
+}
+
+// unscheduledMessage reports a pending pod with the scheduler's explanation, e.g.
+// "0/2 nodes are available: 2 node(s) had untolerated taint {gpu: true}".
+func unscheduledMessage(pod *corev1.Pod) string {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Message != "" {
+			msg := c.Message
+			if len(msg) > maxSchedulerMessageLength {
+				msg = msg[:maxSchedulerMessageLength] + "..."
+			}
+			return fmt.Sprintf("pod %s is not scheduled: %s", pod.Name, msg)
+		}
+	}
+	return fmt.Sprintf("pod %s is not scheduled", pod.Name)
+}
+
+// describeTaint renders a taint as kubectl does, e.g. dedicated=gpu:NoSchedule.
+func describeTaint(t vtypes.NodeTaint) string {
+	s := t.Key
+	if t.Value != "" {
+		s += "=" + t.Value
+	}
+	if t.Effect != "" {
+		s += ":" + t.Effect
+	}
+	return s
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/node"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testNode(name string, nodeLabels map[string]string, taints ...corev1.Taint) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+	}
+}
+
+func testPod(name, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "gpu-job"}},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+}
+
+func TestExecute_NodeChecks(t *testing.T) {
+	gpuTaint := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+	objects := []runtime.Object{
+		testNode("control-plane", map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+		testNode("worker", map[string]string{"accelerator": "gpu"}, gpuTaint),
+	}
+	workers := map[string]string{"accelerator": "gpu"}
+
+	tests := []struct {
+		name    string
+		spec    vtypes.NodeSpec
+		passed  bool
+		message string
+	}{
+		{
+			name:   "taint on selected node",
+			spec:   vtypes.NodeSpec{NodeSelector: workers, Taints: []vtypes.NodeTaint{{Key: "dedicated", Effect: "NoSchedule"}}},
+			passed: true,
+		},
+		{
+			name:    "taint missing on every node",
+			spec:    vtypes.NodeSpec{Taints: []vtypes.NodeTaint{{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"}}},
+			message: "node control-plane: missing taint dedicated=gpu:NoSchedule",
+		},
+		{
+			name:    "labels",
+			spec:    vtypes.NodeSpec{NodeSelector: workers, Labels: map[string]string{"accelerator": "tpu", "zone": "a"}},
+			message: `node worker: label accelerator is "gpu", expected "tpu", missing label zone=a`,
+		},
+		{
+			name:   "conditions and allocatable",
+			spec:   vtypes.NodeSpec{Conditions: []vtypes.ConditionCheck{{Type: "Ready", Status: "True"}}, MinAllocatable: map[string]string{"cpu": "1500m"}},
+			passed: true,
+		},
+		{
+			name:    "allocatable too low",
+			spec:    vtypes.NodeSpec{NodeSelector: workers, MinAllocatable: map[string]string{"memory": "8Gi", "nvidia.com/gpu": "1"}},
+			message: "node worker: allocatable memory is 4Gi, expected at least 8Gi, no allocatable nvidia.com/gpu",
+		},
+		{
+			name:    "no matching nodes",
+			spec:    vtypes.NodeSpec{NodeSelector: map[string]string{"zone": "b"}, Labels: map[string]string{"x": "y"}},
+			message: "No nodes match zone=b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, msg, err := node.Execute(context.Background(), tt.spec, deps(objects...))
+			require.NoError(t, err)
+			assert.Equal(t, tt.passed, passed)
+			if tt.passed {
+				assert.Equal(t, "All node checks passed", msg)
+			} else {
+				assert.Equal(t, tt.message, msg)
+			}
+		})
+	}
+}
+
+func TestExecute_Placement(t *testing.T) {
+	nodes := []runtime.Object{
+		testNode("control-plane", map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+		testNode("worker", map[string]string{"accelerator": "gpu"}),
+	}
+	spec := vtypes.NodeSpec{
+		Target:      &vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "gpu-job"}},
+		ScheduledOn: map[string]string{"accelerator": "gpu"},
+	}
+
+	passed, msg, err := node.Execute(context.Background(), spec, deps(append(nodes, testPod("gpu-job-a", "worker"))...))
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+
+	passed, msg, err = node.Execute(context.Background(), spec, deps(append(nodes, testPod("gpu-job-a", "worker"), testPod("gpu-job-b", "control-plane"))...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "pod gpu-job-b runs on node control-plane, which does not match accelerator=gpu", msg)
+
+	pending := testPod("gpu-job-c", "")
+	pending.Status.Conditions = []corev1.PodCondition{{
+		Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable",
+		Message: "0/2 nodes are available: 1 node(s) had untolerated taint {dedicated: gpu}.",
+	}}
+	passed, msg, err = node.Execute(context.Background(), spec, deps(append(nodes, pending)...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "pod gpu-job-c is not scheduled: 0/2 nodes are available: 1 node(s) had untolerated taint {dedicated: gpu}.", msg)
+
+	passed, msg, err = node.Execute(context.Background(), spec, deps(nodes...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No matching pods found", msg)
+}
+
+func TestExecute_ScheduledWithoutNodeLabels(t *testing.T) {
+	spec := vtypes.NodeSpec{Target: &vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "gpu-job"}}}
+
+	passed, _, err := node.Execute(context.Background(), spec, deps(testPod("gpu-job-a", "worker")))
+	require.NoError(t, err)
+	assert.True(t, passed)
+
+	passed, msg, err := node.Execute(context.Background(), spec, deps(testPod("gpu-job-a", "")))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "pod gpu-job-a is not scheduled", msg)
+}
@@ -0,0 +1,187 @@
+// Package plugin implements the "plugin" validation type.
+// It runs an external validator binary, kubeasy-validator-<name>, found on PATH.
+//
+// Protocol: the plugin receives a JSON Request on stdin, a kubeconfig for the
+// challenge cluster in $KUBECONFIG and the challenge namespace in $KUBEASY_NAMESPACE.
+// The kubeconfig authenticates as the validator ServiceAccount of the namespace (see
+// kube.EnsureValidatorSandbox), never with the user's credentials: plugins are
+// third-party binaries. For the same reason the rest of the user's environment is not
+// passed on: only PATH and a private TMPDIR are set besides these two.
+// It must print a JSON Response on stdout and exit 0; a non-zero exit fails the
+// objective with its stderr as message.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// BinaryPrefix is prepended to the plugin name to find its binary on PATH.
+const BinaryPrefix = "kubeasy-validator-"
+
+// APIVersion identifies the version of the plugin protocol sent in each Request.
+const APIVersion = "kubeasy.dev/validator/v1"
+
+// maxMessageLength caps the plugin output echoed back in a result message.
+const maxMessageLength = 500
+
+// Request is written as JSON to the plugin's stdin.
+type Request struct {
+	APIVersion string                 `json:"apiVersion"`
+	Namespace  string                 `json:"namespace"`
+	Config     map[string]interface{} `json:"config,omitempty"`
+}
+
+// Response is read as JSON from the plugin's stdout.
+type Response struct {
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+func init() {
+	engine.Register(vtypes.TypePlugin, engine.Typed(Execute))
+}
+
+// Execute runs the plugin binary and returns its verdict.
+func Execute(ctx context.Context, spec vtypes.PluginSpec, deps shared.Deps) (bool, string, error) {
+	binary := BinaryPrefix + spec.Name
+	logger.Debug("Executing plugin validation %s", binary)
+
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return false, fmt.Sprintf("Validator plugin %q not found: install %s on your PATH", spec.Name, binary), nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kubeasy-plugin-")
+	if err != nil {
+		return false, "", fmt.Errorf("failed to create plugin work dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	restConfig, err := pluginRestConfig(ctx, deps)
+	if apierrors.IsNotFound(err) {
+		return false, fmt.Sprintf("Validator plugin %q needs the %s ServiceAccount, which is created when the challenge starts: reset and start the challenge again", spec.Name, kube.ValidatorServiceAccountName), nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+	kubeconfig := filepath.Join(tmpDir, "kubeconfig")
+	if err := writeKubeconfig(restConfig, deps.Namespace, kubeconfig); err != nil {
+		return false, "", err
+	}
+
+	input, err := json.Marshal(Request{APIVersion: APIVersion, Namespace: deps.Namespace, Config: spec.Config})
+	if err != nil {
+		return false, "", fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	timeout := time.Duration(spec.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, path) //nolint:gosec // binary name restricted to kubeasy-validator-<name> by the loader
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = pluginEnv(kubeconfig, deps.Namespace, tmpDir)
+
+	runErr := cmd.Run()
+	switch {
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		return false, fmt.Sprintf("Validator plugin %q timed out after %s", spec.Name, timeout), nil
+	case ctx.Err() != nil:
+		return false, "", ctx.Err()
+	case runErr != nil:
+		detail := truncate(strings.TrimSpace(stderr.String()))
+		if detail == "" {
+			detail = runErr.Error()
+		}
+		return false, fmt.Sprintf("Validator plugin %q failed: %s", spec.Name, detail), nil
+	}
+
+	var resp Response
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &resp); err != nil {
+		return false, fmt.Sprintf("Validator plugin %q returned invalid output: %s", spec.Name, truncate(strings.TrimSpace(stdout.String()))), nil
+	}
+	return resp.Passed, resp.Message, nil
+}
+
+// pluginRestConfig returns the credentials of the validator ServiceAccount of the
+// challenge namespace: the sandbox of the executor when enabled, a new token otherwise.
+func pluginRestConfig(ctx context.Context, deps shared.Deps) (*rest.Config, error) {
+	if deps.Sandbox != nil {
+		return deps.Sandbox.RestConfig, nil
+	}
+	if deps.Clientset == nil || deps.RestConfig == nil {
+		return nil, fmt.Errorf("no cluster connection available for plugin")
+	}
+	return kube.ValidatorRestConfig(ctx, deps.Clientset, deps.RestConfig, deps.Namespace, kube.DefaultValidatorTokenTTL)
+}
+
+// pluginEnv returns the environment of a plugin process. It is built from scratch so
+// that the user's tokens and API keys (e.g. keystore.TokenEnvVarName) never reach it.
+func pluginEnv(kubeconfig, namespace, tmpDir string) []string {
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"TMPDIR=" + tmpDir,
+		"KUBECONFIG=" + kubeconfig,
+		"KUBEASY_NAMESPACE=" + namespace,
+	}
+	if runtime.GOOS == "windows" {
+		// Windows programs cannot start without it
+		env = append(env, "SYSTEMROOT="+os.Getenv("SYSTEMROOT"))
+	}
+	return env
+}
+
+// writeKubeconfig writes a standalone kubeconfig for the server of restConfig that
+// authenticates with its bearer token only: client certificates and other
+// credentials are not copied.
+func writeKubeconfig(restConfig *rest.Config, namespace, path string) error {
+	const name = "kubeasy"
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   restConfig.Host,
+		CertificateAuthority:     restConfig.CAFile,
+		CertificateAuthorityData: restConfig.CAData,
+		InsecureSkipTLSVerify:    restConfig.Insecure,
+	}
+	cfg.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: restConfig.BearerToken}
+	cfg.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name, Namespace: namespace}
+	cfg.CurrentContext = name
+
+	if err := clientcmd.WriteToFile(*cfg, path); err != nil {
+		return fmt.Errorf("failed to write plugin kubeconfig: %w", err)
+	}
+	return nil
+}
+
+func truncate(s string) string {
+	if len(s) > maxMessageLength {
+		return s[:maxMessageLength] + "..."
+	}
+	return s
+}
@@ -0,0 +1,150 @@
+package plugin_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/keystore"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/plugin"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// installPlugin writes a shell script named kubeasy-validator-<name> and puts it on PATH.
+func installPlugin(t *testing.T, name, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on Windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, plugin.BinaryPrefix+name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755)) //nolint:gosec // test executable
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// testDeps returns admin credentials and a clientset issuing "validator-token" for
+// the validator ServiceAccount of test-ns.
+func testDeps() shared.Deps {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "validator-token"}}, nil
+	})
+	return shared.Deps{
+		Clientset: clientset,
+		RestConfig: &rest.Config{
+			Host:            "https://127.0.0.1:6443",
+			BearerToken:     "admin-token",
+			TLSClientConfig: rest.TLSClientConfig{CertData: []byte("admin-cert"), KeyData: []byte("admin-key")},
+		},
+		Namespace: "test-ns",
+	}
+}
+
+func TestExecute_PassesRequestAndKubeconfig(t *testing.T) {
+	// Echo back the namespace env var, the request and whether the kubeconfig has the server.
+	installPlugin(t, "echo", `req=$(cat)
+case "$req" in *'"apiVersion":"kubeasy.dev/validator/v1"'*'"minDays":30'*) ;; *) echo "bad request: $req" >&2; exit 1;; esac
+grep -q "server: https://127.0.0.1:6443" "$KUBECONFIG" || { echo "bad kubeconfig" >&2; exit 1; }
+printf '{"passed": true, "message": "ok in %s"}' "$KUBEASY_NAMESPACE"
+`)
+
+	spec := vtypes.PluginSpec{Name: "echo", Config: map[string]interface{}{"minDays": 30}, TimeoutSeconds: 10}
+	passed, msg, err := plugin.Execute(context.Background(), spec, testDeps())
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+	assert.Equal(t, "ok in test-ns", msg)
+}
+
+func TestExecute_KubeconfigUsesValidatorServiceAccount(t *testing.T) {
+	// Print the kubeconfig the plugin received as its message
+	installPlugin(t, "creds", `cat > /dev/null
+printf '{"passed": true, "message": "%s"}' "$(tr '\n' ' ' < "$KUBECONFIG")"
+`)
+
+	passed, msg, err := plugin.Execute(context.Background(), vtypes.PluginSpec{Name: "creds", TimeoutSeconds: 10}, testDeps())
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+	assert.Contains(t, msg, "token: validator-token")
+	assert.NotContains(t, msg, "admin-token")
+	assert.NotContains(t, msg, "client-certificate-data")
+	assert.NotContains(t, msg, "client-key-data")
+}
+
+func TestExecute_DoesNotPassUserEnvironment(t *testing.T) {
+	t.Setenv(keystore.TokenEnvVarName, "user-api-token")
+	t.Setenv("OPENAI_API_KEY", "user-api-key")
+	// Print the environment the plugin received as its message
+	installPlugin(t, "env", `cat > /dev/null
+printf '{"passed": true, "message": "%s"}' "$(env | tr '\n' ' ')"
+`)
+
+	passed, msg, err := plugin.Execute(context.Background(), vtypes.PluginSpec{Name: "env", TimeoutSeconds: 10}, testDeps())
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+	assert.Contains(t, msg, "KUBEASY_NAMESPACE=test-ns")
+	assert.Contains(t, msg, "KUBECONFIG=")
+	assert.NotContains(t, msg, "user-api-token")
+	assert.NotContains(t, msg, "user-api-key")
+	assert.NotContains(t, msg, "HOME=")
+}
+
+func TestExecute_WithoutValidatorServiceAccount(t *testing.T) {
+	installPlugin(t, "creds", `echo '{"passed": true}'`)
+	deps := testDeps()
+	deps.Clientset = fake.NewClientset()
+	deps.Clientset.(*fake.Clientset).PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, nil, apierrors.NewNotFound(corev1.Resource("serviceaccounts"), kube.ValidatorServiceAccountName)
+	})
+
+	passed, msg, err := plugin.Execute(context.Background(), vtypes.PluginSpec{Name: "creds", TimeoutSeconds: 10}, deps)
+	require.NoError(t, err)
+	assert.False(t, passed, "the plugin must not fall back to the admin credentials")
+	assert.Contains(t, msg, kube.ValidatorServiceAccountName)
+}
+
+func TestExecute_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		timeout int
+		wantMsg string
+	}{
+		{"verdict false", `echo '{"passed": false, "message": "certificate expires in 3 days"}'`, 10, "certificate expires in 3 days"},
+		{"non-zero exit", "echo 'boom' >&2; exit 3", 10, `Validator plugin "fail" failed: boom`},
+		{"invalid output", "echo 'not json'", 10, "returned invalid output: not json"},
+		{"timeout", "sleep 5", 1, "timed out after 1s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installPlugin(t, "fail", tt.script)
+			passed, msg, err := plugin.Execute(context.Background(), vtypes.PluginSpec{Name: "fail", TimeoutSeconds: tt.timeout}, testDeps())
+			require.NoError(t, err)
+			assert.False(t, passed)
+			assert.Contains(t, msg, tt.wantMsg)
+		})
+	}
+}
+
+func TestExecute_PluginNotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	passed, msg, err := plugin.Execute(context.Background(), vtypes.PluginSpec{Name: "missing"}, testDeps())
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Contains(t, msg, "kubeasy-validator-missing")
+}
@@ -0,0 +1,142 @@
+// Package poddisruptionbudget implements the "podDisruptionBudget" validation type.
+// It finds the PodDisruptionBudget of a workload and checks its budget and how many
+// voluntary disruptions it currently allows.
+package poddisruptionbudget
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const errNoMatchingPods = "No matching pods found"
+
+func init() {
+	engine.Register(vtypes.TypePodDisruptionBudget, engine.Typed(Execute))
+}
+
+// Execute finds the budget of spec and compares it with the expectations.
+func Execute(ctx context.Context, spec vtypes.PodDisruptionBudgetSpec, deps shared.Deps) (bool, string, error) {
+	pdb, msg, err := findBudget(ctx, deps, spec)
+	if err != nil || pdb == nil {
+		return false, msg, err
+	}
+	logger.Debug("Executing podDisruptionBudget validation for %s", pdb.Name)
+
+	var failures []string
+	if spec.MinAvailable != "" {
+		if problem := budgetProblem("minAvailable", pdb.Spec.MinAvailable, spec.MinAvailable, "maxUnavailable", pdb.Spec.MaxUnavailable); problem != "" {
+			failures = append(failures, problem)
+		}
+	}
+	if spec.MaxUnavailable != "" {
+		if problem := budgetProblem("maxUnavailable", pdb.Spec.MaxUnavailable, spec.MaxUnavailable, "minAvailable", pdb.Spec.MinAvailable); problem != "" {
+			failures = append(failures, problem)
+		}
+	}
+
+	if spec.DisruptionsAllowed != nil {
+		// disruptionsAllowed is stale until the disruption controller has seen the latest spec
+		if pdb.Status.ObservedGeneration < pdb.Generation {
+			return false, fmt.Sprintf("PodDisruptionBudget %s status is not up to date yet", pdb.Name), nil
+		}
+		allowed := int64(pdb.Status.DisruptionsAllowed)
+		ok, err := shared.CompareTypedValues(allowed, spec.Operator, int64(*spec.DisruptionsAllowed))
+		if err != nil {
+			return false, "", err
+		}
+		if !ok {
+			failures = append(failures, fmt.Sprintf("disruptionsAllowed is %d, expected %s %d (%d of %d pods healthy, %d desired)",
+				allowed, spec.Operator, *spec.DisruptionsAllowed,
+				pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods, pdb.Status.DesiredHealthy))
+		}
+	}
+
+	if len(failures) > 0 {
+		return false, fmt.Sprintf("PodDisruptionBudget %s: %s", pdb.Name, strings.Join(failures, "; ")), nil
+	}
+	return true, fmt.Sprintf("PodDisruptionBudget %s matches the expected budget", pdb.Name), nil
+}
+
+// findBudget returns the budget named by spec, or the single budget covering the pods
+// of spec.Target. When there is none it returns a failure message instead.
+func findBudget(ctx context.Context, deps shared.Deps, spec vtypes.PodDisruptionBudgetSpec) (*policyv1.PodDisruptionBudget, string, error) {
+	if spec.Name != "" {
+		pdb, err := deps.Clientset.PolicyV1().PodDisruptionBudgets(deps.Namespace).Get(ctx, spec.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Sprintf("PodDisruptionBudget %s not found", spec.Name), nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get pod disruption budget %s: %w", spec.Name, err)
+		}
+		return pdb, "", nil
+	}
+
+	pods, err := shared.GetTargetPods(ctx, deps, *spec.Target)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(pods) == 0 {
+		return nil, errNoMatchingPods, nil
+	}
+	list, err := deps.Clientset.PolicyV1().PodDisruptionBudgets(deps.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+
+	var covering []*policyv1.PodDisruptionBudget
+	for i := range list.Items {
+		selector, err := metav1.LabelSelectorAsSelector(list.Items[i].Spec.Selector)
+		if err != nil {
+			logger.Debug("Ignoring PodDisruptionBudget %s with invalid selector: %v", list.Items[i].Name, err)
+			continue
+		}
+		for _, pod := range pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				covering = append(covering, &list.Items[i])
+				break
+			}
+		}
+	}
+
+	switch len(covering) {
+	case 0:
+		return nil, "No PodDisruptionBudget covers the matching pods", nil
+	case 1:
+		return covering[0], "", nil
+	default:
+		names := make([]string, len(covering))
+		for i, pdb := range covering {
+			names[i] = pdb.Name
+		}
+		sort.Strings(names)
+		return nil, fmt.Sprintf("The matching pods are covered by several PodDisruptionBudgets (%s); evictions fail when more than one applies",
+			strings.Join(names, ", ")), nil
+	}
+}
+
+// budgetProblem compares the field of a budget with the expected value. A budget sets
+// either minAvailable or maxUnavailable, so the other field is named when it is the one set.
+func budgetProblem(field string, actual *intstr.IntOrString, expected, otherField string, other *intstr.IntOrString) string {
+	switch {
+	case actual != nil && actual.String() == expected:
+		return ""
+	case actual != nil:
+		return fmt.Sprintf("%s is %s, expected %s", field, actual.String(), expected)
+	case other != nil:
+		return fmt.Sprintf("sets %s %s, expected %s %s", otherField, other.String(), field, expected)
+	default:
+		return fmt.Sprintf("%s is not set, expected %s", field, expected)
+	}
+}
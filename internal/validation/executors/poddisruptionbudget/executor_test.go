@@ -0,0 +1,135 @@
+package poddisruptionbudget_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/poddisruptionbudget"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func budget(name string, minAvailable, maxUnavailable *intstr.IntOrString, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Generation: 1},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			ObservedGeneration: 1,
+			DisruptionsAllowed: disruptionsAllowed,
+			CurrentHealthy:     3,
+			DesiredHealthy:     3 - disruptionsAllowed,
+			ExpectedPods:       3,
+		},
+	}
+}
+
+func webPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "web", "tier": "frontend"}}}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+}
+
+func TestExecute_ByName(t *testing.T) {
+	two := intstr.FromInt32(2)
+	half := intstr.FromString("50%")
+	tests := []struct {
+		name    string
+		spec    vtypes.PodDisruptionBudgetSpec
+		passed  bool
+		message string
+	}{
+		{
+			name:    "budget and disruptions match",
+			spec:    vtypes.PodDisruptionBudgetSpec{Name: "web", MinAvailable: "2", Operator: ">=", DisruptionsAllowed: ptr(1)},
+			passed:  true,
+			message: "PodDisruptionBudget web matches the expected budget",
+		},
+		{
+			name:    "different minAvailable",
+			spec:    vtypes.PodDisruptionBudgetSpec{Name: "web", MinAvailable: "50%"},
+			message: "PodDisruptionBudget web: minAvailable is 2, expected 50%",
+		},
+		{
+			name:    "other field set",
+			spec:    vtypes.PodDisruptionBudgetSpec{Name: "web", MaxUnavailable: "1"},
+			message: "PodDisruptionBudget web: sets minAvailable 2, expected maxUnavailable 1",
+		},
+		{
+			name:    "too few disruptions",
+			spec:    vtypes.PodDisruptionBudgetSpec{Name: "web", Operator: ">=", DisruptionsAllowed: ptr(2)},
+			message: "PodDisruptionBudget web: disruptionsAllowed is 1, expected >= 2 (3 of 3 pods healthy, 2 desired)",
+		},
+		{
+			name:    "not found",
+			spec:    vtypes.PodDisruptionBudgetSpec{Name: "api"},
+			message: "PodDisruptionBudget api not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, msg, err := poddisruptionbudget.Execute(context.Background(), tt.spec, deps(budget("web", &two, nil, 1), budget("web-percent", nil, &half, 1)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.passed, passed)
+			assert.Equal(t, tt.message, msg)
+		})
+	}
+}
+
+func TestExecute_StaleStatus(t *testing.T) {
+	pdb := budget("web", nil, ptr(intstr.FromInt32(1)), 0)
+	pdb.Generation = 2
+
+	passed, msg, err := poddisruptionbudget.Execute(context.Background(), vtypes.PodDisruptionBudgetSpec{Name: "web", Operator: "==", DisruptionsAllowed: ptr(1)}, deps(pdb))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "PodDisruptionBudget web status is not up to date yet", msg)
+}
+
+func TestExecute_ByTarget(t *testing.T) {
+	spec := vtypes.PodDisruptionBudgetSpec{
+		Target:         &vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"tier": "frontend"}},
+		MaxUnavailable: "1",
+	}
+	web := budget("web", nil, ptr(intstr.FromInt32(1)), 1)
+	other := budget("other", nil, ptr(intstr.FromInt32(1)), 1)
+	other.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+
+	passed, msg, err := poddisruptionbudget.Execute(context.Background(), spec, deps(webPod("web-0"), webPod("web-1"), web, other))
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Equal(t, "PodDisruptionBudget web matches the expected budget", msg)
+
+	passed, msg, err = poddisruptionbudget.Execute(context.Background(), spec, deps(webPod("web-0"), other))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No PodDisruptionBudget covers the matching pods", msg)
+
+	// An empty selector covers every pod of the namespace
+	all := budget("all", nil, ptr(intstr.FromInt32(1)), 1)
+	all.Spec.Selector = &metav1.LabelSelector{}
+	passed, msg, err = poddisruptionbudget.Execute(context.Background(), spec, deps(webPod("web-0"), web, all))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "The matching pods are covered by several PodDisruptionBudgets (all, web); evictions fail when more than one applies", msg)
+
+	passed, msg, err = poddisruptionbudget.Execute(context.Background(), spec, deps(web))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No matching pods found", msg)
+}
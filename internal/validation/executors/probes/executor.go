@@ -0,0 +1,318 @@
+// Package probes implements the "probes" validation type.
+// It checks the liveness, readiness and startup probe configuration of containers and
+// can request httpGet probe endpoints from the CLI-managed probe pod.
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	msgAllProbesPassed = "All probe checks passed"
+	errNoMatchingPods  = "No matching pods found"
+	errNoRunningPod    = "No running pod found to exercise the probes"
+
+	// maxRequestErrorLength caps the curl error echoed back in a result message.
+	maxRequestErrorLength = 200
+)
+
+func init() {
+	engine.Register(vtypes.TypeProbes, engine.Typed(Execute))
+}
+
+// probeRequest is an httpGet probe to send the way the kubelet would.
+type probeRequest struct {
+	url            string
+	headers        []corev1.HTTPHeader
+	timeoutSeconds int32
+}
+
+// probeResponse is the HTTP status of a probeRequest, or why it got none.
+type probeResponse struct {
+	status int
+	err    error
+}
+
+// fetch sends the requests from inside the cluster. It is a variable so tests can
+// answer requests without a cluster.
+var fetch = fetchFromProbePod
+
+// Execute checks the probes of the selected containers of every pod of spec.Target.
+func Execute(ctx context.Context, spec vtypes.ProbesSpec, deps shared.Deps) (bool, string, error) {
+	logger.Debug("Executing probes validation")
+
+	pods, err := shared.GetTargetPods(ctx, deps, spec.Target)
+	if err != nil {
+		return false, "", err
+	}
+	if len(pods) == 0 {
+		return false, errNoMatchingPods, nil
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	failures, found := shared.CheckContainers(pods, spec.Container, false, func(c *corev1.Container) []string {
+		var problems []string
+		for _, check := range spec.Probes {
+			problems = append(problems, checkProbe(c, check)...)
+		}
+		return problems
+	})
+	if !found {
+		return false, fmt.Sprintf("Container %s not found in the matching pods", spec.Container), nil
+	}
+
+	if len(failures) == 0 && exercises(spec) {
+		msgs, err := exercise(ctx, deps, spec, pods)
+		if err != nil {
+			return false, fmt.Sprintf("Failed to exercise the probes: %v", err), nil
+		}
+		failures = append(failures, msgs...)
+	}
+
+	if len(failures) == 0 {
+		return true, msgAllProbesPassed, nil
+	}
+	return false, strings.Join(failures, "; "), nil
+}
+
+// checkProbe compares the probe of c named by check.Type with the set fields of check.
+func checkProbe(c *corev1.Container, check vtypes.ProbeCheck) []string {
+	probe := probeOf(c, check.Type)
+	if probe == nil {
+		return []string{fmt.Sprintf("no %s probe", check.Type)}
+	}
+
+	var problems []string
+	if check.Path != "" {
+		switch {
+		case probe.HTTPGet == nil:
+			problems = append(problems, fmt.Sprintf("%s probe uses %s, expected httpGet", check.Type, handlerName(probe)))
+		case probe.HTTPGet.Path != check.Path:
+			problems = append(problems, fmt.Sprintf("%s probe path is %q, expected %q", check.Type, probe.HTTPGet.Path, check.Path))
+		}
+	}
+	if check.Port != "" {
+		port := probePort(probe)
+		switch {
+		case port == nil:
+			problems = append(problems, fmt.Sprintf("%s probe uses %s, which has no port", check.Type, handlerName(probe)))
+		case !samePort(c, *port, intstr.Parse(check.Port)):
+			problems = append(problems, fmt.Sprintf("%s probe port is %s, expected %s", check.Type, port.String(), check.Port))
+		}
+	}
+	if check.Exercise && probe.HTTPGet == nil {
+		problems = append(problems, fmt.Sprintf("%s probe uses %s, only httpGet probes can be exercised", check.Type, handlerName(probe)))
+	}
+
+	for _, f := range []struct {
+		name     string
+		actual   int32
+		expected *int32
+	}{
+		{"initialDelaySeconds", probe.InitialDelaySeconds, check.InitialDelaySeconds},
+		{"periodSeconds", probe.PeriodSeconds, check.PeriodSeconds},
+		{"timeoutSeconds", probe.TimeoutSeconds, check.TimeoutSeconds},
+		{"failureThreshold", probe.FailureThreshold, check.FailureThreshold},
+		{"successThreshold", probe.SuccessThreshold, check.SuccessThreshold},
+	} {
+		if f.expected != nil && f.actual != *f.expected {
+			problems = append(problems, fmt.Sprintf("%s probe %s is %d, expected %d", check.Type, f.name, f.actual, *f.expected))
+		}
+	}
+	return problems
+}
+
+func probeOf(c *corev1.Container, probeType string) *corev1.Probe {
+	switch probeType {
+	case vtypes.ProbeLiveness:
+		return c.LivenessProbe
+	case vtypes.ProbeReadiness:
+		return c.ReadinessProbe
+	case vtypes.ProbeStartup:
+		return c.StartupProbe
+	default:
+		return nil
+	}
+}
+
+func handlerName(p *corev1.Probe) string {
+	switch {
+	case p.HTTPGet != nil:
+		return "httpGet"
+	case p.TCPSocket != nil:
+		return "tcpSocket"
+	case p.GRPC != nil:
+		return "grpc"
+	case p.Exec != nil:
+		return "exec"
+	default:
+		return "no handler"
+	}
+}
+
+func probePort(p *corev1.Probe) *intstr.IntOrString {
+	switch {
+	case p.HTTPGet != nil:
+		return &p.HTTPGet.Port
+	case p.TCPSocket != nil:
+		return &p.TCPSocket.Port
+	case p.GRPC != nil:
+		port := intstr.FromInt32(p.GRPC.Port)
+		return &port
+	default:
+		return nil
+	}
+}
+
+// samePort compares two ports, resolving container port names of c, so 8080 and
+// "http" are the same port when c names its port 8080 "http".
+func samePort(c *corev1.Container, a, b intstr.IntOrString) bool {
+	if a.String() == b.String() {
+		return true
+	}
+	na, nb := resolvePort(c, a), resolvePort(c, b)
+	return na != 0 && na == nb
+}
+
+// resolvePort returns the number of port, or 0 when it names no port of c.
+func resolvePort(c *corev1.Container, port intstr.IntOrString) int32 {
+	if port.Type == intstr.Int {
+		return port.IntVal
+	}
+	for _, cp := range c.Ports {
+		if cp.Name == port.StrVal {
+			return cp.ContainerPort
+		}
+	}
+	return 0
+}
+
+func exercises(spec vtypes.ProbesSpec) bool {
+	for _, check := range spec.Probes {
+		if check.Exercise {
+			return true
+		}
+	}
+	return false
+}
+
+// exercise requests the httpGet endpoints of the checks with Exercise on the first
+// running pod, and reports the endpoints that would fail a kubelet probe.
+func exercise(ctx context.Context, deps shared.Deps, spec vtypes.ProbesSpec, pods []corev1.Pod) ([]string, error) {
+	var pod *corev1.Pod
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning && pods[i].Status.PodIP != "" {
+			pod = &pods[i]
+			break
+		}
+	}
+	if pod == nil {
+		return []string{errNoRunningPod}, nil
+	}
+
+	var requests []probeRequest
+	var subjects []string
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if spec.Container != "" && c.Name != spec.Container {
+			continue
+		}
+		for _, check := range spec.Probes {
+			if !check.Exercise {
+				continue
+			}
+			// Validated by checkProbe: exercised probes are httpGet probes
+			probe := probeOf(c, check.Type)
+			requests = append(requests, newRequest(c, pod.Status.PodIP, probe))
+			subjects = append(subjects, fmt.Sprintf("container %s: %s endpoint", c.Name, check.Type))
+		}
+	}
+
+	responses, err := fetch(ctx, deps, requests)
+	if err != nil {
+		return nil, err
+	}
+	var failures []string
+	for i, r := range responses {
+		switch {
+		case r.err != nil:
+			failures = append(failures, fmt.Sprintf("%s %s failed: %v", subjects[i], requests[i].url, r.err))
+		case r.status < 200 || r.status >= 400:
+			failures = append(failures, fmt.Sprintf("%s %s returned %d", subjects[i], requests[i].url, r.status))
+		}
+	}
+	return failures, nil
+}
+
+func newRequest(c *corev1.Container, podIP string, probe *corev1.Probe) probeRequest {
+	get := probe.HTTPGet
+	scheme := "http"
+	if get.Scheme == corev1.URISchemeHTTPS {
+		scheme = "https"
+	}
+	host := podIP
+	if get.Host != "" {
+		host = get.Host
+	}
+	path := get.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	timeout := probe.TimeoutSeconds
+	if timeout < 1 {
+		timeout = 1
+	}
+	port := strconv.Itoa(int(resolvePort(c, get.Port)))
+	return probeRequest{
+		url:            fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, port), path),
+		headers:        get.HTTPHeaders,
+		timeoutSeconds: timeout,
+	}
+}
+
+// fetchFromProbePod sends every request with curl from the probe pod. Like the
+// kubelet, it does not verify TLS certificates or follow redirects.
+func fetchFromProbePod(ctx context.Context, deps shared.Deps, requests []probeRequest) ([]probeResponse, error) {
+	responses := make([]probeResponse, len(requests))
+	err := shared.WithProbePod(ctx, deps, deps.Namespace, func(pod *corev1.Pod) error {
+		for i, r := range requests {
+			cmd := []string{"curl", "-sS", "-k", "-o", "/dev/null", "-w", "%{http_code}", "--max-time", strconv.Itoa(int(r.timeoutSeconds))}
+			for _, h := range r.headers {
+				cmd = append(cmd, "-H", h.Name+": "+h.Value)
+			}
+			stdout, stderr, err := shared.ExecInPod(ctx, deps, pod, append(cmd, r.url))
+			if err != nil {
+				msg := strings.TrimSpace(stderr)
+				if msg == "" {
+					msg = err.Error()
+				}
+				if len(msg) > maxRequestErrorLength {
+					msg = msg[:maxRequestErrorLength] + "..."
+				}
+				responses[i].err = errors.New(msg)
+				continue
+			}
+			status, err := strconv.Atoi(strings.TrimSpace(stdout))
+			if err != nil {
+				responses[i].err = fmt.Errorf("unexpected curl output %q", stdout)
+				continue
+			}
+			responses[i].status = status
+		}
+		return nil
+	})
+	return responses, err
+}
@@ -0,0 +1,188 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var target = vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}}
+
+func webPod(name string, readiness *corev1.Probe) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{
+				Name:           "app",
+				Ports:          []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+				ReadinessProbe: readiness,
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler:  corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8080)}},
+					PeriodSeconds: 10, TimeoutSeconds: 1, FailureThreshold: 3, SuccessThreshold: 1,
+				},
+			},
+			{Name: "sidecar"},
+		}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.5"},
+	}
+}
+
+func httpProbe(path string, port intstr.IntOrString) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler:  corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: path, Port: port}},
+		PeriodSeconds: 5, TimeoutSeconds: 2, FailureThreshold: 3, SuccessThreshold: 1,
+	}
+}
+
+// stubFetch answers every request with responses and records the requests.
+func stubFetch(t *testing.T, response probeResponse, err error) *[]probeRequest {
+	t.Helper()
+	var requests []probeRequest
+	orig := fetch
+	fetch = func(_ context.Context, _ shared.Deps, reqs []probeRequest) ([]probeResponse, error) {
+		requests = append(requests, reqs...)
+		responses := make([]probeResponse, len(reqs))
+		for i := range responses {
+			responses[i] = response
+		}
+		return responses, err
+	}
+	t.Cleanup(func() { fetch = orig })
+	return &requests
+}
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+}
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func TestExecute_Configuration(t *testing.T) {
+	pod := webPod("web-0", httpProbe("/healthz", intstr.FromString("http")))
+	tests := []struct {
+		name    string
+		probes  []vtypes.ProbeCheck
+		message string
+	}{
+		{
+			name: "matching path, named and numeric port",
+			probes: []vtypes.ProbeCheck{
+				{Type: "readiness", Path: "/healthz", Port: "8080", PeriodSeconds: int32Ptr(5)},
+				{Type: "liveness", Port: "http", FailureThreshold: int32Ptr(3)},
+			},
+		},
+		{
+			name:    "wrong path and threshold",
+			probes:  []vtypes.ProbeCheck{{Type: "readiness", Path: "/ready", TimeoutSeconds: int32Ptr(5)}},
+			message: `container app: readiness probe path is "/healthz", expected "/ready", readiness probe timeoutSeconds is 2, expected 5`,
+		},
+		{
+			name:    "path on a tcp probe",
+			probes:  []vtypes.ProbeCheck{{Type: "liveness", Path: "/healthz"}},
+			message: "container app: liveness probe uses tcpSocket, expected httpGet",
+		},
+		{
+			name:    "wrong port",
+			probes:  []vtypes.ProbeCheck{{Type: "liveness", Port: "9090"}},
+			message: "container app: liveness probe port is 8080, expected 9090",
+		},
+		{
+			name:    "missing probe",
+			probes:  []vtypes.ProbeCheck{{Type: "startup"}},
+			message: "container app: no startup probe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, msg, err := Execute(context.Background(), vtypes.ProbesSpec{Target: target, Container: "app", Probes: tt.probes}, deps(pod))
+			require.NoError(t, err)
+			if tt.message == "" {
+				assert.True(t, passed, msg)
+				assert.Equal(t, msgAllProbesPassed, msg)
+				return
+			}
+			assert.False(t, passed)
+			assert.Equal(t, tt.message, msg)
+		})
+	}
+}
+
+func TestExecute_EveryContainerAndPod(t *testing.T) {
+	spec := vtypes.ProbesSpec{Target: target, Probes: []vtypes.ProbeCheck{{Type: "readiness"}}}
+
+	// The same problem in two pods is reported once
+	passed, msg, err := Execute(context.Background(), spec, deps(webPod("web-0", httpProbe("/", intstr.FromInt32(8080))), webPod("web-1", httpProbe("/", intstr.FromInt32(8080)))))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "container sidecar: no readiness probe", msg)
+
+	passed, msg, err = Execute(context.Background(), vtypes.ProbesSpec{Target: target, Container: "proxy", Probes: spec.Probes}, deps(webPod("web-0", nil)))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Container proxy not found in the matching pods", msg)
+
+	passed, msg, err = Execute(context.Background(), spec, deps())
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, errNoMatchingPods, msg)
+}
+
+func TestExecute_Exercise(t *testing.T) {
+	probe := httpProbe("healthz", intstr.FromString("http"))
+	probe.HTTPGet.HTTPHeaders = []corev1.HTTPHeader{{Name: "X-Probe", Value: "1"}}
+	pod := webPod("web-0", probe)
+	spec := vtypes.ProbesSpec{Target: target, Container: "app", Probes: []vtypes.ProbeCheck{{Type: "readiness", Exercise: true}}}
+
+	requests := stubFetch(t, probeResponse{status: 204}, nil)
+	passed, msg, err := Execute(context.Background(), spec, deps(pod))
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+	require.Len(t, *requests, 1)
+	assert.Equal(t, probeRequest{url: "http://10.0.0.5:8080/healthz", headers: probe.HTTPGet.HTTPHeaders, timeoutSeconds: 2}, (*requests)[0])
+
+	stubFetch(t, probeResponse{status: 503}, nil)
+	passed, msg, err = Execute(context.Background(), spec, deps(pod))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "container app: readiness endpoint http://10.0.0.5:8080/healthz returned 503", msg)
+
+	stubFetch(t, probeResponse{err: errors.New("curl: (7) Failed to connect")}, nil)
+	passed, msg, err = Execute(context.Background(), spec, deps(pod))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "container app: readiness endpoint http://10.0.0.5:8080/healthz failed: curl: (7) Failed to connect", msg)
+
+	stubFetch(t, probeResponse{}, errors.New("probe pod failed to become ready"))
+	passed, msg, err = Execute(context.Background(), spec, deps(pod))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Failed to exercise the probes: probe pod failed to become ready", msg)
+
+	pending := webPod("web-0", probe)
+	pending.Status = corev1.PodStatus{Phase: corev1.PodPending}
+	passed, msg, err = Execute(context.Background(), spec, deps(pending))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, errNoRunningPod, msg)
+}
+
+func TestExecute_ExerciseRequiresHTTPGet(t *testing.T) {
+	spec := vtypes.ProbesSpec{Target: target, Container: "app", Probes: []vtypes.ProbeCheck{{Type: "liveness", Exercise: true}}}
+	requests := stubFetch(t, probeResponse{status: 200}, nil)
+
+	passed, msg, err := Execute(context.Background(), spec, deps(webPod("web-0", nil)))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "container app: liveness probe uses tcpSocket, only httpGet probes can be exercised", msg)
+	assert.Empty(t, *requests)
+}
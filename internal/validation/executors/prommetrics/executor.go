@@ -0,0 +1,225 @@
+// Package prommetrics implements the "promMetrics" validation type.
+// It scrapes a Prometheus exposition-format endpoint with curl from the CLI-managed
+// probe pod and compares metric values with thresholds.
+package prommetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	msgAllMetricsPassed = "All metric checks passed"
+
+	// maxScrapeErrorLength caps the curl error echoed back in a result message.
+	maxScrapeErrorLength = 200
+)
+
+func init() {
+	engine.Register(vtypes.TypePromMetrics, engine.Typed(Execute))
+}
+
+// scrape fetches the metrics page at url from inside the cluster. It is a variable so
+// tests can serve metrics without a cluster.
+var scrape = scrapeFromProbePod
+
+// Execute scrapes the endpoint of spec and evaluates every check.
+func Execute(ctx context.Context, spec vtypes.PromMetricsSpec, deps shared.Deps) (bool, string, error) {
+	url := spec.URL
+	if spec.Target != nil {
+		ip, msg, err := targetPodIP(ctx, deps, *spec.Target)
+		if err != nil || msg != "" {
+			return false, msg, err
+		}
+		url = fmt.Sprintf("http://%s%s", net.JoinHostPort(ip, strconv.Itoa(spec.Port)), spec.Path)
+	}
+	logger.Debug("Executing promMetrics validation against %s", url)
+
+	body, err := scrape(ctx, deps, url, spec.TimeoutSeconds)
+	if err != nil {
+		return false, fmt.Sprintf("Failed to scrape %s: %v", url, err), nil
+	}
+
+	families, err := parseMetrics(body)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid metrics from %s: %v", url, err), nil
+	}
+
+	allPassed := true
+	var messages []string
+	for _, c := range spec.Checks {
+		if passed, msg := evaluate(families, c); !passed {
+			allPassed = false
+			messages = append(messages, msg)
+		}
+	}
+	if allPassed {
+		return true, msgAllMetricsPassed, nil
+	}
+	return false, strings.Join(messages, "; "), nil
+}
+
+// targetPodIP returns the IP of the first running pod of target, or a failure message.
+func targetPodIP(ctx context.Context, deps shared.Deps, target vtypes.Target) (string, string, error) {
+	pods, err := shared.GetTargetPods(ctx, deps, target)
+	if err != nil {
+		return "", "", err
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			return pod.Status.PodIP, "", nil
+		}
+	}
+	return "", "No running pod found to scrape", nil
+}
+
+// scrapeFromProbePod runs curl in the probe pod and returns the response body.
+func scrapeFromProbePod(ctx context.Context, deps shared.Deps, url string, timeoutSeconds int) (string, error) {
+	var body string
+	err := shared.WithProbePod(ctx, deps, deps.Namespace, func(pod *corev1.Pod) error {
+		stdout, stderr, err := shared.ExecInPod(ctx, deps, pod, []string{
+			"curl", "-sS", "--fail", "--max-time", strconv.Itoa(timeoutSeconds), url,
+		})
+		if err != nil {
+			if msg := strings.TrimSpace(stderr); msg != "" {
+				if len(msg) > maxScrapeErrorLength {
+					msg = msg[:maxScrapeErrorLength] + "..."
+				}
+				return errors.New(msg)
+			}
+			return err
+		}
+		body = stdout
+		return nil
+	})
+	return body, err
+}
+
+// parseMetrics parses a page in the Prometheus text exposition format.
+func parseMetrics(body string) (map[string]*dto.MetricFamily, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	return parser.TextToMetricFamilies(strings.NewReader(body))
+}
+
+// evaluate checks one metric against its threshold.
+func evaluate(families map[string]*dto.MetricFamily, c vtypes.PromMetricCheck) (bool, string) {
+	value, matched, err := sampleValue(families, c.Metric, c.Labels)
+	if err != nil {
+		return false, fmt.Sprintf("%s: %v", DescribeMetric(c), err)
+	}
+	if matched == 0 {
+		return false, fmt.Sprintf("%s: metric not found", DescribeMetric(c))
+	}
+	ok, err := shared.CompareTypedValues(value, c.Operator, c.Value)
+	if err != nil {
+		return false, fmt.Sprintf("%s: %v", DescribeMetric(c), err)
+	}
+	if !ok {
+		return false, fmt.Sprintf("%s is %s, expected %s %s", DescribeMetric(c), formatValue(value), c.Operator, formatValue(c.Value))
+	}
+	return true, ""
+}
+
+// sampleValue sums the series of metric that carry all of labels and returns how many
+// series matched. Histograms and summaries are read through their _sum and _count series.
+func sampleValue(families map[string]*dto.MetricFamily, metric string, labels map[string]string) (float64, int, error) {
+	family, suffix := families[metric], ""
+	if family == nil {
+		for _, s := range []string{"_sum", "_count"} {
+			if base, ok := strings.CutSuffix(metric, s); ok && families[base] != nil {
+				family, suffix = families[base], s
+			}
+		}
+	}
+	if family == nil {
+		return 0, 0, nil
+	}
+
+	var total float64
+	matched := 0
+	for _, m := range family.GetMetric() {
+		if !hasLabels(m, labels) {
+			continue
+		}
+		v, err := seriesValue(family.GetType(), m, suffix)
+		if err != nil {
+			return 0, 0, err
+		}
+		total += v
+		matched++
+	}
+	return total, matched, nil
+}
+
+func seriesValue(typ dto.MetricType, m *dto.Metric, suffix string) (float64, error) {
+	switch {
+	case typ == dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), nil
+	case typ == dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), nil
+	case typ == dto.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue(), nil
+	case typ == dto.MetricType_HISTOGRAM && suffix == "_sum":
+		return m.GetHistogram().GetSampleSum(), nil
+	case typ == dto.MetricType_HISTOGRAM && suffix == "_count":
+		return float64(m.GetHistogram().GetSampleCount()), nil
+	case typ == dto.MetricType_SUMMARY && suffix == "_sum":
+		return m.GetSummary().GetSampleSum(), nil
+	case typ == dto.MetricType_SUMMARY && suffix == "_count":
+		return float64(m.GetSummary().GetSampleCount()), nil
+	default:
+		return 0, fmt.Errorf("%s metrics have no single value: check their _sum or _count series", strings.ToLower(typ.String()))
+	}
+}
+
+func hasLabels(m *dto.Metric, labels map[string]string) bool {
+	for name, want := range labels {
+		found := false
+		for _, lp := range m.GetLabel() {
+			if lp.GetName() == name && lp.GetValue() == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// DescribeMetric renders a check's series selector in PromQL syntax, e.g. up{job="web"}.
+// 'kubeasy explain' uses it too, so both show the same selector.
+func DescribeMetric(c vtypes.PromMetricCheck) string {
+	if len(c.Labels) == 0 {
+		return c.Metric
+	}
+	pairs := make([]string, 0, len(c.Labels))
+	for k, v := range c.Labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("%s{%s}", c.Metric, strings.Join(pairs, ","))
+}
+
+func formatValue(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,173 @@
+package prommetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testMetrics = `# HELP http_requests_total Total HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{code="200",method="GET"} 120
+http_requests_total{code="200",method="POST"} 30
+http_requests_total{code="500",method="GET"} 2
+# TYPE queue_depth gauge
+queue_depth 7
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="0.1"} 8
+request_duration_seconds_bucket{le="+Inf"} 10
+request_duration_seconds_sum 1.5
+request_duration_seconds_count 10
+`
+
+// stubScrape serves body for every scrape and records the requested URLs.
+func stubScrape(t *testing.T, body string, err error) *[]string {
+	t.Helper()
+	var urls []string
+	orig := scrape
+	scrape = func(_ context.Context, _ shared.Deps, url string, _ int) (string, error) {
+		urls = append(urls, url)
+		return body, err
+	}
+	t.Cleanup(func() { scrape = orig })
+	return &urls
+}
+
+func TestExecute_Checks(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   vtypes.PromMetricCheck
+		passed  bool
+		message string
+	}{
+		{
+			name:   "counter summed across series",
+			check:  vtypes.PromMetricCheck{Metric: "http_requests_total", Operator: ">=", Value: 152},
+			passed: true,
+		},
+		{
+			name:   "label filter",
+			check:  vtypes.PromMetricCheck{Metric: "http_requests_total", Labels: map[string]string{"code": "500"}, Operator: "<", Value: 5},
+			passed: true,
+		},
+		{
+			name:    "label filter fails threshold",
+			check:   vtypes.PromMetricCheck{Metric: "http_requests_total", Labels: map[string]string{"method": "GET", "code": "200"}, Operator: ">", Value: 500},
+			message: `http_requests_total{code="200",method="GET"} is 120, expected > 500`,
+		},
+		{
+			name:   "gauge",
+			check:  vtypes.PromMetricCheck{Metric: "queue_depth", Operator: "==", Value: 7},
+			passed: true,
+		},
+		{
+			name:   "histogram count",
+			check:  vtypes.PromMetricCheck{Metric: "request_duration_seconds_count", Operator: ">=", Value: 10},
+			passed: true,
+		},
+		{
+			name:    "histogram sum",
+			check:   vtypes.PromMetricCheck{Metric: "request_duration_seconds_sum", Operator: "<", Value: 1},
+			message: "request_duration_seconds_sum is 1.5, expected < 1",
+		},
+		{
+			name:    "histogram without suffix",
+			check:   vtypes.PromMetricCheck{Metric: "request_duration_seconds", Operator: ">", Value: 0},
+			message: "request_duration_seconds: histogram metrics have no single value: check their _sum or _count series",
+		},
+		{
+			name:    "missing metric",
+			check:   vtypes.PromMetricCheck{Metric: "missing_total", Operator: ">", Value: 0},
+			message: "missing_total: metric not found",
+		},
+		{
+			name:    "no series with labels",
+			check:   vtypes.PromMetricCheck{Metric: "http_requests_total", Labels: map[string]string{"code": "404"}, Operator: ">=", Value: 0},
+			message: `http_requests_total{code="404"}: metric not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubScrape(t, testMetrics, nil)
+			spec := vtypes.PromMetricsSpec{URL: "http://web.test-ns.svc:9090", Checks: []vtypes.PromMetricCheck{tt.check}}
+
+			passed, msg, err := Execute(context.Background(), spec, shared.Deps{Namespace: "test-ns"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.passed, passed)
+			if tt.passed {
+				assert.Equal(t, msgAllMetricsPassed, msg)
+			} else {
+				assert.Equal(t, tt.message, msg)
+			}
+		})
+	}
+}
+
+func TestExecute_ReportsEveryFailedCheck(t *testing.T) {
+	stubScrape(t, testMetrics, nil)
+	spec := vtypes.PromMetricsSpec{URL: "http://web:9090", Checks: []vtypes.PromMetricCheck{
+		{Metric: "queue_depth", Operator: "<", Value: 5},
+		{Metric: "http_requests_total", Operator: ">", Value: 0},
+		{Metric: "missing_total", Operator: ">", Value: 0},
+	}}
+
+	passed, msg, err := Execute(context.Background(), spec, shared.Deps{})
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "queue_depth is 7, expected < 5; missing_total: metric not found", msg)
+}
+
+func TestExecute_ScrapeAndParseFailures(t *testing.T) {
+	spec := vtypes.PromMetricsSpec{URL: "http://web:9090", Checks: []vtypes.PromMetricCheck{{Metric: "up", Operator: "==", Value: 1}}}
+
+	stubScrape(t, "", errors.New("curl: (7) Failed to connect"))
+	passed, msg, err := Execute(context.Background(), spec, shared.Deps{})
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Failed to scrape http://web:9090: curl: (7) Failed to connect", msg)
+
+	stubScrape(t, "<html>not metrics</html>", nil)
+	passed, msg, err = Execute(context.Background(), spec, shared.Deps{})
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Contains(t, msg, "Invalid metrics from http://web:9090")
+}
+
+func TestExecute_TargetResolvesRunningPod(t *testing.T) {
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "test-ns", Labels: map[string]string{"app": "web"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test-ns", Labels: map[string]string{"app": "web"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.7"},
+	}
+	spec := vtypes.PromMetricsSpec{
+		Target: &vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}},
+		Port:   9090,
+		Path:   "/metrics",
+		Checks: []vtypes.PromMetricCheck{{Metric: "queue_depth", Operator: ">", Value: 0}},
+	}
+
+	urls := stubScrape(t, testMetrics, nil)
+	deps := shared.Deps{Clientset: fake.NewClientset(pending, running), Namespace: "test-ns"}
+	passed, _, err := Execute(context.Background(), spec, deps)
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Equal(t, []string{"http://10.0.0.7:9090/metrics"}, *urls)
+
+	deps = shared.Deps{Clientset: fake.NewClientset(pending), Namespace: "test-ns"}
+	passed, msg, err := Execute(context.Background(), spec, deps)
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No running pod found to scrape", msg)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
 	authv1 "k8s.io/api/authorization/v1"
@@ -14,6 +15,10 @@ import (
 
 const msgAllChecksPassed = "All RBAC checks passed" //nolint:gosec // not a credential
 
+func init() {
+	engine.Register(vtypes.TypeRbac, engine.Typed(Execute))
+}
+
 // Execute validates ServiceAccount permissions for all specified checks.
 func Execute(ctx context.Context, spec vtypes.RbacSpec, deps shared.Deps) (bool, string, error) {
 	saUser := fmt.Sprintf("system:serviceaccount:%s:%s", spec.Namespace, spec.ServiceAccount)
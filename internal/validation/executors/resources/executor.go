@@ -0,0 +1,81 @@
+// Package resources implements the "resources" validation type.
+// It checks that containers set resource requests and limits, within bounds.
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	msgAllResourcesPassed = "All resource checks passed"
+	errNoMatchingPods     = "No matching pods found"
+)
+
+func init() {
+	engine.Register(vtypes.TypeResources, engine.Typed(Execute))
+}
+
+// Execute checks every selected container of the pods of spec.Target.
+func Execute(ctx context.Context, spec vtypes.ResourcesSpec, deps shared.Deps) (bool, string, error) {
+	logger.Debug("Executing resources validation")
+
+	pods, err := shared.GetTargetPods(ctx, deps, spec.Target)
+	if err != nil {
+		return false, "", err
+	}
+	if len(pods) == 0 {
+		return false, errNoMatchingPods, nil
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	failures, found := shared.CheckContainers(pods, spec.Container, false, func(c *corev1.Container) []string {
+		return append(checkList("request", c.Resources.Requests, spec.Requests),
+			checkList("limit", c.Resources.Limits, spec.Limits)...)
+	})
+	if !found {
+		return false, fmt.Sprintf("Container %s not found in the matching pods", spec.Container), nil
+	}
+
+	if len(failures) == 0 {
+		return true, msgAllResourcesPassed, nil
+	}
+	return false, strings.Join(failures, "; "), nil
+}
+
+// checkList compares the requests or limits of a container with the expected bounds,
+// e.g. "no cpu request" or "memory limit is 2Gi, expected at most 1Gi".
+func checkList(kind string, actual corev1.ResourceList, expected map[string]vtypes.ResourceBounds) []string {
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		q, ok := actual[corev1.ResourceName(name)]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("no %s %s", name, kind))
+			continue
+		}
+		// Bounds are validated by the loader
+		bounds := expected[name]
+		if bounds.Min != "" && q.Cmp(resource.MustParse(bounds.Min)) < 0 {
+			problems = append(problems, fmt.Sprintf("%s %s is %s, expected at least %s", name, kind, q.String(), bounds.Min))
+		}
+		if bounds.Max != "" && q.Cmp(resource.MustParse(bounds.Max)) > 0 {
+			problems = append(problems, fmt.Sprintf("%s %s is %s, expected at most %s", name, kind, q.String(), bounds.Max))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,122 @@
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/resources"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func container(name string, requests, limits corev1.ResourceList) corev1.Container {
+	return corev1.Container{Name: name, Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}}
+}
+
+func list(pairs ...string) corev1.ResourceList {
+	l := corev1.ResourceList{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		l[corev1.ResourceName(pairs[i])] = resource.MustParse(pairs[i+1])
+	}
+	return l
+}
+
+func testPod(name string, containers ...corev1.Container) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: containers},
+	}
+}
+
+// webPods selects the pods of testPod.
+var webPods = vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}}
+
+func deps(objects ...runtime.Object) shared.Deps {
+	return shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+}
+
+func TestExecute_Bounds(t *testing.T) {
+	spec := vtypes.ResourcesSpec{
+		Target:   webPods,
+		Requests: map[string]vtypes.ResourceBounds{"cpu": {Min: "50m", Max: "500m"}, "memory": {}},
+		Limits:   map[string]vtypes.ResourceBounds{"memory": {Max: "1Gi"}},
+	}
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		message string
+	}{
+		{
+			name: "within bounds",
+			pod:  testPod("web-0", container("app", list("cpu", "100m", "memory", "128Mi"), list("memory", "1Gi"))),
+		},
+		{
+			name:    "missing values",
+			pod:     testPod("web-0", container("app", list("cpu", "100m"), nil)),
+			message: "container app: no memory request, no memory limit",
+		},
+		{
+			name:    "out of bounds",
+			pod:     testPod("web-0", container("app", list("cpu", "2", "memory", "128Mi"), list("memory", "2Gi"))),
+			message: "container app: cpu request is 2, expected at most 500m, memory limit is 2Gi, expected at most 1Gi",
+		},
+		{
+			name: "per container messages",
+			pod: testPod("web-0",
+				container("app", list("cpu", "10m", "memory", "128Mi"), list("memory", "512Mi")),
+				container("sidecar", nil, nil)),
+			message: "container app: cpu request is 10m, expected at least 50m; container sidecar: no cpu request, no memory request, no memory limit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, msg, err := resources.Execute(context.Background(), spec, deps(tt.pod))
+			require.NoError(t, err)
+			if tt.message == "" {
+				assert.True(t, passed, msg)
+				assert.Equal(t, "All resource checks passed", msg)
+				return
+			}
+			assert.False(t, passed)
+			assert.Equal(t, tt.message, msg)
+		})
+	}
+}
+
+func TestExecute_ContainerSelection(t *testing.T) {
+	spec := vtypes.ResourcesSpec{Target: webPods, Limits: map[string]vtypes.ResourceBounds{"memory": {}}}
+	pods := []runtime.Object{
+		testPod("web-0", container("app", nil, list("memory", "256Mi")), container("sidecar", nil, nil)),
+		testPod("web-1", container("app", nil, list("memory", "256Mi")), container("sidecar", nil, nil)),
+	}
+
+	spec.Container = "app"
+	passed, msg, err := resources.Execute(context.Background(), spec, deps(pods...))
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+
+	// The same failure in two pods is reported once
+	spec.Container = ""
+	passed, msg, err = resources.Execute(context.Background(), spec, deps(pods...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "container sidecar: no memory limit", msg)
+
+	spec.Container = "proxy"
+	passed, msg, err = resources.Execute(context.Background(), spec, deps(pods...))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Container proxy not found in the matching pods", msg)
+
+	passed, msg, err = resources.Execute(context.Background(), spec, deps())
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No matching pods found", msg)
+}
@@ -9,12 +9,11 @@ import (
 	"strings"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/fieldpath"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -24,6 +23,10 @@ const (
 	msgAllChecksPassed     = "All spec checks passed" //nolint:gosec // not a credential
 )
 
+func init() {
+	engine.Register(vtypes.TypeSpec, engine.Typed(Execute))
+}
+
 // Execute validates resource manifest fields using path-based checks.
 func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool, string, error) {
 	logger.Debug("Executing spec validation for %s", spec.Target.Kind)
@@ -41,18 +44,16 @@ func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool,
 
 	switch {
 	case spec.Target.Name != "":
-		obj, err = deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).Get(ctx, spec.Target.Name, metav1.GetOptions{})
+		obj, err = shared.GetObject(ctx, deps, gvr, spec.Target.Name)
 	case len(spec.Target.LabelSelector) > 0:
-		list, listErr := deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).List(ctx, metav1.ListOptions{
-			LabelSelector: labels.SelectorFromSet(spec.Target.LabelSelector).String(),
-		})
+		items, listErr := shared.ListObjects(ctx, deps, gvr, spec.Target.LabelSelector)
 		if listErr != nil {
 			return false, "", listErr
 		}
-		if len(list.Items) == 0 {
+		if len(items) == 0 {
 			return false, errNoMatchingResources, nil
 		}
-		obj = &list.Items[0]
+		obj = &items[0]
 	default:
 		return false, errNoTargetSpecified, nil
 	}
@@ -63,6 +64,11 @@ func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool,
 
 	allPassed := true
 	var messages []string
+	var comparisons []vtypes.Comparison
+	ref := &vtypes.ObjectRef{Kind: spec.Target.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	compared := func(path, operator string, expected, observed interface{}) {
+		comparisons = append(comparisons, vtypes.Comparison{Field: path, Operator: operator, Expected: expected, Observed: observed, ObjectRef: ref})
+	}
 
 	for _, check := range spec.Checks {
 		actual, found, resolveErr := fieldpath.GetRaw(obj.Object, check.Path)
@@ -76,6 +82,7 @@ func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool,
 		case check.Exists != nil:
 			if found != *check.Exists {
 				allPassed = false
+				compared(check.Path, "exists", *check.Exists, actual)
 				if *check.Exists {
 					messages = append(messages, fmt.Sprintf("path %q: field not found (expected to exist)", check.Path))
 				} else {
@@ -87,10 +94,12 @@ func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool,
 			if !found {
 				allPassed = false
 				messages = append(messages, fmt.Sprintf("path %q: field not found", check.Path))
+				compared(check.Path, "==", check.Value, nil)
 				continue
 			}
 			if !valuesEqual(actual, check.Value) {
 				allPassed = false
+				compared(check.Path, "==", check.Value, actual)
 				messages = append(messages, fmt.Sprintf("path %q: got %v, expected %v", check.Path, actual, check.Value))
 			}
 
@@ -98,6 +107,7 @@ func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool,
 			if !found {
 				allPassed = false
 				messages = append(messages, fmt.Sprintf("path %q: field not found", check.Path))
+				compared(check.Path, "contains", check.Contains, nil)
 				continue
 			}
 			slice, ok := actual.([]interface{})
@@ -115,6 +125,7 @@ func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool,
 			}
 			if !matchFound {
 				allPassed = false
+				compared(check.Path, "contains", check.Contains, actual)
 				messages = append(messages, fmt.Sprintf("path %q: no element matches %v", check.Path, check.Contains))
 			}
 
@@ -127,7 +138,7 @@ func Execute(ctx context.Context, spec vtypes.SpecSpec, deps shared.Deps) (bool,
 	if allPassed {
 		return true, msgAllChecksPassed, nil
 	}
-	return false, strings.Join(messages, "; "), nil
+	return shared.CompareFailure(strings.Join(messages, "; "), comparisons)
 }
 
 // valuesEqual compares two values for equality, normalizing numeric types.
@@ -75,10 +75,15 @@ func TestExecute_ValueMismatch(t *testing.T) {
 		Checks: []vtypes.SpecCheck{{Path: "spec.replicas", Value: int64(3)}},
 	}
 
-	passed, msg, err := executorspec.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
-	require.NoError(t, err)
+	passed, _, err := executorspec.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
 	assert.False(t, passed)
-	assert.Contains(t, msg, "got 1, expected 3")
+	var compare *shared.CompareError
+	require.ErrorAs(t, err, &compare)
+	assert.Contains(t, compare.Message, "got 1, expected 3")
+	assert.Equal(t, []vtypes.Comparison{{
+		Field: "spec.replicas", Operator: "==", Expected: int64(3), Observed: int64(1),
+		ObjectRef: &vtypes.ObjectRef{Kind: "Deployment", Namespace: "test-ns", Name: "test"},
+	}}, compare.Comparisons)
 }
 
 func TestExecute_ContainsFound(t *testing.T) {
@@ -130,10 +135,11 @@ func TestExecute_ContainsNotFound(t *testing.T) {
 		}},
 	}
 
-	passed, msg, err := executorspec.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
-	require.NoError(t, err)
+	passed, _, err := executorspec.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
 	assert.False(t, passed)
-	assert.Contains(t, msg, "no element matches")
+	var compare *shared.CompareError
+	require.ErrorAs(t, err, &compare)
+	assert.Contains(t, compare.Message, "no element matches")
 }
 
 func TestExecute_NoChecks(t *testing.T) {
@@ -1,5 +1,6 @@
 // Package status implements the "status" validation type.
-// It checks arbitrary resource status fields using comparison operators.
+// It checks arbitrary resource status fields using comparison operators, and the
+// readiness, completion and restarts of named containers.
 package status
 
 import (
@@ -8,89 +9,256 @@ import (
 	"strings"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/fieldpath"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
 	errNoChecksSpecified   = "No checks specified"
 	errNoMatchingResources = "No matching resources found"
 	errNoTargetSpecified   = "No target name or labelSelector specified"
+	errNoLatestPods        = "No pods of the latest revision found"
+	errNoPodsForContainers = "No pods found to check containers"
 	msgAllChecksPassed     = "All status checks passed"
 )
 
-// Execute validates arbitrary status fields of a Kubernetes resource.
+func init() {
+	engine.Register(vtypes.TypeStatus, engine.Typed(shared.WithDiagnosis(Execute, func(s vtypes.StatusSpec) vtypes.Target { return s.Target })))
+}
+
+// Execute validates arbitrary status fields of a Kubernetes resource and the state of
+// named containers of its pods.
 func Execute(ctx context.Context, spec vtypes.StatusSpec, deps shared.Deps) (bool, string, error) {
 	logger.Debug("Executing status validation for %s", spec.Target.Kind)
 
-	if len(spec.Checks) == 0 {
+	if len(spec.Checks) == 0 && len(spec.Containers) == 0 {
 		return false, errNoChecksSpecified, nil
 	}
 
+	var messages []string
+	var comparisons []vtypes.Comparison
+	if len(spec.Checks) > 0 {
+		passed, msg, failed, err := checkFields(ctx, spec, deps)
+		if err != nil {
+			return false, "", err
+		}
+		if !passed {
+			messages = append(messages, msg)
+			comparisons = failed
+		}
+	}
+	if len(spec.Containers) > 0 {
+		passed, msg, err := checkContainers(ctx, spec, deps)
+		if err != nil {
+			return false, "", err
+		}
+		if !passed {
+			messages = append(messages, msg)
+		}
+	}
+
+	if len(messages) > 0 {
+		return shared.CompareFailure(strings.Join(messages, "; "), comparisons)
+	}
+	return true, msgAllChecksPassed, nil
+}
+
+// checkFields evaluates the field checks against the target resource, or against
+// the pods of its latest revision, and returns the failed comparisons.
+func checkFields(ctx context.Context, spec vtypes.StatusSpec, deps shared.Deps) (bool, string, []vtypes.Comparison, error) {
+	if spec.LatestRevisionOnly {
+		return checkLatestRevisionPods(ctx, spec, deps)
+	}
+
 	gvr, err := shared.GetGVRForKind(spec.Target.Kind)
 	if err != nil {
-		return false, "", err
+		return false, "", nil, err
 	}
 
 	var obj *unstructured.Unstructured
 
 	switch {
 	case spec.Target.Name != "":
-		obj, err = deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).Get(ctx, spec.Target.Name, metav1.GetOptions{})
+		obj, err = shared.GetObject(ctx, deps, gvr, spec.Target.Name)
 	case len(spec.Target.LabelSelector) > 0:
-		list, listErr := deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).List(ctx, metav1.ListOptions{
-			LabelSelector: labels.SelectorFromSet(spec.Target.LabelSelector).String(),
-		})
+		items, listErr := shared.ListObjects(ctx, deps, gvr, spec.Target.LabelSelector)
 		if listErr != nil {
-			return false, "", listErr
+			return false, "", nil, listErr
 		}
-		if len(list.Items) == 0 {
-			return false, errNoMatchingResources, nil
+		if len(items) == 0 {
+			return false, errNoMatchingResources, nil, nil
 		}
-		obj = &list.Items[0]
+		obj = &items[0]
 	default:
-		return false, errNoTargetSpecified, nil
+		return false, errNoTargetSpecified, nil, nil
 	}
 
 	if err != nil {
-		return false, "", fmt.Errorf("failed to get resource: %w", err)
+		return false, "", nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+
+	ref := &vtypes.ObjectRef{Kind: spec.Target.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if messages, comparisons := evaluateChecks(obj.Object, spec.Checks, ref); len(messages) > 0 {
+		return false, strings.Join(messages, "; "), comparisons, nil
+	}
+	return true, msgAllChecksPassed, nil, nil
+}
+
+// checkLatestRevisionPods evaluates the checks against every pod of the current
+// revision of the targeted workload.
+func checkLatestRevisionPods(ctx context.Context, spec vtypes.StatusSpec, deps shared.Deps) (bool, string, []vtypes.Comparison, error) {
+	pods, err := shared.GetLatestRevisionPods(ctx, deps, spec.Target)
+	if err != nil {
+		return false, "", nil, err
+	}
+	if len(pods) == 0 {
+		return false, errNoLatestPods, nil, nil
 	}
 
-	allPassed := true
 	var messages []string
+	var comparisons []vtypes.Comparison
+	for i := range pods {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&pods[i])
+		if err != nil {
+			return false, "", nil, fmt.Errorf("failed to convert pod %s: %w", pods[i].Name, err)
+		}
+		ref := &vtypes.ObjectRef{Kind: "Pod", Namespace: pods[i].Namespace, Name: pods[i].Name}
+		podMessages, podComparisons := evaluateChecks(obj, spec.Checks, ref)
+		for _, msg := range podMessages {
+			messages = append(messages, fmt.Sprintf("Pod %s: %s", pods[i].Name, msg))
+		}
+		comparisons = append(comparisons, podComparisons...)
+	}
+	if len(messages) > 0 {
+		return false, strings.Join(messages, "; "), comparisons, nil
+	}
+	return true, msgAllChecksPassed, nil, nil
+}
 
-	for _, check := range spec.Checks {
-		value, found, err := fieldpath.Get(obj.Object, check.Field)
+// checkContainers evaluates the container checks against every pod of the target.
+func checkContainers(ctx context.Context, spec vtypes.StatusSpec, deps shared.Deps) (bool, string, error) {
+	var pods []corev1.Pod
+	var err error
+	if spec.LatestRevisionOnly {
+		pods, err = shared.GetLatestRevisionPods(ctx, deps, spec.Target)
+	} else {
+		pods, err = shared.GetTargetPods(ctx, deps, spec.Target)
+	}
+	if err != nil {
+		return false, "", err
+	}
+	if len(pods) == 0 {
+		return false, errNoPodsForContainers, nil
+	}
+
+	var messages []string
+	for i := range pods {
+		for _, c := range spec.Containers {
+			if msg := checkContainer(&pods[i], c); msg != "" {
+				messages = append(messages, fmt.Sprintf("Pod %s: %s", pods[i].Name, msg))
+			}
+		}
+	}
+	if len(messages) > 0 {
+		return false, strings.Join(messages, "; "), nil
+	}
+	return true, msgAllChecksPassed, nil
+}
+
+// checkContainer returns why the named container of pod fails c, or "".
+func checkContainer(pod *corev1.Pod, c vtypes.ContainerStatusCheck) string {
+	status, label := findContainerStatus(pod, c.Name)
+	if status == nil {
+		return fmt.Sprintf("container %s not found", c.Name)
+	}
+
+	var problems []string
+	if c.Completed != nil {
+		completed := status.State.Terminated != nil && status.State.Terminated.ExitCode == 0
+		switch {
+		case *c.Completed && !completed:
+			problems = append(problems, fmt.Sprintf("%s has not completed (%s)", label, describeState(status.State)))
+		case !*c.Completed && completed:
+			problems = append(problems, fmt.Sprintf("%s has completed, expected it to keep running", label))
+		}
+	}
+	if c.Ready != nil && status.Ready != *c.Ready {
+		if *c.Ready {
+			problems = append(problems, fmt.Sprintf("%s is not ready (%s)", label, describeState(status.State)))
+		} else {
+			problems = append(problems, fmt.Sprintf("%s is ready, expected not ready", label))
+		}
+	}
+	if c.MaxRestarts != nil && status.RestartCount > *c.MaxRestarts {
+		problems = append(problems, fmt.Sprintf("%s restarted %d times, expected at most %d", label, status.RestartCount, *c.MaxRestarts))
+	}
+	return strings.Join(problems, ", ")
+}
+
+// findContainerStatus returns the status of the named container, looking at init
+// containers (which include native sidecars) after the regular ones, and how to
+// refer to it in messages.
+func findContainerStatus(pod *corev1.Pod, name string) (*corev1.ContainerStatus, string) {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == name {
+			return &pod.Status.ContainerStatuses[i], "container " + name
+		}
+	}
+	for i := range pod.Status.InitContainerStatuses {
+		if pod.Status.InitContainerStatuses[i].Name == name {
+			return &pod.Status.InitContainerStatuses[i], "init container " + name
+		}
+	}
+	return nil, ""
+}
+
+// describeState renders a container state, e.g. "waiting: CrashLoopBackOff".
+func describeState(state corev1.ContainerState) string {
+	switch {
+	case state.Waiting != nil:
+		return "waiting: " + state.Waiting.Reason
+	case state.Terminated != nil:
+		return fmt.Sprintf("terminated: %s, exit code %d", state.Terminated.Reason, state.Terminated.ExitCode)
+	case state.Running != nil:
+		return "running"
+	default:
+		return "not started"
+	}
+}
+
+// evaluateChecks returns one message per check that obj, referenced by ref, does not
+// satisfy, and the comparisons of the checks that could be evaluated.
+func evaluateChecks(obj map[string]interface{}, checks []vtypes.StatusCheck, ref *vtypes.ObjectRef) ([]string, []vtypes.Comparison) {
+	var messages []string
+	var comparisons []vtypes.Comparison
+	for _, check := range checks {
+		value, found, err := fieldpath.Get(obj, check.Field)
 		if err != nil {
-			allPassed = false
 			messages = append(messages, fmt.Sprintf("Field %s: %v", check.Field, err))
 			continue
 		}
+		comparison := vtypes.Comparison{Field: check.Field, Operator: check.Operator, Expected: check.Value, ObjectRef: ref}
 		if !found {
-			allPassed = false
 			messages = append(messages, fmt.Sprintf("Field %s not found", check.Field))
+			comparisons = append(comparisons, comparison)
 			continue
 		}
+		comparison.Observed = value
 
 		passed, compErr := shared.CompareTypedValues(value, check.Operator, check.Value)
 		if compErr != nil {
-			allPassed = false
 			messages = append(messages, fmt.Sprintf("Field %s: %v", check.Field, compErr))
 			continue
 		}
-
 		if !passed {
-			allPassed = false
 			messages = append(messages, fmt.Sprintf("%s: got %v, expected %s %v", check.Field, value, check.Operator, check.Value))
+			comparisons = append(comparisons, comparison)
 		}
 	}
-
-	if allPassed {
-		return true, msgAllChecksPassed, nil
-	}
-	return false, strings.Join(messages, "; "), nil
+	return messages, comparisons
 }
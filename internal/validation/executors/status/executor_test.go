@@ -10,9 +10,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func deps(dynamicClient *dynamicfake.FakeDynamicClient) shared.Deps {
@@ -34,6 +38,14 @@ func deployment(name, namespace string, statusFields map[string]interface{}) *un
 	return &unstructured.Unstructured{Object: obj}
 }
 
+// compareMessage returns the message of the *shared.CompareError a failed check returns.
+func compareMessage(t *testing.T, err error) string {
+	t.Helper()
+	var compare *shared.CompareError
+	require.ErrorAs(t, err, &compare)
+	return compare.Message
+}
+
 func TestExecute_Success(t *testing.T) {
 	d := deployment("test-deployment", "test-ns", map[string]interface{}{"readyReplicas": int64(3)})
 	spec := vtypes.StatusSpec{
@@ -54,8 +66,8 @@ func TestExecute_CheckFailed(t *testing.T) {
 		Checks: []vtypes.StatusCheck{{Field: "readyReplicas", Operator: ">=", Value: int64(3)}},
 	}
 
-	passed, msg, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
-	require.NoError(t, err)
+	passed, _, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
+	msg := compareMessage(t, err)
 	assert.False(t, passed)
 	assert.Contains(t, msg, "got 1, expected >= 3")
 }
@@ -105,8 +117,8 @@ func TestExecute_FieldNotFound(t *testing.T) {
 		Checks: []vtypes.StatusCheck{{Field: "nonexistentField", Operator: "==", Value: int64(0)}},
 	}
 
-	passed, msg, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
-	require.NoError(t, err)
+	passed, _, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
+	msg := compareMessage(t, err)
 	assert.False(t, passed)
 	assert.Contains(t, msg, "not found")
 }
@@ -131,8 +143,8 @@ func TestExecute_InOperator_Fails(t *testing.T) {
 		Checks: []vtypes.StatusCheck{{Field: "phase", Operator: "in", Value: []interface{}{"Running", "Succeeded"}}},
 	}
 
-	passed, msg, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
-	require.NoError(t, err)
+	passed, _, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
+	msg := compareMessage(t, err)
 	assert.False(t, passed)
 	assert.Contains(t, msg, "got Failed")
 }
@@ -157,8 +169,196 @@ func TestExecute_ContainsOperator_Fails(t *testing.T) {
 		Checks: []vtypes.StatusCheck{{Field: "message", Operator: "contains", Value: "successfully"}},
 	}
 
-	passed, msg, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
-	require.NoError(t, err)
+	passed, _, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
+	msg := compareMessage(t, err)
 	assert.False(t, passed)
 	assert.Contains(t, msg, "message")
 }
+
+func revisionObject(apiVersion, kind, name, uid string, annotations map[string]interface{}, ownerUID string, status map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name, "namespace": "test-ns", "uid": uid}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	if ownerUID != "" {
+		metadata["ownerReferences"] = []interface{}{map[string]interface{}{"apiVersion": "apps/v1", "kind": "Owner", "name": "owner", "uid": ownerUID}}
+	}
+	obj := map[string]interface{}{"apiVersion": apiVersion, "kind": kind, "metadata": metadata}
+	if status != nil {
+		obj["status"] = status
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func revisionPod(name, ownerUID string, labels map[string]string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "test-ns", Labels: labels,
+			OwnerReferences: []metav1.OwnerReference{{Name: "owner", UID: types.UID(ownerUID)}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func revisionDeps(pods []runtime.Object, objects ...runtime.Object) shared.Deps {
+	return shared.Deps{
+		Clientset: fake.NewClientset(pods...),
+		DynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			{Group: "apps", Version: "v1", Resource: "replicasets"}: "ReplicaSetList",
+		}, objects...),
+		Namespace: "test-ns",
+	}
+}
+
+func TestExecute_LatestRevisionOnly_Deployment(t *testing.T) {
+	rev := "deployment.kubernetes.io/revision"
+	objects := []runtime.Object{
+		revisionObject("apps/v1", "Deployment", "web", "deploy-uid", map[string]interface{}{rev: "2"}, "", nil),
+		revisionObject("apps/v1", "ReplicaSet", "web-old", "rs-1", map[string]interface{}{rev: "1"}, "deploy-uid", nil),
+		revisionObject("apps/v1", "ReplicaSet", "web-new", "rs-2", map[string]interface{}{rev: "2"}, "deploy-uid", nil),
+	}
+	spec := vtypes.StatusSpec{
+		Target:             vtypes.Target{Kind: "Deployment", Name: "web"},
+		Checks:             []vtypes.StatusCheck{{Field: "phase", Operator: "==", Value: "Running"}},
+		LatestRevisionOnly: true,
+	}
+
+	// The old pod is crash-looping, but only the new revision counts
+	d := revisionDeps([]runtime.Object{
+		revisionPod("web-old-a", "rs-1", nil, corev1.PodFailed),
+		revisionPod("web-new-a", "rs-2", nil, corev1.PodRunning),
+		revisionPod("web-new-b", "rs-2", nil, corev1.PodRunning),
+	}, objects...)
+	passed, msg, err := status.Execute(context.Background(), spec, d)
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+
+	d = revisionDeps([]runtime.Object{
+		revisionPod("web-old-a", "rs-1", nil, corev1.PodRunning),
+		revisionPod("web-new-a", "rs-2", nil, corev1.PodPending),
+	}, objects...)
+	passed, _, err = status.Execute(context.Background(), spec, d)
+	assert.False(t, passed)
+	assert.Equal(t, `Pod web-new-a: phase: got Pending, expected == Running`, compareMessage(t, err))
+
+	d = revisionDeps([]runtime.Object{revisionPod("web-old-a", "rs-1", nil, corev1.PodRunning)}, objects...)
+	passed, msg, err = status.Execute(context.Background(), spec, d)
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No pods of the latest revision found", msg)
+}
+
+func TestExecute_LatestRevisionOnly_StatefulSet(t *testing.T) {
+	sts := revisionObject("apps/v1", "StatefulSet", "db", "sts-uid", nil, "", map[string]interface{}{"updateRevision": "db-7f9"})
+	spec := vtypes.StatusSpec{
+		Target:             vtypes.Target{Kind: "StatefulSet", Name: "db"},
+		Checks:             []vtypes.StatusCheck{{Field: "phase", Operator: "==", Value: "Running"}},
+		LatestRevisionOnly: true,
+	}
+	d := revisionDeps([]runtime.Object{
+		revisionPod("db-0", "sts-uid", map[string]string{"controller-revision-hash": "db-7f9"}, corev1.PodRunning),
+		revisionPod("db-1", "sts-uid", map[string]string{"controller-revision-hash": "db-5c2"}, corev1.PodPending),
+	}, sts)
+
+	passed, msg, err := status.Execute(context.Background(), spec, d)
+	require.NoError(t, err)
+	assert.True(t, passed, msg)
+}
+
+func TestExecute_Containers(t *testing.T) {
+	yes, no := true, false
+	maxRestarts := int32(2)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "test-ns", Labels: map[string]string{"app": "web"}},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: "migrate", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}}},
+				{Name: "seed", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Ready: true, RestartCount: 1, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{Name: "proxy", RestartCount: 5, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+	d := shared.Deps{Clientset: fake.NewClientset(pod), Namespace: "test-ns"}
+	target := vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}}
+
+	tests := []struct {
+		name    string
+		checks  []vtypes.ContainerStatusCheck
+		passed  bool
+		message string
+	}{
+		{
+			name:   "healthy containers",
+			checks: []vtypes.ContainerStatusCheck{{Name: "migrate", Completed: &yes}, {Name: "app", Ready: &yes, MaxRestarts: &maxRestarts}},
+			passed: true,
+		},
+		{
+			name:    "failed init container",
+			checks:  []vtypes.ContainerStatusCheck{{Name: "seed", Completed: &yes}},
+			message: "Pod web-0: init container seed has not completed (terminated: Error, exit code 1)",
+		},
+		{
+			name:    "crash-looping sidecar",
+			checks:  []vtypes.ContainerStatusCheck{{Name: "proxy", Ready: &yes, MaxRestarts: &maxRestarts}},
+			message: "Pod web-0: container proxy is not ready (waiting: CrashLoopBackOff), container proxy restarted 5 times, expected at most 2",
+		},
+		{
+			name:    "expected not ready",
+			checks:  []vtypes.ContainerStatusCheck{{Name: "app", Ready: &no}},
+			message: "Pod web-0: container app is ready, expected not ready",
+		},
+		{
+			name:    "unknown container",
+			checks:  []vtypes.ContainerStatusCheck{{Name: "missing", Ready: &yes}},
+			message: "Pod web-0: container missing not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := vtypes.StatusSpec{Target: target, Containers: tt.checks}
+			passed, msg, err := status.Execute(context.Background(), spec, d)
+			require.NoError(t, err)
+			assert.Equal(t, tt.passed, passed)
+			if !tt.passed {
+				assert.Equal(t, tt.message, msg)
+			}
+		})
+	}
+}
+
+func TestExecute_ContainersNoPods(t *testing.T) {
+	yes := true
+	spec := vtypes.StatusSpec{
+		Target:     vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}},
+		Containers: []vtypes.ContainerStatusCheck{{Name: "app", Ready: &yes}},
+	}
+
+	passed, msg, err := status.Execute(context.Background(), spec, shared.Deps{Clientset: fake.NewClientset(), Namespace: "test-ns"})
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "No pods found to check containers", msg)
+}
+
+func TestExecute_ReportsComparisons(t *testing.T) {
+	d := deployment("web", "test-ns", map[string]interface{}{"readyReplicas": int64(1)})
+	spec := vtypes.StatusSpec{
+		Target: vtypes.Target{Kind: "Deployment", Name: "web"},
+		Checks: []vtypes.StatusCheck{
+			{Field: "readyReplicas", Operator: ">=", Value: int64(3)},
+			{Field: "availableReplicas", Operator: "==", Value: int64(3)},
+			{Field: "readyReplicas", Operator: "==", Value: int64(1)},
+		},
+	}
+
+	_, _, err := status.Execute(context.Background(), spec, deps(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), d)))
+	var compare *shared.CompareError
+	require.ErrorAs(t, err, &compare)
+	ref := &vtypes.ObjectRef{Kind: "Deployment", Namespace: "test-ns", Name: "web"}
+	assert.Equal(t, []vtypes.Comparison{
+		{Field: "readyReplicas", Operator: ">=", Expected: int64(3), Observed: int64(1), ObjectRef: ref},
+		{Field: "availableReplicas", Operator: "==", Expected: int64(3), ObjectRef: ref},
+	}, compare.Comparisons)
+}
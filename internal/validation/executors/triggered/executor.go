@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +34,12 @@ const (
 // the parent validation package (which would create a circular dependency).
 type ExecuteFunc func(ctx context.Context, v vtypes.Validation) vtypes.Result
 
+func init() {
+	engine.Register(vtypes.TypeTriggered, engine.TypedWithEnv(func(ctx context.Context, spec vtypes.TriggeredSpec, env engine.Env) (bool, string, error) {
+		return Execute(ctx, spec, env.Deps, env.Execute)
+	}))
+}
+
 // Execute runs a trigger action, waits, then runs then validators.
 func Execute(ctx context.Context, spec vtypes.TriggeredSpec, deps shared.Deps, execFn ExecuteFunc) (bool, string, error) {
 	logger.Debug("Executing triggered validation: trigger type=%s", spec.Trigger.Type)
@@ -177,7 +184,8 @@ func executeTriggerLoadFromPod(ctx context.Context, trigger vtypes.TriggerConfig
 		fmt.Sprintf("for i in $(seq 1 %d); do curl -s -o /dev/null -- %s; done", totalRequests, quotedURL),
 	}
 
-	req := deps.Clientset.CoreV1().RESTClient().Post().
+	clientset, restConfig := deps.PodClients(pod.Namespace)
+	req := clientset.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(pod.Name).
 		Namespace(pod.Namespace).
@@ -188,7 +196,7 @@ func executeTriggerLoadFromPod(ctx context.Context, trigger vtypes.TriggerConfig
 			Stderr:  true,
 		}, scheme.ParameterCodec)
 
-	exec, err := remotecommand.NewSPDYExecutor(deps.RestConfig, "POST", req.URL())
+	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
 	if err != nil {
 		return fmt.Errorf("load trigger: failed to create executor: %w", err)
 	}
@@ -0,0 +1,463 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/plugin"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/executors/prommetrics"
+)
+
+// Explain renders what a validation checks as human-readable lines, so learners can
+// understand an objective without reading challenge.yaml. Nested validations (the
+// "then" of a triggered objective) are indented.
+func Explain(v Validation) []string {
+	switch spec := v.Spec.(type) {
+	case StatusSpec:
+		subject := DescribeTarget(spec.Target)
+		if spec.LatestRevisionOnly {
+			subject = "each pod of the latest revision of " + subject
+		}
+		lines := []string{fmt.Sprintf("Reads the status of %s and checks that:", subject)}
+		for _, c := range spec.Checks {
+			lines = append(lines, fmt.Sprintf("  - %s %s %v", c.Field, c.Operator, formatExpected(c.Value)))
+		}
+		for _, c := range spec.Containers {
+			lines = append(lines, describeContainerCheck(c)...)
+		}
+		return lines
+
+	case ConditionSpec:
+		lines := []string{fmt.Sprintf("Checks the conditions of %s:", DescribeTarget(spec.Target))}
+		for _, c := range spec.Checks {
+			lines = append(lines, fmt.Sprintf("  - %s is %s", c.Type, c.Status))
+		}
+		return lines
+
+	case LogSpec:
+		mode := "all of"
+		if spec.MatchMode == MatchModeAnyOf {
+			mode = "any of"
+		}
+		source := "the logs"
+		if spec.Previous {
+			source = "the logs of the previous (crashed) container"
+		}
+		if spec.Container != "" {
+			source += fmt.Sprintf(" of container %q", spec.Container)
+		}
+		lines := []string{fmt.Sprintf("Searches %s of %s for %s:", source, DescribeTarget(spec.Target), mode)}
+		for _, s := range spec.ExpectedStrings {
+			lines = append(lines, fmt.Sprintf("  - %q", s))
+		}
+		if spec.SinceSeconds > 0 {
+			lines = append(lines, fmt.Sprintf("Only the last %ds of logs are searched.", spec.SinceSeconds))
+		}
+		return lines
+
+	case EventSpec:
+		lines := []string{fmt.Sprintf("Looks at the events of %s:", DescribeTarget(spec.Target))}
+		if len(spec.ForbiddenReasons) > 0 {
+			lines = append(lines, fmt.Sprintf("  - none may have reason %s", strings.Join(spec.ForbiddenReasons, ", ")))
+		}
+		if len(spec.RequiredReasons) > 0 {
+			lines = append(lines, fmt.Sprintf("  - each of %s must have occurred", strings.Join(spec.RequiredReasons, ", ")))
+		}
+		if spec.SinceSeconds > 0 {
+			lines = append(lines, fmt.Sprintf("Only events from the last %ds count.", spec.SinceSeconds))
+		}
+		return lines
+
+	case ConnectivitySpec:
+		from := "from the CLI host"
+		if spec.Mode != ConnectivityModeExternal {
+			from = "from " + describeSourcePod(spec.SourcePod)
+		}
+		lines := []string{fmt.Sprintf("Sends HTTP requests %s and expects:", from)}
+		for _, c := range spec.Targets {
+			line := fmt.Sprintf("  - %s to answer %d", c.URL, c.ExpectedStatusCode)
+			if c.HostHeader != "" {
+				line += fmt.Sprintf(" (Host: %s)", c.HostHeader)
+			}
+			lines = append(lines, line)
+		}
+		return lines
+
+	case RbacSpec:
+		lines := []string{fmt.Sprintf("Asks the API server what ServiceAccount %s/%s may do:", spec.Namespace, spec.ServiceAccount)}
+		for _, c := range spec.Checks {
+			verdict := "must be allowed"
+			if !c.Allowed {
+				verdict = "must be denied"
+			}
+			resource := c.Resource
+			if c.Subresource != "" {
+				resource += "/" + c.Subresource
+			}
+			lines = append(lines, fmt.Sprintf("  - %s %s %s", c.Verb, resource, verdict))
+		}
+		return lines
+
+	case SpecSpec:
+		lines := []string{fmt.Sprintf("Reads the manifest of %s and checks that:", DescribeTarget(spec.Target))}
+		for _, c := range spec.Checks {
+			switch {
+			case c.Exists != nil && *c.Exists:
+				lines = append(lines, fmt.Sprintf("  - %s is set", c.Path))
+			case c.Exists != nil:
+				lines = append(lines, fmt.Sprintf("  - %s is not set", c.Path))
+			case c.Contains != nil:
+				lines = append(lines, fmt.Sprintf("  - %s contains %v", c.Path, formatExpected(c.Contains)))
+			default:
+				lines = append(lines, fmt.Sprintf("  - %s == %v", c.Path, formatExpected(c.Value)))
+			}
+		}
+		return lines
+
+	case TriggeredSpec:
+		lines := []string{fmt.Sprintf("First %s,", describeTrigger(spec.Trigger))}
+		if spec.WaitAfterSeconds > 0 {
+			lines = append(lines, fmt.Sprintf("waits %ds,", spec.WaitAfterSeconds))
+		}
+		lines = append(lines, "then runs these checks:")
+		for _, then := range spec.Then {
+			title := then.Title
+			if title == "" {
+				title = then.Key
+			}
+			lines = append(lines, fmt.Sprintf("  - %s (%s)", title, then.Type))
+			for _, l := range Explain(then) {
+				lines = append(lines, "    "+l)
+			}
+		}
+		return lines
+
+	case PluginSpec:
+		binary := plugin.BinaryPrefix + spec.Name
+		if len(spec.Config) == 0 {
+			return []string{fmt.Sprintf("Runs the external validator %s.", binary)}
+		}
+		lines := []string{fmt.Sprintf("Runs the external validator %s with:", binary)}
+		keys := make([]string, 0, len(spec.Config))
+		for k := range spec.Config {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("  - %s: %v", k, spec.Config[k]))
+		}
+		return lines
+
+	case PromMetricsSpec:
+		source := spec.URL
+		if spec.Target != nil {
+			source = fmt.Sprintf("port %d%s of %s", spec.Port, spec.Path, DescribeTarget(*spec.Target))
+		}
+		lines := []string{fmt.Sprintf("Scrapes the metrics at %s from a probe pod and checks that:", source)}
+		for _, c := range spec.Checks {
+			lines = append(lines, fmt.Sprintf("  - %s %s %v", prommetrics.DescribeMetric(c), c.Operator, c.Value))
+		}
+		return lines
+
+	case EndpointsSpec:
+		return []string{fmt.Sprintf("Checks that Service %q has %s %d ready endpoint(s).", spec.Service, spec.Operator, spec.ReadyEndpoints)}
+
+	case NetworkPolicySpec:
+		return []string{fmt.Sprintf("Evaluates the NetworkPolicies and expects %s/%d traffic from %s to %s to be %s.",
+			spec.Protocol, spec.Port, describePolicyPeer(spec.From), describePolicyPeer(spec.To), spec.Expect)}
+
+	case NodeSpec:
+		return explainNode(spec)
+
+	case PodDisruptionBudgetSpec:
+		subject := fmt.Sprintf("PodDisruptionBudget %q", spec.Name)
+		if spec.Target != nil {
+			subject = "the PodDisruptionBudget covering " + DescribeTarget(*spec.Target)
+		}
+		lines := []string{fmt.Sprintf("Checks that %s exists and that:", subject)}
+		if spec.MinAvailable != "" {
+			lines = append(lines, "  - minAvailable is "+spec.MinAvailable)
+		}
+		if spec.MaxUnavailable != "" {
+			lines = append(lines, "  - maxUnavailable is "+spec.MaxUnavailable)
+		}
+		if spec.DisruptionsAllowed != nil {
+			lines = append(lines, fmt.Sprintf("  - disruptionsAllowed %s %d", spec.Operator, *spec.DisruptionsAllowed))
+		}
+		if len(lines) == 1 {
+			return []string{fmt.Sprintf("Checks that %s exists.", subject)}
+		}
+		return lines
+
+	case ProbesSpec:
+		containers := "every container"
+		if spec.Container != "" {
+			containers = fmt.Sprintf("container %q", spec.Container)
+		}
+		lines := []string{fmt.Sprintf("Checks the probes of %s in %s:", containers, DescribeTarget(spec.Target))}
+		for _, p := range spec.Probes {
+			lines = append(lines, "  - "+describeProbeCheck(p))
+		}
+		return lines
+
+	case ImagesSpec:
+		containers := "every container"
+		if spec.Container != "" {
+			containers = fmt.Sprintf("container %q", spec.Container)
+		}
+		lines := []string{fmt.Sprintf("Checks the image of %s in %s:", containers, DescribeTarget(spec.Target))}
+		if spec.ForbidLatest {
+			lines = append(lines, "  - does not use the latest tag")
+		}
+		if spec.RequireDigest {
+			lines = append(lines, "  - is pinned to a digest")
+		}
+		if len(spec.AllowedRegistries) > 0 {
+			lines = append(lines, "  - comes from "+strings.Join(spec.AllowedRegistries, ", "))
+		}
+		if spec.PullPolicy != "" {
+			lines = append(lines, "  - is pulled with imagePullPolicy "+spec.PullPolicy)
+		}
+		return lines
+
+	case ResourcesSpec:
+		containers := "every container"
+		if spec.Container != "" {
+			containers = fmt.Sprintf("container %q", spec.Container)
+		}
+		lines := []string{fmt.Sprintf("Checks that %s in %s sets:", containers, DescribeTarget(spec.Target))}
+		lines = append(lines, describeResourceBounds("request", spec.Requests)...)
+		return append(lines, describeResourceBounds("limit", spec.Limits)...)
+
+	case MetadataSpec:
+		lines := []string{fmt.Sprintf("Checks the metadata of %s:", DescribeTarget(spec.Target))}
+		lines = append(lines, describeMetadataChecks("label", spec.Labels)...)
+		return append(lines, describeMetadataChecks("annotation", spec.Annotations)...)
+
+	default:
+		return []string{fmt.Sprintf("Runs a %s validation.", v.Type)}
+	}
+}
+
+// describeContainerCheck renders each assertion of c, e.g. "container app is ready".
+func describeContainerCheck(c ContainerStatusCheck) []string {
+	var lines []string
+	if c.Completed != nil {
+		verb := "has completed successfully"
+		if !*c.Completed {
+			verb = "has not completed"
+		}
+		lines = append(lines, fmt.Sprintf("  - container %s %s", c.Name, verb))
+	}
+	if c.Ready != nil {
+		verb := "is ready"
+		if !*c.Ready {
+			verb = "is not ready"
+		}
+		lines = append(lines, fmt.Sprintf("  - container %s %s", c.Name, verb))
+	}
+	if c.MaxRestarts != nil {
+		lines = append(lines, fmt.Sprintf("  - container %s restarted at most %d times", c.Name, *c.MaxRestarts))
+	}
+	return lines
+}
+
+// DescribeTarget renders a target, e.g. `Deployment "web"` or `Pods with labels app=web`.
+func DescribeTarget(t Target) string {
+	kind := t.Kind
+	if kind == "" {
+		kind = "Pod"
+	}
+	if t.Name != "" {
+		return fmt.Sprintf("%s %q", kind, t.Name)
+	}
+	if len(t.LabelSelector) > 0 {
+		return fmt.Sprintf("%ss with labels %s", kind, formatLabels(t.LabelSelector))
+	}
+	return fmt.Sprintf("every %s", kind)
+}
+
+func describeSourcePod(p SourcePod) string {
+	var desc string
+	switch {
+	case p.Name != "":
+		desc = fmt.Sprintf("pod %q", p.Name)
+	case len(p.LabelSelector) > 0:
+		desc = fmt.Sprintf("a pod with labels %s", formatLabels(p.LabelSelector))
+	default:
+		desc = "a probe pod"
+	}
+	if p.Namespace != "" {
+		desc += fmt.Sprintf(" in namespace %s", p.Namespace)
+	}
+	return desc
+}
+
+func describeTrigger(t TriggerConfig) string {
+	target := ""
+	if t.Target != nil {
+		target = " " + DescribeTarget(*t.Target)
+	}
+	switch t.Type {
+	case TriggerTypeLoad:
+		return fmt.Sprintf("sends %d requests/s to %s for %ds", t.RequestsPerSecond, t.URL, t.DurationSeconds)
+	case TriggerTypeWait:
+		return fmt.Sprintf("waits %ds", t.WaitSeconds)
+	case TriggerTypeDelete:
+		return "deletes" + target
+	case TriggerTypeRollout:
+		return fmt.Sprintf("rolls out image %s on%s", t.Image, target)
+	case TriggerTypeScale:
+		replicas := "?"
+		if t.Replicas != nil {
+			replicas = fmt.Sprintf("%d", *t.Replicas)
+		}
+		return fmt.Sprintf("scales%s to %s replicas", target, replicas)
+	default:
+		return fmt.Sprintf("runs the %s trigger", t.Type)
+	}
+}
+
+// formatLabels renders a label selector in kubectl syntax, sorted by key.
+func explainNode(spec NodeSpec) []string {
+	var lines []string
+	nodes := "every node"
+	if len(spec.NodeSelector) > 0 {
+		nodes = "the nodes with labels " + formatLabels(spec.NodeSelector)
+	}
+	if len(spec.Labels) > 0 || len(spec.Taints) > 0 || len(spec.Conditions) > 0 || len(spec.MinAllocatable) > 0 {
+		lines = append(lines, fmt.Sprintf("Checks %s for:", nodes))
+		if len(spec.Labels) > 0 {
+			lines = append(lines, "  - labels "+formatLabels(spec.Labels))
+		}
+		for _, t := range spec.Taints {
+			taint := t.Key
+			if t.Value != "" {
+				taint += "=" + t.Value
+			}
+			if t.Effect != "" {
+				taint += ":" + t.Effect
+			}
+			lines = append(lines, "  - taint "+taint)
+		}
+		for _, c := range spec.Conditions {
+			lines = append(lines, fmt.Sprintf("  - condition %s %s", c.Type, c.Status))
+		}
+		names := make([]string, 0, len(spec.MinAllocatable))
+		for name := range spec.MinAllocatable {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lines = append(lines, fmt.Sprintf("  - at least %s allocatable %s", spec.MinAllocatable[name], name))
+		}
+	}
+	if spec.Target != nil {
+		line := fmt.Sprintf("Checks that every pod of %s is scheduled", DescribeTarget(*spec.Target))
+		if len(spec.ScheduledOn) > 0 {
+			line += " on a node with labels " + formatLabels(spec.ScheduledOn)
+		}
+		lines = append(lines, line+".")
+	}
+	return lines
+}
+
+func describeProbeCheck(p ProbeCheck) string {
+	var details []string
+	if p.Path != "" {
+		details = append(details, "path "+p.Path)
+	}
+	if p.Port != "" {
+		details = append(details, "port "+p.Port)
+	}
+	for _, f := range []struct {
+		name  string
+		value *int32
+	}{
+		{"initialDelaySeconds", p.InitialDelaySeconds},
+		{"periodSeconds", p.PeriodSeconds},
+		{"timeoutSeconds", p.TimeoutSeconds},
+		{"failureThreshold", p.FailureThreshold},
+		{"successThreshold", p.SuccessThreshold},
+	} {
+		if f.value != nil {
+			details = append(details, fmt.Sprintf("%s %d", f.name, *f.value))
+		}
+	}
+	desc := p.Type + " probe is defined"
+	if len(details) > 0 {
+		desc += " with " + strings.Join(details, ", ")
+	}
+	if p.Exercise {
+		desc += ", and its endpoint answers from a probe pod"
+	}
+	return desc
+}
+
+func describeResourceBounds(kind string, bounds map[string]ResourceBounds) []string {
+	names := make([]string, 0, len(bounds))
+	for name := range bounds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		line := fmt.Sprintf("  - a %s %s", name, kind)
+		switch b := bounds[name]; {
+		case b.Min != "" && b.Max != "":
+			line += fmt.Sprintf(" between %s and %s", b.Min, b.Max)
+		case b.Min != "":
+			line += " of at least " + b.Min
+		case b.Max != "":
+			line += " of at most " + b.Max
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func describeMetadataChecks(kind string, checks []MetadataCheck) []string {
+	lines := make([]string, 0, len(checks))
+	for _, c := range checks {
+		switch {
+		case c.Exists != nil && !*c.Exists:
+			lines = append(lines, fmt.Sprintf("  - %s %s is not set", kind, c.Key))
+		case c.Value != "":
+			lines = append(lines, fmt.Sprintf("  - %s %s=%s", kind, c.Key, c.Value))
+		case c.Matches != "":
+			lines = append(lines, fmt.Sprintf("  - %s %s matches %q", kind, c.Key, c.Matches))
+		default:
+			lines = append(lines, fmt.Sprintf("  - %s %s is set", kind, c.Key))
+		}
+	}
+	return lines
+}
+
+func describePolicyPeer(p NetworkPolicyPeer) string {
+	desc := "pods without labels"
+	if len(p.Labels) > 0 {
+		desc = "pods with labels " + formatLabels(p.Labels)
+	}
+	if p.Namespace != "" {
+		desc += " in namespace " + p.Namespace
+	}
+	return desc
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// formatExpected quotes string values so "True" and true read differently.
+func formatExpected(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,247 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	exists := false
+	replicas := int32(3)
+	completed := true
+	maxRestarts := int32(0)
+	one := 1
+	tests := []struct {
+		name string
+		v    Validation
+		want []string
+	}{
+		{
+			name: "status",
+			v: Validation{Type: TypeStatus, Spec: StatusSpec{
+				Target: Target{Kind: "Deployment", Name: "web"},
+				Checks: []StatusCheck{{Field: "readyReplicas", Operator: ">=", Value: 3}, {Field: "conditions[type=Available].status", Operator: "==", Value: "True"}},
+			}},
+			want: []string{
+				`Reads the status of Deployment "web" and checks that:`,
+				"  - readyReplicas >= 3",
+				`  - conditions[type=Available].status == "True"`,
+			},
+		},
+		{
+			name: "status on latest revision pods",
+			v: Validation{Type: TypeStatus, Spec: StatusSpec{
+				Target: Target{Kind: "Deployment", Name: "web"}, LatestRevisionOnly: true,
+				Checks: []StatusCheck{{Field: "phase", Operator: "==", Value: "Running"}},
+			}},
+			want: []string{`Reads the status of each pod of the latest revision of Deployment "web" and checks that:`, `  - phase == "Running"`},
+		},
+		{
+			name: "status with containers",
+			v: Validation{Type: TypeStatus, Spec: StatusSpec{
+				Target:     Target{Kind: "Pod", Name: "web"},
+				Containers: []ContainerStatusCheck{{Name: "migrate", Completed: &completed}, {Name: "app", Ready: &completed, MaxRestarts: &maxRestarts}},
+			}},
+			want: []string{
+				`Reads the status of Pod "web" and checks that:`,
+				"  - container migrate has completed successfully",
+				"  - container app is ready",
+				"  - container app restarted at most 0 times",
+			},
+		},
+		{
+			name: "condition with label selector",
+			v: Validation{Type: TypeCondition, Spec: ConditionSpec{
+				Target: Target{Kind: "Pod", LabelSelector: map[string]string{"tier": "web", "app": "shop"}},
+				Checks: []ConditionCheck{{Type: "Ready", Status: "True"}},
+			}},
+			want: []string{"Checks the conditions of Pods with labels app=shop,tier=web:", "  - Ready is True"},
+		},
+		{
+			name: "log",
+			v: Validation{Type: TypeLog, Spec: LogSpec{
+				Target: Target{Kind: "Pod", Name: "api"}, Container: "app", ExpectedStrings: []string{"started"}, MatchMode: MatchModeAnyOf, SinceSeconds: 60,
+			}},
+			want: []string{`Searches the logs of container "app" of Pod "api" for any of:`, `  - "started"`, "Only the last 60s of logs are searched."},
+		},
+		{
+			name: "external connectivity",
+			v: Validation{Type: TypeConnectivity, Spec: ConnectivitySpec{
+				Mode:    ConnectivityModeExternal,
+				Targets: []ConnectivityCheck{{URL: "https://shop.local", ExpectedStatusCode: 200, HostHeader: "shop.local"}},
+			}},
+			want: []string{"Sends HTTP requests from the CLI host and expects:", "  - https://shop.local to answer 200 (Host: shop.local)"},
+		},
+		{
+			name: "rbac",
+			v: Validation{Type: TypeRbac, Spec: RbacSpec{
+				ServiceAccount: "reader", Namespace: "ns",
+				Checks: []RbacCheck{{Verb: "get", Resource: "pods", Subresource: "log", Allowed: true}, {Verb: "delete", Resource: "pods"}},
+			}},
+			want: []string{"Asks the API server what ServiceAccount ns/reader may do:", "  - get pods/log must be allowed", "  - delete pods must be denied"},
+		},
+		{
+			name: "spec",
+			v: Validation{Type: TypeSpec, Spec: SpecSpec{
+				Target: Target{Kind: "Deployment", Name: "web"},
+				Checks: []SpecCheck{{Path: "spec.template.spec.containers[0].livenessProbe", Exists: &exists}},
+			}},
+			want: []string{`Reads the manifest of Deployment "web" and checks that:`, "  - spec.template.spec.containers[0].livenessProbe is not set"},
+		},
+		{
+			name: "triggered",
+			v: Validation{Type: TypeTriggered, Spec: TriggeredSpec{
+				Trigger:          TriggerConfig{Type: TriggerTypeScale, Target: &Target{Kind: "Deployment", Name: "web"}, Replicas: &replicas},
+				WaitAfterSeconds: 10,
+				Then: []Validation{{Key: "ready", Type: TypeCondition, Spec: ConditionSpec{
+					Target: Target{Kind: "Deployment", Name: "web"}, Checks: []ConditionCheck{{Type: "Available", Status: "True"}},
+				}}},
+			}},
+			want: []string{
+				`First scales Deployment "web" to 3 replicas,`,
+				"waits 10s,",
+				"then runs these checks:",
+				"  - ready (condition)",
+				`    Checks the conditions of Deployment "web":`,
+				"      - Available is True",
+			},
+		},
+		{
+			name: "plugin",
+			v:    Validation{Type: TypePlugin, Spec: PluginSpec{Name: "cert-check", Config: map[string]interface{}{"secret": "tls", "minDays": 30}}},
+			want: []string{"Runs the external validator kubeasy-validator-cert-check with:", "  - minDays: 30", "  - secret: tls"},
+		},
+		{
+			name: "promMetrics",
+			v: Validation{Type: TypePromMetrics, Spec: PromMetricsSpec{
+				Target: &Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}}, Port: 9090, Path: "/metrics",
+				Checks: []PromMetricCheck{{Metric: "http_requests_total", Labels: map[string]string{"code": "500"}, Operator: "<", Value: 5}},
+			}},
+			want: []string{
+				"Scrapes the metrics at port 9090/metrics of Pods with labels app=web from a probe pod and checks that:",
+				`  - http_requests_total{code="500"} < 5`,
+			},
+		},
+		{
+			name: "endpoints",
+			v:    Validation{Type: TypeEndpoints, Spec: EndpointsSpec{Service: "web", Operator: ">=", ReadyEndpoints: 2}},
+			want: []string{`Checks that Service "web" has >= 2 ready endpoint(s).`},
+		},
+		{
+			name: "networkPolicy",
+			v: Validation{Type: TypeNetworkPolicy, Spec: NetworkPolicySpec{
+				From: NetworkPolicyPeer{Namespace: "monitoring", Labels: map[string]string{"app": "prometheus"}},
+				To:   NetworkPolicyPeer{Labels: map[string]string{"app": "web"}},
+				Port: 9090, Protocol: "TCP", Expect: NetworkPolicyAllowed,
+			}},
+			want: []string{"Evaluates the NetworkPolicies and expects TCP/9090 traffic from pods with labels app=prometheus in namespace monitoring to pods with labels app=web to be allowed."},
+		},
+		{
+			name: "node",
+			v: Validation{Type: TypeNode, Spec: NodeSpec{
+				NodeSelector:   map[string]string{"accelerator": "gpu"},
+				Taints:         []NodeTaint{{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"}},
+				MinAllocatable: map[string]string{"memory": "4Gi"},
+				Target:         &Target{Kind: "Deployment", Name: "trainer"},
+				ScheduledOn:    map[string]string{"accelerator": "gpu"},
+			}},
+			want: []string{
+				"Checks the nodes with labels accelerator=gpu for:",
+				"  - taint dedicated=gpu:NoSchedule",
+				"  - at least 4Gi allocatable memory",
+				`Checks that every pod of Deployment "trainer" is scheduled on a node with labels accelerator=gpu.`,
+			},
+		},
+		{
+			name: "podDisruptionBudget",
+			v: Validation{Type: TypePodDisruptionBudget, Spec: PodDisruptionBudgetSpec{
+				Target: &Target{Kind: "Deployment", Name: "web"}, MinAvailable: "50%", Operator: ">=", DisruptionsAllowed: &one,
+			}},
+			want: []string{
+				`Checks that the PodDisruptionBudget covering Deployment "web" exists and that:`,
+				"  - minAvailable is 50%",
+				"  - disruptionsAllowed >= 1",
+			},
+		},
+		{
+			name: "podDisruptionBudget existence",
+			v:    Validation{Type: TypePodDisruptionBudget, Spec: PodDisruptionBudgetSpec{Name: "web"}},
+			want: []string{`Checks that PodDisruptionBudget "web" exists.`},
+		},
+		{
+			name: "probes",
+			v: Validation{Type: TypeProbes, Spec: ProbesSpec{
+				Target:    Target{Kind: "Deployment", Name: "web"},
+				Container: "app",
+				Probes: []ProbeCheck{
+					{Type: ProbeReadiness, Path: "/ready", Port: "http", Exercise: true},
+					{Type: ProbeLiveness, FailureThreshold: &maxRestarts},
+					{Type: ProbeStartup},
+				},
+			}},
+			want: []string{
+				`Checks the probes of container "app" in Deployment "web":`,
+				"  - readiness probe is defined with path /ready, port http, and its endpoint answers from a probe pod",
+				"  - liveness probe is defined with failureThreshold 0",
+				"  - startup probe is defined",
+			},
+		},
+		{
+			name: "images",
+			v: Validation{Type: TypeImages, Spec: ImagesSpec{
+				Target:            Target{Kind: "Deployment", Name: "web"},
+				ForbidLatest:      true,
+				AllowedRegistries: []string{"ghcr.io/kubeasy-dev", "docker.io/library"},
+				PullPolicy:        "IfNotPresent",
+			}},
+			want: []string{
+				`Checks the image of every container in Deployment "web":`,
+				"  - does not use the latest tag",
+				"  - comes from ghcr.io/kubeasy-dev, docker.io/library",
+				"  - is pulled with imagePullPolicy IfNotPresent",
+			},
+		},
+		{
+			name: "resources",
+			v: Validation{Type: TypeResources, Spec: ResourcesSpec{
+				Target:   Target{Kind: "Deployment", Name: "web"},
+				Requests: map[string]ResourceBounds{"memory": {}, "cpu": {Min: "50m", Max: "500m"}},
+				Limits:   map[string]ResourceBounds{"memory": {Max: "1Gi"}},
+			}},
+			want: []string{
+				`Checks that every container in Deployment "web" sets:`,
+				"  - a cpu request between 50m and 500m",
+				"  - a memory request",
+				"  - a memory limit of at most 1Gi",
+			},
+		},
+		{
+			name: "metadata",
+			v: Validation{Type: TypeMetadata, Spec: MetadataSpec{
+				Target:      Target{Kind: "Service", Name: "web"},
+				Labels:      []MetadataCheck{{Key: "tier", Matches: "frontend|backend"}, {Key: "debug", Exists: &exists}},
+				Annotations: []MetadataCheck{{Key: "prometheus.io/scrape", Value: "true"}, {Key: "owner"}},
+			}},
+			want: []string{
+				`Checks the metadata of Service "web":`,
+				`  - label tier matches "frontend|backend"`,
+				"  - label debug is not set",
+				"  - annotation prometheus.io/scrape=true",
+				"  - annotation owner is set",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Explain(tt.v))
+		})
+	}
+}
+
+func TestDescribeTarget(t *testing.T) {
+	assert.Equal(t, `Service "web"`, DescribeTarget(Target{Kind: "Service", Name: "web"}))
+	assert.Equal(t, "Pods with labels app=web", DescribeTarget(Target{LabelSelector: map[string]string{"app": "web"}}))
+	assert.Equal(t, "every Pod", DescribeTarget(Target{}))
+}
@@ -0,0 +1,101 @@
+package fieldpath
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// aggregatePattern matches an aggregation wrapping a path, e.g. "sum(containerStatuses[*].restartCount)".
+var aggregatePattern = regexp.MustCompile(`^(sum|max|min|count)\((.+)\)$`)
+
+// splitAggregate splits "fn(path)" into its function and inner path.
+func splitAggregate(path string) (AggregateFunc, string, bool) {
+	matches := aggregatePattern.FindStringSubmatch(strings.TrimSpace(path))
+	if matches == nil {
+		return "", "", false
+	}
+	return AggregateFunc(matches[1]), strings.TrimSpace(matches[2]), true
+}
+
+// withAggregate appends the aggregation to the tokens of its inner path.
+func withAggregate(tokens []PathToken, fn AggregateFunc, path string) ([]PathToken, error) {
+	if _, nested := tokens[len(tokens)-1].(AggregateToken); nested {
+		return nil, fmt.Errorf("nested aggregations are not supported in path %q", path)
+	}
+	return append(tokens, AggregateToken{Func: fn}), nil
+}
+
+// aggregate reduces a resolved value with fn. Arrays (e.g. the result of a wildcard)
+// are aggregated element by element; any other value counts as a single element.
+// max and min of an empty array are reported as not found.
+func aggregate(value interface{}, fn AggregateFunc) (interface{}, bool, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+
+	if fn == AggregateCount {
+		return int64(len(values)), true, nil
+	}
+	if len(values) == 0 {
+		if fn == AggregateSum {
+			return int64(0), true, nil
+		}
+		return nil, false, nil
+	}
+
+	allInts := true
+	var intResult int64
+	var floatResult float64
+	for i, v := range values {
+		n, isInt, err := toNumber(v)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot compute %s: element %d: %w", fn, i, err)
+		}
+		allInts = allInts && isInt
+		if i == 0 {
+			intResult, floatResult = int64(n), n
+			continue
+		}
+		switch fn {
+		case AggregateSum:
+			intResult += int64(n)
+			floatResult += n
+		case AggregateMax:
+			if n > floatResult {
+				intResult, floatResult = int64(n), n
+			}
+		case AggregateMin:
+			if n < floatResult {
+				intResult, floatResult = int64(n), n
+			}
+		default:
+			return nil, false, fmt.Errorf("unknown aggregation %q", fn)
+		}
+	}
+
+	if allInts {
+		return intResult, true, nil
+	}
+	return floatResult, true, nil
+}
+
+// toNumber converts an unstructured numeric value to float64. isInt reports whether
+// the value was an integer, so integer aggregations keep an integer result.
+func toNumber(v interface{}) (n float64, isInt bool, err error) {
+	switch num := v.(type) {
+	case int:
+		return float64(num), true, nil
+	case int32:
+		return float64(num), true, nil
+	case int64:
+		return float64(num), true, nil
+	case float32:
+		return float64(num), false, nil
+	case float64:
+		return num, false, nil
+	default:
+		return 0, false, fmt.Errorf("expected a number, got %T", v)
+	}
+}
@@ -0,0 +1,134 @@
+package fieldpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func podWithContainers() map[string]interface{} {
+	return map[string]interface{}{
+		"status": map[string]interface{}{
+			"containerStatuses": []interface{}{
+				map[string]interface{}{"name": "app", "restartCount": int64(2), "ready": true},
+				map[string]interface{}{"name": "sidecar", "restartCount": int64(0), "ready": false},
+				map[string]interface{}{"name": "init", "ready": true},
+			},
+			"usage": []interface{}{0.5, int64(2)},
+		},
+	}
+}
+
+func TestParse_WildcardAndAggregate(t *testing.T) {
+	tokens, err := ParseStatus("sum(containerStatuses[*].restartCount)")
+	require.NoError(t, err)
+	assert.Equal(t, []PathToken{
+		FieldToken{Name: "status"},
+		FieldToken{Name: "containerStatuses"},
+		WildcardToken{},
+		FieldToken{Name: "restartCount"},
+		AggregateToken{Func: AggregateSum},
+	}, tokens)
+
+	tokens, err = ParseRaw("count({.spec.containers[*]})")
+	require.NoError(t, err)
+	assert.Equal(t, []PathToken{
+		FieldToken{Name: "spec"},
+		FieldToken{Name: "containers"},
+		WildcardToken{},
+		AggregateToken{Func: AggregateCount},
+	}, tokens)
+
+	_, err = ParseStatus("sum(count(containerStatuses))")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nested aggregations")
+
+	_, err = ParseStatus("avg(containerStatuses[*].restartCount)")
+	require.Error(t, err, "unknown functions are not aggregations")
+}
+
+func TestResolve_Wildcard(t *testing.T) {
+	obj := podWithContainers()
+
+	value, found, err := Get(obj, "containerStatuses[*].restartCount")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []interface{}{int64(2), int64(0)}, value, "elements without the field are skipped")
+
+	value, found, err = Get(obj, "containerStatuses[*].name")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []interface{}{"app", "sidecar", "init"}, value)
+
+	_, _, err = Get(obj, "containerStatuses[0].name[*]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected array")
+}
+
+func TestResolve_NestedWildcardFlattens(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"ports": []interface{}{
+					map[string]interface{}{"containerPort": int64(80)},
+					map[string]interface{}{"containerPort": int64(443)},
+				}},
+				map[string]interface{}{"ports": []interface{}{
+					map[string]interface{}{"containerPort": int64(9090)},
+				}},
+			},
+		},
+	}
+
+	value, found, err := GetRaw(obj, "spec.containers[*].ports[*].containerPort")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []interface{}{int64(80), int64(443), int64(9090)}, value)
+
+	value, _, err = GetRaw(obj, "count(spec.containers[*].ports[*])")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+}
+
+func TestResolve_Aggregate(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		want      interface{}
+		wantFound bool
+	}{
+		{"sum", "sum(containerStatuses[*].restartCount)", int64(2), true},
+		{"max", "max(containerStatuses[*].restartCount)", int64(2), true},
+		{"min", "min(containerStatuses[*].restartCount)", int64(0), true},
+		{"count of wildcard", "count(containerStatuses[*])", int64(3), true},
+		{"count of array", "count(containerStatuses)", int64(3), true},
+		{"count of scalar", "count(containerStatuses[0].name)", int64(1), true},
+		{"mixed int and float", "sum(usage)", 2.5, true},
+		{"sum of no values", "sum(containerStatuses[*].missing)", int64(0), true},
+		{"max of no values", "max(containerStatuses[*].missing)", nil, false},
+		{"missing field", "sum(missing[*].restartCount)", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, found, err := Get(podWithContainers(), tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestResolve_AggregateErrors(t *testing.T) {
+	_, _, err := Get(podWithContainers(), "sum(containerStatuses[*].name)")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot compute sum: element 0: expected a number, got string")
+
+	_, _, err = Resolve(podWithContainers(), []PathToken{AggregateToken{Func: AggregateSum}})
+	require.Error(t, err)
+
+	_, _, err = Resolve(podWithContainers(), []PathToken{AggregateToken{Func: AggregateSum}, FieldToken{Name: "status"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be the last token")
+}
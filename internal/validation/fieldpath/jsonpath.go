@@ -0,0 +1,160 @@
+package fieldpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsJSONPath reports whether path is a kubectl-style JSONPath expression
+// (e.g. "{.status.readyReplicas}") rather than the native field path syntax.
+func IsJSONPath(path string) bool {
+	path = strings.TrimSpace(path)
+	return strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}")
+}
+
+// ParseJSONPath parses a kubectl-style JSONPath expression into tokens. The expression
+// is absolute: it starts from the root of the object, like `kubectl get -o jsonpath`.
+//
+// Supported subset:
+//   - "{.status.readyReplicas}" -> field access (a leading "$" is allowed)
+//   - "{.metadata.labels['app.kubernetes.io/name']}" -> quoted field access
+//   - "{.status.containerStatuses[0].restartCount}" -> array index ([-1] is the last element)
+//   - "{.status.conditions[-2:]}" -> array slice
+//   - "{.status.conditions[?(@.type=="Ready")].status}" -> array filter (equality only)
+//   - "{.status.containerStatuses[*].restartCount}" -> wildcard
+//
+// Recursive descent (".."), slice steps, unions and multiple templates are rejected.
+func ParseJSONPath(expr string) ([]PathToken, error) {
+	if !IsJSONPath(expr) {
+		return nil, fmt.Errorf("JSONPath %q must be wrapped in braces, e.g. {.status.phase}", expr)
+	}
+	trimmed := strings.TrimSpace(expr)
+	inner := strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+	inner = strings.TrimPrefix(inner, "$")
+	if inner == "" {
+		return nil, fmt.Errorf("JSONPath %q is empty", expr)
+	}
+	if strings.Contains(inner, "{") || strings.Contains(inner, "}") {
+		return nil, fmt.Errorf("JSONPath %q: only a single {...} expression is supported", expr)
+	}
+
+	var tokens []PathToken
+	for pos := 0; pos < len(inner); {
+		switch inner[pos] {
+		case '.':
+			if pos+1 < len(inner) && inner[pos+1] == '.' {
+				return nil, fmt.Errorf("JSONPath %q: recursive descent (..) is not supported", expr)
+			}
+			end := pos + 1
+			for end < len(inner) && inner[end] != '.' && inner[end] != '[' {
+				end++
+			}
+			name := inner[pos+1 : end]
+			if name == "" {
+				return nil, fmt.Errorf("JSONPath %q: empty field name at offset %d", expr, pos)
+			}
+			tokens = append(tokens, FieldToken{Name: name})
+			pos = end
+
+		case '[':
+			end, err := closingBracket(inner, pos)
+			if err != nil {
+				return nil, fmt.Errorf("JSONPath %q: %w", expr, err)
+			}
+			token, err := parseJSONPathBracket(strings.TrimSpace(inner[pos+1 : end]))
+			if err != nil {
+				return nil, fmt.Errorf("JSONPath %q: %w", expr, err)
+			}
+			tokens = append(tokens, token)
+			pos = end + 1
+
+		default:
+			return nil, fmt.Errorf("JSONPath %q: unexpected character %q at offset %d (expected '.' or '[')", expr, inner[pos], pos)
+		}
+	}
+
+	if len(tokens) > MaxPathDepth {
+		return nil, fmt.Errorf("field path exceeds maximum depth of %d levels", MaxPathDepth)
+	}
+	return tokens, nil
+}
+
+// closingBracket returns the index of the ']' matching the '[' at open, skipping
+// brackets inside quoted strings.
+func closingBracket(s string, open int) (int, error) {
+	var quote byte
+	for i := open + 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unclosed bracket at offset %d", open)
+}
+
+// parseJSONPathBracket parses the content of a [...] accessor.
+func parseJSONPathBracket(content string) (PathToken, error) {
+	switch {
+	case content == "":
+		return nil, fmt.Errorf("empty brackets")
+	case content == "*":
+		return WildcardToken{}, nil
+	case strings.HasPrefix(content, "?"):
+		return parseJSONPathFilter(content)
+	case isQuoted(content):
+		return FieldToken{Name: content[1 : len(content)-1]}, nil
+	}
+
+	if strings.Contains(content, ":") {
+		slice, err := parseSlice(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slice [%s]: %w", content, err)
+		}
+		return slice, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported accessor [%s] (expected an index, a start:end slice, *, a quoted field name or a ?(@.field==value) filter)", content)
+	}
+	return ArrayIndexToken{Index: index}, nil
+}
+
+// parseJSONPathFilter parses a "?(@.field==value)" filter into an ArrayFilterToken.
+func parseJSONPathFilter(content string) (PathToken, error) {
+	expr := strings.TrimSpace(strings.TrimPrefix(content, "?"))
+	if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return nil, fmt.Errorf("invalid filter [%s] (expected ?(@.field==value))", content)
+	}
+	expr = strings.TrimSpace(expr[1 : len(expr)-1])
+
+	left, right, ok := strings.Cut(expr, "==")
+	if !ok {
+		return nil, fmt.Errorf("unsupported filter [%s]: only equality (==) is supported", content)
+	}
+	left = strings.TrimSpace(left)
+	right = strings.TrimSpace(right)
+
+	field := strings.TrimPrefix(left, "@.")
+	if field == left || !isValidFieldName(field) {
+		return nil, fmt.Errorf("invalid filter [%s]: left side must be @.<field>", content)
+	}
+	if isQuoted(right) {
+		right = right[1 : len(right)-1]
+	}
+	if right == "" {
+		return nil, fmt.Errorf("invalid filter [%s]: value cannot be empty", content)
+	}
+	return ArrayFilterToken{FilterField: field, FilterValue: right}, nil
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]
+}
@@ -0,0 +1,156 @@
+package fieldpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectedTokens []PathToken
+	}{
+		{
+			name: "simple field",
+			path: "{.status.readyReplicas}",
+			expectedTokens: []PathToken{
+				FieldToken{Name: "status"},
+				FieldToken{Name: "readyReplicas"},
+			},
+		},
+		{
+			name: "root marker and spaces",
+			path: " { $.spec.replicas } ",
+			expectedTokens: []PathToken{
+				FieldToken{Name: "spec"},
+				FieldToken{Name: "replicas"},
+			},
+		},
+		{
+			name: "array index",
+			path: "{.status.containerStatuses[0].restartCount}",
+			expectedTokens: []PathToken{
+				FieldToken{Name: "status"},
+				FieldToken{Name: "containerStatuses"},
+				ArrayIndexToken{Index: 0},
+				FieldToken{Name: "restartCount"},
+			},
+		},
+		{
+			name: "filter with double quotes",
+			path: `{.status.conditions[?(@.type=="Ready")].status}`,
+			expectedTokens: []PathToken{
+				FieldToken{Name: "status"},
+				FieldToken{Name: "conditions"},
+				ArrayFilterToken{FilterField: "type", FilterValue: "Ready"},
+				FieldToken{Name: "status"},
+			},
+		},
+		{
+			name: "filter with single quotes and brackets in value",
+			path: `{.spec.containers[?(@.name == 'a[1]')].image}`,
+			expectedTokens: []PathToken{
+				FieldToken{Name: "spec"},
+				FieldToken{Name: "containers"},
+				ArrayFilterToken{FilterField: "name", FilterValue: "a[1]"},
+				FieldToken{Name: "image"},
+			},
+		},
+		{
+			name: "negative index and slice",
+			path: "{.status.conditions[-1].history[-2:]}",
+			expectedTokens: []PathToken{
+				FieldToken{Name: "status"},
+				FieldToken{Name: "conditions"},
+				ArrayIndexToken{Index: -1},
+				FieldToken{Name: "history"},
+				SliceToken{Start: intPtr(-2)},
+			},
+		},
+		{
+			name: "quoted field name with dots",
+			path: `{.metadata.labels['app.kubernetes.io/name']}`,
+			expectedTokens: []PathToken{
+				FieldToken{Name: "metadata"},
+				FieldToken{Name: "labels"},
+				FieldToken{Name: "app.kubernetes.io/name"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := ParseJSONPath(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTokens, tokens)
+		})
+	}
+}
+
+func TestParseJSONPath_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		errContains string
+	}{
+		{"not wrapped", ".status.phase", "must be wrapped in braces"},
+		{"empty", "{}", "is empty"},
+		{"multiple templates", "{.a}{.b}", "single {...} expression"},
+		{"recursive descent", "{..name}", "recursive descent"},
+		{"missing dot", "{status}", "unexpected character"},
+		{"empty field", "{.status.}", "empty field name"},
+		{"unclosed bracket", "{.items[0}", "unclosed bracket"},
+		{"empty brackets", "{.items[]}", "empty brackets"},
+		{"slice step", "{.items[0:4:2]}", "steps are not supported"},
+		{"unsupported accessor", "{.items[a]}", "unsupported accessor"},
+		{"inequality filter", "{.items[?(@.a!='b')]}", "only equality"},
+		{"filter without @", "{.items[?(a=='b')]}", "left side must be @.<field>"},
+		{"filter without parens", "{.items[?@.a=='b']}", "invalid filter"},
+		{"empty filter value", "{.items[?(@.a=='')]}", "value cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJSONPath(tt.path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestParseStatus_JSONPath(t *testing.T) {
+	tokens, err := ParseStatus(`{.status.conditions[?(@.type=="Ready")].status}`)
+	require.NoError(t, err)
+	native, err := ParseStatus("conditions[type=Ready].status")
+	require.NoError(t, err)
+	assert.Equal(t, native, tokens, "JSONPath and native syntax produce the same tokens")
+
+	_, err = ParseStatus("{.spec.replicas}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must start with .status")
+}
+
+func TestGet_JSONPath(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": int64(3)},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False"},
+				map[string]interface{}{"type": "Ready", "status": "True"},
+			},
+		},
+	}
+
+	value, found, err := Get(obj, `{.status.conditions[?(@.type=="Ready")].status}`)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "True", value)
+
+	value, found, err = GetRaw(obj, "{.spec.replicas}")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(3), value)
+}
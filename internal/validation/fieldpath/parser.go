@@ -27,11 +27,16 @@ const (
 //
 // The input path is automatically prefixed with "status." before parsing —
 // callers provide paths relative to .status (e.g. "readyReplicas", not "status.readyReplicas").
+// kubectl JSONPath expressions (see ParseJSONPath) are also accepted; they are absolute
+// and must start with ".status".
 //
 // Examples:
 //   - "readyReplicas" -> []PathToken{FieldToken{Name: "status"}, FieldToken{Name: "readyReplicas"}}
 //   - "containerStatuses[0].restartCount" -> tokens for status, containerStatuses, [0], restartCount
 //   - "conditions[type=Ready].status" -> tokens for status, conditions, [type=Ready], status
+//   - `{.status.conditions[?(@.type=="Ready")].status}` -> same tokens as above
+//   - "sum(containerStatuses[*].restartCount)" -> tokens for status, containerStatuses, [*], restartCount, sum
+//   - "conditions[-1].type" -> tokens for status, conditions, [-1], type
 func ParseStatus(path string) ([]PathToken, error) {
 	if path == "" {
 		return nil, fmt.Errorf("field path cannot be empty")
@@ -42,6 +47,26 @@ func ParseStatus(path string) ([]PathToken, error) {
 		return nil, fmt.Errorf("field path exceeds maximum length of %d characters", MaxPathLength)
 	}
 
+	if fn, inner, ok := splitAggregate(path); ok {
+		tokens, err := ParseStatus(inner)
+		if err != nil {
+			return nil, err
+		}
+		return withAggregate(tokens, fn, path)
+	}
+
+	// kubectl JSONPath expressions are absolute and must point into .status
+	if IsJSONPath(path) {
+		tokens, err := ParseJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+		if ft, ok := tokens[0].(FieldToken); !ok || ft.Name != "status" {
+			return nil, fmt.Errorf("JSONPath %q must start with .status", path)
+		}
+		return tokens, nil
+	}
+
 	// Automatically prefix with "status."
 	fullPath := "status." + path
 
@@ -160,21 +185,31 @@ func parseSegment(segment string, position int, originalPath string) ([]PathToke
 }
 
 // parseArrayAccessor parses the content inside brackets [...]
-// Returns either ArrayIndexToken or ArrayFilterToken
+// Returns ArrayIndexToken, ArrayFilterToken, SliceToken or WildcardToken
 func parseArrayAccessor(accessor string, position int, originalPath string) (PathToken, error) {
 	// Check for empty accessor
 	if accessor == "" {
 		return nil, fmt.Errorf("invalid array accessor: empty brackets at position %d in path %q", position, originalPath)
 	}
 
-	// Try to parse as integer index
+	if accessor == "*" {
+		return WildcardToken{}, nil
+	}
+
+	// Try to parse as integer index (negative counts from the end)
 	if index, err := strconv.Atoi(accessor); err == nil {
-		if index < 0 {
-			return nil, fmt.Errorf("array index must be non-negative, got %d at position %d in path %q", index, position, originalPath)
-		}
 		return ArrayIndexToken{Index: index}, nil
 	}
 
+	// Try to parse as slice (start:end)
+	if strings.Contains(accessor, ":") && !strings.Contains(accessor, "=") {
+		slice, err := parseSlice(accessor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array slice [%s] at position %d in path %q: %w", accessor, position, originalPath, err)
+		}
+		return slice, nil
+	}
+
 	// Try to parse as filter (key=value)
 	filterMatches := filterPattern.FindStringSubmatch(accessor)
 	if filterMatches == nil {
@@ -182,7 +217,7 @@ func parseArrayAccessor(accessor string, position int, originalPath string) (Pat
 		if strings.Contains(accessor, "=") {
 			return nil, fmt.Errorf("invalid array filter %q at position %d in path %q (filter must be in format 'key=value' with non-empty value)", accessor, position, originalPath)
 		}
-		return nil, fmt.Errorf("invalid array accessor %q at position %d in path %q (expected integer index or key=value filter)", accessor, position, originalPath)
+		return nil, fmt.Errorf("invalid array accessor %q at position %d in path %q (expected integer index, start:end slice, * or key=value filter)", accessor, position, originalPath)
 	}
 
 	filterField := filterMatches[1]
@@ -194,6 +229,29 @@ func parseArrayAccessor(accessor string, position int, originalPath string) (Pat
 	}, nil
 }
 
+// parseSlice parses "start:end" where both bounds are optional integers.
+func parseSlice(accessor string) (SliceToken, error) {
+	parts := strings.Split(accessor, ":")
+	if len(parts) != 2 {
+		return SliceToken{}, fmt.Errorf("expected start:end (steps are not supported)")
+	}
+
+	var slice SliceToken
+	bounds := []**int{&slice.Start, &slice.End}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return SliceToken{}, fmt.Errorf("bound %q is not an integer", part)
+		}
+		*bounds[i] = &n
+	}
+	return slice, nil
+}
+
 // isValidFieldName checks if a string is a valid Kubernetes field name.
 // Must start with a letter, followed by letters, digits, or underscores.
 func isValidFieldName(name string) bool {
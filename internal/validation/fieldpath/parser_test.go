@@ -243,9 +243,14 @@ func TestParse_ErrorCases(t *testing.T) {
 			errContains: "invalid field name",
 		},
 		{
-			name:        "negative array index",
-			path:        "items[-1]",
-			errContains: "must be non-negative",
+			name:        "slice with step",
+			path:        "items[0:4:2]",
+			errContains: "steps are not supported",
+		},
+		{
+			name:        "slice with non-integer bound",
+			path:        "items[a:2]",
+			errContains: "not an integer",
 		},
 		{
 			name:        "invalid array filter missing equals",
@@ -19,14 +19,34 @@ import (
 // - Filter not found: returns (nil, false, error) - no array element matches filter
 // This asymmetry is intentional: missing fields are common and expected, while type mismatches
 // and out-of-bounds access indicate either incorrect paths or unexpected object structure.
+//
+// A wildcard or slice resolves the remaining tokens against every selected array element and
+// returns the found values as a []interface{} (elements where the path is missing are left out).
+// A trailing AggregateToken reduces the resolved value to a single number.
 func Resolve(obj map[string]interface{}, tokens []PathToken) (interface{}, bool, error) {
 	if len(tokens) == 0 {
 		return nil, false, fmt.Errorf("no tokens to resolve")
 	}
 
-	var current interface{} = obj
+	if agg, ok := tokens[len(tokens)-1].(AggregateToken); ok {
+		if len(tokens) == 1 {
+			return nil, false, fmt.Errorf("no tokens to aggregate")
+		}
+		value, found, err := resolveFrom(obj, tokens[:len(tokens)-1], 0)
+		if err != nil || !found {
+			return nil, found, err
+		}
+		return aggregate(value, agg.Func)
+	}
+
+	return resolveFrom(obj, tokens, 0)
+}
 
-	for i, token := range tokens {
+// resolveFrom resolves tokens starting at current. offset is the position of tokens[0]
+// in the full token list, used in error messages.
+func resolveFrom(current interface{}, tokens []PathToken, offset int) (interface{}, bool, error) {
+	for j, token := range tokens {
+		i := offset + j
 		switch t := token.(type) {
 		case FieldToken:
 			// Current must be a map
@@ -72,12 +92,17 @@ func Resolve(obj map[string]interface{}, tokens []PathToken) (interface{}, bool,
 				return nil, false, fmt.Errorf("expected array at token %d (index %d), got %T", i, t.Index, current)
 			}
 
-			// Check bounds
-			if t.Index >= len(currentSlice) {
-				return nil, false, fmt.Errorf("array index %d out of bounds (length: %d) at token %d", t.Index, len(currentSlice), i)
+			// Negative indexes count from the end
+			index := t.Index
+			if index < 0 {
+				index += len(currentSlice)
+			}
+			if index < 0 || index >= len(currentSlice) {
+				return nil, false, fmt.Errorf("array index %d out of bounds (length: %d) at token %d (%s)",
+					t.Index, len(currentSlice), i, availableIndexes(len(currentSlice)))
 			}
 
-			current = currentSlice[t.Index]
+			current = currentSlice[index]
 
 		case ArrayFilterToken:
 			// Current must be a slice
@@ -119,6 +144,24 @@ func Resolve(obj map[string]interface{}, tokens []PathToken) (interface{}, bool,
 					t.FilterField, t.FilterValue, i, availableValues)
 			}
 
+		case WildcardToken:
+			currentSlice, ok := current.([]interface{})
+			if !ok {
+				return nil, false, fmt.Errorf("expected array at token %d ([*]), got %T", i, current)
+			}
+			return resolveEach(currentSlice, tokens[j+1:], i+1)
+
+		case SliceToken:
+			currentSlice, ok := current.([]interface{})
+			if !ok {
+				return nil, false, fmt.Errorf("expected array at token %d (slice %s), got %T", i, t, current)
+			}
+			start, end := sliceBounds(t, len(currentSlice))
+			return resolveEach(currentSlice[start:end], tokens[j+1:], i+1)
+
+		case AggregateToken:
+			return nil, false, fmt.Errorf("aggregation %s must be the last token, found at position %d", t.Func, i)
+
 		default:
 			return nil, false, fmt.Errorf("unknown token type at position %d", i)
 		}
@@ -127,6 +170,68 @@ func Resolve(obj map[string]interface{}, tokens []PathToken) (interface{}, bool,
 	return current, true, nil
 }
 
+// resolveEach resolves tokens against every element and collects the found values.
+// Elements where the path is missing are skipped; nested wildcards and slices produce
+// a flat list of values.
+func resolveEach(elems []interface{}, tokens []PathToken, offset int) (interface{}, bool, error) {
+	flatten := containsFanOut(tokens)
+	results := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		val, found, err := resolveFrom(elem, tokens, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		if !found {
+			continue
+		}
+		if nested, ok := val.([]interface{}); ok && flatten {
+			results = append(results, nested...)
+		} else {
+			results = append(results, val)
+		}
+	}
+	return results, true, nil
+}
+
+// containsFanOut reports whether tokens include a WildcardToken or a SliceToken.
+func containsFanOut(tokens []PathToken) bool {
+	for _, token := range tokens {
+		switch token.(type) {
+		case WildcardToken, SliceToken:
+			return true
+		}
+	}
+	return false
+}
+
+// sliceBounds converts a slice token to [start, end) indexes of an array of length n,
+// resolving negative bounds and clamping out-of-range ones.
+func sliceBounds(t SliceToken, n int) (start, end int) {
+	bound := func(b *int, def int) int {
+		if b == nil {
+			return def
+		}
+		v := *b
+		if v < 0 {
+			v += n
+		}
+		return max(0, min(v, n))
+	}
+	start, end = bound(t.Start, 0), bound(t.End, n)
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+// availableIndexes describes the valid indexes of an array of length n for error messages.
+func availableIndexes(n int) string {
+	if n == 0 {
+		return "array is empty"
+	}
+	return fmt.Sprintf("available indexes: 0..%d or -%d..-1", n-1, n)
+}
+
 // Get is a convenience function that parses a path and resolves it in one call.
 // It automatically prefixes the path with "status." as per the ParseStatus function.
 func Get(obj map[string]interface{}, path string) (interface{}, bool, error) {
@@ -140,6 +245,7 @@ func Get(obj map[string]interface{}, path string) (interface{}, bool, error) {
 
 // ParseRaw parses a field path string into tokens without adding the "status." prefix.
 // Use this for paths that start from the root of the object (e.g., "spec.replicas").
+// kubectl JSONPath expressions such as "{.spec.replicas}" are also accepted.
 func ParseRaw(path string) ([]PathToken, error) {
 	if path == "" {
 		return nil, fmt.Errorf("field path cannot be empty")
@@ -147,6 +253,16 @@ func ParseRaw(path string) ([]PathToken, error) {
 	if len(path) > MaxPathLength {
 		return nil, fmt.Errorf("field path exceeds maximum length of %d characters", MaxPathLength)
 	}
+	if fn, inner, ok := splitAggregate(path); ok {
+		tokens, err := ParseRaw(inner)
+		if err != nil {
+			return nil, err
+		}
+		return withAggregate(tokens, fn, path)
+	}
+	if IsJSONPath(path) {
+		return ParseJSONPath(path)
+	}
 	segments, err := splitPath(path)
 	if err != nil {
 		return nil, err
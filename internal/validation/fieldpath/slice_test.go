@@ -0,0 +1,102 @@
+package fieldpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func conditionsObject() map[string]interface{} {
+	return map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "PodScheduled", "status": "True"},
+				map[string]interface{}{"type": "Initialized", "status": "True"},
+				map[string]interface{}{"type": "ContainersReady", "status": "False"},
+				map[string]interface{}{"type": "Ready", "status": "False"},
+			},
+			"empty": []interface{}{},
+		},
+	}
+}
+
+func TestParse_NegativeIndexAndSlice(t *testing.T) {
+	tests := []struct {
+		path  string
+		token PathToken
+	}{
+		{"conditions[-1]", ArrayIndexToken{Index: -1}},
+		{"conditions[1:3]", SliceToken{Start: intPtr(1), End: intPtr(3)}},
+		{"conditions[-2:]", SliceToken{Start: intPtr(-2)}},
+		{"conditions[:2]", SliceToken{End: intPtr(2)}},
+		{"conditions[:]", SliceToken{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			tokens, err := ParseStatus(tt.path)
+			require.NoError(t, err)
+			require.Len(t, tokens, 3)
+			assert.Equal(t, tt.token, tokens[2])
+		})
+	}
+}
+
+func TestResolve_NegativeIndex(t *testing.T) {
+	value, found, err := Get(conditionsObject(), "conditions[-1].type")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "Ready", value)
+
+	value, _, err = Get(conditionsObject(), "conditions[-4].type")
+	require.NoError(t, err)
+	assert.Equal(t, "PodScheduled", value)
+
+	_, _, err = Get(conditionsObject(), "conditions[-5].type")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "out of bounds")
+	assert.Contains(t, err.Error(), "available indexes: 0..3 or -4..-1")
+
+	_, _, err = Get(conditionsObject(), "empty[0]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "array is empty")
+}
+
+func TestResolve_Slice(t *testing.T) {
+	tests := []struct {
+		path string
+		want []interface{}
+	}{
+		{"conditions[1:3].type", []interface{}{"Initialized", "ContainersReady"}},
+		{"conditions[-2:].type", []interface{}{"ContainersReady", "Ready"}},
+		{"conditions[:1].type", []interface{}{"PodScheduled"}},
+		{"conditions[:].status", []interface{}{"True", "True", "False", "False"}},
+		{"conditions[2:100].type", []interface{}{"ContainersReady", "Ready"}},
+		{"conditions[3:1].type", []interface{}{}},
+		{"empty[-1:]", []interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			value, found, err := Get(conditionsObject(), tt.path)
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestResolve_SliceAggregate(t *testing.T) {
+	value, _, err := Get(conditionsObject(), "count(conditions[-2:])")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), value)
+
+	_, _, err = Get(conditionsObject(), "conditions[0].type[1:]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slice [1:]")
+}
@@ -1,5 +1,7 @@
 package fieldpath
 
+import "fmt"
+
 // TokenType represents the type of a field path token
 type TokenType int
 
@@ -10,6 +12,12 @@ const (
 	TokenArrayIndex
 	// TokenArrayFilter represents array access by filter (e.g., "[type=Ready]")
 	TokenArrayFilter
+	// TokenWildcard represents access to every array element (e.g., "[*]")
+	TokenWildcard
+	// TokenAggregate represents an aggregation of the resolved values (e.g., "sum(...)")
+	TokenAggregate
+	// TokenSlice represents access to a range of array elements (e.g., "[1:3]")
+	TokenSlice
 )
 
 // PathToken is the interface implemented by all token types
@@ -27,7 +35,8 @@ func (t FieldToken) Type() TokenType {
 	return TokenField
 }
 
-// ArrayIndexToken represents an array access by numeric index
+// ArrayIndexToken represents an array access by numeric index.
+// A negative index counts from the end of the array (-1 is the last element).
 type ArrayIndexToken struct {
 	Index int
 }
@@ -47,3 +56,58 @@ type ArrayFilterToken struct {
 func (t ArrayFilterToken) Type() TokenType {
 	return TokenArrayFilter
 }
+
+// WildcardToken represents an access to every element of an array. The remaining
+// tokens are resolved against each element and the results collected in a slice.
+type WildcardToken struct{}
+
+// Type returns the token type
+func (t WildcardToken) Type() TokenType {
+	return TokenWildcard
+}
+
+// SliceToken represents an access to a range of array elements, [Start:End) with
+// Python-like semantics: nil bounds default to the array edges, negative bounds count
+// from the end and out-of-range bounds are clamped. Like WildcardToken, the remaining
+// tokens are resolved against each selected element.
+type SliceToken struct {
+	Start *int
+	End   *int
+}
+
+// Type returns the token type
+func (t SliceToken) Type() TokenType {
+	return TokenSlice
+}
+
+// String returns the slice in path syntax, e.g. "[1:]"
+func (t SliceToken) String() string {
+	var start, end string
+	if t.Start != nil {
+		start = fmt.Sprintf("%d", *t.Start)
+	}
+	if t.End != nil {
+		end = fmt.Sprintf("%d", *t.End)
+	}
+	return "[" + start + ":" + end + "]"
+}
+
+// AggregateFunc is an aggregation applied to the values resolved by a path.
+type AggregateFunc string
+
+const (
+	AggregateSum   AggregateFunc = "sum"
+	AggregateMax   AggregateFunc = "max"
+	AggregateMin   AggregateFunc = "min"
+	AggregateCount AggregateFunc = "count"
+)
+
+// AggregateToken reduces the resolved values to a single number. It is always the last token.
+type AggregateToken struct {
+	Func AggregateFunc
+}
+
+// Type returns the token type
+func (t AggregateToken) Type() TokenType {
+	return TokenAggregate
+}
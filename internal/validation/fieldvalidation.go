@@ -119,6 +119,43 @@ func validateTokensAgainstType(t reflect.Type, tokens []fieldpath.PathToken, ori
 			// Get element type
 			currentType = currentType.Elem()
 
+		case fieldpath.WildcardToken, fieldpath.SliceToken:
+			if currentType.Kind() == reflect.Ptr {
+				currentType = currentType.Elem()
+			}
+
+			if currentType.Kind() != reflect.Slice && currentType.Kind() != reflect.Array {
+				accessor := "[*]"
+				if slice, ok := tok.(fieldpath.SliceToken); ok {
+					accessor = slice.String()
+				}
+				return fmt.Errorf("cannot use %s on non-array type %s in path %q",
+					accessor, currentType.Kind(), originalPath)
+			}
+
+			currentType = currentType.Elem()
+
+		case fieldpath.AggregateToken:
+			if tok.Func == fieldpath.AggregateCount {
+				continue
+			}
+
+			// sum/max/min need numbers, either directly or as array elements
+			valueType := currentType
+			if valueType.Kind() == reflect.Ptr {
+				valueType = valueType.Elem()
+			}
+			if valueType.Kind() == reflect.Slice || valueType.Kind() == reflect.Array {
+				valueType = valueType.Elem()
+			}
+			if valueType.Kind() == reflect.Ptr {
+				valueType = valueType.Elem()
+			}
+			if !isNumericKind(valueType.Kind()) {
+				return fmt.Errorf("cannot compute %s of non-numeric type %s in path %q",
+					tok.Func, valueType.Kind(), originalPath)
+			}
+
 		case fieldpath.ArrayFilterToken:
 			// Handle pointer types
 			if currentType.Kind() == reflect.Ptr {
@@ -155,6 +192,17 @@ func validateTokensAgainstType(t reflect.Type, tokens []fieldpath.PathToken, ori
 	return nil
 }
 
+// isNumericKind reports whether k is an integer or floating point kind.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
 // findStructField finds a field in a struct type by name.
 // Matches against the JSON tag first (case-insensitive), then the field name.
 func findStructField(t reflect.Type, name string) (reflect.StructField, bool) {
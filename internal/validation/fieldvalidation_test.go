@@ -293,6 +293,66 @@ func TestValidateFieldPath_ArraySyntax(t *testing.T) {
 			fieldPath: "conditions[type=Ready].status",
 			wantErr:   false,
 		},
+		{
+			name:      "Valid JSONPath filter",
+			kind:      "Pod",
+			fieldPath: `{.status.conditions[?(@.type=="Ready")].status}`,
+			wantErr:   false,
+		},
+		{
+			name:      "JSONPath with unknown field",
+			kind:      "Deployment",
+			fieldPath: "{.status.readyReplica}",
+			wantErr:   true,
+		},
+		{
+			name:      "Valid wildcard",
+			kind:      "Pod",
+			fieldPath: "containerStatuses[*].restartCount",
+			wantErr:   false,
+		},
+		{
+			name:      "Valid sum aggregation",
+			kind:      "Pod",
+			fieldPath: "sum(containerStatuses[*].restartCount)",
+			wantErr:   false,
+		},
+		{
+			name:      "Valid count aggregation",
+			kind:      "Pod",
+			fieldPath: "count(conditions)",
+			wantErr:   false,
+		},
+		{
+			name:      "Sum of non-numeric field",
+			kind:      "Pod",
+			fieldPath: "sum(containerStatuses[*].name)",
+			wantErr:   true,
+		},
+		{
+			name:      "Valid negative index",
+			kind:      "Pod",
+			fieldPath: "conditions[-1].type",
+			wantErr:   false,
+		},
+		{
+			name:      "Valid slice",
+			kind:      "Pod",
+			fieldPath: "containerStatuses[1:].ready",
+			wantErr:   false,
+		},
+		{
+			name:      "Slice on non-array field",
+			kind:      "Deployment",
+			fieldPath: "readyReplicas[:2]",
+			wantErr:   true,
+		},
+		{
+			name:      "Wildcard on non-array field",
+			kind:      "Deployment",
+			fieldPath: "readyReplicas[*]",
+			wantErr:   true,
+		},
 		{
 			name:      "Array index on non-array field",
 			kind:      "Deployment",
@@ -392,10 +452,10 @@ func TestValidateFieldPath_InvalidPathSyntax(t *testing.T) {
 			wantErrContain: "empty",
 		},
 		{
-			name:           "Negative array index",
+			name:           "Slice with step",
 			kind:           "Pod",
-			fieldPath:      "containerStatuses[-1]",
-			wantErrContain: "non-negative",
+			fieldPath:      "containerStatuses[::2]",
+			wantErrContain: "steps are not supported",
 		},
 	}
 
@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"go.yaml.in/yaml/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// forbiddenActionsKey is the top-level challenge.yaml list of forbidden actions.
+const forbiddenActionsKey = "forbiddenActions"
+
+// forbiddenVerbScale is the pseudo-verb matching updates of the scale subresource.
+const forbiddenVerbScale = "scale"
+
+// forbiddenVerbs lists the verbs a forbidden action may match.
+var forbiddenVerbs = []string{"create", "update", "patch", "delete", "deletecollection", forbiddenVerbScale}
+
+// decodeForbiddenActions decodes the forbiddenActions list of data.
+func decodeForbiddenActions(data []byte) ([]ForbiddenAction, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil, nil //nolint:nilerr // malformed YAML is reported by the parsers
+	}
+	node := mappingValue(doc.Content[0], forbiddenActionsKey)
+	if node == nil {
+		return nil, nil
+	}
+	var actions []ForbiddenAction
+	if err := node.Decode(&actions); err != nil {
+		return nil, fmt.Errorf("%s: %w", forbiddenActionsKey, err)
+	}
+	seen := map[string]bool{}
+	for i, a := range actions {
+		if err := validateForbiddenAction(a); err != nil {
+			return nil, fmt.Errorf("%s[%d] %q: %w", forbiddenActionsKey, i, a.Key, err)
+		}
+		if seen[a.Key] {
+			return nil, fmt.Errorf("%s[%d]: duplicate key %q", forbiddenActionsKey, i, a.Key)
+		}
+		seen[a.Key] = true
+	}
+	return actions, nil
+}
+
+func validateForbiddenAction(a ForbiddenAction) error {
+	switch {
+	case a.Key == "":
+		return fmt.Errorf("key is required")
+	case a.Target.Kind == "":
+		return fmt.Errorf("target.kind is required")
+	case len(a.Verbs) == 0 && a.Event == nil:
+		return fmt.Errorf("at least one of verbs or event is required")
+	}
+	for _, verb := range a.Verbs {
+		if !slices.Contains(forbiddenVerbs, verb) {
+			return fmt.Errorf("unsupported verb %q, expected one of %s", verb, strings.Join(forbiddenVerbs, ", "))
+		}
+	}
+	if a.Event != nil {
+		if a.Event.Reason == "" {
+			return fmt.Errorf("event.reason is required")
+		}
+		if _, err := regexp.Compile(a.Event.MessagePattern); err != nil {
+			return fmt.Errorf("invalid event.messagePattern: %w", err)
+		}
+	}
+	return nil
+}
+
+// CheckForbiddenActions reports, for each action, whether it was taken in namespace
+// after since: a Result that passes when the action was not taken, and otherwise fails
+// with the evidence, from auditEvents (the cluster audit log) or from the Events of the
+// namespace. The Events are only listed when an action matches them; failing to list
+// them is not an error, since the audit log remains.
+func CheckForbiddenActions(ctx context.Context, clientset kubernetes.Interface, namespace string, actions []ForbiddenAction, auditEvents []audit.AuditEvent, since time.Time) []Result {
+	var events []corev1.Event
+	if slices.ContainsFunc(actions, func(a ForbiddenAction) bool { return a.Event != nil }) {
+		list, err := shared.ListEvents(ctx, clientset, namespace)
+		if err != nil {
+			logger.Debug("Could not list events for forbidden actions: %v", err)
+		} else {
+			events = list
+		}
+	}
+
+	results := make([]Result, len(actions))
+	for i, a := range actions {
+		results[i] = Result{Key: a.Key, Passed: true, Message: "Not taken"}
+		evidence := auditEvidence(a, auditEvents, since)
+		if evidence == "" {
+			evidence = eventEvidence(a, events, since)
+		}
+		if evidence != "" {
+			results[i].Passed = false
+			results[i].Message = "Forbidden action taken: " + evidence
+		}
+	}
+	return results
+}
+
+// auditEvidence describes the first successful request of auditEvents taking action a,
+// e.g. "delete networkpolicies/deny-all at 14:03:05", or returns "".
+func auditEvidence(a ForbiddenAction, auditEvents []audit.AuditEvent, since time.Time) string {
+	resource := forbiddenResource(a.Target.Kind)
+	for _, e := range auditEvents {
+		if e.Resource != resource || (a.Target.Name != "" && e.Name != a.Target.Name) ||
+			e.ResponseCode >= 400 || (!since.IsZero() && !e.Timestamp.After(since)) {
+			continue
+		}
+		verb := e.Verb
+		if e.Subresource == "scale" && (verb == "update" || verb == "patch") {
+			verb = forbiddenVerbScale
+		}
+		if !slices.Contains(a.Verbs, verb) && !slices.Contains(a.Verbs, e.Verb) {
+			continue
+		}
+		return fmt.Sprintf("%s %s/%s at %s", verb, e.Resource, e.Name, e.Timestamp.Local().Format(time.TimeOnly))
+	}
+	return ""
+}
+
+// eventEvidence describes the first Event of events matching action a, e.g.
+// "Deployment api: Scaled down replica set api-7d9 from 1 to 0", or returns "".
+func eventEvidence(a ForbiddenAction, events []corev1.Event, since time.Time) string {
+	if a.Event == nil {
+		return ""
+	}
+	// The pattern is validated by the loader
+	pattern := regexp.MustCompile(a.Event.MessagePattern)
+	for _, e := range events {
+		obj := e.InvolvedObject
+		if !strings.EqualFold(obj.Kind, a.Target.Kind) || (a.Target.Name != "" && obj.Name != a.Target.Name) ||
+			e.Reason != a.Event.Reason || !pattern.MatchString(e.Message) {
+			continue
+		}
+		if !since.IsZero() && !eventTime(e).After(since) {
+			continue
+		}
+		return fmt.Sprintf("%s %s: %s", obj.Kind, obj.Name, e.Message)
+	}
+	return ""
+}
+
+// eventTime returns when an Event was last seen.
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// forbiddenResource returns the API resource of kind, as recorded in the audit log.
+func forbiddenResource(kind string) string {
+	if gvr, err := shared.GetGVRForKind(kind); err == nil {
+		return gvr.Resource
+	}
+	return strings.ToLower(kind) + "s"
+}
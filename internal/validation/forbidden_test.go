@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParse_ForbiddenActions(t *testing.T) {
+	config, err := Parse([]byte(`
+vars:
+  app: api
+objectives: []
+forbiddenActions:
+  - key: no-scale-to-zero
+    description: Scaling the API to zero hides the crash
+    target:
+      kind: Deployment
+      name: ${app}
+    verbs: [scale]
+    event:
+      reason: ScalingReplicaSet
+      messagePattern: "to 0$"
+`))
+	require.NoError(t, err)
+	require.Len(t, config.ForbiddenActions, 1)
+	a := config.ForbiddenActions[0]
+	assert.Equal(t, "no-scale-to-zero", a.Key)
+	assert.Equal(t, "api", a.Target.Name)
+	assert.Equal(t, []string{"scale"}, a.Verbs)
+	require.NotNil(t, a.Event)
+	assert.Equal(t, "to 0$", a.Event.MessagePattern)
+}
+
+func TestParse_ForbiddenActionsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		wantErr string
+	}{
+		{
+			name:    "missing key",
+			action:  "target: {kind: Pod}\n    verbs: [delete]",
+			wantErr: "key is required",
+		},
+		{
+			name:    "missing kind",
+			action:  "key: a\n    verbs: [delete]",
+			wantErr: "target.kind is required",
+		},
+		{
+			name:    "nothing to match",
+			action:  "key: a\n    target: {kind: Pod}",
+			wantErr: "at least one of verbs or event is required",
+		},
+		{
+			name:    "unsupported verb",
+			action:  "key: a\n    target: {kind: Pod}\n    verbs: [get]",
+			wantErr: `unsupported verb "get"`,
+		},
+		{
+			name:    "missing event reason",
+			action:  "key: a\n    target: {kind: Pod}\n    event: {messagePattern: x}",
+			wantErr: "event.reason is required",
+		},
+		{
+			name:    "invalid pattern",
+			action:  "key: a\n    target: {kind: Pod}\n    event: {reason: Killing, messagePattern: \"(\"}",
+			wantErr: "invalid event.messagePattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte("objectives: []\nforbiddenActions:\n  - " + tt.action + "\n"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := Parse([]byte("objectives: []\nforbiddenActions:\n  - {key: a, target: {kind: Pod}, verbs: [delete]}\n  - {key: a, target: {kind: Pod}, verbs: [patch]}\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate key "a"`)
+}
+
+func TestCheckForbiddenActions(t *testing.T) {
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	actions := []ForbiddenAction{
+		{Key: "keep-policy", Target: Target{Kind: "NetworkPolicy", Name: "deny-all"}, Verbs: []string{"delete"}},
+		{Key: "no-scale", Target: Target{Kind: "Deployment", Name: "api"}, Verbs: []string{"scale"}},
+		{
+			Key:    "no-scale-event",
+			Target: Target{Kind: "Deployment", Name: "api"},
+			Event:  &ForbiddenActionEvent{Reason: "ScalingReplicaSet", MessagePattern: "to 0$"},
+		},
+		{Key: "keep-pods", Target: Target{Kind: "Pod"}, Verbs: []string{"delete"}},
+	}
+	auditEvents := []audit.AuditEvent{
+		// Before the start, or rejected by the API server: ignored
+		{Timestamp: start.Add(-time.Minute), Verb: "delete", Resource: "networkpolicies", Name: "deny-all"},
+		{Timestamp: start.Add(time.Minute), Verb: "delete", Resource: "pods", Name: "web-0", ResponseCode: 403},
+		{Timestamp: start.Add(2 * time.Minute), Verb: "patch", Resource: "deployments", Subresource: "scale", Name: "api", ResponseCode: 200},
+	}
+	clientset := fake.NewClientset(&corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "api.1", Namespace: "test-ns"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Deployment", Name: "api"},
+		Reason:         "ScalingReplicaSet",
+		Message:        "Scaled down replica set api-7d9 from 1 to 0",
+		LastTimestamp:  metav1.NewTime(start.Add(2 * time.Minute)),
+	})
+
+	results := CheckForbiddenActions(context.Background(), clientset, "test-ns", actions, auditEvents, start)
+	require.Len(t, results, 4)
+
+	assert.True(t, results[0].Passed, results[0].Message)
+	assert.Equal(t, "Not taken", results[0].Message)
+
+	assert.False(t, results[1].Passed)
+	assert.Contains(t, results[1].Message, "Forbidden action taken: scale deployments/api at ")
+
+	assert.False(t, results[2].Passed)
+	assert.Equal(t, "Forbidden action taken: Deployment api: Scaled down replica set api-7d9 from 1 to 0", results[2].Message)
+
+	assert.True(t, results[3].Passed, results[3].Message)
+}
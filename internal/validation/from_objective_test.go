@@ -22,7 +22,7 @@ func TestFromObjective_UnknownSpec(t *testing.T) {
 }
 
 func TestFromObjective_SupportedSpec(t *testing.T) {
-	spec := &StatusSpec{
+	spec := &challenges.StatusSpec{
 		Target: Target{Kind: "Pod", Name: "test-pod"},
 	}
 	obj := challenges.Objective{
@@ -35,5 +35,5 @@ func TestFromObjective_SupportedSpec(t *testing.T) {
 
 	assert.Equal(t, "status-obj", v.Key)
 	assert.Equal(t, TypeStatus, v.Type)
-	assert.Equal(t, *spec, v.Spec)
+	assert.Equal(t, StatusSpec{Target: spec.Target}, v.Spec)
 }
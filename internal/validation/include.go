@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"go.yaml.in/yaml/v3"
+)
+
+// includeKey marks an objectives entry that is replaced by the objectives of a
+// shared fragment, e.g. "- include: ../lib/no-crashloops.yaml".
+const includeKey = "include"
+
+// fetchInclude reads an included fragment: a local path, a file:// URL or a URL from
+// a trusted domain. A variable so tests can serve remote fragments.
+var fetchInclude = func(ctx context.Context, location string) ([]byte, error) {
+	if !strings.Contains(location, "://") {
+		location = "file://" + location
+	}
+	return kube.FetchManifest(ctx, location)
+}
+
+// ResolveIncludes inlines the objectives of the "include" entries of challenge.yaml.
+// path is the location of data: relative includes are resolved against its directory,
+// or against the URL of the fragment that includes them. A fragment is a YAML file
+// with an objectives list, which may include other fragments; include cycles are
+// rejected. Includes are resolved before template variables, so fragments can
+// reference the vars of the challenge. Remote fragments are downloaded under ctx.
+//
+// data is returned unchanged when it has no include.
+func ResolveIncludes(ctx context.Context, data []byte, path string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return data, nil //nolint:nilerr // malformed YAML is reported by the parsers
+	}
+	// The objectives of variants may include fragments too
+	lists := []*yaml.Node{mappingValue(doc.Content[0], "objectives")}
+	if variants := mappingValue(doc.Content[0], variantsKey); variants != nil && variants.Kind == yaml.SequenceNode {
+		for _, v := range variants.Content {
+			lists = append(lists, mappingValue(v, "objectives"))
+		}
+	}
+	if !slices.ContainsFunc(lists, func(objectives *yaml.Node) bool { return firstInclude(objectives) >= 0 }) {
+		return data, nil
+	}
+
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	for _, objectives := range lists {
+		if firstInclude(objectives) < 0 {
+			continue
+		}
+		items, err := resolveIncludes(ctx, objectives, []string{root})
+		if err != nil {
+			return nil, err
+		}
+		objectives.Content = items
+	}
+
+	resolved, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode challenge: %w", err)
+	}
+	return resolved, nil
+}
+
+// resolveIncludes returns the objectives of items with includes replaced by the
+// objectives of their fragment. stack holds the locations being resolved, the
+// document of items last.
+func resolveIncludes(ctx context.Context, items *yaml.Node, stack []string) ([]*yaml.Node, error) {
+	var resolved []*yaml.Node
+	for i, item := range items.Content {
+		source := mappingValue(item, includeKey)
+		if source == nil {
+			resolved = append(resolved, item)
+			continue
+		}
+		switch {
+		case len(item.Content) != 2:
+			return nil, fmt.Errorf("objectives[%d]: include cannot be combined with other fields", i)
+		case source.Kind != yaml.ScalarNode || source.Value == "":
+			return nil, fmt.Errorf("objectives[%d]: include must be a path or URL", i)
+		}
+
+		location := includeLocation(stack[len(stack)-1], source.Value)
+		if slices.Contains(stack, location) {
+			return nil, fmt.Errorf("include cycle: %s", strings.Join(append(stack, location), " -> "))
+		}
+		objectives, err := loadFragment(ctx, location)
+		if err != nil {
+			return nil, fmt.Errorf("objectives[%d]: include %q: %w", i, source.Value, err)
+		}
+		nested, err := resolveIncludes(ctx, objectives, append(slices.Clip(stack), location))
+		if err != nil {
+			return nil, fmt.Errorf("include %q: %w", source.Value, err)
+		}
+		resolved = append(resolved, nested...)
+	}
+	return resolved, nil
+}
+
+// loadFragment reads the objectives list of the fragment at location.
+func loadFragment(ctx context.Context, location string) (*yaml.Node, error) {
+	data, err := fetchInclude(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse fragment: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("fragment has no objectives list")
+	}
+	objectives := mappingValue(doc.Content[0], "objectives")
+	if objectives == nil || objectives.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("fragment has no objectives list")
+	}
+	return objectives, nil
+}
+
+// includeLocation resolves source relative to parent, the location of the including
+// document: a URL or an absolute file path.
+func includeLocation(parent, source string) string {
+	if strings.Contains(source, "://") && !strings.HasPrefix(source, "file://") {
+		return source
+	}
+	source = strings.TrimPrefix(source, "file://")
+	if strings.Contains(parent, "://") {
+		base, baseErr := url.Parse(parent)
+		ref, refErr := url.Parse(source)
+		if baseErr == nil && refErr == nil {
+			return base.ResolveReference(ref).String()
+		}
+	}
+	if filepath.IsAbs(source) {
+		return filepath.Clean(source)
+	}
+	return filepath.Join(filepath.Dir(parent), source)
+}
+
+// firstInclude returns the index of the first include entry of objectives, or -1.
+func firstInclude(objectives *yaml.Node) int {
+	if objectives == nil || objectives.Kind != yaml.SequenceNode {
+		return -1
+	}
+	for i, item := range objectives.Content {
+		if mappingValue(item, includeKey) != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkIncludesResolved rejects the include entries of data: they are resolved by the
+// file loaders (see ResolveIncludes), which know where the file lives.
+func checkIncludesResolved(data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil //nolint:nilerr // malformed YAML is reported by the parsers
+	}
+	if i := firstInclude(mappingValue(doc.Content[0], "objectives")); i >= 0 {
+		return fmt.Errorf("objectives[%d]: include is only supported in challenge files loaded from disk", i)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const noCrashloopsFragment = `
+objectives:
+  - key: no-crashloops
+    type: status
+    spec:
+      target:
+        kind: Pod
+        labelSelector:
+          app: ${app:-web}
+      checks:
+        - field: restartCount
+          operator: "<"
+          value: 3
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestLoadFromFile_Includes(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"lib/no-crashloops.yaml": noCrashloopsFragment,
+		"lib/standard.yaml":      "objectives:\n  - include: no-crashloops.yaml\n",
+		"pod-evicted/challenge.yaml": `
+vars:
+  app: api
+objectives:
+  - include: ../lib/standard.yaml
+  - key: scraped
+    type: metadata
+    spec:
+      target:
+        kind: Service
+        name: ${app}
+      annotations:
+        - key: prometheus.io/scrape
+`,
+	})
+
+	config, err := LoadFromFile(context.Background(), filepath.Join(dir, "pod-evicted", "challenge.yaml"))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 2)
+	assert.Equal(t, "no-crashloops", config.Validations[0].Key)
+	assert.Equal(t, map[string]string{"app": "api"}, config.Validations[0].Spec.(StatusSpec).Target.LabelSelector)
+	assert.Equal(t, "scraped", config.Validations[1].Key)
+}
+
+func TestResolveIncludes_Remote(t *testing.T) {
+	orig := fetchInclude
+	var fetched []string
+	fetchInclude = func(_ context.Context, source string) ([]byte, error) {
+		fetched = append(fetched, source)
+		switch source {
+		case "https://raw.githubusercontent.com/kubeasy-dev/lib/main/standard.yaml":
+			return []byte("objectives:\n  - include: pods/no-crashloops.yaml\n"), nil
+		case "https://raw.githubusercontent.com/kubeasy-dev/lib/main/pods/no-crashloops.yaml":
+			return []byte(noCrashloopsFragment), nil
+		}
+		return nil, fmt.Errorf("unexpected source %s", source)
+	}
+	t.Cleanup(func() { fetchInclude = orig })
+
+	data, err := ResolveIncludes(context.Background(), []byte("objectives:\n  - include: https://raw.githubusercontent.com/kubeasy-dev/lib/main/standard.yaml\n"), "challenge.yaml")
+	require.NoError(t, err)
+	config, err := Parse(data)
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	assert.Equal(t, "no-crashloops", config.Validations[0].Key)
+	assert.Len(t, fetched, 2)
+}
+
+func TestResolveIncludes_Cancelled(t *testing.T) {
+	orig := fetchInclude
+	fetchInclude = func(ctx context.Context, _ string) ([]byte, error) {
+		return nil, ctx.Err()
+	}
+	t.Cleanup(func() { fetchInclude = orig })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ResolveIncludes(ctx, []byte("objectives:\n  - include: https://raw.githubusercontent.com/kubeasy-dev/lib/main/standard.yaml\n"), "challenge.yaml")
+	require.ErrorIs(t, err, context.Canceled, "the download runs under the caller's context")
+}
+
+func TestResolveIncludes_Errors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml":     "objectives:\n  - include: b.yaml\n",
+		"b.yaml":     "objectives:\n  - include: a.yaml\n",
+		"self.yaml":  "objectives:\n  - include: challenge/challenge.yaml\n",
+		"empty.yaml": "title: nothing to include\n",
+	})
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"cycle", "objectives:\n  - include: ../a.yaml\n", fmt.Sprintf("include cycle: %[1]s/challenge/challenge.yaml -> %[1]s/a.yaml -> %[1]s/b.yaml -> %[1]s/a.yaml", dir)},
+		{"includes itself", "objectives:\n  - include: ../self.yaml\n", "include cycle"},
+		{"missing file", "objectives:\n  - key: a\n  - include: ../missing.yaml\n", `objectives[1]: include "../missing.yaml"`},
+		{"no objectives", "objectives:\n  - include: ../empty.yaml\n", "fragment has no objectives list"},
+		{"extra fields", "objectives:\n  - include: ../a.yaml\n    key: a\n", "objectives[0]: include cannot be combined with other fields"},
+		{"empty source", "objectives:\n  - include: \"\"\n", "objectives[0]: include must be a path or URL"},
+		{"untrusted URL", "objectives:\n  - include: https://example.com/lib.yaml\n", "is not from a trusted domain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveIncludes(context.Background(), []byte(tt.data), filepath.Join(dir, "challenge", "challenge.yaml"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParse_UnresolvedInclude(t *testing.T) {
+	_, err := Parse([]byte("objectives:\n  - include: lib/standard.yaml\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "objectives[0]: include is only supported in challenge files loaded from disk")
+}
@@ -2,12 +2,11 @@ package validation
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
 
-	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
 	"github.com/kubeasy-dev/registry/pkg/challenges"
 	"go.yaml.in/yaml/v3"
 )
@@ -15,6 +14,10 @@ import (
 const (
 	// DefaultLogSinceSeconds is the default time window for log searches (5 minutes).
 	DefaultLogSinceSeconds = 300
+	// DefaultLogTailLines is the default number of lines read from each container log.
+	DefaultLogTailLines = 1000
+	// DefaultLogMaxBytes is the default cap on the log bytes read by a log validation.
+	DefaultLogMaxBytes = 256 * 1024
 
 	// DefaultEventSinceSeconds is the default time window for event searches (5 minutes).
 	DefaultEventSinceSeconds = 300
@@ -30,12 +33,31 @@ const (
 )
 
 // LoadFromFile loads validations from a local challenge.yaml file.
-func LoadFromFile(path string) (*ValidationConfig, error) {
+func LoadFromFile(ctx context.Context, path string) (*ValidationConfig, error) {
+	data, err := readChallengeFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// LoadFromFileStrict loads validations from a local challenge.yaml file, rejecting
+// files that do not match the JSON Schema. Used by authoring (dev) commands.
+func LoadFromFileStrict(ctx context.Context, path string) (*ValidationConfig, error) {
+	data, err := readChallengeFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStrict(data)
+}
+
+// readChallengeFile reads a local challenge.yaml file and resolves its includes.
+func readChallengeFile(ctx context.Context, path string) ([]byte, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
-	return Parse(data)
+	return ResolveIncludes(ctx, data, path)
 }
 
 // FindLocalChallengeFile looks for challenge.yaml in common local development paths.
@@ -61,12 +83,97 @@ func FindLocalChallengeFile(slug string) string {
 
 // Parse parses a challenge.yaml into a ValidationConfig ready for execution.
 // Delegates to the registry's shared parser and applies CLI-specific defaults.
+// A challenge with variants is parsed with its first variant.
 func Parse(data []byte) (*ValidationConfig, error) {
-	c, err := challenges.ParseBytes(data, "")
+	return ParseForSeed(data, "")
+}
+
+// ParseForSeed is Parse with the variant selected by seed (see VariantSeed and
+// SelectVariant), whose name is recorded in the returned config.
+func ParseForSeed(data []byte, seed string) (*ValidationConfig, error) {
+	data, variant, err := SelectVariant(data, seed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse challenge: %w", err)
 	}
-	return fromChallenge(c), nil
+	_, config, err := parseChallenge(data, "")
+	if err != nil {
+		return nil, err
+	}
+	config.Variant = variant
+	return config, nil
+}
+
+// ParseChallengeSpec parses challenge.yaml bytes into a ChallengeSpec holding both
+// the challenge metadata and the validations ready for execution, with the first
+// variant of a challenge with variants.
+func ParseChallengeSpec(data []byte, slug string) (*ChallengeSpec, error) {
+	data, _, err := SelectVariant(data, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse challenge: %w", err)
+	}
+	c, config, err := parseChallenge(data, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &ChallengeSpec{
+		Slug:               c.Slug,
+		Title:              c.Title,
+		Description:        c.Description,
+		Theme:              c.Theme,
+		Difficulty:         c.Difficulty,
+		Type:               c.Type,
+		EstimatedTime:      c.EstimatedTime,
+		InitialSituation:   c.InitialSituation,
+		MinRequiredVersion: c.MinRequiredVersion,
+		Validations:        config.Validations,
+		ForbiddenActions:   config.ForbiddenActions,
+	}, nil
+}
+
+// LoadChallengeSpecFromFile loads a ChallengeSpec from a local challenge.yaml file.
+// The slug is derived from the name of the directory containing the file.
+func LoadChallengeSpecFromFile(ctx context.Context, path string) (*ChallengeSpec, error) {
+	data, err := readChallengeFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	return ParseChallengeSpec(data, filepath.Base(filepath.Dir(absPath)))
+}
+
+// ListLocalChallenges returns the challenges found in the immediate subdirectories
+// of dir (each containing a challenge.yaml), sorted by slug.
+// Directories whose challenge.yaml fails to parse are reported in the returned error
+// but do not prevent the other challenges from being listed.
+func ListLocalChallenges(ctx context.Context, dir string) ([]*ChallengeSpec, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var specs []*ChallengeSpec
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name(), "challenge.yaml")
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		spec, err := LoadChallengeSpecFromFile(ctx, path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name(), err))
+			continue
+		}
+		specs = append(specs, spec)
+	}
+
+	// os.ReadDir returns entries sorted by filename, so specs are already sorted by slug.
+	return specs, errors.Join(errs...)
 }
 
 // fromChallenge converts a registry Challenge into a CLI ValidationConfig.
@@ -91,12 +198,21 @@ func fromObjective(obj challenges.Objective) Validation {
 
 	// Registry stores pointer specs; executors assert value types — dereference here.
 	switch s := obj.Spec.(type) {
-	case *StatusSpec:
-		v.Spec = *s
+	case *challenges.StatusSpec:
+		v.Spec = StatusSpec{Target: s.Target, Checks: s.Checks}
 	case *ConditionSpec:
 		v.Spec = *s
-	case *LogSpec:
-		cp := *s
+	case *challenges.LogSpec:
+		cp := LogSpec{
+			Target:          s.Target,
+			Container:       s.Container,
+			ExpectedStrings: s.ExpectedStrings,
+			SinceSeconds:    s.SinceSeconds,
+			Previous:        s.Previous,
+			MatchMode:       s.MatchMode,
+			TailLines:       DefaultLogTailLines,
+			MaxBytes:        DefaultLogMaxBytes,
+		}
 		if cp.SinceSeconds == 0 {
 			cp.SinceSeconds = DefaultLogSinceSeconds
 		}
@@ -137,32 +253,69 @@ func fromObjective(obj challenges.Objective) Validation {
 	return v
 }
 
-// LoadForChallenge loads validations for a challenge slug.
-// Tries local file first (dev override), then the Kubeasy API.
-func LoadForChallenge(slug string) (*ValidationConfig, error) {
-	if localPath := FindLocalChallengeFile(slug); localPath != "" {
-		return LoadFromFile(localPath)
+// DecodeSpec decodes the spec of a validation of type typ, in YAML or JSON, into its
+// typed spec, with the defaults and CLI-only fields the loader applies to challenge
+// files.
+func DecodeSpec(typ ValidationType, raw []byte) (interface{}, error) {
+	var spec yaml.Node
+	if err := yaml.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("invalid %s spec: %w", typ, err)
 	}
-
-	client, err := api.NewPublicClient()
+	if len(spec.Content) == 0 || spec.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid %s spec: expected a mapping", typ)
+	}
+	// Decode it as the only objective of a challenge, so registry and CLI-only types
+	// go through the same parsers as in challenge.yaml.
+	doc := map[string]interface{}{
+		"objectives": []interface{}{
+			map[string]interface{}{"key": "spec", "type": string(typ), "spec": spec.Content[0]},
+		},
+	}
+	data, err := yaml.Marshal(doc)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid %s spec: %w", typ, err)
 	}
-
-	resp, err := client.GetChallengeYamlWithResponse(context.Background(), slug)
+	_, config, err := parseChallenge(data, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to load challenge %q from API: %w", slug, err)
+		return nil, err
 	}
+	if len(config.Validations) != 1 || config.Validations[0].Spec == nil {
+		return nil, fmt.Errorf("unsupported validation type %q", typ)
+	}
+	return config.Validations[0].Spec, nil
+}
 
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API returned HTTP %d for challenge %q", resp.StatusCode(), slug)
+// LoadForChallenge loads validations for a challenge slug, with the variant of userID
+// (the first variant when it is empty).
+// Tries local file first (dev override), then the Kubeasy API through the local cache.
+func LoadForChallenge(ctx context.Context, slug, userID string) (*ValidationConfig, error) {
+	seed := VariantSeed(slug, userID)
+	if localPath := FindLocalChallengeFile(slug); localPath != "" {
+		data, err := readChallengeFile(ctx, localPath)
+		if err != nil {
+			return nil, err
+		}
+		return ParseForSeed(data, seed)
 	}
 
-	return Parse(resp.Body)
+	data, err := fetchChallengeYaml(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return ParseForSeed(data, seed)
 }
 
-// ParseChallengeYaml parses challenge.yaml bytes into a ChallengeYamlSpec (for lint/display).
+// ParseChallengeYaml parses challenge.yaml bytes into a ChallengeYamlSpec (for lint/display),
+// with the first variant of a challenge with variants.
 func ParseChallengeYaml(data []byte) (*ChallengeYamlSpec, error) {
+	data, _, err := SelectVariant(data, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse challenge.yaml: %w", err)
+	}
+	data, err = ExpandVars(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse challenge.yaml: %w", err)
+	}
 	var spec ChallengeYamlSpec
 	if err := yaml.Unmarshal(data, &spec); err != nil {
 		return nil, fmt.Errorf("failed to parse challenge.yaml: %w", err)
@@ -172,28 +325,18 @@ func ParseChallengeYaml(data []byte) (*ChallengeYamlSpec, error) {
 
 // LoadChallengeYamlForChallenge loads the full ChallengeYamlSpec for display in kubeasy start.
 // Tries local file first, then the Kubeasy API.
-func LoadChallengeYamlForChallenge(slug string) (*ChallengeYamlSpec, error) {
+func LoadChallengeYamlForChallenge(ctx context.Context, slug string) (*ChallengeYamlSpec, error) {
 	if localPath := FindLocalChallengeFile(slug); localPath != "" {
-		data, err := os.ReadFile(localPath)
+		data, err := readChallengeFile(ctx, localPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read file: %w", err)
+			return nil, err
 		}
 		return ParseChallengeYaml(data)
 	}
 
-	client, err := api.NewPublicClient()
+	data, err := fetchChallengeYaml(ctx, slug)
 	if err != nil {
 		return nil, err
 	}
-
-	resp, err := client.GetChallengeYamlWithResponse(context.Background(), slug)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load challenge %q from API: %w", slug, err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API returned HTTP %d for challenge %q", resp.StatusCode(), slug)
-	}
-
-	return ParseChallengeYaml(resp.Body)
+	return ParseChallengeYaml(data)
 }
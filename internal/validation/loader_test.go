@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -556,14 +557,14 @@ objectives:
 		require.NoError(t, err)
 
 		// Load file
-		config, err := LoadFromFile(filePath)
+		config, err := LoadFromFile(context.Background(), filePath)
 		require.NoError(t, err)
 		require.Len(t, config.Validations, 1)
 		assert.Equal(t, "test", config.Validations[0].Key)
 	})
 
 	t.Run("error - file not found", func(t *testing.T) {
-		_, err := LoadFromFile("/nonexistent/path/challenge.yaml")
+		_, err := LoadFromFile(context.Background(), "/nonexistent/path/challenge.yaml")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to read file")
 	})
@@ -575,7 +576,7 @@ objectives:
 		err := os.WriteFile(filePath, []byte("not: valid: yaml: ["), 0600)
 		require.NoError(t, err)
 
-		_, err = LoadFromFile(filePath)
+		_, err = LoadFromFile(context.Background(), filePath)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to parse")
 	})
@@ -1933,8 +1934,111 @@ objectives: []
 
 	t.Setenv("KUBEASY_LOCAL_CHALLENGES_DIR", dir)
 
-	spec, err := LoadChallengeYamlForChallenge(slug)
+	spec, err := LoadChallengeYamlForChallenge(context.Background(), slug)
 	require.NoError(t, err)
 	assert.Equal(t, "Test", spec.Title)
 	assert.Equal(t, "1.5.0", spec.MinRequiredVersion)
 }
+
+// TestParseChallengeSpec verifies metadata and objectives are parsed together.
+func TestParseChallengeSpec(t *testing.T) {
+	data := []byte(`
+title: "Pod Evicted"
+type: fix
+theme: resources-scaling
+difficulty: easy
+estimatedTime: 15
+initialSituation: "A pod is running."
+description: "The pod keeps crashing."
+objectives:
+  - key: pod-ready
+    title: Pod Ready
+    order: 1
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        labelSelector:
+          app: test
+      checks:
+        - type: Ready
+          status: "True"
+`)
+	spec, err := ParseChallengeSpec(data, "pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, "pod-evicted", spec.Slug)
+	assert.Equal(t, "Pod Evicted", spec.Title)
+	assert.Equal(t, "fix", spec.Type)
+	assert.Equal(t, "resources-scaling", spec.Theme)
+	assert.Equal(t, "easy", spec.Difficulty)
+	assert.Equal(t, 15, spec.EstimatedTime)
+	require.Len(t, spec.Validations, 1)
+	assert.Equal(t, "pod-ready", spec.Validations[0].Key)
+	_, ok := spec.Validations[0].Spec.(ConditionSpec)
+	assert.True(t, ok, "spec should be ConditionSpec")
+
+	_, err = ParseChallengeSpec([]byte(":\tinvalid"), "x")
+	require.Error(t, err)
+}
+
+// TestListLocalChallenges verifies local challenge discovery and slug derivation.
+func TestListLocalChallenges(t *testing.T) {
+	dir := t.TempDir()
+	write := func(slug, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, slug), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, slug, "challenge.yaml"), []byte(content), 0o600))
+	}
+	write("zeta-challenge", "title: Zeta\ndifficulty: hard\nobjectives: []\n")
+	write("alpha-challenge", "title: Alpha\ndifficulty: easy\nestimatedTime: 10\nobjectives: []\n")
+	write("broken-challenge", "objectives:\n  - key: x\n    type: unknown-type\n    spec:\n      foo: bar\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "not-a-challenge"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# readme"), 0o600))
+
+	specs, err := ListLocalChallenges(context.Background(), dir)
+	require.Error(t, err, "broken challenge should be reported")
+	assert.Contains(t, err.Error(), "broken-challenge")
+	require.Len(t, specs, 2)
+	assert.Equal(t, "alpha-challenge", specs[0].Slug)
+	assert.Equal(t, "Alpha", specs[0].Title)
+	assert.Equal(t, 10, specs[0].EstimatedTime)
+	assert.Equal(t, "zeta-challenge", specs[1].Slug)
+
+	_, err = ListLocalChallenges(context.Background(), filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestDecodeSpec(t *testing.T) {
+	spec, err := DecodeSpec(TypeLog, []byte(`
+target:
+  kind: Pod
+  labelSelector:
+    app: web
+expectedStrings: ["ready"]
+`))
+	require.NoError(t, err)
+	logSpec, ok := spec.(LogSpec)
+	require.True(t, ok, "expected LogSpec, got %T", spec)
+	assert.Equal(t, []string{"ready"}, logSpec.ExpectedStrings)
+	assert.Equal(t, DefaultLogSinceSeconds, logSpec.SinceSeconds, "loader defaults are applied")
+
+	spec, err = DecodeSpec(TypeStatus, []byte(`{"target": {"kind": "Deployment", "name": "web"}, "latestRevisionOnly": true}`))
+	require.NoError(t, err)
+	statusSpec, ok := spec.(StatusSpec)
+	require.True(t, ok, "expected StatusSpec, got %T", spec)
+	assert.True(t, statusSpec.LatestRevisionOnly, "CLI-only fields are decoded")
+
+	spec, err = DecodeSpec(TypePlugin, []byte(`name: my-check`))
+	require.NoError(t, err)
+	assert.IsType(t, PluginSpec{}, spec, "CLI-only types are decoded")
+}
+
+func TestDecodeSpec_Invalid(t *testing.T) {
+	_, err := DecodeSpec(TypeStatus, []byte(`- not a mapping`))
+	assert.ErrorContains(t, err, "expected a mapping")
+
+	_, err = DecodeSpec(TypePlugin, []byte(`name: Not_Valid`))
+	assert.ErrorContains(t, err, "invalid plugin name")
+
+	_, err = DecodeSpec("no-such-type", []byte(`a: b`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,647 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/registry/pkg/challenges"
+	"go.yaml.in/yaml/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultPluginTimeoutSeconds is the default time a validator plugin may run.
+const DefaultPluginTimeoutSeconds = 60
+
+const (
+	// DefaultPromMetricsTimeoutSeconds is the default time a metrics scrape may take.
+	DefaultPromMetricsTimeoutSeconds = 10
+	// DefaultPromMetricsPath is scraped on the target pod when no path is given.
+	DefaultPromMetricsPath = "/metrics"
+)
+
+// numericOperators are the comparisons supported by numeric CLI-only checks
+// (promMetrics, endpoints, podDisruptionBudget).
+var numericOperators = map[string]bool{"==": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true}
+
+// intOrPercentPattern matches the values of IntOrString fields such as minAvailable.
+var intOrPercentPattern = regexp.MustCompile(`^[0-9]+%?$`)
+
+// pluginNamePattern restricts plugin names so they map to a single binary name on PATH.
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// localSpecDecoders decodes the specs of CLI-specific objective types. The registry
+// parser rejects these types as unknown, so they are decoded here and masked before
+// the file is handed to it.
+var localSpecDecoders = map[ValidationType]func(node *yaml.Node) (interface{}, error){
+	TypePlugin:              decodePluginSpec,
+	TypePromMetrics:         decodePromMetricsSpec,
+	TypeEndpoints:           decodeEndpointsSpec,
+	TypeNetworkPolicy:       decodeNetworkPolicySpec,
+	TypeNode:                decodeNodeSpec,
+	TypePodDisruptionBudget: decodePodDisruptionBudgetSpec,
+	TypeProbes:              decodeProbesSpec,
+	TypeImages:              decodeImagesSpec,
+	TypeResources:           decodeResourcesSpec,
+	TypeMetadata:            decodeMetadataSpec,
+}
+
+// specExtensions decode the CLI-only fields of registry objective types. The registry
+// parser ignores fields it does not know, so once it has parsed a spec the extension
+// reads those fields from the same YAML node and returns the completed spec.
+var specExtensions = map[ValidationType]func(node *yaml.Node, spec interface{}) (interface{}, error){
+	TypeStatus: extendStatusSpec,
+	TypeLog:    extendLogSpec,
+}
+
+type localObjective struct {
+	typ  ValidationType
+	spec interface{}
+}
+
+// parseChallenge parses challenge.yaml with the registry parser, adding support for
+// template variables, the objective types in localSpecDecoders and forbidden actions.
+// Includes must have been resolved.
+func parseChallenge(data []byte, slug string) (*challenges.Challenge, *ValidationConfig, error) {
+	if err := checkIncludesResolved(data); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse challenge: %w", err)
+	}
+	data, err := ExpandVars(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse challenge: %w", err)
+	}
+	registryData, local, err := maskLocalObjectives(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse challenge: %w", err)
+	}
+	c, err := challenges.ParseBytes(registryData, slug)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse challenge: %w", err)
+	}
+
+	config := fromChallenge(c)
+	for i, lo := range local {
+		config.Validations[i].Type = lo.typ
+		config.Validations[i].Spec = lo.spec
+	}
+	if err := extendSpecs(data, config.Validations); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse challenge: %w", err)
+	}
+	if config.ForbiddenActions, err = decodeForbiddenActions(data); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse challenge: %w", err)
+	}
+	return c, config, nil
+}
+
+// extendSpecs applies specExtensions to the objectives of data, including those nested
+// in triggered objectives. validations must be in the order of the file.
+func extendSpecs(data []byte, validations []Validation) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil //nolint:nilerr // already reported by the registry parser
+	}
+	if err := extendObjectives(mappingValue(doc.Content[0], "objectives"), validations, "objectives"); err != nil {
+		return err
+	}
+	return checkPhases(validations)
+}
+
+// checkPhases requires every top-level objective to set a phase once one of them does,
+// so no objective is left out of the grouped output.
+func checkPhases(validations []Validation) error {
+	if GroupByPhase(validations) == nil {
+		return nil
+	}
+	for i, v := range validations {
+		if v.Phase == "" {
+			return fmt.Errorf("objectives[%d] %q: phase is required when other objectives set one", i, v.Key)
+		}
+	}
+	return nil
+}
+
+func extendObjectives(items *yaml.Node, validations []Validation, field string) error {
+	if items == nil || items.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for i, item := range items.Content {
+		if i >= len(validations) {
+			break
+		}
+		v := &validations[i]
+		if phase := mappingValue(item, "phase"); phase != nil {
+			v.Phase = phase.Value
+		}
+		specNode := mappingValue(item, "spec")
+		if triggered, ok := v.Spec.(TriggeredSpec); ok {
+			if err := extendObjectives(mappingValue(specNode, "then"), triggered.Then, "then"); err != nil {
+				return fmt.Errorf("%s[%d] %q: spec: %w", field, i, v.Key, err)
+			}
+			continue
+		}
+		extend, ok := specExtensions[v.Type]
+		if !ok || specNode == nil || v.Spec == nil {
+			continue
+		}
+		spec, err := extend(specNode, v.Spec)
+		if err != nil {
+			return fmt.Errorf("%s[%d] %q: spec: %w", field, i, v.Key, err)
+		}
+		v.Spec = spec
+	}
+	return nil
+}
+
+func extendStatusSpec(node *yaml.Node, spec interface{}) (interface{}, error) {
+	s := spec.(StatusSpec)
+	var ext struct {
+		LatestRevisionOnly bool                   `yaml:"latestRevisionOnly"`
+		Containers         []ContainerStatusCheck `yaml:"containers"`
+	}
+	if err := node.Decode(&ext); err != nil {
+		return nil, err
+	}
+	for i, c := range ext.Containers {
+		switch {
+		case c.Name == "":
+			return nil, fmt.Errorf("containers[%d]: name is required", i)
+		case c.Ready == nil && c.Completed == nil && c.MaxRestarts == nil:
+			return nil, fmt.Errorf("containers[%d] %q: set at least one of ready, completed or maxRestarts", i, c.Name)
+		case c.MaxRestarts != nil && *c.MaxRestarts < 0:
+			return nil, fmt.Errorf("containers[%d] %q: maxRestarts must not be negative", i, c.Name)
+		}
+	}
+	if ext.LatestRevisionOnly {
+		switch {
+		case s.Target.Kind != "Deployment" && s.Target.Kind != "StatefulSet":
+			return nil, fmt.Errorf("latestRevisionOnly requires a Deployment or StatefulSet target, got %q", s.Target.Kind)
+		case s.Target.Name == "":
+			return nil, fmt.Errorf("latestRevisionOnly requires a target name")
+		}
+	}
+	s.LatestRevisionOnly = ext.LatestRevisionOnly
+	s.Containers = ext.Containers
+	return s, nil
+}
+
+func extendLogSpec(node *yaml.Node, spec interface{}) (interface{}, error) {
+	s := spec.(LogSpec)
+	var ext struct {
+		TailLines *int `yaml:"tailLines"`
+		MaxBytes  *int `yaml:"maxBytes"`
+	}
+	if err := node.Decode(&ext); err != nil {
+		return nil, err
+	}
+	if ext.TailLines != nil {
+		if *ext.TailLines <= 0 || int64(*ext.TailLines) > shared.MaxLogTailLines {
+			return nil, fmt.Errorf("tailLines must be between 1 and %d", shared.MaxLogTailLines)
+		}
+		s.TailLines = *ext.TailLines
+	}
+	if ext.MaxBytes != nil {
+		if *ext.MaxBytes <= 0 || int64(*ext.MaxBytes) > shared.MaxLogBytes {
+			return nil, fmt.Errorf("maxBytes must be between 1 and %d", shared.MaxLogBytes)
+		}
+		s.MaxBytes = *ext.MaxBytes
+	}
+	return s, nil
+}
+
+// maskLocalObjectives decodes the top-level objectives of a local type and rewrites
+// them as spec-less status objectives, so objective indices in registry errors stay
+// accurate. data is returned unchanged when there is no local objective.
+func maskLocalObjectives(data []byte) ([]byte, map[int]localObjective, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		// Let the registry parser report malformed YAML.
+		return data, nil, nil //nolint:nilerr // reported by the registry parser
+	}
+	objectives := mappingValue(doc.Content[0], "objectives")
+	if objectives == nil || objectives.Kind != yaml.SequenceNode {
+		return data, nil, nil
+	}
+
+	local := map[int]localObjective{}
+	for i, item := range objectives.Content {
+		typeNode := mappingValue(item, "type")
+		if typeNode == nil {
+			continue
+		}
+		typ := ValidationType(typeNode.Value)
+		decode, ok := localSpecDecoders[typ]
+		if !ok {
+			continue
+		}
+
+		key := ""
+		if keyNode := mappingValue(item, "key"); keyNode != nil {
+			key = keyNode.Value
+		}
+		spec, err := decode(mappingValue(item, "spec"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("objectives[%d] %q: spec: %w", i, key, err)
+		}
+		local[i] = localObjective{typ: typ, spec: spec}
+
+		typeNode.Value = string(TypeStatus)
+		removeMappingKey(item, "spec")
+	}
+	if len(local) == 0 {
+		return data, nil, nil
+	}
+
+	masked, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to re-encode challenge: %w", err)
+	}
+	return masked, local, nil
+}
+
+func decodePluginSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("plugin spec is required")
+	}
+	var s PluginSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+	if !pluginNamePattern.MatchString(s.Name) {
+		return nil, fmt.Errorf("invalid plugin name %q: use lowercase letters, digits and dashes", s.Name)
+	}
+	switch {
+	case s.TimeoutSeconds < 0:
+		return nil, fmt.Errorf("timeoutSeconds must be positive")
+	case s.TimeoutSeconds > MaxTriggerWaitSeconds:
+		return nil, fmt.Errorf("timeoutSeconds must not exceed %d", MaxTriggerWaitSeconds)
+	case s.TimeoutSeconds == 0:
+		s.TimeoutSeconds = DefaultPluginTimeoutSeconds
+	}
+	return s, nil
+}
+
+func decodePromMetricsSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("promMetrics spec is required")
+	}
+	var s PromMetricsSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case s.URL == "" && s.Target == nil:
+		return nil, fmt.Errorf("either url or target is required")
+	case s.URL != "" && s.Target != nil:
+		return nil, fmt.Errorf("url and target are mutually exclusive")
+	case s.URL != "":
+		if !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://") {
+			return nil, fmt.Errorf("url must start with http:// or https://")
+		}
+		if s.Port != 0 || s.Path != "" {
+			return nil, fmt.Errorf("port and path only apply to target")
+		}
+	default:
+		if s.Port < 1 || s.Port > 65535 {
+			return nil, fmt.Errorf("target requires a port between 1 and 65535")
+		}
+		if s.Path == "" {
+			s.Path = DefaultPromMetricsPath
+		} else if !strings.HasPrefix(s.Path, "/") {
+			return nil, fmt.Errorf("path must start with /")
+		}
+	}
+
+	if len(s.Checks) == 0 {
+		return nil, fmt.Errorf("at least one check is required")
+	}
+	for i, c := range s.Checks {
+		if c.Metric == "" {
+			return nil, fmt.Errorf("checks[%d]: metric is required", i)
+		}
+		if !numericOperators[c.Operator] {
+			return nil, fmt.Errorf("checks[%d]: unsupported operator %q (use ==, !=, >, >=, < or <=)", i, c.Operator)
+		}
+	}
+
+	switch {
+	case s.TimeoutSeconds < 0:
+		return nil, fmt.Errorf("timeoutSeconds must be positive")
+	case s.TimeoutSeconds > MaxTriggerWaitSeconds:
+		return nil, fmt.Errorf("timeoutSeconds must not exceed %d", MaxTriggerWaitSeconds)
+	case s.TimeoutSeconds == 0:
+		s.TimeoutSeconds = DefaultPromMetricsTimeoutSeconds
+	}
+	return s, nil
+}
+
+func decodeEndpointsSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("endpoints spec is required")
+	}
+	var s EndpointsSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+	if s.Service == "" {
+		return nil, fmt.Errorf("service is required")
+	}
+	if s.Operator == "" {
+		s.Operator = "=="
+	} else if !numericOperators[s.Operator] {
+		return nil, fmt.Errorf("unsupported operator %q (use ==, !=, >, >=, < or <=)", s.Operator)
+	}
+	if s.ReadyEndpoints < 0 {
+		return nil, fmt.Errorf("readyEndpoints must not be negative")
+	}
+	return s, nil
+}
+
+func decodeNetworkPolicySpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("networkPolicy spec is required")
+	}
+	var s NetworkPolicySpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+	if s.Port < 1 || s.Port > 65535 {
+		return nil, fmt.Errorf("port must be between 1 and 65535")
+	}
+	switch s.Protocol {
+	case "":
+		s.Protocol = "TCP"
+	case "TCP", "UDP", "SCTP":
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q (use TCP, UDP or SCTP)", s.Protocol)
+	}
+	if s.Expect != NetworkPolicyAllowed && s.Expect != NetworkPolicyDenied {
+		return nil, fmt.Errorf("expect must be %q or %q", NetworkPolicyAllowed, NetworkPolicyDenied)
+	}
+	return s, nil
+}
+
+func decodeNodeSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("node spec is required")
+	}
+	var s NodeSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+
+	nodeChecks := len(s.Labels) > 0 || len(s.Taints) > 0 || len(s.Conditions) > 0 || len(s.MinAllocatable) > 0
+	if !nodeChecks && s.Target == nil {
+		return nil, fmt.Errorf("at least one of labels, taints, conditions, minAllocatable or target is required")
+	}
+	if len(s.ScheduledOn) > 0 && s.Target == nil {
+		return nil, fmt.Errorf("scheduledOn requires a target")
+	}
+	for i, t := range s.Taints {
+		if t.Key == "" {
+			return nil, fmt.Errorf("taints[%d]: key is required", i)
+		}
+		switch corev1.TaintEffect(t.Effect) {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return nil, fmt.Errorf("taints[%d]: unsupported effect %q (use NoSchedule, PreferNoSchedule or NoExecute)", i, t.Effect)
+		}
+	}
+	for i, c := range s.Conditions {
+		if c.Type == "" {
+			return nil, fmt.Errorf("conditions[%d]: type is required", i)
+		}
+		switch corev1.ConditionStatus(c.Status) {
+		case corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown:
+		default:
+			return nil, fmt.Errorf("conditions[%d]: status must be True, False or Unknown", i)
+		}
+	}
+	for name, q := range s.MinAllocatable {
+		if _, err := resource.ParseQuantity(q); err != nil {
+			return nil, fmt.Errorf("minAllocatable.%s: invalid quantity %q", name, q)
+		}
+	}
+	return s, nil
+}
+
+func decodePodDisruptionBudgetSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("podDisruptionBudget spec is required")
+	}
+	var s PodDisruptionBudgetSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case s.Name == "" && s.Target == nil:
+		return nil, fmt.Errorf("either name or target is required")
+	case s.Name != "" && s.Target != nil:
+		return nil, fmt.Errorf("name and target are mutually exclusive")
+	case s.MinAvailable != "" && s.MaxUnavailable != "":
+		return nil, fmt.Errorf("minAvailable and maxUnavailable are mutually exclusive")
+	}
+	for field, v := range map[string]string{"minAvailable": s.MinAvailable, "maxUnavailable": s.MaxUnavailable} {
+		if v != "" && !intOrPercentPattern.MatchString(v) {
+			return nil, fmt.Errorf("%s must be a number or a percentage, got %q", field, v)
+		}
+	}
+
+	if s.DisruptionsAllowed == nil {
+		if s.Operator != "" {
+			return nil, fmt.Errorf("operator requires disruptionsAllowed")
+		}
+		return s, nil
+	}
+	if *s.DisruptionsAllowed < 0 {
+		return nil, fmt.Errorf("disruptionsAllowed must not be negative")
+	}
+	if s.Operator == "" {
+		s.Operator = "=="
+	} else if !numericOperators[s.Operator] {
+		return nil, fmt.Errorf("unsupported operator %q (use ==, !=, >, >=, < or <=)", s.Operator)
+	}
+	return s, nil
+}
+
+func decodeProbesSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("probes spec is required")
+	}
+	var s ProbesSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+	if len(s.Probes) == 0 {
+		return nil, fmt.Errorf("at least one probe is required")
+	}
+	for i, p := range s.Probes {
+		switch p.Type {
+		case ProbeLiveness, ProbeReadiness, ProbeStartup:
+		default:
+			return nil, fmt.Errorf("probes[%d]: unsupported type %q (use liveness, readiness or startup)", i, p.Type)
+		}
+		if p.Path != "" && !strings.HasPrefix(p.Path, "/") {
+			return nil, fmt.Errorf("probes[%d]: path must start with /", i)
+		}
+		for _, f := range []struct {
+			name  string
+			value *int32
+		}{
+			{"initialDelaySeconds", p.InitialDelaySeconds},
+			{"periodSeconds", p.PeriodSeconds},
+			{"timeoutSeconds", p.TimeoutSeconds},
+			{"failureThreshold", p.FailureThreshold},
+			{"successThreshold", p.SuccessThreshold},
+		} {
+			if f.value != nil && *f.value < 0 {
+				return nil, fmt.Errorf("probes[%d]: %s must not be negative", i, f.name)
+			}
+		}
+	}
+	return s, nil
+}
+
+func decodeImagesSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("images spec is required")
+	}
+	var s ImagesSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+	if !s.ForbidLatest && !s.RequireDigest && len(s.AllowedRegistries) == 0 && s.PullPolicy == "" {
+		return nil, fmt.Errorf("at least one of forbidLatest, requireDigest, allowedRegistries or pullPolicy is required")
+	}
+	for i, r := range s.AllowedRegistries {
+		if r == "" || strings.Contains(r, "://") || strings.HasSuffix(r, "/") {
+			return nil, fmt.Errorf("allowedRegistries[%d]: %q must be a registry host or repository prefix, e.g. ghcr.io/org", i, r)
+		}
+	}
+	switch corev1.PullPolicy(s.PullPolicy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return nil, fmt.Errorf("unsupported pullPolicy %q (use Always, IfNotPresent or Never)", s.PullPolicy)
+	}
+	return s, nil
+}
+
+func decodeResourcesSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("resources spec is required")
+	}
+	var s ResourcesSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+	if len(s.Requests) == 0 && len(s.Limits) == 0 {
+		return nil, fmt.Errorf("at least one of requests or limits is required")
+	}
+	for _, field := range []struct {
+		name   string
+		bounds map[string]ResourceBounds
+	}{{"requests", s.Requests}, {"limits", s.Limits}} {
+		names := make([]string, 0, len(field.bounds))
+		for name := range field.bounds {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := validateResourceBounds(field.bounds[name]); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", field.name, name, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+func validateResourceBounds(b ResourceBounds) error {
+	var minimum, maximum resource.Quantity
+	var err error
+	if b.Min != "" {
+		if minimum, err = resource.ParseQuantity(b.Min); err != nil {
+			return fmt.Errorf("invalid min quantity %q", b.Min)
+		}
+	}
+	if b.Max != "" {
+		if maximum, err = resource.ParseQuantity(b.Max); err != nil {
+			return fmt.Errorf("invalid max quantity %q", b.Max)
+		}
+	}
+	if b.Min != "" && b.Max != "" && minimum.Cmp(maximum) > 0 {
+		return fmt.Errorf("min %s is greater than max %s", b.Min, b.Max)
+	}
+	return nil
+}
+
+func decodeMetadataSpec(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, fmt.Errorf("metadata spec is required")
+	}
+	var s MetadataSpec
+	if err := node.Decode(&s); err != nil {
+		return nil, err
+	}
+	switch {
+	case s.Target.Kind == "":
+		return nil, fmt.Errorf("target.kind is required")
+	case s.Target.Name == "" && len(s.Target.LabelSelector) == 0:
+		return nil, fmt.Errorf("target requires a name or a labelSelector")
+	case len(s.Labels) == 0 && len(s.Annotations) == 0:
+		return nil, fmt.Errorf("at least one of labels or annotations is required")
+	}
+	for _, field := range []struct {
+		name   string
+		checks []MetadataCheck
+	}{{"labels", s.Labels}, {"annotations", s.Annotations}} {
+		for i, c := range field.checks {
+			if err := validateMetadataCheck(c); err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", field.name, i, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+func validateMetadataCheck(c MetadataCheck) error {
+	switch {
+	case c.Key == "":
+		return fmt.Errorf("key is required")
+	case c.Value != "" && c.Matches != "":
+		return fmt.Errorf("value and matches are mutually exclusive")
+	case c.Exists != nil && !*c.Exists && (c.Value != "" || c.Matches != ""):
+		return fmt.Errorf("exists: false cannot be combined with value or matches")
+	}
+	if c.Matches != "" {
+		if _, err := regexp.Compile(c.Matches); err != nil {
+			return fmt.Errorf("invalid matches pattern: %w", err)
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value node of key in a YAML mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func removeMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,688 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_PluginValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: pod-ready
+    title: Pod Ready
+    order: 1
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        name: web
+      checks:
+        - type: Ready
+          status: "True"
+  - key: custom
+    title: Custom
+    order: 2
+    type: plugin
+    spec:
+      name: cert-check
+      config:
+        secret: tls
+        minDays: 30
+`
+
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 2)
+
+	assert.Equal(t, TypeCondition, config.Validations[0].Type)
+	assert.IsType(t, ConditionSpec{}, config.Validations[0].Spec)
+
+	v := config.Validations[1]
+	assert.Equal(t, "custom", v.Key)
+	assert.Equal(t, "Custom", v.Title)
+	assert.Equal(t, TypePlugin, v.Type)
+	spec, ok := v.Spec.(PluginSpec)
+	require.True(t, ok, "expected PluginSpec, got %T", v.Spec)
+	assert.Equal(t, "cert-check", spec.Name)
+	assert.Equal(t, DefaultPluginTimeoutSeconds, spec.TimeoutSeconds)
+	assert.Equal(t, map[string]interface{}{"secret": "tls", "minDays": 30}, spec.Config)
+}
+
+func TestParse_PluginValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "plugin spec is required"},
+		{"missing name", "    spec:\n      timeoutSeconds: 10\n", "invalid plugin name"},
+		{"path in name", "    spec:\n      name: ../evil\n", "invalid plugin name"},
+		{"negative timeout", "    spec:\n      name: ok\n      timeoutSeconds: -1\n", "timeoutSeconds must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: custom\n    type: plugin\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "custom"`)
+		})
+	}
+}
+
+func TestParseStrict_PluginValidation(t *testing.T) {
+	yaml := `title: t
+description: d
+theme: pods-containers
+difficulty: easy
+type: fix
+estimatedTime: 10
+initialSituation: i
+objectives:
+  - key: custom
+    type: plugin
+    spec:
+      name: cert-check
+      timeoutSeconds: 20
+`
+	config, err := ParseStrict([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	assert.Equal(t, 20, config.Validations[0].Spec.(PluginSpec).TimeoutSeconds)
+}
+
+func TestParse_PromMetricsValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: serves-traffic
+    type: promMetrics
+    spec:
+      target:
+        kind: Pod
+        labelSelector:
+          app: web
+      port: 9090
+      checks:
+        - metric: http_requests_total
+          labels:
+            code: "200"
+          operator: ">="
+          value: 10
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	spec, ok := config.Validations[0].Spec.(PromMetricsSpec)
+	require.True(t, ok, "expected PromMetricsSpec, got %T", config.Validations[0].Spec)
+	assert.Equal(t, 9090, spec.Port)
+	assert.Equal(t, DefaultPromMetricsPath, spec.Path)
+	assert.Equal(t, DefaultPromMetricsTimeoutSeconds, spec.TimeoutSeconds)
+	assert.Equal(t, []PromMetricCheck{{Metric: "http_requests_total", Labels: map[string]string{"code": "200"}, Operator: ">=", Value: 10}}, spec.Checks)
+}
+
+func TestParse_PromMetricsValidationErrors(t *testing.T) {
+	check := "      checks:\n        - metric: up\n          operator: ==\n          value: 1\n"
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "promMetrics spec is required"},
+		{"no source", "    spec:\n" + check, "either url or target is required"},
+		{"both sources", "    spec:\n      url: http://web:9090\n      target:\n        name: web\n      port: 9090\n" + check, "mutually exclusive"},
+		{"bad scheme", "    spec:\n      url: tcp://web:9090\n" + check, "url must start with http:// or https://"},
+		{"port with url", "    spec:\n      url: http://web:9090\n      port: 9090\n" + check, "port and path only apply to target"},
+		{"missing port", "    spec:\n      target:\n        name: web\n" + check, "port between 1 and 65535"},
+		{"relative path", "    spec:\n      target:\n        name: web\n      port: 9090\n      path: metrics\n" + check, "path must start with /"},
+		{"no checks", "    spec:\n      url: http://web:9090\n", "at least one check is required"},
+		{"bad operator", "    spec:\n      url: http://web:9090\n      checks:\n        - metric: up\n          operator: ~=\n", `checks[0]: unsupported operator "~="`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: metrics\n    type: promMetrics\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "metrics"`)
+		})
+	}
+}
+
+func TestParse_EndpointsValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: has-endpoints
+    type: endpoints
+    spec:
+      service: web
+      readyEndpoints: 2
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	assert.Equal(t, TypeEndpoints, config.Validations[0].Type)
+	assert.Equal(t, EndpointsSpec{Service: "web", Operator: "==", ReadyEndpoints: 2}, config.Validations[0].Spec)
+}
+
+func TestParse_EndpointsValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "endpoints spec is required"},
+		{"missing service", "    spec:\n      readyEndpoints: 1\n", "service is required"},
+		{"bad operator", "    spec:\n      service: web\n      operator: ~=\n", `unsupported operator "~="`},
+		{"negative count", "    spec:\n      service: web\n      readyEndpoints: -1\n", "readyEndpoints must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: endpoints\n    type: endpoints\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "endpoints"`)
+		})
+	}
+}
+
+func TestParse_NetworkPolicyValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: db-isolated
+    type: networkPolicy
+    spec:
+      from:
+        labels:
+          app: debug
+      to:
+        namespace: data
+        labels:
+          app: db
+      port: 5432
+      expect: denied
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	assert.Equal(t, NetworkPolicySpec{
+		From:     NetworkPolicyPeer{Labels: map[string]string{"app": "debug"}},
+		To:       NetworkPolicyPeer{Namespace: "data", Labels: map[string]string{"app": "db"}},
+		Port:     5432,
+		Protocol: "TCP",
+		Expect:   NetworkPolicyDenied,
+	}, config.Validations[0].Spec)
+}
+
+func TestParse_NetworkPolicyValidationErrors(t *testing.T) {
+	peers := "      from:\n        labels: {app: a}\n      to:\n        labels: {app: b}\n"
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "networkPolicy spec is required"},
+		{"missing port", "    spec:\n" + peers + "      expect: allowed\n", "port must be between 1 and 65535"},
+		{"bad protocol", "    spec:\n" + peers + "      port: 80\n      protocol: tcp\n      expect: allowed\n", `unsupported protocol "tcp"`},
+		{"bad expect", "    spec:\n" + peers + "      port: 80\n      expect: blocked\n", `expect must be "allowed" or "denied"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: netpol\n    type: networkPolicy\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "netpol"`)
+		})
+	}
+}
+
+func TestParse_NodeValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: gpu-node
+    type: node
+    spec:
+      nodeSelector:
+        accelerator: gpu
+      taints:
+        - key: dedicated
+          effect: NoSchedule
+      minAllocatable:
+        memory: 4Gi
+      target:
+        kind: Deployment
+        name: trainer
+      scheduledOn:
+        accelerator: gpu
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	assert.Equal(t, NodeSpec{
+		NodeSelector:   map[string]string{"accelerator": "gpu"},
+		Taints:         []NodeTaint{{Key: "dedicated", Effect: "NoSchedule"}},
+		MinAllocatable: map[string]string{"memory": "4Gi"},
+		Target:         &Target{Kind: "Deployment", Name: "trainer"},
+		ScheduledOn:    map[string]string{"accelerator": "gpu"},
+	}, config.Validations[0].Spec)
+}
+
+func TestParse_NodeValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "node spec is required"},
+		{"no checks", "    spec:\n      nodeSelector: {zone: a}\n", "at least one of labels, taints, conditions, minAllocatable or target is required"},
+		{"scheduledOn without target", "    spec:\n      labels: {zone: a}\n      scheduledOn: {zone: a}\n", "scheduledOn requires a target"},
+		{"taint without key", "    spec:\n      taints:\n        - effect: NoSchedule\n", "taints[0]: key is required"},
+		{"bad effect", "    spec:\n      taints:\n        - key: gpu\n          effect: Never\n", `taints[0]: unsupported effect "Never"`},
+		{"bad condition status", "    spec:\n      conditions:\n        - type: Ready\n          status: yes\n", "conditions[0]: status must be True, False or Unknown"},
+		{"bad quantity", "    spec:\n      minAllocatable: {cpu: lots}\n", `minAllocatable.cpu: invalid quantity "lots"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: nodes\n    type: node\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "nodes"`)
+		})
+	}
+}
+
+func TestParse_PodDisruptionBudgetValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: survives-drain
+    type: podDisruptionBudget
+    spec:
+      target:
+        kind: Deployment
+        name: web
+      minAvailable: 2
+      disruptionsAllowed: 1
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	one := 1
+	assert.Equal(t, PodDisruptionBudgetSpec{
+		Target:             &Target{Kind: "Deployment", Name: "web"},
+		MinAvailable:       "2",
+		Operator:           "==",
+		DisruptionsAllowed: &one,
+	}, config.Validations[0].Spec)
+}
+
+func TestParse_PodDisruptionBudgetValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "podDisruptionBudget spec is required"},
+		{"no budget", "    spec:\n      minAvailable: 1\n", "either name or target is required"},
+		{"name and target", "    spec:\n      name: web\n      target:\n        name: web\n", "mutually exclusive"},
+		{"both budgets", "    spec:\n      name: web\n      minAvailable: 1\n      maxUnavailable: 1\n", "minAvailable and maxUnavailable are mutually exclusive"},
+		{"bad percentage", "    spec:\n      name: web\n      maxUnavailable: half\n", `maxUnavailable must be a number or a percentage, got "half"`},
+		{"operator alone", "    spec:\n      name: web\n      operator: \">=\"\n", "operator requires disruptionsAllowed"},
+		{"bad operator", "    spec:\n      name: web\n      operator: ~=\n      disruptionsAllowed: 1\n", `unsupported operator "~="`},
+		{"negative disruptions", "    spec:\n      name: web\n      disruptionsAllowed: -1\n", "disruptionsAllowed must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: pdb\n    type: podDisruptionBudget\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "pdb"`)
+		})
+	}
+}
+
+func TestParse_ProbesValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: health-checks
+    type: probes
+    spec:
+      target:
+        kind: Deployment
+        name: web
+      container: app
+      probes:
+        - type: readiness
+          path: /ready
+          port: 8080
+          periodSeconds: 5
+          exercise: true
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	period := int32(5)
+	assert.Equal(t, ProbesSpec{
+		Target:    Target{Kind: "Deployment", Name: "web"},
+		Container: "app",
+		Probes:    []ProbeCheck{{Type: ProbeReadiness, Path: "/ready", Port: "8080", PeriodSeconds: &period, Exercise: true}},
+	}, config.Validations[0].Spec)
+}
+
+func TestParse_ProbesValidationErrors(t *testing.T) {
+	target := "    spec:\n      target:\n        kind: Deployment\n        name: web\n"
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "probes spec is required"},
+		{"no probes", target, "at least one probe is required"},
+		{"bad type", target + "      probes:\n        - type: ready\n", `probes[0]: unsupported type "ready"`},
+		{"relative path", target + "      probes:\n        - type: liveness\n          path: healthz\n", "probes[0]: path must start with /"},
+		{"negative threshold", target + "      probes:\n        - type: liveness\n          failureThreshold: -1\n", "probes[0]: failureThreshold must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: probes\n    type: probes\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "probes"`)
+		})
+	}
+}
+
+func TestParse_ImagesValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: trusted-images
+    type: images
+    spec:
+      target:
+        kind: Deployment
+        name: web
+      forbidLatest: true
+      requireDigest: true
+      allowedRegistries:
+        - ghcr.io/kubeasy-dev
+      pullPolicy: IfNotPresent
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	assert.Equal(t, ImagesSpec{
+		Target:            Target{Kind: "Deployment", Name: "web"},
+		ForbidLatest:      true,
+		RequireDigest:     true,
+		AllowedRegistries: []string{"ghcr.io/kubeasy-dev"},
+		PullPolicy:        "IfNotPresent",
+	}, config.Validations[0].Spec)
+}
+
+func TestParse_ImagesValidationErrors(t *testing.T) {
+	target := "    spec:\n      target:\n        name: web\n"
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "images spec is required"},
+		{"no rules", target, "at least one of forbidLatest, requireDigest, allowedRegistries or pullPolicy is required"},
+		{"registry url", target + "      allowedRegistries: [https://ghcr.io]\n", `allowedRegistries[0]: "https://ghcr.io" must be a registry host or repository prefix`},
+		{"trailing slash", target + "      allowedRegistries: [ghcr.io/]\n", `allowedRegistries[0]: "ghcr.io/"`},
+		{"bad pull policy", target + "      pullPolicy: Sometimes\n", `unsupported pullPolicy "Sometimes"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: images\n    type: images\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "images"`)
+		})
+	}
+}
+
+func TestParse_ResourcesValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: right-sized
+    type: resources
+    spec:
+      target:
+        kind: Deployment
+        name: web
+      requests:
+        cpu:
+          min: 50m
+          max: "1"
+        memory: {}
+      limits:
+        memory:
+          max: 512Mi
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	assert.Equal(t, ResourcesSpec{
+		Target:   Target{Kind: "Deployment", Name: "web"},
+		Requests: map[string]ResourceBounds{"cpu": {Min: "50m", Max: "1"}, "memory": {}},
+		Limits:   map[string]ResourceBounds{"memory": {Max: "512Mi"}},
+	}, config.Validations[0].Spec)
+}
+
+func TestParse_ResourcesValidationErrors(t *testing.T) {
+	target := "    spec:\n      target:\n        name: web\n"
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "resources spec is required"},
+		{"no resources", target, "at least one of requests or limits is required"},
+		{"bad quantity", target + "      requests:\n        cpu: {min: lots}\n", `requests.cpu: invalid min quantity "lots"`},
+		{"min above max", target + "      limits:\n        memory: {min: 2Gi, max: 1Gi}\n", "limits.memory: min 2Gi is greater than max 1Gi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: resources\n    type: resources\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "resources"`)
+		})
+	}
+}
+
+func TestParse_MetadataValidation(t *testing.T) {
+	yaml := `
+objectives:
+  - key: scraped
+    type: metadata
+    spec:
+      target:
+        kind: Service
+        name: web
+      labels:
+        - key: tier
+          matches: frontend|backend
+        - key: debug
+          exists: false
+      annotations:
+        - key: prometheus.io/scrape
+          value: true
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+	absent := false
+	assert.Equal(t, MetadataSpec{
+		Target:      Target{Kind: "Service", Name: "web"},
+		Labels:      []MetadataCheck{{Key: "tier", Matches: "frontend|backend"}, {Key: "debug", Exists: &absent}},
+		Annotations: []MetadataCheck{{Key: "prometheus.io/scrape", Value: "true"}},
+	}, config.Validations[0].Spec)
+}
+
+func TestParse_MetadataValidationErrors(t *testing.T) {
+	target := "    spec:\n      target:\n        kind: Service\n        name: web\n"
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"missing spec", "", "metadata spec is required"},
+		{"missing kind", "    spec:\n      target:\n        name: web\n      labels:\n        - key: app\n", "target.kind is required"},
+		{"missing target", "    spec:\n      target:\n        kind: Service\n      labels:\n        - key: app\n", "target requires a name or a labelSelector"},
+		{"no checks", target, "at least one of labels or annotations is required"},
+		{"missing key", target + "      labels:\n        - value: web\n", "labels[0]: key is required"},
+		{"value and matches", target + "      annotations:\n        - key: a\n          value: b\n          matches: c\n", "annotations[0]: value and matches are mutually exclusive"},
+		{"absent with value", target + "      labels:\n        - key: a\n          exists: false\n          value: b\n", "labels[0]: exists: false cannot be combined with value or matches"},
+		{"bad pattern", target + "      labels:\n        - key: a\n          matches: \"(\"\n", "labels[0]: invalid matches pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: metadata\n    type: metadata\n" + tt.spec
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), `objectives[0] "metadata"`)
+		})
+	}
+}
+
+func TestParse_StatusLatestRevisionOnly(t *testing.T) {
+	yaml := `
+objectives:
+  - key: new-pods-ready
+    type: status
+    spec:
+      target:
+        kind: Deployment
+        name: web
+      latestRevisionOnly: true
+      checks:
+        - field: phase
+          operator: ==
+          value: Running
+  - key: after-rollout
+    type: triggered
+    spec:
+      trigger:
+        type: rollout
+        target:
+          kind: Deployment
+          name: web
+      then:
+        - key: old-pods-gone
+          type: status
+          spec:
+            target:
+              kind: StatefulSet
+              name: db
+            latestRevisionOnly: true
+            checks:
+              - field: phase
+                operator: ==
+                value: Running
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 2)
+	assert.True(t, config.Validations[0].Spec.(StatusSpec).LatestRevisionOnly)
+	nested := config.Validations[1].Spec.(TriggeredSpec).Then[0]
+	assert.True(t, nested.Spec.(StatusSpec).LatestRevisionOnly)
+
+	_, err = Parse([]byte("objectives:\n  - key: pods\n    type: status\n    spec:\n      target:\n        kind: Pod\n        name: web\n      latestRevisionOnly: true\n      checks:\n        - field: phase\n          operator: ==\n          value: Running\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `objectives[0] "pods": spec: latestRevisionOnly requires a Deployment or StatefulSet target`)
+}
+
+func TestParse_StatusContainers(t *testing.T) {
+	yaml := `
+objectives:
+  - key: containers-healthy
+    type: status
+    spec:
+      target:
+        kind: Deployment
+        name: web
+      containers:
+        - name: migrate
+          completed: true
+        - name: proxy
+          ready: true
+          maxRestarts: 0
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	spec := config.Validations[0].Spec.(StatusSpec)
+	require.Len(t, spec.Containers, 2)
+	assert.Equal(t, "migrate", spec.Containers[0].Name)
+	assert.True(t, *spec.Containers[0].Completed)
+	assert.True(t, *spec.Containers[1].Ready)
+	assert.Equal(t, int32(0), *spec.Containers[1].MaxRestarts)
+
+	tests := []struct {
+		name      string
+		container string
+		wantErr   string
+	}{
+		{"missing name", "        - ready: true\n", "containers[0]: name is required"},
+		{"no assertion", "        - name: app\n", `containers[0] "app": set at least one of ready, completed or maxRestarts`},
+		{"negative restarts", "        - name: app\n          maxRestarts: -1\n", "maxRestarts must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "objectives:\n  - key: c\n    type: status\n    spec:\n      target:\n        kind: Pod\n        name: web\n      containers:\n" + tt.container
+			_, err := Parse([]byte(yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParse_LogLimits(t *testing.T) {
+	base := "objectives:\n  - key: started\n    type: log\n    spec:\n      target:\n        name: web\n      expectedStrings:\n        - Started\n"
+
+	config, err := Parse([]byte(base))
+	require.NoError(t, err)
+	spec := config.Validations[0].Spec.(LogSpec)
+	assert.Equal(t, DefaultLogTailLines, spec.TailLines)
+	assert.Equal(t, DefaultLogMaxBytes, spec.MaxBytes)
+
+	config, err = Parse([]byte(base + "      tailLines: 50\n      maxBytes: 4096\n"))
+	require.NoError(t, err)
+	spec = config.Validations[0].Spec.(LogSpec)
+	assert.Equal(t, 50, spec.TailLines)
+	assert.Equal(t, 4096, spec.MaxBytes)
+
+	tests := []struct {
+		name    string
+		field   string
+		wantErr string
+	}{
+		{"zero tailLines", "      tailLines: 0\n", "tailLines must be between 1 and 10000"},
+		{"tailLines above cap", "      tailLines: 20000\n", "tailLines must be between 1 and 10000"},
+		{"negative maxBytes", "      maxBytes: -1\n", "maxBytes must be between 1 and 1048576"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(base + tt.field))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `objectives[0] "started": spec: `+tt.wantErr)
+		})
+	}
+}
+
+func TestMaskLocalObjectives_NoLocalTypes(t *testing.T) {
+	data := []byte("objectives:\n  - key: a\n    type: status\n")
+	masked, local, err := maskLocalObjectives(data)
+	require.NoError(t, err)
+	assert.Empty(t, local)
+	assert.Equal(t, data, masked, "files without local types are passed through untouched")
+}
@@ -0,0 +1,30 @@
+package validation
+
+// Phase is a named group of objectives, such as "Investigate", "Fix" or "Verify".
+type Phase struct {
+	Name string
+	// Indices are the positions of the phase's objectives in the validations, in
+	// file order.
+	Indices []int
+}
+
+// GroupByPhase groups validations by their Phase, in order of first appearance. It
+// returns nil when no validation sets a phase, in which case results are grouped by
+// type instead.
+func GroupByPhase(validations []Validation) []Phase {
+	var phases []Phase
+	index := map[string]int{}
+	for i, v := range validations {
+		if v.Phase == "" {
+			continue
+		}
+		j, ok := index[v.Phase]
+		if !ok {
+			j = len(phases)
+			index[v.Phase] = j
+			phases = append(phases, Phase{Name: v.Phase})
+		}
+		phases[j].Indices = append(phases[j].Indices, i)
+	}
+	return phases
+}
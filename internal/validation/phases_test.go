@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupByPhase(t *testing.T) {
+	assert.Nil(t, GroupByPhase([]Validation{{Key: "a"}, {Key: "b"}}))
+
+	phases := GroupByPhase([]Validation{
+		{Key: "find-cause", Phase: "Investigate"},
+		{Key: "fix-probe", Phase: "Fix"},
+		{Key: "read-logs", Phase: "Investigate"},
+		{Key: "stable", Phase: "Verify"},
+	})
+	assert.Equal(t, []Phase{
+		{Name: "Investigate", Indices: []int{0, 2}},
+		{Name: "Fix", Indices: []int{1}},
+		{Name: "Verify", Indices: []int{3}},
+	}, phases)
+}
+
+func TestParse_Phases(t *testing.T) {
+	yaml := `
+objectives:
+  - key: found-cause
+    phase: Investigate
+    type: metadata
+    spec:
+      target:
+        kind: Pod
+        name: web
+      labels:
+        - key: investigated
+  - key: pod-ready
+    phase: Fix
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        name: web
+      checks:
+        - type: Ready
+          status: "True"
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 2)
+	assert.Equal(t, "Investigate", config.Validations[0].Phase)
+	assert.Equal(t, "Fix", config.Validations[1].Phase)
+
+	spec, err := ParseChallengeYaml([]byte(yaml))
+	require.NoError(t, err)
+	assert.Equal(t, "Fix", spec.Objectives[1].Phase)
+}
+
+func TestParse_PhaseRequiredOnceUsed(t *testing.T) {
+	yaml := `
+objectives:
+  - key: pod-ready
+    phase: Fix
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        name: web
+      checks:
+        - type: Ready
+          status: "True"
+  - key: stable
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        name: web
+      checks:
+        - type: Ready
+          status: "True"
+`
+	_, err := Parse([]byte(yaml))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `objectives[1] "stable": phase is required when other objectives set one`)
+}
@@ -0,0 +1,222 @@
+package validation
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/kubeasy-dev/registry/pkg/challenges"
+)
+
+//go:generate go run ../../hack/generate-schema -o schema.json
+
+// embeddedSchema is the challenge.yaml JSON Schema generated from RegisteredTypes.
+// Regenerate with `go generate ./internal/validation` after changing a spec type.
+//
+//go:embed schema.json
+var embeddedSchema []byte
+
+// JSONSchemaID is the $id of the challenge.yaml JSON Schema.
+const JSONSchemaID = "https://kubeasy.dev/schemas/challenge.schema.json"
+
+// JSONSchema returns the JSON Schema for challenge.yaml embedded into the CLI.
+func JSONSchema() []byte {
+	return embeddedSchema
+}
+
+// schemaEnums lists the allowed values of named string types used in spec structs.
+var schemaEnums = map[reflect.Type][]string{
+	reflect.TypeOf(vtypes.MatchMode("")): {
+		string(vtypes.MatchModeAllOf),
+		string(vtypes.MatchModeAnyOf),
+	},
+	reflect.TypeOf(vtypes.TriggerType("")): {
+		string(vtypes.TriggerTypeLoad),
+		string(vtypes.TriggerTypeWait),
+		string(vtypes.TriggerTypeDelete),
+		string(vtypes.TriggerTypeRollout),
+		string(vtypes.TriggerTypeScale),
+	},
+}
+
+// schemaFieldEnums lists allowed values for plain string fields, keyed by "<Struct>.<yamlField>".
+var schemaFieldEnums = map[string][]string{
+	"ConnectivitySpec.mode": {vtypes.ConnectivityModeExternal, vtypes.ConnectivityModeInternal},
+}
+
+var validationType = reflect.TypeOf(vtypes.Validation{})
+
+// GenerateJSONSchema builds the JSON Schema (draft 2020-12) describing challenge.yaml
+// from the challenge metadata fields and every spec in RegisteredTypes.
+func GenerateJSONSchema() ([]byte, error) {
+	defs := map[string]interface{}{}
+
+	typeNames := make([]string, 0, len(RegisteredTypes))
+	allOf := make([]interface{}, 0, len(RegisteredTypes))
+	for _, reg := range RegisteredTypes {
+		typeNames = append(typeNames, string(reg.Type))
+		defs[reg.SpecName] = schemaForType(reflect.TypeOf(reg.Spec))
+		allOf = append(allOf, map[string]interface{}{
+			"if": map[string]interface{}{
+				"properties": map[string]interface{}{"type": map[string]interface{}{"const": string(reg.Type)}},
+				"required":   []string{"type"},
+			},
+			"then": map[string]interface{}{
+				"properties": map[string]interface{}{"spec": map[string]interface{}{"$ref": "#/$defs/" + reg.SpecName}},
+			},
+		})
+	}
+
+	defs["Objective"] = map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"key", "type"},
+		"properties": map[string]interface{}{
+			"key":         map[string]interface{}{"type": "string"},
+			"title":       map[string]interface{}{"type": "string"},
+			"description": map[string]interface{}{"type": "string"},
+			"order":       map[string]interface{}{"type": "integer"},
+			"phase":       map[string]interface{}{"type": "string"},
+			"type":        map[string]interface{}{"type": "string", "enum": typeNames},
+			"spec":        map[string]interface{}{"type": "object"},
+		},
+		"allOf": allOf,
+	}
+
+	defs["Variant"] = map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"name", "objectives"},
+		"properties": map[string]interface{}{
+			"name":   map[string]interface{}{"type": "string"},
+			"weight": map[string]interface{}{"type": "integer", "minimum": 1},
+			"vars":   map[string]interface{}{"type": "object"},
+			"objectives": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/$defs/Objective"},
+			},
+		},
+	}
+
+	forbiddenAction := schemaForType(reflect.TypeOf(vtypes.ForbiddenAction{}))
+	forbiddenAction["required"] = []string{"key", "target"}
+	forbiddenAction["properties"].(map[string]interface{})["verbs"] = map[string]interface{}{
+		"type":  "array",
+		"items": map[string]interface{}{"type": "string", "enum": forbiddenVerbs},
+	}
+	defs["ForbiddenAction"] = forbiddenAction
+
+	schema := map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"$id":                  JSONSchemaID,
+		"title":                "Kubeasy challenge.yaml",
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             challengeRequiredFields(),
+		"properties": map[string]interface{}{
+			"title":              map[string]interface{}{"type": "string"},
+			"description":        map[string]interface{}{"type": "string"},
+			"theme":              map[string]interface{}{"type": "string", "enum": challenges.ThemeValues},
+			"difficulty":         map[string]interface{}{"type": "string", "enum": ChallengeDifficultyValues},
+			"type":               map[string]interface{}{"type": "string", "enum": ChallengeTypeValues},
+			"estimatedTime":      map[string]interface{}{"type": "integer", "minimum": 1},
+			"initialSituation":   map[string]interface{}{"type": "string"},
+			"objective":          map[string]interface{}{"type": "string"},
+			"minRequiredVersion": map[string]interface{}{"type": "string"},
+			"vars":               map[string]interface{}{"type": "object"},
+			"objectives": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/$defs/Objective"},
+			},
+			"variants": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/$defs/Variant"},
+			},
+			"forbiddenActions": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/$defs/ForbiddenAction"},
+			},
+		},
+		"$defs": defs,
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// challengeRequiredFields returns the challenge.yaml fields without omitempty,
+// matching the lint convention for required metadata.
+func challengeRequiredFields() []string {
+	t := reflect.TypeOf(ChallengeYamlSpec{})
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("yaml")
+		if tag == "" || tag == "-" || strings.Contains(tag, "omitempty") || t.Field(i).Type.Kind() == reflect.Slice {
+			continue
+		}
+		required = append(required, strings.Split(tag, ",")[0])
+	}
+	return required
+}
+
+// schemaForType converts a Go type into a JSON Schema fragment using its yaml tags.
+func schemaForType(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == validationType {
+		return map[string]interface{}{"$ref": "#/$defs/Objective"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		s := map[string]interface{}{"type": "string"}
+		if values, ok := schemaEnums[t]; ok {
+			s["enum"] = values
+		}
+		return s
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaForType(t.Elem())}
+	case reflect.Struct:
+		props := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("yaml")
+			if tag == "-" || !f.IsExported() {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+			if name == "" {
+				name = f.Name
+			}
+			fs := schemaForType(f.Type)
+			if values, ok := schemaFieldEnums[t.Name()+"."+name]; ok {
+				fs["enum"] = values
+			}
+			props[name] = fs
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": false,
+			"properties":           props,
+		}
+	default:
+		// interface{} values (e.g. StatusCheck.Value) accept any JSON value.
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJSONSchema_EmbeddedUpToDate fails when schema.json drifts from the spec types.
+func TestJSONSchema_EmbeddedUpToDate(t *testing.T) {
+	generated, err := GenerateJSONSchema()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(generated), string(JSONSchema()),
+		"schema.json is out of date: run 'go generate ./internal/validation'")
+}
+
+func TestGenerateJSONSchema_CoversRegisteredTypes(t *testing.T) {
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(JSONSchema(), &schema))
+	assert.Equal(t, JSONSchemaID, schema["$id"])
+
+	defs, ok := schema["$defs"].(map[string]interface{})
+	require.True(t, ok)
+	for _, reg := range RegisteredTypes {
+		assert.Contains(t, defs, reg.SpecName)
+	}
+	assert.Contains(t, defs, "Objective")
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name: "valid document",
+			yaml: `
+title: Test
+difficulty: easy
+objectives:
+  - key: ready
+    type: status
+    spec:
+      target:
+        kind: Deployment
+        name: web
+      checks:
+        - field: readyReplicas
+          operator: ">="
+          value: 1
+`,
+		},
+		{
+			name:     "unknown top-level field",
+			yaml:     "title: Test\ntitel: oops\n",
+			wantPath: "titel",
+			wantMsg:  "unknown field",
+		},
+		{
+			name: "unknown spec field",
+			yaml: `
+objectives:
+  - key: logs
+    type: log
+    spec:
+      target:
+        kind: Pod
+        name: web
+      expectedString: ["ok"]
+`,
+			wantPath: "objectives[0].spec.expectedString",
+			wantMsg:  "unknown field",
+		},
+		{
+			name: "wrong value type",
+			yaml: `
+objectives:
+  - key: events
+    type: event
+    spec:
+      target:
+        kind: Pod
+        name: web
+      sinceSeconds: "five"
+`,
+			wantPath: "objectives[0].spec.sinceSeconds",
+			wantMsg:  "expected integer",
+		},
+		{
+			name: "invalid enum in nested triggered objective",
+			yaml: `
+objectives:
+  - key: trig
+    type: triggered
+    spec:
+      trigger:
+        type: explode
+      then:
+        - key: nested
+          type: status
+`,
+			wantPath: "objectives[0].spec.trigger.type",
+			wantMsg:  "invalid value",
+		},
+		{
+			name:     "missing objective key",
+			yaml:     "objectives:\n  - type: status\n",
+			wantPath: "objectives[0].key",
+			wantMsg:  "required field is missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			violations, err := ValidateAgainstSchema([]byte(tc.yaml))
+			require.NoError(t, err)
+
+			var relevant []SchemaViolation
+			for _, v := range violations {
+				// Top-level required metadata is not the focus of these cases.
+				if v.Message == "required field is missing" && v.Path != tc.wantPath {
+					continue
+				}
+				relevant = append(relevant, v)
+			}
+
+			if tc.wantPath == "" {
+				assert.Empty(t, relevant)
+				return
+			}
+			require.Len(t, relevant, 1, "violations: %v", relevant)
+			assert.Equal(t, tc.wantPath, relevant[0].Path)
+			assert.Contains(t, relevant[0].Message, tc.wantMsg)
+		})
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	valid := `
+title: Test
+type: fix
+theme: networking
+difficulty: easy
+estimatedTime: 10
+description: d
+initialSituation: s
+objectives:
+  - key: ready
+    type: condition
+    spec:
+      target:
+        kind: Pod
+        name: web
+      checks:
+        - type: Ready
+          status: "True"
+`
+	config, err := ParseStrict([]byte(valid))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 1)
+
+	_, err = ParseStrict([]byte(valid + "unexpected: true\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected: unknown field")
+}
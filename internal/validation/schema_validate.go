@@ -0,0 +1,286 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// SchemaViolation describes a single place where a challenge.yaml does not match the JSON Schema.
+type SchemaViolation struct {
+	Path    string
+	Message string
+}
+
+// String formats the violation as "<path>: <message>".
+func (v SchemaViolation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+var (
+	parsedSchemaOnce sync.Once
+	parsedSchema     map[string]interface{}
+	parsedSchemaErr  error
+)
+
+func loadParsedSchema() (map[string]interface{}, error) {
+	parsedSchemaOnce.Do(func() {
+		parsedSchemaErr = json.Unmarshal(embeddedSchema, &parsedSchema)
+	})
+	return parsedSchema, parsedSchemaErr
+}
+
+// ValidateAgainstSchema checks challenge.yaml bytes against the embedded JSON Schema,
+// after applying the first variant and expanding template variables. It supports the subset of JSON Schema emitted by GenerateJSONSchema
+// (type, enum, const, properties, required, additionalProperties, items, $ref, allOf/if/then).
+func ValidateAgainstSchema(data []byte) ([]SchemaViolation, error) {
+	schema, err := loadParsedSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded JSON schema: %w", err)
+	}
+
+	data, _, err = SelectVariant(data, "")
+	if err != nil {
+		return nil, err
+	}
+	data, err = ExpandVars(data)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	v := &schemaValidator{root: schema}
+	v.validate(schema, doc, "")
+	return v.violations, nil
+}
+
+// ParseStrict validates challenge.yaml against the JSON Schema before parsing it.
+// Unlike Parse, unknown fields and wrongly typed values are rejected, which is what
+// challenge authors want; Parse stays lenient so older CLIs tolerate newer files.
+func ParseStrict(data []byte) (*ValidationConfig, error) {
+	violations, err := ValidateAgainstSchema(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		msgs := make([]string, len(violations))
+		for i, v := range violations {
+			msgs[i] = v.String()
+		}
+		return nil, fmt.Errorf("challenge.yaml does not match schema: %s", strings.Join(msgs, "; "))
+	}
+	return Parse(data)
+}
+
+type schemaValidator struct {
+	root       map[string]interface{}
+	violations []SchemaViolation
+}
+
+func (v *schemaValidator) addf(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, SchemaViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) resolve(ref string) map[string]interface{} {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil
+	}
+	defs, _ := v.root["$defs"].(map[string]interface{})
+	def, _ := defs[name].(map[string]interface{})
+	return def
+}
+
+// matches reports whether value satisfies schema without recording violations.
+func (v *schemaValidator) matches(schema map[string]interface{}, value interface{}) bool {
+	probe := &schemaValidator{root: v.root}
+	probe.validate(schema, value, "")
+	return len(probe.violations) == 0
+}
+
+func (v *schemaValidator) validate(schema map[string]interface{}, value interface{}, path string) {
+	if schema == nil || value == nil {
+		// Empty YAML values (e.g. "description:") are treated as absent.
+		return
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		v.validate(v.resolve(ref), value, path)
+		return
+	}
+
+	if t, ok := schema["type"].(string); ok && !schemaTypeMatches(t, value) {
+		v.addf(path, "expected %s, got %s", t, describeYAMLValue(value))
+		return
+	}
+
+	if c, ok := schema["const"]; ok && fmt.Sprint(c) != fmt.Sprint(value) {
+		v.addf(path, "must be %v", c)
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		allowed := make([]string, len(enum))
+		for i, e := range enum {
+			allowed[i] = fmt.Sprint(e)
+			if allowed[i] == fmt.Sprint(value) {
+				found = true
+			}
+		}
+		if !found {
+			v.addf(path, "invalid value %q (allowed: %s)", fmt.Sprint(value), strings.Join(allowed, ", "))
+		}
+	}
+
+	if min, ok := schema["minimum"].(float64); ok {
+		if n, isNum := toFloat(value); isNum && n < min {
+			v.addf(path, "must be >= %v", min)
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(schema, val, path)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range val {
+				v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			subSchema, _ := sub.(map[string]interface{})
+			ifSchema, hasIf := subSchema["if"].(map[string]interface{})
+			if hasIf {
+				if !v.matches(ifSchema, value) {
+					continue
+				}
+				then, _ := subSchema["then"].(map[string]interface{})
+				v.validate(then, value, path)
+				continue
+			}
+			v.validate(subSchema, value, path)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(schema map[string]interface{}, obj map[string]interface{}, path string) {
+	props, _ := schema["properties"].(map[string]interface{})
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name := fmt.Sprint(r)
+			if _, present := obj[name]; !present {
+				v.addf(joinSchemaPath(path, name), "required field is missing")
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := joinSchemaPath(path, k)
+		if propSchema, ok := props[k].(map[string]interface{}); ok {
+			v.validate(propSchema, obj[k], childPath)
+			continue
+		}
+		switch ap := schema["additionalProperties"].(type) {
+		case bool:
+			if !ap && props != nil {
+				known := make([]string, 0, len(props))
+				for name := range props {
+					known = append(known, name)
+				}
+				slices.Sort(known)
+				v.addf(childPath, "unknown field (known fields: %s)", strings.Join(known, ", "))
+			}
+		case map[string]interface{}:
+			v.validate(ap, obj[k], childPath)
+		}
+	}
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func schemaTypeMatches(t string, value interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		switch n := value.(type) {
+		case int, int64, uint64:
+			return true
+		case float64:
+			return n == float64(int64(n))
+		}
+		return false
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	}
+	return true
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func describeYAMLValue(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,200 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/dynamic/dynamiclister"
+	"k8s.io/client-go/tools/cache"
+)
+
+// cacheSyncTimeout bounds how long an informer may take to list the namespace.
+// Resources that do not sync in time are read directly from the API server, and
+// their informer is stopped until cacheRetryDelay has passed. Variables so tests can
+// shorten them.
+var (
+	cacheSyncTimeout = 10 * time.Second
+	cacheRetryDelay  = 30 * time.Second
+)
+
+// ObjectCache serves Get and List lookups of namespaced objects from shared informers,
+// so validations that are re-run repeatedly (watch mode, retry polls) read from memory
+// instead of the API server. An informer is started lazily for each resource on first
+// use and kept up to date by a watch until Stop is called. Informers run on the
+// context of the cache, not on the one of the lookup that started them. A nil
+// *ObjectCache is valid and disables caching.
+type ObjectCache struct {
+	client    dynamic.Interface
+	namespace string
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	mu        sync.Mutex
+	informers map[schema.GroupVersionResource]*cachedResource
+	stopped   bool
+}
+
+// cachedResource is the informer of one resource. lister is nil until the informer
+// has synced; lookups meanwhile go to the API server.
+type cachedResource struct {
+	mu sync.Mutex
+	// synced is closed when the current sync attempt ends.
+	synced chan struct{}
+	// cancel stops the running informer; nil when none runs.
+	cancel   context.CancelFunc
+	lister   dynamiclister.NamespaceLister
+	failedAt time.Time
+}
+
+// NewObjectCache creates a cache of the objects in namespace.
+func NewObjectCache(client dynamic.Interface, namespace string) *ObjectCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ObjectCache{
+		client:    client,
+		namespace: namespace,
+		ctx:       ctx,
+		cancel:    cancel,
+		informers: map[schema.GroupVersionResource]*cachedResource{},
+	}
+}
+
+// Stop shuts down every informer. Later lookups go to the API server.
+func (c *ObjectCache) Stop() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	c.cancel()
+}
+
+// lister returns the synced lister for gvr, starting its informer on first use, or
+// nil when the resource cannot be served from the cache. A lookup whose ctx ends
+// before the informer synced reads from the API server; the informer keeps syncing
+// for later lookups.
+func (c *ObjectCache) lister(ctx context.Context, gvr schema.GroupVersionResource) dynamiclister.NamespaceLister {
+	// The metrics API cannot be watched, so it is always read from the API server
+	if c == nil || gvr.Group == MetricsGroup {
+		return nil
+	}
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return nil
+	}
+	r, ok := c.informers[gvr]
+	if !ok {
+		r = &cachedResource{}
+		c.informers[gvr] = r
+	}
+	c.mu.Unlock()
+
+	r.mu.Lock()
+	if r.lister != nil {
+		defer r.mu.Unlock()
+		return r.lister
+	}
+	if r.cancel == nil && (r.failedAt.IsZero() || time.Since(r.failedAt) >= cacheRetryDelay) {
+		c.startInformer(r, gvr)
+	}
+	synced := r.synced
+	r.mu.Unlock()
+
+	select {
+	case <-synced:
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.lister
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// startInformer starts the informer of gvr and waits for its sync in the background.
+// An informer that does not sync within cacheSyncTimeout is stopped. r.mu is held.
+func (c *ObjectCache) startInformer(r *cachedResource, gvr schema.GroupVersionResource) {
+	runCtx, cancel := context.WithCancel(c.ctx)
+	informer := dynamicinformer.NewFilteredDynamicInformer(c.client, gvr, c.namespace, 0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil)
+	go informer.Informer().Run(runCtx.Done())
+
+	synced := make(chan struct{})
+	r.synced, r.cancel = synced, cancel
+	go func() {
+		defer close(synced)
+		syncCtx, stopWaiting := context.WithTimeout(runCtx, cacheSyncTimeout)
+		defer stopWaiting()
+		ok := cache.WaitForCacheSync(syncCtx.Done(), informer.Informer().HasSynced)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if !ok {
+			logger.Debug("Object cache: %s did not sync, reading it from the API server", gvr.Resource)
+			cancel()
+			r.cancel, r.failedAt = nil, time.Now()
+			return
+		}
+		r.lister = dynamiclister.New(informer.Informer().GetIndexer(), gvr).Namespace(c.namespace)
+	}()
+}
+
+// GetObject returns the named object of gvr in the deps namespace, from deps.Cache when
+// it is enabled and from the API server otherwise. The returned object may be modified.
+func GetObject(ctx context.Context, deps Deps, gvr schema.GroupVersionResource, name string) (*unstructured.Unstructured, error) {
+	if gvr.Group == MetricsGroup {
+		if err := RequireMetricsAPI(ctx, deps); err != nil {
+			return nil, err
+		}
+	}
+	if lister := deps.Cache.lister(ctx, gvr); lister != nil {
+		obj, err := lister.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return obj.DeepCopy(), nil
+	}
+	return deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// ListObjects returns the objects of gvr in the deps namespace that match selector
+// (all objects when it is empty), sorted by name like the API server returns them.
+func ListObjects(ctx context.Context, deps Deps, gvr schema.GroupVersionResource, selector map[string]string) ([]unstructured.Unstructured, error) {
+	if gvr.Group == MetricsGroup {
+		if err := RequireMetricsAPI(ctx, deps); err != nil {
+			return nil, err
+		}
+	}
+	if lister := deps.Cache.lister(ctx, gvr); lister != nil {
+		cached, err := lister.List(labels.SelectorFromSet(selector))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s from cache: %w", gvr.Resource, err)
+		}
+		items := make([]unstructured.Unstructured, 0, len(cached))
+		for _, obj := range cached {
+			items = append(items, *obj.DeepCopy())
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+		return items, nil
+	}
+
+	opts := metav1.ListOptions{}
+	if len(selector) > 0 {
+		opts.LabelSelector = labels.SelectorFromSet(selector).String()
+	}
+	list, err := deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
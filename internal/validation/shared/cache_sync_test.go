@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var syncTestDeployments = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+func newSyncTestClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		syncTestDeployments: "DeploymentList",
+	})
+}
+
+func TestObjectCache_CancelledLookupKeepsCaching(t *testing.T) {
+	client := newSyncTestClient()
+	c := NewObjectCache(client, "test-ns")
+	defer c.Stop()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.lister(cancelled, syncTestDeployments)
+
+	// The informer started by the cancelled lookup keeps running for the next ones
+	assert.NotNil(t, c.lister(context.Background(), syncTestDeployments))
+}
+
+func TestObjectCache_FailedSyncStopsAndRetries(t *testing.T) {
+	savedTimeout, savedDelay := cacheSyncTimeout, cacheRetryDelay
+	t.Cleanup(func() { cacheSyncTimeout, cacheRetryDelay = savedTimeout, savedDelay })
+	cacheSyncTimeout, cacheRetryDelay = 500*time.Millisecond, 0
+
+	client := newSyncTestClient()
+	var failing atomic.Bool
+	failing.Store(true)
+	client.PrependReactor("list", "deployments", func(k8stesting.Action) (bool, k8sruntime.Object, error) {
+		if failing.Load() {
+			return true, nil, errors.New("list unavailable")
+		}
+		return false, nil, nil
+	})
+	c := NewObjectCache(client, "test-ns")
+	defer c.Stop()
+
+	assert.Nil(t, c.lister(context.Background(), syncTestDeployments), "an unsynced resource is read from the API server")
+	r := c.informers[syncTestDeployments]
+	r.mu.Lock()
+	assert.Nil(t, r.cancel, "the informer that did not sync is stopped")
+	r.mu.Unlock()
+
+	failing.Store(false)
+	require.NotNil(t, c.lister(context.Background(), syncTestDeployments), "a later lookup starts a new informer")
+}
+
+func TestObjectCache_RetryDelay(t *testing.T) {
+	savedTimeout, savedDelay := cacheSyncTimeout, cacheRetryDelay
+	t.Cleanup(func() { cacheSyncTimeout, cacheRetryDelay = savedTimeout, savedDelay })
+	cacheSyncTimeout, cacheRetryDelay = 500*time.Millisecond, time.Hour
+
+	client := newSyncTestClient()
+	var lists atomic.Int32
+	client.PrependReactor("list", "deployments", func(k8stesting.Action) (bool, k8sruntime.Object, error) {
+		lists.Add(1)
+		return true, nil, errors.New("list unavailable")
+	})
+	c := NewObjectCache(client, "test-ns")
+	defer c.Stop()
+
+	assert.Nil(t, c.lister(context.Background(), syncTestDeployments))
+	attempts := lists.Load()
+	start := time.Now()
+	assert.Nil(t, c.lister(context.Background(), syncTestDeployments))
+	assert.Less(t, time.Since(start), cacheSyncTimeout, "lookups do not wait on a resource that failed to sync")
+	assert.Equal(t, attempts, lists.Load(), "no informer runs until the retry delay has passed")
+}
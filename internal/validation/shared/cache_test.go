@@ -0,0 +1,143 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var (
+	cacheTestPods        = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	cacheTestDeployments = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+func cacheTestObject(apiVersion, kind, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "test-ns",
+			"labels":    labels,
+		},
+	}}
+}
+
+func newCacheTestClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		cacheTestPods:        "PodList",
+		cacheTestDeployments: "DeploymentList",
+	}, objects...)
+}
+
+func TestObjectCache_RepeatedLookupsHitMemory(t *testing.T) {
+	client := newCacheTestClient(
+		cacheTestObject("apps/v1", "Deployment", "web", map[string]interface{}{"app": "web"}),
+		cacheTestObject("apps/v1", "Deployment", "api", map[string]interface{}{"app": "api"}),
+	)
+	c := shared.NewObjectCache(client, "test-ns")
+	defer c.Stop()
+	deps := shared.Deps{DynamicClient: client, Namespace: "test-ns", Cache: c}
+
+	obj, err := shared.GetObject(context.Background(), deps, cacheTestDeployments, "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", obj.GetName())
+	warmActions := len(client.Actions())
+
+	for range 5 {
+		_, err := shared.GetObject(context.Background(), deps, cacheTestDeployments, "api")
+		require.NoError(t, err)
+		items, err := shared.ListObjects(context.Background(), deps, cacheTestDeployments, map[string]string{"app": "web"})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "web", items[0].GetName())
+	}
+	assert.Len(t, client.Actions(), warmActions, "cached lookups must not reach the API server")
+
+	_, err = shared.GetObject(context.Background(), deps, cacheTestDeployments, "missing")
+	assert.True(t, apierrors.IsNotFound(err), "missing objects report NotFound, got %v", err)
+}
+
+func TestObjectCache_SeesLaterChanges(t *testing.T) {
+	client := newCacheTestClient()
+	c := shared.NewObjectCache(client, "test-ns")
+	defer c.Stop()
+	deps := shared.Deps{DynamicClient: client, Namespace: "test-ns", Cache: c}
+
+	items, err := shared.ListObjects(context.Background(), deps, cacheTestDeployments, nil)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	_, err = client.Resource(cacheTestDeployments).Namespace("test-ns").Create(context.Background(),
+		cacheTestObject("apps/v1", "Deployment", "web", nil), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		obj, err := shared.GetObject(context.Background(), deps, cacheTestDeployments, "web")
+		return err == nil && obj.GetName() == "web"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestObjectCache_ReturnsCopies(t *testing.T) {
+	client := newCacheTestClient(cacheTestObject("apps/v1", "Deployment", "web", nil))
+	c := shared.NewObjectCache(client, "test-ns")
+	defer c.Stop()
+	deps := shared.Deps{DynamicClient: client, Namespace: "test-ns", Cache: c}
+
+	obj, err := shared.GetObject(context.Background(), deps, cacheTestDeployments, "web")
+	require.NoError(t, err)
+	obj.SetName("changed")
+
+	obj, err = shared.GetObject(context.Background(), deps, cacheTestDeployments, "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", obj.GetName())
+}
+
+func TestObjectCache_StoppedOrNilFallsBackToAPI(t *testing.T) {
+	client := newCacheTestClient(cacheTestObject("apps/v1", "Deployment", "web", nil))
+	c := shared.NewObjectCache(client, "test-ns")
+	c.Stop()
+
+	for _, cache := range []*shared.ObjectCache{nil, c} {
+		client.ClearActions()
+		deps := shared.Deps{DynamicClient: client, Namespace: "test-ns", Cache: cache}
+		obj, err := shared.GetObject(context.Background(), deps, cacheTestDeployments, "web")
+		require.NoError(t, err)
+		assert.Equal(t, "web", obj.GetName())
+		require.Len(t, client.Actions(), 1)
+		assert.Equal(t, "get", client.Actions()[0].GetVerb())
+	}
+}
+
+func TestGetTargetPods_FromCache(t *testing.T) {
+	client := newCacheTestClient(
+		cacheTestObject("v1", "Pod", "web-1", map[string]interface{}{"app": "web"}),
+		cacheTestObject("v1", "Pod", "web-0", map[string]interface{}{"app": "web"}),
+		cacheTestObject("v1", "Pod", "db-0", map[string]interface{}{"app": "db"}),
+	)
+	c := shared.NewObjectCache(client, "test-ns")
+	defer c.Stop()
+	deps := shared.Deps{DynamicClient: client, Namespace: "test-ns", Cache: c}
+
+	pods, err := shared.GetTargetPods(context.Background(), deps, vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}})
+	require.NoError(t, err)
+	require.Len(t, pods, 2)
+	assert.Equal(t, "web-0", pods[0].Name)
+	assert.Equal(t, "web-1", pods[1].Name)
+
+	pods, err = shared.GetTargetPods(context.Background(), deps, vtypes.Target{Name: "db-0"})
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "db", pods[0].Labels["app"])
+}
@@ -0,0 +1,150 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrCommandNotAllowed is returned by ExecInPod for a command that does not match the
+// vetted command templates.
+var ErrCommandNotAllowed = errors.New("command not allowed")
+
+// commandFlag describes a flag an allowed command may take; validate checks its
+// value, and is nil for flags without one.
+type commandFlag struct {
+	validate func(value string) error
+}
+
+// allowedCommands lists the commands validations may run in pods, with the flags each
+// accepts. Every other argument must be an http(s) URL. Commands are executed without
+// a shell, so this keeps challenge-provided values (URLs, headers) from turning into
+// extra options, such as curl's -o or --config, that would read or write files.
+var allowedCommands = map[string]map[string]commandFlag{
+	"curl": {
+		"-s":                {},
+		"-sS":               {},
+		"-k":                {},
+		"--fail":            {},
+		"-o":                {validate: equals("/dev/null")},
+		"-w":                {validate: equals("%{http_code}")},
+		"--connect-timeout": {validate: positiveInteger},
+		"--max-time":        {validate: positiveInteger},
+		"-H":                {validate: httpHeader},
+	},
+}
+
+// headerNamePattern matches the token allowed as an HTTP header name (RFC 9110).
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// CheckCommand returns an error wrapping ErrCommandNotAllowed unless command matches
+// the vetted command templates and every parameter is safe.
+func CheckCommand(command []string) error {
+	if len(command) == 0 {
+		return fmt.Errorf("%w: empty command", ErrCommandNotAllowed)
+	}
+	flags, ok := allowedCommands[command[0]]
+	if !ok {
+		return fmt.Errorf("%w: %q is not an allowed command", ErrCommandNotAllowed, command[0])
+	}
+	urls := 0
+	for i := 1; i < len(command); i++ {
+		arg := command[i]
+		if !strings.HasPrefix(arg, "-") {
+			if err := httpURL(arg); err != nil {
+				return fmt.Errorf("%w: %s argument %q: %v", ErrCommandNotAllowed, command[0], arg, err)
+			}
+			urls++
+			continue
+		}
+		flag, ok := flags[arg]
+		if !ok {
+			return fmt.Errorf("%w: %s flag %q is not allowed", ErrCommandNotAllowed, command[0], arg)
+		}
+		if flag.validate == nil {
+			continue
+		}
+		if i+1 == len(command) {
+			return fmt.Errorf("%w: %s flag %s requires a value", ErrCommandNotAllowed, command[0], arg)
+		}
+		i++
+		if err := flag.validate(command[i]); err != nil {
+			return fmt.Errorf("%w: %s %s %q: %v", ErrCommandNotAllowed, command[0], arg, command[i], err)
+		}
+	}
+	if urls != 1 {
+		return fmt.Errorf("%w: %s requires exactly one URL, got %d", ErrCommandNotAllowed, command[0], urls)
+	}
+	return nil
+}
+
+// FormatCommand renders command for logs, quoting the arguments a shell would split.
+func FormatCommand(command []string) string {
+	parts := make([]string, len(command))
+	for i, arg := range command {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`{}[]*?;&|<>()#") {
+			arg = strconv.Quote(arg)
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
+func equals(expected string) func(string) error {
+	return func(value string) error {
+		if value != expected {
+			return fmt.Errorf("expected %q", expected)
+		}
+		return nil
+	}
+}
+
+func positiveInteger(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 1 {
+		return fmt.Errorf("expected a positive integer")
+	}
+	return nil
+}
+
+// httpHeader accepts "Name: value" headers without control characters, so a value
+// cannot inject another header.
+func httpHeader(value string) error {
+	name, v, ok := strings.Cut(value, ":")
+	if !ok || !headerNamePattern.MatchString(name) {
+		return fmt.Errorf("expected a header as \"Name: value\"")
+	}
+	if hasControlCharacter(v) {
+		return fmt.Errorf("header value contains control characters")
+	}
+	return nil
+}
+
+// httpURL accepts absolute http and https URLs. Brackets and braces are only allowed
+// in the host (IPv6 literals): curl expands them as URL globs elsewhere, turning one
+// request into many.
+func httpURL(value string) error {
+	if hasControlCharacter(value) || strings.ContainsAny(value, " \t") {
+		return fmt.Errorf("URL contains whitespace or control characters")
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("only http and https URLs are allowed")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL has no host")
+	}
+	if rest := strings.Replace(value, u.Host, "", 1); strings.ContainsAny(rest, "[]{}") {
+		return fmt.Errorf("URL globs are not allowed")
+	}
+	return nil
+}
+
+func hasControlCharacter(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool { return r < 0x20 || r == 0x7f })
+}
@@ -0,0 +1,116 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command []string
+		wantErr string
+	}{
+		{
+			name:    "connectivity",
+			command: []string{"curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", "--connect-timeout", "5", "http://svc:80"},
+		},
+		{
+			name:    "probe with headers",
+			command: []string{"curl", "-sS", "-k", "-o", "/dev/null", "-w", "%{http_code}", "--max-time", "1", "-H", "X-Probe: kubelet", "https://[fd00::1]:8443/healthz"},
+		},
+		{
+			name:    "metrics scrape",
+			command: []string{"curl", "-sS", "--fail", "--max-time", "10", "http://10.0.0.1:9090/metrics?name=up"},
+		},
+		{
+			name:    "empty",
+			command: nil,
+			wantErr: "empty command",
+		},
+		{
+			name:    "other command",
+			command: []string{"sh", "-c", "id"},
+			wantErr: `"sh" is not an allowed command`,
+		},
+		{
+			name:    "unknown flag",
+			command: []string{"curl", "--config", "/etc/passwd", "http://svc"},
+			wantErr: `curl flag "--config" is not allowed`,
+		},
+		{
+			name:    "option injected as URL",
+			command: []string{"curl", "-s", "-o/tmp/out"},
+			wantErr: `curl flag "-o/tmp/out" is not allowed`,
+		},
+		{
+			name:    "output file",
+			command: []string{"curl", "-o", "/tmp/out", "http://svc"},
+			wantErr: `curl -o "/tmp/out": expected "/dev/null"`,
+		},
+		{
+			name:    "missing value",
+			command: []string{"curl", "http://svc", "--max-time"},
+			wantErr: "curl flag --max-time requires a value",
+		},
+		{
+			name:    "invalid timeout",
+			command: []string{"curl", "--max-time", "0", "http://svc"},
+			wantErr: "expected a positive integer",
+		},
+		{
+			name:    "file URL",
+			command: []string{"curl", "file:///etc/passwd"},
+			wantErr: "only http and https URLs are allowed",
+		},
+		{
+			name:    "relative URL",
+			command: []string{"curl", "svc:80"},
+			wantErr: "only http and https URLs are allowed",
+		},
+		{
+			name:    "URL glob",
+			command: []string{"curl", "http://svc/[1-1000]"},
+			wantErr: "URL globs are not allowed",
+		},
+		{
+			name:    "URL with whitespace",
+			command: []string{"curl", "http://svc/a b"},
+			wantErr: "whitespace or control characters",
+		},
+		{
+			name:    "header injection",
+			command: []string{"curl", "-H", "X-A: a\r\nX-B: b", "http://svc"},
+			wantErr: "header value contains control characters",
+		},
+		{
+			name:    "invalid header name",
+			command: []string{"curl", "-H", "@/etc/passwd", "http://svc"},
+			wantErr: `expected a header as "Name: value"`,
+		},
+		{
+			name:    "two URLs",
+			command: []string{"curl", "http://a", "http://b"},
+			wantErr: "exactly one URL, got 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckCommand(tt.command)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrCommandNotAllowed)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFormatCommand(t *testing.T) {
+	assert.Equal(t,
+		`curl -w "%{http_code}" -H "X-Probe: a" http://svc:80/`,
+		FormatCommand([]string{"curl", "-w", "%{http_code}", "-H", "X-Probe: a", "http://svc:80/"}))
+}
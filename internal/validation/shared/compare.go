@@ -4,10 +4,33 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// CompareError is returned by an executor whose validation failed on comparisons. The
+// engine turns it into a failed result carrying Message and the structured
+// Comparisons, so callers can show observed and expected values side by side.
+type CompareError struct {
+	Message     string
+	Comparisons []vtypes.Comparison
+}
+
+func (e *CompareError) Error() string {
+	return e.Message
+}
+
+// CompareFailure returns msg as a failure: a *CompareError when comparisons is not
+// empty, else a plain failed outcome.
+func CompareFailure(msg string, comparisons []vtypes.Comparison) (bool, string, error) {
+	if len(comparisons) == 0 {
+		return false, msg, nil
+	}
+	return false, "", &CompareError{Message: msg, Comparisons: comparisons}
+}
+
 // GetNestedInt64 extracts an int64 value from a nested map.
 func GetNestedInt64(obj map[string]interface{}, fields ...string) (int64, bool, error) {
 	val, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ContainerCheck returns the problems of one container, e.g. "no cpu request".
+type ContainerCheck func(c *corev1.Container) []string
+
+// CheckContainers runs check on the containers of pods named container, or on all of
+// them when container is empty; init containers are checked too when withInit is set.
+// It returns one "container <name>: <problems>" message per failing container and
+// whether any container matched.
+//
+// Pods of a workload share their containers, so a failure found in several pods is
+// reported once.
+func CheckContainers(pods []corev1.Pod, container string, withInit bool, check ContainerCheck) (failures []string, found bool) {
+	seen := map[string]bool{}
+	visit := func(kind string, c *corev1.Container) {
+		if container != "" && c.Name != container {
+			return
+		}
+		found = true
+		problems := check(c)
+		if len(problems) == 0 {
+			return
+		}
+		if msg := fmt.Sprintf("%s %s: %s", kind, c.Name, strings.Join(problems, ", ")); !seen[msg] {
+			seen[msg] = true
+			failures = append(failures, msg)
+		}
+	}
+	for i := range pods {
+		if withInit {
+			for j := range pods[i].Spec.InitContainers {
+				visit("init container", &pods[i].Spec.InitContainers[j])
+			}
+		}
+		for j := range pods[i].Spec.Containers {
+			visit("container", &pods[i].Spec.Containers[j])
+		}
+	}
+	return failures, found
+}
@@ -0,0 +1,36 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCheckContainers(t *testing.T) {
+	pod := corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate", Image: "busybox"}},
+		Containers:     []corev1.Container{{Name: "app", Image: "nginx"}, {Name: "proxy", Image: "envoy"}},
+	}}
+	pods := []corev1.Pod{pod, pod}
+	untagged := func(c *corev1.Container) []string {
+		return []string{"image " + c.Image + " has no tag"}
+	}
+
+	failures, found := shared.CheckContainers(pods, "", false, untagged)
+	assert.True(t, found)
+	assert.Equal(t, []string{"container app: image nginx has no tag", "container proxy: image envoy has no tag"}, failures, "failures shared by the pods are reported once")
+
+	failures, found = shared.CheckContainers(pods, "migrate", true, untagged)
+	assert.True(t, found)
+	assert.Equal(t, []string{"init container migrate: image busybox has no tag"}, failures)
+
+	failures, found = shared.CheckContainers(pods, "migrate", false, untagged)
+	assert.False(t, found)
+	assert.Empty(t, failures)
+
+	failures, found = shared.CheckContainers(pods, "app", false, func(*corev1.Container) []string { return nil })
+	assert.True(t, found)
+	assert.Empty(t, failures)
+}
@@ -4,6 +4,7 @@ package shared
 import (
 	"sync"
 
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -15,5 +16,26 @@ type Deps struct {
 	DynamicClient dynamic.Interface
 	RestConfig    *rest.Config
 	Namespace     string
-	ProbeMu       *sync.Mutex // serializes probe-mode connectivity checks
+	ProbeMu       *sync.Mutex             // serializes probe-mode connectivity checks
+	Cache         *ObjectCache            // optional; nil reads every object from the API server
+	ExecSem       chan struct{}           // bounds concurrent ExecInPod sessions (see NewExecLimiter); nil is unbounded
+	Server        kube.ServerCapabilities // gates features on the server version (see RequireFeature); zero assumes all
+	Sandbox       *Sandbox                // optional least-privilege clients for pod exec and logs; nil uses the clients above
+}
+
+// Sandbox holds the clients authenticated as the validator ServiceAccount of the
+// challenge namespace (see kube.EnsureValidatorSandbox).
+type Sandbox struct {
+	Clientset  kubernetes.Interface
+	RestConfig *rest.Config
+}
+
+// PodClients returns the clients used to read the logs of, or exec into, pods of
+// namespace: the sandbox clients for the challenge namespace when set, the admin
+// clients otherwise, since the sandbox has no rights outside its namespace.
+func (d Deps) PodClients(namespace string) (kubernetes.Interface, *rest.Config) {
+	if d.Sandbox != nil && namespace == d.Namespace {
+		return d.Sandbox.Clientset, d.Sandbox.RestConfig
+	}
+	return d.Clientset, d.RestConfig
 }
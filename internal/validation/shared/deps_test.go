@@ -0,0 +1,33 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func TestDeps_PodClients(t *testing.T) {
+	admin := fake.NewClientset()
+	adminConfig := &rest.Config{Host: "https://admin"}
+	deps := shared.Deps{Clientset: admin, RestConfig: adminConfig, Namespace: "pod-evicted"}
+
+	clientset, restConfig := deps.PodClients("pod-evicted")
+	assert.Same(t, admin, clientset, "no sandbox uses the admin clients")
+	assert.Same(t, adminConfig, restConfig)
+
+	sandbox := fake.NewClientset()
+	sandboxConfig := &rest.Config{Host: "https://admin", BearerToken: "validator-token"}
+	deps.Sandbox = &shared.Sandbox{Clientset: sandbox, RestConfig: sandboxConfig}
+
+	clientset, restConfig = deps.PodClients("pod-evicted")
+	assert.Same(t, sandbox, clientset)
+	assert.Same(t, sandboxConfig, restConfig)
+
+	// The sandbox has no rights outside the challenge namespace.
+	clientset, restConfig = deps.PodClients("kubeasy-system")
+	assert.Same(t, admin, clientset)
+	assert.Same(t, adminConfig, restConfig)
+}
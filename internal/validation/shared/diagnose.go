@@ -0,0 +1,204 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// maxDiagnosisFindings limits how many findings are appended to a failure message.
+	maxDiagnosisFindings = 3
+	// maxDiagnosisMessageLength truncates Kubernetes messages quoted in findings.
+	maxDiagnosisMessageLength = 120
+	// diagnosisEventWindow is how far back warning events are considered.
+	diagnosisEventWindow = 15 * time.Minute
+)
+
+// WithDiagnosis wraps a typed executor so that failed results get a short diagnosis of
+// the target's pods appended to their message (see Diagnose).
+func WithDiagnosis[S any](fn func(ctx context.Context, spec S, deps Deps) (bool, string, error), target func(S) vtypes.Target) func(ctx context.Context, spec S, deps Deps) (bool, string, error) {
+	return func(ctx context.Context, spec S, deps Deps) (bool, string, error) {
+		passed, msg, err := fn(ctx, spec, deps)
+		var compare *CompareError
+		isCompare := errors.As(err, &compare)
+		if passed || (err != nil && !isCompare) {
+			return passed, msg, err
+		}
+		if diagnosis := Diagnose(ctx, deps, target(spec)); diagnosis != "" {
+			if isCompare {
+				compare.Message = fmt.Sprintf("%s (diagnosis: %s)", compare.Message, diagnosis)
+			} else {
+				msg = fmt.Sprintf("%s (diagnosis: %s)", msg, diagnosis)
+			}
+		}
+		return passed, msg, err
+	}
+}
+
+// Diagnose inspects the pods behind target and their recent warning events, and returns
+// a short explanation of what is going wrong (e.g. "image pull failing: ErrImagePull for
+// nginx:1.99"), or "" when nothing stands out. It is best effort: lookup errors are only logged.
+func Diagnose(ctx context.Context, deps Deps, target vtypes.Target) string {
+	if deps.Clientset == nil || (target.Kind != "" && target.Kind != "Pod" && deps.DynamicClient == nil) {
+		return ""
+	}
+
+	pods, err := GetTargetPods(ctx, deps, target)
+	if err != nil {
+		logger.Debug("Diagnosis: failed to get target pods: %v", err)
+		return ""
+	}
+
+	var findings []string
+	for i := range pods {
+		findings = append(findings, podFindings(&pods[i])...)
+	}
+
+	involved := map[string]bool{}
+	if target.Name != "" {
+		involved[target.Name] = true
+	}
+	for _, pod := range pods {
+		involved[pod.Name] = true
+	}
+	findings = append(findings, eventFindings(ctx, deps, involved)...)
+
+	return strings.Join(uniqueFirst(findings, maxDiagnosisFindings), "; ")
+}
+
+// podFindings describes scheduling and container problems of a pod.
+func podFindings(pod *corev1.Pod) []string {
+	var findings []string
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse {
+			findings = append(findings, fmt.Sprintf("pod %s not scheduled: %s", pod.Name, truncate(cond.Message)))
+		}
+	}
+
+	images := map[string]string{}
+	for _, c := range pod.Spec.InitContainers {
+		images[c.Name] = c.Image
+	}
+	for _, c := range pod.Spec.Containers {
+		images[c.Name] = c.Image
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		image := cs.Image
+		if image == "" {
+			image = images[cs.Name]
+		}
+
+		if waiting := cs.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case "ContainerCreating", "PodInitializing", "":
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull":
+				findings = append(findings, fmt.Sprintf("image pull failing: %s for %s", waiting.Reason, image))
+			case "CrashLoopBackOff":
+				findings = append(findings, fmt.Sprintf("container %s crash-looping%s", cs.Name, lastTermination(cs)))
+			case "CreateContainerConfigError", "CreateContainerError":
+				findings = append(findings, fmt.Sprintf("container %s cannot be created: %s", cs.Name, truncate(waiting.Message)))
+			default:
+				findings = append(findings, fmt.Sprintf("container %s waiting: %s", cs.Name, waiting.Reason))
+			}
+		}
+
+		if term := cs.LastTerminationState.Terminated; term != nil && term.Reason == "OOMKilled" && cs.State.Waiting == nil {
+			findings = append(findings, fmt.Sprintf("container %s was OOMKilled", cs.Name))
+		}
+	}
+	return findings
+}
+
+// lastTermination describes why a crash-looping container last exited.
+func lastTermination(cs corev1.ContainerStatus) string {
+	term := cs.LastTerminationState.Terminated
+	if term == nil {
+		return ""
+	}
+	if term.Reason != "" && term.Reason != "Error" {
+		return fmt.Sprintf(" (last exit: %s)", term.Reason)
+	}
+	return fmt.Sprintf(" (last exit code %d)", term.ExitCode)
+}
+
+// eventFindings describes recent warning events of the involved objects, newest first.
+func eventFindings(ctx context.Context, deps Deps, involved map[string]bool) []string {
+	events, err := ListEvents(ctx, deps.Clientset, deps.Namespace)
+	if err != nil {
+		logger.Debug("Diagnosis: failed to list events: %v", err)
+		return nil
+	}
+
+	cutoff := time.Now().Add(-diagnosisEventWindow)
+	var recent []corev1.Event
+	for _, ev := range events {
+		if ev.Type == corev1.EventTypeWarning && involved[ev.InvolvedObject.Name] && eventTime(ev).After(cutoff) {
+			recent = append(recent, ev)
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool {
+		return eventTime(recent[i]).After(eventTime(recent[j]))
+	})
+
+	findings := make([]string, 0, len(recent))
+	for _, ev := range recent {
+		switch ev.Reason {
+		case "Unhealthy":
+			findings = append(findings, fmt.Sprintf("probe failing: %s", truncate(ev.Message)))
+		case "FailedMount", "FailedAttachVolume":
+			findings = append(findings, fmt.Sprintf("volume mount failing: %s", truncate(ev.Message)))
+		case "FailedScheduling", "Failed", "BackOff":
+			// Already covered by the pod's conditions and container states.
+		default:
+			findings = append(findings, fmt.Sprintf("%s: %s", ev.Reason, truncate(ev.Message)))
+		}
+	}
+	return findings
+}
+
+// eventTime returns the most recent timestamp of an event.
+func eventTime(ev corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	default:
+		return ev.CreationTimestamp.Time
+	}
+}
+
+// uniqueFirst returns the first n distinct values.
+func uniqueFirst(values []string, n int) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, v := range values {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+		if len(result) == n {
+			break
+		}
+	}
+	return result
+}
+
+func truncate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= maxDiagnosisMessageLength {
+		return s
+	}
+	return s[:maxDiagnosisMessageLength] + "..."
+}
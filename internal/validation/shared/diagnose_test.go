@@ -0,0 +1,171 @@
+package shared_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func diagnosisPod(name string, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1.99"}}},
+		Status:     corev1.PodStatus{ContainerStatuses: statuses},
+	}
+}
+
+func warningEvent(name, involved, reason, message string, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: involved},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+		LastTimestamp:  metav1.NewTime(at),
+	}
+}
+
+func diagnose(objects ...runtime.Object) string {
+	deps := shared.Deps{Clientset: fake.NewClientset(objects...), Namespace: "test-ns"}
+	return shared.Diagnose(context.Background(), deps, vtypes.Target{Kind: "Pod", LabelSelector: map[string]string{"app": "web"}})
+}
+
+func TestDiagnose_ContainerStates(t *testing.T) {
+	tests := []struct {
+		name   string
+		status corev1.ContainerStatus
+		want   string
+	}{
+		{
+			name:   "image pull",
+			status: corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+			want:   "image pull failing: ErrImagePull for nginx:1.99",
+		},
+		{
+			name: "crash loop with OOM",
+			status: corev1.ContainerStatus{
+				Name:                 "app",
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			},
+			want: "container app crash-looping (last exit: OOMKilled)",
+		},
+		{
+			name: "crash loop with exit code",
+			status: corev1.ContainerStatus{
+				Name:                 "app",
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			},
+			want: "container app crash-looping (last exit code 1)",
+		},
+		{
+			name:   "config error",
+			status: corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CreateContainerConfigError", Message: `secret "db" not found`}}},
+			want:   `container app cannot be created: secret "db" not found`,
+		},
+		{
+			name: "restarted after OOM",
+			status: corev1.ContainerStatus{
+				Name:                 "app",
+				State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}},
+			},
+			want: "container app was OOMKilled",
+		},
+		{
+			name:   "still creating",
+			status: corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diagnose(diagnosisPod("web-1", tt.status)))
+		})
+	}
+}
+
+func TestDiagnose_Unschedulable(t *testing.T) {
+	pod := diagnosisPod("web-1")
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Message: "0/1 nodes are available: 1 Insufficient memory.",
+	}}
+	assert.Equal(t, "pod web-1 not scheduled: 0/1 nodes are available: 1 Insufficient memory.", diagnose(pod))
+}
+
+func TestDiagnose_Events(t *testing.T) {
+	now := time.Now()
+	pod := diagnosisPod("web-1")
+	objects := []runtime.Object{
+		pod,
+		warningEvent("old", "web-1", "Unhealthy", "Liveness probe failed: stale", now.Add(-time.Hour)),
+		warningEvent("probe", "web-1", "Unhealthy", "Readiness probe failed: HTTP probe failed with statuscode: 503", now.Add(-time.Minute)),
+		warningEvent("mount", "web-1", "FailedMount", `configmap "cfg" not found`, now.Add(-2*time.Minute)),
+		warningEvent("other-pod", "db-0", "Unhealthy", "Readiness probe failed", now),
+		warningEvent("sched", "web-1", "FailedScheduling", "ignored", now),
+	}
+
+	assert.Equal(t,
+		`probe failing: Readiness probe failed: HTTP probe failed with statuscode: 503; volume mount failing: configmap "cfg" not found`,
+		diagnose(objects...))
+}
+
+func TestDiagnose_LimitsAndTruncates(t *testing.T) {
+	now := time.Now()
+	long := strings.Repeat("x", 200)
+	objects := []runtime.Object{diagnosisPod("web-1")}
+	for i, reason := range []string{"A", "B", "C", "D"} {
+		objects = append(objects, warningEvent(reason, "web-1", reason, long, now.Add(-time.Duration(i)*time.Second)))
+	}
+
+	findings := strings.Split(diagnose(objects...), "; ")
+	assert.Len(t, findings, 3)
+	assert.Equal(t, "A: "+strings.Repeat("x", 120)+"...", findings[0])
+}
+
+func TestDiagnose_NoClientset(t *testing.T) {
+	assert.Empty(t, shared.Diagnose(context.Background(), shared.Deps{}, vtypes.Target{Kind: "Pod", Name: "web-1"}))
+}
+
+func TestWithDiagnosis(t *testing.T) {
+	pod := diagnosisPod("web-1", corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}})
+	deps := shared.Deps{Clientset: fake.NewClientset(pod), Namespace: "test-ns"}
+	target := func(s vtypes.Target) vtypes.Target { return s }
+
+	failing := shared.WithDiagnosis(func(ctx context.Context, s vtypes.Target, d shared.Deps) (bool, string, error) {
+		return false, "Ready: got False", nil
+	}, target)
+	passed, msg, err := failing(context.Background(), vtypes.Target{Kind: "Pod", Name: "web-1"}, deps)
+	assert.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "Ready: got False (diagnosis: image pull failing: ImagePullBackOff for nginx:1.99)", msg)
+
+	passing := shared.WithDiagnosis(func(ctx context.Context, s vtypes.Target, d shared.Deps) (bool, string, error) {
+		return true, "All checks passed", nil
+	}, target)
+	_, msg, _ = passing(context.Background(), vtypes.Target{Kind: "Pod", Name: "web-1"}, deps)
+	assert.Equal(t, "All checks passed", msg, "passing results are left untouched")
+
+	compared := shared.WithDiagnosis(func(ctx context.Context, s vtypes.Target, d shared.Deps) (bool, string, error) {
+		return shared.CompareFailure("Ready: got False", []vtypes.Comparison{{Field: "Ready", Operator: "==", Expected: "True", Observed: "False"}})
+	}, target)
+	_, _, err = compared(context.Background(), vtypes.Target{Kind: "Pod", Name: "web-1"}, deps)
+	var compare *shared.CompareError
+	require.ErrorAs(t, err, &compare)
+	assert.Equal(t, "Ready: got False (diagnosis: image pull failing: ImagePullBackOff for nginx:1.99)", compare.Message)
+	assert.Len(t, compare.Comparisons, 1)
+}
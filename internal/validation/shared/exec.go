@@ -0,0 +1,83 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ErrExecUnavailable is returned by ExecInPod when deps has no usable REST config,
+// which is the case with the fake clients used in tests.
+var ErrExecUnavailable = errors.New("exec not available in test environment")
+
+// ExecInPod runs command in the first container of pod and returns its stdout and
+// stderr. A non-zero exit status is returned as an error along with the output.
+// Only commands passing CheckCommand are run, and each one is logged. At most
+// MaxConcurrentExecs sessions sharing deps.ExecSem run at once.
+func ExecInPod(ctx context.Context, deps Deps, pod *corev1.Pod, command []string) (string, string, error) {
+	if err := CheckCommand(command); err != nil {
+		logger.Warning("Refused to exec in pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return "", "", err
+	}
+	// Fake clientsets have a non-nil RESTClient but an internally nil client
+	if deps.RestConfig == nil || deps.RestConfig.Host == "" {
+		return "", "", ErrExecUnavailable
+	}
+	release, err := acquireExec(ctx, deps)
+	if err != nil {
+		return "", "", err
+	}
+	defer release()
+	logger.Info("Exec in pod %s/%s: %s", pod.Namespace, pod.Name, FormatCommand(command))
+
+	clientset, restConfig := deps.PodClients(pod.Namespace)
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Command: command,
+			Stdout:  true,
+			Stderr:  true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	return stdout.String(), stderr.String(), err
+}
+
+// WithProbePod deploys the CLI-managed kubeasy-probe pod in namespace, runs fn with it
+// and deletes it afterwards. Probe pods have a fixed name, so calls are serialized
+// with deps.ProbeMu.
+func WithProbePod(ctx context.Context, deps Deps, namespace string, fn func(pod *corev1.Pod) error) error {
+	deps.ProbeMu.Lock()
+	defer deps.ProbeMu.Unlock()
+
+	pod, err := deployer.CreateProbePod(ctx, deps.Clientset, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to create probe pod: %w", err)
+	}
+	defer func() {
+		_ = deployer.DeleteProbePod(ctx, deps.Clientset, namespace)
+	}()
+	if err := deployer.WaitForProbePodReady(ctx, deps.Clientset, namespace); err != nil {
+		return fmt.Errorf("probe pod failed to become ready: %w", err)
+	}
+	return fn(pod)
+}
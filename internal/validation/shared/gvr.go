@@ -82,6 +82,9 @@ func GetGVRForKind(kind string) (schema.GroupVersionResource, error) {
 	// scheduling.k8s.io/v1
 	case "priorityclass":
 		return schema.GroupVersionResource{Group: "scheduling.k8s.io", Version: "v1", Resource: "priorityclasses"}, nil
+	// metrics.k8s.io/v1beta1 (served by metrics-server)
+	case "podmetrics":
+		return schema.GroupVersionResource{Group: MetricsGroup, Version: "v1beta1", Resource: "pods"}, nil
 	// cert-manager.io/v1
 	case "certificate":
 		return schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}, nil
@@ -174,6 +174,11 @@ func TestGetGVRForKind(t *testing.T) {
 			expected: schema.GroupVersionResource{Group: "scheduling.k8s.io", Version: "v1", Resource: "priorityclasses"},
 		},
 		// cert-manager.io/v1
+		{
+			name:     "PodMetrics",
+			kind:     "PodMetrics",
+			expected: schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"},
+		},
 		{
 			name:     "Certificate",
 			kind:     "Certificate",
@@ -0,0 +1,82 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Guardrails bounding the load a challenge spec can put on the API server and the
+// memory of the CLI, whatever the number of objectives and pods it targets.
+const (
+	// MaxConcurrentExecs caps the exec sessions open at once across an Executor.
+	MaxConcurrentExecs = 4
+	// MaxLogBytes caps the log bytes fetched by one validation, across all its pods.
+	MaxLogBytes int64 = 1 << 20
+	// MaxLogTailLines caps the lines fetched from each container log.
+	MaxLogTailLines int64 = 10000
+	// EventPageSize is the page size used when listing Events.
+	EventPageSize int64 = 500
+	// MaxEvents caps the Events read by one listing.
+	MaxEvents = 5000
+)
+
+// NewExecLimiter returns the semaphore stored in Deps.ExecSem.
+func NewExecLimiter() chan struct{} {
+	return make(chan struct{}, MaxConcurrentExecs)
+}
+
+// acquireExec waits for an exec slot of deps.ExecSem and returns the function
+// releasing it. Without a semaphore, execs are not bounded.
+func acquireExec(ctx context.Context, deps Deps) (func(), error) {
+	if deps.ExecSem == nil {
+		return func() {}, nil
+	}
+	select {
+	case deps.ExecSem <- struct{}{}:
+		return func() { <-deps.ExecSem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ListEvents lists the Events of namespace page by page, stopping after MaxEvents.
+func ListEvents(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]corev1.Event, error) {
+	var events []corev1.Event
+	opts := metav1.ListOptions{Limit: EventPageSize}
+	for {
+		list, err := clientset.CoreV1().Events(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, list.Items...)
+		if len(events) >= MaxEvents {
+			logger.Warning("More than %d events in namespace %s, ignoring the rest", MaxEvents, namespace)
+			return events[:MaxEvents], nil
+		}
+		if list.Continue == "" {
+			return events, nil
+		}
+		opts.Continue = list.Continue
+	}
+}
+
+// ReadLimited reads at most limit bytes of r, reporting whether more were available.
+func ReadLimited(r io.Reader, limit int64) ([]byte, bool, error) {
+	if limit < 0 {
+		limit = 0
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return data[:limit], true, nil
+	}
+	return data, false, nil
+}
@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAcquireExec_BoundsConcurrentSessions(t *testing.T) {
+	deps := Deps{ExecSem: NewExecLimiter()}
+	var releases []func()
+	for range MaxConcurrentExecs {
+		release, err := acquireExec(context.Background(), deps)
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := acquireExec(ctx, deps)
+	assert.ErrorIs(t, err, context.Canceled, "a full limiter waits until the context ends")
+
+	releases[0]()
+	release, err := acquireExec(context.Background(), deps)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAcquireExec_Unbounded(t *testing.T) {
+	release, err := acquireExec(context.Background(), Deps{})
+	require.NoError(t, err)
+	release()
+}
+
+func TestListEvents_FollowsPages(t *testing.T) {
+	clientset := fake.NewClientset()
+	calls := 0
+	clientset.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).ListOptions
+		assert.Equal(t, EventPageSize, opts.Limit)
+		calls++
+		list := &corev1.EventList{Items: []corev1.Event{{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("ev-%d", calls)}}}}
+		if calls < 3 {
+			list.Continue = fmt.Sprintf("token-%d", calls)
+		}
+		return true, list, nil
+	})
+
+	events, err := ListEvents(context.Background(), clientset, "test-ns")
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, 3, calls)
+}
+
+func TestListEvents_StopsAtMaxEvents(t *testing.T) {
+	clientset := fake.NewClientset()
+	calls := 0
+	clientset.PrependReactor("list", "events", func(k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		return true, &corev1.EventList{
+			ListMeta: metav1.ListMeta{Continue: "more"},
+			Items:    make([]corev1.Event, EventPageSize),
+		}, nil
+	})
+
+	events, err := ListEvents(context.Background(), clientset, "test-ns")
+	require.NoError(t, err)
+	assert.Len(t, events, MaxEvents)
+	assert.Equal(t, MaxEvents/int(EventPageSize), calls)
+}
+
+func TestReadLimited(t *testing.T) {
+	data, more, err := ReadLimited(strings.NewReader("hello world"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.True(t, more)
+
+	data, more, err = ReadLimited(strings.NewReader("hello"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.False(t, more)
+}
@@ -0,0 +1,53 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// MetricsGroup is the API group served by metrics-server (PodMetrics, NodeMetrics).
+const MetricsGroup = "metrics.k8s.io"
+
+// metricsGroupVersion is the version of MetricsGroup that metrics-server serves.
+const metricsGroupVersion = MetricsGroup + "/v1beta1"
+
+// SkipError reports that a validation cannot run in this cluster, e.g. because an
+// add-on it depends on is not installed. The engine turns it into a skipped result
+// instead of a failure.
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string {
+	return e.Reason
+}
+
+// RequireMetricsAPI returns a *SkipError when the metrics.k8s.io API is not served,
+// which is the case until metrics-server is installed and ready.
+func RequireMetricsAPI(ctx context.Context, deps Deps) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, err := deps.Clientset.Discovery().ServerResourcesForGroupVersion(metricsGroupVersion)
+	switch {
+	case err == nil:
+		return nil
+	case apierrors.IsNotFound(err):
+		return &SkipError{Reason: "Skipped: metrics-server is not installed in the cluster, so the metrics.k8s.io API is unavailable"}
+	case apierrors.IsServiceUnavailable(err):
+		return &SkipError{Reason: "Skipped: metrics-server is installed but not ready yet, retry in a minute"}
+	default:
+		return fmt.Errorf("failed to discover the metrics.k8s.io API: %w", err)
+	}
+}
+
+// RequireFeature returns a *SkipError when the server version does not provide f.
+func RequireFeature(deps Deps, f kube.Feature) error {
+	if deps.Server.Supports(f) {
+		return nil
+	}
+	return &SkipError{Reason: fmt.Sprintf("Skipped: %s require Kubernetes %s, the cluster runs %s", f.Name, f.MinVersion, deps.Server.Version)}
+}
@@ -0,0 +1,67 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var podMetricsGVR = schema.GroupVersionResource{Group: shared.MetricsGroup, Version: "v1beta1", Resource: "pods"}
+
+func metricsDeps(t *testing.T, served bool) shared.Deps {
+	t.Helper()
+	clientset := fake.NewClientset()
+	if served {
+		clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+			GroupVersion: "metrics.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "pods", Kind: "PodMetrics", Namespaced: true}},
+		}}
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{podMetricsGVR: "PodMetricsList"})
+	return shared.Deps{Clientset: clientset, DynamicClient: dynamicClient, Namespace: "test-ns"}
+}
+
+func TestRequireMetricsAPI(t *testing.T) {
+	require.NoError(t, shared.RequireMetricsAPI(context.Background(), metricsDeps(t, true)))
+
+	err := shared.RequireMetricsAPI(context.Background(), metricsDeps(t, false))
+	var skip *shared.SkipError
+	require.ErrorAs(t, err, &skip)
+	assert.Contains(t, skip.Reason, "metrics-server is not installed")
+}
+
+func TestGetObject_MetricsWithoutMetricsServerIsSkipped(t *testing.T) {
+	deps := metricsDeps(t, false)
+
+	_, err := shared.GetObject(context.Background(), deps, podMetricsGVR, "web")
+	var skip *shared.SkipError
+	assert.ErrorAs(t, err, &skip)
+
+	_, err = shared.ListObjects(context.Background(), deps, podMetricsGVR, nil)
+	assert.ErrorAs(t, err, &skip)
+
+	items, err := shared.ListObjects(context.Background(), metricsDeps(t, true), podMetricsGVR, nil)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestRequireFeature(t *testing.T) {
+	assert.NoError(t, shared.RequireFeature(shared.Deps{}, kube.FeatureEphemeralContainers), "unknown version")
+	assert.NoError(t, shared.RequireFeature(shared.Deps{Server: kube.ServerCapabilities{Version: "1.30.2"}}, kube.FeatureEphemeralContainers))
+
+	err := shared.RequireFeature(shared.Deps{Server: kube.ServerCapabilities{Version: "1.24.0"}}, kube.FeatureEphemeralContainers)
+	var skip *shared.SkipError
+	require.ErrorAs(t, err, &skip)
+	assert.Equal(t, "Skipped: ephemeral containers require Kubernetes 1.25, the cluster runs 1.24.0", skip.Reason)
+}
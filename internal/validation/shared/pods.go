@@ -3,14 +3,20 @@ package shared
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
 // GetTargetPods returns pods matching the target specification.
 func GetTargetPods(ctx context.Context, deps Deps, target vtypes.Target) ([]corev1.Pod, error) {
 	if target.Kind != "Pod" && target.Kind != "" {
@@ -18,6 +24,13 @@ func GetTargetPods(ctx context.Context, deps Deps, target vtypes.Target) ([]core
 	}
 
 	if target.Name != "" {
+		if deps.Cache != nil {
+			obj, err := GetObject(ctx, deps, podsGVR, target.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pod %s: %w", target.Name, err)
+			}
+			return toPods([]unstructured.Unstructured{*obj})
+		}
 		pod, err := deps.Clientset.CoreV1().Pods(deps.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("failed to get pod %s: %w", target.Name, err)
@@ -25,50 +38,183 @@ func GetTargetPods(ctx context.Context, deps Deps, target vtypes.Target) ([]core
 		return []corev1.Pod{*pod}, nil
 	}
 
-	opts := metav1.ListOptions{}
-	if len(target.LabelSelector) > 0 {
-		opts.LabelSelector = labels.SelectorFromSet(target.LabelSelector).String()
-	}
-
-	pods, err := deps.Clientset.CoreV1().Pods(deps.Namespace).List(ctx, opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
-
-	return pods.Items, nil
+	return listPods(ctx, deps, target.LabelSelector)
 }
 
 // GetPodsForResource returns pods owned by a higher-level resource (Deployment, StatefulSet, etc.).
+// A named resource is resolved through ownerReferences; a labelSelector selects pods by label.
 func GetPodsForResource(ctx context.Context, deps Deps, target vtypes.Target) ([]corev1.Pod, error) {
 	gvr, err := GetGVRForKind(target.Kind)
 	if err != nil {
 		return nil, err
 	}
 
-	var labelSelector string
-
 	switch {
 	case target.Name != "":
-		obj, err := deps.DynamicClient.Resource(gvr).Namespace(deps.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		obj, err := GetObject(ctx, deps, gvr, target.Name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get %s %s: %w", target.Kind, target.Name, err)
 		}
-		selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
-		if len(selector) > 0 {
-			labelSelector = labels.SelectorFromSet(selector).String()
-		}
+		return podsOwnedBy(ctx, deps, target.Kind, obj)
 	case len(target.LabelSelector) > 0:
-		labelSelector = labels.SelectorFromSet(target.LabelSelector).String()
+		return listPods(ctx, deps, target.LabelSelector)
 	default:
 		return nil, fmt.Errorf("target %s: must specify name or labelSelector", target.Kind)
 	}
+}
 
-	pods, err := deps.Clientset.CoreV1().Pods(deps.Namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
+// intermediateOwners maps the kinds that do not own their pods directly to the kind
+// in between: Deployment → ReplicaSet → Pod and CronJob → Job → Pod.
+var intermediateOwners = map[string]schema.GroupVersionResource{
+	"deployment": {Group: "apps", Version: "v1", Resource: "replicasets"},
+	"cronjob":    {Group: "batch", Version: "v1", Resource: "jobs"},
+}
+
+// podsOwnedBy returns the pods controlled by obj, following ownerReferences instead of
+// spec.selector: this works for Jobs, CronJobs and custom controllers, and during a
+// rollout it never picks up pods of another workload that carry the same labels.
+func podsOwnedBy(ctx context.Context, deps Deps, kind string, obj *unstructured.Unstructured) ([]corev1.Pod, error) {
+	owners := map[types.UID]bool{obj.GetUID(): true}
+	if gvr, ok := intermediateOwners[strings.ToLower(kind)]; ok {
+		items, err := ListObjects(ctx, deps, gvr, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+		}
+		owners = map[types.UID]bool{}
+		for _, item := range items {
+			if ownedBy(item.GetOwnerReferences(), obj.GetUID()) {
+				owners[item.GetUID()] = true
+			}
+		}
+	}
+
+	// Pods always match their controller's selector, so it narrows the list when set
+	selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+	pods, err := listPods(ctx, deps, selector)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, err
+	}
+	owned := pods[:0]
+	for _, pod := range pods {
+		for _, ref := range pod.OwnerReferences {
+			if owners[ref.UID] {
+				owned = append(owned, pod)
+				break
+			}
+		}
+	}
+	return owned, nil
+}
+
+// Annotation and label that tie pods to a workload revision.
+const (
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	controllerRevisionHashLabel  = "controller-revision-hash"
+)
+
+// GetLatestRevisionPods returns the pods of the current revision of a named Deployment
+// or StatefulSet: the pods of the ReplicaSet whose revision matches the Deployment's,
+// or the pods labelled with the StatefulSet's updateRevision. Pods of older revisions
+// that are still running during a rollout are left out.
+func GetLatestRevisionPods(ctx context.Context, deps Deps, target vtypes.Target) ([]corev1.Pod, error) {
+	kind := strings.ToLower(target.Kind)
+	if kind != "deployment" && kind != "statefulset" {
+		return nil, fmt.Errorf("latest revision lookup requires a Deployment or StatefulSet, got %q", target.Kind)
+	}
+	if target.Name == "" {
+		return nil, fmt.Errorf("latest revision lookup requires a target name")
+	}
+	gvr, err := GetGVRForKind(target.Kind)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := GetObject(ctx, deps, gvr, target.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", target.Kind, target.Name, err)
 	}
 
+	if kind == "statefulset" {
+		revision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+		pods, err := podsOwnedBy(ctx, deps, target.Kind, obj)
+		if err != nil {
+			return nil, err
+		}
+		current := pods[:0]
+		for _, pod := range pods {
+			if revision != "" && pod.Labels[controllerRevisionHashLabel] == revision {
+				current = append(current, pod)
+			}
+		}
+		return current, nil
+	}
+
+	revision := obj.GetAnnotations()[deploymentRevisionAnnotation]
+	replicaSets, err := ListObjects(ctx, deps, intermediateOwners["deployment"], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	var currentRS types.UID
+	for _, rs := range replicaSets {
+		if revision != "" && ownedBy(rs.GetOwnerReferences(), obj.GetUID()) && rs.GetAnnotations()[deploymentRevisionAnnotation] == revision {
+			currentRS = rs.GetUID()
+			break
+		}
+	}
+	if currentRS == "" {
+		return nil, nil
+	}
+
+	selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+	pods, err := listPods(ctx, deps, selector)
+	if err != nil {
+		return nil, err
+	}
+	current := pods[:0]
+	for _, pod := range pods {
+		if ownedBy(pod.OwnerReferences, currentRS) {
+			current = append(current, pod)
+		}
+	}
+	return current, nil
+}
+
+func ownedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// listPods lists the pods matching selector, from deps.Cache when it is enabled.
+func listPods(ctx context.Context, deps Deps, selector map[string]string) ([]corev1.Pod, error) {
+	if deps.Cache != nil {
+		items, err := ListObjects(ctx, deps, podsGVR, selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		return toPods(items)
+	}
+
+	opts := metav1.ListOptions{}
+	if len(selector) > 0 {
+		opts.LabelSelector = labels.SelectorFromSet(selector).String()
+	}
+	pods, err := deps.Clientset.CoreV1().Pods(deps.Namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
 	return pods.Items, nil
 }
+
+// toPods converts pods read through the dynamic client into typed pods.
+func toPods(items []unstructured.Unstructured) ([]corev1.Pod, error) {
+	pods := make([]corev1.Pod, len(items))
+	for i := range items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(items[i].Object, &pods[i]); err != nil {
+			return nil, fmt.Errorf("failed to convert pod %s: %w", items[i].GetName(), err)
+		}
+	}
+	return pods, nil
+}
@@ -12,6 +12,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -48,33 +50,87 @@ func TestGetTargetPods_ByLabelSelector(t *testing.T) {
 	assert.Len(t, pods, 2)
 }
 
-func TestGetPodsForResource_Deployment(t *testing.T) {
-	deployment := &unstructured.Unstructured{
-		Object: map[string]interface{}{
-			"apiVersion": "apps/v1",
-			"kind":       "Deployment",
-			"metadata": map[string]interface{}{
-				"name":      "test-deployment",
-				"namespace": "test-ns",
-			},
-			"spec": map[string]interface{}{
-				"selector": map[string]interface{}{
-					"matchLabels": map[string]interface{}{"app": "test"},
-				},
-			},
-		},
+func ownedObject(apiVersion, kind, name, uid string, owner *metav1.OwnerReference, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": "test-ns", "uid": uid},
+	}}
+	if spec != nil {
+		obj.Object["spec"] = spec
 	}
-	pod := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns", Labels: map[string]string{"app": "test"}},
+	if owner != nil {
+		obj.SetOwnerReferences([]metav1.OwnerReference{*owner})
+	}
+	return obj
+}
+
+func ownedPod(name string, labels map[string]string, ownerUID string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: labels}}
+	if ownerUID != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{Name: "owner", UID: types.UID(ownerUID)}}
 	}
+	return pod
+}
+
+func newOwnerTestClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "apps", Version: "v1", Resource: "replicasets"}: "ReplicaSetList",
+		{Group: "batch", Version: "v1", Resource: "jobs"}:       "JobList",
+	}, objects...)
+}
+
+func TestGetPodsForResource_Deployment(t *testing.T) {
+	selector := map[string]interface{}{"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "test"}}}
+	labels := map[string]string{"app": "test"}
 	deps := shared.Deps{
-		Clientset:     fake.NewClientset(pod),
-		DynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment),
-		Namespace:     "test-ns",
+		Clientset: fake.NewClientset(
+			ownedPod("test-pod-old", labels, "rs-old"),
+			ownedPod("test-pod-new", labels, "rs-new"),
+			ownedPod("other-pod", labels, "rs-other"), // same labels, other Deployment
+			ownedPod("bare-pod", labels, ""),
+		),
+		DynamicClient: newOwnerTestClient(
+			ownedObject("apps/v1", "Deployment", "test-deployment", "deploy-uid", nil, selector),
+			ownedObject("apps/v1", "ReplicaSet", "test-deployment-old", "rs-old", &metav1.OwnerReference{UID: "deploy-uid"}, nil),
+			ownedObject("apps/v1", "ReplicaSet", "test-deployment-new", "rs-new", &metav1.OwnerReference{UID: "deploy-uid"}, nil),
+			ownedObject("apps/v1", "ReplicaSet", "other-rs", "rs-other", &metav1.OwnerReference{UID: "other-uid"}, nil),
+		),
+		Namespace: "test-ns",
 	}
 
 	pods, err := shared.GetPodsForResource(context.Background(), deps, vtypes.Target{Kind: "Deployment", Name: "test-deployment"})
 	require.NoError(t, err)
+	names := make([]string, len(pods))
+	for i, pod := range pods {
+		names[i] = pod.Name
+	}
+	assert.ElementsMatch(t, []string{"test-pod-old", "test-pod-new"}, names)
+}
+
+func TestGetPodsForResource_JobsAndCronJobs(t *testing.T) {
+	deps := shared.Deps{
+		Clientset: fake.NewClientset(
+			ownedPod("migrate-abc", map[string]string{"job-name": "migrate"}, "job-uid"),
+			ownedPod("backup-123-xyz", nil, "cron-job-uid"),
+			ownedPod("unrelated", nil, "other-uid"),
+		),
+		DynamicClient: newOwnerTestClient(
+			// No spec.selector.matchLabels: pods are only found through ownerReferences
+			ownedObject("batch/v1", "Job", "migrate", "job-uid", nil, nil),
+			ownedObject("batch/v1", "CronJob", "backup", "cron-uid", nil, nil),
+			ownedObject("batch/v1", "Job", "backup-123", "cron-job-uid", &metav1.OwnerReference{UID: "cron-uid"}, nil),
+		),
+		Namespace: "test-ns",
+	}
+
+	pods, err := shared.GetPodsForResource(context.Background(), deps, vtypes.Target{Kind: "Job", Name: "migrate"})
+	require.NoError(t, err)
 	require.Len(t, pods, 1)
-	assert.Equal(t, "test-pod", pods[0].Name)
+	assert.Equal(t, "migrate-abc", pods[0].Name)
+
+	pods, err = shared.GetPodsForResource(context.Background(), deps, vtypes.Target{Kind: "CronJob", Name: "backup"})
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "backup-123-xyz", pods[0].Name)
 }
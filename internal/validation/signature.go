@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+)
+
+// ChallengeSignatureHeader is the response header of GET /api/challenges/{slug}/yaml
+// carrying the detached minisign signature of the file, base64-encoded.
+const ChallengeSignatureHeader = "X-Challenge-Signature"
+
+// ChallengePublicKeysEnv lists minisign public keys trusted to sign challenge.yaml, in
+// addition to constants.ChallengePublicKeys, separated by commas.
+const ChallengePublicKeysEnv = "KUBEASY_CHALLENGE_PUBLIC_KEYS"
+
+// minisignAlgorithm is the signature algorithm supported: Ed25519 over the file itself,
+// as produced by signify and by minisign -l. Prehashed ("ED") signatures need BLAKE2b,
+// which the CLI does not ship.
+const minisignAlgorithm = "Ed"
+
+type minisignKeyID [8]byte
+
+type minisignSignature struct {
+	keyID     minisignKeyID
+	signature []byte
+	// trustedComment and globalSignature are set for minisign signatures; the global
+	// signature covers the signature and the trusted comment.
+	trustedComment  []byte
+	globalSignature []byte
+}
+
+// verifyChallengeSignature checks the signature of a challenge.yaml served by the API
+// against the trusted keys. Once a key is trusted, unsigned files and invalid
+// signatures are rejected, so a tampered file cannot run its validations (which may
+// exec commands in the cluster); without trusted keys, nothing is verified.
+func verifyChallengeSignature(data []byte, signature string) error {
+	keys, err := trustedChallengeKeys()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if signature == "" {
+		return fmt.Errorf("challenge.yaml is not signed")
+	}
+	sig, err := parseMinisignSignature(signature)
+	if err != nil {
+		return fmt.Errorf("invalid challenge.yaml signature: %w", err)
+	}
+	key, ok := keys[sig.keyID]
+	if !ok {
+		return fmt.Errorf("challenge.yaml is signed with untrusted key %X", sig.keyID)
+	}
+	if !ed25519.Verify(key, data, sig.signature) {
+		return fmt.Errorf("challenge.yaml signature verification failed: the file was modified")
+	}
+	if sig.globalSignature != nil && !ed25519.Verify(key, append(bytes.Clone(sig.signature), sig.trustedComment...), sig.globalSignature) {
+		return fmt.Errorf("challenge.yaml signature verification failed: invalid trusted comment")
+	}
+	return nil
+}
+
+// trustedChallengeKeys returns the trusted public keys by key ID.
+func trustedChallengeKeys() (map[minisignKeyID]ed25519.PublicKey, error) {
+	keys := map[minisignKeyID]ed25519.PublicKey{}
+	for _, list := range []string{constants.ChallengePublicKeys, os.Getenv(ChallengePublicKeysEnv)} {
+		for _, encoded := range strings.Split(list, ",") {
+			encoded = strings.TrimSpace(encoded)
+			if encoded == "" {
+				continue
+			}
+			id, key, err := parseMinisignPublicKey(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted challenge key %q: %w", encoded, err)
+			}
+			keys[id] = key
+		}
+	}
+	return keys, nil
+}
+
+// parseMinisignPublicKey decodes a minisign public key, as printed by minisign -G or on
+// the second line of a .pub file.
+func parseMinisignPublicKey(encoded string) (minisignKeyID, ed25519.PublicKey, error) {
+	var id minisignKeyID
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return id, nil, err
+	}
+	if len(raw) != 2+len(id)+ed25519.PublicKeySize || string(raw[:2]) != minisignAlgorithm {
+		return id, nil, fmt.Errorf("not an Ed25519 minisign public key")
+	}
+	copy(id[:], raw[2:10])
+	return id, ed25519.PublicKey(raw[10:]), nil
+}
+
+// parseMinisignSignature decodes a base64-encoded .minisig (or signify .sig) file.
+func parseMinisignSignature(encoded string) (*minisignSignature, error) {
+	file, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(string(file), "\n"), "\n")
+	if len(lines) != 2 && len(lines) != 4 {
+		return nil, fmt.Errorf("expected 2 or 4 lines, got %d", len(lines))
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil {
+		return nil, err
+	}
+	sig := &minisignSignature{}
+	if len(raw) != 2+len(sig.keyID)+ed25519.SignatureSize {
+		return nil, fmt.Errorf("unexpected signature length %d", len(raw))
+	}
+	if algorithm := string(raw[:2]); algorithm != minisignAlgorithm {
+		return nil, fmt.Errorf("unsupported algorithm %q (sign with minisign -l)", algorithm)
+	}
+	copy(sig.keyID[:], raw[2:10])
+	sig.signature = raw[10:]
+
+	if len(lines) == 4 {
+		comment, ok := strings.CutPrefix(lines[2], "trusted comment: ")
+		if !ok {
+			return nil, fmt.Errorf("missing trusted comment")
+		}
+		sig.trustedComment = []byte(comment)
+		if sig.globalSignature, err = base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3])); err != nil {
+			return nil, err
+		}
+	}
+	return sig, nil
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner signs like minisign -l with a fresh key.
+type testSigner struct {
+	id      [8]byte
+	private ed25519.PrivateKey
+	public  string
+}
+
+func newTestSigner(t *testing.T, id byte) *testSigner {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	s := &testSigner{id: [8]byte{id}, private: priv}
+	s.public = base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), s.id[:]...), pub...))
+	return s
+}
+
+// sign returns the base64-encoded .minisig file of data, in the signify format
+// (without trusted comment) when comment is empty.
+func (s *testSigner) sign(algorithm string, data []byte, comment string) string {
+	sig := ed25519.Sign(s.private, data)
+	raw := append(append([]byte(algorithm), s.id[:]...), sig...)
+	file := fmt.Sprintf("untrusted comment: signature\n%s\n", base64.StdEncoding.EncodeToString(raw))
+	if comment != "" {
+		global := ed25519.Sign(s.private, append(sig, comment...))
+		file += fmt.Sprintf("trusted comment: %s\n%s\n", comment, base64.StdEncoding.EncodeToString(global))
+	}
+	return base64.StdEncoding.EncodeToString([]byte(file))
+}
+
+func TestVerifyChallengeSignature(t *testing.T) {
+	data := []byte("objectives: []\n")
+	signer := newTestSigner(t, 1)
+	other := newTestSigner(t, 2)
+
+	// Nothing is verified without trusted keys
+	t.Setenv(ChallengePublicKeysEnv, "")
+	require.NoError(t, verifyChallengeSignature(data, ""))
+
+	t.Setenv(ChallengePublicKeysEnv, signer.public)
+	require.NoError(t, verifyChallengeSignature(data, signer.sign("Ed", data, "timestamp:1760000000")))
+	require.NoError(t, verifyChallengeSignature(data, signer.sign("Ed", data, "")), "signify format")
+
+	tests := []struct {
+		name      string
+		data      []byte
+		signature string
+		wantErr   string
+	}{
+		{name: "unsigned", data: data, wantErr: "challenge.yaml is not signed"},
+		{name: "tampered", data: []byte("objectives: [evil]\n"), signature: signer.sign("Ed", data, "c"), wantErr: "the file was modified"},
+		{name: "untrusted key", data: data, signature: other.sign("Ed", data, "c"), wantErr: "untrusted key 0200000000000000"},
+		{name: "prehashed", data: data, signature: signer.sign("ED", data, "c"), wantErr: `unsupported algorithm "ED"`},
+		{name: "garbage", data: data, signature: "not base64!", wantErr: "invalid challenge.yaml signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyChallengeSignature(tt.data, tt.signature)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	t.Setenv(ChallengePublicKeysEnv, signer.public+", "+other.public)
+	require.NoError(t, verifyChallengeSignature(data, other.sign("Ed", data, "c")), "any trusted key")
+
+	t.Setenv(ChallengePublicKeysEnv, "bm90IGEga2V5")
+	err := verifyChallengeSignature(data, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid trusted challenge key")
+}
@@ -0,0 +1,66 @@
+package validation
+
+import "time"
+
+// Summary aggregates the results of a validation run.
+type Summary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	// Duration is the wall-clock time of the run.
+	Duration time.Duration `json:"-"`
+	// ByType breaks the counts down per validation type, in order of first appearance.
+	ByType []TypeSummary `json:"byType"`
+}
+
+// TypeSummary holds the counts of one validation type. Duration adds up the time its
+// validations took, which exceeds the wall-clock time when they ran in parallel.
+type TypeSummary struct {
+	Type     ValidationType `json:"type"`
+	Total    int            `json:"total"`
+	Passed   int            `json:"passed"`
+	Failed   int            `json:"failed"`
+	Skipped  int            `json:"skipped"`
+	Duration time.Duration  `json:"-"`
+}
+
+// AllPassed reports whether every validation passed. Skipped validations did not.
+func (s Summary) AllPassed() bool {
+	return s.Passed == s.Total
+}
+
+// Summarize counts results by outcome and type. results are in the order of
+// validations; validations without a result (after a fail-fast stop) count as failed.
+func Summarize(validations []Validation, results []Result, duration time.Duration) Summary {
+	s := Summary{Total: len(validations), Duration: duration}
+	index := make(map[ValidationType]int)
+	for i, v := range validations {
+		pos, ok := index[v.Type]
+		if !ok {
+			pos = len(s.ByType)
+			index[v.Type] = pos
+			s.ByType = append(s.ByType, TypeSummary{Type: v.Type})
+		}
+		t := &s.ByType[pos]
+		t.Total++
+
+		switch {
+		case i >= len(results):
+			s.Failed++
+			t.Failed++
+			continue
+		case results[i].Passed:
+			s.Passed++
+			t.Passed++
+		case results[i].Skipped:
+			s.Skipped++
+			t.Skipped++
+		default:
+			s.Failed++
+			t.Failed++
+		}
+		t.Duration += results[i].Duration
+	}
+	return s
+}
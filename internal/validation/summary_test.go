@@ -0,0 +1,63 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	validations := []validation.Validation{
+		{Key: "a", Type: validation.TypeCondition},
+		{Key: "b", Type: validation.TypeStatus},
+		{Key: "c", Type: validation.TypeCondition},
+		{Key: "d", Type: validation.TypeSpec},
+	}
+	results := []validation.Result{
+		{Key: "a", Passed: true, Duration: 100 * time.Millisecond},
+		{Key: "b", Passed: false, Duration: 50 * time.Millisecond},
+		{Key: "c", Passed: true, Duration: 200 * time.Millisecond},
+		{Key: "d", Skipped: true},
+	}
+
+	s := validation.Summarize(validations, results, 250*time.Millisecond)
+
+	assert.Equal(t, 4, s.Total)
+	assert.Equal(t, 2, s.Passed)
+	assert.Equal(t, 1, s.Failed)
+	assert.Equal(t, 1, s.Skipped)
+	assert.Equal(t, 250*time.Millisecond, s.Duration)
+	assert.False(t, s.AllPassed())
+
+	require.Len(t, s.ByType, 3)
+	assert.Equal(t, validation.TypeSummary{
+		Type: validation.TypeCondition, Total: 2, Passed: 2, Duration: 300 * time.Millisecond,
+	}, s.ByType[0])
+	assert.Equal(t, validation.TypeStatus, s.ByType[1].Type)
+	assert.Equal(t, 1, s.ByType[1].Failed)
+	assert.Equal(t, validation.TypeSpec, s.ByType[2].Type)
+	assert.Equal(t, 1, s.ByType[2].Skipped)
+}
+
+func TestSummarize_FailFastCountsMissingAsFailed(t *testing.T) {
+	validations := []validation.Validation{
+		{Key: "a", Type: validation.TypeCondition},
+		{Key: "b", Type: validation.TypeEvent},
+	}
+	results := []validation.Result{{Key: "a", Passed: false}}
+
+	s := validation.Summarize(validations, results, time.Second)
+
+	assert.Equal(t, 2, s.Failed)
+	require.Len(t, s.ByType, 2)
+	assert.Equal(t, 1, s.ByType[1].Failed)
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	s := validation.Summarize(nil, nil, 0)
+	assert.True(t, s.AllPassed())
+	assert.Empty(t, s.ByType)
+}
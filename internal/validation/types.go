@@ -7,42 +7,73 @@ import "github.com/kubeasy-dev/kubeasy-cli/internal/validation/vtypes"
 
 // Type aliases — keep all external callers working without any import changes.
 type (
-	ValidationConfig  = vtypes.ValidationConfig
-	Validation        = vtypes.Validation
-	ValidationType    = vtypes.ValidationType
-	Result            = vtypes.Result
-	Target            = vtypes.Target
-	StatusSpec        = vtypes.StatusSpec
-	StatusCheck       = vtypes.StatusCheck
-	ConditionSpec     = vtypes.ConditionSpec
-	ConditionCheck    = vtypes.ConditionCheck
-	LogSpec           = vtypes.LogSpec
-	MatchMode         = vtypes.MatchMode
-	EventSpec         = vtypes.EventSpec
-	ConnectivitySpec  = vtypes.ConnectivitySpec
-	SourcePod         = vtypes.SourcePod
-	ConnectivityCheck = vtypes.ConnectivityCheck
-	TLSConfig         = vtypes.TLSConfig
-	RbacSpec          = vtypes.RbacSpec
-	RbacCheck         = vtypes.RbacCheck
-	SpecSpec          = vtypes.SpecSpec
-	SpecCheck         = vtypes.SpecCheck
-	TriggeredSpec     = vtypes.TriggeredSpec
-	TriggerConfig     = vtypes.TriggerConfig
-	TriggerType       = vtypes.TriggerType
-	TypeRegistration  = vtypes.TypeRegistration
+	ValidationConfig        = vtypes.ValidationConfig
+	Validation              = vtypes.Validation
+	ValidationType          = vtypes.ValidationType
+	Result                  = vtypes.Result
+	Comparison              = vtypes.Comparison
+	ObjectRef               = vtypes.ObjectRef
+	Target                  = vtypes.Target
+	StatusSpec              = vtypes.StatusSpec
+	StatusCheck             = vtypes.StatusCheck
+	ContainerStatusCheck    = vtypes.ContainerStatusCheck
+	ConditionSpec           = vtypes.ConditionSpec
+	ConditionCheck          = vtypes.ConditionCheck
+	LogSpec                 = vtypes.LogSpec
+	MatchMode               = vtypes.MatchMode
+	EventSpec               = vtypes.EventSpec
+	ConnectivitySpec        = vtypes.ConnectivitySpec
+	SourcePod               = vtypes.SourcePod
+	ConnectivityCheck       = vtypes.ConnectivityCheck
+	TLSConfig               = vtypes.TLSConfig
+	RbacSpec                = vtypes.RbacSpec
+	RbacCheck               = vtypes.RbacCheck
+	SpecSpec                = vtypes.SpecSpec
+	SpecCheck               = vtypes.SpecCheck
+	TriggeredSpec           = vtypes.TriggeredSpec
+	TriggerConfig           = vtypes.TriggerConfig
+	TriggerType             = vtypes.TriggerType
+	PluginSpec              = vtypes.PluginSpec
+	PromMetricsSpec         = vtypes.PromMetricsSpec
+	PromMetricCheck         = vtypes.PromMetricCheck
+	EndpointsSpec           = vtypes.EndpointsSpec
+	NetworkPolicySpec       = vtypes.NetworkPolicySpec
+	NetworkPolicyPeer       = vtypes.NetworkPolicyPeer
+	NodeSpec                = vtypes.NodeSpec
+	NodeTaint               = vtypes.NodeTaint
+	PodDisruptionBudgetSpec = vtypes.PodDisruptionBudgetSpec
+	ProbesSpec              = vtypes.ProbesSpec
+	ProbeCheck              = vtypes.ProbeCheck
+	ImagesSpec              = vtypes.ImagesSpec
+	ResourcesSpec           = vtypes.ResourcesSpec
+	ResourceBounds          = vtypes.ResourceBounds
+	MetadataSpec            = vtypes.MetadataSpec
+	MetadataCheck           = vtypes.MetadataCheck
+	ForbiddenAction         = vtypes.ForbiddenAction
+	ForbiddenActionEvent    = vtypes.ForbiddenActionEvent
+	TypeRegistration        = vtypes.TypeRegistration
 )
 
 // Validation type constants.
 const (
-	TypeStatus       = vtypes.TypeStatus
-	TypeCondition    = vtypes.TypeCondition
-	TypeLog          = vtypes.TypeLog
-	TypeEvent        = vtypes.TypeEvent
-	TypeConnectivity = vtypes.TypeConnectivity
-	TypeRbac         = vtypes.TypeRbac
-	TypeSpec         = vtypes.TypeSpec
-	TypeTriggered    = vtypes.TypeTriggered
+	TypeStatus              = vtypes.TypeStatus
+	TypeCondition           = vtypes.TypeCondition
+	TypeLog                 = vtypes.TypeLog
+	TypeEvent               = vtypes.TypeEvent
+	TypeConnectivity        = vtypes.TypeConnectivity
+	TypeRbac                = vtypes.TypeRbac
+	TypeSpec                = vtypes.TypeSpec
+	TypeTriggered           = vtypes.TypeTriggered
+	TypePlugin              = vtypes.TypePlugin
+	TypePromMetrics         = vtypes.TypePromMetrics
+	TypeEndpoints           = vtypes.TypeEndpoints
+	TypeNetworkPolicy       = vtypes.TypeNetworkPolicy
+	TypeNode                = vtypes.TypeNode
+	TypePodDisruptionBudget = vtypes.TypePodDisruptionBudget
+	TypeProbes              = vtypes.TypeProbes
+	TypeImages              = vtypes.TypeImages
+	TypeResources           = vtypes.TypeResources
+	TypeMetadata            = vtypes.TypeMetadata
 )
 
 // Connectivity mode constants.
@@ -51,6 +82,19 @@ const (
 	ConnectivityModeInternal = vtypes.ConnectivityModeInternal
 )
 
+// Probe type constants for probes validation.
+const (
+	ProbeLiveness  = vtypes.ProbeLiveness
+	ProbeReadiness = vtypes.ProbeReadiness
+	ProbeStartup   = vtypes.ProbeStartup
+)
+
+// NetworkPolicy verdict constants.
+const (
+	NetworkPolicyAllowed = vtypes.NetworkPolicyAllowed
+	NetworkPolicyDenied  = vtypes.NetworkPolicyDenied
+)
+
 // MatchMode constants for log validation.
 const (
 	MatchModeAllOf = vtypes.MatchModeAllOf
@@ -67,12 +111,16 @@ const (
 )
 
 // RegisteredTypes lists all validation types for schema generation.
-// Adding a new type: add it to vtypes.RegisteredTypes in vtypes/types.go.
+// Adding a new type: add it to vtypes.RegisteredTypes in vtypes/types.go, then
+// register its Validator with engine.Register from the init of its executor package.
 var RegisteredTypes = vtypes.RegisteredTypes
 
 // ChallengeYamlSpec is the single source of truth for the challenge.yaml file format.
 type ChallengeYamlSpec = vtypes.ChallengeYamlSpec
 
+// ChallengeSpec is a parsed challenge.yaml with metadata and executable validations.
+type ChallengeSpec = vtypes.ChallengeSpec
+
 // ChallengeDifficultyValues and ChallengeTypeValues drive lint validation and Zod schema generation.
 var (
 	ChallengeDifficultyValues = vtypes.ChallengeDifficultyValues
@@ -0,0 +1,207 @@
+package validation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// variantsKey is the top-level challenge.yaml block declaring scenario variants.
+const variantsKey = "variants"
+
+// variant is one alternative objective set of a challenge.yaml variants block.
+type variant struct {
+	name       string
+	weight     int
+	objectives *yaml.Node
+	vars       *yaml.Node
+}
+
+// VariantSeed returns the seed that selects the variant of a challenge for a user, so
+// each user gets a stable variant per challenge, independently from one challenge to
+// the next.
+func VariantSeed(slug, userID string) string {
+	if userID == "" {
+		return ""
+	}
+	return slug + "/" + userID
+}
+
+// CheckVariantUser returns an error when config was loaded for a challenge with variants
+// while the user could not be fetched (userErr). Without the user the first variant
+// is selected, which authoring commands want but is not the variant the user was
+// assigned, so it must not be graded or shown as theirs.
+func CheckVariantUser(slug string, config *ValidationConfig, userErr error) error {
+	if userErr == nil || config == nil || config.Variant == "" {
+		return nil
+	}
+	return fmt.Errorf("cannot select your variant of %s: failed to fetch your profile: %w", slug, userErr)
+}
+
+// SelectVariant applies the variant of challenge.yaml chosen by seed: a weighted draw
+// that is the same for every call with the same seed. An empty seed selects the first
+// variant, which is what authoring commands run. It returns data unchanged and an
+// empty name when the challenge has no variants.
+func SelectVariant(data []byte, seed string) ([]byte, string, error) {
+	return applyVariant(data, func(variants []variant) variant {
+		if seed == "" {
+			return variants[0]
+		}
+		return chooseVariant(variants, seed)
+	})
+}
+
+// ApplyVariant applies the named variant of challenge.yaml; lint uses it to check
+// every variant.
+func ApplyVariant(data []byte, name string) ([]byte, error) {
+	var found bool
+	applied, _, err := applyVariant(data, func(variants []variant) variant {
+		for _, v := range variants {
+			if v.name == name {
+				found = true
+				return v
+			}
+		}
+		return variants[0]
+	})
+	if err == nil && !found {
+		return nil, fmt.Errorf("unknown variant %q", name)
+	}
+	return applied, err
+}
+
+// VariantNames returns the names of the variants of challenge.yaml, in file order.
+func VariantNames(data []byte) ([]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil, nil //nolint:nilerr // malformed YAML is reported by the parsers
+	}
+	variants, err := decodeVariants(mappingValue(doc.Content[0], variantsKey))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(variants))
+	for i, v := range variants {
+		names[i] = v.name
+	}
+	return names, nil
+}
+
+// applyVariant appends the objectives of the variant returned by choose to the
+// objectives of data, merges its vars over the challenge vars and removes the variants
+// block.
+func applyVariant(data []byte, choose func([]variant) variant) ([]byte, string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return data, "", nil //nolint:nilerr // malformed YAML is reported by the parsers
+	}
+	root := doc.Content[0]
+	variantsNode := mappingValue(root, variantsKey)
+	if variantsNode == nil {
+		return data, "", nil
+	}
+	variants, err := decodeVariants(variantsNode)
+	if err != nil {
+		return nil, "", err
+	}
+	chosen := choose(variants)
+	removeMappingKey(root, variantsKey)
+
+	objectives := mappingValue(root, "objectives")
+	if objectives == nil {
+		objectives = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingValue(root, "objectives", objectives)
+	}
+	if objectives.Kind != yaml.SequenceNode {
+		return nil, "", fmt.Errorf("objectives must be a list")
+	}
+	objectives.Content = append(objectives.Content, chosen.objectives.Content...)
+
+	if chosen.vars != nil {
+		vars := mappingValue(root, varsKey)
+		if vars == nil || vars.Kind != yaml.MappingNode {
+			vars = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(root, varsKey, vars)
+		}
+		for i := 0; i+1 < len(chosen.vars.Content); i += 2 {
+			setMappingValue(vars, chosen.vars.Content[i].Value, chosen.vars.Content[i+1])
+		}
+	}
+
+	applied, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to re-encode challenge: %w", err)
+	}
+	return applied, chosen.name, nil
+}
+
+func decodeVariants(node *yaml.Node) ([]variant, error) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return nil, fmt.Errorf("variants must be a non-empty list")
+	}
+	variants := make([]variant, 0, len(node.Content))
+	seen := map[string]bool{}
+	for i, item := range node.Content {
+		v := variant{weight: 1}
+		if n := mappingValue(item, "name"); n != nil {
+			v.name = n.Value
+		}
+		switch {
+		case v.name == "":
+			return nil, fmt.Errorf("variants[%d]: name is required", i)
+		case seen[v.name]:
+			return nil, fmt.Errorf("variants[%d]: duplicate name %q", i, v.name)
+		}
+		seen[v.name] = true
+
+		if w := mappingValue(item, "weight"); w != nil {
+			if err := w.Decode(&v.weight); err != nil || v.weight < 1 {
+				return nil, fmt.Errorf("variants[%d] %q: weight must be a positive integer", i, v.name)
+			}
+		}
+		v.objectives = mappingValue(item, "objectives")
+		if v.objectives == nil || v.objectives.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("variants[%d] %q: objectives list is required", i, v.name)
+		}
+		v.vars = mappingValue(item, varsKey)
+		if v.vars != nil && v.vars.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("variants[%d] %q: vars must be a mapping of names to values", i, v.name)
+		}
+		variants = append(variants, v)
+	}
+	return variants, nil
+}
+
+// chooseVariant draws a variant with a probability proportional to its weight, using
+// a hash of seed as the random number.
+func chooseVariant(variants []variant, seed string) variant {
+	total := 0
+	for _, v := range variants {
+		total += v.weight
+	}
+	sum := sha256.Sum256([]byte(seed))
+	n := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total)) //nolint:gosec // less than total
+	for _, v := range variants {
+		if n < v.weight {
+			return v
+		}
+		n -= v.weight
+	}
+	return variants[len(variants)-1]
+}
+
+// setMappingValue sets key to value in a mapping node, appending the key when missing.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
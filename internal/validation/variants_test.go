@@ -0,0 +1,174 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const variantsChallenge = `vars:
+  app: web
+  port: 8080
+objectives:
+  - key: scraped
+    type: metadata
+    spec:
+      target:
+        kind: Service
+        name: ${app}
+      annotations:
+        - key: prometheus.io/port
+          value: "${port}"
+variants:
+  - name: default
+    weight: 3
+    objectives:
+      - key: reachable
+        type: endpoints
+        spec:
+          service: ${app}
+  - name: port-9090
+    vars:
+      port: 9090
+    objectives:
+      - key: reachable
+        type: endpoints
+        spec:
+          service: ${app}
+          readyEndpoints: 2
+`
+
+func TestSelectVariant(t *testing.T) {
+	data, name, err := SelectVariant([]byte(variantsChallenge), "")
+	require.NoError(t, err)
+	assert.Equal(t, "default", name)
+	assert.NotContains(t, string(data), "variants:")
+
+	// The same seed always selects the same variant
+	for i := range 20 {
+		seed := VariantSeed("pod-evicted", fmt.Sprintf("user-%d", i))
+		_, first, err := SelectVariant([]byte(variantsChallenge), seed)
+		require.NoError(t, err)
+		_, again, err := SelectVariant([]byte(variantsChallenge), seed)
+		require.NoError(t, err)
+		assert.Equal(t, first, again)
+	}
+
+	// Selection follows the weights
+	counts := map[string]int{}
+	for i := range 400 {
+		_, name, err := SelectVariant([]byte(variantsChallenge), VariantSeed("pod-evicted", fmt.Sprintf("user-%d", i)))
+		require.NoError(t, err)
+		counts[name]++
+	}
+	assert.InDelta(t, 300, counts["default"], 40)
+	assert.InDelta(t, 100, counts["port-9090"], 40)
+
+	unchanged, name, err := SelectVariant([]byte("objectives: []\n"), "seed")
+	require.NoError(t, err)
+	assert.Empty(t, name)
+	assert.Equal(t, "objectives: []\n", string(unchanged))
+}
+
+func TestVariantSeed(t *testing.T) {
+	assert.Equal(t, "pod-evicted/user-1", VariantSeed("pod-evicted", "user-1"))
+	assert.Empty(t, VariantSeed("pod-evicted", ""))
+}
+
+func TestApplyVariant(t *testing.T) {
+	data, err := ApplyVariant([]byte(variantsChallenge), "port-9090")
+	require.NoError(t, err)
+
+	config, err := Parse(data)
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 2)
+	assert.Equal(t, "scraped", config.Validations[0].Key)
+	assert.Equal(t, "reachable", config.Validations[1].Key)
+
+	// Variant vars override the challenge vars
+	metadata, ok := config.Validations[0].Spec.(MetadataSpec)
+	require.True(t, ok)
+	assert.Equal(t, "9090", metadata.Annotations[0].Value)
+	endpoints, ok := config.Validations[1].Spec.(EndpointsSpec)
+	require.True(t, ok)
+	assert.Equal(t, "web", endpoints.Service)
+
+	_, err = ApplyVariant([]byte(variantsChallenge), "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown variant "missing"`)
+
+	names, err := VariantNames([]byte(variantsChallenge))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "port-9090"}, names)
+}
+
+func TestParseForSeed(t *testing.T) {
+	config, err := Parse([]byte(variantsChallenge))
+	require.NoError(t, err)
+	assert.Equal(t, "default", config.Variant)
+
+	config, err = ParseForSeed([]byte("objectives: []\n"), "seed")
+	require.NoError(t, err)
+	assert.Empty(t, config.Variant)
+}
+
+func TestCheckVariantUser(t *testing.T) {
+	profileErr := fmt.Errorf("not authenticated")
+	withVariant := &ValidationConfig{Variant: "default"}
+	withoutVariant := &ValidationConfig{}
+
+	assert.NoError(t, CheckVariantUser("demo", withVariant, nil))
+	assert.NoError(t, CheckVariantUser("demo", withoutVariant, profileErr), "a challenge without variants needs no user")
+	err := CheckVariantUser("demo", withVariant, profileErr)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, profileErr)
+	assert.Contains(t, err.Error(), "cannot select your variant of demo")
+}
+
+func TestSelectVariantErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "empty list",
+			data:    "variants: []\n",
+			wantErr: "variants must be a non-empty list",
+		},
+		{
+			name:    "missing name",
+			data:    "variants:\n  - objectives: []\n",
+			wantErr: "variants[0]: name is required",
+		},
+		{
+			name:    "duplicate name",
+			data:    "variants:\n  - name: a\n    objectives: []\n  - name: a\n    objectives: []\n",
+			wantErr: `variants[1]: duplicate name "a"`,
+		},
+		{
+			name:    "invalid weight",
+			data:    "variants:\n  - name: a\n    weight: 0\n    objectives: []\n",
+			wantErr: `variants[0] "a": weight must be a positive integer`,
+		},
+		{
+			name:    "missing objectives",
+			data:    "variants:\n  - name: a\n",
+			wantErr: `variants[0] "a": objectives list is required`,
+		},
+		{
+			name:    "vars not a mapping",
+			data:    "variants:\n  - name: a\n    vars: [x]\n    objectives: []\n",
+			wantErr: `variants[0] "a": vars must be a mapping of names to values`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := SelectVariant([]byte(tt.data), "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
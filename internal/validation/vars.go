@@ -0,0 +1,136 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// varsKey is the top-level challenge.yaml block declaring template variables.
+const varsKey = "vars"
+
+// varRefPattern matches ${name} and ${name:-default} references, and the $${ escape
+// that produces a literal "${".
+var varRefPattern = regexp.MustCompile(`\$\$\{|\$\{([^}:]*)(:-([^}]*))?\}`)
+
+// varNamePattern restricts variable names to identifiers.
+var varNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExpandVars resolves the vars block of challenge.yaml: every ${name} in a scalar value
+// is replaced with the variable's value, or with default for ${name:-default} when the
+// variable is not declared, and $${ is an escaped "${". A reference that makes up a
+// whole unquoted value takes the type of the substituted text, so "replicas: ${n}"
+// stays an integer. The vars block is removed from the returned data.
+//
+// Files without a vars block are returned unchanged, so ${...} in existing
+// challenges (e.g. shell snippets in descriptions) keeps its literal meaning.
+func ExpandVars(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return data, nil //nolint:nilerr // malformed YAML is reported by the parsers
+	}
+	root := doc.Content[0]
+	varsNode := mappingValue(root, varsKey)
+	if varsNode == nil {
+		return data, nil
+	}
+
+	vars, err := decodeVars(varsNode)
+	if err != nil {
+		return nil, err
+	}
+	removeMappingKey(root, varsKey)
+	if err := expandNode(root, vars); err != nil {
+		return nil, err
+	}
+
+	expanded, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode challenge: %w", err)
+	}
+	return expanded, nil
+}
+
+func decodeVars(node *yaml.Node) (map[string]string, error) {
+	if node.Kind != yaml.MappingNode {
+		if node.Tag == "!!null" {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("vars must be a mapping of names to values")
+	}
+	vars := make(map[string]string, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case !varNamePattern.MatchString(name):
+			return nil, fmt.Errorf("vars: invalid variable name %q", name)
+		case value.Kind != yaml.ScalarNode:
+			return nil, fmt.Errorf("vars.%s: value must be a string, number or boolean", name)
+		}
+		vars[name] = value.Value
+	}
+	return vars, nil
+}
+
+// expandNode substitutes variables in the scalar values below node. Mapping keys are
+// left as they are.
+func expandNode(node *yaml.Node, vars map[string]string) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := expandNode(node.Content[i], vars); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if err := expandNode(item, vars); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "${") {
+			return nil
+		}
+		value, err := expandString(node.Value, vars)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		node.Value = value
+		if node.Style == 0 {
+			// Let the encoder resolve the type of the substituted text
+			node.Tag = ""
+		}
+	}
+	return nil
+}
+
+func expandString(s string, vars map[string]string) (string, error) {
+	var err error
+	expanded := varRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref == "$${" {
+			return "${"
+		}
+		m := varRefPattern.FindStringSubmatch(ref)
+		name, hasDefault, def := m[1], m[2] != "", m[3]
+		if !varNamePattern.MatchString(name) {
+			if err == nil {
+				err = fmt.Errorf("invalid variable reference %q", ref)
+			}
+			return ref
+		}
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		if hasDefault {
+			return def
+		}
+		if err == nil {
+			err = fmt.Errorf("undefined variable %q", name)
+		}
+		return ref
+	})
+	return expanded, err
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandVars(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "no vars block is left untouched",
+			data: "description: run ${HOME}/bin\n",
+			want: "description: run ${HOME}/bin\n",
+		},
+		{
+			name: "substitutes and removes the vars block",
+			data: "vars:\n  ns: shop\n  port: 8080\nurl: http://api.${ns}.svc:${port}/health\n",
+			want: "url: http://api.shop.svc:8080/health\n",
+		},
+		{
+			name: "whole plain values take the substituted type",
+			data: "vars:\n  replicas: 3\nreplicas: ${replicas}\nquoted: \"${replicas}\"\n",
+			want: "replicas: 3\nquoted: \"3\"\n",
+		},
+		{
+			name: "defaults and escapes",
+			data: "vars: {}\nimage: ${image:-nginx:1.27}\nscript: echo $${HOME}\n",
+			want: "image: nginx:1.27\nscript: echo ${HOME}\n",
+		},
+		{
+			name: "declared values win over defaults",
+			data: "vars:\n  image: redis\nimage: ${image:-nginx}\n",
+			want: "image: redis\n",
+		},
+		{
+			name: "keys are not expanded",
+			data: "vars:\n  x: y\nlabels:\n  ${x}: ${x}\n",
+			want: "labels:\n    ${x}: y\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandVars([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestExpandVarsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"undefined", "vars:\n  a: b\nx: ${c}\n", `line 3: undefined variable "c"`},
+		{"invalid reference", "vars:\n  a: b\nx: ${a-b}\n", `line 3: invalid variable reference "${a-b}"`},
+		{"invalid name", "vars:\n  a-b: c\n", `vars: invalid variable name "a-b"`},
+		{"non-scalar value", "vars:\n  a: [1, 2]\n", "vars.a: value must be a string, number or boolean"},
+		{"not a mapping", "vars: [a]\n", "vars must be a mapping of names to values"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExpandVars([]byte(tt.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParse_Vars(t *testing.T) {
+	yaml := `
+vars:
+  app: web
+  port: 8080
+objectives:
+  - key: reachable
+    type: endpoints
+    spec:
+      service: ${app}
+      readyEndpoints: ${ready:-2}
+  - key: scraped
+    type: metadata
+    spec:
+      target:
+        kind: Service
+        name: ${app}
+      annotations:
+        - key: prometheus.io/port
+          value: ${port}
+`
+	config, err := Parse([]byte(yaml))
+	require.NoError(t, err)
+	require.Len(t, config.Validations, 2)
+	assert.Equal(t, EndpointsSpec{Service: "web", Operator: "==", ReadyEndpoints: 2}, config.Validations[0].Spec)
+	assert.Equal(t, MetadataSpec{
+		Target:      Target{Kind: "Service", Name: "web"},
+		Annotations: []MetadataCheck{{Key: "prometheus.io/port", Value: "8080"}},
+	}, config.Validations[1].Spec)
+
+	_, err = Parse([]byte("vars: {}\nobjectives:\n  - key: a\n    type: endpoints\n    spec:\n      service: ${svc}\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `undefined variable "svc"`)
+}
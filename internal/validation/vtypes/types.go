@@ -4,6 +4,7 @@
 package vtypes
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/kubeasy-dev/registry/pkg/challenges"
@@ -14,11 +15,9 @@ import (
 type (
 	ValidationType    = challenges.ObjectiveType
 	Target            = challenges.Target
-	StatusSpec        = challenges.StatusSpec
 	StatusCheck       = challenges.StatusCheck
 	ConditionSpec     = challenges.ConditionSpec
 	ConditionCheck    = challenges.ConditionCheck
-	LogSpec           = challenges.LogSpec
 	MatchMode         = challenges.MatchMode
 	EventSpec         = challenges.EventSpec
 	ConnectivitySpec  = challenges.ConnectivitySpec
@@ -45,12 +44,55 @@ const (
 	TypeTriggered    = challenges.TypeTriggered
 )
 
+// CLI-specific validation types. The registry parser does not know them, so their
+// objectives are decoded by the CLI loader before the rest of the file is handed to
+// the registry.
+const (
+	// TypePlugin delegates an objective to an external validator binary.
+	TypePlugin ValidationType = "plugin"
+	// TypePromMetrics scrapes a Prometheus metrics endpoint from a probe pod and checks
+	// metric values.
+	TypePromMetrics ValidationType = "promMetrics"
+	// TypeEndpoints checks the ready endpoints of a Service through its EndpointSlices.
+	TypeEndpoints ValidationType = "endpoints"
+	// TypeNetworkPolicy evaluates the NetworkPolicies of the cluster for a source and a
+	// destination pod, without sending traffic.
+	TypeNetworkPolicy ValidationType = "networkPolicy"
+	// TypeNode checks node labels, taints, conditions and allocatable resources, and
+	// where pods were scheduled.
+	TypeNode ValidationType = "node"
+	// TypePodDisruptionBudget checks the PodDisruptionBudget of a workload.
+	TypePodDisruptionBudget ValidationType = "podDisruptionBudget"
+	// TypeProbes checks the liveness, readiness and startup probes of containers.
+	TypeProbes ValidationType = "probes"
+	// TypeImages checks container images against supply-chain rules (tags, registries,
+	// digests, pull policy).
+	TypeImages ValidationType = "images"
+	// TypeResources checks the resource requests and limits of containers.
+	TypeResources ValidationType = "resources"
+	// TypeMetadata checks the labels and annotations of any resource.
+	TypeMetadata ValidationType = "metadata"
+)
+
 // Connectivity mode constants.
 const (
 	ConnectivityModeExternal = "external"
 	ConnectivityModeInternal = "internal"
 )
 
+// Probe type constants for probes validation.
+const (
+	ProbeLiveness  = "liveness"
+	ProbeReadiness = "readiness"
+	ProbeStartup   = "startup"
+)
+
+// NetworkPolicy verdict constants.
+const (
+	NetworkPolicyAllowed = "allowed"
+	NetworkPolicyDenied  = "denied"
+)
+
 // MatchMode constants for log validation.
 const (
 	MatchModeAllOf = challenges.MatchModeAllOf
@@ -77,6 +119,10 @@ var (
 // ValidationConfig is the top-level structure holding all validations for a challenge.
 type ValidationConfig struct {
 	Validations []Validation `yaml:"objectives" json:"objectives"`
+	// Variant is the name of the selected variant of a challenge with variants.
+	Variant string `yaml:"-" json:"variant,omitempty"`
+	// ForbiddenActions are checked alongside the objectives when submitting.
+	ForbiddenActions []ForbiddenAction `yaml:"forbiddenActions,omitempty" json:"forbiddenActions,omitempty"`
 }
 
 // Validation is a single validation check ready for execution.
@@ -86,8 +132,59 @@ type Validation struct {
 	Description string         `yaml:"description" json:"description"`
 	Order       int            `yaml:"order" json:"order"`
 	Type        ValidationType `yaml:"type" json:"type"`
+	// Phase names the group the objective belongs to, e.g. "Investigate", "Fix" or
+	// "Verify". CLI-only: read by the CLI loader and used to group results.
+	Phase string `yaml:"phase,omitempty" json:"phase,omitempty"`
 	// Spec is the typed spec (e.g. StatusSpec, LogSpec). Populated by fromObjective().
 	Spec interface{} `yaml:"-" json:"-"`
+	// RawSpec is the spec as written in a challenge file, in YAML or JSON, for
+	// validations built outside the loader. When Spec is nil the executor decodes it
+	// on demand, the way the loader would.
+	RawSpec json.RawMessage `yaml:"-" json:"spec,omitempty"`
+}
+
+// StatusSpec validates arbitrary status fields using comparison operators.
+// It mirrors the registry spec and adds CLI-only options, which the registry parser
+// ignores and the CLI loader decodes itself.
+type StatusSpec struct {
+	Target Target        `yaml:"target" json:"target"`
+	Checks []StatusCheck `yaml:"checks" json:"checks"`
+	// LatestRevisionOnly evaluates the checks against every pod of the current revision
+	// of the targeted Deployment or StatefulSet instead of the workload itself, so pods
+	// of an older revision still running during a rollout are ignored.
+	LatestRevisionOnly bool `yaml:"latestRevisionOnly,omitempty" json:"latestRevisionOnly,omitempty"`
+	// Containers checks named containers of every pod of the target, including init
+	// and sidecar containers, which pod-level conditions do not tell apart.
+	Containers []ContainerStatusCheck `yaml:"containers,omitempty" json:"containers,omitempty"`
+}
+
+// ContainerStatusCheck asserts the state of one container, found by name among the
+// regular and init containers of a pod. Unset fields are not checked.
+type ContainerStatusCheck struct {
+	Name string `yaml:"name" json:"name"`
+	// Ready requires the container to be ready (true) or not ready (false).
+	Ready *bool `yaml:"ready,omitempty" json:"ready,omitempty"`
+	// Completed requires the container to have exited with code 0 (true), as init
+	// containers do, or not (false).
+	Completed *bool `yaml:"completed,omitempty" json:"completed,omitempty"`
+	// MaxRestarts is the highest restart count allowed.
+	MaxRestarts *int32 `yaml:"maxRestarts,omitempty" json:"maxRestarts,omitempty"`
+}
+
+// LogSpec searches container logs for expected strings.
+// It mirrors the registry spec and adds CLI-only options, which the registry parser
+// ignores and the CLI loader decodes itself.
+type LogSpec struct {
+	Target          Target    `yaml:"target" json:"target"`
+	Container       string    `yaml:"container,omitempty" json:"container,omitempty"`
+	ExpectedStrings []string  `yaml:"expectedStrings" json:"expectedStrings"`
+	SinceSeconds    int       `yaml:"sinceSeconds,omitempty" json:"sinceSeconds,omitempty"`
+	Previous        bool      `yaml:"previous,omitempty" json:"previous,omitempty"`
+	MatchMode       MatchMode `yaml:"matchMode,omitempty" json:"matchMode,omitempty"`
+	// TailLines is the number of lines read from the end of each container log.
+	TailLines int `yaml:"tailLines,omitempty" json:"tailLines,omitempty"`
+	// MaxBytes caps the log bytes read across all target pods.
+	MaxBytes int `yaml:"maxBytes,omitempty" json:"maxBytes,omitempty"`
 }
 
 // TriggeredSpec orchestrates a trigger action followed by CLI Validation validators.
@@ -98,12 +195,227 @@ type TriggeredSpec struct {
 	Then             []Validation  `yaml:"then" json:"then"`
 }
 
+// PluginSpec runs the external validator kubeasy-validator-<name> found on PATH.
+// Config is passed to the plugin verbatim.
+type PluginSpec struct {
+	Name           string                 `yaml:"name" json:"name"`
+	Config         map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	TimeoutSeconds int                    `yaml:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+}
+
+// PromMetricsSpec scrapes a Prometheus exposition-format endpoint from a probe pod in
+// the challenge namespace. The endpoint is URL, or Port and Path (default /metrics) on
+// the first running pod of Target.
+type PromMetricsSpec struct {
+	URL            string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Target         *Target           `yaml:"target,omitempty" json:"target,omitempty"`
+	Port           int               `yaml:"port,omitempty" json:"port,omitempty"`
+	Path           string            `yaml:"path,omitempty" json:"path,omitempty"`
+	Checks         []PromMetricCheck `yaml:"checks" json:"checks"`
+	TimeoutSeconds int               `yaml:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+}
+
+// PromMetricCheck compares a metric with a threshold, e.g. http_requests_total > 0.
+// Only series carrying all of Labels are considered; the values of several matching
+// series are summed. Histogram and summary metrics are read through their _sum and
+// _count series.
+type PromMetricCheck struct {
+	Metric   string            `yaml:"metric" json:"metric"`
+	Labels   map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Operator string            `yaml:"operator" json:"operator"`
+	Value    float64           `yaml:"value" json:"value"`
+}
+
+// EndpointsSpec compares the number of ready endpoints of a Service, counted from its
+// EndpointSlices, with ReadyEndpoints (e.g. ">= 2", or "== 0" for challenges where the
+// selector must not match anything).
+type EndpointsSpec struct {
+	Service        string `yaml:"service" json:"service"`
+	Operator       string `yaml:"operator,omitempty" json:"operator,omitempty"`
+	ReadyEndpoints int    `yaml:"readyEndpoints" json:"readyEndpoints"`
+}
+
+// NetworkPolicySpec expects traffic from From to To on Port to be allowed or denied by
+// the NetworkPolicies that select them. Protocol defaults to TCP.
+type NetworkPolicySpec struct {
+	From     NetworkPolicyPeer `yaml:"from" json:"from"`
+	To       NetworkPolicyPeer `yaml:"to" json:"to"`
+	Port     int               `yaml:"port" json:"port"`
+	Protocol string            `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Expect   string            `yaml:"expect" json:"expect"`
+}
+
+// NetworkPolicyPeer describes a pod by its labels. Namespace defaults to the challenge
+// namespace. The pod does not have to exist: policies are matched against Labels.
+type NetworkPolicyPeer struct {
+	Namespace string            `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels" json:"labels"`
+}
+
+// NodeSpec checks the nodes of the cluster for scheduling challenges. Labels, Taints,
+// Conditions and MinAllocatable apply to every node matching NodeSelector (all nodes
+// when empty). When Target is set, every pod of Target must be scheduled, on a node
+// carrying the ScheduledOn labels when they are given.
+type NodeSpec struct {
+	NodeSelector   map[string]string `yaml:"nodeSelector,omitempty" json:"nodeSelector,omitempty"`
+	Labels         map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Taints         []NodeTaint       `yaml:"taints,omitempty" json:"taints,omitempty"`
+	Conditions     []ConditionCheck  `yaml:"conditions,omitempty" json:"conditions,omitempty"`
+	MinAllocatable map[string]string `yaml:"minAllocatable,omitempty" json:"minAllocatable,omitempty"`
+	Target         *Target           `yaml:"target,omitempty" json:"target,omitempty"`
+	ScheduledOn    map[string]string `yaml:"scheduledOn,omitempty" json:"scheduledOn,omitempty"`
+}
+
+// NodeTaint matches a node taint by key and, when they are set, by value and effect.
+type NodeTaint struct {
+	Key    string `yaml:"key" json:"key"`
+	Value  string `yaml:"value,omitempty" json:"value,omitempty"`
+	Effect string `yaml:"effect,omitempty" json:"effect,omitempty"`
+}
+
+// PodDisruptionBudgetSpec checks a PodDisruptionBudget, given by Name or as the single
+// budget covering the pods of Target. MinAvailable and MaxUnavailable are a number or
+// a percentage and must equal the budget's; Operator (default ==) compares the
+// budget's current status.disruptionsAllowed with DisruptionsAllowed.
+type PodDisruptionBudgetSpec struct {
+	Name               string  `yaml:"name,omitempty" json:"name,omitempty"`
+	Target             *Target `yaml:"target,omitempty" json:"target,omitempty"`
+	MinAvailable       string  `yaml:"minAvailable,omitempty" json:"minAvailable,omitempty"`
+	MaxUnavailable     string  `yaml:"maxUnavailable,omitempty" json:"maxUnavailable,omitempty"`
+	Operator           string  `yaml:"operator,omitempty" json:"operator,omitempty"`
+	DisruptionsAllowed *int    `yaml:"disruptionsAllowed,omitempty" json:"disruptionsAllowed,omitempty"`
+}
+
+// ProbesSpec checks the probes of the containers of the pods of Target: every container,
+// or only Container when it is set.
+type ProbesSpec struct {
+	Target    Target       `yaml:"target" json:"target"`
+	Container string       `yaml:"container,omitempty" json:"container,omitempty"`
+	Probes    []ProbeCheck `yaml:"probes" json:"probes"`
+}
+
+// ProbeCheck expects a probe of Type (liveness, readiness or startup) to be defined.
+// Fields that are set must match the probe: Path its httpGet path, Port its httpGet,
+// tcpSocket or grpc port (a number or a container port name), and the timing fields
+// their values. With Exercise, the httpGet endpoint of a running pod is requested from
+// the probe pod and must answer like a passing kubelet probe (status 200-399).
+type ProbeCheck struct {
+	Type                string `yaml:"type" json:"type"`
+	Path                string `yaml:"path,omitempty" json:"path,omitempty"`
+	Port                string `yaml:"port,omitempty" json:"port,omitempty"`
+	InitialDelaySeconds *int32 `yaml:"initialDelaySeconds,omitempty" json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       *int32 `yaml:"periodSeconds,omitempty" json:"periodSeconds,omitempty"`
+	TimeoutSeconds      *int32 `yaml:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+	FailureThreshold    *int32 `yaml:"failureThreshold,omitempty" json:"failureThreshold,omitempty"`
+	SuccessThreshold    *int32 `yaml:"successThreshold,omitempty" json:"successThreshold,omitempty"`
+	Exercise            bool   `yaml:"exercise,omitempty" json:"exercise,omitempty"`
+}
+
+// ImagesSpec checks the images of the containers, init containers included, of the
+// pods of Target: every container, or only Container when it is set. Each rule that is
+// set applies to every checked container. AllowedRegistries entries are registry hosts
+// (docker.io) or repository prefixes (ghcr.io/kubeasy-dev); Docker Hub images such as
+// "nginx" are normalized to docker.io/library/nginx.
+type ImagesSpec struct {
+	Target            Target   `yaml:"target" json:"target"`
+	Container         string   `yaml:"container,omitempty" json:"container,omitempty"`
+	ForbidLatest      bool     `yaml:"forbidLatest,omitempty" json:"forbidLatest,omitempty"`
+	RequireDigest     bool     `yaml:"requireDigest,omitempty" json:"requireDigest,omitempty"`
+	AllowedRegistries []string `yaml:"allowedRegistries,omitempty" json:"allowedRegistries,omitempty"`
+	PullPolicy        string   `yaml:"pullPolicy,omitempty" json:"pullPolicy,omitempty"`
+}
+
+// ResourcesSpec checks the resources of the containers (init containers excluded) of the
+// pods of Target: every container, or only Container when it is set. Each resource
+// listed in Requests or Limits (cpu, memory, ephemeral-storage, ...) must be set, within
+// its bounds when they are given.
+type ResourcesSpec struct {
+	Target    Target                    `yaml:"target" json:"target"`
+	Container string                    `yaml:"container,omitempty" json:"container,omitempty"`
+	Requests  map[string]ResourceBounds `yaml:"requests,omitempty" json:"requests,omitempty"`
+	Limits    map[string]ResourceBounds `yaml:"limits,omitempty" json:"limits,omitempty"`
+}
+
+// ResourceBounds are inclusive quantity bounds, e.g. {min: 100m, max: "1"}. Either may
+// be omitted; with neither, the resource only has to be set.
+type ResourceBounds struct {
+	Min string `yaml:"min,omitempty" json:"min,omitempty"`
+	Max string `yaml:"max,omitempty" json:"max,omitempty"`
+}
+
+// MetadataSpec checks the labels and annotations of the resources of Target: the named
+// resource, or every resource matching its labelSelector.
+type MetadataSpec struct {
+	Target      Target          `yaml:"target" json:"target"`
+	Labels      []MetadataCheck `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations []MetadataCheck `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// MetadataCheck asserts a label or annotation. As in spec checks, Exists: false requires
+// Key to be absent. Otherwise Key must be set and, when given, equal Value or match the
+// regular expression Matches as a whole.
+type MetadataCheck struct {
+	Key     string `yaml:"key" json:"key"`
+	Exists  *bool  `yaml:"exists,omitempty" json:"exists,omitempty"`
+	Value   string `yaml:"value,omitempty" json:"value,omitempty"`
+	Matches string `yaml:"matches,omitempty" json:"matches,omitempty"`
+}
+
+// ForbiddenAction is an action that solves a challenge the wrong way, e.g. scaling the
+// broken Deployment to zero or deleting the NetworkPolicy under study. It is taken when
+// the cluster audit log records one of Verbs on Target, or when an Event matching Event
+// is reported for Target, after the challenge was started. CLI-only: read from the
+// top-level forbiddenActions list of challenge.yaml by the CLI loader.
+type ForbiddenAction struct {
+	Key         string `yaml:"key" json:"key"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Target selects the resource by kind and, optionally, name.
+	Target Target `yaml:"target" json:"target"`
+	// Verbs are API verbs such as delete, patch or update. "scale" matches updates of
+	// the scale subresource, as made by kubectl scale.
+	Verbs []string              `yaml:"verbs,omitempty" json:"verbs,omitempty"`
+	Event *ForbiddenActionEvent `yaml:"event,omitempty" json:"event,omitempty"`
+}
+
+// ForbiddenActionEvent matches the Events reported for the target of a forbidden action:
+// Reason must be equal and the regular expression MessagePattern, when set, must match
+// part of the message.
+type ForbiddenActionEvent struct {
+	Reason         string `yaml:"reason" json:"reason"`
+	MessagePattern string `yaml:"messagePattern,omitempty" json:"messagePattern,omitempty"`
+}
+
 // Result is the outcome of a single validation execution.
 type Result struct {
-	Key      string        `json:"key"`
-	Passed   bool          `json:"passed"`
-	Message  string        `json:"message"`
-	Duration time.Duration `json:"-"`
+	Key     string `json:"key"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+	// Skipped is set when the validation could not run in this cluster (e.g. a
+	// required add-on is missing). A skipped result never counts as passed.
+	Skipped bool `json:"skipped,omitempty"`
+	// Comparisons details the failed comparisons behind a failure, when the
+	// validation type reports them (status, spec, condition).
+	Comparisons []Comparison  `json:"comparisons,omitempty"`
+	Duration    time.Duration `json:"-"`
+}
+
+// Comparison is one failed comparison of an observed value with the expected one,
+// structured so it can be rendered as a diff.
+type Comparison struct {
+	// Field is the compared field path, or condition type.
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Expected interface{} `json:"expected,omitempty"`
+	// Observed is nil when the field was not found.
+	Observed  interface{} `json:"observed,omitempty"`
+	ObjectRef *ObjectRef  `json:"objectRef,omitempty"`
+}
+
+// ObjectRef names the object a Comparison was made on.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
 }
 
 // ChallengeYamlSpec represents the full structure of a challenge.yaml file.
@@ -120,6 +432,24 @@ type ChallengeYamlSpec struct {
 	Objectives         []Validation `yaml:"objectives"`
 }
 
+// ChallengeSpec is a fully parsed challenge.yaml: display metadata plus the
+// validations ready for execution. It lets local-only challenges be described
+// and listed without the API, keeping challenge.yaml the single source of truth.
+type ChallengeSpec struct {
+	Slug               string       `json:"slug"`
+	Title              string       `json:"title"`
+	Description        string       `json:"description"`
+	Theme              string       `json:"theme"`
+	Difficulty         string       `json:"difficulty"`
+	Type               string       `json:"type"`
+	EstimatedTime      int          `json:"estimatedTime"`
+	InitialSituation   string       `json:"initialSituation"`
+	MinRequiredVersion string       `json:"minRequiredVersion,omitempty"`
+	Validations        []Validation `json:"objectives"`
+	// ForbiddenActions are the actions the challenge forbids.
+	ForbiddenActions []ForbiddenAction `json:"forbiddenActions,omitempty"`
+}
+
 // TypeRegistration associates a ValidationType with its spec struct for schema generation.
 type TypeRegistration struct {
 	Type     ValidationType
@@ -139,4 +469,14 @@ var RegisteredTypes = []TypeRegistration{
 	{TypeRbac, RbacSpec{}, "RbacSpec"},
 	{TypeSpec, SpecSpec{}, "SpecSpec"},
 	{TypeTriggered, TriggeredSpec{}, "TriggeredSpec"},
+	{TypePlugin, PluginSpec{}, "PluginSpec"},
+	{TypePromMetrics, PromMetricsSpec{}, "PromMetricsSpec"},
+	{TypeEndpoints, EndpointsSpec{}, "EndpointsSpec"},
+	{TypeNetworkPolicy, NetworkPolicySpec{}, "NetworkPolicySpec"},
+	{TypeNode, NodeSpec{}, "NodeSpec"},
+	{TypePodDisruptionBudget, PodDisruptionBudgetSpec{}, "PodDisruptionBudgetSpec"},
+	{TypeProbes, ProbesSpec{}, "ProbesSpec"},
+	{TypeImages, ImagesSpec{}, "ImagesSpec"},
+	{TypeResources, ResourcesSpec{}, "ResourcesSpec"},
+	{TypeMetadata, MetadataSpec{}, "MetadataSpec"},
 }
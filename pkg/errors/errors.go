@@ -0,0 +1,83 @@
+// Package errors exposes the stable error codes of the CLI, such as KUBE_CTX_NOT_FOUND
+// or API_UNAUTHORIZED, to SDK users: CodeOf finds the code of an error returned by
+// pkg/sdk, so callers can key off a code instead of a message. The implementation
+// lives in internal/errors; everything here is an alias of it.
+package errors
+
+import (
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+)
+
+// Types shared with the CLI.
+type (
+	Code  = kerrors.Code
+	Coder = kerrors.Coder
+	Error = kerrors.Error
+	JSON  = kerrors.JSON
+)
+
+// Codes. CodeUnknown is reported for errors no package gave a code to.
+const (
+	CodeUnknown = kerrors.CodeUnknown
+
+	// Local input and environment
+	CodeInvalidInput         = kerrors.CodeInvalidInput
+	CodeConfirmationRequired = kerrors.CodeConfirmationRequired
+	CodeInterrupted          = kerrors.CodeInterrupted
+	CodeTimeout              = kerrors.CodeTimeout
+
+	// Cluster
+	CodeKubeContextNotFound = kerrors.CodeKubeContextNotFound
+	CodeKubeClient          = kerrors.CodeKubeClient
+	CodeClusterNotKubeasy   = kerrors.CodeClusterNotKubeasy
+
+	// Challenge deployment
+	CodeDeployTimeout        = kerrors.CodeDeployTimeout
+	CodeManifestsUnavailable = kerrors.CodeManifestsUnavailable
+
+	// Kubeasy API
+	CodeAPIUnauthorized = kerrors.CodeAPIUnauthorized
+	CodeAPINotFound     = kerrors.CodeAPINotFound
+	CodeAPIUnreachable  = kerrors.CodeAPIUnreachable
+	CodeAPIUnavailable  = kerrors.CodeAPIUnavailable
+	CodeAPIError        = kerrors.CodeAPIError
+
+	// Challenge lifecycle
+	CodeChallengeNotStarted = kerrors.CodeChallengeNotStarted
+	CodeChallengeCompleted  = kerrors.CodeChallengeCompleted
+	CodeNoAttemptsLeft      = kerrors.CodeNoAttemptsLeft
+	CodeSubmissionCooldown  = kerrors.CodeSubmissionCooldown
+	CodeTimeLimitExpired    = kerrors.CodeTimeLimitExpired
+	CodeNoValidations       = kerrors.CodeNoValidations
+	CodeValidationFailed    = kerrors.CodeValidationFailed
+)
+
+// Exit statuses of the CLI, by family of codes.
+const (
+	ExitFailure     = kerrors.ExitFailure
+	ExitUsage       = kerrors.ExitUsage
+	ExitCluster     = kerrors.ExitCluster
+	ExitAPI         = kerrors.ExitAPI
+	ExitAuth        = kerrors.ExitAuth
+	ExitDeploy      = kerrors.ExitDeploy
+	ExitChallenge   = kerrors.ExitChallenge
+	ExitValidation  = kerrors.ExitValidation
+	ExitInterrupted = kerrors.ExitInterrupted
+)
+
+// New returns an error with code and a message formatted like fmt.Errorf.
+func New(code Code, format string, args ...any) error {
+	return kerrors.New(code, format, args...)
+}
+
+// Wrap gives err the code, unless it already has one.
+func Wrap(code Code, err error) error { return kerrors.Wrap(code, err) }
+
+// CodeOf returns the first code found in the chain of err.
+func CodeOf(err error) Code { return kerrors.CodeOf(err) }
+
+// ExitCode returns the exit status of the CLI for err.
+func ExitCode(err error) int { return kerrors.ExitCode(err) }
+
+// ToJSON returns the JSON representation of err.
+func ToJSON(err error) JSON { return kerrors.ToJSON(err) }
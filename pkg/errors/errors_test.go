@@ -0,0 +1,15 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOf_SDKErrors(t *testing.T) {
+	assert.Equal(t, errors.CodeChallengeNotStarted, errors.CodeOf(fmt.Errorf("submit: %w", sdk.ErrNotStarted)))
+	assert.Equal(t, errors.ExitChallenge, errors.ExitCode(sdk.ErrNoAttemptsLeft))
+}
@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+)
+
+// Reset deletes the resources of a challenge, resets its progress and submissions on
+// the server and clears its local state.
+func (c *Client) Reset(ctx context.Context, slug string) error {
+	return steps.Runner{OnEvent: c.report.step}.Run(ctx, c.ResetPlan(slug))
+}
+
+// ResetPlan returns the steps of Reset, e.g. to list them before running them.
+func (c *Client) ResetPlan(slug string) []Step {
+	return []Step{
+		c.CleanupStep(slug),
+		{
+			Name:    "reset-progress",
+			Scope:   steps.ScopeAPI,
+			Actions: []string{fmt.Sprintf("Reset progress and submissions of challenge '%s' on the server", slug)},
+			Run: func(ctx context.Context) error {
+				err := c.report.task("Resetting challenge progress on server", func() error {
+					result, err := c.api.ResetChallenge(ctx, slug)
+					if err != nil {
+						return err
+					}
+					if !result.Success {
+						return fmt.Errorf("reset failed: %s", result.Message)
+					}
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("failed to reset challenge progress: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "clear-local-state",
+			Scope: steps.ScopeLocal,
+			Actions: []string{
+				fmt.Sprintf("Remove local state %s", audit.GetStateDir(slug)),
+				fmt.Sprintf("Remove challenge '%s' from %s", slug, state.GetPath()),
+			},
+			Run: func(ctx context.Context) error {
+				if err := audit.ClearState(slug); err != nil {
+					logger.Debug("Could not clear audit state: %v", err)
+				}
+				err := state.Update(func(s *state.State) error {
+					delete(s.Challenges, slug)
+					return nil
+				})
+				if err != nil {
+					logger.Debug("Could not clear local state: %v", err)
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// CleanupStep deletes the namespace of a challenge and restores the kubectl context.
+func (c *Client) CleanupStep(slug string) Step {
+	var actions []string
+	for _, step := range deployer.PlanCleanup(nil, slug) {
+		actions = append(actions, step.Description)
+	}
+	return Step{
+		Name:    "cleanup",
+		Scope:   steps.ScopeCluster,
+		Actions: actions,
+		Run: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			defer cancel()
+
+			cluster, err := c.guardedCluster(ctx)
+			if err != nil {
+				return err
+			}
+			err = c.report.task("Deleting challenge resources", func() error {
+				return deployer.CleanupChallenge(ctx, cluster.Clientset, slug)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete challenge resources: %w", err)
+			}
+			c.report.info("Challenge resources deleted")
+			return nil
+		},
+	}
+}
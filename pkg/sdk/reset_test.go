@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestResetPlan_CoversClusterAPIAndLocalState(t *testing.T) {
+	plan := New(Config{}).ResetPlan("pod-evicted")
+	var scopes []string
+	for _, step := range plan {
+		scopes = append(scopes, step.Scope)
+	}
+	assert.Equal(t, []string{steps.ScopeCluster, steps.ScopeAPI, steps.ScopeLocal}, scopes)
+}
+
+func TestReset(t *testing.T) {
+	var reset string
+	client := newTestClient(t, API{
+		ResetChallenge: func(ctx context.Context, slug string) (*ResetResponse, error) {
+			reset = slug
+			return &ResetResponse{Success: true}, nil
+		},
+	})
+	require.NoError(t, audit.SaveTimestamp("pod-evicted"))
+	writeKubeconfig(t)
+
+	require.NoError(t, client.Reset(context.Background(), "pod-evicted"))
+	assert.Equal(t, "pod-evicted", reset)
+	_, err := audit.LoadTimestamp("pod-evicted")
+	assert.Error(t, err, "the local state is cleared")
+}
+
+// writeKubeconfig writes a kubeconfig with the kubeasy context under $HOME, whose
+// namespace cleanup restores.
+func writeKubeconfig(t *testing.T) {
+	t.Helper()
+	config := clientcmdapi.NewConfig()
+	config.Clusters["kind-kubeasy"] = &clientcmdapi.Cluster{Server: "https://localhost:6443"}
+	config.Contexts[constants.KubeasyClusterContext] = &clientcmdapi.Context{Cluster: "kind-kubeasy", Namespace: "pod-evicted"}
+	config.CurrentContext = constants.KubeasyClusterContext
+	require.NoError(t, clientcmd.WriteToFile(*config, filepath.Join(os.Getenv("HOME"), ".kube", "config")))
+}
+
+func TestReset_GuardRefusesCluster(t *testing.T) {
+	refused := errors.New("not a kubeasy cluster")
+	client := New(Config{
+		Cluster:      newTestClient(t, API{}).cluster,
+		GuardCluster: func(ctx context.Context, _ kubernetes.Interface) error { return refused },
+		API: API{ResetChallenge: func(ctx context.Context, slug string) (*ResetResponse, error) {
+			t.Fatal("progress must not be reset when the cluster is refused")
+			return nil, nil
+		}},
+	})
+
+	assert.ErrorIs(t, client.Reset(context.Background(), "pod-evicted"), refused)
+}
@@ -0,0 +1,260 @@
+// Package sdk runs the challenge flows of the kubeasy CLI (start, verify, submit and
+// reset) for programmatic use, e.g. by grading servers, web backends or tests. The
+// cobra commands are thin wrappers around it.
+//
+// A Client holds everything a flow needs: the Kubeasy API, the cluster clients and
+// how to report progress. Nothing is read from command-line flags and nothing is
+// printed; progress goes to the Reporter and the outcome of a flow is returned.
+// The flows still record their local bookkeeping (start time, last results,
+// submissions) under ~/.kubeasy, like the CLI does.
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/keystore"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Types shared with the CLI.
+type (
+	Challenge        = api.ChallengeEntity
+	Progress         = api.ChallengeStatusResponse
+	StartResponse    = api.ChallengeStartResponse
+	SubmitRequest    = api.ChallengeSubmitRequest
+	SubmitResponse   = api.ChallengeSubmitResponse
+	ResetResponse    = api.ChallengeResetResponse
+	Validation       = validation.Validation
+	ValidationConfig = validation.ValidationConfig
+	Result           = validation.Result
+	ProgressEvent    = validation.ProgressEvent
+	TimeLimit        = audit.TimeLimit
+	Step             = steps.Step
+	StepEvent        = steps.Event
+	DeployError      = deployer.DeployError
+	Diagnosis        = deployer.Diagnosis
+	FailureCause     = deployer.FailureCause
+	DeployBudgets    = deployer.DeployBudgets
+	NetworkIsolation = kube.NetworkIsolation
+	ResourceStatus   = kube.ResourceStatus
+	ResourceHealth   = kube.ResourceHealth
+	CredentialStore  = keystore.CredentialStore
+	StorageType      = keystore.StorageType
+)
+
+// API is the part of the Kubeasy API the flows call. Fields left nil are filled from
+// DefaultAPI by New.
+type API struct {
+	GetChallenge    func(ctx context.Context, slug string) (*Challenge, error)
+	GetProgress     func(ctx context.Context, slug string) (*Progress, error)
+	StartChallenge  func(ctx context.Context, slug string) (*StartResponse, error)
+	SubmitChallenge func(ctx context.Context, slug string, req SubmitRequest) (*SubmitResponse, error)
+	ResetChallenge  func(ctx context.Context, slug string) (*ResetResponse, error)
+	// UserID returns the ID of the user, which selects their variant of challenges
+	// with variants.
+	UserID func(ctx context.Context) (string, error)
+}
+
+// SetCredentialStore sets where DefaultAPI reads the API key from, e.g. a store
+// backed by a secret manager. The default is the store of 'kubeasy login': the
+// KUBEASY_TOKEN environment variable, the system keyring, then the credentials file.
+func SetCredentialStore(store CredentialStore) { api.SetCredentialStore(store) }
+
+// DefaultAPI calls the Kubeasy API with the credentials of SetCredentialStore.
+func DefaultAPI() API {
+	return API{
+		GetChallenge:    api.GetChallengeBySlug,
+		GetProgress:     api.GetChallengeStatus,
+		StartChallenge:  api.StartChallengeWithResponse,
+		SubmitChallenge: api.SubmitChallenge,
+		ResetChallenge:  api.ResetChallenge,
+		UserID: func(ctx context.Context) (string, error) {
+			profile, err := api.GetProfile(ctx)
+			if err != nil {
+				return "", err
+			}
+			return profile.ID, nil
+		},
+	}
+}
+
+func (a API) withDefaults() API {
+	d := DefaultAPI()
+	if a.GetChallenge == nil {
+		a.GetChallenge = d.GetChallenge
+	}
+	if a.GetProgress == nil {
+		a.GetProgress = d.GetProgress
+	}
+	if a.StartChallenge == nil {
+		a.StartChallenge = d.StartChallenge
+	}
+	if a.SubmitChallenge == nil {
+		a.SubmitChallenge = d.SubmitChallenge
+	}
+	if a.ResetChallenge == nil {
+		a.ResetChallenge = d.ResetChallenge
+	}
+	if a.UserID == nil {
+		a.UserID = d.UserID
+	}
+	return a
+}
+
+// Cluster holds the clients of the cluster challenges run in.
+type Cluster struct {
+	Clientset     kubernetes.Interface
+	DynamicClient dynamic.Interface
+	RestConfig    *rest.Config
+}
+
+// ClusterFromKubeconfig connects to the kubeasy context of the user's kubeconfig.
+func ClusterFromKubeconfig() (*Cluster, error) {
+	clientset, err := kube.GetKubernetesClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+	dynamicClient, err := kube.GetDynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+	restConfig, err := kube.GetRestConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get REST config: %w", err)
+	}
+	return &Cluster{Clientset: clientset, DynamicClient: dynamicClient, RestConfig: restConfig}, nil
+}
+
+// Reporter receives the progress of the flows. Nil fields report nothing.
+type Reporter struct {
+	// Task wraps a long-running operation, e.g. to show a spinner titled title.
+	Task func(title string, fn func() error) error
+	// Info and Warn receive notices that do not stop the flow.
+	Info func(msg string)
+	Warn func(msg string)
+	// Step receives the progress of multi-step flows (start, reset).
+	Step func(StepEvent)
+	// Ready receives, every few seconds while a deploy waits for the challenge
+	// resources, the last status of each of them.
+	Ready func(namespace string, statuses []ResourceStatus)
+}
+
+func (r Reporter) task(title string, fn func() error) error {
+	if r.Task == nil {
+		return fn()
+	}
+	return r.Task(title, fn)
+}
+
+func (r Reporter) info(msg string) {
+	if r.Info != nil {
+		r.Info(msg)
+	}
+}
+
+func (r Reporter) warn(msg string) {
+	if r.Warn != nil {
+		r.Warn(msg)
+	}
+}
+
+func (r Reporter) step(e StepEvent) {
+	if r.Step != nil {
+		r.Step(e)
+	}
+}
+
+// Config configures a Client.
+type Config struct {
+	API API
+	// Cluster holds the clients of the cluster. When nil, Connect is called on first
+	// use, or ClusterFromKubeconfig when Connect is nil too.
+	Cluster *Cluster
+	Connect func() (*Cluster, error)
+	// LoadValidations loads the validations of a challenge for a user. Nil loads them
+	// like the CLI: from a local challenge directory or the challenges repository.
+	LoadValidations func(ctx context.Context, slug, userID string) (*ValidationConfig, error)
+	// GuardCluster is called before a flow changes the cluster and can refuse it. Nil
+	// refuses clusters without the marker installed by 'kubeasy setup'.
+	GuardCluster func(ctx context.Context, clientset kubernetes.Interface) error
+	// Deploy applies the manifests of a challenge in its namespace and waits for them
+	// to be ready, returning a hash of what was applied. Nil deploys them from the
+	// challenges registry.
+	Deploy   func(ctx context.Context, cluster *Cluster, slug string) (string, error)
+	Reporter Reporter
+}
+
+// Client runs the challenge flows. It is safe to reuse across flows but not for
+// concurrent flows on the same challenge.
+type Client struct {
+	api             API
+	cluster         *Cluster
+	connect         func() (*Cluster, error)
+	loadValidations func(ctx context.Context, slug, userID string) (*ValidationConfig, error)
+	guardCluster    func(ctx context.Context, clientset kubernetes.Interface) error
+	deploy          func(ctx context.Context, cluster *Cluster, slug string) (string, error)
+	report          Reporter
+}
+
+// New returns a Client for cfg.
+func New(cfg Config) *Client {
+	c := &Client{
+		api:             cfg.API.withDefaults(),
+		cluster:         cfg.Cluster,
+		connect:         cfg.Connect,
+		loadValidations: cfg.LoadValidations,
+		guardCluster:    cfg.GuardCluster,
+		deploy:          cfg.Deploy,
+		report:          cfg.Reporter,
+	}
+	if c.connect == nil {
+		c.connect = ClusterFromKubeconfig
+	}
+	if c.loadValidations == nil {
+		c.loadValidations = validation.LoadForChallenge
+	}
+	if c.guardCluster == nil {
+		c.guardCluster = kube.VerifyClusterMarker
+	}
+	if c.deploy == nil {
+		c.deploy = deployFromRegistry
+	}
+	return c
+}
+
+func deployFromRegistry(ctx context.Context, cluster *Cluster, slug string) (string, error) {
+	return deployer.DeployChallengeFromRegistry(ctx, cluster.Clientset, cluster.DynamicClient, slug)
+}
+
+// Cluster returns the cluster clients, connecting on first use.
+func (c *Client) Cluster() (*Cluster, error) {
+	if c.cluster == nil {
+		cluster, err := c.connect()
+		if err != nil {
+			return nil, err
+		}
+		c.cluster = cluster
+	}
+	return c.cluster, nil
+}
+
+// guardedCluster returns the cluster clients once GuardCluster allowed changing it.
+func (c *Client) guardedCluster(ctx context.Context) (*Cluster, error) {
+	cluster, err := c.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.guardCluster(ctx, cluster.Clientset); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
@@ -0,0 +1,46 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/engine"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// testValidationType passes when the spec is true.
+const testValidationType validation.ValidationType = "sdk-test"
+
+func init() {
+	engine.Register(testValidationType, engine.ValidatorFunc(func(_ context.Context, _ engine.Env, spec interface{}) validation.Result {
+		passed, _ := spec.(bool)
+		return validation.Result{Passed: passed, Message: "checked"}
+	}))
+}
+
+// newTestClient returns a client on a fake cluster holding the namespace of
+// pod-evicted, with the given validations and no API: tests set the API calls they expect.
+func newTestClient(t *testing.T, apiFuncs API, validations ...Validation) *Client {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	apiFuncs.UserID = func(ctx context.Context) (string, error) { return "user-1", nil }
+	return New(Config{
+		API: apiFuncs,
+		Cluster: &Cluster{
+			Clientset:     fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pod-evicted", UID: "uid-1"}}),
+			DynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+			RestConfig:    &rest.Config{},
+		},
+		LoadValidations: func(ctx context.Context, slug, userID string) (*ValidationConfig, error) {
+			return &ValidationConfig{Validations: validations}, nil
+		},
+		GuardCluster: func(ctx context.Context, _ kubernetes.Interface) error { return nil },
+	})
+}
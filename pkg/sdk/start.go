@@ -0,0 +1,416 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/constants"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/deployer"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/semver"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/steps"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StartMode tells which steps of a start still have to run.
+type StartMode int
+
+const (
+	StartFresh          StartMode = iota // deploy the environment and register progress
+	StartResumeDeploy                    // progress registered, environment missing or half-deployed
+	StartResumeFinish                    // deployed and registered, local bookkeeping interrupted
+	StartAlreadyStarted                  // nothing left to do
+	StartAlreadyDone                     // the challenge is completed, nothing was started
+)
+
+// Steps of a start. The running step is recorded in the local state, so an
+// interrupted start can be resumed.
+const (
+	StartStepNamespace = "namespace"
+	StartStepDeploy    = "deploy"
+	StartStepContext   = "context"
+	StartStepRegister  = "register"
+	StartStepRecord    = "record"
+)
+
+// StartOptions configure Start.
+type StartOptions struct {
+	// TimeLimit time-boxes the attempt; the zero value means untimed.
+	TimeLimit TimeLimit
+	// KeepPartial keeps what a failed start created, so the next start resumes
+	// where it stopped, instead of rolling it back.
+	KeepPartial bool
+	// DeployBudgets bounds the phases of the deploy; zero fields use the defaults.
+	DeployBudgets DeployBudgets
+	// NetworkIsolation is the baseline of NetworkPolicies installed in the namespace
+	// before the manifests are applied; empty installs none.
+	NetworkIsolation NetworkIsolation
+}
+
+// Started is the outcome of Start.
+type Started struct {
+	Challenge *Challenge
+	// Mode is how the start went: StartAlreadyStarted and StartAlreadyDone mean
+	// nothing was changed.
+	Mode StartMode
+	// Inventory lists what was deployed in the namespace with its health, once the
+	// start completed. It is empty when the namespace could not be inspected.
+	Inventory []ResourceStatus
+}
+
+// Start deploys a challenge in its namespace and registers its progress. A challenge
+// whose previous start was interrupted is resumed; a completed or already running
+// challenge is left untouched and reported by the Mode of the result.
+func (c *Client) Start(ctx context.Context, slug string, opts StartOptions) (*Started, error) {
+	var challenge *Challenge
+	err := c.report.task("Fetching challenge details", func() error {
+		var err error
+		challenge, err = c.api.GetChallenge(ctx, slug)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch challenge: %w", err)
+	}
+	c.report.info(fmt.Sprintf("Challenge: %s", challenge.Title))
+
+	var progress *Progress
+	err = c.report.task("Checking challenge progress", func() error {
+		var err error
+		progress, err = c.api.GetProgress(ctx, slug)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch challenge progress: %w", err)
+	}
+
+	started := &Started{Challenge: challenge, Mode: StartFresh}
+	if progress != nil && progress.Status == "completed" {
+		started.Mode = StartAlreadyDone
+		return started, nil
+	}
+	if progress != nil && progress.Status == "in_progress" {
+		started.Mode = c.detectStartMode(ctx, slug)
+	}
+	switch started.Mode {
+	case StartAlreadyStarted:
+		return started, nil
+	case StartResumeDeploy:
+		c.report.warn("Challenge already registered but its environment is incomplete: resuming deployment")
+	case StartResumeFinish:
+		c.report.info("Challenge environment already deployed: finishing the interrupted start")
+	}
+
+	if err := CheckMinRequiredVersion(ctx, slug, constants.Version); err != nil {
+		return nil, err
+	}
+
+	runner := steps.Runner{
+		Rollback: !opts.KeepPartial,
+		OnEvent: func(e steps.Event) {
+			if e.Status == steps.StatusStarted {
+				markStartStep(slug, e.Step)
+			}
+			c.report.step(e)
+		},
+	}
+	ctx = deployer.WithDeployBudgets(ctx, opts.DeployBudgets)
+	if err := runner.Run(ctx, c.StartPlan(slug, started.Mode, progress, opts)); err != nil {
+		var stepErr *steps.Error
+		if !opts.KeepPartial && errors.As(err, &stepErr) && stepErr.RollbackErr == nil {
+			// Nothing partial is left behind, so the next start begins from scratch
+			markStartStep(slug, "")
+		}
+		return nil, err
+	}
+	markStartStep(slug, "")
+	started.Inventory = c.inventory(ctx, slug)
+	return started, nil
+}
+
+// inventory lists what is deployed in the namespace of a challenge. It is best effort:
+// errors are only logged.
+func (c *Client) inventory(ctx context.Context, slug string) []ResourceStatus {
+	cluster, err := c.Cluster()
+	if err != nil {
+		logger.Debug("Could not connect to the cluster: %v", err)
+		return nil
+	}
+	statuses, err := kube.InventoryNamespace(ctx, cluster.Clientset, slug)
+	if err != nil {
+		logger.Debug("Could not list the resources of %s: %v", slug, err)
+	}
+	return statuses
+}
+
+// detectStartMode decides how to continue a challenge the API already reports in
+// progress, from the step a previous start was interrupted at and whether the
+// namespace still exists.
+func (c *Client) detectStartMode(ctx context.Context, slug string) StartMode {
+	interrupted := ""
+	if s, err := state.Load(); err != nil {
+		logger.Debug("Could not load local state: %v", err)
+	} else if ch, ok := s.Challenges[slug]; ok {
+		interrupted = ch.StartStep
+	}
+
+	exists, err := c.namespaceExists(ctx, slug)
+	if err != nil {
+		// Without the cluster we cannot tell: never redeploy over the learner's work.
+		logger.Debug("Could not check namespace %s: %v", slug, err)
+		exists = true
+	}
+	return resolveStartMode(exists, interrupted)
+}
+
+func resolveStartMode(namespaceExists bool, interruptedStep string) StartMode {
+	switch {
+	case !namespaceExists:
+		return StartResumeDeploy
+	case interruptedStep == StartStepNamespace, interruptedStep == StartStepDeploy, interruptedStep == StartStepContext:
+		return StartResumeDeploy
+	case interruptedStep == StartStepRegister, interruptedStep == StartStepRecord:
+		return StartResumeFinish
+	default:
+		return StartAlreadyStarted
+	}
+}
+
+func (c *Client) namespaceExists(ctx context.Context, namespace string) (bool, error) {
+	cluster, err := c.Cluster()
+	if err != nil {
+		return false, err
+	}
+	_, err = cluster.Clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// markStartStep records the step start is about to run; "" marks the start complete.
+func markStartStep(slug, step string) {
+	err := state.Update(func(s *state.State) error {
+		s.Challenge(slug).StartStep = step
+		return nil
+	})
+	if err != nil {
+		logger.Debug("Could not record start step for %s: %v", slug, err)
+	}
+}
+
+// StartPlan returns the steps of a start: create the namespace (with the validator
+// ServiceAccount that exec and log validations run as, and the NetworkPolicy
+// baseline of StartOptions.NetworkIsolation), apply the challenge
+// manifests, point the kubectl context at the namespace, register progress on the
+// API and record the start locally. The cluster steps are idempotent, so they can run
+// again after an interrupted start; steps already done for mode are skipped.
+func (c *Client) StartPlan(slug string, mode StartMode, progress *Progress, opts StartOptions) []Step {
+	var (
+		cluster          *Cluster
+		createdNamespace bool
+		manifestsHash    string
+		startedAt        = time.Now()
+	)
+	if progress != nil {
+		// Resumed starts keep the start time the API already knows
+		if ts, ok := parseAPITime(progress.StartedAt); ok {
+			startedAt = ts
+		}
+	}
+	deployed := func(ctx context.Context) (bool, error) { return mode == StartResumeFinish, nil }
+	isolated := opts.NetworkIsolation != "" && opts.NetworkIsolation != kube.NetworkIsolationNone
+	namespaceActions := []string{
+		fmt.Sprintf("Create namespace '%s'", slug),
+		fmt.Sprintf("Create the validation ServiceAccount '%s'", kube.ValidatorServiceAccountName),
+	}
+	if isolated {
+		namespaceActions = append(namespaceActions, fmt.Sprintf("Install the '%s' NetworkPolicy baseline", opts.NetworkIsolation))
+	}
+	registered := func(ctx context.Context) (bool, error) { return mode != StartFresh, nil }
+
+	return []Step{
+		{
+			Name:    StartStepNamespace,
+			Scope:   steps.ScopeCluster,
+			Actions: namespaceActions,
+			Done:    deployed,
+			Run: func(ctx context.Context) error {
+				var err error
+				if cluster, err = c.guardedCluster(ctx); err != nil {
+					return err
+				}
+				_, err = cluster.Clientset.CoreV1().Namespaces().Get(ctx, slug, metav1.GetOptions{})
+				createdNamespace = apierrors.IsNotFound(err)
+
+				err = c.report.task("Creating namespace", func() error {
+					return kube.CreateNamespace(ctx, cluster.Clientset, slug)
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create namespace: %w", err)
+				}
+				if err := kube.EnsureValidatorSandbox(ctx, cluster.Clientset, slug); err != nil {
+					return err
+				}
+				if isolated {
+					if err := kube.EnsureNetworkIsolation(ctx, cluster.Clientset, slug, opts.NetworkIsolation); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Rollback: func(ctx context.Context) error {
+				// Never delete a namespace this start did not create
+				if !createdNamespace {
+					return nil
+				}
+				return c.report.task(fmt.Sprintf("Deleting namespace '%s'", slug), func() error {
+					return deployer.CleanupChallenge(ctx, cluster.Clientset, slug)
+				})
+			},
+		},
+		{
+			Name:    StartStepDeploy,
+			Scope:   steps.ScopeCluster,
+			Actions: []string{fmt.Sprintf("Apply the manifests of challenge '%s'", slug)},
+			Done:    deployed,
+			Run: func(ctx context.Context) error {
+				if c.report.Ready != nil {
+					ctx = kube.WithReadyProgress(ctx, c.report.Ready)
+				}
+				err := c.report.task("Deploying challenge", func() error {
+					var err error
+					manifestsHash, err = c.deploy(ctx, cluster, slug)
+					return err
+				})
+				if err != nil {
+					return fmt.Errorf("failed to deploy challenge: %w", deployer.Diagnosed(ctx, cluster.Clientset, slug, err))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    StartStepContext,
+			Scope:   steps.ScopeLocal,
+			Actions: []string{fmt.Sprintf("Switch kubectl context '%s' to namespace '%s'", constants.KubeasyClusterContext, slug)},
+			Done:    deployed,
+			Run: func(ctx context.Context) error {
+				// Not fatal: the learner can still pass -n explicitly
+				if err := kube.SetNamespaceForContext(constants.KubeasyClusterContext, slug); err != nil {
+					logger.Debug("Failed to set namespace for context: %v", err)
+					c.report.warn("Could not configure kubectl context namespace")
+				} else {
+					c.report.info("Kubectl context configured")
+				}
+				return nil
+			},
+			Rollback: func(ctx context.Context) error {
+				return kube.SetNamespaceForContext(constants.KubeasyClusterContext, "default")
+			},
+		},
+		{
+			Name:    StartStepRegister,
+			Scope:   steps.ScopeAPI,
+			Actions: []string{fmt.Sprintf("Register challenge '%s' as started", slug)},
+			Done:    registered,
+			Run: func(ctx context.Context) error {
+				var started *StartResponse
+				err := c.report.task("Registering challenge progress", func() error {
+					var err error
+					started, err = c.api.StartChallenge(ctx, slug)
+					return err
+				})
+				if err != nil {
+					return fmt.Errorf("failed to start challenge: %w", err)
+				}
+				if started != nil {
+					if ts, ok := parseAPITime(&started.StartedAt); ok {
+						startedAt = ts
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:    StartStepRecord,
+			Scope:   steps.ScopeLocal,
+			Actions: []string{fmt.Sprintf("Record the start time and the deployed environment in %s", audit.GetStateDir(slug))},
+			Run: func(ctx context.Context) error {
+				if err := audit.SaveTimestamp(slug); err != nil {
+					logger.Debug("Could not save start timestamp: %v", err)
+				}
+				if err := audit.SaveStartTime(slug, startedAt); err != nil {
+					logger.Debug("Could not save start time: %v", err)
+				}
+				if opts.TimeLimit.Limit > 0 {
+					if err := audit.SaveTimeLimit(slug, opts.TimeLimit); err != nil {
+						c.report.warn("Could not save the time limit")
+						logger.Debug("Could not save time limit: %v", err)
+					}
+				}
+				c.recordBaseline(ctx, slug, manifestsHash)
+				return nil
+			},
+		},
+	}
+}
+
+// recordBaseline stores the namespace UID and the hash of the applied manifests, so
+// submit can tell when the namespace was recreated outside the CLI. A resumed start
+// that did not deploy keeps the hash recorded by the interrupted one.
+func (c *Client) recordBaseline(ctx context.Context, slug, manifestsHash string) {
+	cluster, err := c.Cluster()
+	if err != nil {
+		logger.Debug("Could not connect to the cluster: %v", err)
+		return
+	}
+	ns, err := cluster.Clientset.CoreV1().Namespaces().Get(ctx, slug, metav1.GetOptions{})
+	if err != nil {
+		logger.Debug("Could not read namespace %s: %v", slug, err)
+		return
+	}
+	if manifestsHash == "" {
+		if previous, ok, err := audit.LoadBaseline(slug); err == nil && ok {
+			manifestsHash = previous.ManifestsHash
+		}
+	}
+	if err := audit.SaveBaseline(slug, audit.Baseline{ManifestsHash: manifestsHash, NamespaceUID: string(ns.UID)}); err != nil {
+		logger.Debug("Could not save baseline: %v", err)
+	}
+}
+
+// CheckMinRequiredVersion loads challenge.yaml for the given slug and verifies
+// cliVersion meets its minRequiredVersion constraint. It is a no-op when the field is
+// absent or cliVersion is a pre-release build.
+func CheckMinRequiredVersion(ctx context.Context, slug, cliVersion string) error {
+	spec, err := validation.LoadChallengeYamlForChallenge(ctx, slug)
+	if err != nil {
+		// Non-fatal: if challenge.yaml is unavailable we cannot block the user.
+		logger.Debug("Could not load challenge.yaml for version check: %v", err)
+		return nil
+	}
+
+	required := spec.MinRequiredVersion
+	if required == "" {
+		return nil
+	}
+
+	if semver.IsPreRelease(cliVersion) {
+		logger.Debug("Pre-release CLI build (%s), skipping minRequiredVersion check", cliVersion)
+		return nil
+	}
+
+	if semver.Compare(semver.Normalize(cliVersion), semver.Normalize(required)) < 0 {
+		return fmt.Errorf(
+			"this challenge requires kubeasy-cli >= %s (you have %s)\nPlease update: %s/kubeasy-cli/releases/latest",
+			required, cliVersion, constants.GithubRootURL,
+		)
+	}
+	return nil
+}
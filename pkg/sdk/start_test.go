@@ -0,0 +1,230 @@
+package sdk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTempChallengeYaml creates a challenge.yaml for slug under a temp directory and
+// sets KUBEASY_LOCAL_CHALLENGES_DIR so the loader finds it.
+func writeTempChallengeYaml(t *testing.T, slug, content string) {
+	t.Helper()
+	dir := t.TempDir()
+	challengeDir := filepath.Join(dir, slug)
+	require.NoError(t, os.MkdirAll(challengeDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(challengeDir, "challenge.yaml"), []byte(content), 0o600))
+	t.Setenv("KUBEASY_LOCAL_CHALLENGES_DIR", dir)
+}
+
+// TestCheckMinRequiredVersion covers the four branches of the version gate.
+func TestCheckMinRequiredVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		cliVersion  string
+		yamlContent string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "no minRequiredVersion field — always passes",
+			cliVersion: "2.0.0",
+			yamlContent: `title: "Test"
+type: fix
+theme: networking
+difficulty: easy
+estimatedTime: 30
+initialSituation: "test"
+description: "test"
+objective: "test"
+objectives: []
+`,
+			wantErr: false,
+		},
+		{
+			name:       "pre-release CLI build — skips check",
+			cliVersion: "dev",
+			yamlContent: `title: "Test"
+minRequiredVersion: "99.0.0"
+objectives: []
+`,
+			wantErr: false,
+		},
+		{
+			name:       "CLI version >= required — passes",
+			cliVersion: "2.1.0",
+			yamlContent: `title: "Test"
+minRequiredVersion: "2.0.0"
+objectives: []
+`,
+			wantErr: false,
+		},
+		{
+			name:       "CLI version == required — passes",
+			cliVersion: "2.0.0",
+			yamlContent: `title: "Test"
+minRequiredVersion: "2.0.0"
+objectives: []
+`,
+			wantErr: false,
+		},
+		{
+			name:       "CLI version < required — blocked",
+			cliVersion: "1.9.0",
+			yamlContent: `title: "Test"
+minRequiredVersion: "2.0.0"
+objectives: []
+`,
+			wantErr:     true,
+			errContains: "requires kubeasy-cli >= 2.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writeTempChallengeYaml(t, "test-challenge", tc.yamlContent)
+
+			err := CheckMinRequiredVersion(context.Background(), "test-challenge", tc.cliVersion)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	started := "2026-01-02T03:04:05Z"
+	client := newTestClient(t, API{
+		GetChallenge: func(ctx context.Context, slug string) (*Challenge, error) {
+			return &Challenge{Title: "Pod Evicted"}, nil
+		},
+		GetProgress: func(ctx context.Context, slug string) (*Progress, error) {
+			return &Progress{Status: "in_progress", StartedAt: &started}, nil
+		},
+		StartChallenge: func(ctx context.Context, slug string) (*StartResponse, error) {
+			t.Fatal("the start endpoint must not be called again")
+			return nil, nil
+		},
+	})
+	writeTempChallengeYaml(t, "pod-evicted", "title: \"Test\"\nobjectives: []\n")
+
+	res, err := client.Start(context.Background(), "pod-evicted", StartOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, StartAlreadyStarted, res.Mode, "the namespace exists and no start was interrupted")
+
+	// A start interrupted after registration only finishes the local bookkeeping
+	markStartStep("pod-evicted", StartStepRegister)
+	res, err = client.Start(context.Background(), "pod-evicted", StartOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, StartResumeFinish, res.Mode)
+
+	s, err := state.Load()
+	require.NoError(t, err)
+	assert.Empty(t, s.Challenges["pod-evicted"].StartStep, "a completed start clears its step")
+	ts, err := audit.LoadStartTime("pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, started, ts.UTC().Format(time.RFC3339), "the API start time is kept")
+	baseline, ok, err := audit.LoadBaseline("pod-evicted")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "uid-1", baseline.NamespaceUID)
+}
+
+func TestStart_AlreadyCompleted(t *testing.T) {
+	client := newTestClient(t, progressAPI(&Progress{Status: "completed"}))
+
+	res, err := client.Start(context.Background(), "pod-evicted", StartOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, StartAlreadyDone, res.Mode)
+}
+
+func TestResolveStartMode(t *testing.T) {
+	tests := []struct {
+		name            string
+		namespaceExists bool
+		interruptedStep string
+		want            StartMode
+	}{
+		{name: "running challenge", namespaceExists: true, want: StartAlreadyStarted},
+		{name: "namespace deleted", namespaceExists: false, want: StartResumeDeploy},
+		{name: "interrupted during deployment", namespaceExists: true, interruptedStep: StartStepDeploy, want: StartResumeDeploy},
+		{name: "interrupted during registration", namespaceExists: true, interruptedStep: StartStepRegister, want: StartResumeFinish},
+		{name: "interrupted during registration, namespace gone", namespaceExists: false, interruptedStep: StartStepRegister, want: StartResumeDeploy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveStartMode(tt.namespaceExists, tt.interruptedStep))
+		})
+	}
+}
+
+// TestStartPlan_SkipsWhatIsDone verifies which steps each start mode runs.
+func TestStartPlan_SkipsWhatIsDone(t *testing.T) {
+	tests := []struct {
+		mode StartMode
+		runs []string
+	}{
+		{mode: StartFresh, runs: []string{StartStepNamespace, StartStepDeploy, StartStepContext, StartStepRegister, StartStepRecord}},
+		{mode: StartResumeDeploy, runs: []string{StartStepNamespace, StartStepDeploy, StartStepContext, StartStepRecord}},
+		{mode: StartResumeFinish, runs: []string{StartStepRecord}},
+	}
+	client := New(Config{})
+	for _, tt := range tests {
+		var runs []string
+		for _, step := range client.StartPlan("pod-evicted", tt.mode, nil, StartOptions{}) {
+			if step.Done != nil {
+				done, err := step.Done(context.Background())
+				require.NoError(t, err)
+				if done {
+					continue
+				}
+			}
+			runs = append(runs, step.Name)
+		}
+		assert.Equal(t, tt.runs, runs, "mode %d", tt.mode)
+	}
+}
+
+func TestStartPlan_NetworkIsolationAction(t *testing.T) {
+	plan := New(Config{}).StartPlan("pod-evicted", StartFresh, nil, StartOptions{NetworkIsolation: kube.NetworkIsolationStrict})
+	require.Equal(t, StartStepNamespace, plan[0].Name)
+	assert.Equal(t, []string{"Create namespace 'pod-evicted'", "Create the validation ServiceAccount 'kubeasy-validator'", "Install the 'strict' NetworkPolicy baseline"}, plan[0].Actions)
+}
+
+// TestStartPlan_RollbackKeepsUnownedNamespace verifies a failed start never deletes a
+// namespace it did not create.
+func TestStartPlan_RollbackKeepsUnownedNamespace(t *testing.T) {
+	plan := New(Config{}).StartPlan("pod-evicted", StartResumeDeploy, nil, StartOptions{})
+	require.Equal(t, StartStepNamespace, plan[0].Name)
+	require.NotNil(t, plan[0].Rollback)
+
+	// The namespace step has not run, so it created nothing and holds no client.
+	assert.NoError(t, plan[0].Rollback(context.Background()))
+}
+
+func TestRecordBaseline(t *testing.T) {
+	client := newTestClient(t, API{})
+
+	client.recordBaseline(context.Background(), "pod-evicted", "hash-1")
+	baseline, ok, err := audit.LoadBaseline("pod-evicted")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, audit.Baseline{ManifestsHash: "hash-1", NamespaceUID: "uid-1"}, baseline)
+
+	// A resumed start that did not deploy keeps the recorded hash
+	client.recordBaseline(context.Background(), "pod-evicted", "")
+	baseline, _, err = audit.LoadBaseline("pod-evicted")
+	require.NoError(t, err)
+	assert.Equal(t, audit.Baseline{ManifestsHash: "hash-1", NamespaceUID: "uid-1"}, baseline)
+}
@@ -0,0 +1,312 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/telemetry"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Reasons CheckSubmittable refuses a submission.
+var (
+	ErrNotStarted       = kerrors.New(kerrors.CodeChallengeNotStarted, "challenge not started")
+	ErrAlreadyCompleted = kerrors.New(kerrors.CodeChallengeCompleted, "challenge already completed")
+	ErrNoAttemptsLeft   = kerrors.New(kerrors.CodeNoAttemptsLeft, "no submission attempts left")
+)
+
+// CooldownError is returned by CheckSubmitLimits while submissions are on cooldown.
+type CooldownError struct {
+	Until time.Time
+	// Wait is the time left at the check.
+	Wait time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("submission cooldown: next attempt in %s", e.Wait.Round(time.Second))
+}
+
+// ErrorCode implements kerrors.Coder.
+func (e *CooldownError) ErrorCode() kerrors.Code { return kerrors.CodeSubmissionCooldown }
+
+// CheckSubmittable fetches a challenge and its progress, and returns an error when
+// the API would reject a submission: ErrNotStarted, ErrAlreadyCompleted or an
+// error of CheckSubmitLimits.
+func (c *Client) CheckSubmittable(ctx context.Context, slug string) (*Challenge, *Progress, error) {
+	var challenge *Challenge
+	err := c.report.task("Verifying challenge", func() error {
+		var err error
+		challenge, err = c.api.GetChallenge(ctx, slug)
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch challenge: %w", err)
+	}
+
+	var progress *Progress
+	err = c.report.task("Checking progress", func() error {
+		var err error
+		progress, err = c.api.GetProgress(ctx, slug)
+		return err
+	})
+	if err != nil {
+		return challenge, nil, fmt.Errorf("failed to fetch challenge progress: %w", err)
+	}
+	if progress == nil {
+		return challenge, nil, ErrNotStarted
+	}
+	if progress.Status == "completed" {
+		return challenge, progress, ErrAlreadyCompleted
+	}
+	return challenge, progress, CheckSubmitLimits(progress, time.Now())
+}
+
+// CheckSubmitLimits returns a *CooldownError or ErrNoAttemptsLeft when progress
+// reports that a submission made at now would be rejected.
+func CheckSubmitLimits(progress *Progress, now time.Time) error {
+	if until, ok := parseAPITime(progress.CooldownUntil); ok && until.After(now) {
+		return &CooldownError{Until: until, Wait: until.Sub(now)}
+	}
+	if progress.AttemptsRemaining != nil && *progress.AttemptsRemaining <= 0 {
+		return ErrNoAttemptsLeft
+	}
+	return nil
+}
+
+// StartedAt returns when a challenge was started, preferring the time recorded by
+// Start over the one reported by the API.
+func StartedAt(slug string, progress *Progress) (time.Time, bool) {
+	if ts, err := audit.LoadStartTime(slug); err == nil {
+		return ts, true
+	}
+	if progress != nil {
+		return parseAPITime(progress.StartedAt)
+	}
+	return time.Time{}, false
+}
+
+func parseAPITime(s *string) (time.Time, bool) {
+	if s == nil || *s == "" {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		logger.Debug("Could not parse API time %q: %v", *s, err)
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
+// SubmitOptions configure Submit.
+type SubmitOptions struct {
+	// StartedAt is when the attempt started; it sets the duration of the submission.
+	// Zero leaves the duration unset.
+	StartedAt time.Time
+}
+
+// Submission is the outcome of a submission that reached the API.
+type Submission struct {
+	Response *SubmitResponse
+	// Passed reports whether the submitted verification passed locally.
+	Passed bool
+	// Submissions is the number of submissions made so far, 0 when unknown.
+	Submissions int
+	// EnvironmentRecreated reports that the namespace was recreated outside the CLI
+	// since the challenge was started.
+	EnvironmentRecreated bool
+}
+
+// Completed reports whether the submission completed the challenge.
+func (s *Submission) Completed() bool {
+	return s.Passed && s.Response != nil && s.Response.Success
+}
+
+// Submit sends a verification to the API, with the audit events recorded since the
+// last submission. A response the API rejects is returned as an error.
+func (c *Client) Submit(ctx context.Context, v *Verification, opts SubmitOptions) (*Submission, error) {
+	cluster, err := c.Cluster()
+	if err != nil {
+		return nil, err
+	}
+
+	req := SubmitRequest{
+		Results: apiResults(v.Results),
+		Variant: optional(v.Config.Variant),
+	}
+	if events := auditEventsSinceLastSubmit(v.Slug); len(events) > 0 {
+		req.AuditEvents = &events
+	}
+	if violations := v.Violations(); len(violations) > 0 {
+		req.ForbiddenActions = &violations
+	}
+	var recreated bool
+	if baseline, ok := loadBaseline(v.Slug); ok {
+		recreated = environmentRecreated(ctx, cluster.Clientset, v.Slug, baseline)
+		req.ManifestsHash = optional(baseline.ManifestsHash)
+		req.EnvironmentRecreated = optional(recreated)
+	}
+	if !opts.StartedAt.IsZero() {
+		duration := int(time.Since(opts.StartedAt).Seconds())
+		req.DurationSeconds = &duration
+	}
+	if telemetry.Enabled() {
+		env := telemetry.Environment(ctx, cluster.Clientset)
+		req.Environment = &env
+	}
+	res, err := c.api.SubmitChallenge(ctx, v.Slug, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit results: %w", err)
+	}
+
+	// Advance the audit window unconditionally (even on 422 / partial failure).
+	// This is deliberate: re-sending events from a failed window on retry would
+	// cause the backend to receive duplicates. Each submit window is self-contained.
+	if saveErr := audit.SaveTimestamp(v.Slug); saveErr != nil {
+		logger.Debug("Could not save audit timestamp: %v", saveErr)
+	}
+	s := &Submission{
+		Response:             res,
+		Passed:               v.Passed(),
+		Submissions:          recordSubmission(v.Slug, time.Now()),
+		EnvironmentRecreated: recreated,
+	}
+	if s.Passed && !res.Success {
+		if res.Message != nil {
+			return s, fmt.Errorf("submission failed: %s", *res.Message)
+		}
+		return s, fmt.Errorf("submission failed")
+	}
+	return s, nil
+}
+
+// auditEventsSinceLastSubmit returns the audit events recorded since the challenge
+// was started or last submitted. If no timestamp is available (e.g. challenge started
+// before audit support), none are returned to avoid leaking historical events.
+func auditEventsSinceLastSubmit(slug string) []api.SubmitAuditEvent {
+	ts, err := audit.LoadTimestamp(slug)
+	if err != nil {
+		logger.Debug("No audit timestamp for %s, skipping audit collection: %v", slug, err)
+		return nil
+	}
+	rawAuditEvents, err := audit.ReadAndFilter(audit.GetAuditLogPath(), slug, ts)
+	if err != nil {
+		logger.Debug("Could not read audit log: %v", err)
+	}
+	// Convert audit.AuditEvent → api.SubmitAuditEvent to keep the api package
+	// free of dependencies on internal implementation packages.
+	events := make([]api.SubmitAuditEvent, len(rawAuditEvents))
+	for i, e := range rawAuditEvents {
+		events[i] = api.SubmitAuditEvent{
+			Timestamp:    e.Timestamp,
+			Verb:         e.Verb,
+			Resource:     e.Resource,
+			Subresource:  optional(e.Subresource),
+			Name:         optional(e.Name),
+			Namespace:    optional(e.Namespace),
+			UserAgent:    optional(e.UserAgent),
+			ResponseCode: optional(e.ResponseCode),
+		}
+	}
+	return events
+}
+
+// loadBaseline returns the environment recorded when the challenge was started, or
+// ok=false when none was.
+func loadBaseline(slug string) (audit.Baseline, bool) {
+	baseline, ok, err := audit.LoadBaseline(slug)
+	if err != nil {
+		logger.Debug("Could not load baseline for %s: %v", slug, err)
+		return audit.Baseline{}, false
+	}
+	return baseline, ok
+}
+
+// environmentRecreated reports whether the challenge namespace was deleted and created
+// again since the start recorded in baseline, which means the challenge was reset
+// without the CLI.
+func environmentRecreated(ctx context.Context, clientset kubernetes.Interface, slug string, baseline audit.Baseline) bool {
+	if baseline.NamespaceUID == "" {
+		return false
+	}
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, slug, metav1.GetOptions{})
+	if err != nil {
+		logger.Debug("Could not read namespace %s: %v", slug, err)
+		return false
+	}
+	return string(ns.UID) != baseline.NamespaceUID
+}
+
+// apiResults converts validation results for the submission.
+func apiResults(results []Result) []api.ObjectiveResult {
+	out := make([]api.ObjectiveResult, len(results))
+	for i, r := range results {
+		msg := r.Message
+		out[i] = api.ObjectiveResult{
+			ObjectiveKey: r.Key,
+			Passed:       r.Passed,
+			Message:      &msg,
+			Comparisons:  apiComparisons(r.Comparisons),
+		}
+	}
+	return out
+}
+
+// apiComparisons converts the failed comparisons of a result for the submission.
+func apiComparisons(comparisons []validation.Comparison) *[]api.ObjectiveComparison {
+	if len(comparisons) == 0 {
+		return nil
+	}
+	out := make([]api.ObjectiveComparison, len(comparisons))
+	for i, c := range comparisons {
+		out[i] = api.ObjectiveComparison{
+			Field:    c.Field,
+			Operator: c.Operator,
+		}
+		if c.Expected != nil {
+			out[i].Expected = &c.Expected
+		}
+		if c.Observed != nil {
+			out[i].Observed = &c.Observed
+		}
+		if c.ObjectRef != nil {
+			out[i].ObjectRef = &api.ObjectRef{Kind: c.ObjectRef.Kind, Namespace: optional(c.ObjectRef.Namespace), Name: c.ObjectRef.Name}
+		}
+	}
+	return &out
+}
+
+// optional returns a pointer to v, or nil for the zero value, which the API reads as
+// an absent optional field.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// recordSubmission counts a submission sent to the API in the local state store and
+// returns the number of submissions made so far, 0 when it could not be recorded.
+func recordSubmission(slug string, now time.Time) int {
+	var count int
+	err := state.Update(func(s *state.State) error {
+		c := s.Challenge(slug)
+		c.Submissions++
+		c.LastSubmittedAt = &now
+		count = c.Submissions
+		return nil
+	})
+	if err != nil {
+		logger.Debug("Could not record submission for %s: %v", slug, err)
+		return 0
+	}
+	return count
+}
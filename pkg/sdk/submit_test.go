@@ -0,0 +1,162 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/state"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func progressAPI(progress *Progress) API {
+	return API{
+		GetChallenge: func(ctx context.Context, slug string) (*Challenge, error) {
+			return &Challenge{Title: "Pod Evicted"}, nil
+		},
+		GetProgress: func(ctx context.Context, slug string) (*Progress, error) { return progress, nil },
+	}
+}
+
+func TestCheckSubmittable(t *testing.T) {
+	attempts := 0
+	tests := []struct {
+		name     string
+		progress *Progress
+		want     error
+	}{
+		{name: "not started", progress: nil, want: ErrNotStarted},
+		{name: "completed", progress: &Progress{Status: "completed"}, want: ErrAlreadyCompleted},
+		{name: "no attempts left", progress: &Progress{Status: "in_progress", AttemptsRemaining: &attempts}, want: ErrNoAttemptsLeft},
+		{name: "in progress", progress: &Progress{Status: "in_progress"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge, _, err := newTestClient(t, progressAPI(tt.progress)).CheckSubmittable(context.Background(), "pod-evicted")
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Pod Evicted", challenge.Title)
+		})
+	}
+}
+
+func TestCheckSubmitLimits(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	attempts := func(n int) *int { return &n }
+	at := func(ts time.Time) *string { s := ts.Format(time.RFC3339); return &s }
+
+	assert.NoError(t, CheckSubmitLimits(&Progress{}, now), "no limits reported")
+	assert.NoError(t, CheckSubmitLimits(&Progress{AttemptsRemaining: attempts(1)}, now))
+	assert.NoError(t, CheckSubmitLimits(&Progress{CooldownUntil: at(now.Add(-time.Second))}, now), "cooldown over")
+	assert.ErrorIs(t, CheckSubmitLimits(&Progress{AttemptsRemaining: attempts(0)}, now), ErrNoAttemptsLeft)
+
+	var cooldown *CooldownError
+	err := CheckSubmitLimits(&Progress{AttemptsRemaining: attempts(3), CooldownUntil: at(now.Add(90 * time.Second))}, now)
+	require.ErrorAs(t, err, &cooldown)
+	assert.Equal(t, 90*time.Second, cooldown.Wait)
+	assert.Contains(t, err.Error(), "next attempt in 1m30s")
+}
+
+func TestSubmit(t *testing.T) {
+	var sent SubmitRequest
+	client := newTestClient(t, API{
+		SubmitChallenge: func(ctx context.Context, slug string, req SubmitRequest) (*SubmitResponse, error) {
+			sent = req
+			return &SubmitResponse{Success: true}, nil
+		},
+	})
+	require.NoError(t, audit.SaveBaseline("pod-evicted", audit.Baseline{ManifestsHash: "hash-1", NamespaceUID: "uid-0"}))
+
+	v := &Verification{
+		Slug:    "pod-evicted",
+		Config:  &ValidationConfig{Variant: "b"},
+		Results: []Result{{Key: "pod-ready", Passed: true, Message: "ok"}},
+	}
+	s, err := client.Submit(context.Background(), v, SubmitOptions{StartedAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	assert.True(t, s.Completed())
+	assert.Equal(t, 1, s.Submissions)
+	assert.True(t, s.EnvironmentRecreated, "the namespace UID changed since start")
+
+	require.NotNil(t, sent.EnvironmentRecreated)
+	assert.True(t, *sent.EnvironmentRecreated)
+	require.NotNil(t, sent.ManifestsHash)
+	assert.Equal(t, "hash-1", *sent.ManifestsHash, "the backend learns which manifests were deployed")
+	require.NotNil(t, sent.Variant)
+	assert.Equal(t, "b", *sent.Variant)
+	require.Len(t, sent.Results, 1)
+	assert.Equal(t, "pod-ready", sent.Results[0].ObjectiveKey)
+	require.NotNil(t, sent.DurationSeconds)
+	assert.GreaterOrEqual(t, *sent.DurationSeconds, 60)
+
+	st, err := state.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 1, st.Challenges["pod-evicted"].Submissions)
+}
+
+func TestSubmit_RejectedPassingResults(t *testing.T) {
+	msg := "challenge closed"
+	client := newTestClient(t, API{
+		SubmitChallenge: func(ctx context.Context, slug string, req SubmitRequest) (*SubmitResponse, error) {
+			return &SubmitResponse{Message: &msg}, nil
+		},
+	})
+	v := &Verification{Slug: "pod-evicted", Config: &ValidationConfig{}, Results: []Result{{Key: "pod-ready", Passed: true}}}
+
+	s, err := client.Submit(context.Background(), v, SubmitOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), msg)
+	require.NotNil(t, s, "the submission reached the API")
+	assert.False(t, s.Completed())
+}
+
+func TestEnvironmentRecreated(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	clientset := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pod-evicted", UID: "uid-2"}})
+
+	_, ok := loadBaseline("pod-evicted")
+	assert.False(t, ok, "no baseline recorded")
+	assert.False(t, environmentRecreated(context.Background(), clientset, "pod-evicted", audit.Baseline{}), "baseline without a namespace UID")
+
+	assert.False(t, environmentRecreated(context.Background(), clientset, "pod-evicted", audit.Baseline{NamespaceUID: "uid-2"}))
+	assert.True(t, environmentRecreated(context.Background(), clientset, "pod-evicted", audit.Baseline{NamespaceUID: "uid-1"}))
+	assert.False(t, environmentRecreated(context.Background(), fake.NewClientset(), "pod-evicted", audit.Baseline{NamespaceUID: "uid-1"}), "namespace missing")
+}
+
+func TestAPIComparisons(t *testing.T) {
+	assert.Nil(t, apiComparisons(nil))
+
+	got := apiComparisons([]validation.Comparison{
+		{Field: "readyReplicas", Operator: ">=", Expected: int64(3), Observed: int64(1), ObjectRef: &validation.ObjectRef{Kind: "Deployment", Namespace: "demo", Name: "web"}},
+		{Field: "Ready", Operator: "==", Expected: "True"},
+	})
+	value := func(v interface{}) *interface{} { return &v }
+	namespace := "demo"
+	assert.Equal(t, &[]api.ObjectiveComparison{
+		{Field: "readyReplicas", Operator: ">=", Expected: value(int64(3)), Observed: value(int64(1)), ObjectRef: &api.ObjectRef{Kind: "Deployment", Namespace: &namespace, Name: "web"}},
+		{Field: "Ready", Operator: "==", Expected: value("True")},
+	}, got)
+}
+
+func TestRecordSubmission_CountsSubmissions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, 1, recordSubmission("pod-evicted", now))
+	assert.Equal(t, 2, recordSubmission("pod-evicted", now))
+
+	s, err := state.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Challenges["pod-evicted"].Submissions)
+	assert.True(t, now.Equal(*s.Challenges["pod-evicted"].LastSubmittedAt))
+}
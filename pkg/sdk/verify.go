@@ -0,0 +1,170 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/api"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	kerrors "github.com/kubeasy-dev/kubeasy-cli/internal/errors"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/logger"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/validation/shared"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrNoValidations is returned by Verify for a challenge without validations.
+var ErrNoValidations = kerrors.New(kerrors.CodeNoValidations, "no validations found for this challenge")
+
+// VerifyOptions configure Verify.
+type VerifyOptions struct {
+	// Since is when the attempt started: forbidden actions are checked from then on.
+	// Zero checks the whole audit log.
+	Since time.Time
+	// OnLoaded, when set, is called with the validations once loaded, before they run.
+	OnLoaded func(*ValidationConfig)
+	// OnProgress, when set, receives the progress of each validation.
+	OnProgress func(ProgressEvent)
+}
+
+// Verification is the outcome of running the validations of a challenge.
+type Verification struct {
+	Slug   string
+	Config *ValidationConfig
+	// Results are in the order of Config.Validations.
+	Results []Result
+	// Forbidden holds one result per forbidden action of the challenge.
+	Forbidden []Result
+	Duration  time.Duration
+}
+
+// Passed reports whether every validation passed and no forbidden action was taken.
+func (v *Verification) Passed() bool {
+	for _, r := range v.Results {
+		if !r.Passed {
+			return false
+		}
+	}
+	return len(v.Violations()) == 0
+}
+
+// Violations returns the forbidden actions that were taken.
+func (v *Verification) Violations() []api.ForbiddenActionViolation {
+	var violations []api.ForbiddenActionViolation
+	for _, r := range v.Forbidden {
+		if !r.Passed {
+			violations = append(violations, api.ForbiddenActionViolation{Key: r.Key, Message: r.Message})
+		}
+	}
+	return violations
+}
+
+// Failing names what did not pass: the title (or key) of each failed validation, then
+// each forbidden action taken.
+func (v *Verification) Failing() []string {
+	var failing []string
+	for i, r := range v.Results {
+		if r.Passed {
+			continue
+		}
+		name := r.Key
+		if i < len(v.Config.Validations) && v.Config.Validations[i].Title != "" {
+			name = v.Config.Validations[i].Title
+		}
+		failing = append(failing, name)
+	}
+	for _, violation := range v.Violations() {
+		failing = append(failing, "forbidden action: "+violation.Key)
+	}
+	return failing
+}
+
+// Summary counts the results by outcome and type.
+func (v *Verification) Summary() validation.Summary {
+	return validation.Summarize(v.Config.Validations, v.Results, v.Duration)
+}
+
+// Verify runs the validations and forbidden action checks of a challenge in its
+// namespace. The results are saved locally for 'kubeasy explain'.
+func (c *Client) Verify(ctx context.Context, slug string, opts VerifyOptions) (*Verification, error) {
+	userID, userErr := c.api.UserID(ctx)
+	var config *ValidationConfig
+	err := c.report.task("Loading validations", func() error {
+		var err error
+		if config, err = c.loadValidations(ctx, slug, userID); err != nil {
+			return err
+		}
+		return validation.CheckVariantUser(slug, config, userErr)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validations: %w", err)
+	}
+	if userErr != nil {
+		logger.Debug("Could not fetch the user profile: %v", userErr)
+	}
+	if len(config.Validations) == 0 {
+		return nil, ErrNoValidations
+	}
+
+	cluster, err := c.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	executor := validation.NewExecutor(cluster.Clientset, cluster.DynamicClient, cluster.RestConfig, slug)
+	if err := executor.EnableSandbox(ctx); err != nil {
+		switch {
+		case errors.Is(err, shared.ErrExecUnavailable):
+			// Without a REST config there are no admin credentials to swap
+			logger.Debug("Validations run without a sandbox: %v", err)
+		case apierrors.IsNotFound(err):
+			// Namespaces started before the sandbox have no validator ServiceAccount
+			logger.Debug("Validator sandbox unavailable: %v", err)
+			c.report.warn(fmt.Sprintf("%s has no %s ServiceAccount: validations run with your admin credentials. Reset and start the challenge again to sandbox them", slug, kube.ValidatorServiceAccountName))
+		default:
+			return nil, fmt.Errorf("failed to sandbox the validations: %w", err)
+		}
+	}
+	if opts.OnLoaded != nil {
+		opts.OnLoaded(config)
+	}
+
+	onProgress := opts.OnProgress
+	if onProgress == nil {
+		onProgress = func(ProgressEvent) {}
+	}
+	start := time.Now()
+	results := executor.ExecuteAllWithProgress(ctx, config.Validations, onProgress)
+	v := &Verification{Slug: slug, Config: config, Results: results, Duration: time.Since(start)}
+	saveLastResults(slug, results, time.Now())
+
+	v.Forbidden = checkForbiddenActions(ctx, cluster.Clientset, slug, config.ForbiddenActions, opts.Since)
+	return v, nil
+}
+
+// checkForbiddenActions checks the forbidden actions of a challenge against the audit
+// log and the Events of namespace since the challenge was started.
+func checkForbiddenActions(ctx context.Context, clientset kubernetes.Interface, namespace string, actions []validation.ForbiddenAction, since time.Time) []Result {
+	if len(actions) == 0 {
+		return nil
+	}
+	auditEvents, err := audit.ReadAndFilter(audit.GetAuditLogPath(), namespace, since)
+	if err != nil {
+		logger.Debug("Could not read audit log: %v", err)
+	}
+	return validation.CheckForbiddenActions(ctx, clientset, namespace, actions, auditEvents, since)
+}
+
+// saveLastResults records the results locally so 'kubeasy explain' can show them.
+func saveLastResults(slug string, results []Result, now time.Time) {
+	observed := make([]audit.ObservedResult, len(results))
+	for i, r := range results {
+		observed[i] = audit.ObservedResult{Key: r.Key, Passed: r.Passed, Message: r.Message, ObservedAt: now}
+	}
+	if err := audit.SaveLastResults(slug, observed); err != nil {
+		logger.Debug("Could not save results for %s: %v", slug, err)
+	}
+}
@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeasy-dev/kubeasy-cli/internal/audit"
+	"github.com/kubeasy-dev/kubeasy-cli/internal/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestVerify(t *testing.T) {
+	client := newTestClient(t, API{},
+		Validation{Key: "pod-ready", Title: "Pod Ready", Type: testValidationType, Spec: true},
+		Validation{Key: "logs", Type: testValidationType, Spec: false},
+	)
+
+	var loaded, events int
+	v, err := client.Verify(context.Background(), "pod-evicted", VerifyOptions{
+		OnLoaded:   func(*ValidationConfig) { loaded++ },
+		OnProgress: func(ProgressEvent) { events++ },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+	assert.Equal(t, 4, events, "a start and an end event per validation")
+	require.Len(t, v.Results, 2)
+	assert.True(t, v.Results[0].Passed)
+	assert.False(t, v.Passed())
+	assert.Equal(t, []string{"logs"}, v.Failing())
+	assert.Equal(t, 1, v.Summary().Passed)
+
+	saved, err := audit.LoadLastResults("pod-evicted")
+	require.NoError(t, err)
+	assert.Len(t, saved, 2, "results are saved for 'kubeasy explain'")
+}
+
+func TestVerify_NoValidations(t *testing.T) {
+	_, err := newTestClient(t, API{}).Verify(context.Background(), "pod-evicted", VerifyOptions{})
+	assert.ErrorIs(t, err, ErrNoValidations)
+}
+
+func TestVerify_VariantNeedsUser(t *testing.T) {
+	profileErr := errors.New("not authenticated")
+	client := newTestClient(t, API{})
+	client.api.UserID = func(context.Context) (string, error) { return "", profileErr }
+	var loadedFor string
+	client.loadValidations = func(_ context.Context, _, userID string) (*ValidationConfig, error) {
+		loadedFor = userID
+		return &ValidationConfig{Variant: "default", Validations: []Validation{{Key: "pod-ready", Type: testValidationType, Spec: true}}}, nil
+	}
+
+	_, err := client.Verify(context.Background(), "pod-evicted", VerifyOptions{})
+	require.ErrorIs(t, err, profileErr, "the first variant is not the user's")
+	assert.Empty(t, loadedFor)
+
+	// Without variants, every user gets the same objectives
+	client.loadValidations = func(context.Context, string, string) (*ValidationConfig, error) {
+		return &ValidationConfig{Validations: []Validation{{Key: "pod-ready", Type: testValidationType, Spec: true}}}, nil
+	}
+	v, err := client.Verify(context.Background(), "pod-evicted", VerifyOptions{})
+	require.NoError(t, err)
+	assert.True(t, v.Passed())
+}
+
+func TestVerification_ForbiddenActions(t *testing.T) {
+	v := &Verification{
+		Config:    &ValidationConfig{Validations: []Validation{{Key: "pod-ready"}}},
+		Results:   []Result{{Key: "pod-ready", Passed: true}},
+		Forbidden: []Result{{Key: "no-delete", Message: "pod deleted"}, {Key: "no-scale", Passed: true}},
+	}
+	assert.False(t, v.Passed(), "a forbidden action fails the verification")
+	assert.Equal(t, []string{"forbidden action: no-delete"}, v.Failing())
+	require.Len(t, v.Violations(), 1)
+	assert.Equal(t, "pod deleted", v.Violations()[0].Message)
+}
+
+func TestVerify_Sandbox(t *testing.T) {
+	validations := []Validation{{Key: "pod-ready", Type: testValidationType, Spec: true}}
+	sandboxed := func(t *testing.T, tokenErr error) (*Client, *[]string) {
+		client := newTestClient(t, API{}, validations...)
+		clientset := client.cluster.Clientset.(*fake.Clientset)
+		clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			if action.GetSubresource() != "token" {
+				return false, nil, nil
+			}
+			if tokenErr != nil {
+				return true, nil, tokenErr
+			}
+			return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "validator-token"}}, nil
+		})
+		client.cluster.RestConfig = &rest.Config{Host: "https://127.0.0.1:6443"}
+		var warnings []string
+		client.report.Warn = func(msg string) { warnings = append(warnings, msg) }
+		return client, &warnings
+	}
+
+	t.Run("sandboxed", func(t *testing.T) {
+		client, warnings := sandboxed(t, nil)
+		v, err := client.Verify(context.Background(), "pod-evicted", VerifyOptions{})
+		require.NoError(t, err)
+		assert.True(t, v.Passed())
+		assert.Empty(t, *warnings)
+	})
+
+	t.Run("namespace started before the sandbox", func(t *testing.T) {
+		notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, kube.ValidatorServiceAccountName)
+		client, warnings := sandboxed(t, notFound)
+		v, err := client.Verify(context.Background(), "pod-evicted", VerifyOptions{})
+		require.NoError(t, err, "older namespaces fall back to the admin credentials")
+		assert.True(t, v.Passed())
+		require.Len(t, *warnings, 1)
+		assert.Contains(t, (*warnings)[0], "admin credentials")
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "serviceaccounts"}, kube.ValidatorServiceAccountName, errors.New("denied"))
+		client, _ := sandboxed(t, forbidden)
+		_, err := client.Verify(context.Background(), "pod-evicted", VerifyOptions{})
+		require.Error(t, err, "validations must not silently run as admin")
+		assert.True(t, apierrors.IsForbidden(err))
+	})
+}
@@ -133,7 +133,7 @@ spec:
 		challengeDir, err := devutils.ResolveLocalChallengeDir(devTestSlug, "")
 		require.NoError(t, err)
 
-		err = deployer.DeployLocalChallenge(ctx, clientset, dynamicClient, challengeDir, devTestSlug)
+		_, err = deployer.DeployLocalChallenge(ctx, clientset, dynamicClient, challengeDir, devTestSlug)
 		require.NoError(t, err, "DeployLocalChallenge should succeed")
 
 		// Verify the Deployment was created
@@ -147,7 +147,7 @@ spec:
 		require.NoError(t, err)
 
 		// Load validations from local file
-		config, err := validation.LoadFromFile(filepath.Join(challengeDir, "challenge.yaml"))
+		config, err := validation.LoadFromFile(context.Background(), filepath.Join(challengeDir, "challenge.yaml"))
 		require.NoError(t, err, "should load validations from challenge.yaml")
 		require.NotEmpty(t, config.Validations, "should have at least one validation")
 
@@ -0,0 +1,54 @@
+//go:build kindintegration
+// +build kindintegration
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestE2EHarness runs the hidden 'kubeasy e2e' command on the e2e-harness fixture,
+// reusing the cluster created by TestMain, and checks its report.
+func TestE2EHarness(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Minute)
+	defer cancel()
+
+	fixture, err := filepath.Abs(filepath.Join("testdata", "e2e-harness"))
+	require.NoError(t, err)
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+
+	cmd := exec.CommandContext(ctx, "go", "run", "../..", "e2e", "e2e-harness",
+		"--dir", fixture,
+		"--cluster-name", e2eClusterName,
+		"--report", reportPath,
+		"--no-spinner",
+	)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	runErr := cmd.Run()
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err, "the report is written even when the run fails")
+	var report struct {
+		Passed bool `json:"passed"`
+		Steps  []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"steps"`
+	}
+	require.NoError(t, json.Unmarshal(data, &report))
+	for _, step := range report.Steps {
+		assert.Equal(t, "passed", step.Status, "step %s: %s", step.Name, step.Error)
+	}
+	assert.True(t, report.Passed)
+	assert.NoError(t, runErr)
+}
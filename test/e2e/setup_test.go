@@ -85,13 +85,13 @@ func TestInfrastructureSetup(t *testing.T) {
 	})
 
 	t.Run("not-ready-before-setup", func(t *testing.T) {
-		ready, err := deployer.IsInfrastructureReady()
+		ready, err := deployer.IsInfrastructureReady(context.Background())
 		require.NoError(t, err, "IsInfrastructureReady should not error")
 		assert.False(t, ready, "infrastructure should not be ready on a fresh cluster")
 	})
 
 	t.Run("setup-infrastructure", func(t *testing.T) {
-		err := deployer.SetupInfrastructure()
+		err := deployer.SetupInfrastructure(context.Background())
 		require.NoError(t, err, "SetupInfrastructure should succeed")
 
 		clientset, err := kube.GetKubernetesClient()
@@ -132,16 +132,16 @@ func TestInfrastructureSetup(t *testing.T) {
 	})
 
 	t.Run("ready-after-setup", func(t *testing.T) {
-		ready, err := deployer.IsInfrastructureReady()
+		ready, err := deployer.IsInfrastructureReady(context.Background())
 		require.NoError(t, err, "IsInfrastructureReady should not error")
 		assert.True(t, ready, "infrastructure should be ready after setup")
 	})
 
 	t.Run("setup-idempotency", func(t *testing.T) {
-		err := deployer.SetupInfrastructure()
+		err := deployer.SetupInfrastructure(context.Background())
 		require.NoError(t, err, "SetupInfrastructure should be idempotent")
 
-		ready, err := deployer.IsInfrastructureReady()
+		ready, err := deployer.IsInfrastructureReady(context.Background())
 		require.NoError(t, err)
 		assert.True(t, ready, "infrastructure should still be ready after re-running setup")
 	})
@@ -182,7 +182,7 @@ func TestChallengeLifecycle(t *testing.T) {
 
 	t.Run("validate", func(t *testing.T) {
 		// Load validations for the challenge (from GitHub)
-		config, err := validation.LoadForChallenge(testChallengeSlug)
+		config, err := validation.LoadForChallenge(context.Background(), testChallengeSlug, "")
 		require.NoError(t, err, "should load validations for %s", testChallengeSlug)
 		require.NotEmpty(t, config.Validations, "challenge should have at least one validation")
 
@@ -5,6 +5,7 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -39,7 +40,7 @@ data:
 	require.NoError(t, err)
 
 	// Deploy
-	err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
+	_, err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
 	require.NoError(t, err)
 
 	// Verify the ConfigMap was created
@@ -74,7 +75,7 @@ data:
 	err = os.WriteFile(filepath.Join(policiesDir, "policy.yaml"), []byte(configMapYAML), 0600)
 	require.NoError(t, err)
 
-	err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
+	_, err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
 	require.NoError(t, err)
 
 	cm, err := env.Clientset.CoreV1().ConfigMaps(env.Namespace).Get(ctx, "policy-config", metav1.GetOptions{})
@@ -91,7 +92,7 @@ func TestDeployLocalChallenge_SkipsMissingDirs(t *testing.T) {
 	// Create an empty challenge directory (no manifests/ or policies/)
 	challengeDir := t.TempDir()
 
-	err := deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
+	_, err := deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
 	assert.NoError(t, err, "should succeed even without manifests/ or policies/ dirs")
 }
 
@@ -123,7 +124,7 @@ data:
 	err = os.WriteFile(filepath.Join(manifestsDir, "config.yaml"), []byte(configMapYAML), 0600)
 	require.NoError(t, err)
 
-	err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
+	_, err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
 	require.NoError(t, err)
 
 	// Verify only the YAML file was applied
@@ -163,7 +164,7 @@ data:
 	err = os.WriteFile(filepath.Join(subDir, "cm2.yaml"), []byte(cm2), 0600)
 	require.NoError(t, err)
 
-	err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
+	_, err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
 	require.NoError(t, err)
 
 	// Verify both ConfigMaps exist
@@ -191,6 +192,38 @@ func TestDeployLocalChallenge_InvalidManifest(t *testing.T) {
 	err = os.WriteFile(filepath.Join(manifestsDir, "bad.yaml"), []byte("not: a kubernetes manifest"), 0600)
 	require.NoError(t, err)
 
-	err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
+	_, err = deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
 	assert.NoError(t, err, "invalid documents are skipped, not treated as errors")
 }
+
+// TestApplySolution_OverlaysDeployedChallenge verifies that ApplySolution applies
+// every manifest of the solution directory, including nested ones, on top of the
+// resources deployed from manifests/.
+func TestApplySolution_OverlaysDeployedChallenge(t *testing.T) {
+	env := helpers.SetupEnvTest(t)
+	ctx := context.Background()
+
+	challengeDir := t.TempDir()
+	manifestsDir := filepath.Join(challengeDir, "manifests")
+	solutionDir := filepath.Join(challengeDir, deployer.SolutionDirName, "fix")
+	require.NoError(t, os.MkdirAll(manifestsDir, 0755))
+	require.NoError(t, os.MkdirAll(solutionDir, 0755))
+
+	configMapYAML := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  mode: %s
+`
+	require.NoError(t, os.WriteFile(filepath.Join(manifestsDir, "configmap.yaml"), []byte(fmt.Sprintf(configMapYAML, "broken")), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(solutionDir, "configmap.yaml"), []byte(fmt.Sprintf(configMapYAML, "fixed")), 0600))
+
+	_, err := deployer.DeployLocalChallenge(ctx, env.Clientset, env.DynamicClient, challengeDir, env.Namespace)
+	require.NoError(t, err)
+	require.NoError(t, deployer.ApplySolution(ctx, env.Clientset, env.DynamicClient, filepath.Join(challengeDir, deployer.SolutionDirName), env.Namespace))
+
+	cm, err := env.Clientset.CoreV1().ConfigMaps(env.Namespace).Get(ctx, "app-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "fixed", cm.Data["mode"])
+}